@@ -0,0 +1,62 @@
+// k8m-agent 运行在无法被 k8m 直接访问的集群内（如边缘、私有网络中的集群），
+// 主动向 k8m 建立 WebSocket 隧道，k8m 对该集群的访问经隧道转发，由 agent 使用自身 ServiceAccount 调用本地 apiserver。
+// 隧道不转发需要协议升级的请求，经 agent 接入的集群不支持终端、执行命令、文件管理与节点 Shell。
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/weibaohui/k8m/pkg/tunnel"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	var opts tunnel.AgentOptions
+	var kubeconfig string
+	cmd := &cobra.Command{
+		Use:           "k8m-agent",
+		Short:         "k8m 集群 agent，反向连接 k8m 以管理无法直连的集群",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			restConfig, err := loadRestConfig(kubeconfig)
+			if err != nil {
+				return err
+			}
+			opts.RestConfig = restConfig
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return tunnel.RunAgent(ctx, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Server, "server", "s", os.Getenv("K8M_SERVER"), "k8m 访问地址，如 https://k8m.example.com，可通过环境变量 K8M_SERVER 设置")
+	cmd.Flags().StringVarP(&opts.Token, "token", "t", os.Getenv("K8M_AGENT_TOKEN"), "k8m 管理员为该集群签发的 agent 令牌，可通过环境变量 K8M_AGENT_TOKEN 设置")
+	cmd.Flags().StringVarP(&opts.Name, "name", "n", os.Getenv("K8M_AGENT_NAME"), "集群名称，以令牌绑定的名称为准，填写时须与之一致，可通过环境变量 K8M_AGENT_NAME 设置")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "kubeconfig 路径，为空时使用集群内 ServiceAccount")
+	return cmd
+}
+
+// loadRestConfig 优先使用指定的 kubeconfig，否则使用集群内配置
+func loadRestConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("加载集群内配置失败，集群外运行请指定 --kubeconfig: %w", err)
+	}
+	return restConfig, nil
+}
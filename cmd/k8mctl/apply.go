@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// importResult 导入接口返回的单个资源处理结果
+type importResult struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+}
+
+func newApplyCmd(opts *globalOptions) *cobra.Command {
+	var file string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "apply -f <file>",
+		Short: "应用资源清单，支持 yaml 文件或 zip/tar.gz 压缩包，- 表示从标准输入读取",
+		Example: `  k8mctl apply -f deploy.yaml
+  k8mctl apply -f manifests.tar.gz --dry-run
+  cat deploy.yaml | k8mctl apply -f -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return fmt.Errorf("请使用 -f 指定资源清单文件")
+			}
+			c, err := opts.clusterClient()
+			if err != nil {
+				return err
+			}
+
+			var r io.Reader = os.Stdin
+			name := "stdin.yaml"
+			if file != "-" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+				name = filepath.Base(file)
+			}
+
+			fields := map[string]string{}
+			if dryRun {
+				fields["dry_run"] = "true"
+			}
+			var data struct {
+				Applied bool           `json:"applied"`
+				Valid   bool           `json:"valid"`
+				Results []importResult `json:"results"`
+			}
+			if err := c.postForm(clusterPath(opts.cluster, "/import"), fields, "file", name, r, &data); err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tACTION\tSTATUS\tMESSAGE")
+			for _, it := range data.Results {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", it.Kind, it.Namespace, it.Name, it.Action, it.Status, it.Message)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if !data.Valid {
+				return fmt.Errorf("资源清单校验未通过，未执行应用")
+			}
+			if !dryRun && !data.Applied {
+				return fmt.Errorf("应用失败，已回滚本次创建的资源")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "资源清单文件路径")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "仅在服务端校验，不实际应用")
+	return cmd
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client k8m API 客户端
+type client struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+// apiResponse k8m 接口统一的 amis 响应格式
+type apiResponse struct {
+	Status    int             `json:"status"`
+	Msg       string          `json:"msg"`
+	Data      json.RawMessage `json:"data"`
+	RequestID string          `json:"request_id,omitempty"`
+}
+
+func newClient(server, token string) *client {
+	return &client{
+		server: strings.TrimRight(server, "/"),
+		token:  token,
+		http:   &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// clusterPath 拼接集群范围的接口路径，集群ID 使用 URL 安全的 base64 编码
+func clusterPath(cluster, path string) string {
+	return "/k8s/cluster/" + base64.RawURLEncoding.EncodeToString([]byte(cluster)) + path
+}
+
+func (c *client) newRequest(method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := c.server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	return req, nil
+}
+
+// do 发送请求，HTTP 状态码异常时返回错误，成功时由调用方负责关闭响应体
+func (c *client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("请求被限流，请在 %s 秒后重试: %s", resp.Header.Get("Retry-After"), strings.TrimSpace(string(data)))
+		}
+		return nil, fmt.Errorf("请求失败[%d]: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return resp, nil
+}
+
+// decode 解析 amis 格式的响应，status 非 0 时返回错误，data 反序列化到 out
+func decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	var r apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if r.Status != 0 {
+		if r.RequestID != "" {
+			return fmt.Errorf("%s (request_id: %s)", r.Msg, r.RequestID)
+		}
+		return fmt.Errorf("%s", r.Msg)
+	}
+	if out == nil || len(r.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Data, out)
+}
+
+// getJSON 发送 GET 请求并解析 amis 响应
+func (c *client) getJSON(path string, query url.Values, out any) error {
+	req, err := c.newRequest(http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	return decode(resp, out)
+}
+
+// postForm 以 multipart/form-data 发送字段与文件，并解析 amis 响应
+func (c *client) postForm(path string, fields map[string]string, fileField, fileName string, file io.Reader, out any) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+	if file != nil {
+		part, err := w.CreateFormFile(fileField, fileName)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := c.newRequest(http.MethodPost, path, nil, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	return decode(resp, out)
+}
+
+// stream 发送 GET 请求并返回原始响应；若服务端返回的是 JSON 格式的错误则转换为 error
+func (c *client) stream(path string, query url.Values) (*http.Response, error) {
+	req, err := c.newRequest(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return nil, decode(resp, nil)
+	}
+	return resp, nil
+}
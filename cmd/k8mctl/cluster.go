@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// clusterInfo 集群列表中使用到的字段
+type clusterInfo struct {
+	ClusterID            string `json:"cluster_id"`
+	ClusterName          string `json:"clusterName"`
+	Server               string `json:"server"`
+	ServerVersion        string `json:"serverVersion"`
+	ClusterConnectStatus string `json:"clusterConnectStatus"`
+}
+
+func newClusterCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "集群相关操作",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "列出当前用户有权限访问的集群",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+			var clusters []clusterInfo
+			if err := c.getJSON("/params/cluster/all", nil, &clusters); err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CLUSTER ID\tNAME\tSTATUS\tVERSION\tSERVER")
+			for _, cl := range clusters {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", cl.ClusterID, cl.ClusterName, cl.ClusterConnectStatus, cl.ServerVersion, cl.Server)
+			}
+			return w.Flush()
+		},
+	})
+	return cmd
+}
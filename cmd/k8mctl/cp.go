@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// podPath 解析后的容器内路径，格式为 [namespace/]pod:path
+type podPath struct {
+	Namespace string
+	Pod       string
+	Path      string
+}
+
+// parsePodPath 解析 [namespace/]pod:path 格式的参数，不是容器路径时返回 false
+func parsePodPath(arg, defaultNamespace string) (*podPath, bool) {
+	i := strings.Index(arg, ":")
+	if i <= 0 {
+		return nil, false
+	}
+	// Windows 盘符，如 C:\data
+	if i == 1 && len(arg) > 2 && (arg[2] == '\\' || arg[2] == '/') {
+		return nil, false
+	}
+	p := &podPath{Namespace: defaultNamespace, Pod: arg[:i], Path: arg[i+1:]}
+	if ns, pod, ok := strings.Cut(p.Pod, "/"); ok {
+		p.Namespace, p.Pod = ns, pod
+	}
+	return p, true
+}
+
+func newCpCmd(opts *globalOptions) *cobra.Command {
+	var namespace, container string
+	cmd := &cobra.Command{
+		Use:   "cp <src> <dst>",
+		Short: "在本地与容器之间复制文件",
+		Example: `  # 从容器下载文件
+  k8mctl cp default/nginx-0:/etc/nginx/nginx.conf ./nginx.conf -c nginx
+  # 上传文件到容器目录
+  k8mctl cp ./app.conf nginx-0:/tmp/ -n default -c nginx`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.clusterClient()
+			if err != nil {
+				return err
+			}
+			src, srcIsPod := parsePodPath(args[0], namespace)
+			dst, dstIsPod := parsePodPath(args[1], namespace)
+			switch {
+			case srcIsPod && !dstIsPod:
+				return downloadFromPod(c, opts.cluster, src, container, args[1])
+			case !srcIsPod && dstIsPod:
+				return uploadToPod(c, opts.cluster, args[0], dst, container)
+			default:
+				return fmt.Errorf("源和目标必须一个是本地路径，一个是 [namespace/]pod:path 格式的容器路径")
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Pod 所在命名空间")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "容器名称")
+	return cmd
+}
+
+// downloadFromPod 下载容器内的文件到本地，本地路径为目录时使用原文件名
+func downloadFromPod(c *client, cluster string, src *podPath, container, local string) error {
+	query := url.Values{}
+	query.Set("namespace", src.Namespace)
+	query.Set("podName", src.Pod)
+	query.Set("containerName", container)
+	query.Set("path", src.Path)
+	resp, err := c.stream(clusterPath(cluster, "/file/download"), query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if st, err := os.Stat(local); err == nil && st.IsDir() {
+		local = filepath.Join(local, path.Base(src.Path))
+	}
+	f, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	n, err := io.Copy(f, resp.Body)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "已下载 %s/%s:%s -> %s (%d bytes)\n", src.Namespace, src.Pod, src.Path, local, n)
+	return nil
+}
+
+// uploadToPod 上传本地文件到容器内。目标路径以 / 结尾时视为目录，使用本地文件名
+func uploadToPod(c *client, cluster, local string, dst *podPath, container string) error {
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dir, name := path.Split(dst.Path)
+	if name == "" {
+		name = filepath.Base(local)
+	}
+	if dir == "" {
+		dir = "/"
+	}
+
+	var result struct {
+		File struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"file"`
+	}
+	fields := map[string]string{
+		"namespace":     dst.Namespace,
+		"podName":       dst.Pod,
+		"containerName": container,
+		"path":          dir,
+		"fileName":      name,
+	}
+	if err := c.postForm(clusterPath(cluster, "/file/upload"), fields, "file", name, f, &result); err != nil {
+		return err
+	}
+	if result.File.Status != "done" {
+		return fmt.Errorf("上传失败: %s", result.File.Error)
+	}
+	fmt.Fprintf(os.Stderr, "已上传 %s -> %s/%s:%s\n", local, dst.Namespace, dst.Pod, path.Join(dir, name))
+	return nil
+}
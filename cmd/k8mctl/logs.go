@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newLogsCmd(opts *globalOptions) *cobra.Command {
+	var (
+		namespace, container string
+		follow, previous     bool
+		timestamps           bool
+		tail                 int64
+		sinceSeconds         int64
+	)
+	cmd := &cobra.Command{
+		Use:   "logs <pod>",
+		Short: "查看容器日志",
+		Example: `  k8mctl logs nginx-0 -n default -c nginx --tail 100
+  k8mctl logs nginx-0 -n default -c nginx -f`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.clusterClient()
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			if tail >= 0 {
+				query.Set("tailLines", strconv.FormatInt(tail, 10))
+			}
+			if sinceSeconds > 0 {
+				query.Set("sinceSeconds", strconv.FormatInt(sinceSeconds, 10))
+			}
+			if previous {
+				query.Set("previous", "true")
+			}
+			if timestamps {
+				query.Set("timestamps", "true")
+			}
+
+			pod := url.PathEscape(args[0])
+			ns := url.PathEscape(namespace)
+			ctr := url.PathEscape(container)
+			if !follow {
+				p := fmt.Sprintf("/pod/logs/download/ns/%s/pod_name/%s/container/%s", ns, pod, ctr)
+				resp, err := c.stream(clusterPath(opts.cluster, p), query)
+				if err != nil {
+					return err
+				}
+				defer resp.Body.Close()
+				_, err = io.Copy(os.Stdout, resp.Body)
+				return err
+			}
+
+			query.Set("follow", "true")
+			p := fmt.Sprintf("/pod/logs/sse/ns/%s/pod_name/%s/container/%s", ns, pod, ctr)
+			resp, err := c.stream(clusterPath(opts.cluster, p), query)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			return printSSE(resp.Body, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Pod 所在命名空间")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "容器名称")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "持续输出日志")
+	cmd.Flags().BoolVarP(&previous, "previous", "p", false, "查看上一次运行的容器日志")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "显示时间戳")
+	cmd.Flags().Int64Var(&tail, "tail", -1, "仅显示最后 N 行，-1 为全部")
+	cmd.Flags().Int64Var(&sinceSeconds, "since-seconds", 0, "仅显示最近 N 秒的日志")
+	return cmd
+}
+
+// printSSE 输出 SSE 流中 message 事件的数据，忽略心跳；收到 error 事件时返回错误
+func printSSE(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data := strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
+			switch event {
+			case "error":
+				return fmt.Errorf("%s", data)
+			case "heartbeat":
+			default:
+				fmt.Fprintln(w, strings.TrimRight(data, "\n"))
+			}
+		case line == "":
+			event = ""
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,65 @@
+// k8mctl 是 k8m 的命令行工具，通过 k8m 的 API 访问集群。
+// 所有操作使用个人中心申请的 API 密钥认证，与页面操作一样经过 k8m 的权限校验与操作审计，
+// 自动化脚本无需再持有原始 kubeconfig。
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// globalOptions 所有子命令共用的参数
+type globalOptions struct {
+	server  string // k8m 访问地址
+	token   string // API 密钥
+	cluster string // 目标集群ID
+}
+
+func newRootCmd() *cobra.Command {
+	opts := &globalOptions{}
+	root := &cobra.Command{
+		Use:           "k8mctl",
+		Short:         "k8m 命令行工具，通过 k8m API 访问集群",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.PersistentFlags().StringVarP(&opts.server, "server", "s", os.Getenv("K8M_SERVER"), "k8m 访问地址，如 http://127.0.0.1:3618，可通过环境变量 K8M_SERVER 设置")
+	root.PersistentFlags().StringVarP(&opts.token, "token", "t", os.Getenv("K8M_TOKEN"), "API 密钥，在个人中心-API密钥菜单下申请，可通过环境变量 K8M_TOKEN 设置")
+	root.PersistentFlags().StringVar(&opts.cluster, "cluster", os.Getenv("K8M_CLUSTER"), "目标集群ID，可通过环境变量 K8M_CLUSTER 设置")
+
+	root.AddCommand(
+		newClusterCmd(opts),
+		newCpCmd(opts),
+		newLogsCmd(opts),
+		newApplyCmd(opts),
+	)
+	return root
+}
+
+// client 根据全局参数创建 API 客户端
+func (o *globalOptions) client() (*client, error) {
+	if o.server == "" {
+		return nil, fmt.Errorf("未指定 k8m 访问地址，请使用 --server 或环境变量 K8M_SERVER")
+	}
+	if o.token == "" {
+		return nil, fmt.Errorf("未指定 API 密钥，请使用 --token 或环境变量 K8M_TOKEN")
+	}
+	return newClient(o.server, o.token), nil
+}
+
+// clusterClient 创建 API 客户端，并要求已指定目标集群
+func (o *globalOptions) clusterClient() (*client, error) {
+	if o.cluster == "" {
+		return nil, fmt.Errorf("未指定集群，请使用 --cluster 或环境变量 K8M_CLUSTER，可通过 k8mctl cluster list 查看")
+	}
+	return o.client()
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,13 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// 支持的数据库驱动类型
+const (
+	DriverSqlite   = "sqlite"
+	DriverMysql    = "mysql"
+	DriverPostgres = "postgresql"
+)
+
 // 定义全局变量
 var (
 	once       sync.Once
@@ -40,20 +48,40 @@ func connDB() (*gorm.DB, error) {
 		)
 
 		cfg := flag.Init()
-		if cfg.DBDriver == "sqlite" {
+		switch NormalizeDriver(cfg.DBDriver) {
+		case DriverSqlite:
 			dbInstance, dbErr = openSqliteDB(cfg, customLogger)
-			return
-		} else if cfg.DBDriver == "mysql" {
+		case DriverMysql:
 			dbInstance, dbErr = openMysqlDB(cfg, customLogger)
-			return
-		} else if cfg.DBDriver == "postgresql" {
+		case DriverPostgres:
 			dbInstance, dbErr = openPostgresDB(cfg, customLogger)
-			return
+		default:
+			dbErr = fmt.Errorf("不支持的数据库驱动类型: %s，可选 sqlite、mysql、postgresql", cfg.DBDriver)
 		}
 	})
 	return dbInstance, dbErr
 }
 
+// NormalizeDriver 将数据库驱动名称统一为标准名称，兼容常见别名，如 sqlite3、postgres、pg。
+// 无法识别的名称原样返回（小写）。
+func NormalizeDriver(driver string) string {
+	d := strings.ToLower(strings.TrimSpace(driver))
+	switch d {
+	case "", "sqlite", "sqlite3":
+		return DriverSqlite
+	case "mysql", "mariadb":
+		return DriverMysql
+	case "postgresql", "postgres", "pg", "pgsql":
+		return DriverPostgres
+	}
+	return d
+}
+
+// Driver 返回当前使用的数据库驱动类型（标准名称）
+func Driver() string {
+	return NormalizeDriver(flag.Init().DBDriver)
+}
+
 // openSqliteDB 负责初始化并返回sqlite数据库连接实例。
 func openSqliteDB(cfg *flag.Config, customLogger logger.Interface) (*gorm.DB, error) {
 	if _, err := os.Stat(cfg.SqlitePath); os.IsNotExist(err) {
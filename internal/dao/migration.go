@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"k8s.io/klog/v2"
+)
+
+// SchemaMigration 记录已执行的版本化迁移，避免重复执行
+type SchemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:128" json:"id,omitempty"` // 迁移标识
+	AppliedAt time.Time `json:"applied_at,omitempty"`
+}
+
+// Migration 一次版本化的数据迁移。
+// AutoMigrate 只负责表结构的增量同步，数据修正、字段重命名等一次性操作应通过 Migration 完成。
+type Migration struct {
+	ID      string // 唯一标识，建议使用 日期_描述 的形式，如 20250101_fix_xxx
+	Migrate func(tx *gorm.DB) error
+}
+
+// RunMigrations 按顺序执行尚未执行过的迁移。
+// 每个迁移与其执行记录在同一事务中提交，失败时回滚并停止后续迁移。
+// 多实例同时启动时，迁移记录的主键冲突会导致后启动的实例事务失败，不会重复执行。
+func RunMigrations(migrations []Migration) error {
+	db := DB()
+	if db == nil {
+		return fmt.Errorf("数据库未初始化")
+	}
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("创建迁移记录表失败: %w", err)
+	}
+
+	var applied []SchemaMigration
+	if err := db.Find(&applied).Error; err != nil {
+		return fmt.Errorf("读取迁移记录失败: %w", err)
+	}
+	done := make(map[string]bool, len(applied))
+	for _, m := range applied {
+		done[m.ID] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.ID] {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Migrate(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{ID: m.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("执行迁移[%s]失败: %w", m.ID, err)
+		}
+		klog.V(2).Infof("数据库迁移[%s]执行完成(%s)", m.ID, Driver())
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	chim "github.com/go-chi/chi/v5/middleware"
 	cmiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	_ "github.com/swaggo/http-swagger" // 导入 swagger 文档
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/weibaohui/k8m/pkg/cb"
@@ -19,14 +20,26 @@ import (
 	"github.com/weibaohui/k8m/pkg/controller/admin/cluster"
 	"github.com/weibaohui/k8m/pkg/controller/admin/config"
 	"github.com/weibaohui/k8m/pkg/controller/admin/menu"
+	adminproject "github.com/weibaohui/k8m/pkg/controller/admin/project"
 	"github.com/weibaohui/k8m/pkg/controller/admin/user"
+	"github.com/weibaohui/k8m/pkg/controller/admission"
+	"github.com/weibaohui/k8m/pkg/controller/agent"
+	"github.com/weibaohui/k8m/pkg/controller/approval"
+	"github.com/weibaohui/k8m/pkg/controller/bulk"
+	"github.com/weibaohui/k8m/pkg/controller/changeset"
 	"github.com/weibaohui/k8m/pkg/controller/cluster_status"
 	"github.com/weibaohui/k8m/pkg/controller/cm"
+	"github.com/weibaohui/k8m/pkg/controller/component"
 	"github.com/weibaohui/k8m/pkg/controller/cronjob"
 	"github.com/weibaohui/k8m/pkg/controller/deploy"
 	"github.com/weibaohui/k8m/pkg/controller/doc"
+	"github.com/weibaohui/k8m/pkg/controller/drift"
 	"github.com/weibaohui/k8m/pkg/controller/ds"
 	"github.com/weibaohui/k8m/pkg/controller/dynamic"
+	"github.com/weibaohui/k8m/pkg/controller/graphql"
+	"github.com/weibaohui/k8m/pkg/controller/hibernation"
+	"github.com/weibaohui/k8m/pkg/controller/hook"
+	"github.com/weibaohui/k8m/pkg/controller/image"
 	"github.com/weibaohui/k8m/pkg/controller/ingressclass"
 	"github.com/weibaohui/k8m/pkg/controller/log"
 	"github.com/weibaohui/k8m/pkg/controller/login"
@@ -34,22 +47,34 @@ import (
 	"github.com/weibaohui/k8m/pkg/controller/ns"
 	"github.com/weibaohui/k8m/pkg/controller/param"
 	"github.com/weibaohui/k8m/pkg/controller/pod"
+	"github.com/weibaohui/k8m/pkg/controller/project"
+	"github.com/weibaohui/k8m/pkg/controller/report"
 	"github.com/weibaohui/k8m/pkg/controller/rs"
+	"github.com/weibaohui/k8m/pkg/controller/sa"
 	"github.com/weibaohui/k8m/pkg/controller/sso"
 	"github.com/weibaohui/k8m/pkg/controller/storageclass"
 	"github.com/weibaohui/k8m/pkg/controller/sts"
 	"github.com/weibaohui/k8m/pkg/controller/svc"
+	"github.com/weibaohui/k8m/pkg/controller/tasks"
 	"github.com/weibaohui/k8m/pkg/controller/template"
+	"github.com/weibaohui/k8m/pkg/controller/upgrade"
+	"github.com/weibaohui/k8m/pkg/controller/user/favorite"
 	"github.com/weibaohui/k8m/pkg/controller/user/profile"
+	"github.com/weibaohui/k8m/pkg/davfs"
 	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/grpcapi"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/listener"
 	"github.com/weibaohui/k8m/pkg/middleware"
 	_ "github.com/weibaohui/k8m/pkg/models" // 注册模型
 	"github.com/weibaohui/k8m/pkg/plugins"
 	"github.com/weibaohui/k8m/pkg/plugins/modules"
 	aiService "github.com/weibaohui/k8m/pkg/plugins/modules/ai/service"
 	_ "github.com/weibaohui/k8m/pkg/plugins/modules/registrar" // 注册插件集中器
+	"github.com/weibaohui/k8m/pkg/plugins/modules/swagger"
 	"github.com/weibaohui/k8m/pkg/response"
 	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/telemetry"
 	"github.com/weibaohui/kom/callbacks"
 	"k8s.io/klog/v2"
 )
@@ -81,12 +106,19 @@ func Init() {
 	cfg.BuildDate = BuildDate
 	cfg.ShowConfigInfo()
 
+	// 初始化链路追踪，未配置导出地址时仅设置传播器
+	telemetry.InitTracer(cfg.OtelEndpoint, cfg.OtelServiceName, Version)
+
 	// 打印版本和 Git commit 信息
 	klog.V(2).Infof("版本: %s\n", Version)
 	klog.V(2).Infof("Git Commit: %s\n", GitCommit)
 
 	// 初始化 AI 内置模型参数（通过统一接口）
 	aiService.AIService().SetVars(InnerApiKey, InnerApiUrl, InnerModel)
+	// 清理上次运行遗留的上传暂存文件
+	service.UploadStagingService().Start()
+	// API 错误信息按用户设置的语言翻译
+	i18n.SetUserLangResolver(service.UserPreferenceService().LangFromRequest)
 	go func() {
 		// 初始化kom
 		// 先注册回调，后面集群连接后，需要执行回调
@@ -121,6 +153,24 @@ func Init() {
 		// 打印集群连接信息
 		klog.Infof("处理%d个集群，其中%d个集群已连接", len(service.ClusterService().AllClusters()), len(service.ClusterService().ConnectedClusters()))
 
+		// 定期比对命名空间基线
+		service.DriftService().Start()
+		// 定期清理文件回收站
+		service.FileTrashService().Start()
+		// 定期按保留策略清理制品
+		service.ArtifactService().Start()
+		// 定期检查容器文件监视
+		service.FileWatchService().Start()
+		// 定期应用到期的变更集
+		service.ChangeSetService().Start()
+		// 定期清理中断与过期的异步任务
+		service.TaskService().Start()
+		service.ReportScheduleService().Start()
+		// 定期执行到期的环境休眠与唤醒
+		service.HibernationService().Start()
+		// 定期比对服务器与集群、NTP 的时间
+		service.ClockService().Start()
+
 	}()
 
 }
@@ -132,20 +182,22 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 	if !cfg.Debug {
 		r.Use(chim.Recoverer)
 	}
+	r.Use(middleware.APIVersionMiddleware())
+	r.Use(middleware.AccessLogMiddleware(cfg.AccessLog))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-None-Match", "Accept-Language", i18n.LangHeader, response.RequestIDHeader},
+		ExposedHeaders:   []string{"Link", "ETag", response.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 	if cfg.Debug {
 		r.Use(chim.Logger)
 	}
+	r.Use(middleware.TelemetryMiddleware())
 	r.Use(chim.Compress(9, "text/html", "text/css", "application/json", "text/javascript", "font/woff2"))
-	r.Use(middleware.AuthMiddleware())
-	r.Use(middleware.EnsureSelectedClusterMiddleware())
+	r.Use(middleware.RequestChain()...)
 	r.Use(chim.Heartbeat("/ping"))
 
 	pagesFS, _ := fs.Sub(embeddedFiles, "ui/dist/pages")
@@ -158,6 +210,12 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 	if cfg.Debug {
 		r.Mount("/debug", cmiddleware.Profiler())
 	}
+	if cfg.EnableMetrics {
+		if cfg.MetricsToken == "" {
+			klog.Warningf("/metrics 已开启且未设置 --metrics-token，任何人均可访问")
+		}
+		r.Handle("/metrics", middleware.MetricsTokenAuth(cfg.MetricsToken)(promhttp.Handler()))
+	}
 
 	r.Get("/favicon.ico", response.Adapter(func(c *response.Context) {
 		favicon, _ := embeddedFiles.ReadFile("ui/dist/favicon.ico")
@@ -180,6 +238,20 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 		}
 	})
 
+	// OpenAPI 3 文档，与 Swagger 文档一同受插件开关控制
+	r.Get("/api/openapi.json", response.Adapter(func(c *response.Context) {
+		if !mgr.IsRunning(modules.PluginNameSwagger) {
+			c.JSON(http.StatusForbidden, response.H{"error": "Swagger documentation is disabled", "message": "Swagger文档已被禁用，请联系管理员启用"})
+			return
+		}
+		doc, err := swagger.OpenAPI3(middleware.APIVersionPrefix, "/")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", doc)
+	}))
+
 	r.Get("/", response.Adapter(func(c *response.Context) {
 		index, err := embeddedFiles.ReadFile("ui/dist/index.html")
 		if err != nil {
@@ -198,6 +270,16 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 		sso.RegisterAuthRoutes(auth)
 	})
 
+	r.Route("/hooks", func(hooks chi.Router) {
+		hook.RegisterHookRoutes(hooks)
+	})
+	r.Route("/agent", func(agentRouter chi.Router) {
+		agent.RegisterAgentRoutes(agentRouter)
+	})
+	if cfg.EnableWebDAV {
+		davfs.RegisterRoutes(r)
+	}
+
 	r.Route("/", func(root chi.Router) {
 		mgr.RegisterRootRoutes(root)
 	})
@@ -218,12 +300,36 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 		dynamic.RegisterPodAntiAffinityRoutes(api)
 		dynamic.RegisterTolerationRoutes(api)
 		dynamic.RegisterPodLinkRoutes(api)
+		dynamic.RegisterExportRoutes(api)
+		dynamic.RegisterImportRoutes(api)
+		dynamic.RegisterSelectorRoutes(api)
+		changeset.RegisterChangeSetRoutes(api)
+		component.RegisterComponentRoutes(api)
+		upgrade.RegisterUpgradeRoutes(api)
 		pod.RegisterLabelRoutes(api)
 		pod.RegisterLogRoutes(api)
 		pod.RegisterXtermRoutes(api)
 		pod.RegisterPodFileRoutes(api)
 		pod.RegisterResourceRoutes(api)
 		pod.RegisterPortRoutes(api)
+		pod.RegisterDescribeRoutes(api)
+		pod.RegisterCrashLoopRoutes(api)
+		pod.RegisterInitRoutes(api)
+		pod.RegisterDiagnosticsRoutes(api)
+		pod.RegisterIndexRoutes(api)
+		pod.RegisterVolumeRoutes(api)
+		pod.RegisterLifecycleRoutes(api)
+		pod.RegisterExecRoutes(api)
+		pod.RegisterProbeRoutes(api)
+		pod.RegisterDNSRoutes(api)
+		image.RegisterImageRoutes(api)
+		report.RegisterOOMRoutes(api)
+		report.RegisterExportRoutes(api)
+		drift.RegisterDriftRoutes(api)
+		hibernation.RegisterHibernationRoutes(api)
+		graphql.RegisterGraphQLRoutes(api)
+		admission.RegisterAdmissionRoutes(api)
+		sa.RegisterRBACRoutes(api)
 		deploy.RegisterActionRoutes(api)
 		svc.RegisterActionRoutes(api)
 		node.RegisterActionRoutes(api)
@@ -240,12 +346,18 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 		storageclass.RegisterRoutes(api)
 		ingressclass.RegisterRoutes(api)
 		doc.RegisterRoutes(api)
+		template.RegisterTemplateInstantiateRoutes(api)
 		mgr.RegisterClusterRoutes(api)
 	})
 
 	r.Route("/mgm", func(mgm chi.Router) {
 		template.RegisterTemplateRoutes(mgm)
+		bulk.RegisterMetadataRoutes(mgm)
 		profile.RegisterProfileRoutes(mgm)
+		favorite.RegisterFavoriteRoutes(mgm)
+		approval.RegisterApprovalRoutes(mgm)
+		project.RegisterProjectRoutes(mgm)
+		tasks.RegisterTaskRoutes(mgm)
 		log.RegisterLogRoutes(mgm)
 		cluster.RegisterUserClusterRoutes(mgm)
 		mgr.RegisterManagementRoutes(mgm)
@@ -254,12 +366,26 @@ func buildRouter(mgr *plugins.Manager, r chi.Router) http.Handler {
 	r.Route("/admin", func(admin chi.Router) {
 		sadmin := admin.With(middleware.PlatformAuthMiddleware())
 		config.RegisterConditionRoutes(sadmin)
+		config.RegisterCRDConditionRoutes(sadmin)
+		config.RegisterClockRoutes(sadmin)
+		config.RegisterArtifactRoutes(sadmin)
 		config.RegisterSSOConfigRoutes(sadmin)
 		config.RegisterLdapConfigRoutes(sadmin)
+		config.RegisterRegistryCredentialRoutes(sadmin)
+		config.RegisterAdmissionPolicyRoutes(sadmin)
+		config.RegisterCommandPolicyRoutes(sadmin)
+		config.RegisterReadOnlyRoutes(sadmin)
+		config.RegisterApprovalRuleRoutes(sadmin)
+		config.RegisterEncryptionRoutes(sadmin)
+		config.RegisterAccessRuleRoutes(sadmin)
+		config.RegisterInboundWebhookRoutes(sadmin)
+		config.RegisterClusterAgentRoutes(sadmin)
+		config.RegisterReportScheduleRoutes(sadmin)
 		config.RegisterConfigRoutes(sadmin)
 		user.RegisterClusterPermissionRoutes(sadmin)
 		user.RegisterAdminUserRoutes(sadmin)
 		user.RegisterAdminUserGroupRoutes(sadmin)
+		adminproject.RegisterAdminProjectRoutes(sadmin)
 		cluster.RegisterAdminClusterRoutes(sadmin)
 		menu.RegisterAdminMenuRoutes(sadmin)
 		mgr.RegisterAdminRoutes(sadmin)
@@ -284,8 +410,13 @@ func main() {
 	mgr.SetAtomicHandler(ah)
 
 	cfg := flag.Init()
+	listeners, err := listener.Load(cfg)
+	if err != nil {
+		klog.Fatalf("监听配置错误: %v", err)
+	}
 	showBootInfo(Version, cfg.Port)
-	err := http.ListenAndServe(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port), ah)
+	grpcapi.Start()
+	err = listener.Serve(listeners, ah)
 	if err != nil {
 		klog.Fatalf("Error %v", err)
 	}
@@ -0,0 +1,281 @@
+// Package accessrule 按角色、操作限制访问来源与时间段。
+// 规则适用于某类操作时，请求须同时满足其 IP 白名单、地区与时间段限制，否则拒绝
+package accessrule
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AllRoles 规则适用于全部用户
+const AllRoles = "*"
+
+// Operation 可单独限制的操作类别
+type Operation struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	// anyMethod 为 true 时 GET 请求也计入，如 websocket 终端
+	anyMethod bool
+	re        *regexp.Regexp
+}
+
+const clusterPrefix = `^/k8s/cluster/[^/]+(/.*)?/`
+
+// Operations 支持的操作类别。未指定操作的规则适用于全部请求
+var Operations = []*Operation{
+	{Name: "exec", Label: "容器执行与终端", anyMethod: true,
+		re: regexp.MustCompile(clusterPrefix + `(exec-command|create_[a-z]+_shell|xterm)(/.*)?$`)},
+	{Name: "file_delete", Label: "删除容器文件",
+		re: regexp.MustCompile(clusterPrefix + `file/delete$`)},
+	{Name: "file_write", Label: "上传、修改容器文件",
+		re: regexp.MustCompile(clusterPrefix + `file/(save|upload(/batch|/workload)?|copy|to_config|from_config|s3/pull|trash/restore/[^/]+)$`)},
+	{Name: "delete", Label: "删除资源",
+		re: regexp.MustCompile(clusterPrefix + `(remove|batch/remove|force_remove)(/.*)?$`)},
+	{Name: "node", Label: "节点维护",
+		re: regexp.MustCompile(clusterPrefix + `(drain|cordon|uncordon|[a-z_]*taints)(/.*)?$`)},
+	{Name: "write", Label: "集群变更",
+		re: regexp.MustCompile(clusterPrefix + `(update[^/]*|remove|batch/remove|force_remove|create|import|apply|` +
+			`restart|restore|stop|pause|resume|rollout/undo|scale/replica/[^/]+|batch_update_images|` +
+			`drain|cordon|uncordon|[a-z_]*taints|set_default|exec-command|create_[a-z]+_shell|` +
+			`file/(save|delete|upload(/batch|/workload)?|copy|to_config|from_config|s3/pull|trash/restore/[^/]+))(/.*)?$`)},
+	{Name: "admin", Label: "平台管理", anyMethod: true,
+		re: regexp.MustCompile(`^/admin/`)},
+}
+
+// Classify 返回请求所属的操作类别
+func Classify(method, path string) []string {
+	var ops []string
+	for _, op := range Operations {
+		if !op.anyMethod && (method == "GET" || method == "HEAD" || method == "OPTIONS") {
+			continue
+		}
+		if op.re.MatchString(path) {
+			ops = append(ops, op.Name)
+		}
+	}
+	return ops
+}
+
+// Spec 规则的文本配置，列表项以逗号或换行分隔
+type Spec struct {
+	Roles      string // 适用角色，为空或 * 表示全部用户
+	Operations string // 适用操作，为空表示全部请求
+	CIDRs      string // 允许的来源 IP 或 CIDR，为空不限制
+	Countries  string // 允许的国家/地区代码，为空不限制
+	Days       string // 允许的星期，0 为周日，支持 1-5 区间，为空不限制
+	StartTime  string // 允许的开始时间 HH:MM
+	EndTime    string // 允许的结束时间 HH:MM，早于开始时间表示跨越午夜
+	Timezone   string // 时间段所用时区，为空使用服务器时区
+}
+
+// Rule 编译后的访问规则
+type Rule struct {
+	Name       string
+	roles      []string
+	operations []string
+	nets       []*net.IPNet
+	countries  []string
+	days       [7]bool
+	hasDays    bool
+	start, end int // 自零点起的分钟数，start < 0 表示不限时间段
+	loc        *time.Location
+}
+
+// Request 待校验的请求
+type Request struct {
+	Roles      []string
+	Operations []string
+	IP         string
+	Country    string
+	Time       time.Time
+}
+
+// Compile 解析并校验规则配置
+func Compile(name string, spec Spec) (*Rule, error) {
+	rule := &Rule{Name: name, start: -1, end: -1, loc: time.Local}
+	for _, role := range split(spec.Roles) {
+		if role == AllRoles {
+			rule.roles = nil
+			break
+		}
+		rule.roles = append(rule.roles, role)
+	}
+	for _, op := range split(spec.Operations) {
+		if !slices.ContainsFunc(Operations, func(o *Operation) bool { return o.Name == op }) {
+			return nil, fmt.Errorf("不支持的操作类别: %s", op)
+		}
+		rule.operations = append(rule.operations, op)
+	}
+	for _, item := range split(spec.CIDRs) {
+		ipNet, err := parseNet(item)
+		if err != nil {
+			return nil, err
+		}
+		rule.nets = append(rule.nets, ipNet)
+	}
+	for _, c := range split(spec.Countries) {
+		rule.countries = append(rule.countries, strings.ToUpper(c))
+	}
+	for _, item := range split(spec.Days) {
+		from, to, found := strings.Cut(item, "-")
+		if !found {
+			to = from
+		}
+		a, errA := strconv.Atoi(strings.TrimSpace(from))
+		b, errB := strconv.Atoi(strings.TrimSpace(to))
+		if errA != nil || errB != nil || a < 0 || b > 6 || a > b {
+			return nil, fmt.Errorf("星期格式错误: %s，应为 0-6，0 表示周日", item)
+		}
+		for d := a; d <= b; d++ {
+			rule.days[d] = true
+		}
+		rule.hasDays = true
+	}
+	start, end := strings.TrimSpace(spec.StartTime), strings.TrimSpace(spec.EndTime)
+	if (start == "") != (end == "") {
+		return nil, fmt.Errorf("开始时间与结束时间须同时设置")
+	}
+	if start != "" {
+		var err error
+		if rule.start, err = parseClock(start); err != nil {
+			return nil, err
+		}
+		if rule.end, err = parseClock(end); err != nil {
+			return nil, err
+		}
+		if rule.start == rule.end {
+			return nil, fmt.Errorf("开始时间与结束时间不能相同")
+		}
+	}
+	if tz := strings.TrimSpace(spec.Timezone); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("时区无效: %s", tz)
+		}
+		rule.loc = loc
+	}
+	if len(rule.nets) == 0 && len(rule.countries) == 0 && !rule.hasDays && rule.start < 0 {
+		return nil, fmt.Errorf("规则至少需要设置 IP 白名单、地区或时间段之一")
+	}
+	return rule, nil
+}
+
+// MatchOperations 规则是否适用于请求的操作类别
+func (r *Rule) MatchOperations(ops []string) bool {
+	if len(r.operations) == 0 {
+		return true
+	}
+	for _, op := range ops {
+		if slices.Contains(r.operations, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchRoles 规则是否适用于具有这些角色的用户
+func (r *Rule) MatchRoles(roles []string) bool {
+	if len(r.roles) == 0 {
+		return true
+	}
+	for _, role := range roles {
+		if slices.Contains(r.roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Applies 规则是否适用于该请求
+func (r *Rule) Applies(req *Request) bool {
+	return r.MatchOperations(req.Operations) && r.MatchRoles(req.Roles)
+}
+
+// Check 校验请求是否满足规则的来源与时间段限制，不满足时返回原因
+func (r *Rule) Check(req *Request) error {
+	if len(r.nets) > 0 {
+		ip := net.ParseIP(req.IP)
+		if ip == nil || !slices.ContainsFunc(r.nets, func(n *net.IPNet) bool { return n.Contains(ip) }) {
+			return fmt.Errorf("来源 IP %s 不在访问规则[%s]的白名单内", req.IP, r.Name)
+		}
+	}
+	if len(r.countries) > 0 && !slices.Contains(r.countries, strings.ToUpper(req.Country)) {
+		country := req.Country
+		if country == "" {
+			country = "未知"
+		}
+		return fmt.Errorf("来源地区 %s 不在访问规则[%s]允许的范围内", country, r.Name)
+	}
+	if !r.hasDays && r.start < 0 {
+		return nil
+	}
+	now := req.Time.In(r.loc)
+	minute := now.Hour()*60 + now.Minute()
+	day := now.Weekday()
+	if r.start >= 0 && r.start > r.end && minute < r.end {
+		// 跨越午夜的时间段，凌晨部分属于前一天开始的窗口
+		day = (day + 6) % 7
+	}
+	if r.hasDays && !r.days[day] {
+		return fmt.Errorf("访问规则[%s]不允许在%s执行该操作", r.Name, weekdayNames[now.Weekday()])
+	}
+	if r.start >= 0 && !r.inWindow(minute) {
+		return fmt.Errorf("访问规则[%s]仅允许在 %s-%s 执行该操作", r.Name, formatClock(r.start), formatClock(r.end))
+	}
+	return nil
+}
+
+func (r *Rule) inWindow(minute int) bool {
+	if r.start < r.end {
+		return minute >= r.start && minute < r.end
+	}
+	return minute >= r.start || minute < r.end
+}
+
+var weekdayNames = [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+func split(s string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseNet(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("IP 格式错误: %s", s)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("CIDR 格式错误: %s", s)
+	}
+	return ipNet, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("时间格式错误: %s，应为 HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -0,0 +1,113 @@
+package accessrule
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         []string
+	}{
+		{"POST", "/k8s/cluster/c1/pod/exec-command/ns/default/name/web", []string{"exec", "write"}},
+		{"GET", "/k8s/cluster/c1/pod/xterm/ns/default/pod_name/web", []string{"exec"}},
+		{"POST", "/k8s/cluster/c1/file/delete", []string{"file_delete", "write"}},
+		{"POST", "/k8s/cluster/c1/file/upload/batch", []string{"file_write", "write"}},
+		{"POST", "/k8s/cluster/c1/dynamic/apps/v1/Deployment/remove/ns/default/name/web", []string{"delete", "write"}},
+		{"POST", "/k8s/cluster/c1/node/drain/name/n1", []string{"node", "write"}},
+		{"GET", "/k8s/cluster/c1/file/list", nil},
+		{"GET", "/admin/user/list", []string{"admin"}},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.method, tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("Classify(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+	}{
+		{"无限制条件", Spec{Roles: "guest"}},
+		{"未知操作", Spec{Operations: "reboot", CIDRs: "10.0.0.0/8"}},
+		{"CIDR 错误", Spec{CIDRs: "10.0.0.0/33"}},
+		{"星期越界", Spec{Days: "1-7"}},
+		{"只有开始时间", Spec{StartTime: "09:00"}},
+		{"时间相同", Spec{StartTime: "09:00", EndTime: "09:00"}},
+		{"时区无效", Spec{Days: "1", Timezone: "Mars/Base"}},
+	}
+	for _, tt := range tests {
+		if _, err := Compile(tt.name, tt.spec); err == nil {
+			t.Errorf("%s: 应返回错误", tt.name)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	rule, err := Compile("exec 办公网", Spec{
+		Roles:      "guest, cluster_pod_exec",
+		Operations: "exec,file_delete",
+		CIDRs:      "10.0.0.0/8\n192.168.1.10",
+		Days:       "1-5",
+		StartTime:  "09:00",
+		EndTime:    "18:00",
+		Timezone:   "Asia/Shanghai",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	monday := time.Date(2026, 10, 12, 10, 0, 0, 0, loc)
+
+	req := &Request{Roles: []string{"cluster_admin"}, Operations: []string{"exec"}}
+	if rule.Applies(req) {
+		t.Error("角色不匹配时不应适用")
+	}
+	req = &Request{Roles: []string{"guest"}, Operations: []string{"write"}}
+	if rule.Applies(req) {
+		t.Error("操作不匹配时不应适用")
+	}
+
+	ok := &Request{Roles: []string{"guest"}, Operations: []string{"exec"}, IP: "10.1.2.3", Time: monday}
+	if !rule.Applies(ok) || rule.Check(ok) != nil {
+		t.Errorf("工作时间办公网应放行: %v", rule.Check(ok))
+	}
+	for name, req := range map[string]*Request{
+		"IP 不在白名单": {IP: "172.16.0.1", Time: monday},
+		"非工作日":     {IP: "192.168.1.10", Time: monday.AddDate(0, 0, 5)},
+		"下班时间":     {IP: "10.1.2.3", Time: monday.Add(9 * time.Hour)},
+		"其他时区换算":   {IP: "10.1.2.3", Time: time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)},
+	} {
+		if rule.Check(req) == nil {
+			t.Errorf("%s: 应拒绝", name)
+		}
+	}
+}
+
+func TestCheckOvernight(t *testing.T) {
+	rule, err := Compile("夜间变更窗口", Spec{Days: "5", StartTime: "22:00", EndTime: "02:00", Timezone: "UTC", Countries: "cn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	friday := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		at      time.Time
+		country string
+		allow   bool
+	}{
+		{friday.Add(23 * time.Hour), "CN", true},
+		{friday.Add(25 * time.Hour), "cn", true}, // 周六凌晨属于周五开始的窗口
+		{friday.Add(1 * time.Hour), "CN", false}, // 周五凌晨属于周四开始的窗口
+		{friday.Add(23 * time.Hour), "US", false},
+		{friday.Add(23 * time.Hour), "", false},
+	} {
+		err := rule.Check(&Request{Country: tt.country, Time: tt.at})
+		if (err == nil) != tt.allow {
+			t.Errorf("%s %q: allow=%v, err=%v", tt.at, tt.country, tt.allow, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package admission 解析 API Server 准入拒绝信息，将 Webhook、Gatekeeper、Kyverno、
+// ValidatingAdmissionPolicy 等返回的文本整理为结构化的违规列表。
+package admission
+
+import (
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// 违规来源
+const (
+	SourceWebhook    = "webhook"                     // 普通准入 Webhook
+	SourceGatekeeper = "gatekeeper"                  // OPA Gatekeeper 约束
+	SourceKyverno    = "kyverno"                     // Kyverno 策略
+	SourceVAP        = "validating_admission_policy" // 内置 ValidatingAdmissionPolicy
+	SourceQuota      = "quota"                       // ResourceQuota 超限
+	SourceValidation = "validation"                  // 字段校验失败
+	SourceOther      = "other"
+)
+
+// Violation 单条准入违规
+type Violation struct {
+	Source  string `json:"source"`
+	Webhook string `json:"webhook,omitempty"` // Webhook 名称
+	Policy  string `json:"policy,omitempty"`  // 策略或约束名称
+	Rule    string `json:"rule,omitempty"`    // 规则名称，字段校验失败时为字段路径
+	Binding string `json:"binding,omitempty"` // ValidatingAdmissionPolicyBinding 名称
+	Message string `json:"message"`
+}
+
+var (
+	webhookDenied   = regexp.MustCompile(`admission webhook "([^"]+)" denied the request:\s*`)
+	vapDenied       = regexp.MustCompile(`ValidatingAdmissionPolicy '([^']+)' with binding '([^']+)' denied request:\s*`)
+	gatekeeperEntry = regexp.MustCompile(`^\[([^\]]+)\]\s*(.*)$`)
+)
+
+// ParseError 从 API Server 返回的错误中提取违规信息，非 API 错误时按原始文本处理
+func ParseError(err error) []Violation {
+	if err == nil {
+		return nil
+	}
+	status, ok := err.(apierrors.APIStatus)
+	if !ok {
+		return ParseMessage(err.Error())
+	}
+	result := ParseMessage(status.Status().Message)
+	// 非准入拒绝的字段校验失败，逐个字段列出
+	if details := status.Status().Details; details != nil && len(details.Causes) > 0 &&
+		(len(result) == 0 || result[0].Source == SourceOther) {
+		result = nil
+		for _, cause := range details.Causes {
+			result = append(result, Violation{Source: SourceValidation, Rule: cause.Field, Message: cause.Message})
+		}
+	}
+	return result
+}
+
+// ParseMessage 解析准入拒绝文本
+func ParseMessage(message string) []Violation {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil
+	}
+
+	if m := webhookDenied.FindStringSubmatchIndex(message); m != nil {
+		webhook := message[m[2]:m[3]]
+		rest := strings.TrimSpace(message[m[1]:])
+		lower := strings.ToLower(webhook)
+		switch {
+		case strings.Contains(lower, "gatekeeper"):
+			if v := parseGatekeeper(webhook, rest); len(v) > 0 {
+				return v
+			}
+		case strings.Contains(lower, "kyverno"):
+			if v := parseKyverno(webhook, rest); len(v) > 0 {
+				return v
+			}
+		}
+		return []Violation{{Source: SourceWebhook, Webhook: webhook, Message: rest}}
+	}
+
+	if m := vapDenied.FindStringSubmatchIndex(message); m != nil {
+		return []Violation{{
+			Source:  SourceVAP,
+			Policy:  message[m[2]:m[3]],
+			Binding: message[m[4]:m[5]],
+			Message: strings.TrimSpace(message[m[1]:]),
+		}}
+	}
+
+	if strings.Contains(message, "exceeded quota") {
+		return []Violation{{Source: SourceQuota, Message: message}}
+	}
+	return []Violation{{Source: SourceOther, Message: message}}
+}
+
+// parseGatekeeper Gatekeeper 每行一条违规，格式为 [约束名称] 信息
+func parseGatekeeper(webhook, rest string) []Violation {
+	var result []Violation
+	for _, line := range strings.Split(rest, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		m := gatekeeperEntry.FindStringSubmatch(line)
+		if m == nil {
+			// 信息跨行时并入上一条
+			if len(result) > 0 {
+				result[len(result)-1].Message += "\n" + line
+			}
+			continue
+		}
+		result = append(result, Violation{Source: SourceGatekeeper, Webhook: webhook, Policy: m[1], Message: m[2]})
+	}
+	return result
+}
+
+// parseKyverno Kyverno 按策略分组列出失败的规则：
+//
+//	resource Deployment/default/nginx was blocked due to the following policies
+//
+//	require-labels:
+//	  check-for-labels: 'validation error: label app is required'
+func parseKyverno(webhook, rest string) []Violation {
+	var result []Violation
+	policy := ""
+	for _, line := range strings.Split(rest, "\n") {
+		if strings.TrimSpace(line) == "" || strings.Contains(line, "was blocked due to the following policies") {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			if strings.HasSuffix(trimmed, ":") {
+				policy = strings.TrimSuffix(trimmed, ":")
+			}
+			continue
+		}
+		i := strings.Index(trimmed, ":")
+		if i <= 0 || policy == "" {
+			continue
+		}
+		msg := strings.TrimSpace(trimmed[i+1:])
+		msg = strings.Trim(msg, `'"`)
+		result = append(result, Violation{Source: SourceKyverno, Webhook: webhook, Policy: policy, Rule: trimmed[:i], Message: msg})
+	}
+	return result
+}
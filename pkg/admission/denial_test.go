@@ -0,0 +1,46 @@
+package admission
+
+import (
+	"testing"
+)
+
+func TestParseMessageGatekeeper(t *testing.T) {
+	msg := `admission webhook "validation.gatekeeper.sh" denied the request: [require-owner] you must provide labels: {"owner"}
+[deny-latest] image tag latest is not allowed`
+	v := ParseMessage(msg)
+	if len(v) != 2 {
+		t.Fatalf("got %+v", v)
+	}
+	if v[0].Source != SourceGatekeeper || v[0].Policy != "require-owner" || v[0].Message != `you must provide labels: {"owner"}` {
+		t.Errorf("unexpected %+v", v[0])
+	}
+	if v[1].Policy != "deny-latest" || v[1].Webhook != "validation.gatekeeper.sh" {
+		t.Errorf("unexpected %+v", v[1])
+	}
+}
+
+func TestParseMessageKyverno(t *testing.T) {
+	msg := "admission webhook \"validate.kyverno.svc-fail\" denied the request: \n\nresource Deployment/default/nginx was blocked due to the following policies \n\nrequire-labels:\n  check-for-labels: 'validation error: label app.kubernetes.io/name is required. rule check-for-labels failed at path /metadata/labels/app.kubernetes.io/name/'\ndisallow-latest:\n  validate-image-tag: 'validation error: Using a mutable image tag e.g. latest is not allowed.'\n"
+	v := ParseMessage(msg)
+	if len(v) != 2 {
+		t.Fatalf("got %+v", v)
+	}
+	if v[0].Source != SourceKyverno || v[0].Policy != "require-labels" || v[0].Rule != "check-for-labels" {
+		t.Errorf("unexpected %+v", v[0])
+	}
+	if v[1].Policy != "disallow-latest" || v[1].Message != "validation error: Using a mutable image tag e.g. latest is not allowed." {
+		t.Errorf("unexpected %+v", v[1])
+	}
+}
+
+func TestParseMessageVAPAndWebhook(t *testing.T) {
+	v := ParseMessage(`deployments.apps "nginx" is forbidden: ValidatingAdmissionPolicy 'replica-limit' with binding 'replica-limit-binding' denied request: replicas must be no greater than 5`)
+	if len(v) != 1 || v[0].Source != SourceVAP || v[0].Policy != "replica-limit" || v[0].Binding != "replica-limit-binding" || v[0].Message != "replicas must be no greater than 5" {
+		t.Errorf("unexpected %+v", v)
+	}
+
+	v = ParseMessage(`admission webhook "check.example.com" denied the request: team label missing`)
+	if len(v) != 1 || v[0].Source != SourceWebhook || v[0].Webhook != "check.example.com" || v[0].Message != "team label missing" {
+		t.Errorf("unexpected %+v", v)
+	}
+}
@@ -0,0 +1,132 @@
+package admission
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// 策略动作
+const (
+	ActionDeny = "deny" // 命中时阻止操作
+	ActionWarn = "warn" // 命中时放行，并在操作日志中记录警告
+)
+
+// Request 经由 k8m 的一次写操作，作为策略表达式的变量
+type Request struct {
+	Cluster   string
+	User      string
+	Operation string // create、update、patch、delete
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+	Object    map[string]any // 创建、更新的对象，删除时为空
+	Patch     map[string]any // patch 操作的补丁内容
+}
+
+// env 表达式可用的变量与函数
+func env(req *Request) map[string]any {
+	return map[string]any{
+		"cluster":   req.Cluster,
+		"user":      req.User,
+		"operation": req.Operation,
+		"group":     req.Group,
+		"version":   req.Version,
+		"kind":      req.Kind,
+		"namespace": req.Namespace,
+		"name":      req.Name,
+		"object":    req.Object,
+		"patch":     req.Patch,
+		"images":    ContainerImages,
+	}
+}
+
+// Compile 编译策略表达式，表达式返回 true 表示命中策略。
+// 表达式使用 expr 语法，例如：
+//
+//	any(images(object), {# endsWith ":latest"})
+//	kind == "Deployment" && object.spec.replicas > 10
+func Compile(expression string) (*vm.Program, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("策略表达式不能为空")
+	}
+	program, err := expr.Compile(expression, expr.Env(env(&Request{})), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("策略表达式编译失败: %w", err)
+	}
+	return program, nil
+}
+
+// Eval 执行策略表达式，返回是否命中
+func Eval(program *vm.Program, req *Request) (bool, error) {
+	out, err := expr.Run(program, env(req))
+	if err != nil {
+		return false, err
+	}
+	matched, _ := out.(bool)
+	return matched, nil
+}
+
+// ContainerImages 返回对象中声明的全部容器镜像，支持 Pod、工作负载模板与 CronJob
+func ContainerImages(obj map[string]any) []string {
+	var images []string
+	collect := func(podSpec map[string]any) {
+		for _, key := range []string{"initContainers", "containers", "ephemeralContainers"} {
+			list, _ := podSpec[key].([]any)
+			for _, item := range list {
+				if c, ok := item.(map[string]any); ok {
+					if image, ok := c["image"].(string); ok && image != "" {
+						images = append(images, image)
+					}
+				}
+			}
+		}
+	}
+	spec, _ := obj["spec"].(map[string]any)
+	if spec == nil {
+		return images
+	}
+	collect(spec)
+	if tpl, ok := nested(spec, "template", "spec"); ok {
+		collect(tpl)
+	}
+	if tpl, ok := nested(spec, "jobTemplate", "spec", "template", "spec"); ok {
+		collect(tpl)
+	}
+	return images
+}
+
+func nested(m map[string]any, keys ...string) (map[string]any, bool) {
+	cur := m
+	for _, k := range keys {
+		next, ok := cur[k].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
+
+// MatchList 判断 value 是否匹配逗号分隔的列表，列表项支持 * 通配，列表为空表示匹配全部
+func MatchList(list, value string) bool {
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return true
+	}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if ok, _ := path.Match(item, value); ok || strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
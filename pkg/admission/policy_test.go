@@ -0,0 +1,65 @@
+package admission
+
+import (
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestEvalLatestImage(t *testing.T) {
+	program, err := Compile(`operation != "delete" && any(images(object), {# endsWith ":latest" || !(# contains ":")})`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj map[string]any
+	_ = yaml.Unmarshal([]byte(`
+kind: CronJob
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: job
+            image: busybox:1.36
+          initContainers:
+          - name: init
+            image: alpine
+`), &obj)
+
+	matched, err := Eval(program, &Request{Operation: "create", Kind: "CronJob", Object: obj})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matched {
+		t.Errorf("expected image without tag to match")
+	}
+
+	matched, err = Eval(program, &Request{Operation: "delete", Kind: "CronJob"})
+	if err != nil || matched {
+		t.Errorf("delete should not match: %v %v", matched, err)
+	}
+}
+
+func TestCompileRejectsNonBool(t *testing.T) {
+	if _, err := Compile(`kind`); err == nil {
+		t.Errorf("expected compile error for non-bool expression")
+	}
+}
+
+func TestMatchList(t *testing.T) {
+	cases := []struct {
+		list, value string
+		want        bool
+	}{
+		{"", "anything", true},
+		{"prod-*, staging", "prod-web", true},
+		{"prod-*, staging", "staging", true},
+		{"prod-*, staging", "dev", false},
+	}
+	for _, c := range cases {
+		if got := MatchList(c.list, c.value); got != c.want {
+			t.Errorf("MatchList(%q, %q) = %v", c.list, c.value, got)
+		}
+	}
+}
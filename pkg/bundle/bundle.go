@@ -0,0 +1,185 @@
+// Package bundle 生成诊断包：将文本、JSON 与容器中的文件打包为 tar.gz。
+// 单项内容收集失败不影响整个包，失败原因记录在包内的 errors.txt 中
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ErrorsFile 记录收集失败原因的文件名
+const ErrorsFile = "errors.txt"
+
+// Writer 诊断包写入器，非并发安全
+type Writer struct {
+	root    string
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	modTime time.Time
+	limit   int64 // 包内文件内容总大小上限，0 为不限制
+	size    int64
+	files   []string
+	errors  []string
+}
+
+// NewWriter 创建诊断包，所有文件位于 root 目录下，limit 为内容总大小上限（字节），0 为不限制
+func NewWriter(w io.Writer, root string, limit int64) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{root: root, gz: gz, tw: tar.NewWriter(gz), modTime: time.Now(), limit: limit}
+}
+
+// Files 已写入的文件，不含 root 前缀
+func (b *Writer) Files() []string {
+	return b.files
+}
+
+// Errorf 记录一项收集失败，关闭时写入 errors.txt
+func (b *Writer) Errorf(format string, args ...any) {
+	b.errors = append(b.errors, fmt.Sprintf(format, args...))
+}
+
+// Errors 已记录的收集失败
+func (b *Writer) Errors() []string {
+	return b.errors
+}
+
+// remaining 剩余可写入的字节数
+func (b *Writer) remaining() int64 {
+	if b.limit <= 0 {
+		return -1
+	}
+	return max(b.limit-b.size, 0)
+}
+
+// AddFile 写入文件，超出总大小上限时记录失败并跳过
+func (b *Writer) AddFile(name string, data []byte) error {
+	if r := b.remaining(); r >= 0 && int64(len(data)) > r {
+		b.Errorf("%s: 超出诊断包大小上限，已跳过（%d 字节）", name, len(data))
+		return nil
+	}
+	return b.write(name, int64(len(data)), b.modTime, bytes.NewReader(data))
+}
+
+// AddJSON 以缩进格式写入 JSON
+func (b *Writer) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.Errorf("%s: %v", name, err)
+		return nil
+	}
+	return b.AddFile(name, data)
+}
+
+// AddYAML 写入 YAML
+func (b *Writer) AddYAML(name string, v any) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		b.Errorf("%s: %v", name, err)
+		return nil
+	}
+	return b.AddFile(name, data)
+}
+
+// AddReader 读取 r 的内容写入文件，最多写入 limit 字节（0 为不限制），超出部分截断
+func (b *Writer) AddReader(name string, r io.Reader, limit int64) error {
+	if rest := b.remaining(); rest >= 0 && (limit <= 0 || rest < limit) {
+		if rest == 0 {
+			b.Errorf("%s: 超出诊断包大小上限，已跳过", name)
+			return nil
+		}
+		limit = rest
+	}
+	if limit > 0 {
+		r = io.LimitReader(r, limit)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil && len(data) == 0 {
+		b.Errorf("%s: %v", name, err)
+		return nil
+	}
+	if err != nil {
+		b.Errorf("%s: 读取中断，内容不完整: %v", name, err)
+	}
+	return b.write(name, int64(len(data)), b.modTime, bytes.NewReader(data))
+}
+
+// AddTar 将 tar 流中的普通文件写入 prefix 目录，保留原路径与修改时间。
+// 单个文件超过 maxFile 字节或超出总大小上限时跳过并记录，目录、链接等其他类型的条目忽略
+func (b *Writer) AddTar(prefix string, r io.Reader, maxFile int64) (int, error) {
+	tr := tar.NewReader(r)
+	count := 0
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Join(prefix, cleanPath(hdr.Name))
+		if maxFile > 0 && hdr.Size > maxFile {
+			b.Errorf("%s: 文件大小 %d 字节超过单个文件上限 %d 字节，已跳过", name, hdr.Size, maxFile)
+			continue
+		}
+		if rest := b.remaining(); rest >= 0 && hdr.Size > rest {
+			b.Errorf("%s: 超出诊断包大小上限，已跳过（%d 字节）", name, hdr.Size)
+			continue
+		}
+		if err := b.write(name, hdr.Size, hdr.ModTime, tr); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+// cleanPath 去除绝对路径与 .. 片段，防止解包时写到目录之外
+func cleanPath(name string) string {
+	name = path.Clean("/" + name)
+	return strings.TrimPrefix(name, "/")
+}
+
+func (b *Writer) write(name string, size int64, modTime time.Time, r io.Reader) error {
+	name = cleanPath(name)
+	hdr := &tar.Header{
+		Name:     path.Join(b.root, name),
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(b.tw, r, size); err != nil {
+		return err
+	}
+	b.size += size
+	b.files = append(b.files, name)
+	return nil
+}
+
+// Close 写入 errors.txt 并结束压缩流
+func (b *Writer) Close() error {
+	if len(b.errors) > 0 {
+		data := strings.Join(b.errors, "\n") + "\n"
+		if err := b.write(ErrorsFile, int64(len(data)), b.modTime, strings.NewReader(data)); err != nil {
+			return err
+		}
+	}
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+	return b.gz.Close()
+}
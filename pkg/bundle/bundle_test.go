@@ -0,0 +1,148 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readBundle 解包并返回文件名到内容的映射
+func readBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(content)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var src bytes.Buffer
+	tw := tar.NewWriter(&src)
+	for name, content := range map[string]string{"var/log/app.log": "hello", "../../etc/passwd": "x", "big.bin": strings.Repeat("b", 100)} {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0o644, Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.WriteHeader(&tar.Header{Name: "var/log/link", Linkname: "app.log", Typeflag: tar.TypeSymlink})
+	_ = tw.Close()
+
+	var out bytes.Buffer
+	b := NewWriter(&out, "pod-diag", 0)
+	_ = b.AddFile("/pod.yaml", []byte("kind: Pod\n"))
+	_ = b.AddJSON("meta.json", map[string]string{"a": "b"})
+	_ = b.AddReader("logs/app.log", strings.NewReader("0123456789"), 4)
+	n, err := b.AddTar("files/app", &src, 50)
+	if err != nil || n != 2 {
+		t.Fatalf("AddTar = %d, %v", n, err)
+	}
+	b.Errorf("env: %s", "forbidden")
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := readBundle(t, out.Bytes())
+	want := map[string]string{
+		"pod-diag/pod.yaml":                  "kind: Pod\n",
+		"pod-diag/meta.json":                 "{\n  \"a\": \"b\"\n}",
+		"pod-diag/logs/app.log":              "0123",
+		"pod-diag/files/app/var/log/app.log": "hello",
+		"pod-diag/files/app/etc/passwd":      "x",
+	}
+	for name, content := range want {
+		if files[name] != content {
+			t.Errorf("%s = %q, want %q", name, files[name], content)
+		}
+	}
+	errs := files["pod-diag/errors.txt"]
+	if !strings.Contains(errs, "files/app/big.bin") || !strings.Contains(errs, "env: forbidden") {
+		t.Fatalf("unexpected errors.txt: %q", errs)
+	}
+	if len(files) != len(want)+1 {
+		t.Fatalf("unexpected files: %v", files)
+	}
+}
+
+func TestWriterLimit(t *testing.T) {
+	var out bytes.Buffer
+	b := NewWriter(&out, "bundle", 10)
+	_ = b.AddFile("a.txt", []byte("12345678"))
+	_ = b.AddFile("b.txt", []byte("12345"))
+	_ = b.AddReader("c.txt", strings.NewReader("abcdef"), 0)
+	_ = b.AddReader("d.txt", strings.NewReader("abcdef"), 0)
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	files := readBundle(t, out.Bytes())
+	if files["bundle/a.txt"] != "12345678" || files["bundle/c.txt"] != "ab" {
+		t.Fatalf("unexpected files: %v", files)
+	}
+	if _, ok := files["bundle/b.txt"]; ok {
+		t.Fatal("超出上限的文件应跳过")
+	}
+	if _, ok := files["bundle/d.txt"]; ok {
+		t.Fatal("已无剩余空间时应跳过")
+	}
+	if len(b.Errors()) != 2 {
+		t.Fatalf("unexpected errors: %v", b.Errors())
+	}
+}
+
+func TestParseGlobs(t *testing.T) {
+	globs, err := ParseGlobs("/var/log/*.log, /tmp/hs_err_pid*.log\n/data/[ab]?.txt")
+	if err != nil || len(globs) != 3 {
+		t.Fatalf("ParseGlobs = %v, %v", globs, err)
+	}
+	for _, bad := range []string{"var/log/*.log", "/tmp/$(id)", "/tmp/a b", "/tmp/a;rm", "/var/../etc/*", "/tmp/`x`"} {
+		if _, err := ParseGlobs(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	if _, err := ParseGlobs(strings.Repeat("/a,", MaxGlobs+1)); err == nil {
+		t.Error("expected error for too many globs")
+	}
+	if script := FileListScript(globs); !strings.HasPrefix(script, "for f in /var/log/*.log /tmp/hs_err_pid*.log /data/[ab]?.txt; do") {
+		t.Errorf("unexpected script: %s", script)
+	}
+}
+
+func TestParseFileList(t *testing.T) {
+	out := "5\t/var/log/a.log\n5\t/var/log/a.log\n500\t/var/log/big.log\n7\t/var/log/b.log\n9\t/var/log/c.log\ngarbage\n"
+	files, skipped := ParseFileList(out, 100, 2)
+	if strings.Join(files, ",") != "/var/log/a.log,/var/log/b.log" {
+		t.Fatalf("unexpected files: %v", files)
+	}
+	if len(skipped) != 2 || !strings.Contains(skipped[0], "big.log") || !strings.Contains(skipped[1], "c.log") {
+		t.Fatalf("unexpected skipped: %v", skipped)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	cases := map[string]string{
+		"--token=abc123 --v=2":                   "--token=****** --v=2",
+		`{"password": "p@ss", "user": "admin"}`:  `{"password": "****", "user": "admin"}`,
+		"db_password: hunter2":                   "db_password: *******",
+		"Authorization: Bearer eyJhbGciOiJIUzI1": "Authorization: Bearer ****************",
+		"dsn=mysql://root:secretpw@db:3306/k8m":  "dsn=mysql://root:********@db:3306/k8m",
+		"Started container nginx":                "Started container nginx",
+	}
+	for in, want := range cases {
+		if got := RedactSecrets(in); got != want {
+			t.Errorf("RedactSecrets(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package bundle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxGlobs 一次收集允许的匹配模式个数
+const MaxGlobs = 20
+
+// globPattern 容器内文件匹配模式允许的字符。不含空白、引号与 shell 元字符，可直接拼入 sh 脚本由 shell 展开
+var globPattern = regexp.MustCompile(`^/[A-Za-z0-9._/*?\[\]@%+=:,~-]*$`)
+
+// ParseGlobs 解析逗号或换行分隔的容器内文件匹配模式，如 /var/log/*.log、/tmp/hs_err_pid*.log
+func ParseGlobs(value string) ([]string, error) {
+	var globs []string
+	for _, g := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if g = strings.TrimSpace(g); g == "" {
+			continue
+		}
+		if !globPattern.MatchString(g) {
+			return nil, fmt.Errorf("文件匹配模式 %s 无效，须为绝对路径，只能包含字母、数字及 ._-/*?[] 等字符", g)
+		}
+		for _, seg := range strings.Split(g, "/") {
+			if seg == ".." {
+				return nil, fmt.Errorf("文件匹配模式 %s 不能包含 ..", g)
+			}
+		}
+		globs = append(globs, g)
+	}
+	if len(globs) > MaxGlobs {
+		return nil, fmt.Errorf("文件匹配模式最多 %d 个", MaxGlobs)
+	}
+	return globs, nil
+}
+
+// FileListScript 在容器中展开匹配模式的 sh 脚本，逐行输出普通文件（跟随链接）的 "大小<TAB>路径"。
+// globs 须经 ParseGlobs 校验
+func FileListScript(globs []string) string {
+	return "for f in " + strings.Join(globs, " ") +
+		`; do [ -f "$f" ] && s=$(stat -L -c %s "$f" 2>/dev/null) && printf '%s\t%s\n' "$s" "$f"; done; true`
+}
+
+// ParseFileList 解析 FileListScript 的输出，去重后返回不超过 maxFile 字节的文件，最多 maxCount 个；
+// 被跳过的文件附带原因一并返回
+func ParseFileList(out string, maxFile int64, maxCount int) (files []string, skipped []string) {
+	seen := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		sizeText, file, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok || file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		size, err := strconv.ParseInt(sizeText, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case maxFile > 0 && size > maxFile:
+			skipped = append(skipped, fmt.Sprintf("%s: 文件大小 %d 字节超过单个文件上限 %d 字节，已跳过", file, size, maxFile))
+		case maxCount > 0 && len(files) >= maxCount:
+			skipped = append(skipped, fmt.Sprintf("%s: 匹配文件超过 %d 个，已跳过", file, maxCount))
+		default:
+			files = append(files, file)
+		}
+	}
+	return files, skipped
+}
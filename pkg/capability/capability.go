@@ -0,0 +1,113 @@
+// Package capability 定义按集群探测的能力与管理员设置的功能开关，合并为集群最终可用的功能，
+// 并给出依赖某项能力的集群接口，供中间件在集群不支持时提前返回明确的提示、前端隐藏对应功能。只做计算，不访问集群。
+package capability
+
+import (
+	"regexp"
+	"slices"
+)
+
+// 能力名称
+const (
+	Metrics        = "metrics"         // metrics-server，节点与 Pod 实时用量
+	GatewayAPI     = "gateway_api"     // Gateway API CRD
+	VolumeSnapshot = "volume_snapshot" // CSI 卷快照 CRD
+	OpenKruise     = "openkruise"      // OpenKruise CRD
+	Istio          = "istio"           // Istio CRD
+	Exec           = "exec"            // k8m 所用凭据可在 Pod 中执行命令，终端与文件管理依赖该能力
+)
+
+// 功能开关取值，未设置时按探测结果
+const (
+	ModeAuto     = ""
+	ModeEnabled  = "enabled"
+	ModeDisabled = "disabled"
+)
+
+// Definition 能力说明与依赖它的集群接口
+type Definition struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	re          *regexp.Regexp
+}
+
+const clusterPrefix = `^/k8s/cluster/[^/]+`
+
+// Definitions 支持探测的能力
+var Definitions = []*Definition{
+	{Name: Metrics, Label: "Metrics Server", Description: "节点、Pod 实时 CPU 与内存用量",
+		re: regexp.MustCompile(clusterPrefix + `/(node|pod)/top/`)},
+	{Name: GatewayAPI, Label: "Gateway API", Description: "Gateway、HTTPRoute 等资源管理",
+		re: regexp.MustCompile(clusterPrefix + `/plugins/gatewayapi/`)},
+	{Name: VolumeSnapshot, Label: "卷快照", Description: "VolumeSnapshot 备份与恢复"},
+	{Name: OpenKruise, Label: "OpenKruise", Description: "OpenKruise 工作负载管理",
+		re: regexp.MustCompile(clusterPrefix + `/plugins/openkruise/`)},
+	{Name: Istio, Label: "Istio", Description: "Istio 流量治理资源管理",
+		re: regexp.MustCompile(clusterPrefix + `/plugins/istio/`)},
+	{Name: Exec, Label: "容器执行", Description: "容器终端、执行命令、文件管理、节点 Shell",
+		re: regexp.MustCompile(clusterPrefix + `/(file/|pod/(exec-command|xterm)/|node/name/[^/]+/create_[a-z]+_shell$)`)},
+}
+
+// Known 是否为支持的能力
+func Known(name string) bool {
+	return slices.ContainsFunc(Definitions, func(d *Definition) bool { return d.Name == name })
+}
+
+// ValidMode 是否为有效的开关取值
+func ValidMode(mode string) bool {
+	return mode == ModeAuto || mode == ModeEnabled || mode == ModeDisabled
+}
+
+// ForPath 返回集群接口依赖的能力，不依赖任何能力时返回空
+func ForPath(path string) []string {
+	var names []string
+	for _, d := range Definitions {
+		if d.re != nil && d.re.MatchString(path) {
+			names = append(names, d.Name)
+		}
+	}
+	return names
+}
+
+// Probe 单项能力的探测结果
+type Probe struct {
+	Detected bool   `json:"detected"`
+	Detail   string `json:"detail,omitempty"` // 判断依据或探测失败的原因
+}
+
+// Capability 集群的一项能力
+type Capability struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Detected    bool   `json:"detected"` // 探测结果
+	Detail      string `json:"detail,omitempty"`
+	Mode        string `json:"mode,omitempty"` // 管理员设置的开关，为空表示按探测结果
+	Enabled     bool   `json:"enabled"`        // 最终是否可用
+}
+
+// Resolve 按定义顺序合并探测结果与功能开关，开关优先，可用于关闭已安装的功能或在探测不准时强制开启
+func Resolve(probes map[string]Probe, modes map[string]string) []*Capability {
+	result := make([]*Capability, 0, len(Definitions))
+	for _, d := range Definitions {
+		p := probes[d.Name]
+		c := &Capability{
+			Name:        d.Name,
+			Label:       d.Label,
+			Description: d.Description,
+			Detected:    p.Detected,
+			Detail:      p.Detail,
+			Mode:        modes[d.Name],
+			Enabled:     p.Detected,
+		}
+		switch c.Mode {
+		case ModeEnabled:
+			c.Enabled = true
+		case ModeDisabled:
+			c.Enabled = false
+		}
+		result = append(result, c)
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package capability
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestForPath(t *testing.T) {
+	cases := map[string][]string{
+		"/k8s/cluster/Y2x1c3Rlcg/pod/top/ns/default/list":                      {Metrics},
+		"/k8s/cluster/Y2x1c3Rlcg/node/top/list":                                {Metrics},
+		"/k8s/cluster/Y2x1c3Rlcg/plugins/gatewayapi/gateway_class/option_list": {GatewayAPI},
+		"/k8s/cluster/Y2x1c3Rlcg/file/batch-download":                          {Exec},
+		"/k8s/cluster/Y2x1c3Rlcg/pod/xterm/ns/default/pod_name/web-0":          {Exec},
+		"/k8s/cluster/Y2x1c3Rlcg/node/name/n1/create_node_shell":               {Exec},
+		"/k8s/cluster/Y2x1c3Rlcg/_/v1/ConfigMap/ns/default/name/file":          nil,
+		"/k8s/cluster/Y2x1c3Rlcg/pod/usage/ns/default/name/web-0":              nil,
+		"/admin/cluster/file/option_list":                                      nil,
+	}
+	for path, want := range cases {
+		if got := ForPath(path); !slices.Equal(got, want) {
+			t.Errorf("ForPath(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	probes := map[string]Probe{
+		Metrics:    {Detected: true, Detail: "metrics.k8s.io/v1beta1"},
+		GatewayAPI: {Detected: false},
+		Exec:       {Detected: true},
+	}
+	modes := map[string]string{Metrics: ModeDisabled, GatewayAPI: ModeEnabled}
+	got := map[string]*Capability{}
+	for _, c := range Resolve(probes, modes) {
+		got[c.Name] = c
+	}
+	if len(got) != len(Definitions) {
+		t.Fatalf("got %d capabilities", len(got))
+	}
+	if c := got[Metrics]; !c.Detected || c.Enabled || c.Mode != ModeDisabled {
+		t.Errorf("metrics = %+v", c)
+	}
+	if c := got[GatewayAPI]; c.Detected || !c.Enabled {
+		t.Errorf("gateway = %+v", c)
+	}
+	if c := got[Exec]; !c.Enabled || c.Mode != ModeAuto {
+		t.Errorf("exec = %+v", c)
+	}
+	if c := got[Istio]; c.Enabled {
+		t.Errorf("istio without probe = %+v", c)
+	}
+}
+
+func TestValid(t *testing.T) {
+	if !Known(VolumeSnapshot) || Known("gpu") {
+		t.Error("Known")
+	}
+	if !ValidMode("") || !ValidMode(ModeDisabled) || ValidMode("off") {
+		t.Error("ValidMode")
+	}
+}
@@ -1,15 +1,23 @@
 package cb
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/k8m/pkg/changeid"
 	"github.com/weibaohui/k8m/pkg/comm"
 	"github.com/weibaohui/k8m/pkg/constants"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/service"
 	"github.com/weibaohui/kom/kom"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 )
 
 func RegisterDefaultCallbacks(cluster *service.ClusterConfig) func() {
@@ -29,15 +37,27 @@ func RegisterDefaultCallbacks(cluster *service.ClusterConfig) func() {
 
 	deleteCallback := kom.Cluster(selectedCluster).Callback().Delete()
 	_ = deleteCallback.Before("*").Register("k8m:delete", handleDelete)
+	// 替换内置删除，支持通过上下文指定宽限期
+	_ = deleteCallback.Replace("kom:delete", deleteWithOptions)
 
 	updateCallback := kom.Cluster(selectedCluster).Callback().Update()
 	_ = updateCallback.Before("*").Register("k8m:update", handleUpdate)
+	// 替换内置更新，支持通过上下文指定预演
+	_ = updateCallback.Replace("kom:update", updateWithOptions)
 
 	patchCallback := kom.Cluster(selectedCluster).Callback().Patch()
 	_ = patchCallback.Before("*").Register("k8m:patch", handlePatch)
 
 	createCallback := kom.Cluster(selectedCluster).Callback().Create()
 	_ = createCallback.Before("*").Register("k8m:create", handleCreate)
+	// 替换内置创建，支持通过上下文指定预演
+	_ = createCallback.Replace("kom:create", createWithOptions)
+
+	// 经由 k8m 的写操作成功后，使对应资源的列表缓存失效
+	_ = deleteCallback.After("*").Register("k8m:delete-list-cache", handleInvalidateListCache)
+	_ = updateCallback.After("*").Register("k8m:update-list-cache", handleInvalidateListCache)
+	_ = patchCallback.After("*").Register("k8m:patch-list-cache", handleInvalidateListCache)
+	_ = createCallback.After("*").Register("k8m:create-list-cache", handleInvalidateListCache)
 
 	execCallback := kom.Cluster(selectedCluster).Callback().Exec()
 	_ = execCallback.Before("*").Register("k8m:pod-exec", handleExec)
@@ -48,9 +68,15 @@ func RegisterDefaultCallbacks(cluster *service.ClusterConfig) func() {
 	return nil
 }
 
+// handleInvalidateListCache 写操作完成后使该类资源的列表缓存失效
+func handleInvalidateListCache(k8s *kom.Kubectl) error {
+	service.ListCacheService().Invalidate(k8s.ID, k8s.Statement.GVK.Kind)
+	return nil
+}
+
 // handleCommonLogic 根据用户在指定集群上的角色和命名空间权限，校验其是否有执行指定 Kubernetes 操作（如读取、变更、Exec 等）的权限。
 // 平台管理员拥有所有权限，集群管理员拥有全部操作权限，特定操作（如 Exec、只读）需具备对应角色及命名空间权限。
-// 若为内部监听（如 node watch），则跳过权限校验。
+// 若为内部监听（如 node watch），则跳过权限校验。以用户身份发起的调用在校验前按用户、按集群限流。
 //
 // 参数：
 //
@@ -70,9 +96,137 @@ func handleCommonLogic(k8s *kom.Kubectl, action string) error {
 		nsList = append(nsList, ns)
 	}
 	name := stmt.Name
+	// 以用户身份发起的调用按用户、按集群限流
+	if err := service.RateLimitService().Wait(ctx, cluster); err != nil {
+		return err
+	}
 	return comm.CheckPermissionLogic(ctx, cluster, nsList, ns, name, action)
 }
-func saveLog2DB(k8s *kom.Kubectl, action string, err error) {
+
+// handlePolicy 在权限校验通过后评估平台准入策略，命中 deny 策略时返回错误，命中 warn 策略时返回警告。
+// 无用户信息的内部操作不受策略约束。
+func handlePolicy(k8s *kom.Kubectl, action string) ([]string, error) {
+	stmt := k8s.Statement
+	username, _ := stmt.Context.Value(constants.JwtUserName).(string)
+	if username == "" {
+		return nil, nil
+	}
+	req := &admission.Request{
+		Cluster:   k8s.ID,
+		User:      username,
+		Operation: action,
+		Group:     stmt.GVK.Group,
+		Version:   stmt.GVK.Version,
+		Kind:      stmt.GVK.Kind,
+		Namespace: stmt.Namespace,
+		Name:      stmt.Name,
+	}
+	if action != "delete" && stmt.Dest != nil {
+		if bs, err := json.Marshal(stmt.Dest); err == nil {
+			_ = json.Unmarshal(bs, &req.Object)
+		}
+		if req.Name == "" {
+			req.Name = admissionObjectName(req.Object)
+		}
+	}
+	if stmt.PatchData != "" {
+		_ = json.Unmarshal([]byte(stmt.PatchData), &req.Patch)
+	}
+	return service.AdmissionPolicyService().Evaluate(req)
+}
+
+func admissionObjectName(obj map[string]any) string {
+	meta, _ := obj["metadata"].(map[string]any)
+	name, _ := meta["name"].(string)
+	return name
+}
+
+// handleApproval 命中变更审批规则时将本次操作转为待审批的变更申请，并阻止直接执行
+func handleApproval(k8s *kom.Kubectl, action string) error {
+	stmt := k8s.Statement
+	op := &service.ChangeOperation{
+		Cluster:   k8s.ID,
+		Group:     stmt.GVK.Group,
+		Version:   stmt.GVK.Version,
+		Kind:      stmt.GVK.Kind,
+		Namespace: stmt.Namespace,
+		Name:      stmt.Name,
+		Operation: action,
+		Force:     stmt.ForceDelete,
+	}
+	switch action {
+	case "patch":
+		op.PatchType, op.Payload = string(stmt.PatchType), stmt.PatchData
+	case "create", "update":
+		var obj map[string]any
+		if bs, err := json.Marshal(stmt.Dest); err == nil {
+			_ = json.Unmarshal(bs, &obj)
+		}
+		if obj == nil {
+			return nil
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		u.SetGroupVersionKind(stmt.GVK)
+		if op.Namespace == "" {
+			op.Namespace = u.GetNamespace()
+		}
+		if op.Name == "" {
+			op.Name = u.GetName()
+		}
+		bs, _ := json.Marshal(u.Object)
+		op.Payload = string(bs)
+	}
+	return service.ApprovalService().Intercept(stmt.Context, op)
+}
+
+// handleWrite 写操作的通用处理：只读模式检查、权限校验、平台准入策略评估、变更审批与操作日志
+func handleWrite(k8s *kom.Kubectl, action string) error {
+	err := service.ReadOnlyService().Check(k8s.ID)
+	if err == nil {
+		err = handleCommonLogic(k8s, action)
+	}
+	var warnings []string
+	if err == nil {
+		warnings, err = handlePolicy(k8s, action)
+	}
+	// 预演只校验权限与策略，不进入审批，不写入变更ID与操作日志
+	if isDryRun(k8s) {
+		return err
+	}
+	if err == nil {
+		err = handleApproval(k8s, action)
+	}
+	var changeID string
+	if err == nil {
+		changeID = handleChangeID(k8s, action)
+	}
+	saveLog2DB(k8s, action, err, changeID, warnings...)
+	return err
+}
+
+// handleChangeID 在用户创建、更新、补丁的资源上写入变更ID注解，之后的事件与发布可据此关联到本次变更。
+// 无用户信息的内部操作与无法写入注解的补丁类型不记录
+func handleChangeID(k8s *kom.Kubectl, action string) string {
+	stmt := k8s.Statement
+	if username, _ := stmt.Context.Value(constants.JwtUserName).(string); username == "" {
+		return ""
+	}
+	id := changeid.New()
+	switch action {
+	case "create", "update":
+		if changeid.Annotate(stmt.Dest, id) {
+			return id
+		}
+	case "patch":
+		if data, ok := changeid.AnnotatePatch(stmt.PatchType, stmt.PatchData, id); ok {
+			stmt.PatchData = data
+			return id
+		}
+	}
+	return ""
+}
+
+func saveLog2DB(k8s *kom.Kubectl, action string, err error, changeID string, warnings ...string) {
 	stmt := k8s.Statement
 	cluster := k8s.ID
 	ctx := stmt.Context
@@ -90,6 +244,8 @@ func saveLog2DB(k8s *kom.Kubectl, action string, err error) {
 		Group:        stmt.GVK.Group,
 		Role:         strings.Join(roles, ","),
 		ActionResult: "success",
+		PolicyWarn:   strings.Join(warnings, "\n"),
+		ChangeID:     changeID,
 	}
 
 	if err != nil {
@@ -103,32 +259,156 @@ func saveLog2DB(k8s *kom.Kubectl, action string, err error) {
 	service.OperationLogService().Add(&log)
 
 }
+
+// isDryRun 上下文中是否指定了预演
+func isDryRun(k8s *kom.Kubectl) bool {
+	dryRun, _ := k8s.Statement.Context.Value(constants.DryRun).(bool)
+	return dryRun
+}
+
+// createWithOptions 与内置创建一致，上下文中指定预演时以 dryRun=All 提交
+func createWithOptions(k8s *kom.Kubectl) error {
+	stmt := k8s.Statement
+	obj, ri, err := writeTarget(k8s)
+	if err != nil {
+		return err
+	}
+	opts := metav1.CreateOptions{}
+	if isDryRun(k8s) {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	if _, err := ri.Create(stmt.Context, obj, opts); err != nil {
+		return err
+	}
+	stmt.RowsAffected = 1
+	return nil
+}
+
+// updateWithOptions 与内置更新一致，上下文中指定预演时以 dryRun=All 提交，预演结果不回写 Dest
+func updateWithOptions(k8s *kom.Kubectl) error {
+	stmt := k8s.Statement
+	obj, ri, err := writeTarget(k8s)
+	if err != nil {
+		return err
+	}
+	opts := metav1.UpdateOptions{}
+	if isDryRun(k8s) {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	res, err := ri.Update(stmt.Context, obj, opts)
+	if err != nil {
+		return err
+	}
+	stmt.RowsAffected = 1
+	if isDryRun(k8s) {
+		return nil
+	}
+	if stmt.RemoveManagedFields {
+		res.SetManagedFields(nil)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, stmt.Dest)
+}
+
+// writeTarget 将 Dest 转换为待提交的对象，命名空间级资源未指定命名空间时使用 default
+func writeTarget(k8s *kom.Kubectl) (*unstructured.Unstructured, dynamic.ResourceInterface, error) {
+	stmt := k8s.Statement
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(stmt.Dest)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := &unstructured.Unstructured{Object: data}
+	if !stmt.Namespaced {
+		return obj, k8s.DynamicClient().Resource(stmt.GVR), nil
+	}
+	ns := stmt.Namespace
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	obj.SetNamespace(ns)
+	return obj, k8s.DynamicClient().Resource(stmt.GVR).Namespace(ns), nil
+}
+
+// deleteWithOptions 与内置删除一致，上下文中指定了宽限期或级联策略时以其覆盖默认值
+func deleteWithOptions(k8s *kom.Kubectl) error {
+	stmt := k8s.Statement
+	if stmt.Name == "" {
+		return fmt.Errorf("删除对象必须指定名称")
+	}
+	opts := metav1.DeleteOptions{}
+	if stmt.ForceDelete {
+		background := metav1.DeletePropagationBackground
+		opts.PropagationPolicy = &background
+		opts.GracePeriodSeconds = ptr.To(int64(0))
+	}
+	if grace, ok := stmt.Context.Value(constants.DeleteGracePeriod).(int64); ok {
+		opts.GracePeriodSeconds = ptr.To(grace)
+	}
+	if propagation, ok := stmt.Context.Value(constants.DeletePropagation).(metav1.DeletionPropagation); ok {
+		opts.PropagationPolicy = ptr.To(propagation)
+	}
+	var err error
+	if stmt.Namespaced {
+		ns := stmt.Namespace
+		if ns == "" {
+			ns = metav1.NamespaceDefault
+		}
+		err = k8s.DynamicClient().Resource(stmt.GVR).Namespace(ns).Delete(stmt.Context, stmt.Name, opts)
+	} else {
+		err = k8s.DynamicClient().Resource(stmt.GVR).Delete(stmt.Context, stmt.Name, opts)
+	}
+	if err != nil {
+		return err
+	}
+	stmt.RowsAffected = 1
+	return nil
+}
+
 func handleDelete(k8s *kom.Kubectl) error {
-	err := handleCommonLogic(k8s, "delete")
-	saveLog2DB(k8s, "delete", err)
-	return err
+	return handleWrite(k8s, "delete")
 }
 
 func handleUpdate(k8s *kom.Kubectl) error {
-	err := handleCommonLogic(k8s, "update")
-	saveLog2DB(k8s, "update", err)
-	return err
+	return handleWrite(k8s, "update")
 }
 
 func handlePatch(k8s *kom.Kubectl) error {
-	err := handleCommonLogic(k8s, "patch")
-	saveLog2DB(k8s, "patch", err)
-	return err
+	return handleWrite(k8s, "patch")
 }
 
 func handleCreate(k8s *kom.Kubectl) error {
-	err := handleCommonLogic(k8s, "create")
-	saveLog2DB(k8s, "create", err)
-	return err
+	return handleWrite(k8s, "create")
 }
 func handleExec(k8s *kom.Kubectl) error {
-	err := handleCommonLogic(k8s, "exec")
-	saveLog2DB(k8s, "exec", err)
+	err := service.ReadOnlyService().CheckExec(k8s.Statement.Context, k8s.ID)
+	if err == nil {
+		err = handleCommonLogic(k8s, "exec")
+	}
+	if err == nil {
+		if err = handleCommandPolicy(k8s); err != nil {
+			return err
+		}
+	}
+	saveLog2DB(k8s, "exec", err, "")
+	return err
+}
+
+// handleCommandPolicy 校验容器命令策略，拦截记录写入操作日志。
+// 交互式终端启动的是固定的 shell，输入的命令由终端自行校验。
+func handleCommandPolicy(k8s *kom.Kubectl) error {
+	stmt := k8s.Statement
+	scope, _ := stmt.Context.Value(constants.CommandScope).(string)
+	if scope == constants.CommandScopeTerminal {
+		return nil
+	}
+	if scope == "" {
+		scope = constants.CommandScopeExec
+	}
+	cmdline := strings.Join(append([]string{stmt.Command}, stmt.Args...), " ")
+	err := service.CommandPolicyService().Check(k8s.ID, scope, cmdline)
+	if err != nil {
+		username, _ := stmt.Context.Value(constants.JwtUserName).(string)
+		service.CommandPolicyService().RecordViolation(k8s.ID, stmt.Namespace, stmt.Name, stmt.ContainerName, username, scope, cmdline, err)
+	}
 	return err
 }
 
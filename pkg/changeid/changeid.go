@@ -0,0 +1,82 @@
+// Package changeid 为经由 k8m 的变更生成变更ID，并写入资源注解。Deployment 的注解会被复制到新建的 ReplicaSet，
+// 之后的事件、发布可沿属主链找回引起它们的变更。只做计算，不访问集群。
+package changeid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Annotation 记录最近一次经由 k8m 变更的ID
+const Annotation = "k8m.io/change-id"
+
+// New 生成变更ID，形如 20260102150405-a1b2c3，按时间有序
+func New() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return time.Now().Format("20060102150405") + "-" + hex.EncodeToString(b)
+}
+
+// Get 读取对象上的变更ID
+func Get(obj metav1.Object) string {
+	return obj.GetAnnotations()[Annotation]
+}
+
+// Annotate 在创建、更新的对象上写入变更ID。dest 可以是对象指针或指向对象指针的指针，
+// 无法识别为 Kubernetes 对象时返回 false
+func Annotate(dest any, id string) bool {
+	v := reflect.ValueOf(dest)
+	for v.IsValid() && v.Kind() == reflect.Ptr && !v.IsNil() {
+		if obj, ok := v.Interface().(metav1.Object); ok {
+			ann := obj.GetAnnotations()
+			if ann == nil {
+				ann = map[string]string{}
+			}
+			ann[Annotation] = id
+			obj.SetAnnotations(ann)
+			return true
+		}
+		v = v.Elem()
+	}
+	return false
+}
+
+// AnnotatePatch 在 merge、strategic merge 补丁中加入变更ID注解。
+// JSON Patch 与 Apply 补丁不修改；补丁要清空全部注解时也不修改，避免改变补丁语义
+func AnnotatePatch(pt types.PatchType, data, id string) (string, bool) {
+	if pt != types.MergePatchType && pt != types.StrategicMergePatchType {
+		return data, false
+	}
+	var patch map[string]any
+	if err := json.Unmarshal([]byte(data), &patch); err != nil || patch == nil {
+		return data, false
+	}
+	metadata, ok := patch["metadata"].(map[string]any)
+	if !ok {
+		if _, exists := patch["metadata"]; exists {
+			return data, false
+		}
+		metadata = map[string]any{}
+		patch["metadata"] = metadata
+	}
+	annotations, ok := metadata["annotations"].(map[string]any)
+	if !ok {
+		if _, exists := metadata["annotations"]; exists {
+			return data, false
+		}
+		annotations = map[string]any{}
+		metadata["annotations"] = annotations
+	}
+	annotations[Annotation] = id
+	bs, err := json.Marshal(patch)
+	if err != nil {
+		return data, false
+	}
+	return string(bs), true
+}
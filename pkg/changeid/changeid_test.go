@@ -0,0 +1,66 @@
+package changeid
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNew(t *testing.T) {
+	if id := New(); !regexp.MustCompile(`^\d{14}-[0-9a-f]{6}$`).MatchString(id) {
+		t.Errorf("id = %s", id)
+	}
+	if New() == New() {
+		t.Error("ids should differ")
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]any{"metadata": map[string]any{"name": "a"}}}
+	if !Annotate(&u, "c1") || Get(u) != "c1" {
+		t.Errorf("pointer to pointer: %v", u.Object)
+	}
+	d := &appsv1.Deployment{}
+	d.Annotations = map[string]string{"keep": "yes"}
+	if !Annotate(d, "c2") || Get(d) != "c2" || d.Annotations["keep"] != "yes" {
+		t.Errorf("typed: %v", d.Annotations)
+	}
+	var raw []byte
+	if Annotate(&raw, "c3") || Annotate(nil, "c3") {
+		t.Error("non-object should not be annotated")
+	}
+}
+
+func TestAnnotatePatch(t *testing.T) {
+	out, ok := AnnotatePatch(types.MergePatchType, `{"spec":{"replicas":0},"metadata":{"annotations":{"x":null}}}`, "c1")
+	var m map[string]any
+	if !ok || json.Unmarshal([]byte(out), &m) != nil {
+		t.Fatalf("out = %s", out)
+	}
+	ann := m["metadata"].(map[string]any)["annotations"].(map[string]any)
+	if ann[Annotation] != "c1" || ann["x"] != nil || m["spec"].(map[string]any)["replicas"] != float64(0) {
+		t.Errorf("patch = %s", out)
+	}
+
+	out, ok = AnnotatePatch(types.StrategicMergePatchType, `{"spec":{}}`, "c2")
+	if !ok || out != `{"metadata":{"annotations":{"k8m.io/change-id":"c2"}},"spec":{}}` {
+		t.Errorf("strategic = %s", out)
+	}
+
+	for _, tc := range []struct {
+		pt   types.PatchType
+		data string
+	}{
+		{types.JSONPatchType, `[{"op":"replace","path":"/spec/replicas","value":1}]`},
+		{types.MergePatchType, `{"metadata":{"annotations":null}}`},
+		{types.MergePatchType, `not json`},
+	} {
+		if out, ok := AnnotatePatch(tc.pt, tc.data, "c"); ok || out != tc.data {
+			t.Errorf("%s %s should be unchanged, got %s", tc.pt, tc.data, out)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package clockskew 估算本机与其他时间源（集群 apiserver、NTP 服务器）之间的时钟偏差。
+// 偏差为对方时间减去本机时间，为正表示本机时间落后。
+package clockskew
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ntpEpochOffset NTP 纪元（1900 年）与 Unix 纪元之间的秒数
+const ntpEpochOffset = 2208988800
+
+const ntpPacketSize = 48
+
+// HTTPDateOffset 根据 HTTP 响应的 Date 头估算偏差，以请求往返的中点作为对方生成响应的时刻。
+// Date 头只精确到秒，结果误差在一秒左右
+func HTTPDateOffset(date string, sent, received time.Time) (time.Duration, error) {
+	if date == "" {
+		return 0, fmt.Errorf("响应中没有 Date 头")
+	}
+	remote, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("解析 Date 头 %q 失败: %w", date, err)
+	}
+	mid := sent.Add(received.Sub(sent) / 2)
+	// Date 头截断到秒，补半秒使估算居中
+	return remote.Add(500 * time.Millisecond).Sub(mid), nil
+}
+
+// NTPResult 一次 SNTP 查询的结果
+type NTPResult struct {
+	Offset  time.Duration // 服务器时间减去本机时间
+	RTT     time.Duration // 网络往返时间
+	Stratum uint8
+}
+
+// QueryNTP 向 NTP 服务器发送 SNTP 请求，server 未带端口时使用 123
+func QueryNTP(ctx context.Context, server string) (*NTPResult, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	sent := time.Now()
+	if _, err := conn.Write(ntpRequest(sent)); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, ntpPacketSize)
+	n, err := conn.Read(buf)
+	received := time.Now()
+	if err != nil {
+		return nil, err
+	}
+	return parseNTPResponse(buf[:n], sent, received)
+}
+
+// ntpRequest 构造客户端请求：版本 4、模式 3，发送时间写入 Transmit Timestamp
+func ntpRequest(sent time.Time) []byte {
+	b := make([]byte, ntpPacketSize)
+	b[0] = 4<<3 | 3
+	binary.BigEndian.PutUint64(b[40:], toNTPTime(sent))
+	return b
+}
+
+// parseNTPResponse 按 RFC 4330 计算偏差与往返时间：
+// offset = ((T2 - T1) + (T3 - T4)) / 2，rtt = (T4 - T1) - (T3 - T2)
+func parseNTPResponse(b []byte, sent, received time.Time) (*NTPResult, error) {
+	if len(b) < ntpPacketSize {
+		return nil, fmt.Errorf("NTP 响应长度 %d 不足", len(b))
+	}
+	if mode := b[0] & 0x7; mode != 4 {
+		return nil, fmt.Errorf("NTP 响应模式 %d 无效", mode)
+	}
+	if b[0]>>6 == 3 {
+		return nil, fmt.Errorf("NTP 服务器时钟未同步")
+	}
+	stratum := b[1]
+	if stratum == 0 {
+		return nil, fmt.Errorf("NTP 服务器拒绝请求（Kiss-o'-Death %q）", string(b[12:16]))
+	}
+	// 服务器应原样返回请求中的发送时间，不一致说明不是本次请求的响应
+	if binary.BigEndian.Uint64(b[24:]) != toNTPTime(sent) {
+		return nil, fmt.Errorf("NTP 响应与请求不匹配")
+	}
+	t2 := fromNTPTime(binary.BigEndian.Uint64(b[32:]))
+	t3 := fromNTPTime(binary.BigEndian.Uint64(b[40:]))
+	offset := (t2.Sub(sent) + t3.Sub(received)) / 2
+	rtt := received.Sub(sent) - t3.Sub(t2)
+	return &NTPResult{Offset: offset, RTT: max(rtt, 0), Stratum: stratum}, nil
+}
+
+// toNTPTime 转换为 NTP 64 位时间戳：高 32 位为秒，低 32 位为秒的小数部分
+func toNTPTime(t time.Time) uint64 {
+	nsec := uint64(t.Sub(time.Unix(-ntpEpochOffset, 0)))
+	sec := nsec / uint64(time.Second)
+	frac := (nsec % uint64(time.Second)) << 32 / uint64(time.Second)
+	return sec<<32 | frac
+}
+
+func fromNTPTime(v uint64) time.Time {
+	sec := int64(v >> 32)
+	nsec := int64((v & 0xffffffff) * uint64(time.Second) >> 32)
+	return time.Unix(sec-ntpEpochOffset, nsec)
+}
@@ -0,0 +1,73 @@
+package clockskew
+
+import (
+	"encoding/binary"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNTPTimeRoundTrip(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 30, 15, 123456789, time.UTC)
+	got := fromNTPTime(toNTPTime(now))
+	if d := got.Sub(now); d < -time.Microsecond || d > time.Microsecond {
+		t.Fatalf("round trip drift %v", d)
+	}
+	if sec := toNTPTime(time.Unix(0, 0)) >> 32; sec != ntpEpochOffset {
+		t.Fatalf("unix epoch = %d NTP seconds, want %d", sec, ntpEpochOffset)
+	}
+}
+
+func ntpResponse(sent, t2, t3 time.Time, stratum uint8) []byte {
+	b := make([]byte, ntpPacketSize)
+	b[0] = 4<<3 | 4
+	b[1] = stratum
+	binary.BigEndian.PutUint64(b[24:], toNTPTime(sent))
+	binary.BigEndian.PutUint64(b[32:], toNTPTime(t2))
+	binary.BigEndian.PutUint64(b[40:], toNTPTime(t3))
+	return b
+}
+
+func TestParseNTPResponse(t *testing.T) {
+	sent := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	received := sent.Add(100 * time.Millisecond)
+	// 服务器快 5 秒，单程 40ms，处理 20ms
+	t2 := sent.Add(5*time.Second + 40*time.Millisecond)
+	t3 := t2.Add(20 * time.Millisecond)
+	r, err := parseNTPResponse(ntpResponse(sent, t2, t3, 2), sent, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := r.Offset - 5*time.Second; d < -time.Millisecond || d > time.Millisecond {
+		t.Fatalf("offset = %v, want 5s", r.Offset)
+	}
+	if d := r.RTT - 80*time.Millisecond; d < -time.Millisecond || d > time.Millisecond {
+		t.Fatalf("rtt = %v, want 80ms", r.RTT)
+	}
+
+	if _, err := parseNTPResponse(ntpResponse(sent, t2, t3, 0), sent, received); err == nil {
+		t.Fatal("stratum 0 should be rejected")
+	}
+	if _, err := parseNTPResponse(ntpResponse(sent.Add(time.Second), t2, t3, 2), sent, received); err == nil {
+		t.Fatal("mismatched origin timestamp should be rejected")
+	}
+	if _, err := parseNTPResponse(make([]byte, 10), sent, received); err == nil {
+		t.Fatal("short packet should be rejected")
+	}
+}
+
+func TestHTTPDateOffset(t *testing.T) {
+	sent := time.Date(2026, 3, 1, 8, 0, 0, 200_000_000, time.UTC)
+	received := sent.Add(200 * time.Millisecond)
+	date := sent.Add(-2 * time.Minute).Format(http.TimeFormat)
+	offset, err := HTTPDateOffset(date, sent, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := offset + 2*time.Minute; d < -time.Second || d > time.Second {
+		t.Fatalf("offset = %v, want about -2m", offset)
+	}
+	if _, err := HTTPDateOffset("", sent, received); err == nil {
+		t.Fatal("empty Date header should be rejected")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/duke-git/lancet/v2/slice"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/i18n"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/service"
 	"k8s.io/klog/v2"
@@ -26,7 +27,7 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 	username := fmt.Sprintf("%s", ctx.Value(constants.JwtUserName))
 
 	if username == "" {
-		return fmt.Errorf("用户为空%v，默认阻止", nil)
+		return i18n.NewError(i18n.CodePermissionNoUser)
 	}
 	var err error
 	// 先看是不是平台管理员
@@ -39,16 +40,16 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 
 	if err != nil || len(clusterUserRoles) == 0 {
 		// 没有集群权限，报错
-		return fmt.Errorf("用户[%s]获取集群授权错误，默认阻止", username)
+		return i18n.NewError(i18n.CodePermissionLookupFailed, username)
 	}
 
 	if clusterUserRoles != nil && len(clusterUserRoles) == 0 {
-		return fmt.Errorf("用户[%s]没有集群授权", username)
+		return i18n.NewError(i18n.CodePermissionNoCluster, username)
 	}
 	if _, ok := slice.FindBy(clusterUserRoles, func(index int, item *models.ClusterUserRole) bool {
 		return item.Cluster == cluster
 	}); !ok {
-		return fmt.Errorf("用户[%s]没有集群[%s]访问权限", username, cluster)
+		return i18n.NewError(i18n.CodePermissionCluster, username, cluster)
 	}
 
 	// 下面都是有集群的访问权限的情况，需要进一步区分是什么类型的操作。
@@ -72,13 +73,13 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 				return item.Cluster == cluster && item.Role == constants.RoleClusterReadonly
 			})
 			if len(rdOnlyClusters) == 0 {
-				return fmt.Errorf("用户[%s]没有集群[%s] 只读权限", username, cluster)
+				return i18n.NewError(i18n.CodePermissionReadonly, username, cluster)
 			}
 			execClusters := slice.Filter(clusterUserRoles, func(index int, item *models.ClusterUserRole) bool {
 				return item.Cluster == cluster && item.Role == constants.RoleClusterPodExec
 			})
 			if len(execClusters) == 0 {
-				return fmt.Errorf("用户[%s]没有集群[%s] Exec权限", username, cluster)
+				return i18n.NewError(i18n.CodePermissionExec, username, cluster)
 			}
 			if len(nsList) > 0 {
 				// 具备只读+Exec权限了，那么继续看是否有该ns的权限.
@@ -89,13 +90,13 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 					return item.BlacklistNamespaces != "" && utils.AnyIn(nsList, strings.Split(item.BlacklistNamespaces, ","))
 				})
 				if len(execClustersWithBNs) > 0 {
-					return fmt.Errorf("用户[%s]没有集群[%s] [%s] Exec权限-进入命名空间黑名单", username, cluster, strings.Join(nsList, ","))
+					return i18n.NewError(i18n.CodePermissionExecNsBlack, username, cluster, strings.Join(nsList, ","))
 				}
 				execClustersWithNs := slice.Filter(execClusters, func(index int, item *models.ClusterUserRole) bool {
 					return item.Namespaces == "" || utils.AllIn(nsList, strings.Split(item.Namespaces, ","))
 				})
 				if len(execClustersWithNs) == 0 {
-					return fmt.Errorf("用户[%s]没有集群[%s] [%s] Exec权限-不在命名空间白名单", username, cluster, strings.Join(nsList, ","))
+					return i18n.NewError(i18n.CodePermissionExecNsWhite, username, cluster, strings.Join(nsList, ","))
 				}
 			}
 		} else {
@@ -107,7 +108,7 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 					return item.BlacklistNamespaces != "" && utils.AnyIn(nsList, strings.Split(item.BlacklistNamespaces, ","))
 				})
 				if len(execClustersWithBNs) > 0 {
-					return fmt.Errorf("用户[%s]没有集群[%s] [%s] Exec权限-进入命名空间黑名单", username, cluster, strings.Join(nsList, ","))
+					return i18n.NewError(i18n.CodePermissionExecNsBlack, username, cluster, strings.Join(nsList, ","))
 				}
 
 				// ns为空，或者ns列表中含有当前ns，那么就允许执行。
@@ -115,7 +116,7 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 					return item.Namespaces == "" || utils.AllIn(nsList, strings.Split(item.Namespaces, ","))
 				})
 				if len(execClustersWithNs) == 0 {
-					return fmt.Errorf("用户[%s]没有集群[%s] [%s] Exec权限-不在命名空间白名单", username, cluster, strings.Join(nsList, ","))
+					return i18n.NewError(i18n.CodePermissionExecNsWhite, username, cluster, strings.Join(nsList, ","))
 				}
 			}
 		}
@@ -125,7 +126,7 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 			return item.Cluster == cluster && item.Role == constants.RoleClusterAdmin
 		})
 		if len(changeClusters) == 0 {
-			return fmt.Errorf("用户[%s]没有集群[%s] 操作权限", username, cluster)
+			return i18n.NewError(i18n.CodePermissionWrite, username, cluster)
 		}
 		if len(nsList) > 0 {
 			// 具备操作权限了，那么继续看是否有该ns的权限.
@@ -136,14 +137,14 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 				return item.BlacklistNamespaces != "" && utils.AnyIn(nsList, strings.Split(item.BlacklistNamespaces, ","))
 			})
 			if len(execClustersWithBNs) > 0 {
-				return fmt.Errorf("用户[%s]没有集群[%s] [%s] 操作权限-进入命名空间黑名单", username, cluster, strings.Join(nsList, ","))
+				return i18n.NewError(i18n.CodePermissionWriteNsBlack, username, cluster, strings.Join(nsList, ","))
 			}
 
 			changeClustersWithNs := slice.Filter(changeClusters, func(index int, item *models.ClusterUserRole) bool {
 				return item.Namespaces == "" || utils.AllIn(nsList, strings.Split(item.Namespaces, ","))
 			})
 			if len(changeClustersWithNs) == 0 {
-				return fmt.Errorf("用户[%s]没有集群[%s] [%s] 操作权限-不在命名空间白名单", username, cluster, strings.Join(nsList, ","))
+				return i18n.NewError(i18n.CodePermissionWriteNsWhite, username, cluster, strings.Join(nsList, ","))
 			}
 		}
 	default:
@@ -154,7 +155,7 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 			return item.Cluster == cluster && (item.Role == constants.RoleClusterReadonly || item.Role == constants.RoleClusterAdmin)
 		})
 		if len(readClusters) == 0 {
-			return fmt.Errorf("用户[%s]没有集群[%s] 读取/管理员 权限", username, cluster)
+			return i18n.NewError(i18n.CodePermissionRead, username, cluster)
 		}
 		if len(nsList) > 0 {
 			// 具备操作权限了，那么继续看是否有该ns的权限.
@@ -165,14 +166,14 @@ func CheckPermissionLogic(ctx context.Context, cluster string, nsList []string,
 				return item.BlacklistNamespaces != "" && utils.AnyIn(nsList, strings.Split(item.BlacklistNamespaces, ","))
 			})
 			if len(execClustersWithBNs) > 0 {
-				return fmt.Errorf("用户[%s]没有集群[%s] [%s] 读取权限-进入命名空间黑名单", username, cluster, strings.Join(nsList, ","))
+				return i18n.NewError(i18n.CodePermissionReadNsBlack, username, cluster, strings.Join(nsList, ","))
 			}
 
 			readClustersWithNs := slice.Filter(readClusters, func(index int, item *models.ClusterUserRole) bool {
 				return item.Namespaces == "" || utils.AllIn(nsList, strings.Split(item.Namespaces, ","))
 			})
 			if len(readClustersWithNs) == 0 {
-				return fmt.Errorf("用户[%s]没有集群[%s] [%s] 读取权限-不在命名空间白名单", username, cluster, strings.Join(nsList, ","))
+				return i18n.NewError(i18n.CodePermissionReadNsWhite, username, cluster, strings.Join(nsList, ","))
 			}
 		}
 	}
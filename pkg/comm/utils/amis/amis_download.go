@@ -0,0 +1,48 @@
+package amis
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/response"
+)
+
+// WriteDownload 以附件形式输出内容，支持 Range、If-Range 断点续传。
+// etag 需在内容变化时随之变化，为空时不支持 If-Range 校验；modtime 为零值时不输出 Last-Modified。
+func WriteDownload(c *response.Context, fileName, contentType, etag string, modtime time.Time, content io.ReadSeeker) {
+	c.Header("Content-Disposition", "attachment; filename="+fileName)
+	serveContent(c, fileName, contentType, etag, modtime, content)
+}
+
+// WriteInline 在浏览器中直接展示内容，同样支持断点续传。
+// contentType 应为 utils.InlineContentType 处理后的安全类型，并禁用嗅探、以沙箱方式展示，防止内容中的脚本在 k8m 页面域下执行。
+func WriteInline(c *response.Context, fileName, contentType, etag string, modtime time.Time, content io.ReadSeeker) {
+	c.Header("Content-Disposition", "inline; filename="+fileName)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "sandbox")
+	serveContent(c, fileName, contentType, etag, modtime, content)
+}
+
+func serveContent(c *response.Context, fileName, contentType, etag string, modtime time.Time, content io.ReadSeeker) {
+	// 显式设置类型，避免 ServeContent 为探测类型额外读取内容
+	c.Header("Content-Type", contentType)
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	http.ServeContent(c.Writer, c.Request, fileName, modtime, content)
+}
+
+// WriteDownloadData 以附件形式输出内存中的内容，按内容摘要生成 ETag，支持断点续传
+func WriteDownloadData(c *response.Context, fileName, contentType string, data []byte) {
+	WriteDownload(c, fileName, contentType, ContentETag(data), time.Time{}, bytes.NewReader(data))
+}
+
+// ContentETag 按内容摘要生成 ETag
+func ContentETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
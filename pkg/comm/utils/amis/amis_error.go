@@ -0,0 +1,107 @@
+package amis
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/ratelimit"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// Error 统一的错误响应模型。status 固定为 1 以兼容 amis；code 为稳定的错误码，调用方应按 code 分支处理而不是匹配 msg
+type Error struct {
+	Status    int           `json:"status"`
+	Code      i18n.Code     `json:"code"`
+	Msg       string        `json:"msg"`
+	Details   *ErrorDetails `json:"details,omitempty"`
+	RequestID string        `json:"request_id"`
+}
+
+// ErrorDetails 错误详情，目前来自 API Server 返回的 Status
+type ErrorDetails struct {
+	Reason            string   `json:"reason,omitempty"`
+	Group             string   `json:"group,omitempty"`
+	Kind              string   `json:"kind,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	Causes            []string `json:"causes,omitempty"`
+	RetryAfterSeconds int32    `json:"retry_after_seconds,omitempty"`
+}
+
+// NewError 根据错误生成错误响应：带错误码的错误按请求语言翻译，
+// API Server 错误按 Forbidden、NotFound、Timeout 等原因映射为稳定的错误码并附带详情，其余为 unknown
+func NewError(r *http.Request, err error) *Error {
+	code, msg := i18n.Localize(err, i18n.LangFromRequest(r))
+	result := &Error{
+		Status:    1,
+		Code:      code,
+		Msg:       msg,
+		RequestID: response.RequestID(r.Context()),
+	}
+	if code == i18n.CodeUnknown {
+		result.Code, result.Details = classifyError(err)
+	}
+	return result
+}
+
+// WriteError 以指定 HTTP 状态码输出错误响应，限流错误固定返回 429 并通过 Retry-After 告知等待的秒数
+func WriteError(c *response.Context, httpStatus int, err error) {
+	telemetry.RecordAPIError(c.Request)
+	klog.FromContext(c.Request.Context()).V(6).Info("api error", "path", c.Request.URL.Path, "err", err.Error())
+	result := NewError(c.Request, err)
+	if delay, ok := ratelimit.RetryAfter(err); ok {
+		seconds := max(int(math.Ceil(delay.Seconds())), 1)
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		result.Details = &ErrorDetails{RetryAfterSeconds: int32(seconds)}
+		httpStatus = http.StatusTooManyRequests
+	}
+	c.JSON(httpStatus, result)
+}
+
+// classifyError 识别 API Server 与网络错误
+func classifyError(err error) (i18n.Code, *ErrorDetails) {
+	var details *ErrorDetails
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		s := status.Status()
+		details = &ErrorDetails{Reason: string(s.Reason)}
+		if d := s.Details; d != nil {
+			details.Group, details.Kind, details.Name = d.Group, d.Kind, d.Name
+			details.RetryAfterSeconds = d.RetryAfterSeconds
+			for _, cause := range d.Causes {
+				if cause.Field != "" {
+					details.Causes = append(details.Causes, cause.Field+": "+cause.Message)
+				} else {
+					details.Causes = append(details.Causes, cause.Message)
+				}
+			}
+		}
+	}
+
+	var netErr net.Error
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return i18n.CodeUnauthorized, details
+	case apierrors.IsForbidden(err):
+		return i18n.CodeForbidden, details
+	case apierrors.IsNotFound(err):
+		return i18n.CodeNotFound, details
+	case apierrors.IsAlreadyExists(err), apierrors.IsConflict(err):
+		return i18n.CodeConflict, details
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return i18n.CodeInvalidParam, details
+	case apierrors.IsTooManyRequests(err):
+		return i18n.CodeTooManyRequests, details
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err),
+		errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return i18n.CodeTimeout, details
+	}
+	return i18n.CodeUnknown, details
+}
@@ -0,0 +1,45 @@
+package amis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weibaohui/k8m/pkg/i18n"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestNewError(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	invalid := apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "web",
+		field.ErrorList{field.Required(field.NewPath("spec", "selector"), "")})
+	cases := []struct {
+		name string
+		err  error
+		want i18n.Code
+	}{
+		{"forbidden", apierrors.NewForbidden(gr, "web", errors.New("rbac")), i18n.CodeForbidden},
+		{"wrapped not found", fmt.Errorf("读取失败: %w", apierrors.NewNotFound(gr, "web")), i18n.CodeNotFound},
+		{"server timeout", apierrors.NewServerTimeout(gr, "get", 3), i18n.CodeTimeout},
+		{"deadline", fmt.Errorf("exec: %w", context.DeadlineExceeded), i18n.CodeTimeout},
+		{"conflict", apierrors.NewConflict(gr, "web", errors.New("modified")), i18n.CodeConflict},
+		{"invalid", invalid, i18n.CodeInvalidParam},
+		{"coded", i18n.NewError(i18n.CodeFilePathRequired), i18n.CodeFilePathRequired},
+		{"plain", errors.New("boom"), i18n.CodeUnknown},
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, c := range cases {
+		if got := NewError(r, c.err); got.Code != c.want || got.Status != 1 {
+			t.Errorf("%s: code = %s, want %s", c.name, got.Code, c.want)
+		}
+	}
+
+	got := NewError(r, invalid)
+	if got.Details == nil || got.Details.Kind != "Deployment" || got.Details.Name != "web" || len(got.Details.Causes) != 1 {
+		t.Errorf("unexpected details: %+v", got.Details)
+	}
+}
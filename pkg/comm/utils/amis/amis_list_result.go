@@ -1,6 +1,9 @@
 package amis
 
 import (
+	"encoding/json"
+	"net/http"
+
 	"github.com/weibaohui/k8m/pkg/response"
 )
 
@@ -32,26 +35,46 @@ func WriteJsonList[T any](c *response.Context, data []T) {
 }
 
 func WriteJsonListWithTotal[T any](c *response.Context, total int64, data []T) {
+	c.JSON(200, listWithTotal(total, data))
+}
+
+// listWithTotal 构造带总数的列表响应，无数据时 rows 输出空数组
+func listWithTotal[T any](total int64, data []T) response.H {
 	if len(data) > 0 {
-		c.JSON(200, response.H{
+		return response.H{
 			"status": 0,
 			"msg":    "success",
 			"data": ListResponse[T]{
 				Count: total,
 				Rows:  data,
 			},
-		})
-	} else {
-		c.JSON(200, response.H{
-			"status": 0,
-			"msg":    "无数据",
-			"data": ListResponse[T]{
-				Count: total,
-				Rows:  []T{},
-			},
-		})
+		}
+	}
+	return response.H{
+		"status": 0,
+		"msg":    "无数据",
+		"data": ListResponse[T]{
+			Count: total,
+			Rows:  []T{},
+		},
 	}
+}
+
+// MarshalListWithTotal 按 WriteJsonListWithTotal 的格式序列化列表响应，用于缓存
+func MarshalListWithTotal[T any](total int64, data []T) ([]byte, error) {
+	return json.Marshal(listWithTotal(total, data))
+}
 
+// WriteJsonBytesWithETag 输出已序列化的JSON内容并携带ETag。
+// 请求头 If-None-Match 与 ETag 一致时仅返回 304，不输出内容。
+func WriteJsonBytesWithETag(c *response.Context, etag string, body []byte) {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	if match := c.GetHeader("If-None-Match"); match != "" && match == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
 }
 
 func WriteJsonListWithError[T any](c *response.Context, data []T, err error) {
@@ -1,6 +1,8 @@
 package amis
 
 import (
+	"net/http"
+
 	"github.com/weibaohui/k8m/pkg/response"
 )
 
@@ -16,11 +18,10 @@ func WriteJsonOKMsg(c *response.Context, msg string) {
 		"msg":    msg,
 	})
 }
+
+// WriteJsonError 输出amis格式的错误响应，响应中携带请求ID，便于根据用户反馈定位服务端日志，错误模型见 Error
 func WriteJsonError(c *response.Context, err error) {
-	c.JSON(200, response.H{
-		"status": 1,
-		"msg":    err.Error(),
-	})
+	WriteError(c, http.StatusOK, err)
 }
 func WriteJsonErrorOrOK(c *response.Context, err error) {
 	if err == nil {
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFile 从上传文件中提取出的单个清单文件
+type ManifestFile struct {
+	Name    string `json:"name"`
+	Content string `json:"-"`
+}
+
+// 单个清单文件大小上限，防止压缩包炸弹
+const maxManifestFileSize = 10 << 20
+
+// 压缩包内清单文件解压后的总大小上限
+const maxManifestTotalSize = 50 << 20
+
+// isManifestFileName 判断文件是否为清单文件
+func isManifestFileName(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+// ExtractManifestFiles 根据文件名识别 zip、tar、tar.gz/tgz 压缩包或普通YAML文件，提取其中的清单文件。
+// 压缩包内仅提取 .yaml、.yml、.json 文件，按包内顺序返回。
+func ExtractManifestFiles(fileName string, data []byte) ([]*ManifestFile, error) {
+	lower := strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return extractZip(data)
+	case strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("解压gzip失败: %w", err)
+		}
+		defer gz.Close()
+		return extractTar(gz)
+	case strings.HasSuffix(lower, ".tar"):
+		return extractTar(bytes.NewReader(data))
+	default:
+		return []*ManifestFile{{Name: fileName, Content: string(data)}}, nil
+	}
+}
+
+func extractZip(data []byte) ([]*ManifestFile, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("读取zip失败: %w", err)
+	}
+	var files []*ManifestFile
+	var total int
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isManifestFileName(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("读取 %s 失败: %w", f.Name, err)
+		}
+		content, err := readLimited(rc, f.Name)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if total += len(content); total > maxManifestTotalSize {
+			return nil, fmt.Errorf("压缩包解压后超过大小限制")
+		}
+		files = append(files, &ManifestFile{Name: f.Name, Content: content})
+	}
+	return files, nil
+}
+
+func extractTar(r io.Reader) ([]*ManifestFile, error) {
+	tr := tar.NewReader(r)
+	var files []*ManifestFile
+	var total int
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取tar失败: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isManifestFileName(hdr.Name) {
+			continue
+		}
+		content, err := readLimited(tr, hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		if total += len(content); total > maxManifestTotalSize {
+			return nil, fmt.Errorf("压缩包解压后超过大小限制")
+		}
+		files = append(files, &ManifestFile{Name: hdr.Name, Content: content})
+	}
+	return files, nil
+}
+
+func readLimited(r io.Reader, name string) (string, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxManifestFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("读取 %s 失败: %w", name, err)
+	}
+	if len(content) > maxManifestFileSize {
+		return "", fmt.Errorf("文件 %s 超过大小限制", name)
+	}
+	return string(content), nil
+}
+
+// SplitYAMLDocuments 按 "---" 分割多文档YAML，忽略空文档
+func SplitYAMLDocuments(content string) []string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	var docs []string
+	var current []string
+	flush := func() {
+		doc := strings.TrimSpace(strings.Join(current, "\n"))
+		current = current[:0]
+		if doc == "" {
+			return
+		}
+		// 仅包含注释的文档同样忽略
+		for _, line := range strings.Split(doc, "\n") {
+			if l := strings.TrimSpace(line); l != "" && !strings.HasPrefix(l, "#") {
+				docs = append(docs, doc)
+				return
+			}
+		}
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(strings.TrimPrefix(line, "---")) == "" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return docs
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/weibaohui/k8m/pkg/constants"
@@ -11,7 +12,8 @@ import (
 
 // GetJWTClaims 从 Gin 上下文的请求头或查询参数中提取并解析 JWT，返回其 claims。
 // 若未提供 Token、Token 无效或 claims 解析失败，则返回相应错误。
-func GetJWTClaims(c *response.Context, jwtTokenSecret string) (jwt.MapClaims, error) {
+// leeway 为校验 exp、iat、nbf 时容忍的时钟偏差。
+func GetJWTClaims(c *response.Context, jwtTokenSecret string, leeway time.Duration) (jwt.MapClaims, error) {
 	tokenString := c.GetHeader("Authorization")
 	if tokenString == "" {
 		// 尝试从query中获取
@@ -26,27 +28,13 @@ func GetJWTClaims(c *response.Context, jwtTokenSecret string) (jwt.MapClaims, er
 		tokenString = tokenString[7:]
 	}
 
-	var jwtSecret = []byte(jwtTokenSecret)
-
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		return jwtSecret, nil
-	})
-	if err != nil || !token.Valid {
-		return nil, fmt.Errorf("Token 无效")
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil, fmt.Errorf("invalid JWT claims")
-	}
-
-	return claims, nil
+	return parseJWT(tokenString, jwtTokenSecret, leeway)
 }
 
 // GetUsernameFromToken 从JWT令牌字符串中解析并返回用户名。
 // 如果令牌无效或未包含用户名字段，则返回错误。
-func GetUsernameFromToken(authToken string, jwtTokenSecret string) (string, error) {
-	claims, err := GetJwtMapClaimsFromToken(authToken, jwtTokenSecret)
+func GetUsernameFromToken(authToken string, jwtTokenSecret string, leeway time.Duration) (string, error) {
+	claims, err := GetJwtMapClaimsFromToken(authToken, jwtTokenSecret, leeway)
 	if err != nil {
 		return "", err
 	}
@@ -64,9 +52,10 @@ func GetUsernameFromToken(authToken string, jwtTokenSecret string) (string, erro
 //
 // @param authToken 需要解析的 JWT token 字符串，可带有 "Bearer " 前缀。
 // @param jwtTokenSecret 用于验证 token 的密钥。
+// @param leeway 校验有效期时容忍的时钟偏差。
 // @return jwt.MapClaims 解析出的 JWT claims。
 // @return error 解析或验证失败时返回的错误。
-func GetJwtMapClaimsFromToken(authToken string, jwtTokenSecret string) (jwt.MapClaims, error) {
+func GetJwtMapClaimsFromToken(authToken string, jwtTokenSecret string, leeway time.Duration) (jwt.MapClaims, error) {
 	if authToken == "" {
 		return nil, fmt.Errorf("未提供 Token")
 	}
@@ -76,9 +65,16 @@ func GetJwtMapClaimsFromToken(authToken string, jwtTokenSecret string) (jwt.MapC
 		authToken = authToken[7:]
 	}
 
+	return parseJWT(authToken, jwtTokenSecret, leeway)
+}
+
+// parseJWT 校验签名后按 leeway 校验有效期。
+// jwt/v4 内置的有效期校验不支持容忍时钟偏差，因此先跳过内置校验再自行校验
+func parseJWT(tokenString string, jwtTokenSecret string, leeway time.Duration) (jwt.MapClaims, error) {
 	var jwtSecret = []byte(jwtTokenSecret)
 
-	token, err := jwt.Parse(authToken, func(token *jwt.Token) (any, error) {
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	token, err := parser.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		return jwtSecret, nil
 	})
 	if err != nil || !token.Valid {
@@ -89,9 +85,23 @@ func GetJwtMapClaimsFromToken(authToken string, jwtTokenSecret string) (jwt.MapC
 	if !ok {
 		return nil, fmt.Errorf("invalid JWT claims")
 	}
-	// 主动检查有效期
-	if err := claims.Valid(); err != nil {
+	if err := VerifyClaimsTime(claims, time.Now(), leeway); err != nil {
 		return nil, err
 	}
 	return claims, nil
 }
+
+// VerifyClaimsTime 校验 exp、iat、nbf，允许 leeway 以内的时钟偏差
+func VerifyClaimsTime(claims jwt.MapClaims, now time.Time, leeway time.Duration) error {
+	skew := int64(max(leeway, 0) / time.Second)
+	if !claims.VerifyExpiresAt(now.Unix()-skew, false) {
+		return fmt.Errorf("Token 已过期")
+	}
+	if !claims.VerifyIssuedAt(now.Unix()+skew, false) {
+		return fmt.Errorf("Token 签发时间晚于服务器当前时间，请检查服务器时钟")
+	}
+	if !claims.VerifyNotBefore(now.Unix()+skew, false) {
+		return fmt.Errorf("Token 尚未生效，请检查服务器时钟")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestVerifyClaimsTime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		leeway time.Duration
+		ok     bool
+	}{
+		{"valid", jwt.MapClaims{"exp": float64(now.Unix() + 60), "iat": float64(now.Unix())}, 0, true},
+		{"expired", jwt.MapClaims{"exp": float64(now.Unix() - 10)}, 0, false},
+		{"expired within leeway", jwt.MapClaims{"exp": float64(now.Unix() - 10)}, 30 * time.Second, true},
+		{"issued in future", jwt.MapClaims{"iat": float64(now.Unix() + 20)}, 0, false},
+		{"issued in future within leeway", jwt.MapClaims{"iat": float64(now.Unix() + 20)}, 30 * time.Second, true},
+		{"not yet valid", jwt.MapClaims{"nbf": float64(now.Unix() + 90)}, 30 * time.Second, false},
+		{"no time claims", jwt.MapClaims{"username": "admin"}, 0, true},
+	}
+	for _, tt := range tests {
+		err := VerifyClaimsTime(tt.claims, now, tt.leeway)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v, want ok=%v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestGetJwtMapClaimsFromTokenLeeway(t *testing.T) {
+	secret := "test-secret"
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"username": "admin",
+		"exp":      time.Now().Add(-10 * time.Second).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetJwtMapClaimsFromToken(token, secret, 0); err == nil {
+		t.Fatal("expired token should be rejected without leeway")
+	}
+	if _, err := GetJwtMapClaimsFromToken("Bearer "+token, secret, time.Minute); err != nil {
+		t.Fatalf("expired token within leeway should be accepted: %v", err)
+	}
+	if _, err := GetJwtMapClaimsFromToken(token, "other", time.Minute); err == nil {
+		t.Fatal("token signed with another secret should be rejected")
+	}
+}
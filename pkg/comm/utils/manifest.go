@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseManifests 将多文档YAML解析为对象列表，kind: List 展开为其中的各项。
+// 每个对象缺少 apiVersion、kind、metadata.name（或 generateName）时返回错误
+func ParseManifests(content string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for i, doc := range SplitYAMLDocuments(content) {
+		var raw map[string]any
+		if err := yaml.Unmarshal([]byte(doc), &raw); err != nil {
+			return nil, fmt.Errorf("第%d个文档解析失败: %w", i+1, err)
+		}
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("第%d个文档缺少 apiVersion 或 kind", i+1)
+		}
+		// List 本身没有名称，展开后逐项校验
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("第%d个文档解析List失败: %w", i+1, err)
+			}
+			for j := range list.Items {
+				item := &list.Items[j]
+				if err := validateManifest(item); err != nil {
+					return nil, fmt.Errorf("第%d个文档第%d项%w", i+1, j+1, err)
+				}
+				objs = append(objs, item)
+			}
+			continue
+		}
+		if err := validateManifest(obj); err != nil {
+			return nil, fmt.Errorf("第%d个文档%w", i+1, err)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func validateManifest(obj *unstructured.Unstructured) error {
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		return fmt.Errorf("缺少 apiVersion 或 kind")
+	}
+	if obj.GetName() == "" && obj.GetGenerateName() == "" {
+		return fmt.Errorf("(%s)缺少 metadata.name", obj.GetKind())
+	}
+	return nil
+}
+
+// 导出时需要移除的注解
+var exportIgnoredAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+}
+
+// CleanupForExport 移除服务端维护的字段，使导出的资源可以直接提交到Git或在其他集群中重新应用
+func CleanupForExport(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+	unstructured.RemoveNestedField(out.Object, "status")
+	for _, field := range []string{"uid", "resourceVersion", "managedFields", "creationTimestamp", "generation", "selfLink", "deletionTimestamp", "deletionGracePeriodSeconds"} {
+		unstructured.RemoveNestedField(out.Object, "metadata", field)
+	}
+
+	annotations := out.GetAnnotations()
+	for _, k := range exportIgnoredAnnotations {
+		delete(annotations, k)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(out.Object, "metadata", "annotations")
+	} else {
+		out.SetAnnotations(annotations)
+	}
+
+	// Service 的 clusterIP 由集群分配，重新应用到其他集群时会冲突。
+	// Headless Service 的 clusterIP 为 None，是用户声明的，需要保留
+	if out.GetKind() == "Service" {
+		if ip, _, _ := unstructured.NestedString(out.Object, "spec", "clusterIP"); ip != "None" {
+			unstructured.RemoveNestedField(out.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(out.Object, "spec", "clusterIPs")
+		}
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseManifests(t *testing.T) {
+	content := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    generateName: c-
+`
+	objs, err := ParseManifests(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range objs {
+		got = append(got, o.GetKind()+"/"+o.GetName()+o.GetGenerateName())
+	}
+	if strings.Join(got, ",") != "ConfigMap/a,ConfigMap/b,Secret/c-" {
+		t.Errorf("objects = %v", got)
+	}
+
+	cases := map[string]string{
+		"kind: ConfigMap\nmetadata:\n  name: a":                                           "缺少 apiVersion 或 kind",
+		"apiVersion: v1\nkind: ConfigMap":                                                 "缺少 metadata.name",
+		"apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n":       "第1个文档第1项(ConfigMap)缺少 metadata.name",
+		"apiVersion: v1\nkind: List\nitems:\n- kind: ConfigMap\n  metadata:\n    name: x": "第1项",
+	}
+	for doc, want := range cases {
+		if _, err := ParseManifests(doc); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseManifests(%q) err = %v, want %q", doc, err, want)
+		}
+	}
+}
+
+func TestCleanupForExportService(t *testing.T) {
+	svc := func(ip string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]any{"name": "web", "uid": "123", "resourceVersion": "9"},
+			"spec": map[string]any{
+				"clusterIP":  ip,
+				"clusterIPs": []any{ip},
+			},
+			"status": map[string]any{"loadBalancer": map[string]any{}},
+		}}
+	}
+
+	out := CleanupForExport(svc("10.96.0.10"))
+	if _, found, _ := unstructured.NestedFieldNoCopy(out.Object, "spec", "clusterIP"); found {
+		t.Error("allocated clusterIP should be removed")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(out.Object, "spec", "clusterIPs"); found {
+		t.Error("allocated clusterIPs should be removed")
+	}
+	if out.GetUID() != "" || out.GetResourceVersion() != "" {
+		t.Error("server-set metadata should be removed")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(out.Object, "status"); found {
+		t.Error("status should be removed")
+	}
+
+	// headless Service
+	out = CleanupForExport(svc("None"))
+	if ip, _, _ := unstructured.NestedString(out.Object, "spec", "clusterIP"); ip != "None" {
+		t.Errorf("headless clusterIP = %q, want None", ip)
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const textPlainUTF8 = "text/plain; charset=utf-8"
+
+// ContentTypeByExtension 按扩展名推断内容类型，无法识别时返回空
+func ContentTypeByExtension(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == "" {
+		return ""
+	}
+	return mime.TypeByExtension(ext)
+}
+
+// DetectContentType 先按扩展名推断内容类型，无法识别时按文件头部的魔数识别，文本内容识别为 text/plain
+func DetectContentType(filename string, head []byte) string {
+	if ct := ContentTypeByExtension(filename); ct != "" {
+		return ct
+	}
+	if len(head) == 0 {
+		return "application/octet-stream"
+	}
+	ct := http.DetectContentType(head)
+	if ct == "application/octet-stream" {
+		if isText, _ := IsTextFile(head); isText {
+			return textPlainUTF8
+		}
+	}
+	return ct
+}
+
+// InlineContentType 返回在浏览器中直接查看时使用的内容类型。
+// 图片、音视频、PDF 保持原类型；HTML、SVG、脚本等可执行内容及其他文本按纯文本展示，避免在 k8m 页面域下执行；
+// 其余类型返回空，表示不适合直接查看。
+func InlineContentType(contentType string) string {
+	base, _, _ := strings.Cut(contentType, ";")
+	base = strings.TrimSpace(strings.ToLower(base))
+	switch {
+	case base == "image/svg+xml":
+		return textPlainUTF8
+	case strings.HasPrefix(base, "image/"), strings.HasPrefix(base, "video/"), strings.HasPrefix(base, "audio/"), base == "application/pdf":
+		return contentType
+	case strings.HasPrefix(base, "text/"),
+		base == "application/json", base == "application/xml", base == "application/javascript",
+		base == "application/x-yaml", base == "application/yaml", base == "application/toml":
+		return textPlainUTF8
+	}
+	return ""
+}
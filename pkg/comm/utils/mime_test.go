@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestDetectContentType(t *testing.T) {
+	cases := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{"report.pdf", nil, "application/pdf"},
+		{"logo", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "image/png"},
+		{"app.conf", []byte("worker_processes 1;\n"), "text/plain; charset=utf-8"},
+		{"data.bin", []byte{0x00, 0x01, 0x02, 0xff}, "application/octet-stream"},
+		{"empty", nil, "application/octet-stream"},
+	}
+	for _, c := range cases {
+		if got := DetectContentType(c.name, c.head); got != c.want {
+			t.Errorf("DetectContentType(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestInlineContentType(t *testing.T) {
+	cases := map[string]string{
+		"image/png":                "image/png",
+		"application/pdf":          "application/pdf",
+		"image/svg+xml":            "text/plain; charset=utf-8",
+		"text/html; charset=utf-8": "text/plain; charset=utf-8",
+		"application/json":         "text/plain; charset=utf-8",
+		"application/zip":          "",
+	}
+	for in, want := range cases {
+		if got := InlineContentType(in); got != want {
+			t.Errorf("InlineContentType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
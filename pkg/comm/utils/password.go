@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordClasses 统计密码包含的字符种类数：大写字母、小写字母、数字、其他符号
+func PasswordClasses(password string) int {
+	var upper, lower, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	n := 0
+	for _, b := range []bool{upper, lower, digit, other} {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+// CheckPasswordComplexity 按最小长度与最少字符种类校验密码复杂度，minLength、minClasses 为 0 表示不限制。
+// 设置了任一规则时，密码不能包含用户名（不区分大小写）
+func CheckPasswordComplexity(password, username string, minLength, minClasses int) error {
+	if password == "" {
+		return fmt.Errorf("密码不能为空")
+	}
+	if minLength > 0 && utf8.RuneCountInString(password) < minLength {
+		return fmt.Errorf("密码长度不能少于 %d 位", minLength)
+	}
+	if minClasses > 0 && PasswordClasses(password) < minClasses {
+		return fmt.Errorf("密码须至少包含大写字母、小写字母、数字、符号中的 %d 种", minClasses)
+	}
+	if (minLength > 0 || minClasses > 0) && username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return fmt.Errorf("密码不能包含用户名")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package utils
+
+import "testing"
+
+func TestPasswordClasses(t *testing.T) {
+	tests := map[string]int{
+		"":         0,
+		"abc":      1,
+		"abcABC":   2,
+		"abc123":   2,
+		"aB3":      3,
+		"aB3!":     4,
+		"密码123":    2,
+		"   ":      1,
+		"ABC-_=+.": 2,
+	}
+	for password, want := range tests {
+		if got := PasswordClasses(password); got != want {
+			t.Errorf("PasswordClasses(%q) = %d, want %d", password, got, want)
+		}
+	}
+}
+
+func TestCheckPasswordComplexity(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		username   string
+		minLength  int
+		minClasses int
+		wantErr    bool
+	}{
+		{name: "空密码", password: "", wantErr: true},
+		{name: "不限制", password: "a", username: "a"},
+		{name: "长度不足", password: "aB3!", minLength: 8, wantErr: true},
+		{name: "长度按字符计算", password: "密码密码密码密码", minLength: 8},
+		{name: "种类不足", password: "abcdefgh", minClasses: 2, wantErr: true},
+		{name: "满足规则", password: "Abcdefg1", minLength: 8, minClasses: 3},
+		{name: "包含用户名", password: "Admin@2024", username: "admin", minLength: 8, wantErr: true},
+		{name: "未设规则时允许包含用户名", password: "admin123", username: "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPasswordComplexity(tt.password, tt.username, tt.minLength, tt.minClasses)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPasswordComplexity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -136,3 +136,8 @@ var ansiEscapeRegex = regexp.MustCompile(`\x1B\[[0-9;]*[A-Za-z]`)
 func CleanANSISequences(input string) string {
 	return ansiEscapeRegex.ReplaceAllString(input, "")
 }
+
+// ShellQuote 使用单引号包裹参数，避免路径中的特殊字符被 shell 解释
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
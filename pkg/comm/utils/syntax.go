@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// CheckSyntax 按文件扩展名校验内容语法，返回发现的问题，不支持的类型返回空
+func CheckSyntax(filename string, content []byte) []string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return checkYAML(content)
+	case ".json":
+		return checkJSON(content)
+	case ".xml":
+		return checkXML(content)
+	case ".properties":
+		return checkProperties(content)
+	case ".ini":
+		return checkINI(content)
+	case ".env":
+		return checkEnv(content)
+	}
+	return nil
+}
+
+// checkYAML 支持 --- 分隔的多文档
+func checkYAML(content []byte) []string {
+	var problems []string
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return append(problems, fmt.Sprintf("YAML 读取失败: %v", err))
+		}
+		var v any
+		if err := yaml.Unmarshal(doc, &v); err != nil {
+			problems = append(problems, fmt.Sprintf("第 %d 个 YAML 文档: %v", i, err))
+		}
+	}
+	return problems
+}
+
+func checkJSON(content []byte) []string {
+	var v any
+	if err := json.Unmarshal(content, &v); err != nil {
+		var se *json.SyntaxError
+		if errors.As(err, &se) {
+			line, col := offsetToLine(content, se.Offset)
+			return []string{fmt.Sprintf("JSON 第 %d 行第 %d 列: %v", line, col, se)}
+		}
+		return []string{fmt.Sprintf("JSON 解析失败: %v", err)}
+	}
+	return nil
+}
+
+func checkXML(content []byte) []string {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return []string{fmt.Sprintf("XML 解析失败: %v", err)}
+		}
+	}
+}
+
+// checkProperties 每个非注释行需为 key=value 或 key:value，支持 \ 续行
+func checkProperties(content []byte) []string {
+	var problems []string
+	continued := false
+	for i, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		wasContinued := continued
+		continued = strings.HasSuffix(trimmed, `\`) && !strings.HasSuffix(trimmed, `\\`)
+		if wasContinued || trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			continue
+		}
+		sep := strings.IndexAny(trimmed, "=:")
+		if sep == 0 {
+			problems = append(problems, fmt.Sprintf("第 %d 行: 缺少键名", i+1))
+		}
+	}
+	return problems
+}
+
+// checkINI 支持 [section]、key=value、key: value 以及 ; # 注释
+func checkINI(content []byte) []string {
+	var problems []string
+	for i, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			if !strings.HasSuffix(trimmed, "]") || len(trimmed) < 3 {
+				problems = append(problems, fmt.Sprintf("第 %d 行: 节名称格式错误 %s", i+1, trimmed))
+			}
+			continue
+		}
+		sep := strings.IndexAny(trimmed, "=:")
+		if sep < 0 {
+			problems = append(problems, fmt.Sprintf("第 %d 行: 缺少 = 分隔符", i+1))
+		} else if sep == 0 {
+			problems = append(problems, fmt.Sprintf("第 %d 行: 缺少键名", i+1))
+		}
+	}
+	return problems
+}
+
+// checkEnv 每个非注释行需为 KEY=VALUE，允许 export 前缀
+func checkEnv(content []byte) []string {
+	var problems []string
+	for i, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		trimmed = strings.TrimPrefix(trimmed, "export ")
+		key, _, ok := strings.Cut(trimmed, "=")
+		if !ok || strings.TrimSpace(key) == "" || strings.ContainsAny(strings.TrimSpace(key), " \t") {
+			problems = append(problems, fmt.Sprintf("第 %d 行: 应为 KEY=VALUE 格式", i+1))
+		}
+	}
+	return problems
+}
+
+func offsetToLine(content []byte, offset int64) (int, int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestCheckSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		problems int
+	}{
+		{name: "yaml多文档", filename: "a.yaml", content: "a: 1\n---\nb: [1, 2]\n", problems: 0},
+		{name: "yaml缩进错误", filename: "a.yml", content: "a: 1\n---\nb:\n  - 1\n c: 2\n", problems: 1},
+		{name: "json正确", filename: "a.json", content: `{"a": 1}`, problems: 0},
+		{name: "json缺少括号", filename: "a.json", content: "{\n\"a\": 1,\n", problems: 1},
+		{name: "xml未闭合", filename: "a.xml", content: "<a><b></a>", problems: 1},
+		{name: "properties", filename: "app.properties", content: "# c\na=1\nb: 2\nlong=x \\\n  y\n=bad\n", problems: 1},
+		{name: "ini", filename: "my.ini", content: "[mysqld]\nport=3306\nbad line\n[broken\n", problems: 2},
+		{name: "env", filename: ".env", content: "A=1\nexport B=2\nC D=3\n", problems: 1},
+		{name: "未知类型", filename: "nginx.conf", content: "{{{", problems: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckSyntax(tt.filename, []byte(tt.content))
+			if len(got) != tt.problems {
+				t.Errorf("CheckSyntax() = %v, want %d problems", got, tt.problems)
+			}
+		})
+	}
+}
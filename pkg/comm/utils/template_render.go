@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	TemplateEngineGo       = "go"       // Go text/template 语法，如 {{ .name }}
+	TemplateEngineEnvsubst = "envsubst" // envsubst 语法，如 ${NAME} 或 $NAME
+)
+
+// RenderTemplate 按指定引擎对模板内容进行变量替换。
+// engine 为空时原样返回；缺失变量会返回错误，避免将未替换的占位符应用到集群。
+func RenderTemplate(engine string, content string, vars map[string]string) (string, error) {
+	switch engine {
+	case "":
+		return content, nil
+	case TemplateEngineGo:
+		tpl, err := template.New("manifest").Option("missingkey=error").Parse(content)
+		if err != nil {
+			return "", fmt.Errorf("解析模板失败: %w", err)
+		}
+		var buf bytes.Buffer
+		if err = tpl.Execute(&buf, vars); err != nil {
+			return "", fmt.Errorf("渲染模板失败: %w", err)
+		}
+		return buf.String(), nil
+	case TemplateEngineEnvsubst:
+		var missing []string
+		result := os.Expand(content, func(key string) string {
+			if v, ok := vars[key]; ok {
+				return v
+			}
+			missing = append(missing, key)
+			return ""
+		})
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return "", fmt.Errorf("缺少模板变量: %s", strings.Join(missing, ","))
+		}
+		return result, nil
+	default:
+		return "", fmt.Errorf("不支持的模板引擎: %s", engine)
+	}
+}
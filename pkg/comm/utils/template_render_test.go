@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+func TestRenderTemplate(t *testing.T) {
+	vars := map[string]string{"name": "nginx", "NS": "default"}
+	tests := []struct {
+		name    string
+		engine  string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "无引擎原样返回", engine: "", content: "name: {{ .name }}", want: "name: {{ .name }}"},
+		{name: "go模板", engine: TemplateEngineGo, content: "name: {{ .name }}", want: "name: nginx"},
+		{name: "go模板缺失变量", engine: TemplateEngineGo, content: "name: {{ .image }}", wantErr: true},
+		{name: "envsubst", engine: TemplateEngineEnvsubst, content: "namespace: ${NS}/$NS", want: "namespace: default/default"},
+		{name: "envsubst缺失变量", engine: TemplateEngineEnvsubst, content: "image: ${IMAGE}", wantErr: true},
+		{name: "未知引擎", engine: "jinja", content: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(tt.engine, tt.content, vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
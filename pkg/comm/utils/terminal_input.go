@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitTerminalInput 将终端尚未提交的输入 pending 与新收到的按键 data 按回车、换行切分。
+// 返回本次提交的各行命令（已处理退格等编辑按键）与剩余未提交的原始输入，粘贴多行时每一行都会返回
+func SplitTerminalInput(pending string, data []byte) (lines []string, rest string) {
+	input := pending + string(data)
+	for {
+		i := strings.IndexAny(input, "\r\n")
+		if i < 0 {
+			return lines, input
+		}
+		if line := TerminalLine(input[:i]); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+		input = input[i+1:]
+	}
+}
+
+// TerminalLine 按行编辑规则还原按键序列对应的命令：去除 ANSI 转义序列，
+// 退格删除前一个字符，Ctrl+W 删除前一个单词，Ctrl+U、Ctrl+C 清空当前行，其余控制字符忽略
+func TerminalLine(keys string) string {
+	var line []rune
+	for _, r := range CleanANSISequences(keys) {
+		switch {
+		case r == '\x7f' || r == '\b':
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		case r == '\x17':
+			i := len(line)
+			for i > 0 && unicode.IsSpace(line[i-1]) {
+				i--
+			}
+			for i > 0 && !unicode.IsSpace(line[i-1]) {
+				i--
+			}
+			line = line[:i]
+		case r == '\x15' || r == '\x03':
+			line = line[:0]
+		case r == '\t':
+			line = append(line, ' ')
+		case unicode.IsControl(r):
+		default:
+			line = append(line, r)
+		}
+	}
+	return string(line)
+}
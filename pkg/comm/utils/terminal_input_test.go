@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitTerminalInput(t *testing.T) {
+	cases := []struct {
+		pending string
+		data    string
+		lines   []string
+		rest    string
+	}{
+		{"", "ls", nil, "ls"},
+		{"l", "s\r", []string{"ls"}, ""},
+		{"", "ls\rrm -rf /\r", []string{"ls", "rm -rf /"}, ""},
+		{"", "ls\nrm -rf /\nech", []string{"ls", "rm -rf /"}, "ech"},
+		{"", "\r\r", nil, ""},
+		{"rm -rf /tmp/x", "\x7f\x7f\x7f\x7f\x7f\r", []string{"rm -rf /"}, ""},
+	}
+	for _, tc := range cases {
+		lines, rest := SplitTerminalInput(tc.pending, []byte(tc.data))
+		if !slices.Equal(lines, tc.lines) || rest != tc.rest {
+			t.Errorf("SplitTerminalInput(%q, %q) = %q, %q", tc.pending, tc.data, lines, rest)
+		}
+	}
+}
+
+func TestTerminalLine(t *testing.T) {
+	cases := map[string]string{
+		"rm -rf /x\x7f":              "rm -rf /",
+		"rn\bm -rf /":                "rm -rf /",
+		"echo ok\x15rm -rf /":        "rm -rf /",
+		"ls\x03rm -rf /":             "rm -rf /",
+		"echo abc\x17rm -rf /":       "echo rm -rf /",
+		"\x1b[Arm\x1b[D\x1b[C -rf /": "rm -rf /",
+		"rm\t-rf\x01 /":              "rm -rf /",
+	}
+	for keys, want := range cases {
+		if got := TerminalLine(keys); got != want {
+			t.Errorf("TerminalLine(%q) = %q, want %q", keys, got, want)
+		}
+	}
+}
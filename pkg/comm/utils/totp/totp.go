@@ -4,7 +4,9 @@ import (
 	"encoding/base32"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 )
@@ -23,8 +25,8 @@ func GenerateSecret(username string) (string, string, error) {
 	return key.Secret(), key.URL(), nil
 }
 
-// ValidateCode 验证TOTP代码
-func ValidateCode(secret string, code string) bool {
+// ValidateCode 验证TOTP代码，skew 为当前时间前后各容忍的周期数（每周期 30 秒），用于容忍手机与服务器之间的时钟偏差
+func ValidateCode(secret string, code string, skew uint) bool {
 	// 确保密钥是base32编码的
 	secret = strings.ToUpper(secret)
 	secret = strings.TrimSpace(secret)
@@ -34,8 +36,14 @@ func ValidateCode(secret string, code string) bool {
 		return false
 	}
 
-	// 验证代码
-	return totp.Validate(code, secret)
+	// 验证代码，参数与 GenerateSecret 生成的密钥一致
+	ok, err := totp.ValidateCustom(code, secret, time.Now().UTC(), totp.ValidateOpts{
+		Period:    30,
+		Skew:      skew,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	return err == nil && ok
 }
 
 // GenerateBackupCodes 生成备用恢复码
@@ -0,0 +1,333 @@
+// Package conditions 汇总一类资源的 .status.conditions：按条件类型、状态、原因计数，
+// 并按主条件判断对象是否健康，支持按条件或额外状态字段下钻到具体对象。只做计算，不访问集群。
+package conditions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultPrimary 未配置时判断健康使用的条件类型
+const DefaultPrimary = "Ready"
+
+// StatusMissing 下钻时表示对象没有该条件
+const StatusMissing = "Missing"
+
+// MaxFields 额外统计的状态字段数上限
+const MaxFields = 10
+
+var fieldPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// Options 汇总参数
+type Options struct {
+	Types   []string // 统计的条件类型，为空时统计全部
+	Primary string   // 主条件，为空时使用 Ready
+	Fields  []string // 额外按取值计数的字段路径，如 status.health.status
+	Reverse []string // 为 True 时表示异常的条件类型关键字，包含匹配，如 Pressure
+}
+
+// ReasonCount 某条件在某状态下的原因计数
+type ReasonCount struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// ConditionSummary 单个条件类型的汇总
+type ConditionSummary struct {
+	Type      string         `json:"type"`
+	Reversed  bool           `json:"reversed"` // 为 True 时表示异常
+	True      int            `json:"true"`
+	False     int            `json:"false"`
+	Unknown   int            `json:"unknown"`
+	Missing   int            `json:"missing"`   // 没有该条件的对象数
+	Unhealthy int            `json:"unhealthy"` // 处于异常状态的对象数
+	Reasons   []*ReasonCount `json:"reasons"`
+}
+
+// ValueCount 字段取值计数
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// FieldSummary 额外状态字段的取值分布
+type FieldSummary struct {
+	Path   string        `json:"path"`
+	Values []*ValueCount `json:"values"`
+}
+
+// Summary 一类资源的条件汇总
+type Summary struct {
+	Total      int                 `json:"total"`
+	Primary    string              `json:"primary"`
+	Healthy    int                 `json:"healthy"`   // 主条件处于正常状态
+	Unhealthy  int                 `json:"unhealthy"` // 主条件处于异常状态
+	NoStatus   int                 `json:"no_status"` // 没有主条件
+	Conditions []*ConditionSummary `json:"conditions"`
+	Fields     []*FieldSummary     `json:"fields"`
+}
+
+// Query 下钻条件：按条件类型（可选状态、原因）或按字段取值过滤
+type Query struct {
+	Type   string `json:"type"`
+	Status string `json:"status"` // True、False、Unknown、Missing，为空时不限
+	Reason string `json:"reason"`
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+}
+
+// Object 下钻结果中的对象
+type Object struct {
+	Namespace          string `json:"namespace,omitempty"`
+	Name               string `json:"name"`
+	Status             string `json:"status,omitempty"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"last_transition_time,omitempty"`
+	Value              string `json:"value,omitempty"` // 按字段下钻时的字段值
+}
+
+type condition struct {
+	Type, Status, Reason, Message, LastTransitionTime string
+}
+
+// SplitList 拆分逗号或换行分隔的配置项，去除空白与重复
+func SplitList(value string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		item = strings.TrimSpace(item)
+		if item != "" && !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ValidateFields 校验字段路径，路径以点分隔，如 status.health.status
+func ValidateFields(fields []string) error {
+	if len(fields) > MaxFields {
+		return fmt.Errorf("状态字段最多 %d 个", MaxFields)
+	}
+	for _, f := range fields {
+		if !fieldPattern.MatchString(f) {
+			return fmt.Errorf("状态字段路径无效: %s", f)
+		}
+	}
+	return nil
+}
+
+// Reversed 条件类型是否为 True 时表示异常
+func (o *Options) Reversed(conditionType string) bool {
+	for _, r := range o.Reverse {
+		if r != "" && strings.Contains(conditionType, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Options) primary() string {
+	if o.Primary == "" {
+		return DefaultPrimary
+	}
+	return o.Primary
+}
+
+// healthy 条件状态是否正常，Unknown 视为异常
+func (o *Options) healthy(c condition) bool {
+	if o.Reversed(c.Type) {
+		return c.Status == "False"
+	}
+	return c.Status == "True"
+}
+
+// Summarize 汇总对象的条件
+func Summarize(items []*unstructured.Unstructured, opts *Options) *Summary {
+	s := &Summary{Total: len(items), Primary: opts.primary(), Conditions: []*ConditionSummary{}, Fields: []*FieldSummary{}}
+	wanted := map[string]bool{}
+	for _, t := range opts.Types {
+		wanted[t] = true
+	}
+	byType := map[string]*ConditionSummary{}
+	reasons := map[string]*ReasonCount{}
+	present := map[string]int{}
+	for _, t := range opts.Types {
+		byType[t] = &ConditionSummary{Type: t, Reversed: opts.Reversed(t), Reasons: []*ReasonCount{}}
+	}
+	for _, item := range items {
+		conds := parse(item)
+		if c, ok := conds[s.Primary]; !ok {
+			s.NoStatus++
+		} else if opts.healthy(c) {
+			s.Healthy++
+		} else {
+			s.Unhealthy++
+		}
+		for t, c := range conds {
+			if len(wanted) > 0 && !wanted[t] {
+				continue
+			}
+			cs, ok := byType[t]
+			if !ok {
+				cs = &ConditionSummary{Type: t, Reversed: opts.Reversed(t), Reasons: []*ReasonCount{}}
+				byType[t] = cs
+			}
+			present[t]++
+			switch c.Status {
+			case "True":
+				cs.True++
+			case "False":
+				cs.False++
+			default:
+				cs.Unknown++
+			}
+			if !opts.healthy(c) {
+				cs.Unhealthy++
+			}
+			key := t + "\x00" + c.Status + "\x00" + c.Reason
+			rc, ok := reasons[key]
+			if !ok {
+				rc = &ReasonCount{Status: c.Status, Reason: c.Reason}
+				reasons[key] = rc
+				cs.Reasons = append(cs.Reasons, rc)
+			}
+			rc.Count++
+		}
+	}
+	for t, cs := range byType {
+		cs.Missing = len(items) - present[t]
+		sort.Slice(cs.Reasons, func(i, j int) bool {
+			if cs.Reasons[i].Count != cs.Reasons[j].Count {
+				return cs.Reasons[i].Count > cs.Reasons[j].Count
+			}
+			return cs.Reasons[i].Status+cs.Reasons[i].Reason < cs.Reasons[j].Status+cs.Reasons[j].Reason
+		})
+		s.Conditions = append(s.Conditions, cs)
+	}
+	// 主条件排在最前，其余按异常数、类型排序
+	sort.Slice(s.Conditions, func(i, j int) bool {
+		a, b := s.Conditions[i], s.Conditions[j]
+		if (a.Type == s.Primary) != (b.Type == s.Primary) {
+			return a.Type == s.Primary
+		}
+		if a.Unhealthy != b.Unhealthy {
+			return a.Unhealthy > b.Unhealthy
+		}
+		return a.Type < b.Type
+	})
+
+	for _, path := range opts.Fields {
+		fs := &FieldSummary{Path: path, Values: []*ValueCount{}}
+		counts := map[string]*ValueCount{}
+		for _, item := range items {
+			v := fieldValue(item, path)
+			vc, ok := counts[v]
+			if !ok {
+				vc = &ValueCount{Value: v}
+				counts[v] = vc
+				fs.Values = append(fs.Values, vc)
+			}
+			vc.Count++
+		}
+		sort.Slice(fs.Values, func(i, j int) bool {
+			if fs.Values[i].Count != fs.Values[j].Count {
+				return fs.Values[i].Count > fs.Values[j].Count
+			}
+			return fs.Values[i].Value < fs.Values[j].Value
+		})
+		s.Fields = append(s.Fields, fs)
+	}
+	return s
+}
+
+// Filter 返回满足下钻条件的对象，按命名空间、名称排序
+func Filter(items []*unstructured.Unstructured, q *Query) []*Object {
+	result := []*Object{}
+	for _, item := range items {
+		obj := &Object{Namespace: item.GetNamespace(), Name: item.GetName()}
+		switch {
+		case q.Field != "":
+			obj.Value = fieldValue(item, q.Field)
+			if obj.Value != q.Value {
+				continue
+			}
+		case q.Type != "":
+			c, ok := parse(item)[q.Type]
+			if !ok {
+				if q.Status != "" && q.Status != StatusMissing {
+					continue
+				}
+				obj.Status = StatusMissing
+				break
+			}
+			if q.Status == StatusMissing || q.Status != "" && c.Status != q.Status || q.Reason != "" && c.Reason != q.Reason {
+				continue
+			}
+			obj.Status, obj.Reason, obj.Message, obj.LastTransitionTime = c.Status, c.Reason, c.Message, c.LastTransitionTime
+		}
+		result = append(result, obj)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// parse 读取 status.conditions，按类型索引
+func parse(item *unstructured.Unstructured) map[string]condition {
+	result := map[string]condition{}
+	list, found, err := unstructured.NestedSlice(item.Object, "status", "conditions")
+	if err != nil || !found {
+		return result
+	}
+	for _, raw := range list {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		c := condition{
+			Type:               str(m["type"]),
+			Status:             str(m["status"]),
+			Reason:             str(m["reason"]),
+			Message:            str(m["message"]),
+			LastTransitionTime: str(m["lastTransitionTime"]),
+		}
+		if c.Type != "" {
+			result[c.Type] = c
+		}
+	}
+	return result
+}
+
+func str(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// fieldValue 读取字段值，不存在或不是标量时返回空
+func fieldValue(item *unstructured.Unstructured, path string) string {
+	v, found, err := unstructured.NestedFieldNoCopy(item.Object, strings.Split(path, ".")...)
+	if err != nil || !found {
+		return ""
+	}
+	switch v.(type) {
+	case map[string]any, []any:
+		return ""
+	}
+	return str(v)
+}
@@ -0,0 +1,98 @@
+package conditions
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func testObject(ns, name string, health string, conds ...map[string]any) *unstructured.Unstructured {
+	list := make([]any, 0, len(conds))
+	for _, c := range conds {
+		list = append(list, c)
+	}
+	status := map[string]any{"conditions": list}
+	if health != "" {
+		status["health"] = map[string]any{"status": health}
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"namespace": ns, "name": name},
+		"status":   status,
+	}}
+}
+
+func cond(t, status, reason string) map[string]any {
+	return map[string]any{"type": t, "status": status, "reason": reason, "message": t + " " + reason}
+}
+
+func testItems() []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{
+		testObject("a", "ok", "Healthy", cond("Ready", "True", "Issued"), cond("DiskPressure", "False", "")),
+		testObject("a", "expired", "Degraded", cond("Ready", "False", "Expired"), cond("DiskPressure", "True", "Full")),
+		testObject("b", "pending", "", cond("Ready", "Unknown", "Pending")),
+		testObject("b", "empty", ""),
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(testItems(), &Options{Reverse: []string{"Pressure"}, Fields: []string{"status.health.status"}})
+	if s.Total != 4 || s.Healthy != 1 || s.Unhealthy != 2 || s.NoStatus != 1 {
+		t.Fatalf("unexpected totals: %+v", s)
+	}
+	if len(s.Conditions) != 2 || s.Conditions[0].Type != "Ready" {
+		t.Fatalf("primary condition should come first: %+v", s.Conditions)
+	}
+	ready := s.Conditions[0]
+	if ready.True != 1 || ready.False != 1 || ready.Unknown != 1 || ready.Missing != 1 || ready.Unhealthy != 2 || len(ready.Reasons) != 3 {
+		t.Fatalf("unexpected Ready summary: %+v", ready)
+	}
+	disk := s.Conditions[1]
+	if !disk.Reversed || disk.Unhealthy != 1 || disk.Missing != 2 {
+		t.Fatalf("unexpected DiskPressure summary: %+v", disk)
+	}
+	f := s.Fields[0]
+	if len(f.Values) != 3 || f.Values[0].Value != "" || f.Values[0].Count != 2 {
+		t.Fatalf("unexpected field summary: %+v", f.Values)
+	}
+
+	only := Summarize(testItems(), &Options{Types: []string{"Synced"}, Primary: "Synced"})
+	if len(only.Conditions) != 1 || only.Conditions[0].Missing != 4 || only.NoStatus != 4 {
+		t.Fatalf("configured type without data should be listed as missing: %+v", only.Conditions[0])
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := testItems()
+	cases := []struct {
+		q    Query
+		want []string
+	}{
+		{Query{Type: "Ready", Status: "False", Reason: "Expired"}, []string{"expired"}},
+		{Query{Type: "Ready", Status: StatusMissing}, []string{"empty"}},
+		{Query{Type: "Ready"}, []string{"expired", "ok", "empty", "pending"}},
+		{Query{Field: "status.health.status", Value: "Degraded"}, []string{"expired"}},
+		{Query{Field: "status.health.status", Value: ""}, []string{"empty", "pending"}},
+	}
+	for _, c := range cases {
+		got := Filter(items, &c.q)
+		if len(got) != len(c.want) {
+			t.Fatalf("Filter(%+v) = %d objects, want %v", c.q, len(got), c.want)
+		}
+		for i, name := range c.want {
+			if got[i].Name != name {
+				t.Fatalf("Filter(%+v)[%d] = %s, want %s", c.q, i, got[i].Name, name)
+			}
+		}
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	if err := ValidateFields(SplitList("status.health.status, status.sync.status\nstatus.health.status")); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []string{"status..x", ".status", "status[0]", "status health"} {
+		if err := ValidateFields([]string{bad}); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
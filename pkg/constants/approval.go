@@ -0,0 +1,4 @@
+package constants
+
+// ApprovedChange 上下文中标记已批准变更申请的键，值为申请 ID，执行时不再触发审批
+const ApprovedChange = "approvedChange"
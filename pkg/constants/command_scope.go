@@ -0,0 +1,14 @@
+package constants
+
+// CommandScope 上下文中标记容器命令来源的键，命令策略据此选择适用范围
+const CommandScope = "commandScope"
+
+// 命令来源
+const (
+	CommandScopeTerminal = "terminal" // 交互式终端
+	CommandScopeExec     = "exec"     // 一次性命令接口
+	CommandScopeFile     = "file"     // 文件管理底层执行的命令
+)
+
+// ReadOnlyExec 上下文中标记只读容器命令的键，如文件浏览、下载、校验和计算，只读模式下仍允许执行
+const ReadOnlyExec = "readOnlyExec"
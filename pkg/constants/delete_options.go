@@ -0,0 +1,7 @@
+package constants
+
+// DeleteGracePeriod 上下文中指定删除宽限期（秒）的键，值为 int64，未设置时使用资源默认的宽限期
+const DeleteGracePeriod = "deleteGracePeriod"
+
+// DeletePropagation 上下文中指定删除级联策略的键，值为 metav1.DeletionPropagation，如 Orphan 表示保留下级资源
+const DeletePropagation = "deletePropagation"
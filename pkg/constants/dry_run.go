@@ -0,0 +1,5 @@
+package constants
+
+// DryRun 上下文中设置为 true 时，经 kom 的创建与更新以 dryRun=All 提交：仍校验只读模式、权限与准入策略，
+// 但不会修改集群，也不进入变更审批、不写入变更ID与操作日志
+const DryRun = "dryRun"
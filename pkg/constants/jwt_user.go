@@ -1,6 +1,7 @@
 package constants
 
 const (
-	JwtUserName = "username"
-	ClusterID   = "clusterID"
+	JwtUserName           = "username"
+	JwtMustChangePassword = "must_change_password" // 密码过期或被要求轮换时签发的 Token 只能用于修改密码
+	ClusterID             = "clusterID"
 )
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// CapabilityModeRequest 设置集群功能开关
+type CapabilityModeRequest struct {
+	Name string `json:"name"`
+	Mode string `json:"mode"` // enabled、disabled，为空表示按探测结果
+}
+
+// @Summary 获取集群能力与功能开关
+// @Security BearerAuth
+// @Param cluster path string true "base64编码的集群ID"
+// @Param refresh query bool false "为 true 时重新探测"
+// @Success 200 {object} string
+// @Router /admin/cluster/{cluster}/capabilities [get]
+func (a *Controller) Capabilities(c *response.Context) {
+	clusterID, err := utils.DecodeBase64(c.Param("cluster"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	report, err := service.CapabilityService().Get(clusterID, c.Query("refresh") == "true")
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, report)
+}
+
+// @Summary 设置集群功能开关
+// @Description 开关优先于探测结果，可关闭已安装的功能，或在探测不准时强制开启
+// @Security BearerAuth
+// @Param cluster path string true "base64编码的集群ID"
+// @Param body body CapabilityModeRequest true "功能名称与开关"
+// @Success 200 {object} string
+// @Router /admin/cluster/{cluster}/capabilities/save [post]
+func (a *Controller) SaveCapability(c *response.Context) {
+	clusterID, err := utils.DecodeBase64(c.Param("cluster"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req CapabilityModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := service.CapabilityService().SetMode(clusterID, req.Name, req.Mode); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
@@ -26,6 +26,9 @@ func RegisterAdminClusterRoutes(r chi.Router) {
 	r.Post("/cluster/kubeconfig/save", response.Adapter(ctrl.SaveKubeConfig))
 	r.Post("/cluster/kubeconfig/remove", response.Adapter(ctrl.RemoveKubeConfig))
 	r.Post("/cluster/{cluster}/disconnect", response.Adapter(ctrl.Disconnect))
+	r.Get("/cluster/{cluster}/support_bundle", response.Adapter(ctrl.SupportBundle))
+	r.Get("/cluster/{cluster}/capabilities", response.Adapter(ctrl.Capabilities))
+	r.Post("/cluster/{cluster}/capabilities/save", response.Adapter(ctrl.SaveCapability))
 	r.Post("/cluster/aws/save", response.Adapter(ctrl.SaveAWSEKSCluster))
 	r.Post("/cluster/token/save", response.Adapter(ctrl.SaveTokenCluster))
 	r.Get("/cluster/config/{id}", response.Adapter(ctrl.GetClusterConfig))
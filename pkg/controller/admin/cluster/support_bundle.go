@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 下载集群支持包
+// @Description 将节点条件、控制面 Pod 状态与日志、异常工作负载、Warning 事件以及 k8m 记录的该集群操作审计与终端命令打包为 tar.gz，用于提交工单。
+// @Description 文本中的口令、令牌等敏感值已掩码；各项尽力收集，失败原因记录在包内 errors.txt
+// @Security BearerAuth
+// @Param cluster path string true "base64编码的集群ID"
+// @Param since query string false "事件与审计记录的时间范围，如 6h、48h，默认24h，最大168h"
+// @Param tail query int false "每个控制面容器读取的日志行数，默认500，最大5000"
+// @Param save query bool false "为 true 时同时保存到制品存储"
+// @Success 200 {file} file
+// @Router /admin/cluster/{cluster}/support_bundle [get]
+func (a *Controller) SupportBundle(c *response.Context) {
+	clusterID, err := utils.DecodeBase64(c.Param("cluster"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	opts := &service.SupportBundleOptions{}
+	if since := c.Query("since"); since != "" {
+		if opts.Since, err = time.ParseDuration(since); err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("since 参数无效: %s", since))
+			return
+		}
+	}
+	if tail := c.Query("tail"); tail != "" {
+		if opts.TailLines, err = strconv.ParseInt(tail, 10, 64); err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("tail 参数无效: %s", tail))
+			return
+		}
+	}
+
+	// 先写入本地临时文件，收集失败时仍可返回 JSON 错误
+	tmp, err := os.CreateTemp(service.UploadStagingService().Root(), "k8m-support-bundle-*")
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := service.SupportBundleService().Collect(amis.GetContextWithUser(c), clusterID, opts, tmp); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	fileName := fmt.Sprintf("support-bundle-%s-%s.tar.gz", utils.SanitizeFileName(clusterID), time.Now().Format("20060102-150405"))
+	if c.Query("save") == "true" {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		key := strings.Join([]string{service.ArtifactSupportBundle, utils.SanitizeFileName(clusterID), fileName}, "/")
+		if _, err := service.ArtifactService().Save(c.Request.Context(), key, tmp, -1, "application/gzip"); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
+	amis.WriteDownload(c, fileName, "application/gzip", "", time.Time{}, tmp)
+}
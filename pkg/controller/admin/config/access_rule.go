@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/accessrule"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/listener"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type AccessRuleController struct{}
+
+// RegisterAccessRuleRoutes 注册访问规则管理路由
+func RegisterAccessRuleRoutes(r chi.Router) {
+	ctrl := &AccessRuleController{}
+	r.Get("/access/rule/list", response.Adapter(ctrl.List))
+	r.Get("/access/rule/operations", response.Adapter(ctrl.Operations))
+	r.Post("/access/rule/save", response.Adapter(ctrl.Save))
+	r.Post("/access/rule/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/access/rule/check", response.Adapter(ctrl.Check))
+}
+
+// @Summary 访问规则列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/access/rule/list [get]
+func (ac *AccessRuleController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.AccessRule{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 访问规则可限制的操作类别
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/access/rule/operations [get]
+func (ac *AccessRuleController) Operations(c *response.Context) {
+	options := make([]map[string]string, 0, len(accessrule.Operations))
+	for _, op := range accessrule.Operations {
+		options = append(options, map[string]string{"label": op.Label, "value": op.Name})
+	}
+	amis.WriteJsonData(c, response.H{"options": options})
+}
+
+// @Summary 保存访问规则
+// @Description roles 为平台或集群角色，为空或 * 表示全部用户；operations 为空表示全部请求；cidrs、countries、days、start_time/end_time 至少设置一项。
+// @Description 启用的规则若会阻止当前管理员访问管理接口，将拒绝保存
+// @Security BearerAuth
+// @Param body body models.AccessRule true "访问规则"
+// @Success 200 {object} string
+// @Router /admin/access/rule/save [post]
+func (ac *AccessRuleController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.AccessRule{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.Name == "" {
+		amis.WriteJsonError(c, fmt.Errorf("规则名称不能为空"))
+		return
+	}
+	if _, err := service.AccessRuleService().Compile(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.Enabled {
+		// 避免管理员保存后无法再访问管理接口修正规则
+		req := &service.AccessRequest{
+			Username:   amis.GetLoginUser(c),
+			IP:         listener.ClientIP(c.Request),
+			Country:    listener.Country(c.Request),
+			Operations: accessrule.Classify(c.Request.Method, c.Request.URL.Path),
+			Time:       time.Now(),
+		}
+		if err := service.AccessRuleService().CheckRule(&m, req); err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("保存后将阻止当前管理员访问管理接口，请调整规则: %w", err))
+			return
+		}
+	}
+
+	var err error
+	if m.ID == 0 {
+		err = m.Save(params)
+	} else {
+		fields := []string{"name", "description", "roles", "operations", "clusters", "cidrs", "countries",
+			"days", "start_time", "end_time", "timezone", "enabled", "updated_at"}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.AccessRuleService().Invalidate()
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除访问规则
+// @Security BearerAuth
+// @Param ids path string true "规则ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/access/rule/delete/{ids} [post]
+func (ac *AccessRuleController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 可能由其他管理员创建，不按创建人过滤
+	m := &models.AccessRule{}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.AccessRuleService().Invalidate()
+	amis.WriteJsonOK(c)
+}
+
+// CheckAccessRequest 访问校验请求
+type CheckAccessRequest struct {
+	Username   string `json:"username"`
+	Cluster    string `json:"cluster"`
+	IP         string `json:"ip"`
+	Country    string `json:"country"`
+	Operations string `json:"operations"` // 逗号分隔
+	Time       string `json:"time"`       // RFC3339，为空使用当前时间
+}
+
+// @Summary 校验访问
+// @Description 按当前已启用的规则校验指定用户在给定来源、时间执行操作是否被允许，用于确认规则效果
+// @Security BearerAuth
+// @Param body body CheckAccessRequest true "访问请求"
+// @Success 200 {object} string
+// @Router /admin/access/rule/check [post]
+func (ac *AccessRuleController) Check(c *response.Context) {
+	var body CheckAccessRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if body.Username == "" {
+		amis.WriteJsonError(c, fmt.Errorf("用户名不能为空"))
+		return
+	}
+	at := time.Now()
+	if body.Time != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, body.Time); err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("时间格式错误，应为 RFC3339: %w", err))
+			return
+		}
+	}
+	req := &service.AccessRequest{
+		Username: body.Username,
+		Cluster:  body.Cluster,
+		IP:       body.IP,
+		Country:  body.Country,
+		Time:     at,
+	}
+	for _, op := range strings.Split(body.Operations, ",") {
+		if op = strings.TrimSpace(op); op != "" {
+			req.Operations = append(req.Operations, op)
+		}
+	}
+	if err := service.AccessRuleService().Check(req); err != nil {
+		amis.WriteJsonData(c, response.H{"allowed": false, "message": err.Error()})
+		return
+	}
+	amis.WriteJsonData(c, response.H{"allowed": true})
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+type AdmissionPolicyController struct{}
+
+// RegisterAdmissionPolicyRoutes 注册平台准入策略管理路由
+func RegisterAdmissionPolicyRoutes(r chi.Router) {
+	ctrl := &AdmissionPolicyController{}
+	r.Get("/admission/policy/list", response.Adapter(ctrl.List))
+	r.Post("/admission/policy/save", response.Adapter(ctrl.Save))
+	r.Post("/admission/policy/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/admission/policy/test", response.Adapter(ctrl.Test))
+}
+
+// @Summary 准入策略列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/admission/policy/list [get]
+func (ac *AdmissionPolicyController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.AdmissionPolicy{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存准入策略
+// @Description 新建或编辑策略。expression 为返回布尔值的 expr 表达式，可用变量：cluster、user、operation、group、version、kind、namespace、name、object、patch，函数 images(object) 返回全部容器镜像
+// @Security BearerAuth
+// @Param body body models.AdmissionPolicy true "准入策略"
+// @Success 200 {object} string
+// @Router /admin/admission/policy/save [post]
+func (ac *AdmissionPolicyController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.AdmissionPolicy{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.Name == "" {
+		amis.WriteJsonError(c, fmt.Errorf("策略名称不能为空"))
+		return
+	}
+	if m.Action != admission.ActionDeny && m.Action != admission.ActionWarn {
+		amis.WriteJsonError(c, fmt.Errorf("不支持的策略动作: %s", m.Action))
+		return
+	}
+	if _, err := admission.Compile(m.Expression); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	var err error
+	if m.ID == 0 {
+		err = m.Save(params)
+	} else {
+		fields := []string{"name", "description", "expression", "message", "action", "operations", "kinds", "clusters", "namespaces", "enabled", "updated_at"}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.AdmissionPolicyService().Invalidate()
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除准入策略
+// @Security BearerAuth
+// @Param ids path string true "策略ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/admission/policy/delete/{ids} [post]
+func (ac *AdmissionPolicyController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 策略由平台管理员共同维护，不按创建人过滤
+	m := &models.AdmissionPolicy{}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.AdmissionPolicyService().Invalidate()
+	amis.WriteJsonOK(c)
+}
+
+// TestPolicyRequest 策略试运行请求
+type TestPolicyRequest struct {
+	Expression string `json:"expression"`
+	Yaml       string `json:"yaml"`      // 待评估的资源
+	Operation  string `json:"operation"` // 为空时按 create 评估
+	Cluster    string `json:"cluster"`
+}
+
+// @Summary 试运行准入策略
+// @Description 使用给定资源评估表达式，返回是否命中，不影响已保存的策略
+// @Security BearerAuth
+// @Param body body TestPolicyRequest true "表达式与资源"
+// @Success 200 {object} string
+// @Router /admin/admission/policy/test [post]
+func (ac *AdmissionPolicyController) Test(c *response.Context) {
+	var req TestPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	program, err := admission.Compile(req.Expression)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var obj map[string]any
+	if err := yaml.Unmarshal([]byte(req.Yaml), &obj); err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("资源解析失败: %w", err))
+		return
+	}
+	if req.Operation == "" {
+		req.Operation = "create"
+	}
+	ar := &admission.Request{
+		Cluster:   req.Cluster,
+		User:      amis.GetLoginUser(c),
+		Operation: req.Operation,
+		Object:    obj,
+	}
+	if apiVersion, ok := obj["apiVersion"].(string); ok {
+		if gv, err := schema.ParseGroupVersion(apiVersion); err == nil {
+			ar.Group, ar.Version = gv.Group, gv.Version
+		}
+	}
+	ar.Kind, _ = obj["kind"].(string)
+	if meta, ok := obj["metadata"].(map[string]any); ok {
+		ar.Namespace, _ = meta["namespace"].(string)
+		ar.Name, _ = meta["name"].(string)
+	}
+	matched, err := admission.Eval(program, ar)
+	if err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("表达式运行失败: %w", err))
+		return
+	}
+	amis.WriteJsonData(c, response.H{"matched": matched, "images": admission.ContainerImages(obj)})
+}
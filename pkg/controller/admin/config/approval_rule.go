@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duke-git/lancet/v2/slice"
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type ApprovalRuleController struct{}
+
+// RegisterApprovalRuleRoutes 注册变更审批规则管理路由
+func RegisterApprovalRuleRoutes(r chi.Router) {
+	ctrl := &ApprovalRuleController{}
+	r.Get("/approval/rule/list", response.Adapter(ctrl.List))
+	r.Post("/approval/rule/save", response.Adapter(ctrl.Save))
+	r.Post("/approval/rule/delete/{ids}", response.Adapter(ctrl.Delete))
+}
+
+// @Summary 变更审批规则列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/approval/rule/list [get]
+func (ac *ApprovalRuleController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ApprovalRule{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存变更审批规则
+// @Description namespaces 为受保护的命名空间，逗号分隔，支持 * 通配；命中规则的变更操作将转为变更申请，由另一名审批人批准后执行
+// @Security BearerAuth
+// @Param body body models.ApprovalRule true "变更审批规则"
+// @Success 200 {object} string
+// @Router /admin/approval/rule/save [post]
+func (ac *ApprovalRuleController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.ApprovalRule{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.Name == "" || strings.TrimSpace(m.Namespaces) == "" {
+		amis.WriteJsonError(c, fmt.Errorf("规则名称与受保护的命名空间不能为空"))
+		return
+	}
+	for _, op := range strings.Split(m.Operations, ",") {
+		if op = strings.TrimSpace(op); op != "" && !slice.Contain([]string{"create", "update", "patch", "delete", "*"}, op) {
+			amis.WriteJsonError(c, fmt.Errorf("不支持的操作: %s", op))
+			return
+		}
+	}
+
+	var err error
+	if m.ID == 0 {
+		err = m.Save(params)
+	} else {
+		fields := []string{"name", "description", "clusters", "namespaces", "kinds", "operations", "approvers", "webhooks", "enabled", "updated_at"}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.ApprovalService().Invalidate()
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除变更审批规则
+// @Description 删除规则不影响已提交的变更申请
+// @Security BearerAuth
+// @Param ids path string true "规则ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/approval/rule/delete/{ids} [post]
+func (ac *ApprovalRuleController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.ApprovalRule{}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.ApprovalService().Invalidate()
+	amis.WriteJsonOK(c)
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"io"
+	"path"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"k8s.io/klog/v2"
+)
+
+type ArtifactController struct {
+}
+
+// RegisterArtifactRoutes 注册制品存储管理路由
+func RegisterArtifactRoutes(r chi.Router) {
+	ctrl := &ArtifactController{}
+	r.Get("/artifact/usage", response.Adapter(ctrl.Usage))
+	r.Get("/artifact/list", response.Adapter(ctrl.List))
+	r.Get("/artifact/download", response.Adapter(ctrl.Download))
+	r.Post("/artifact/delete", response.Adapter(ctrl.Delete))
+	r.Post("/artifact/cleanup", response.Adapter(ctrl.Cleanup))
+	r.Get("/artifact/policy/list", response.Adapter(ctrl.PolicyList))
+	r.Post("/artifact/policy/save", response.Adapter(ctrl.PolicySave))
+	r.Post("/artifact/policy/delete/{ids}", response.Adapter(ctrl.PolicyDelete))
+}
+
+// @Summary 制品存储用量报表
+// @Description 返回存储驱动、存储位置，以及各类别制品的数量、占用空间、最早与最新时间和保留策略
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/artifact/usage [get]
+func (ac *ArtifactController) Usage(c *response.Context) {
+	usage, err := service.ArtifactService().Usage(c.Request.Context())
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, usage)
+}
+
+// @Summary 列出制品
+// @Security BearerAuth
+// @Param category query string false "制品类别，如 support-bundle，为空时列出全部"
+// @Success 200 {object} string
+// @Router /admin/artifact/list [get]
+func (ac *ArtifactController) List(c *response.Context) {
+	list, err := service.ArtifactService().List(c.Request.Context(), c.Query("category"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, list)
+}
+
+// @Summary 下载制品
+// @Security BearerAuth
+// @Param key query string true "制品 key"
+// @Success 200 {file} file
+// @Router /admin/artifact/download [get]
+func (ac *ArtifactController) Download(c *response.Context) {
+	key := c.Query("key")
+	body, size, err := service.ArtifactService().Open(c.Request.Context(), key)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	defer body.Close()
+	c.Header("Content-Disposition", "attachment; filename="+path.Base(key))
+	c.Header("Content-Type", "application/octet-stream")
+	if size > 0 {
+		c.Header("Content-Length", strconv.FormatInt(size, 10))
+	}
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		klog.V(6).Infof("下载制品 %s 中断: %v", key, err)
+	}
+}
+
+// @Summary 删除制品
+// @Security BearerAuth
+// @Param body body object true "{keys: 制品 key 列表}"
+// @Success 200 {object} string
+// @Router /admin/artifact/delete [post]
+func (ac *ArtifactController) Delete(c *response.Context) {
+	var req struct {
+		Keys []string `json:"keys"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	err := service.ArtifactService().Delete(c.Request.Context(), req.Keys)
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// @Summary 按保留策略立即清理制品
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/artifact/cleanup [post]
+func (ac *ArtifactController) Cleanup(c *response.Context) {
+	result, err := service.ArtifactService().Cleanup(c.Request.Context())
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
+
+// @Summary 获取制品保留策略列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/artifact/policy/list [get]
+func (ac *ArtifactController) PolicyList(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ArtifactPolicy{}
+
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存制品保留策略
+// @Description 按类别新增或更新，保留天数、数量、总大小均为 0 时不自动清理
+// @Security BearerAuth
+// @Param body body models.ArtifactPolicy true "保留策略"
+// @Success 200 {object} string
+// @Router /admin/artifact/policy/save [post]
+func (ac *ArtifactController) PolicySave(c *response.Context) {
+	m := models.ArtifactPolicy{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.CreatedBy = dao.BuildParams(c).UserName
+	if err := service.ArtifactService().SavePolicy(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"id": m.ID,
+	})
+}
+
+// @Summary 删除制品保留策略
+// @Security BearerAuth
+// @Param ids path string true "策略ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/artifact/policy/delete/{ids} [post]
+func (ac *ArtifactController) PolicyDelete(c *response.Context) {
+	ids := c.Param("ids")
+	params := dao.BuildParams(c)
+	m := &models.ArtifactPolicy{}
+
+	if err := m.Delete(params, ids); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type ClockController struct {
+}
+
+// RegisterClockRoutes 注册时钟偏差检查路由
+func RegisterClockRoutes(r chi.Router) {
+	ctrl := &ClockController{}
+	r.Get("/clock/status", response.Adapter(ctrl.Status))
+	r.Post("/clock/check", response.Adapter(ctrl.Check))
+}
+
+// @Summary 获取时钟偏差检查结果
+// @Description 返回最近一次检查中各集群 apiserver 与 NTP 服务器相对 k8m 服务器的时间偏差
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/clock/status [get]
+func (cc *ClockController) Status(c *response.Context) {
+	status := service.ClockService().Status()
+	if status == nil {
+		status = service.ClockService().Check(c.Request.Context())
+	}
+	amis.WriteJsonData(c, status)
+}
+
+// @Summary 立即检查时钟偏差
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/clock/check [post]
+func (cc *ClockController) Check(c *response.Context) {
+	amis.WriteJsonData(c, service.ClockService().Check(c.Request.Context()))
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/tunnel"
+	"gorm.io/gorm"
+)
+
+type ClusterAgentController struct{}
+
+// RegisterClusterAgentRoutes 注册集群 agent 令牌管理路由
+func RegisterClusterAgentRoutes(r chi.Router) {
+	ctrl := &ClusterAgentController{}
+	r.Get("/cluster_agent/list", response.Adapter(ctrl.List))
+	r.Post("/cluster_agent/save", response.Adapter(ctrl.Save))
+	r.Post("/cluster_agent/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/cluster_agent/id/{id}/reset_token", response.Adapter(ctrl.ResetToken))
+}
+
+// @Summary 集群 agent 列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/cluster_agent/list [get]
+func (ac *ClusterAgentController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.ClusterAgent{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	for _, item := range items {
+		_, item.Online = tunnel.Get(item.Name)
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存集群 agent
+// @Description 新建时签发接入令牌，令牌与名称绑定，仅在新建与重置时返回一次。名称创建后不可修改，停用后在线隧道立即断开
+// @Security BearerAuth
+// @Param body body models.ClusterAgent true "集群 agent"
+// @Success 200 {object} string
+// @Router /admin/cluster_agent/save [post]
+func (ac *ClusterAgentController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.ClusterAgent{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	if m.ID == 0 {
+		if !tunnel.NamePattern.MatchString(m.Name) {
+			amis.WriteJsonError(c, fmt.Errorf("agent 名称不合法，仅支持小写字母、数字、- 和 ."))
+			return
+		}
+		token, err := service.ClusterAgentService().ResetToken(&m)
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		if err := m.Save(params); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		amis.WriteJsonData(c, response.H{"id": m.ID, "name": m.Name, "token": token})
+		return
+	}
+
+	params.UserName = ""
+	existing, err := m.GetOne(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", m.ID)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("agent 不存在: %w", err))
+		return
+	}
+	err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+		return db.Select("description", "enabled", "updated_at")
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if !m.Enabled {
+		tunnel.Close(existing.Name)
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除集群 agent
+// @Description 删除后令牌失效，在线隧道立即断开
+// @Security BearerAuth
+// @Param ids path string true "agent ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/cluster_agent/delete/{ids} [post]
+func (ac *ClusterAgentController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 可能由其他管理员创建，不按创建人过滤
+	m := &models.ClusterAgent{}
+	items, _, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN ?", utils.ToInt64Slice(c.Param("ids")))
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	for _, item := range items {
+		tunnel.Close(item.Name)
+	}
+	amis.WriteJsonOK(c)
+}
+
+// @Summary 重置集群 agent 令牌
+// @Description 签发新令牌并返回，原令牌立即失效，在线隧道随之断开，agent 需使用新令牌重新接入
+// @Security BearerAuth
+// @Param id path int true "agent ID"
+// @Success 200 {object} string
+// @Router /admin/cluster_agent/id/{id}/reset_token [post]
+func (ac *ClusterAgentController) ResetToken(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.ClusterAgent{}
+	item, err := m.GetOne(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", c.Param("id"))
+	})
+	if err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("agent 不存在: %w", err))
+		return
+	}
+	token, err := service.ClusterAgentService().ResetToken(item)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"name": item.Name, "token": token})
+}
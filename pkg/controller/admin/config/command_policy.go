@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type CommandPolicyController struct{}
+
+// RegisterCommandPolicyRoutes 注册容器命令策略管理路由
+func RegisterCommandPolicyRoutes(r chi.Router) {
+	ctrl := &CommandPolicyController{}
+	r.Get("/command/policy/list", response.Adapter(ctrl.List))
+	r.Post("/command/policy/save", response.Adapter(ctrl.Save))
+	r.Post("/command/policy/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/command/policy/check", response.Adapter(ctrl.Check))
+}
+
+// @Summary 命令策略列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/command/policy/list [get]
+func (cc *CommandPolicyController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.CommandPolicy{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存命令策略
+// @Description pattern 为正则表达式，匹配完整命令行的任意部分；scopes 可选 terminal、exec、file，为空表示全部
+// @Security BearerAuth
+// @Param body body models.CommandPolicy true "命令策略"
+// @Success 200 {object} string
+// @Router /admin/command/policy/save [post]
+func (cc *CommandPolicyController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.CommandPolicy{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.Name == "" || m.Pattern == "" {
+		amis.WriteJsonError(c, fmt.Errorf("策略名称与匹配规则不能为空"))
+		return
+	}
+	if m.Action != service.CommandPolicyDeny && m.Action != service.CommandPolicyAllow {
+		amis.WriteJsonError(c, fmt.Errorf("不支持的策略动作: %s", m.Action))
+		return
+	}
+	if _, err := regexp.Compile(m.Pattern); err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("匹配规则不是有效的正则表达式: %w", err))
+		return
+	}
+
+	var err error
+	if m.ID == 0 {
+		err = m.Save(params)
+	} else {
+		fields := []string{"name", "description", "pattern", "action", "scopes", "clusters", "enabled", "updated_at"}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.CommandPolicyService().Invalidate()
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除命令策略
+// @Security BearerAuth
+// @Param ids path string true "策略ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/command/policy/delete/{ids} [post]
+func (cc *CommandPolicyController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 含内置策略，不按创建人过滤
+	m := &models.CommandPolicy{}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	service.CommandPolicyService().Invalidate()
+	amis.WriteJsonOK(c)
+}
+
+// CheckCommandRequest 命令校验请求
+type CheckCommandRequest struct {
+	Cluster string `json:"cluster"`
+	Scope   string `json:"scope"`
+	Command string `json:"command"`
+}
+
+// @Summary 校验命令
+// @Description 按当前已启用的策略校验命令，用于确认策略效果，不会执行命令
+// @Security BearerAuth
+// @Param body body CheckCommandRequest true "命令"
+// @Success 200 {object} string
+// @Router /admin/command/policy/check [post]
+func (cc *CommandPolicyController) Check(c *response.Context) {
+	var req CheckCommandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := service.CommandPolicyService().Check(req.Cluster, req.Scope, req.Command); err != nil {
+		amis.WriteJsonData(c, response.H{"allowed": false, "message": err.Error()})
+		return
+	}
+	amis.WriteJsonData(c, response.H{"allowed": true})
+}
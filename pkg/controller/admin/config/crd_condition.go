@@ -0,0 +1,77 @@
+package config
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type CRDConditionController struct {
+}
+
+// RegisterCRDConditionRoutes 注册自定义资源状态看板配置路由
+func RegisterCRDConditionRoutes(r chi.Router) {
+	ctrl := &CRDConditionController{}
+	r.Get("/crd_condition/list", response.Adapter(ctrl.List))
+	r.Post("/crd_condition/save", response.Adapter(ctrl.Save))
+	r.Post("/crd_condition/delete/{ids}", response.Adapter(ctrl.Delete))
+}
+
+// @Summary 获取自定义资源状态看板配置列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/crd_condition/list [get]
+func (cc *CRDConditionController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.CRDConditionConfig{}
+
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存自定义资源状态看板配置
+// @Description 按 group、kind 新增或更新，types、fields 为逗号分隔的条件类型与状态字段路径
+// @Security BearerAuth
+// @Param body body models.CRDConditionConfig true "看板配置"
+// @Success 200 {object} string
+// @Router /admin/crd_condition/save [post]
+func (cc *CRDConditionController) Save(c *response.Context) {
+	m := models.CRDConditionConfig{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.CreatedBy = dao.BuildParams(c).UserName
+	if err := service.CRConditionService().SaveConfig(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"id": m.ID,
+	})
+}
+
+// @Summary 删除自定义资源状态看板配置
+// @Description 删除后恢复默认配置：展示全部条件，以 Ready 为主条件
+// @Security BearerAuth
+// @Param ids path string true "配置ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/crd_condition/delete/{ids} [post]
+func (cc *CRDConditionController) Delete(c *response.Context) {
+	ids := c.Param("ids")
+	params := dao.BuildParams(c)
+	m := &models.CRDConditionConfig{}
+
+	if err := m.Delete(params, ids); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
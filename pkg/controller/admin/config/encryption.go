@@ -0,0 +1,46 @@
+package config
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type EncryptionController struct{}
+
+// RegisterEncryptionRoutes 注册敏感字段加密状态与主密钥轮换路由
+func RegisterEncryptionRoutes(r chi.Router) {
+	ctrl := &EncryptionController{}
+	r.Get("/encryption/status", response.Adapter(ctrl.Status))
+	r.Post("/encryption/rotate", response.Adapter(ctrl.Rotate))
+}
+
+// @Summary 敏感字段加密状态
+// @Description 当前主密钥版本，以及 kubeconfig、镜像仓库凭据、令牌等敏感字段按主密钥版本统计的记录数。legacy 为内置静态密钥加密，plaintext 为明文
+// @Security BearerAuth
+// @Success 200 {object} service.EncryptionStatus
+// @Router /admin/encryption/status [get]
+func (ec *EncryptionController) Status(c *response.Context) {
+	status, err := service.EncryptionService().Status()
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, status)
+}
+
+// @Summary 重新加密敏感字段
+// @Description 使用当前主密钥重新加密所有未使用当前主密钥的敏感字段，包括旧主密钥、内置静态密钥加密的数据以及明文。
+// @Description 轮换主密钥的步骤：将旧主密钥移入 MASTER_KEY_PREVIOUS，设置新的 MASTER_KEY 与 MASTER_KEY_ID 后重启，调用本接口，确认状态中不再有旧版本后移除旧主密钥
+// @Security BearerAuth
+// @Success 200 {object} []service.EncryptionRotateResult
+// @Router /admin/encryption/rotate [post]
+func (ec *EncryptionController) Rotate(c *response.Context) {
+	results, err := service.EncryptionService().Rotate()
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, int64(len(results)), results)
+}
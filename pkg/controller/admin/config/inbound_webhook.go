@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/inbound"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type InboundWebhookController struct{}
+
+// RegisterInboundWebhookRoutes 注册入站 webhook 管理路由
+func RegisterInboundWebhookRoutes(r chi.Router) {
+	ctrl := &InboundWebhookController{}
+	r.Get("/inbound_webhook/list", response.Adapter(ctrl.List))
+	r.Post("/inbound_webhook/save", response.Adapter(ctrl.Save))
+	r.Post("/inbound_webhook/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/inbound_webhook/id/{id}/reset_secret", response.Adapter(ctrl.ResetSecret))
+}
+
+// @Summary 入站 webhook 列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/inbound_webhook/list [get]
+func (ic *InboundWebhookController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.InboundWebhook{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存入站 webhook
+// @Description action 可选 restart、set_image、apply_template。新建时生成地址标识与签名密钥，签名密钥仅在新建与重置时返回一次。
+// @Description 以创建人身份执行操作
+// @Security BearerAuth
+// @Param body body models.InboundWebhook true "入站 webhook"
+// @Success 200 {object} string
+// @Router /admin/inbound_webhook/save [post]
+func (ic *InboundWebhookController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.InboundWebhook{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := service.InboundWebhookService().Validate(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	if m.ID == 0 {
+		key, err := inbound.NewKey()
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		secret, err := service.InboundWebhookService().ResetSecret(&m)
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		m.Key = key
+		if err := m.Save(params); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		amis.WriteJsonData(c, response.H{"id": m.ID, "url": "/hooks/" + m.Key, "secret": secret})
+		return
+	}
+
+	fields := []string{"name", "description", "action", "cluster", "namespace", "kind", "target", "container",
+		"image_path", "image_pattern", "template_id", "variables", "enabled", "updated_at"}
+	err := m.Save(params, func(db *gorm.DB) *gorm.DB {
+		return db.Select(fields)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除入站 webhook
+// @Security BearerAuth
+// @Param ids path string true "webhook ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/inbound_webhook/delete/{ids} [post]
+func (ic *InboundWebhookController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 可能由其他管理员创建，不按创建人过滤
+	m := &models.InboundWebhook{}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
+
+// @Summary 重置入站 webhook 签名密钥
+// @Description 生成新的签名密钥并返回，原密钥立即失效
+// @Security BearerAuth
+// @Param id path int true "webhook ID"
+// @Success 200 {object} string
+// @Router /admin/inbound_webhook/id/{id}/reset_secret [post]
+func (ic *InboundWebhookController) ResetSecret(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.InboundWebhook{}
+	item, err := m.GetOne(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", c.Param("id"))
+	})
+	if err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("webhook 不存在: %w", err))
+		return
+	}
+	secret, err := service.InboundWebhookService().ResetSecret(item)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"url": "/hooks/" + item.Key, "secret": secret})
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"encoding/base64"
 	"fmt"
 	"net/http"
 
@@ -10,6 +9,8 @@ import (
 	"github.com/weibaohui/k8m/internal/dao"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/envelope"
+	"github.com/weibaohui/k8m/pkg/i18n"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/response"
 	"gorm.io/gorm"
@@ -60,7 +61,7 @@ func (lc *LdapConfigController) LDAPConfigDetail(c *response.Context) {
 		return db.Where("id = ?", id)
 	})
 	if err != nil || conf == nil {
-		c.JSON(http.StatusNotFound, response.H{"status": 1, "msg": "未找到配置"})
+		amis.WriteError(c, http.StatusNotFound, i18n.NewError(i18n.CodeNotFound, "LDAP配置"))
 		return
 	}
 	c.JSON(http.StatusOK, response.H{"status": 0, "msg": "ok", "data": conf})
@@ -86,23 +87,23 @@ func (lc *LdapConfigController) LDAPConfigSave(c *response.Context) {
 				m.BindPassword = old.BindPassword
 			} else if m.BindPassword != old.BindPassword {
 				// 仅当填写新密码时才加密
-				encrypted, err := utils.AesEncrypt([]byte(m.BindPassword))
+				encrypted, err := envelope.Encrypt(m.BindPassword)
 				if err != nil {
-					c.JSON(http.StatusInternalServerError, response.H{"status": 1, "msg": "密码加密失败"})
+					amis.WriteError(c, http.StatusInternalServerError, fmt.Errorf("密码加密失败: %w", err))
 					return
 				}
-				m.BindPassword = base64.StdEncoding.EncodeToString(encrypted)
+				m.BindPassword = encrypted
 			}
 		}
 	} else {
 		// 新增配置时也要对密码进行加密
 		if m.BindPassword != "" {
-			encrypted, err := utils.AesEncrypt([]byte(m.BindPassword))
+			encrypted, err := envelope.Encrypt(m.BindPassword)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, response.H{"status": 1, "msg": "密码加密失败"})
+				amis.WriteError(c, http.StatusInternalServerError, fmt.Errorf("密码加密失败: %w", err))
 				return
 			}
-			m.BindPassword = base64.StdEncoding.EncodeToString(encrypted)
+			m.BindPassword = encrypted
 		}
 	}
 
@@ -186,7 +187,7 @@ func (lc *LdapConfigController) LDAPConfigTestConnect(c *response.Context) {
 	}
 	var req Req
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.H{"status": 1, "msg": "参数错误"})
+		amis.WriteError(c, http.StatusBadRequest, i18n.NewError(i18n.CodeInvalidParam, err))
 		return
 	}
 
@@ -194,7 +195,7 @@ func (lc *LdapConfigController) LDAPConfigTestConnect(c *response.Context) {
 	conn, err := ldap.Dial("tcp", addr)
 	if err != nil {
 		klog.Errorf("连接LDAP服务器失败: %v", err)
-		c.JSON(http.StatusOK, response.H{"status": 1, "msg": fmt.Sprintf("连接LDAP服务器失败: %v", err)})
+		amis.WriteJsonError(c, fmt.Errorf("连接LDAP服务器失败: %w", err))
 		return
 	}
 	defer conn.Close()
@@ -208,8 +209,8 @@ func (lc *LdapConfigController) LDAPConfigTestConnect(c *response.Context) {
 	// 如果明文失败，再尝试解密（兼容编辑后密文场景）
 	decryptedPwd := req.BindPassword
 	if req.BindPassword != "" {
-		if plain, err := utils.AesDecrypt(req.BindPassword); err == nil {
-			decryptedPwd = string(plain)
+		if plain, err := envelope.Decrypt(req.BindPassword); err == nil {
+			decryptedPwd = plain
 			if err := conn.Bind(req.BindDN, decryptedPwd); err == nil {
 				c.JSON(http.StatusOK, response.H{"status": 0, "msg": "连接成功"})
 				return
@@ -218,5 +219,5 @@ func (lc *LdapConfigController) LDAPConfigTestConnect(c *response.Context) {
 	}
 
 	klog.Errorf("管理员账号或密码错误")
-	c.JSON(http.StatusOK, response.H{"status": 1, "msg": "管理员账号或密码错误"})
+	amis.WriteJsonError(c, i18n.NewError(i18n.CodeLDAPBindFailed))
 }
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type ReadOnlyController struct{}
+
+// RegisterReadOnlyRoutes 注册只读模式管理路由
+func RegisterReadOnlyRoutes(r chi.Router) {
+	ctrl := &ReadOnlyController{}
+	r.Get("/readonly/list", response.Adapter(ctrl.List))
+	r.Post("/readonly/save", response.Adapter(ctrl.Save))
+}
+
+// @Summary 只读模式列表
+// @Description 列出当前生效的全局与集群只读设置
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/readonly/list [get]
+func (rc *ReadOnlyController) List(c *response.Context) {
+	items, err := service.ReadOnlyService().List()
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, int64(len(items)), items)
+}
+
+// @Summary 开启或关闭只读模式
+// @Description cluster 为空时设置全局开关；until 为到期时间，到期后自动解除。开启后阻止保存、上传、删除、应用、Exec 等变更操作
+// @Security BearerAuth
+// @Param body body service.ReadOnlyState true "只读模式设置"
+// @Success 200 {object} string
+// @Router /admin/readonly/save [post]
+func (rc *ReadOnlyController) Save(c *response.Context) {
+	var state service.ReadOnlyState
+	if err := c.ShouldBindJSON(&state); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	state.UpdatedBy = amis.GetLoginUser(c)
+	err := service.ReadOnlyService().Set(&state)
+	amis.WriteJsonErrorOrOK(c, err)
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/registry"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type RegistryCredentialController struct{}
+
+// RegisterRegistryCredentialRoutes 注册镜像仓库凭据管理路由
+func RegisterRegistryCredentialRoutes(r chi.Router) {
+	ctrl := &RegistryCredentialController{}
+	r.Get("/registry/credential/list", response.Adapter(ctrl.List))
+	r.Post("/registry/credential/save", response.Adapter(ctrl.Save))
+	r.Post("/registry/credential/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/registry/credential/test", response.Adapter(ctrl.TestLogin))
+	r.Get("/registry/credential/id/{id}/sync/list", response.Adapter(ctrl.SyncList))
+	r.Post("/registry/credential/id/{id}/sync", response.Adapter(ctrl.Sync))
+	r.Post("/registry/credential/sync/delete/{ids}", response.Adapter(ctrl.SyncDelete))
+}
+
+// @Summary 镜像仓库凭据列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/registry/credential/list [get]
+func (rc *RegistryCredentialController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.RegistryCredential{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	// 不返回密码
+	for _, item := range items {
+		item.Password = ""
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存镜像仓库凭据
+// @Description 新建或编辑凭据，编辑时密码留空表示不修改
+// @Security BearerAuth
+// @Param body body models.RegistryCredential true "仓库凭据"
+// @Success 200 {object} string
+// @Router /admin/registry/credential/save [post]
+func (rc *RegistryCredentialController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.RegistryCredential{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.Registry = registry.NormalizeRegistry(m.Registry)
+	if m.Registry == "" {
+		amis.WriteJsonError(c, fmt.Errorf("仓库地址不能为空"))
+		return
+	}
+
+	var err error
+	isEdit := m.ID > 0
+	if !isEdit {
+		err = m.Save(params)
+	} else {
+		fields := []string{"name", "registry", "cluster", "namespace", "username", "insecure", "updated_at"}
+		// 编辑时未填写密码则保留原密码
+		if m.Password != "" {
+			fields = append(fields, "password")
+		}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	// 已同步到集群的 Secret 随凭据更新
+	if isEdit {
+		go service.RegistryCredentialService().ResyncAll(m.ID)
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除镜像仓库凭据
+// @Security BearerAuth
+// @Param ids path string true "凭据ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/registry/credential/delete/{ids} [post]
+func (rc *RegistryCredentialController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 凭据由平台管理员共同维护，不按创建人过滤
+	ids := c.Param("ids")
+	m := &models.RegistryCredential{}
+	if err := m.Delete(params, ids); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	// 同步记录随凭据删除，集群中已同步的 Secret 保留
+	dao.DB().Where("credential_id in ?", utils.ToInt64Slice(ids)).Delete(&models.RegistryCredentialSync{})
+	amis.WriteJsonOK(c)
+}
+
+// TestLoginRequest 测试登录请求，填写 ID 且未填写密码时使用已保存的密码
+type TestLoginRequest struct {
+	ID       uint   `json:"id"`
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Insecure bool   `json:"insecure"`
+}
+
+// @Summary 测试镜像仓库登录
+// @Security BearerAuth
+// @Param body body TestLoginRequest true "仓库凭据"
+// @Success 200 {object} string
+// @Router /admin/registry/credential/test [post]
+func (rc *RegistryCredentialController) TestLogin(c *response.Context) {
+	var req TestLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.ID > 0 && req.Password == "" {
+		var saved models.RegistryCredential
+		if err := dao.DB().First(&saved, req.ID).Error; err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		req.Password = saved.Password
+	}
+	if registry.NormalizeRegistry(req.Registry) == "" {
+		amis.WriteJsonError(c, fmt.Errorf("仓库地址不能为空"))
+		return
+	}
+	client := registry.NewClient(registry.Credential{Username: req.Username, Password: req.Password}, req.Insecure)
+	if err := client.Login(c.Request.Context(), req.Registry); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOKMsg(c, "登录成功")
+}
+
+// @Summary 凭据同步记录列表
+// @Security BearerAuth
+// @Param id path int true "凭据ID"
+// @Success 200 {object} string
+// @Router /admin/registry/credential/id/{id}/sync/list [get]
+func (rc *RegistryCredentialController) SyncList(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	id := c.Param("id")
+	m := &models.RegistryCredentialSync{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("credential_id = ?", id)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// SyncRequest 同步请求
+type SyncRequest struct {
+	Cluster        string   `json:"cluster"`
+	Namespaces     []string `json:"namespaces"`
+	SecretName     string   `json:"secret_name"`     // 为空时使用 k8m-registry-<凭据ID>
+	ServiceAccount string   `json:"service_account"` // 同步后绑定到该 ServiceAccount，如 default，为空不绑定
+}
+
+// @Summary 同步凭据到命名空间
+// @Description 在所选命名空间中创建或更新 imagePullSecret，并可绑定到 ServiceAccount，凭据修改后自动重新同步
+// @Security BearerAuth
+// @Param id path int true "凭据ID"
+// @Param body body SyncRequest true "同步目标"
+// @Success 200 {object} string
+// @Router /admin/registry/credential/id/{id}/sync [post]
+func (rc *RegistryCredentialController) Sync(c *response.Context) {
+	var req SyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Cluster == "" || len(req.Namespaces) == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("请选择集群和命名空间"))
+		return
+	}
+	var cred models.RegistryCredential
+	if err := dao.DB().First(&cred, c.Param("id")).Error; err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.SecretName == "" {
+		req.SecretName = fmt.Sprintf("k8m-registry-%d", cred.ID)
+	}
+
+	results := make([]*models.RegistryCredentialSync, 0, len(req.Namespaces))
+	for _, ns := range req.Namespaces {
+		target := &models.RegistryCredentialSync{}
+		// 同一目标重复同步时更新原记录
+		dao.DB().Where(&models.RegistryCredentialSync{CredentialID: cred.ID, Cluster: req.Cluster, Namespace: ns, SecretName: req.SecretName}).First(target)
+		target.CredentialID = cred.ID
+		target.Cluster = req.Cluster
+		target.Namespace = ns
+		target.SecretName = req.SecretName
+		target.ServiceAccount = req.ServiceAccount
+		if target.CreatedBy == "" {
+			target.CreatedBy = amis.GetLoginUser(c)
+		}
+		_ = service.RegistryCredentialService().Sync(&cred, target)
+		results = append(results, target)
+	}
+	amis.WriteJsonList(c, results)
+}
+
+// @Summary 删除凭据同步记录
+// @Security BearerAuth
+// @Param ids path string true "同步记录ID，多个用逗号分隔"
+// @Param delete_secret query bool false "是否同时删除集群中由 k8m 创建的 Secret"
+// @Success 200 {object} string
+// @Router /admin/registry/credential/sync/delete/{ids} [post]
+func (rc *RegistryCredentialController) SyncDelete(c *response.Context) {
+	ids := utils.ToInt64Slice(c.Param("ids"))
+	if c.Query("delete_secret") == "true" {
+		var targets []*models.RegistryCredentialSync
+		dao.DB().Where("id in ?", ids).Find(&targets)
+		for _, t := range targets {
+			if err := service.RegistryCredentialService().DeleteSecret(t); err != nil {
+				amis.WriteJsonError(c, fmt.Errorf("删除 %s/%s 中的 Secret 失败: %w", t.Cluster, t.Namespace, err))
+				return
+			}
+		}
+	}
+	if err := dao.DB().Where("id in ?", ids).Delete(&models.RegistryCredentialSync{}).Error; err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
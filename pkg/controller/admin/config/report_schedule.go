@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type ReportScheduleController struct{}
+
+// RegisterReportScheduleRoutes 注册报表计划管理路由
+func RegisterReportScheduleRoutes(r chi.Router) {
+	ctrl := &ReportScheduleController{}
+	r.Get("/report_schedule/list", response.Adapter(ctrl.List))
+	r.Get("/report_schedule/types", response.Adapter(ctrl.Types))
+	r.Post("/report_schedule/save", response.Adapter(ctrl.Save))
+	r.Post("/report_schedule/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Post("/report_schedule/id/{id}/run", response.Adapter(ctrl.Run))
+}
+
+// @Summary 报表计划列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/report_schedule/list [get]
+func (rc *ReportScheduleController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ReportSchedule{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 报表类型选项
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /admin/report_schedule/types [get]
+func (rc *ReportScheduleController) Types(c *response.Context) {
+	var options []response.H
+	for _, t := range service.ReportService().Types() {
+		options = append(options, response.H{"label": t.Title, "value": t.Name, "description": t.Description})
+	}
+	amis.WriteJsonData(c, response.H{"options": options})
+}
+
+// @Summary 保存报表计划
+// @Description 按 cron 定期生成报表并发送给 webhook 接收者，邮件接收者收到附件，其他接收者只收到汇总消息。以创建人身份读取集群资源
+// @Security BearerAuth
+// @Param body body models.ReportSchedule true "报表计划"
+// @Success 200 {object} string
+// @Router /admin/report_schedule/save [post]
+func (rc *ReportScheduleController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.ReportSchedule{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := service.ReportScheduleService().Validate(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var err error
+	if m.ID == 0 {
+		err = m.Save(params)
+	} else {
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select("name", "clusters", "types", "format", "cron", "webhooks", "enabled", "next_run_at", "updated_at")
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID, "next_run_at": m.NextRunAt})
+}
+
+// @Summary 删除报表计划
+// @Security BearerAuth
+// @Param ids path string true "计划ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/report_schedule/delete/{ids} [post]
+func (rc *ReportScheduleController) Delete(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = "" // 可能由其他管理员创建，不按创建人过滤
+	m := &models.ReportSchedule{}
+	if err := m.Delete(params, c.Param("ids")); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
+
+// @Summary 立即执行报表计划
+// @Description 以异步任务执行，返回任务ID，可通过 /mgm/tasks/id/{id} 查看进度
+// @Security BearerAuth
+// @Param id path int true "计划ID"
+// @Success 200 {object} string
+// @Router /admin/report_schedule/id/{id}/run [post]
+func (rc *ReportScheduleController) Run(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.ReportSchedule{}
+	item, err := m.GetOne(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", c.Param("id"))
+	})
+	if err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("报表计划不存在: %w", err))
+		return
+	}
+	t, err := service.ReportScheduleService().Run(item)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"task_id": t.ID})
+}
@@ -0,0 +1,216 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type AdminProjectController struct{}
+
+// RegisterAdminProjectRoutes 注册项目（团队）管理路由
+func RegisterAdminProjectRoutes(r chi.Router) {
+	ctrl := &AdminProjectController{}
+	r.Get("/project/list", response.Adapter(ctrl.List))
+	r.Post("/project/save", response.Adapter(ctrl.Save))
+	r.Post("/project/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Get("/project/{id}", response.Adapter(ctrl.Detail))
+	r.Post("/project/{id}/members/save", response.Adapter(ctrl.SaveMembers))
+	r.Post("/project/{id}/namespaces/save", response.Adapter(ctrl.SaveNamespaces))
+	r.Post("/project/{id}/quota/sync", response.Adapter(ctrl.SyncQuota))
+}
+
+// getProject 按路径中的 id 获取项目
+func getProject(c *response.Context) (*models.Project, error) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.Project{}
+	item, err := m.GetOne(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", utils.ToUInt(c.Param("id")))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("项目不存在: %v", err)
+	}
+	return item, nil
+}
+
+// @Summary 项目列表
+// @Description 获取全部项目（团队）
+// @Security BearerAuth
+// @Success 200 {object} []models.Project
+// @Router /admin/project/list [get]
+func (pc *AdminProjectController) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.Project{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 项目详情
+// @Description 获取项目及其成员、包含的集群与命名空间
+// @Security BearerAuth
+// @Param id path int true "项目ID"
+// @Success 200 {object} service.ProjectView
+// @Router /admin/project/{id} [get]
+func (pc *AdminProjectController) Detail(c *response.Context) {
+	item, err := getProject(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	view, err := service.ProjectService().View(item, amis.GetLoginUser(c))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, view)
+}
+
+// @Summary 保存项目
+// @Description 新增或更新项目，项目名称创建后不能修改；quota 为项目内每个命名空间的 ResourceQuota hard，JSON 对象
+// @Security BearerAuth
+// @Param data body models.Project true "项目信息"
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/project/save [post]
+func (pc *AdminProjectController) Save(c *response.Context) {
+	params := dao.BuildParams(c)
+	creator := params.UserName
+	params.UserName = ""
+	m := models.Project{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" || strings.Contains(m.Name, ",") {
+		amis.WriteJsonError(c, fmt.Errorf("项目名称不能为空且不能包含逗号"))
+		return
+	}
+	if _, err := service.ProjectService().ParseQuota(m.Quota); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.ID > 0 {
+		old, err := m.GetOne(params, func(db *gorm.DB) *gorm.DB {
+			return db.Where("id = ?", m.ID)
+		})
+		if err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("项目不存在: %v", err))
+			return
+		}
+		// 模板共享与列表范围均按名称引用项目
+		if old.Name != m.Name {
+			amis.WriteJsonError(c, fmt.Errorf("项目名称创建后不能修改"))
+			return
+		}
+		m.CreatedBy = old.CreatedBy
+	} else {
+		m.CreatedBy = creator
+	}
+	if err := m.Save(params); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"id": m.ID,
+	})
+}
+
+// @Summary 删除项目
+// @Description 删除一个或多个项目及其成员、命名空间，已同步到集群的 ResourceQuota 不会删除
+// @Security BearerAuth
+// @Param ids path string true "项目ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/project/delete/{ids} [post]
+func (pc *AdminProjectController) Delete(c *response.Context) {
+	if err := service.ProjectService().DeleteProjects(utils.ToInt64Slice(c.Param("ids"))); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
+
+// MembersRequest 项目成员列表
+type MembersRequest struct {
+	Members []*models.ProjectMember `json:"members"`
+}
+
+// @Summary 保存项目成员
+// @Description 整体替换项目成员，角色为 owner（负责人，可管理成员）或 member
+// @Security BearerAuth
+// @Param id path int true "项目ID"
+// @Param data body MembersRequest true "成员列表"
+// @Success 200 {object} string
+// @Router /admin/project/{id}/members/save [post]
+func (pc *AdminProjectController) SaveMembers(c *response.Context) {
+	item, err := getProject(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req MembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonErrorOrOK(c, service.ProjectService().SaveMembers(item.ID, req.Members))
+}
+
+// NamespacesRequest 项目包含的集群与命名空间
+type NamespacesRequest struct {
+	Namespaces []*models.ProjectNamespace `json:"namespaces"`
+}
+
+// @Summary 保存项目命名空间
+// @Description 整体替换项目包含的集群与命名空间，namespace 为空表示整个集群
+// @Security BearerAuth
+// @Param id path int true "项目ID"
+// @Param data body NamespacesRequest true "命名空间列表"
+// @Success 200 {object} string
+// @Router /admin/project/{id}/namespaces/save [post]
+func (pc *AdminProjectController) SaveNamespaces(c *response.Context) {
+	item, err := getProject(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req NamespacesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonErrorOrOK(c, service.ProjectService().SaveNamespaces(item.ID, req.Namespaces))
+}
+
+// @Summary 同步项目配额
+// @Description 将项目配额以名为 k8m-project-quota 的 ResourceQuota 创建或更新到项目内的每个命名空间，返回每个命名空间的结果
+// @Security BearerAuth
+// @Param id path int true "项目ID"
+// @Success 200 {object} []service.ProjectQuotaResult
+// @Router /admin/project/{id}/quota/sync [post]
+func (pc *AdminProjectController) SyncQuota(c *response.Context) {
+	item, err := getProject(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	results, err := service.ProjectService().SyncQuota(amis.GetContextWithUser(c), item)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, results)
+}
@@ -1,7 +1,6 @@
 package user
 
 import (
-	"encoding/base64"
 	"fmt"
 
 	"github.com/duke-git/lancet/v2/slice"
@@ -29,6 +28,8 @@ func RegisterAdminUserRoutes(r chi.Router) {
 	r.Post("/user/save", response.Adapter(ctrl.Save))
 	r.Post("/user/delete/{ids}", response.Adapter(ctrl.Delete))
 	r.Post("/user/update_psw/{id}", response.Adapter(ctrl.UpdatePsw))
+	r.Post("/user/{id}/force_rotation", response.Adapter(ctrl.ForcePasswordRotation))
+	r.Post("/user/{id}/groups", response.Adapter(ctrl.AssignGroups))
 	r.Get("/user/option_list", response.Adapter(ctrl.UserOptionList))
 	// 2FA 平台管理员可操作，管理用户
 	r.Post("/user/2fa/disable/{id}", response.Adapter(ctrl.Disable2FA))
@@ -45,7 +46,7 @@ func (a *AdminUserController) List(c *response.Context) {
 
 	queryFuncs := genQueryFuncs(c, params)
 	queryFuncs = append(queryFuncs, func(db *gorm.DB) *gorm.DB {
-		return db.Select([]string{"id", "group_names", "two_fa_enabled", "username", "two_fa_type", "two_fa_app_name", "source", "created_at", "updated_at", "disabled"})
+		return db.Select([]string{"id", "group_names", "two_fa_enabled", "username", "two_fa_type", "two_fa_app_name", "source", "created_at", "updated_at", "disabled", "password_changed_at", "must_change_password"})
 	})
 	items, total, err := m.List(params, queryFuncs...)
 	if err != nil {
@@ -106,6 +107,13 @@ func (a *AdminUserController) Delete(c *response.Context) {
 	params := dao.BuildParams(c)
 	m := &models.User{}
 
+	var usernames []string
+	dao.DB().Model(&models.User{}).Where("id in ?", utils.ToInt64Slice(ids)).Pluck("username", &usernames)
+	if slice.Contain(usernames, amis.GetLoginUser(c)) {
+		amis.WriteJsonError(c, fmt.Errorf("不能删除当前登录的用户"))
+		return
+	}
+
 	queryFuncs := genQueryFuncs(c, params)
 
 	err := m.Delete(params, ids, queryFuncs...)
@@ -113,13 +121,39 @@ func (a *AdminUserController) Delete(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	dao.DB().Where("username in ?", usernames).Delete(&models.PasswordHistory{})
 	// 清除用户的缓存
-	service.UserService().ClearCacheByKey(m.Username)
+	for _, username := range usernames {
+		service.UserService().ClearCacheByKey(username)
+	}
 	amis.WriteJsonOK(c)
 }
 
+// AssignGroupsRequest 设置用户所在用户组的请求
+type AssignGroupsRequest struct {
+	GroupNames []string `json:"group_names"`
+}
+
+// @Summary 设置用户所在的用户组
+// @Description 整体替换用户所在的用户组，用户组必须已存在，传空列表表示移出全部用户组
+// @Security BearerAuth
+// @Accept json
+// @Param id path string true "用户ID"
+// @Param data body AssignGroupsRequest true "用户组列表"
+// @Success 200 {object} string
+// @Router /admin/user/{id}/groups [post]
+func (a *AdminUserController) AssignGroups(c *response.Context) {
+	var req AssignGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonErrorOrOK(c, service.UserService().AssignGroups(utils.ToUInt(c.Param("id")), req.GroupNames))
+}
+
 // @Summary 更新用户密码
-// @Description 根据ID更新用户密码
+// @Description 根据ID重置本地用户密码，新密码须符合密码策略，重置后该用户的登录会话全部失效。
+// @Description must_change_password 为 true 时用户下次登录须再修改密码，适用于下发临时密码
 // @Security BearerAuth
 // @Accept json
 // @Param id path string true "用户ID"
@@ -129,40 +163,36 @@ func (a *AdminUserController) Delete(c *response.Context) {
 func (a *AdminUserController) UpdatePsw(c *response.Context) {
 
 	id := c.Param("id")
-	params := dao.BuildParams(c)
 	m := models.User{}
 	err := c.ShouldBindJSON(&m)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	m.ID = uint(utils.ToInt64(id))
 
-	// 密码 + 盐重新计算
 	pswBytes, err := utils.AesDecrypt(m.Password)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	m.Salt = utils.RandNLengthString(8)
-	psw, err := utils.AesEncrypt([]byte(fmt.Sprintf("%s%s", string(pswBytes), m.Salt)))
-	if err != nil {
-		amis.WriteJsonError(c, err)
-		return
-	}
-
-	m.Password = base64.StdEncoding.EncodeToString(psw)
 
-	queryFuncs := genQueryFuncs(c, params)
-	queryFuncs = append(queryFuncs, func(db *gorm.DB) *gorm.DB {
-		return db.Select([]string{"password", "salt"}).Updates(m)
-	})
-	err = m.Save(params, queryFuncs...)
-	if err != nil {
-		amis.WriteJsonError(c, err)
+	var username string
+	dao.DB().Model(&models.User{}).Where("id = ?", utils.ToInt64(id)).Pluck("username", &username)
+	if username == "" {
+		amis.WriteJsonError(c, fmt.Errorf("用户不存在"))
 		return
 	}
-	amis.WriteJsonOK(c)
+	amis.WriteJsonErrorOrOK(c, service.UserService().ChangePassword(username, string(pswBytes), m.MustChangePassword))
+}
+
+// @Summary 强制用户轮换密码
+// @Description 要求本地用户下次登录时修改密码，并使其已登录的会话立即失效
+// @Security BearerAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} string
+// @Router /admin/user/{id}/force_rotation [post]
+func (a *AdminUserController) ForcePasswordRotation(c *response.Context) {
+	amis.WriteJsonErrorOrOK(c, service.UserService().ForcePasswordRotation(utils.ToUInt(c.Param("id"))))
 }
 
 func genQueryFuncs(c *response.Context, params *dao.Params) []func(*gorm.DB) *gorm.DB {
@@ -266,8 +296,16 @@ func (a *AdminUserController) UserStatusQuickSave(c *response.Context) {
 
 	var entity models.User
 	entity.ID = utils.ToUInt(id)
+	if err := dao.DB().Select("id", "username").First(&entity).Error; err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("用户不存在"))
+		return
+	}
 
 	if disabled == "true" {
+		if entity.Username == amis.GetLoginUser(c) {
+			amis.WriteJsonError(c, fmt.Errorf("不能禁用当前登录的用户"))
+			return
+		}
 		entity.Disabled = true
 	} else {
 		entity.Disabled = false
@@ -278,5 +316,6 @@ func (a *AdminUserController) UserStatusQuickSave(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	service.UserService().ClearCacheByKey(entity.Username)
 	amis.WriteJsonErrorOrOK(c, err)
 }
@@ -1,9 +1,13 @@
 package user
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/duke-git/lancet/v2/slice"
 	"github.com/go-chi/chi/v5"
 	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/response"
@@ -24,6 +28,9 @@ func RegisterAdminUserGroupRoutes(r chi.Router) {
 	r.Post("/user_group/save_menu", response.Adapter(ctrl.SaveUserGroupMenu))
 	r.Post("/user_group/delete/{ids}", response.Adapter(ctrl.DeleteUserGroup))
 	r.Get("/user_group/option_list", response.Adapter(ctrl.GroupOptionList))
+	r.Get("/user_group/mapping/list", response.Adapter(ctrl.ListMapping))
+	r.Post("/user_group/mapping/save", response.Adapter(ctrl.SaveMapping))
+	r.Post("/user_group/mapping/delete/{ids}", response.Adapter(ctrl.DeleteMapping))
 }
 
 // @Summary 获取用户组列表
@@ -128,13 +135,18 @@ func (a *AdminUserGroupController) DeleteUserGroup(c *response.Context) {
 	params := dao.BuildParams(c)
 	m := &models.UserGroup{}
 
+	var groupNames []string
+	dao.DB().Model(&models.UserGroup{}).Where("id in ?", utils.ToInt64Slice(ids)).Pluck("group_name", &groupNames)
+
 	err := m.Delete(params, ids)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
 	// 清除用户组的缓存
-	service.UserService().ClearCacheByKey(m.GroupName)
+	for _, g := range groupNames {
+		service.UserService().ClearCacheByKey(g)
+	}
 	amis.WriteJsonOK(c)
 }
 
@@ -169,3 +181,70 @@ func (a *AdminUserGroupController) GroupOptionList(c *response.Context) {
 		"options": names,
 	})
 }
+
+// @Summary 外部用户组映射列表
+// @Description 获取 OIDC、LDAP 用户组与 k8m 用户组的映射
+// @Security BearerAuth
+// @Success 200 {object} []models.ExternalGroupMapping
+// @Router /admin/user_group/mapping/list [get]
+func (a *AdminUserGroupController) ListMapping(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ExternalGroupMapping{}
+	items, total, err := m.List(params)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存外部用户组映射
+// @Description 新增或更新映射。OIDC 用户登录时 groups 声明中的组按映射转换，未配置映射的组名原样使用；
+// @Description LDAP 用户登录时按 memberOf 中组的 DN 或 CN 转换，并与默认用户组合并
+// @Security BearerAuth
+// @Accept json
+// @Param data body models.ExternalGroupMapping true "映射信息"
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/user_group/mapping/save [post]
+func (a *AdminUserGroupController) SaveMapping(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := models.ExternalGroupMapping{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if m.Source != models.ExternalGroupSourceOIDC && m.Source != models.ExternalGroupSourceLDAP {
+		amis.WriteJsonError(c, fmt.Errorf("不支持的来源: %s", m.Source))
+		return
+	}
+	m.ExternalGroup = strings.TrimSpace(m.ExternalGroup)
+	if m.ExternalGroup == "" {
+		amis.WriteJsonError(c, fmt.Errorf("外部用户组不能为空"))
+		return
+	}
+	var count int64
+	dao.DB().Model(&models.UserGroup{}).Where("group_name = ?", m.GroupName).Count(&count)
+	if count == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("用户组 %s 不存在", m.GroupName))
+		return
+	}
+	if err := m.Save(params); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"id": m.ID,
+	})
+}
+
+// @Summary 删除外部用户组映射
+// @Description 根据ID批量删除映射，已同步的用户组不会变化
+// @Security BearerAuth
+// @Param ids path string true "映射ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /admin/user_group/mapping/delete/{ids} [post]
+func (a *AdminUserGroupController) DeleteMapping(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ExternalGroupMapping{}
+	amis.WriteJsonErrorOrOK(c, m.Delete(params, c.Param("ids")))
+}
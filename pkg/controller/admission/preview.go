@@ -0,0 +1,70 @@
+package admission
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type Controller struct{}
+
+// RegisterAdmissionRoutes 注册准入预演路由
+func RegisterAdmissionRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Post("/admission/preview", response.Adapter(ctrl.Preview))
+}
+
+// PreviewRequest 准入预演请求
+type PreviewRequest struct {
+	Yaml string `json:"yaml"` // 多文档YAML
+}
+
+// @Summary 准入策略预演
+// @Description 以服务端 dryRun 方式逐个提交清单中的资源，返回各准入 Webhook、Gatekeeper、Kyverno、ValidatingAdmissionPolicy 的拒绝原因与警告，不会修改集群
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param body body PreviewRequest true "清单"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/admission/preview [post]
+func (ac *Controller) Preview(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	var req PreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	objs, err := service.ManifestService().Parse(req.Yaml)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if len(objs) == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("清单中没有资源"))
+		return
+	}
+
+	results := make([]*service.AdmissionPreview, 0, len(objs))
+	for _, obj := range objs {
+		result, err := service.ManifestService().PreviewAdmission(ctx, selectedCluster, obj)
+		if err != nil {
+			// 资源类型不存在、无权限等无法预演的情况同样逐个返回
+			result = &service.AdmissionPreview{
+				APIVersion: obj.GetAPIVersion(),
+				Kind:       obj.GetKind(),
+				Namespace:  obj.GetNamespace(),
+				Name:       obj.GetName(),
+				Message:    err.Error(),
+			}
+		}
+		results = append(results, result)
+	}
+	amis.WriteJsonList(c, results)
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"github.com/weibaohui/k8m/pkg/tunnel"
+	"k8s.io/klog/v2"
+)
+
+type Controller struct{}
+
+// RegisterAgentRoutes 注册集群 agent 接入路由
+func RegisterAgentRoutes(r chi.Router) {
+	ctrl := &Controller{}
+	r.Get("/connect", response.Adapter(ctrl.Connect))
+}
+
+// Connect 接收集群内 k8m-agent 主动建立的 WebSocket 隧道。
+// agent 使用管理员为其签发的令牌认证，集群名称以令牌绑定的名称为准，上报的名称不一致时拒绝。
+// 隧道建立后以 Agent/<名称> 注册集群，断开后集群随之断开，agent 重连后自动恢复。
+// 同名 agent 已在线时拒绝新连接。
+func (ac *Controller) Connect(c *response.Context) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	agent, err := service.ClusterAgentService().Authenticate(token)
+	if err != nil {
+		klog.Warningf("agent 连接认证失败，来源 %s: %v", c.Request.RemoteAddr, err)
+		c.JSON(http.StatusUnauthorized, response.H{"msg": "agent 认证失败"})
+		return
+	}
+	name := agent.Name
+	if reported := c.GetHeader(tunnel.AgentNameHeader); reported != "" && reported != name {
+		klog.Warningf("agent[%s] 上报的名称 %s 与令牌不符，来源 %s", name, reported, c.Request.RemoteAddr)
+		c.JSON(http.StatusForbidden, response.H{"msg": "agent 名称与令牌不符"})
+		return
+	}
+	if _, online := tunnel.Get(name); online {
+		c.JSON(http.StatusConflict, response.H{"msg": tunnel.ErrSessionExists.Error()})
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		// agent 不是浏览器，不携带 Origin
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		klog.Errorf("agent[%s] WebSocket Upgrade Error:%v", name, err)
+		return
+	}
+	session, err := tunnel.Register(name, conn)
+	if errors.Is(err, tunnel.ErrSessionExists) {
+		// 检查与登记之间有同名连接接入
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()), time.Now().Add(time.Second))
+		_ = conn.Close()
+		return
+	}
+	defer telemetry.TrackWebSocketSession("agent_tunnel")()
+	go service.ClusterAgentService().Touch(agent, c.Request.RemoteAddr)
+	go service.ClusterService().ConnectAgentCluster(name)
+
+	err = session.Serve()
+	klog.V(4).Infof("agent[%s] 隧道断开: %v", name, err)
+	// 同名 agent 已经以新连接接入时，不能断开集群
+	if _, online := tunnel.Get(name); !online {
+		service.ClusterService().DisconnectAgentCluster(name)
+	}
+}
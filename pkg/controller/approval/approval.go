@@ -0,0 +1,146 @@
+package approval
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+// pendingMaxItems 待我审批列表最多返回的申请数
+const pendingMaxItems = 500
+
+type Controller struct{}
+
+// RegisterApprovalRoutes 注册变更申请与审批路由
+func RegisterApprovalRoutes(mgm chi.Router) {
+	ctrl := &Controller{}
+	mgm.Get("/approval/list", response.Adapter(ctrl.List))
+	mgm.Get("/approval/pending", response.Adapter(ctrl.Pending))
+	mgm.Get("/approval/id/{id}", response.Adapter(ctrl.Detail))
+	mgm.Post("/approval/id/{id}/approve", response.Adapter(ctrl.Approve))
+	mgm.Post("/approval/id/{id}/reject", response.Adapter(ctrl.Reject))
+	mgm.Post("/approval/id/{id}/cancel", response.Adapter(ctrl.Cancel))
+}
+
+// ReviewRequest 审批意见
+type ReviewRequest struct {
+	Comment string `json:"comment"`
+}
+
+// List 列出当前用户提交的变更申请
+// @Summary 我的变更申请
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/approval/list [get]
+func (ac *Controller) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ChangeRequest{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Select(listColumns).Where("requested_by = ?", params.UserName)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// Pending 列出当前用户可以审批的待审批申请
+// @Summary 待我审批的变更申请
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/approval/pending [get]
+func (ac *Controller) Pending(c *response.Context) {
+	username := amis.GetLoginUser(c)
+	var list []*models.ChangeRequest
+	err := dao.DB().Select(listColumns).
+		Where("status = ? and requested_by <> ?", service.ChangeStatusPending, username).
+		Order("id desc").Limit(pendingMaxItems).Find(&list).Error
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	items := make([]*models.ChangeRequest, 0, len(list))
+	for _, cr := range list {
+		if service.ApprovalService().CanReview(username, cr) {
+			items = append(items, cr)
+		}
+	}
+	amis.WriteJsonListWithTotal(c, int64(len(items)), items)
+}
+
+// listColumns 列表不返回变更内容
+var listColumns = []string{"id", "rule_id", "cluster", "kind", "namespace", "name", "operation", "status", "message",
+	"requested_by", "reviewed_by", "reviewed_at", "applied_at", "created_at"}
+
+// Detail 查看变更申请的内容与字段差异，仅申请人与审批人可见
+// @Summary 变更申请详情
+// @Security BearerAuth
+// @Param id path int true "申请ID"
+// @Success 200 {object} string
+// @Router /mgm/approval/id/{id} [get]
+func (ac *Controller) Detail(c *response.Context) {
+	username := amis.GetLoginUser(c)
+	var cr models.ChangeRequest
+	if err := dao.DB().First(&cr, c.Param("id")).Error; err != nil {
+		amis.WriteJsonError(c, fmt.Errorf("变更申请不存在: %w", err))
+		return
+	}
+	if cr.RequestedBy != username && cr.ReviewedBy != username && !service.ApprovalService().CanReview(username, &cr) {
+		amis.WriteJsonError(c, fmt.Errorf("无权查看变更申请 #%d", cr.ID))
+		return
+	}
+	amis.WriteJsonData(c, cr)
+}
+
+// Approve 批准变更申请，批准后立即以申请人身份执行
+// @Summary 批准变更申请
+// @Description 申请人不能审批自己的申请；执行失败时申请状态为 failed，message 为失败原因
+// @Security BearerAuth
+// @Param id path int true "申请ID"
+// @Param body body ReviewRequest false "审批意见"
+// @Success 200 {object} string
+// @Router /mgm/approval/id/{id}/approve [post]
+func (ac *Controller) Approve(c *response.Context) {
+	var req ReviewRequest
+	_ = c.ShouldBindJSON(&req)
+	cr, err := service.ApprovalService().Approve(utils.ToUInt(c.Param("id")), amis.GetLoginUser(c), req.Comment)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	cr.Payload = ""
+	amis.WriteJsonData(c, cr)
+}
+
+// Reject 驳回变更申请
+// @Summary 驳回变更申请
+// @Security BearerAuth
+// @Param id path int true "申请ID"
+// @Param body body ReviewRequest false "审批意见"
+// @Success 200 {object} string
+// @Router /mgm/approval/id/{id}/reject [post]
+func (ac *Controller) Reject(c *response.Context) {
+	var req ReviewRequest
+	_ = c.ShouldBindJSON(&req)
+	_, err := service.ApprovalService().Reject(utils.ToUInt(c.Param("id")), amis.GetLoginUser(c), req.Comment)
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// Cancel 撤回自己提交的待审批申请
+// @Summary 撤回变更申请
+// @Security BearerAuth
+// @Param id path int true "申请ID"
+// @Success 200 {object} string
+// @Router /mgm/approval/id/{id}/cancel [post]
+func (ac *Controller) Cancel(c *response.Context) {
+	_, err := service.ApprovalService().Cancel(utils.ToUInt(c.Param("id")), amis.GetLoginUser(c))
+	amis.WriteJsonErrorOrOK(c, err)
+}
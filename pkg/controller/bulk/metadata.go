@@ -0,0 +1,185 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+type MetadataController struct{}
+
+// RegisterMetadataRoutes 注册批量标签、注解编辑路由，挂载在 /mgm 下，支持跨集群操作
+func RegisterMetadataRoutes(r chi.Router) {
+	ctrl := &MetadataController{}
+	r.Post("/bulk/metadata", response.Adapter(ctrl.Apply))
+}
+
+// MetadataRequest 批量编辑标签、注解的请求
+type MetadataRequest struct {
+	Clusters          []string          `json:"clusters"`           // 目标集群ID列表
+	Group             string            `json:"group"`              // 资源group
+	Version           string            `json:"version"`            // 资源version
+	Kind              string            `json:"kind"`               // 资源kind
+	Namespaces        []string          `json:"namespaces"`         // 命名空间列表，为空表示全部命名空间
+	LabelSelector     string            `json:"label_selector"`     // 标签选择器
+	AddLabels         map[string]string `json:"add_labels"`         // 新增或覆盖的标签
+	RemoveLabels      []string          `json:"remove_labels"`      // 删除的标签key
+	AddAnnotations    map[string]string `json:"add_annotations"`    // 新增或覆盖的注解
+	RemoveAnnotations []string          `json:"remove_annotations"` // 删除的注解key
+	DryRun            bool              `json:"dry_run"`            // 仅预览受影响的资源，不实际修改
+}
+
+// MetadataResult 单个资源的处理结果
+type MetadataResult struct {
+	Cluster           string            `json:"cluster"`
+	Namespace         string            `json:"namespace,omitempty"`
+	Name              string            `json:"name"`
+	Changed           bool              `json:"changed"`
+	LabelsBefore      map[string]string `json:"labels_before,omitempty"`
+	LabelsAfter       map[string]string `json:"labels_after,omitempty"`
+	AnnotationsBefore map[string]string `json:"annotations_before,omitempty"`
+	AnnotationsAfter  map[string]string `json:"annotations_after,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+// @Summary 批量编辑标签与注解
+// @Description 按 kind、命名空间、标签选择器在一个或多个集群中选择资源，批量新增或删除标签、注解，支持 dry_run 预览
+// @Security BearerAuth
+// @Param body body MetadataRequest true "批量编辑参数"
+// @Success 200 {object} string
+// @Router /mgm/bulk/metadata [post]
+func (mc *MetadataController) Apply(c *response.Context) {
+	var req MetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Kind == "" || req.Version == "" {
+		amis.WriteJsonError(c, fmt.Errorf("kind、version不能为空"))
+		return
+	}
+	if len(req.Clusters) == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("请至少选择一个集群"))
+		return
+	}
+	if len(req.AddLabels)+len(req.RemoveLabels)+len(req.AddAnnotations)+len(req.RemoveAnnotations) == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("未指定任何标签或注解变更"))
+		return
+	}
+
+	username := amis.GetLoginUser(c)
+	for _, cluster := range req.Clusters {
+		if err := service.UserService().CheckClusterAccess(username, cluster); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
+
+	patch, err := buildMetadataPatch(&req)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	ctx := amis.GetContextWithUser(c)
+	var results []*MetadataResult
+	for _, cluster := range req.Clusters {
+		results = append(results, mc.applyCluster(ctx, cluster, &req, patch)...)
+	}
+	amis.WriteJsonList(c, results)
+}
+
+// applyCluster 在单个集群中执行批量编辑
+func (mc *MetadataController) applyCluster(ctx context.Context, cluster string, req *MetadataRequest, patch string) []*MetadataResult {
+	var list []*unstructured.Unstructured
+	sql := kom.Cluster(cluster).WithContext(ctx).
+		RemoveManagedFields().
+		GVK(req.Group, req.Version, req.Kind)
+	if len(req.Namespaces) > 0 {
+		sql = sql.Namespace(req.Namespaces...)
+	} else {
+		sql = sql.AllNamespace()
+	}
+	if req.LabelSelector != "" {
+		sql = sql.WithLabelSelector(req.LabelSelector)
+	}
+	if err := sql.List(&list).Error; err != nil {
+		return []*MetadataResult{{Cluster: cluster, Error: err.Error()}}
+	}
+
+	results := make([]*MetadataResult, 0, len(list))
+	for _, item := range list {
+		r := &MetadataResult{
+			Cluster:           cluster,
+			Namespace:         item.GetNamespace(),
+			Name:              item.GetName(),
+			LabelsBefore:      item.GetLabels(),
+			AnnotationsBefore: item.GetAnnotations(),
+		}
+		r.LabelsAfter = applyChanges(r.LabelsBefore, req.AddLabels, req.RemoveLabels)
+		r.AnnotationsAfter = applyChanges(r.AnnotationsBefore, req.AddAnnotations, req.RemoveAnnotations)
+		r.Changed = !maps.Equal(r.LabelsBefore, r.LabelsAfter) || !maps.Equal(r.AnnotationsBefore, r.AnnotationsAfter)
+		results = append(results, r)
+
+		if req.DryRun || !r.Changed {
+			continue
+		}
+		var obj *unstructured.Unstructured
+		err := kom.Cluster(cluster).WithContext(ctx).
+			CRD(req.Group, req.Version, req.Kind).
+			Namespace(item.GetNamespace()).Name(item.GetName()).
+			Patch(&obj, types.MergePatchType, patch).Error
+		if err != nil {
+			klog.V(6).Infof("bulk metadata patch %s %s/%s error: %v", req.Kind, item.GetNamespace(), item.GetName(), err)
+			r.Error = err.Error()
+		}
+	}
+	return results
+}
+
+// applyChanges 计算变更后的键值集合
+func applyChanges(before map[string]string, add map[string]string, remove []string) map[string]string {
+	after := make(map[string]string, len(before)+len(add))
+	maps.Copy(after, before)
+	for _, k := range remove {
+		delete(after, k)
+	}
+	maps.Copy(after, add)
+	return after
+}
+
+// buildMetadataPatch 生成 JSON Merge Patch，删除的key置为null
+func buildMetadataPatch(req *MetadataRequest) (string, error) {
+	build := func(add map[string]string, remove []string) map[string]any {
+		m := map[string]any{}
+		for _, k := range remove {
+			m[k] = nil
+		}
+		for k, v := range add {
+			m[k] = v
+		}
+		return m
+	}
+	metadata := map[string]any{}
+	if len(req.AddLabels)+len(req.RemoveLabels) > 0 {
+		metadata["labels"] = build(req.AddLabels, req.RemoveLabels)
+	}
+	if len(req.AddAnnotations)+len(req.RemoveAnnotations) > 0 {
+		metadata["annotations"] = build(req.AddAnnotations, req.RemoveAnnotations)
+	}
+	bytes, err := json.Marshal(map[string]any{"metadata": metadata})
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,243 @@
+package changeset
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type Controller struct{}
+
+// RegisterChangeSetRoutes 注册变更集路由
+func RegisterChangeSetRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/changeset/list", response.Adapter(ctrl.List))
+	api.Post("/changeset/save", response.Adapter(ctrl.Save))
+	api.Post("/changeset/delete/{ids}", response.Adapter(ctrl.Delete))
+	api.Get("/changeset/id/{id}", response.Adapter(ctrl.Detail))
+	api.Post("/changeset/id/{id}/dry_run", response.Adapter(ctrl.DryRun))
+	api.Post("/changeset/id/{id}/apply", response.Adapter(ctrl.Apply))
+	api.Post("/changeset/id/{id}/cancel", response.Adapter(ctrl.Cancel))
+}
+
+// getChangeSet 读取当前集群下的变更集
+func getChangeSet(c *response.Context, selectedCluster string) (*models.ChangeSet, error) {
+	var cs models.ChangeSet
+	if err := dao.DB().Where("id = ? and cluster = ?", c.Param("id"), selectedCluster).First(&cs).Error; err != nil {
+		return nil, fmt.Errorf("变更集不存在: %w", err)
+	}
+	return &cs, nil
+}
+
+// checkOwner 变更集以创建人身份执行，只有创建人可以修改或立即应用，平台管理员可以取消
+func checkOwner(c *response.Context, cs *models.ChangeSet, allowAdmin bool) error {
+	username := amis.GetLoginUser(c)
+	if cs.CreatedBy == username || (allowAdmin && service.UserService().IsUserPlatformAdmin(username)) {
+		return nil
+	}
+	return fmt.Errorf("只有变更集创建人可以执行该操作")
+}
+
+// @Summary 变更集列表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/list [get]
+func (cc *Controller) List(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.ChangeSet{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Omit("manifests", "results").Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 变更集详情
+// @Description 包含清单与最近一次执行中每个资源的结果
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "变更集ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/id/{id} [get]
+func (cc *Controller) Detail(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	cs, err := getChangeSet(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, cs)
+}
+
+// @Summary 保存变更集
+// @Description 新建或编辑变更集，manifests 为多文档YAML。scheduled_at 为计划应用时间，到期后按依赖顺序应用，为空时需手动应用；只能编辑尚未应用的变更集
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param body body models.ChangeSet true "变更集"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/save [post]
+func (cc *Controller) Save(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := models.ChangeSet{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.Cluster = selectedCluster
+	m.CreatedBy = params.UserName
+	if err := service.ChangeSetService().Validate(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	if m.ID == 0 {
+		m.Status, m.Message, m.Results, m.StartedAt, m.FinishedAt = service.ChangeSetStatusScheduled, "", nil, nil, nil
+		err = m.Save(params)
+	} else {
+		var count int64
+		dao.DB().Model(&models.ChangeSet{}).
+			Where("id = ? and cluster = ? and created_by = ? and status = ?", m.ID, selectedCluster, params.UserName, service.ChangeSetStatusScheduled).
+			Count(&count)
+		if count == 0 {
+			amis.WriteJsonError(c, fmt.Errorf("变更集不存在、不属于当前用户或已开始应用"))
+			return
+		}
+		fields := []string{"name", "description", "manifests", "scheduled_at", "updated_at"}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除变更集
+// @Description 只能删除自己创建且不在应用中的变更集，删除不会撤销已应用的变更
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ids path string true "变更集ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/delete/{ids} [post]
+func (cc *Controller) Delete(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := &models.ChangeSet{}
+	err = m.Delete(params, c.Param("ids"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ? and status <> ?", selectedCluster, service.ChangeSetStatusRunning)
+	})
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// @Summary 预演变更集
+// @Description 以 dryRun=All 方式逐个预演，返回每个资源的结果，不修改集群
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "变更集ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/id/{id}/dry_run [post]
+func (cc *Controller) DryRun(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	cs, err := getChangeSet(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	items, err := service.ChangeSetService().DryRun(amis.GetContextWithUser(c), cs)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, items)
+}
+
+// @Summary 立即应用变更集
+// @Description 不等待计划时间立即应用，任一资源失败时逆序回滚：新创建的资源被删除，更新过的资源恢复为应用前的定义
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "变更集ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/id/{id}/apply [post]
+func (cc *Controller) Apply(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	cs, err := getChangeSet(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := checkOwner(c, cs, false); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	cs, err = service.ChangeSetService().Run(cs.ID)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, cs)
+}
+
+// @Summary 取消变更集
+// @Description 取消尚未开始应用的变更集
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "变更集ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/changeset/id/{id}/cancel [post]
+func (cc *Controller) Cancel(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	cs, err := getChangeSet(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := checkOwner(c, cs, true); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	err = service.ChangeSetService().Cancel(cs.ID)
+	amis.WriteJsonErrorOrOK(c, err)
+}
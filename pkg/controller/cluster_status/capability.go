@@ -0,0 +1,28 @@
+package cluster_status
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 获取集群能力
+// @Description 返回集群是否安装 metrics-server、Gateway API、卷快照等组件以及 k8m 能否在容器中执行命令，并合并管理员设置的功能开关，enabled 为 false 的功能前端应隐藏。探测结果缓存 10 分钟
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param refresh query bool false "为 true 时重新探测"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/capabilities [get]
+func (cc *ClusterController) Capabilities(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	report, err := service.CapabilityService().Get(selectedCluster, c.Query("refresh") == "true")
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, report)
+}
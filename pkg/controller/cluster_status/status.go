@@ -13,6 +13,7 @@ type ClusterController struct{}
 func RegisterClusterRoutes(r chi.Router) {
 	ctrl := &ClusterController{}
 	r.Get("/status/resource_count/cache_seconds/{cache}", response.Adapter(ctrl.ClusterResourceCount))
+	r.Get("/capabilities", response.Adapter(ctrl.Capabilities))
 }
 
 // @Summary 获取集群资源数量统计
@@ -3,16 +3,14 @@ package cm
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"mime/multipart"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
 	"github.com/weibaohui/kom/kom"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,12 +61,12 @@ func (cc *Controller) Import(c *response.Context) {
 	}
 
 	// 保存上传文件
-	tempFilePath, err := saveUploadedFile(file)
+	tempFilePath, release, err := service.UploadStagingService().Stage(amis.GetLoginUser(c), file)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	defer os.Remove(tempFilePath) // 请求结束时删除临时文件
+	defer release() // 请求结束时删除临时文件
 
 	var cm *v1.ConfigMap
 	err = kom.Cluster(selectedCluster).WithContext(ctx).Resource(&v1.ConfigMap{}).Name(name).Namespace(ns).Get(&cm).Error
@@ -239,34 +237,3 @@ func (cc *Controller) Create(c *response.Context) {
 
 	amis.WriteJsonErrorOrOK(c, err)
 }
-
-// saveUploadedFile 保存上传文件并返回临时文件路径
-func saveUploadedFile(file *multipart.FileHeader) (string, error) {
-	// 创建临时目录
-	tempDir, err := os.MkdirTemp("", "upload-*")
-	if err != nil {
-		return "", fmt.Errorf("创建临时目录错误: %v", err)
-	}
-
-	// 使用原始文件名生成临时文件路径
-	tempFilePath := filepath.Join(tempDir, file.Filename)
-
-	// 创建并保存文件
-	tempFile, err := os.Create(tempFilePath)
-	if err != nil {
-		return "", fmt.Errorf("创建临时文件错误: %v", err)
-	}
-	defer tempFile.Close()
-
-	src, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("无法打开上传文件: %v", err)
-	}
-	defer src.Close()
-
-	if _, err := io.Copy(tempFile, src); err != nil {
-		return "", fmt.Errorf("无法写入临时文件: %v", err)
-	}
-
-	return tempFilePath, nil
-}
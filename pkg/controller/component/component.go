@@ -0,0 +1,78 @@
+package component
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type Controller struct{}
+
+// RegisterComponentRoutes 注册集群核心组件配置路由
+func RegisterComponentRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/component/config", response.Adapter(ctrl.Config))
+	api.Post("/component/{component}/config", response.Adapter(ctrl.Update))
+}
+
+// UpdateRequest 组件配置修改请求
+type UpdateRequest struct {
+	Content         string `json:"content"`          // 完整的 Corefile 或 kube-proxy config.conf
+	ResourceVersion string `json:"resource_version"` // 读取配置时返回的 resource_version
+	Confirm         bool   `json:"confirm"`          // 必须为 true，确认已了解修改对全集群的影响
+}
+
+// @Summary 集群核心组件配置
+// @Description 汇总 CoreDNS 的 Corefile 与就绪情况、kube-proxy 的代理模式与配置、以静态 Pod 运行的控制面组件启动参数及 apiserver 准入插件列表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} service.ClusterComponents
+// @Router /k8s/cluster/{cluster}/component/config [get]
+func (cc *Controller) Config(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	result, err := service.ClusterComponentService().Components(amis.GetContextWithUser(c), selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
+
+// @Summary 修改集群核心组件配置
+// @Description component 可选 coredns（修改 Corefile，启用 reload 插件时自动生效）、kube-proxy（修改 config.conf，需重建 kube-proxy Pod 后生效）。
+// @Description 仅平台管理员与集群管理员可以修改；需传入读取时的 resource_version 并确认，配置被他人修改或校验不通过时拒绝写入
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param component path string true "组件：coredns、kube-proxy"
+// @Param body body UpdateRequest true "配置内容"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/component/{component}/config [post]
+func (cc *Controller) Update(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if !service.UserService().IsUserClusterAdmin(amis.GetLoginUser(c), selectedCluster) {
+		amis.WriteJsonError(c, fmt.Errorf("只有平台管理员或集群管理员可以修改集群组件配置"))
+		return
+	}
+	var req UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if !req.Confirm {
+		amis.WriteJsonError(c, fmt.Errorf("修改集群组件配置影响全集群，请确认后再提交"))
+		return
+	}
+	err = service.ClusterComponentService().UpdateConfig(amis.GetContextWithUser(c), selectedCluster, c.Param("component"), req.Content, req.ResourceVersion)
+	amis.WriteJsonErrorOrOK(c, err)
+}
@@ -0,0 +1,260 @@
+package drift
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type Controller struct{}
+
+// RegisterDriftRoutes 注册基线漂移检测路由
+func RegisterDriftRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/drift/baseline/list", response.Adapter(ctrl.List))
+	api.Post("/drift/baseline/save", response.Adapter(ctrl.Save))
+	api.Post("/drift/baseline/delete/{ids}", response.Adapter(ctrl.Delete))
+	api.Post("/drift/baseline/id/{id}/check", response.Adapter(ctrl.Check))
+	api.Get("/drift/baseline/id/{id}/results", response.Adapter(ctrl.Results))
+	api.Post("/drift/baseline/id/{id}/revert", response.Adapter(ctrl.Revert))
+}
+
+// getBaseline 读取当前集群下的基线
+func getBaseline(c *response.Context, selectedCluster string) (*models.DriftBaseline, error) {
+	var b models.DriftBaseline
+	if err := dao.DB().Where("id = ? and cluster = ?", c.Param("id"), selectedCluster).First(&b).Error; err != nil {
+		return nil, fmt.Errorf("基线不存在: %w", err)
+	}
+	return &b, nil
+}
+
+// @Summary 基线列表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/drift/baseline/list [get]
+func (dc *Controller) List(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.DriftBaseline{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	// 不返回令牌与清单内容
+	for _, item := range items {
+		item.GitToken = ""
+		item.Manifests = ""
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存基线
+// @Description 新建或编辑命名空间基线。source 为 upload 时 manifests 填写多文档YAML；为 git 时填写 git_url（原始文件或 tar.gz、zip 归档地址），编辑时令牌留空表示不修改
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param body body models.DriftBaseline true "基线"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/drift/baseline/save [post]
+func (dc *Controller) Save(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := models.DriftBaseline{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.Cluster = selectedCluster
+	if m.Namespace == "" {
+		amis.WriteJsonError(c, fmt.Errorf("命名空间不能为空"))
+		return
+	}
+	switch m.Source {
+	case service.DriftSourceUpload:
+		if _, err := service.ManifestService().Parse(m.Manifests); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	case service.DriftSourceGit:
+		if m.GitURL == "" {
+			amis.WriteJsonError(c, fmt.Errorf("Git 地址不能为空"))
+			return
+		}
+	default:
+		amis.WriteJsonError(c, fmt.Errorf("不支持的基线来源: %s", m.Source))
+		return
+	}
+	if m.CheckInterval < 0 {
+		m.CheckInterval = 0
+	}
+
+	if m.ID == 0 {
+		m.Status, m.Message, m.DriftCount, m.LastCheckedAt = "", "", 0, nil
+		err = m.Save(params)
+	} else {
+		var count int64
+		dao.DB().Model(&models.DriftBaseline{}).Where("id = ? and cluster = ?", m.ID, selectedCluster).Count(&count)
+		if count == 0 {
+			amis.WriteJsonError(c, fmt.Errorf("基线不存在"))
+			return
+		}
+		fields := []string{"name", "namespace", "source", "git_url", "git_path", "manifests", "check_interval", "updated_at"}
+		if m.GitToken != "" {
+			fields = append(fields, "git_token")
+		}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select(fields)
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID})
+}
+
+// @Summary 删除基线
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ids path string true "基线ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/drift/baseline/delete/{ids} [post]
+func (dc *Controller) Delete(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	ids := c.Param("ids")
+	m := &models.DriftBaseline{}
+	err = m.Delete(params, ids, func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	// 只清理已删除基线的结果，他人创建的基线不会被删除
+	dao.DB().Where("baseline_id in ? and baseline_id not in (?)", utils.ToInt64Slice(ids), dao.DB().Model(&models.DriftBaseline{}).Select("id")).
+		Delete(&models.DriftResult{})
+	amis.WriteJsonOK(c)
+}
+
+// @Summary 执行漂移检测
+// @Description 立即比对基线与集群中的实际对象，返回每个资源的结果
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "基线ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/drift/baseline/id/{id}/check [post]
+func (dc *Controller) Check(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	b, err := getBaseline(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	results, err := service.DriftService().Check(amis.GetContextWithUser(c), b.ID)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, results)
+}
+
+// @Summary 最近一次漂移检测结果
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "基线ID"
+// @Param status query string false "按状态过滤：in_sync、drifted、missing、error"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/drift/baseline/id/{id}/results [get]
+func (dc *Controller) Results(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	b, err := getBaseline(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	q := dao.DB().Where("baseline_id = ?", b.ID)
+	if status := c.Query("status"); status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var results []*models.DriftResult
+	if err := q.Order("kind, name").Find(&results).Error; err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, results)
+}
+
+// RevertRequest 恢复单个资源的请求
+type RevertRequest struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// @Summary 恢复资源为基线定义
+// @Description 资源不存在时按基线重新创建，否则将基线中声明的字段写回集群，完成后重新执行检测
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "基线ID"
+// @Param body body RevertRequest true "资源"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/drift/baseline/id/{id}/revert [post]
+func (dc *Controller) Revert(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	b, err := getBaseline(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req RevertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	if err := service.DriftService().Revert(ctx, b.ID, req.Kind, req.Namespace, req.Name); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if _, err := service.DriftService().Check(ctx, b.ID); err != nil {
+		amis.WriteJsonOKMsg(c, fmt.Sprintf("已恢复，重新检测失败: %v", err))
+		return
+	}
+	amis.WriteJsonOKMsg(c, "已恢复为基线定义")
+}
@@ -2,6 +2,11 @@ package dynamic
 
 import (
 	"fmt"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
 )
 
 type yamlRequest struct {
@@ -23,3 +28,10 @@ func getResourcePaths(kind string) ([]string, error) {
 		return nil, fmt.Errorf("unsupported resource kind: %s", kind)
 	}
 }
+
+// touchRecent 记录用户最近访问的资源，异步写入，不影响接口响应
+func touchRecent(c *response.Context, cluster, group, version, kind, ns, name string) {
+	username := amis.GetLoginUser(c)
+	ref := &models.ResourceRef{Cluster: cluster, Group: group, Version: version, Kind: kind, Namespace: ns, Name: name}
+	go service.UserResourceService().Touch(username, ref)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
 	"github.com/weibaohui/kom/kom"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -24,11 +25,13 @@ func RegisterContainerRoutes(r chi.Router) {
 	r.Get("/{kind}/group/{group}/version/{version}/image_pull_secrets/ns/{ns}/name/{name}", response.Adapter(ctrl.ImagePullSecretOptionList))
 	r.Get("/{kind}/group/{group}/version/{version}/container_health_checks/ns/{ns}/name/{name}/container/{container_name}", response.Adapter(ctrl.ContainerHealthChecksInfo))
 	r.Get("/{kind}/group/{group}/version/{version}/container_env/ns/{ns}/name/{name}/container/{container_name}", response.Adapter(ctrl.ContainerEnvInfo))
+	r.Get("/{kind}/group/{group}/version/{version}/container_env_resolved/ns/{ns}/name/{name}/container/{container_name}", response.Adapter(ctrl.ContainerEnvResolved))
 
 	r.Post("/{kind}/group/{group}/version/{version}/update_image/ns/{ns}/name/{name}", response.Adapter(ctrl.UpdateImageTag))
 	r.Post("/{kind}/group/{group}/version/{version}/update_resources/ns/{ns}/name/{name}", response.Adapter(ctrl.UpdateResources))
 	r.Post("/{kind}/group/{group}/version/{version}/update_health_checks/ns/{ns}/name/{name}", response.Adapter(ctrl.UpdateHealthChecks))
 	r.Post("/{kind}/group/{group}/version/{version}/update_env/ns/{ns}/name/{name}", response.Adapter(ctrl.UpdateContainerEnv))
+	r.Post("/{kind}/group/{group}/version/{version}/patch_env/ns/{ns}/name/{name}/container/{container_name}", response.Adapter(ctrl.PatchContainerEnv))
 }
 
 // @Summary 获取容器镜像拉取密钥选项
@@ -468,6 +471,64 @@ func (cc *ContainerController) ContainerEnvInfo(c *response.Context) {
 
 }
 
+// @Summary 获取容器生效的环境变量及其来源
+// @Description 按 kubelet 的顺序列出 envFrom 与 env，解析 ConfigMap/Secret 引用、fieldRef 与 resourceFieldRef；Secret 中的值被掩码，被同名变量覆盖的条目标记 overridden
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param kind path string true "资源类型"
+// @Param group path string true "API组"
+// @Param version path string true "API版本"
+// @Param ns path string true "命名空间"
+// @Param name path string true "资源名称"
+// @Param container_name path string true "容器名称"
+// @Success 200 {array} service.EnvVarView
+// @Router /k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_env_resolved/ns/{ns}/name/{name}/container/{container_name} [get]
+func (cc *ContainerController) ContainerEnvResolved(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	items, err := service.ContainerEnvService().Resolve(ctx, selectedCluster, c.Param("group"), c.Param("version"), c.Param("kind"),
+		c.Param("ns"), c.Param("name"), c.Param("container_name"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, items)
+}
+
+// @Summary 修改容器环境变量
+// @Description 只修改请求中列出的变量，其余 env 与 envFrom 保持不变；set 中的变量写为直接填写的值，remove 中的变量从 env 中删除
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param kind path string true "资源类型"
+// @Param group path string true "API组"
+// @Param version path string true "API版本"
+// @Param ns path string true "命名空间"
+// @Param name path string true "资源名称"
+// @Param container_name path string true "容器名称"
+// @Param body body service.EnvPatch true "环境变量修改内容"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/patch_env/ns/{ns}/name/{name}/container/{container_name} [post]
+func (cc *ContainerController) PatchContainerEnv(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req service.EnvPatch
+	if err = c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	err = service.ContainerEnvService().Patch(ctx, selectedCluster, c.Param("group"), c.Param("version"), c.Param("kind"),
+		c.Param("ns"), c.Param("name"), c.Param("container_name"), &req)
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
 type ContainerEnv struct {
 	ContainerName string            `json:"container_name"`
 	Envs          map[string]string `json:"envs"`
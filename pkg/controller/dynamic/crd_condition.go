@@ -0,0 +1,71 @@
+package dynamic
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/conditions"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 自定义资源状态看板
+// @Description 汇总某类资源全部对象的 status.conditions，按条件类型、状态、原因计数，并按主条件统计健康对象数；
+// @Description 展示的条件类型、主条件与额外统计的状态字段按 CRD 在 /admin/crd_condition 中配置
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param kind path string true "资源类型"
+// @Param group path string true "API组"
+// @Param version path string true "API版本"
+// @Param ns query string false "命名空间，为空时汇总全部命名空间"
+// @Success 200 {object} service.CRConditionSummary
+// @Router /k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/conditions/summary [get]
+func (cc *CRDController) ConditionSummary(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	summary, err := service.CRConditionService().Summary(ctx, selectedCluster, c.Param("group"), c.Param("version"), c.Param("kind"), c.Query("ns"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, summary)
+}
+
+// @Summary 自定义资源状态下钻
+// @Description 按条件类型（可选状态、原因）或按状态字段取值列出对象，status 为 Missing 时列出没有该条件的对象
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param kind path string true "资源类型"
+// @Param group path string true "API组"
+// @Param version path string true "API版本"
+// @Param ns query string false "命名空间"
+// @Param type query string false "条件类型"
+// @Param status query string false "条件状态：True、False、Unknown、Missing"
+// @Param reason query string false "条件原因"
+// @Param field query string false "状态字段路径，如 status.health.status"
+// @Param value query string false "状态字段取值"
+// @Success 200 {object} []conditions.Object
+// @Router /k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/conditions/objects [get]
+func (cc *CRDController) ConditionObjects(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	q := &conditions.Query{
+		Type:   c.Query("type"),
+		Status: c.Query("status"),
+		Reason: c.Query("reason"),
+		Field:  c.Query("field"),
+		Value:  c.Query("value"),
+	}
+	list, err := service.CRConditionService().Objects(ctx, selectedCluster, c.Param("group"), c.Param("version"), c.Param("kind"), c.Query("ns"), q)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, list)
+}
@@ -19,6 +19,8 @@ func RegisterCRDRoutes(r chi.Router) {
 	r.Get("/crd/group/option_list", response.Adapter(ctrl.GroupOptionList))
 	r.Get("/crd/kind/option_list", response.Adapter(ctrl.KindOptionList))
 	r.Get("/crd/status", response.Adapter(ctrl.CRDStatus))
+	r.Get("/{kind}/group/{group}/version/{version}/conditions/summary", response.Adapter(ctrl.ConditionSummary))
+	r.Get("/{kind}/group/{group}/version/{version}/conditions/objects", response.Adapter(ctrl.ConditionObjects))
 }
 
 // @Summary 获取CRD组选项列表
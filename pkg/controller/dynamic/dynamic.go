@@ -2,6 +2,7 @@ package dynamic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
@@ -47,6 +48,7 @@ func RegisterActionRoutes(r chi.Router) {
 // @Param group path string true "资源组"
 // @Param version path string true "资源版本"
 // @Param ns path string true "命名空间"
+// @Param body body object false "查询条件，project 为项目名称时仅查询项目包含的命名空间"
 // @Success 200 {object} string
 // @Router /k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list/ns/{ns} [post]
 func (ac *ActionController) List(c *response.Context) {
@@ -75,6 +77,35 @@ func (ac *ActionController) List(c *response.Context) {
 		return
 	}
 
+	// 指定项目时，查询范围限定为项目在该集群中包含的命名空间
+	if project, ok := jsonData["project"].(string); ok {
+		delete(jsonData, "project")
+		if project != "" {
+			nsList, err = service.ProjectService().ScopeNamespaces(amis.GetLoginUser(c), project, selectedCluster, nsList)
+			if err != nil {
+				amis.WriteJsonError(c, err)
+				return
+			}
+			if len(nsList) == 0 {
+				amis.WriteJsonListWithTotal(c, 0, []*unstructured.Unstructured{})
+				return
+			}
+			ns = strings.Join(nsList, ",")
+		}
+	}
+
+	// 相同用户、相同查询条件的列表结果短期缓存，资源变更时失效
+	cacheKey := ""
+	if service.ListCacheService().TTL() > 0 {
+		if query, err := json.Marshal(jsonData); err == nil {
+			cacheKey = service.ListCacheService().Key(selectedCluster, group, version, kind, ns, amis.GetLoginUser(c), query)
+			if entry, ok := service.ListCacheService().Get(cacheKey); ok {
+				amis.WriteJsonBytesWithETag(c, entry.ETag, entry.Body)
+				return
+			}
+		}
+	}
+
 	var total int64
 	var list []*unstructured.Unstructured
 	sql := kom.Cluster(selectedCluster).WithContext(ctx).
@@ -144,6 +175,13 @@ func (ac *ActionController) List(c *response.Context) {
 		List(&list).Error
 
 	list = ac.fillList(selectedCluster, kind, list)
+	if err == nil && cacheKey != "" {
+		if body, mErr := amis.MarshalListWithTotal(total, list); mErr == nil {
+			etag := service.ListCacheService().Set(cacheKey, body)
+			amis.WriteJsonBytesWithETag(c, etag, body)
+			return
+		}
+	}
 	amis.WriteJsonListTotalWithError(c, total, list, err)
 }
 
@@ -161,6 +199,9 @@ func (ac *ActionController) fillList(selectedCluster string, kind string, list [
 			}
 		}
 	case "Pod":
+		for i := range list {
+			service.PodService().SetContainerInsightOnPod(list[i])
+		}
 		if service.ClusterService().GetPodStatusAggregated(selectedCluster) {
 			// 已缓存聚合状态，可以填充
 			for i := range list {
@@ -277,6 +318,7 @@ func (ac *ActionController) Fetch(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	touchRecent(c, selectedCluster, group, version, kind, ns, name)
 
 	yamlStr, err := utils.ConvertUnstructuredToYAML(obj)
 	if err != nil {
@@ -318,6 +360,10 @@ func (ac *ActionController) FetchJson(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	touchRecent(c, selectedCluster, group, version, kind, ns, name)
+	if kind == "Pod" {
+		service.PodService().SetContainerInsightOnPod(obj)
+	}
 
 	amis.WriteJsonData(c, obj)
 }
@@ -540,6 +586,7 @@ func (ac *ActionController) Describe(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	touchRecent(c, selectedCluster, group, version, kind, ns, name)
 	amis.WriteJsonData(c, string(result))
 }
 
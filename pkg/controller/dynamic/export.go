@@ -0,0 +1,139 @@
+package dynamic
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	utils2 "github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/kom/kom"
+	"github.com/weibaohui/kom/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ExportController struct{}
+
+// RegisterExportRoutes 注册资源导出路由
+func RegisterExportRoutes(api chi.Router) {
+	ctrl := &ExportController{}
+	api.Post("/export", response.Adapter(ctrl.Export))
+}
+
+// ExportItem 待导出的资源
+type ExportItem struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// ExportRequest 资源导出请求
+type ExportRequest struct {
+	Items []ExportItem `json:"items"`
+	// Format 导出格式：为空返回JSON包裹的YAML文本，yaml 下载多文档YAML文件，tar 下载每个资源一个文件的tar包
+	Format string `json:"format"`
+}
+
+// @Summary 导出资源YAML
+// @Description 导出一个或多个资源，移除 status、uid、resourceVersion、managedFields、creationTimestamp 等服务端字段，便于提交到Git或重新应用
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param body body ExportRequest true "导出参数"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/export [post]
+func (ec *ExportController) Export(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req ExportRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if len(req.Items) == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("请选择需要导出的资源"))
+		return
+	}
+
+	ctx := amis.GetContextWithUser(c)
+	docs := make([]string, 0, len(req.Items))
+	fileNames := make([]string, 0, len(req.Items))
+	for _, item := range req.Items {
+		var obj *unstructured.Unstructured
+		err = kom.Cluster(selectedCluster).WithContext(ctx).
+			Name(item.Name).Namespace(item.Namespace).
+			CRD(item.Group, item.Version, item.Kind).
+			Get(&obj).Error
+		if err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("获取资源 %s %s/%s 失败: %w", item.Kind, item.Namespace, item.Name, err))
+			return
+		}
+		yamlStr, err := utils.ConvertUnstructuredToYAML(utils2.CleanupForExport(obj))
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		docs = append(docs, strings.TrimSpace(yamlStr))
+		fileNames = append(fileNames, exportFileName(item))
+	}
+
+	switch req.Format {
+	case "yaml":
+		amis.WriteDownloadData(c, "export.yaml", "application/x-yaml", []byte(strings.Join(docs, "\n---\n")+"\n"))
+	case "tar":
+		data, err := buildExportTar(fileNames, docs)
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		amis.WriteDownloadData(c, "export.tar", "application/x-tar", data)
+	default:
+		amis.WriteJsonData(c, response.H{
+			"yaml": strings.Join(docs, "\n---\n") + "\n",
+		})
+	}
+}
+
+// exportFileName 生成tar包内的文件路径，集群级资源放在 _cluster 目录下
+func exportFileName(item ExportItem) string {
+	dir := item.Namespace
+	if dir == "" {
+		dir = "_cluster"
+	}
+	return path.Join(dir, fmt.Sprintf("%s-%s.yaml", strings.ToLower(item.Kind), item.Name))
+}
+
+// buildExportTar 将多个YAML文档打包为tar
+func buildExportTar(fileNames []string, docs []string) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now()
+	for i, doc := range docs {
+		content := []byte(doc + "\n")
+		hdr := &tar.Header{
+			Name:    fileNames[i],
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
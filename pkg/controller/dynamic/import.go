@@ -0,0 +1,243 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+type ImportController struct{}
+
+// maxImportSize 导入请求体大小上限
+const maxImportSize = 32 << 20
+
+// RegisterImportRoutes 注册清单导入路由
+func RegisterImportRoutes(api chi.Router) {
+	ctrl := &ImportController{}
+	api.Post("/import", response.Adapter(ctrl.Import))
+}
+
+// 单个资源的导入状态
+const (
+	importStatusValid      = "valid"       // 预演通过
+	importStatusPending    = "pending"     // 依赖本次导入中的 Namespace 或 CRD，预演时无法校验
+	importStatusInvalid    = "invalid"     // 预演失败
+	importStatusApplied    = "applied"     // 已应用
+	importStatusFailed     = "failed"      // 应用失败
+	importStatusSkipped    = "skipped"     // 因前序失败未执行
+	importStatusRolledBack = "rolled_back" // 已应用但因后续失败被回滚
+)
+
+// ImportResult 单个资源的导入结果
+type ImportResult struct {
+	File       string `json:"file"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Action     string `json:"action,omitempty"` // create、update、created、updated
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+
+	obj      *unstructured.Unstructured
+	previous *unstructured.Unstructured // 更新前的对象，用于回滚
+}
+
+// @Summary 导入清单
+// @Description 上传 tar、tar.gz、zip 压缩包或多文档YAML，解析校验全部文档并逐个服务端预演，全部通过后按依赖顺序（Namespace、CRD优先）应用。
+// @Description 应用过程中任一资源失败时逆序回滚：本次新创建的资源被删除，更新过的资源恢复为导入前的定义，并返回每个资源的处理结果。
+// @Description 同时按集群版本检查已弃用或已移除的 apiVersion，在 deprecations 中返回建议的替代版本。
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param file formData file false "清单文件或压缩包"
+// @Param yaml formData string false "多文档YAML文本，与file二选一"
+// @Param dry_run formData string false "为true时仅预演不应用"
+// @Param convert_deprecated formData string false "为true时将使用已弃用 apiVersion 且可无损转换的资源自动改为替代版本"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/import [post]
+func (ic *ImportController) Import(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+
+	files, err := readImportFiles(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	dryRun := c.PostForm("dry_run") == "true"
+
+	var results []*ImportResult
+	for _, f := range files {
+		objs, err := service.ManifestService().Parse(f.Content)
+		if err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("%s: %w", f.Name, err))
+			return
+		}
+		for _, obj := range objs {
+			results = append(results, &ImportResult{
+				File:       f.Name,
+				APIVersion: obj.GetAPIVersion(),
+				Kind:       obj.GetKind(),
+				Namespace:  obj.GetNamespace(),
+				Name:       obj.GetName(),
+				obj:        obj,
+			})
+		}
+	}
+	if len(results) == 0 {
+		amis.WriteJsonError(c, fmt.Errorf("未找到任何资源清单"))
+		return
+	}
+
+	objs := make([]*unstructured.Unstructured, len(results))
+	for i, r := range results {
+		objs[i] = r.obj
+	}
+	deprecations, err := service.ManifestService().CheckDeprecated(selectedCluster, objs, c.PostForm("convert_deprecated") == "true")
+	if err != nil {
+		klog.V(6).Infof("import check deprecated api error: %v", err)
+	}
+	for _, r := range results {
+		r.APIVersion = r.obj.GetAPIVersion()
+	}
+	service.ManifestService().SortByDependency(objs)
+	byObj := make(map[*unstructured.Unstructured]*ImportResult, len(results))
+	for _, r := range results {
+		byObj[r.obj] = r
+	}
+	for i, obj := range objs {
+		results[i] = byObj[obj]
+	}
+
+	valid := ic.dryRunAll(ctx, selectedCluster, results)
+	if dryRun || !valid {
+		amis.WriteJsonData(c, response.H{
+			"applied":      false,
+			"valid":        valid,
+			"results":      results,
+			"deprecations": deprecations,
+		})
+		return
+	}
+
+	applied := ic.applyAll(ctx, selectedCluster, results)
+	amis.WriteJsonData(c, response.H{
+		"applied":      applied,
+		"valid":        valid,
+		"results":      results,
+		"deprecations": deprecations,
+	})
+}
+
+// readImportFiles 读取上传的文件或表单中的YAML文本
+func readImportFiles(c *response.Context) ([]*utils.ManifestFile, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return nil, fmt.Errorf("上传内容超过 %dMB 大小限制", maxImportSize>>20)
+		}
+		text := c.PostForm("yaml")
+		if text == "" {
+			return nil, fmt.Errorf("请上传清单文件或填写YAML内容")
+		}
+		return []*utils.ManifestFile{{Name: "input.yaml", Content: text}}, nil
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("打开上传的文件错误: %w", err)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("读取上传的文件错误: %w", err)
+	}
+	return utils.ExtractManifestFiles(file.Filename, data)
+}
+
+// dryRunAll 逐个预演，返回是否全部通过
+func (ic *ImportController) dryRunAll(ctx context.Context, cluster string, results []*ImportResult) bool {
+	// 本次导入中将要创建的 Namespace 和 CRD，依赖它们的资源预演时会失败，标记为 pending
+	newNamespaces := map[string]bool{}
+	newGroups := map[string]bool{}
+	for _, r := range results {
+		switch r.Kind {
+		case "Namespace":
+			newNamespaces[r.Name] = true
+		case "CustomResourceDefinition":
+			if group, found, _ := unstructured.NestedString(r.obj.Object, "spec", "group"); found {
+				newGroups[group] = true
+			}
+		}
+	}
+
+	valid := true
+	for _, r := range results {
+		action, err := service.ManifestService().DryRun(ctx, cluster, r.obj)
+		r.Action = action
+		switch {
+		case err == nil:
+			r.Status = importStatusValid
+		case newGroups[r.obj.GroupVersionKind().Group]:
+			r.Status = importStatusPending
+			r.Message = "依赖本次导入的CRD，应用时校验"
+		case apierrors.IsNotFound(err) && newNamespaces[r.Namespace]:
+			r.Status = importStatusPending
+			r.Message = "依赖本次导入的Namespace，应用时校验"
+		default:
+			r.Status = importStatusInvalid
+			r.Message = err.Error()
+			valid = false
+		}
+	}
+	return valid
+}
+
+// applyAll 按顺序应用，失败时逆序回滚：删除本次新创建的资源，更新过的资源恢复为导入前的定义
+func (ic *ImportController) applyAll(ctx context.Context, cluster string, results []*ImportResult) bool {
+	var done []*ImportResult
+	for i, r := range results {
+		action, previous, err := service.ManifestService().ApplyTracked(ctx, cluster, r.obj)
+		r.Action = action
+		if err == nil {
+			r.Status = importStatusApplied
+			r.Message = ""
+			r.previous = previous
+			done = append(done, r)
+			continue
+		}
+
+		r.Status = importStatusFailed
+		r.Message = err.Error()
+		for _, rest := range results[i+1:] {
+			rest.Status = importStatusSkipped
+		}
+		for j := len(done) - 1; j >= 0; j-- {
+			d := done[j]
+			if err := service.ManifestService().Rollback(ctx, cluster, d.obj, d.Action, d.previous); err != nil {
+				klog.V(6).Infof("import rollback %s %s/%s error: %v", d.Kind, d.obj.GetNamespace(), d.Name, err)
+				d.Message = fmt.Sprintf("回滚失败: %v", err)
+				continue
+			}
+			d.Status = importStatusRolledBack
+		}
+		return false
+	}
+	return true
+}
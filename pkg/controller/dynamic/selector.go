@@ -0,0 +1,98 @@
+package dynamic
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/selector"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type SelectorController struct{}
+
+// RegisterSelectorRoutes 注册选择器校验路由
+func RegisterSelectorRoutes(api chi.Router) {
+	ctrl := &SelectorController{}
+	api.Post("/{kind}/group/{group}/version/{version}/selector/validate", response.Adapter(ctrl.Validate))
+}
+
+// SelectorValidateRequest 选择器校验请求
+type SelectorValidateRequest struct {
+	LabelSelector string `json:"label_selector"`
+	FieldSelector string `json:"field_selector"`
+	// Namespace 命名空间，多个用逗号分隔，为空时统计全部命名空间
+	Namespace string `json:"namespace"`
+}
+
+// SelectorValidateResult 选择器校验结果，校验通过时给出匹配数量
+type SelectorValidateResult struct {
+	Valid bool             `json:"valid"`
+	Label *selector.Result `json:"label"`
+	Field *selector.Result `json:"field"`
+	// Count 按规范化选择器从缓存中统计的匹配数量，缓存有效期内可能与实时结果存在偏差
+	Count      int64  `json:"count"`
+	CountError string `json:"count_error,omitempty"`
+}
+
+// @Summary 校验标签与字段选择器
+// @Description 解析查询构造器提交的标签选择器与字段选择器，返回规范化写法与估算的匹配数量；
+// @Description 语法错误返回出错的字符位置（从 0 开始）。字段选择器支持的字段由资源类型决定，不支持时在 count_error 中给出集群返回的错误
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param kind path string true "资源类型"
+// @Param group path string true "API组"
+// @Param version path string true "API版本"
+// @Param body body SelectorValidateRequest true "选择器"
+// @Success 200 {object} SelectorValidateResult
+// @Router /k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/selector/validate [post]
+func (sc *SelectorController) Validate(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req SelectorValidateRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	result := &SelectorValidateResult{
+		Label: selector.ValidateLabel(req.LabelSelector),
+		Field: selector.ValidateField(req.FieldSelector),
+	}
+	result.Valid = result.Label.Valid() && result.Field.Valid()
+	if !result.Valid {
+		amis.WriteJsonData(c, result)
+		return
+	}
+
+	var ttl time.Duration
+	if cfg := flag.Init(); cfg.ResourceCacheTimeout > 0 {
+		ttl = time.Duration(cfg.ResourceCacheTimeout) * time.Second
+	}
+	sql := kom.Cluster(selectedCluster).WithContext(amis.GetContextWithUser(c)).WithCache(ttl).
+		CRD(c.Param("group"), c.Param("version"), c.Param("kind"))
+	if req.Namespace == "" {
+		sql = sql.AllNamespace()
+	} else {
+		sql = sql.Namespace(strings.Split(req.Namespace, ",")...)
+	}
+	if result.Label.Normalized != "" {
+		sql = sql.WithLabelSelector(result.Label.Normalized)
+	}
+	if result.Field.Normalized != "" {
+		sql = sql.WithFieldSelector(result.Field.Normalized)
+	}
+	var list []*unstructured.Unstructured
+	if err = sql.List(&list).Error; err != nil {
+		result.CountError = err.Error()
+	}
+	result.Count = int64(len(list))
+	amis.WriteJsonData(c, result)
+}
@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/gql"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type Controller struct{}
+
+// RegisterGraphQLRoutes 注册 GraphQL 组合查询路由
+func RegisterGraphQLRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Post("/graphql", response.Adapter(ctrl.Query))
+}
+
+// writeError 按 GraphQL 响应格式返回错误，便于 GraphQL 客户端统一处理
+func writeError(c *response.Context, status int, err error) {
+	c.JSON(status, response.H{
+		"errors": []response.H{{"message": err.Error()}},
+	})
+}
+
+// @Summary GraphQL 组合查询
+// @Description 一次请求按需获取 Deployment、Pod、事件与实时指标等关联资源，需在平台参数中开启。schema 见 pkg/gql/schema.graphql
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param body body gql.Request true "GraphQL 请求，包含 query、operationName、variables"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/graphql [post]
+func (gc *Controller) Query(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err)
+		return
+	}
+	var req gql.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	resp, err := service.GraphQLService().Execute(ctx, selectedCluster, &req)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
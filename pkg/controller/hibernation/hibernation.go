@@ -0,0 +1,200 @@
+package hibernation
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type Controller struct{}
+
+// RegisterHibernationRoutes 注册环境休眠路由
+func RegisterHibernationRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/hibernation/list", response.Adapter(ctrl.List))
+	api.Post("/hibernation/save", response.Adapter(ctrl.Save))
+	api.Post("/hibernation/delete/{ids}", response.Adapter(ctrl.Delete))
+	api.Get("/hibernation/id/{id}/workloads", response.Adapter(ctrl.Workloads))
+	api.Post("/hibernation/id/{id}/sleep", response.Adapter(ctrl.Sleep))
+	api.Post("/hibernation/id/{id}/wake", response.Adapter(ctrl.Wake))
+}
+
+// getHibernation 读取当前集群下的休眠计划
+func getHibernation(c *response.Context, selectedCluster string) (*models.Hibernation, error) {
+	var m models.Hibernation
+	if err := dao.DB().Where("id = ? and cluster = ?", c.Param("id"), selectedCluster).First(&m).Error; err != nil {
+		return nil, fmt.Errorf("休眠计划不存在: %w", err)
+	}
+	return &m, nil
+}
+
+// @Summary 休眠计划列表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/hibernation/list [get]
+func (hc *Controller) List(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.Hibernation{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存休眠计划
+// @Description 新建或编辑休眠计划。namespaces 为逗号分隔的命名空间；enabled 为 true 时按 sleep_cron、wake_cron 定时休眠与唤醒，
+// @Description 如工作日 20 点休眠（0 20 * * 1-5）、8 点唤醒（0 8 * * 1-5）。定时执行以最近一次保存计划的用户身份进行
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param body body models.Hibernation true "休眠计划"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/hibernation/save [post]
+func (hc *Controller) Save(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := models.Hibernation{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.Cluster = selectedCluster
+	// 定时执行使用保存人的权限，不能指定为他人
+	m.CreatedBy = amis.GetLoginUser(c)
+	if err := service.HibernationService().Validate(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	if m.ID == 0 {
+		m.State, m.LastAction, m.LastStatus, m.LastMessage, m.LastRunAt, m.LastTaskID = service.HibernationAwake, "", "", "", nil, 0
+		err = m.Save(params)
+	} else {
+		if _, err := getHibernation(c, selectedCluster); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		params.UserName = ""
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select("name", "namespaces", "sleep_cron", "wake_cron", "enabled", "next_sleep_at", "next_wake_at", "created_by", "updated_at")
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID, "next_sleep_at": m.NextSleepAt, "next_wake_at": m.NextWakeAt})
+}
+
+// @Summary 删除休眠计划
+// @Description 只删除计划，已休眠的工作负载保持当前副本数，可通过注解 k8m.io/hibernated-replicas 查看原副本数
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ids path string true "计划ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/hibernation/delete/{ids} [post]
+func (hc *Controller) Delete(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := &models.Hibernation{}
+	err = m.Delete(params, c.Param("ids"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
+
+// @Summary 休眠计划涉及的工作负载
+// @Description 列出计划中命名空间下的 Deployment、StatefulSet，包括当前副本数与休眠前记录的副本数
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "计划ID"
+// @Success 200 {object} []service.HibernationWorkload
+// @Router /k8s/cluster/{cluster}/hibernation/id/{id}/workloads [get]
+func (hc *Controller) Workloads(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m, err := getHibernation(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	items, err := service.HibernationService().Workloads(amis.GetContextWithUser(c), m)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, items)
+}
+
+// @Summary 立即休眠
+// @Description 将计划中命名空间下的 Deployment、StatefulSet 缩容到 0，原副本数记录在注解 k8m.io/hibernated-replicas 中。
+// @Description 带有注解 k8m.io/hibernate: "false" 的工作负载不参与休眠。以异步任务执行，返回任务ID
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "计划ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/hibernation/id/{id}/sleep [post]
+func (hc *Controller) Sleep(c *response.Context) {
+	hc.run(c, service.HibernationSleep)
+}
+
+// @Summary 立即唤醒
+// @Description 将休眠的工作负载恢复到原副本数并移除注解；休眠期间已手动调整副本数的保留当前值。以异步任务执行，返回任务ID
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path int true "计划ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/hibernation/id/{id}/wake [post]
+func (hc *Controller) Wake(c *response.Context) {
+	hc.run(c, service.HibernationWake)
+}
+
+func (hc *Controller) run(c *response.Context, action string) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m, err := getHibernation(c, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	t, err := service.HibernationService().Run(m, action, amis.GetLoginUser(c))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"task_id": t.ID})
+}
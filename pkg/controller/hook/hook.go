@@ -0,0 +1,92 @@
+package hook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/inbound"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"k8s.io/klog/v2"
+)
+
+// maxPayloadSize 请求体大小上限
+const maxPayloadSize = 1 << 20
+
+type Controller struct{}
+
+// RegisterHookRoutes 注册入站 webhook 接收路由，使用请求体签名认证，无需登录
+func RegisterHookRoutes(r chi.Router) {
+	ctrl := &Controller{}
+	r.Post("/{key}", response.Adapter(ctrl.Receive))
+}
+
+// @Summary 接收入站 webhook
+// @Description 外部系统触发预定义的操作。请求头 X-K8m-Signature-256（或 X-Hub-Signature-256）为请求体的 HMAC-SHA256 签名，格式 sha256=<hex>。
+// @Description 可选请求头 X-K8m-Timestamp 为 Unix 秒，携带时签名内容为 "<timestamp>.<body>"，与服务器时间相差超过 5 分钟的请求被拒绝。
+// @Description 请求头 X-K8m-Delivery（或 X-GitHub-Delivery）为投递标识，24 小时内重复的标识返回 409
+// @Description 请求体为 JSON：set_image 从配置的路径读取镜像或标签，apply_template 可通过 variables 覆盖已声明的模板变量
+// @Param key path string true "webhook 标识"
+// @Success 200 {object} string
+// @Router /hooks/{key} [post]
+func (hc *Controller) Receive(c *response.Context) {
+	hook, err := service.InboundWebhookService().GetByKey(c.Param("key"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.H{"message": err.Error()})
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPayloadSize))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, response.H{"message": "请求体过大"})
+		return
+	}
+	var signature string
+	for _, h := range inbound.SignatureHeaders {
+		if signature = c.GetHeader(h); signature != "" {
+			break
+		}
+	}
+	if err := service.InboundWebhookService().Verify(hook, body, signature, c.GetHeader(inbound.TimestampHeader)); err != nil {
+		klog.Warningf("webhook[%s]签名校验失败，来源 %s: %v", hook.Name, c.Request.RemoteAddr, err)
+		c.JSON(http.StatusUnauthorized, response.H{"message": err.Error()})
+		return
+	}
+	// 签名通过后再记录投递标识，避免未认证的请求占用标识
+	var delivery string
+	for _, h := range inbound.DeliveryHeaders {
+		if delivery = c.GetHeader(h); delivery != "" {
+			break
+		}
+	}
+	if err := service.InboundWebhookService().RecordDelivery(hook, delivery); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrDuplicateDelivery) {
+			status = http.StatusConflict
+			klog.Warningf("webhook[%s]收到重复投递 %s，来源 %s", hook.Name, delivery, c.Request.RemoteAddr)
+		}
+		c.JSON(status, response.H{"message": err.Error()})
+		return
+	}
+	// GitHub 保存 webhook 时发送 ping 事件，仅用于确认地址与密钥
+	if c.GetHeader("X-GitHub-Event") == "ping" {
+		c.JSON(http.StatusOK, response.H{"message": "pong"})
+		return
+	}
+
+	payload := map[string]any{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			c.JSON(http.StatusBadRequest, response.H{"message": "请求体不是有效的 JSON 对象: " + err.Error()})
+			return
+		}
+	}
+	message, err := service.InboundWebhookService().Trigger(hook, payload)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, response.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.H{"message": message})
+}
@@ -0,0 +1,79 @@
+package hook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/envelope"
+	"github.com/weibaohui/k8m/pkg/inbound"
+	"github.com/weibaohui/k8m/pkg/middleware"
+	"github.com/weibaohui/k8m/pkg/models"
+)
+
+// 引入 service 包会在当前目录创建数据库文件
+func TestMain(m *testing.M) {
+	code := m.Run()
+	_ = os.RemoveAll("data")
+	os.Exit(code)
+}
+
+// newRouter 与 main 相同：/hooks 挂载在 /k8s/cluster 之外，请求经过完整的校验中间件
+func newRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.RequestChain()...)
+	r.Route("/hooks", func(hooks chi.Router) {
+		RegisterHookRoutes(hooks)
+	})
+	return r
+}
+
+func TestReceiveThroughMiddleware(t *testing.T) {
+	secret, err := envelope.Encrypt("s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := &models.InboundWebhook{Name: "ping", Key: "route-test", Secret: secret, Enabled: true, Action: "restart"}
+	if err := dao.DB().Create(hook).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dao.DB().Delete(hook) })
+
+	router := newRouter()
+	send := func(key string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/hooks/"+key, strings.NewReader(`{}`))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	signed := map[string]string{
+		"X-Hub-Signature-256": inbound.Sign("s3cret", []byte(`{}`)),
+		"X-GitHub-Event":      "ping",
+		"X-GitHub-Delivery":   "d-1",
+	}
+	tests := []struct {
+		name    string
+		key     string
+		headers map[string]string
+		want    int
+	}{
+		{"unknown key", "missing", nil, http.StatusNotFound},
+		{"missing signature", "route-test", nil, http.StatusUnauthorized},
+		{"signed ping", "route-test", signed, http.StatusOK},
+		{"replayed delivery", "route-test", signed, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		w := send(tt.key, tt.headers)
+		if w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d, body %s", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+}
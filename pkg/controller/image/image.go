@@ -0,0 +1,245 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/registry"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+type Controller struct{}
+
+// RegisterImageRoutes 注册镜像元数据查询路由
+func RegisterImageRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/image/inspect", response.Adapter(ctrl.Inspect))
+	api.Get("/image/workload/kind/{kind}/ns/{ns}/name/{name}", response.Adapter(ctrl.WorkloadImages))
+	api.Get("/image/pull_failures", response.Adapter(ctrl.PullFailures))
+	api.Post("/image/workload/kind/{kind}/ns/{ns}/name/{name}/update", response.Adapter(ctrl.UpdateWorkloadImage))
+	api.Post("/image/workload/kind/{kind}/ns/{ns}/name/{name}/container/{container}/rollback", response.Adapter(ctrl.RollbackWorkloadImage))
+}
+
+// ContainerImage 工作负载中单个容器使用的镜像及其元数据
+type ContainerImage struct {
+	Container string              `json:"container"`
+	Init      bool                `json:"init,omitempty"` // 是否为 initContainer
+	Image     string              `json:"image"`
+	Info      *registry.ImageInfo `json:"info,omitempty"`
+	Error     string              `json:"error,omitempty"` // 读取失败原因，不影响其他容器
+}
+
+// @Summary 查询镜像元数据
+// @Description 从镜像仓库读取镜像的层、大小、入口命令、环境变量、端口、标签、创建时间等信息，私有仓库使用平台配置的仓库凭据
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param image query string true "镜像名称，如 nginx:1.25"
+// @Param namespace query string false "命名空间，用于匹配命名空间级别的仓库凭据"
+// @Param platform query string false "多架构镜像选择的平台，默认 linux/amd64"
+// @Success 200 {object} registry.ImageInfo
+// @Router /k8s/cluster/{cluster}/image/inspect [get]
+func (ic *Controller) Inspect(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	image := c.Query("image")
+	if image == "" {
+		amis.WriteJsonError(c, fmt.Errorf("镜像名称不能为空"))
+		return
+	}
+	info, err := service.ImageService().Inspect(ctx, selectedCluster, c.Query("namespace"), image, nil, c.Query("platform"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, info)
+}
+
+// @Summary 查询工作负载使用的镜像元数据
+// @Description 读取工作负载所有容器（含 initContainer）使用的镜像元数据，私有仓库依次使用平台配置的仓库凭据、工作负载的 imagePullSecrets
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param kind path string true "资源类型，支持 Pod、Deployment、StatefulSet、DaemonSet、ReplicaSet、Job、CronJob"
+// @Param ns path string true "命名空间"
+// @Param name path string true "资源名称"
+// @Param platform query string false "多架构镜像选择的平台，默认 linux/amd64"
+// @Success 200 {array} ContainerImage
+// @Router /k8s/cluster/{cluster}/image/workload/kind/{kind}/ns/{ns}/name/{name} [get]
+func (ic *Controller) WorkloadImages(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ns := c.Param("ns")
+	spec, err := getPodSpec(ctx, selectedCluster, c.Param("kind"), ns, c.Param("name"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	var pullSecrets []string
+	for _, s := range spec.ImagePullSecrets {
+		pullSecrets = append(pullSecrets, s.Name)
+	}
+	var items []*ContainerImage
+	for _, ctn := range spec.InitContainers {
+		items = append(items, &ContainerImage{Container: ctn.Name, Init: true, Image: ctn.Image})
+	}
+	for _, ctn := range spec.Containers {
+		items = append(items, &ContainerImage{Container: ctn.Name, Image: ctn.Image})
+	}
+
+	platform := c.Query("platform")
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func(item *ContainerImage) {
+			defer wg.Done()
+			info, err := service.ImageService().Inspect(ctx, selectedCluster, ns, item.Image, pullSecrets, platform)
+			if err != nil {
+				item.Error = err.Error()
+				return
+			}
+			item.Info = info
+		}(item)
+	}
+	wg.Wait()
+	amis.WriteJsonList(c, items)
+}
+
+// getPodSpec 读取工作负载的 Pod 模板
+func getPodSpec(ctx context.Context, cluster, kind, ns, name string) (*v1.PodSpec, error) {
+	k := kom.Cluster(cluster).WithContext(ctx)
+	switch strings.ToLower(kind) {
+	case "pod":
+		var obj v1.Pod
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec, err
+	case "deployment":
+		var obj appsv1.Deployment
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec.Template.Spec, err
+	case "statefulset":
+		var obj appsv1.StatefulSet
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec.Template.Spec, err
+	case "daemonset":
+		var obj appsv1.DaemonSet
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec.Template.Spec, err
+	case "replicaset":
+		var obj appsv1.ReplicaSet
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec.Template.Spec, err
+	case "job":
+		var obj batchv1.Job
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec.Template.Spec, err
+	case "cronjob":
+		var obj batchv1.CronJob
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &obj.Spec.JobTemplate.Spec.Template.Spec, err
+	}
+	return nil, fmt.Errorf("不支持的资源类型: %s", kind)
+}
+
+// @Summary 镜像拉取失败分析
+// @Description 列出处于 ImagePullBackOff/ErrImagePull 状态的容器，分析是否由缺少仓库凭据导致，并给出可同步的平台凭据
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param namespace query string false "命名空间，为空查询全部"
+// @Param cause query string false "按原因过滤：missing_credentials、invalid_credentials、image_not_found、other"
+// @Success 200 {array} service.ImagePullFailure
+// @Router /k8s/cluster/{cluster}/image/pull_failures [get]
+func (ic *Controller) PullFailures(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	items, err := service.RegistryCredentialService().PullFailures(ctx, selectedCluster, c.Query("namespace"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if cause := c.Query("cause"); cause != "" {
+		filtered := []*service.ImagePullFailure{}
+		for _, item := range items {
+			if item.Cause == cause {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+	amis.WriteJsonList(c, items)
+}
+
+// @Summary 更新工作负载容器镜像
+// @Description 只修改一个容器的镜像，无需编辑完整YAML。image 可带标签或摘要；pin_digest=true 时从镜像仓库解析标签对应的摘要，写入 image:tag@sha256:xxx。更新前的镜像记录在工作负载注解 previous-image.k8m.io/<容器名> 中，可一键回滚
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param kind path string true "资源类型，支持 Deployment、StatefulSet、DaemonSet"
+// @Param ns path string true "命名空间"
+// @Param name path string true "资源名称"
+// @Param body body service.WorkloadImageUpdate true "镜像更新内容"
+// @Success 200 {object} service.WorkloadImageResult
+// @Router /k8s/cluster/{cluster}/image/workload/kind/{kind}/ns/{ns}/name/{name}/update [post]
+func (ic *Controller) UpdateWorkloadImage(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req service.WorkloadImageUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	result, err := service.ImageService().UpdateWorkloadImage(ctx, selectedCluster, c.Param("kind"), c.Param("ns"), c.Param("name"), &req)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
+
+// @Summary 回滚工作负载容器镜像
+// @Description 将容器镜像恢复为上一次通过镜像更新接口修改前的镜像，回滚前的镜像同样被记录，再次回滚即可撤销
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param kind path string true "资源类型，支持 Deployment、StatefulSet、DaemonSet"
+// @Param ns path string true "命名空间"
+// @Param name path string true "资源名称"
+// @Param container path string true "容器名称"
+// @Success 200 {object} service.WorkloadImageResult
+// @Router /k8s/cluster/{cluster}/image/workload/kind/{kind}/ns/{ns}/name/{name}/container/{container}/rollback [post]
+func (ic *Controller) RollbackWorkloadImage(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	result, err := service.ImageService().RollbackWorkloadImage(ctx, selectedCluster, c.Param("kind"), c.Param("ns"), c.Param("name"), c.Param("container"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
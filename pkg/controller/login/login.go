@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -17,6 +19,7 @@ import (
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/totp"
 	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/listener"
 	"github.com/weibaohui/k8m/pkg/service"
 	"k8s.io/klog/v2"
 )
@@ -28,6 +31,45 @@ type Controller struct{}
 func RegisterLoginRoutes(r chi.Router) {
 	ctrl := &Controller{}
 	r.Post("/login", response.Adapter(ctrl.LoginByPassword))
+	r.Get("/external", response.Adapter(ctrl.LoginByExternal))
+}
+
+// LoginByExternal 由客户端证书或可信代理头认证的用户直接登录，签发 Token 写入前端后跳转首页。
+// 网关或反向代理可将登录入口指向该地址，避免在 k8m 中再次登录
+// @Summary 外部身份登录
+// @Description 使用监听识别出的客户端证书 CN 或可信代理传递的用户名登录，返回写入 Token 并跳转首页的页面
+// @Success 200 {object} string "登录成功"
+// @Failure 401 {object} string "未识别到外部身份"
+// @Router /auth/external [get]
+func (lc *Controller) LoginByExternal(c *response.Context) {
+	username, source, ok := listener.ExternalUser(c.Request)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.H{"message": "未识别到客户端证书或可信代理传递的用户"})
+		return
+	}
+	if err := service.UserService().EnsureExternalUser(username, source); err != nil {
+		klog.Errorf("外部身份[%s:%s]登录失败: %v", source, username, err)
+		c.JSON(http.StatusUnauthorized, response.H{"message": err.Error()})
+		return
+	}
+	token, err := service.UserService().GenerateLoginToken(username, 24*time.Hour, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.H{"message": err.Error()})
+		return
+	}
+	html := fmt.Sprintf(`<!DOCTYPE html>
+<html>
+  <head><title>Login Success</title></head>
+  <body>
+    <script>
+      localStorage.setItem("token", %q);
+      window.location.href = "/#/";
+    </script>
+    <p>登录成功，正在跳转...</p>
+  </body>
+</html>
+`, token)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
 }
 
 // Request  用户结构体
@@ -87,7 +129,7 @@ func (lc *Controller) LoginByPassword(c *response.Context) {
 			return
 		}
 		// Admin用户不需要2FA验证
-		token, _ := service.UserService().GenerateJWTTokenOnlyUserName(req.Username, time.Hour*24)
+		token, _ := service.UserService().GenerateLoginToken(req.Username, time.Hour*24, false)
 		c.JSON(http.StatusOK, response.H{"token": token})
 		return
 	} else {
@@ -135,14 +177,16 @@ func (lc *Controller) LoginByPassword(c *response.Context) {
 						return
 					}
 					// 验证2FA代码
-					if !totp.ValidateCode(v.TwoFASecret, req.Code) {
+					if !totp.ValidateCode(v.TwoFASecret, req.Code, uint(flag.Init().TOTPSkew)) {
 						c.JSON(http.StatusUnauthorized, response.H{"message": "2FA验证码错误"})
 						return
 					}
 				}
 
-				token, _ := service.UserService().GenerateJWTTokenOnlyUserName(v.Username, 24*time.Hour)
-				c.JSON(http.StatusOK, response.H{"token": token})
+				// 密码过期或被要求轮换时，签发的 Token 只能用于修改密码
+				mustChange := service.UserService().PasswordExpired(v)
+				token, _ := service.UserService().GenerateLoginToken(v.Username, 24*time.Hour, mustChange)
+				c.JSON(http.StatusOK, response.H{"token": token, "must_change_password": mustChange})
 				return
 			}
 		}
@@ -154,7 +198,7 @@ func (lc *Controller) LoginByPassword(c *response.Context) {
 // handleLDAPLogin 处理LDAP登录流程
 func handleLDAPLogin(c *response.Context, username, password, code string, cfg *flag.Config) error {
 	// 1. LDAP认证
-	_, err := service.UserService().LoginWithLdap(username, password, cfg)
+	entry, err := service.UserService().LoginWithLdap(username, password, cfg)
 	if err != nil {
 		klog.Errorf("LDAP登录失败: %v", err)
 		c.JSON(http.StatusUnauthorized, response.H{"message": "LDAP登录验证失败"})
@@ -174,6 +218,15 @@ func handleLDAPLogin(c *response.Context, username, password, code string, cfg *
 	if err == nil && config != nil {
 		defaultGroup = config.DefaultGroup
 	}
+	// memberOf 中按映射转换得到的用户组，配置了映射时每次登录都会同步
+	mapped := service.UserService().MapExternalGroups(models.ExternalGroupSourceLDAP, entry.GetAttributeValues("memberOf"), false)
+	groups := defaultGroup
+	if len(mapped) > 0 {
+		if defaultGroup != "" && !slices.Contains(mapped, defaultGroup) {
+			mapped = append([]string{defaultGroup}, mapped...)
+		}
+		groups = strings.Join(mapped, ",")
+	}
 
 	// 2. 检查用户是否已存在
 	userModel := &models.User{}
@@ -185,10 +238,15 @@ func handleLDAPLogin(c *response.Context, username, password, code string, cfg *
 			c.JSON(http.StatusUnauthorized, response.H{"message": "用户被禁用"})
 			return errors.New("用户被禁用")
 		}
-		// 已存在直接走后续流程
+		if len(mapped) > 0 && userModel.Source == "ldap_config" && userModel.GroupNames != groups {
+			if err := userModel.UpdateColumn("group_names", groups); err != nil {
+				klog.Errorf("同步LDAP用户[%s]用户组失败: %v", username, err)
+			}
+			service.UserService().ClearCacheByKey(username)
+		}
 	} else if errors.Is(err, gorm.ErrRecordNotFound) {
 		// 用户不存在，插入
-		if err := service.UserService().CheckAndCreateUser(username, "ldap_config", defaultGroup); err != nil {
+		if err := service.UserService().CheckAndCreateUser(username, "ldap_config", groups); err != nil {
 			klog.Errorf("创建/检查LDAP用户失败: %v", err)
 			c.JSON(http.StatusInternalServerError, response.H{"message": "系统错误"})
 			return err
@@ -214,7 +272,7 @@ func handleLDAPLogin(c *response.Context, username, password, code string, cfg *
 	}
 
 	// 5. 生成token
-	token, _ := service.UserService().GenerateJWTTokenOnlyUserName(username, 24*time.Hour)
+	token, _ := service.UserService().GenerateLoginToken(username, 24*time.Hour, false)
 	c.JSON(http.StatusOK, response.H{"token": token})
 	return nil
 }
@@ -237,7 +295,7 @@ func validateTwoFA(user *models.User, code string, c *response.Context) error {
 			c.JSON(http.StatusUnauthorized, response.H{"message": "请输入2FA验证码"})
 			return errors.New("2FA验证码未提供")
 		}
-		if !totp.ValidateCode(user.TwoFASecret, code) {
+		if !totp.ValidateCode(user.TwoFASecret, code, uint(flag.Init().TOTPSkew)) {
 			c.JSON(http.StatusUnauthorized, response.H{"message": "2FA验证码错误"})
 			return errors.New("2FA验证码错误")
 		}
@@ -0,0 +1,60 @@
+package node
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 预览驱逐节点的影响
+// @Description 列出节点上将被驱逐与忽略的 Pod、适用的 PDB 及其剩余可中断数，并判断驱逐是否会因 PDB 被拒绝，不做任何变更
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param name path string true "节点名称"
+// @Success 200 {object} disruption.Preview
+// @Router /k8s/cluster/{cluster}/node/drain/preview/name/{name} [get]
+func (nc *ActionController) DrainPreview(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	preview, err := service.NodeService().DrainPreview(ctx, selectedCluster, []string{c.Param("name")}, nil)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, preview)
+}
+
+// @Summary 预览驱逐多个节点或指定 Pod 的影响
+// @Description 节点上的 Pod 与指定的 Pod 合并评估，同一 PDB 的可中断数按全部待驱逐 Pod 累计消耗
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param name_list body []string false "节点名称列表"
+// @Param pods body []service.DrainPodKey false "Pod 列表"
+// @Success 200 {object} disruption.Preview
+// @Router /k8s/cluster/{cluster}/node/drain/preview [post]
+func (nc *ActionController) BatchDrainPreview(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req struct {
+		Names []string              `json:"name_list"`
+		Pods  []service.DrainPodKey `json:"pods"`
+	}
+	if err = c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	preview, err := service.NodeService().DrainPreview(ctx, selectedCluster, req.Names, req.Pods)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, preview)
+}
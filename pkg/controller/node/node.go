@@ -1,9 +1,14 @@
 package node
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/task"
 	"github.com/weibaohui/kom/kom"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -19,12 +24,15 @@ func RegisterActionRoutes(r chi.Router) {
 	r.Post("/node/batch/drain", response.Adapter(ctrl.BatchDrain))
 	r.Post("/node/batch/cordon", response.Adapter(ctrl.BatchCordon))
 	r.Post("/node/batch/uncordon", response.Adapter(ctrl.BatchUnCordon))
+	r.Get("/node/drain/preview/name/{name}", response.Adapter(ctrl.DrainPreview))
+	r.Post("/node/drain/preview", response.Adapter(ctrl.BatchDrainPreview))
 }
 
 // @Summary 驱逐指定节点
 // @Security BearerAuth
 // @Param cluster query string true "集群名称"
 // @Param name path string true "节点名称"
+// @Param async query bool false "为 true 时作为异步任务执行，返回任务ID，可在 /mgm/tasks 查看进度与取消"
 // @Success 200 {object} string
 // @Router /k8s/cluster/{cluster}/node/drain/name/{name} [post]
 func (nc *ActionController) Drain(c *response.Context) {
@@ -35,12 +43,48 @@ func (nc *ActionController) Drain(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	if c.Query("async") == "true" {
+		submitDrainTask(c, selectedCluster, []string{name})
+		return
+	}
 
 	err = kom.Cluster(selectedCluster).WithContext(ctx).Resource(&v1.Node{}).Name(name).
 		Ctl().Node().Drain()
 	amis.WriteJsonErrorOrOK(c, err)
 }
 
+// submitDrainTask 以异步任务逐个驱逐节点，取消后不再处理剩余节点
+func submitDrainTask(c *response.Context, cluster string, names []string) {
+	title := fmt.Sprintf("驱逐节点 %s", strings.Join(names, ", "))
+	t, err := service.TaskService().Submit(amis.GetContextWithUser(c), "node_drain", title, cluster, func(run *task.Run) (string, error) {
+		var failed []string
+		for i, name := range names {
+			if err := run.Context().Err(); err != nil {
+				return "", err
+			}
+			run.Progress(i*100/len(names), "正在驱逐节点 %s（%d/%d）", name, i+1, len(names))
+			run.Logf("开始驱逐节点 %s", name)
+			err := kom.Cluster(cluster).WithContext(run.Context()).Resource(&v1.Node{}).Name(name).
+				Ctl().Node().Drain()
+			if err != nil {
+				run.Logf("驱逐节点 %s 失败: %v", name, err)
+				failed = append(failed, name)
+				continue
+			}
+			run.Logf("节点 %s 驱逐完成", name)
+		}
+		if len(failed) > 0 {
+			return "", fmt.Errorf("%d 个节点驱逐失败: %s", len(failed), strings.Join(failed, ", "))
+		}
+		return fmt.Sprintf("已驱逐 %d 个节点", len(names)), nil
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"task_id": t.ID})
+}
+
 // @Summary 隔离指定节点
 // @Security BearerAuth
 // @Param cluster query string true "集群名称"
@@ -85,6 +129,7 @@ func (nc *ActionController) UnCordon(c *response.Context) {
 // @Security BearerAuth
 // @Param cluster query string true "集群名称"
 // @Param name_list body []string true "节点名称列表"
+// @Param async query bool false "为 true 时作为异步任务执行，返回任务ID，可在 /mgm/tasks 查看进度与取消"
 // @Success 200 {object} string
 // @Router /k8s/cluster/{cluster}/node/batch/drain [post]
 func (nc *ActionController) BatchDrain(c *response.Context) {
@@ -102,6 +147,14 @@ func (nc *ActionController) BatchDrain(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	if c.Query("async") == "true" {
+		if len(req.Names) == 0 {
+			amis.WriteJsonError(c, fmt.Errorf("节点列表不能为空"))
+			return
+		}
+		submitDrainTask(c, selectedCluster, req.Names)
+		return
+	}
 
 	for i := 0; i < len(req.Names); i++ {
 		name := req.Names[i]
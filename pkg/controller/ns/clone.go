@@ -0,0 +1,58 @@
+package ns
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 克隆命名空间
+// @Description 将命名空间中选定类型的资源克隆到新的命名空间或其他集群，用于快速搭建测试环境。
+// @Description 可按前缀、后缀、子串替换改写资源名称，引用被改名资源的字段（卷、环境变量、ServiceAccount、Ingress 后端、RoleBinding 等）同步改写；
+// @Description Secret 可原样复制（copy）、不复制（exclude）或使用目标集群 sealed-secrets 证书重新加密为 SealedSecret（seal）。
+// @Description 由控制器生成的资源（如 ReplicaSet、Pod）不克隆，PVC 只克隆声明不复制数据。dry_run 为 true 时仅在目标集群预演
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "源命名空间"
+// @Param body body service.NamespaceCloneRequest true "克隆参数"
+// @Success 200 {object} service.NamespaceCloneResult
+// @Router /k8s/cluster/{cluster}/ns/clone/ns/{ns} [post]
+func (nc *Controller) Clone(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req service.NamespaceCloneRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	// 预演直接调用集群接口，需先确认用户有权访问目标集群
+	if req.TargetCluster != "" && req.TargetCluster != selectedCluster {
+		username := amis.GetLoginUser(c)
+		if !service.UserService().IsUserPlatformAdmin(username) {
+			clusters, err := service.UserService().GetClusterNames(username)
+			if err != nil {
+				amis.WriteJsonError(c, err)
+				return
+			}
+			if !slices.Contains(clusters, req.TargetCluster) {
+				amis.WriteJsonError(c, fmt.Errorf("无权限访问目标集群: %s", req.TargetCluster))
+				return
+			}
+		}
+	}
+
+	result, err := service.NsCloneService().Clone(ctx, selectedCluster, c.Param("ns"), &req)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
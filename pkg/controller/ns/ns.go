@@ -21,6 +21,8 @@ func RegisterRoutes(r chi.Router) {
 	r.Get("/ns/option_list", response.Adapter(ctrl.OptionList))
 	r.Post("/ResourceQuota/create", response.Adapter(ctrl.CreateResourceQuota))
 	r.Post("/LimitRange/create", response.Adapter(ctrl.CreateLimitRange))
+	r.Get("/ns/timeline/ns/{ns}", response.Adapter(ctrl.Timeline))
+	r.Post("/ns/clone/ns/{ns}", response.Adapter(ctrl.Clone))
 
 }
 
@@ -0,0 +1,74 @@
+package ns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// maxTimelineRange 时间线单次查询的最大时间范围
+const maxTimelineRange = 7 * 24 * time.Hour
+
+// @Summary 获取命名空间变更时间线
+// @Description 合并平台操作日志、Kubernetes 事件与工作负载发布记录，按时间倒序返回，用于排查"最近一小时改了什么"。
+// @Description 经由 k8m 的变更会在资源上记录注解 k8m.io/change-id，之后的 Warning 事件、发布与发布失败沿属主链关联到该变更，在 change 中返回变更ID、操作人与操作
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param since query string false "开始时间，时长（如 1h、30m，表示距今）或 RFC3339 时间，默认 1h"
+// @Param until query string false "结束时间，RFC3339 时间，默认当前时间"
+// @Param source query string false "来源过滤，逗号分隔：audit、event、rollout"
+// @Param change query string false "变更ID，只返回该变更的操作日志及由其引起的 Warning 事件与发布"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/ns/timeline/ns/{ns} [get]
+func (nc *Controller) Timeline(c *response.Context) {
+	ns := c.Param("ns")
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	until := time.Now()
+	if v := c.Query("until"); v != "" {
+		until, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("until 格式错误: %w", err))
+			return
+		}
+	}
+	since := until.Add(-time.Hour)
+	if v := c.Query("since"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			since = until.Add(-d)
+		} else if since, err = time.Parse(time.RFC3339, v); err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("since 格式错误，应为时长或 RFC3339 时间: %s", v))
+			return
+		}
+	}
+	if !since.Before(until) {
+		amis.WriteJsonError(c, fmt.Errorf("开始时间应早于结束时间"))
+		return
+	}
+	if until.Sub(since) > maxTimelineRange {
+		amis.WriteJsonError(c, fmt.Errorf("查询时间范围不能超过 %s", maxTimelineRange))
+		return
+	}
+
+	var sources []string
+	if v := c.Query("source"); v != "" {
+		sources = strings.Split(v, ",")
+	}
+
+	list, err := service.TimelineService().Namespace(ctx, selectedCluster, ns, since, until, sources, c.Query("change"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, list)
+}
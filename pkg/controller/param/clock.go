@@ -0,0 +1,16 @@
+package param
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 时钟偏差状态
+// @Description 获取最近一次 k8m 服务器与集群 apiserver、NTP 时间比对的摘要，skewed 为 true 时用于页面顶部告警
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /params/clock/status [get]
+func (pc *Controller) ClockStatus(c *response.Context) {
+	amis.WriteJsonData(c, service.ClockService().Summary())
+}
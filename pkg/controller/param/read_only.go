@@ -0,0 +1,21 @@
+package param
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// @Summary 只读模式状态
+// @Description 获取全局或指定集群当前生效的只读设置，未开启时 enabled 为 false，用于页面顶部提示
+// @Security BearerAuth
+// @Param cluster query string false "集群ID"
+// @Success 200 {object} string
+// @Router /params/readonly/status [get]
+func (pc *Controller) ReadOnlyStatus(c *response.Context) {
+	state := service.ReadOnlyService().Status(c.Query("cluster"))
+	if state == nil {
+		state = &service.ReadOnlyState{}
+	}
+	amis.WriteJsonData(c, state)
+}
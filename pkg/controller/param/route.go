@@ -24,4 +24,8 @@ func RegisterParamRoutes(r chi.Router) {
 	r.Get("/helm/repo/option_list", response.Adapter(ctrl.HelmRepoOptionList))
 	// 获取翻转显示的指标列表
 	r.Get("/condition/reverse/list", response.Adapter(ctrl.Conditions))
+	// 获取只读模式状态
+	r.Get("/readonly/status", response.Adapter(ctrl.ReadOnlyStatus))
+	// 获取时钟偏差状态
+	r.Get("/clock/status", response.Adapter(ctrl.ClockStatus))
 }
@@ -0,0 +1,52 @@
+package pod
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type CrashLoopController struct{}
+
+func RegisterCrashLoopRoutes(api chi.Router) {
+	ctrl := &CrashLoopController{}
+	api.Get("/pod/crashloop/ns/{ns}", response.Adapter(ctrl.Analyze))
+}
+
+// @Summary 诊断重启循环的容器
+// @Description 检查处于 CrashLoopBackOff 等状态的容器，汇总上次终止退出码、OOMKilled、探针失败事件与上一次运行的日志末尾，给出诊断类别：oom、bad_command、failed_probe、missing_config、app_error、unknown
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name query string false "Pod名称，为空时诊断整个命名空间"
+// @Param cause query string false "按诊断类别过滤"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/pod/crashloop/ns/{ns} [get]
+func (cc *CrashLoopController) Analyze(c *response.Context) {
+	ns := c.Param("ns")
+	name := c.Query("name")
+	cause := c.Query("cause")
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	list, err := service.PodService().AnalyzeCrashLoop(ctx, selectedCluster, ns, name)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if cause != "" {
+		filtered := make([]*service.CrashLoopDiagnosis, 0, len(list))
+		for _, d := range list {
+			if d.Cause == cause {
+				filtered = append(filtered, d)
+			}
+		}
+		list = filtered
+	}
+	amis.WriteJsonList(c, list)
+}
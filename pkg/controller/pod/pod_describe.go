@@ -0,0 +1,380 @@
+package pod
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+type DescribeController struct{}
+
+func RegisterDescribeRoutes(api chi.Router) {
+	ctrl := &DescribeController{}
+	api.Get("/pod/describe/ns/{ns}/name/{name}", response.Adapter(ctrl.Describe))
+}
+
+// PodDescribe 与 kubectl describe pod 展示内容对应的结构化结果
+type PodDescribe struct {
+	Name              string              `json:"name"`
+	Namespace         string              `json:"namespace"`
+	UID               string              `json:"uid"`
+	Priority          *int32              `json:"priority,omitempty"`
+	PriorityClassName string              `json:"priority_class_name,omitempty"`
+	ServiceAccount    string              `json:"service_account,omitempty"`
+	Node              string              `json:"node,omitempty"`
+	HostIP            string              `json:"host_ip,omitempty"`
+	PodIPs            []string            `json:"pod_ips,omitempty"`
+	StartTime         *time.Time          `json:"start_time,omitempty"`
+	Labels            map[string]string   `json:"labels,omitempty"`
+	Annotations       map[string]string   `json:"annotations,omitempty"`
+	Phase             v1.PodPhase         `json:"phase"`
+	Reason            string              `json:"reason,omitempty"`
+	Message           string              `json:"message,omitempty"`
+	QOSClass          v1.PodQOSClass      `json:"qos_class,omitempty"`
+	ControlledBy      string              `json:"controlled_by,omitempty"` // 形如 ReplicaSet/nginx-5d8f7c
+	NodeSelector      map[string]string   `json:"node_selector,omitempty"`
+	Tolerations       []v1.Toleration     `json:"tolerations,omitempty"`
+	Conditions        []PodConditionItem  `json:"conditions,omitempty"`
+	InitContainers    []ContainerDescribe `json:"init_containers,omitempty"`
+	Containers        []ContainerDescribe `json:"containers"`
+	Volumes           []VolumeDescribe    `json:"volumes,omitempty"`
+	Events            []EventDescribe     `json:"events"`
+}
+
+// PodConditionItem Pod 状态条件
+type PodConditionItem struct {
+	Type               v1.PodConditionType `json:"type"`
+	Status             v1.ConditionStatus  `json:"status"`
+	Reason             string              `json:"reason,omitempty"`
+	Message            string              `json:"message,omitempty"`
+	LastTransitionTime time.Time           `json:"last_transition_time,omitempty"`
+}
+
+// ContainerDescribe 容器的定义与运行状态
+type ContainerDescribe struct {
+	Name         string            `json:"name"`
+	Image        string            `json:"image"`
+	ImageID      string            `json:"image_id,omitempty"`
+	ContainerID  string            `json:"container_id,omitempty"`
+	Ports        []string          `json:"ports,omitempty"` // 形如 80/TCP
+	Command      []string          `json:"command,omitempty"`
+	Args         []string          `json:"args,omitempty"`
+	State        ContainerState    `json:"state"`
+	LastState    *ContainerState   `json:"last_state,omitempty"` // 上一次终止的状态，用于排查重启原因
+	Ready        bool              `json:"ready"`
+	RestartCount int32             `json:"restart_count"`
+	Requests     map[string]string `json:"requests,omitempty"`
+	Limits       map[string]string `json:"limits,omitempty"`
+	Mounts       []string          `json:"mounts,omitempty"` // 形如 /data from data (ro)
+}
+
+// ContainerState 容器状态，State 为 Running、Waiting、Terminated 之一
+type ContainerState struct {
+	State      string     `json:"state"`
+	Reason     string     `json:"reason,omitempty"`
+	Message    string     `json:"message,omitempty"`
+	ExitCode   *int32     `json:"exit_code,omitempty"`
+	Signal     int32      `json:"signal,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// VolumeDescribe 卷定义，Type 为卷来源类型，Source 为来源的关键信息
+type VolumeDescribe struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Source string `json:"source,omitempty"`
+}
+
+// EventDescribe Pod 相关事件
+type EventDescribe struct {
+	Type           string    `json:"type"`
+	Reason         string    `json:"reason"`
+	From           string    `json:"from,omitempty"`
+	Message        string    `json:"message"`
+	Count          int32     `json:"count"`
+	FirstTimestamp time.Time `json:"first_timestamp,omitempty"`
+	LastTimestamp  time.Time `json:"last_timestamp,omitempty"`
+}
+
+// @Summary 获取Pod描述信息
+// @Description 一次返回 kubectl describe pod 展示的全部信息：状态条件、容忍、卷、事件、容器状态及上次终止原因
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Success 200 {object} PodDescribe
+// @Router /k8s/cluster/{cluster}/pod/describe/ns/{ns}/name/{name} [get]
+func (dc *DescribeController) Describe(c *response.Context) {
+	ns := c.Param("ns")
+	name := c.Param("name")
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	var pod v1.Pod
+	err = kom.Cluster(selectedCluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name).Get(&pod).Error
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	result := buildPodDescribe(&pod)
+
+	// 事件获取失败不影响其他信息展示
+	var events []v1.Event
+	err = kom.Cluster(selectedCluster).WithContext(ctx).Resource(&v1.Event{}).Namespace(ns).
+		WithFieldSelector(fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", name)).
+		List(&events).Error
+	if err != nil {
+		klog.V(6).Infof("获取Pod[%s/%s]事件失败: %v", ns, name, err)
+	}
+	result.Events = buildEventDescribes(events, string(pod.UID))
+
+	amis.WriteJsonData(c, result)
+}
+
+// buildPodDescribe 将 Pod 对象整理为描述信息，不包含事件
+func buildPodDescribe(pod *v1.Pod) *PodDescribe {
+	d := &PodDescribe{
+		Name:              pod.Name,
+		Namespace:         pod.Namespace,
+		UID:               string(pod.UID),
+		Priority:          pod.Spec.Priority,
+		PriorityClassName: pod.Spec.PriorityClassName,
+		ServiceAccount:    pod.Spec.ServiceAccountName,
+		Node:              pod.Spec.NodeName,
+		HostIP:            pod.Status.HostIP,
+		Labels:            pod.Labels,
+		Annotations:       pod.Annotations,
+		Phase:             pod.Status.Phase,
+		Reason:            pod.Status.Reason,
+		Message:           pod.Status.Message,
+		QOSClass:          pod.Status.QOSClass,
+		NodeSelector:      pod.Spec.NodeSelector,
+		Tolerations:       pod.Spec.Tolerations,
+		Containers:        []ContainerDescribe{},
+		Events:            []EventDescribe{},
+	}
+	if pod.Status.StartTime != nil {
+		t := pod.Status.StartTime.Time
+		d.StartTime = &t
+	}
+	for _, ip := range pod.Status.PodIPs {
+		d.PodIPs = append(d.PodIPs, ip.IP)
+	}
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			d.ControlledBy = ref.Kind + "/" + ref.Name
+		}
+	}
+	// 正在删除的 Pod 与 kubectl 一致显示为 Terminating
+	if pod.DeletionTimestamp != nil {
+		d.Reason = "Terminating"
+	}
+	for _, cond := range pod.Status.Conditions {
+		d.Conditions = append(d.Conditions, PodConditionItem{
+			Type:               cond.Type,
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime.Time,
+		})
+	}
+
+	for _, ctn := range pod.Spec.InitContainers {
+		d.InitContainers = append(d.InitContainers, buildContainerDescribe(ctn, pod.Status.InitContainerStatuses))
+	}
+	for _, ctn := range pod.Spec.Containers {
+		d.Containers = append(d.Containers, buildContainerDescribe(ctn, pod.Status.ContainerStatuses))
+	}
+	for _, vol := range pod.Spec.Volumes {
+		d.Volumes = append(d.Volumes, buildVolumeDescribe(vol))
+	}
+	return d
+}
+
+// buildContainerDescribe 合并容器定义与对应的运行状态
+func buildContainerDescribe(ctn v1.Container, statuses []v1.ContainerStatus) ContainerDescribe {
+	d := ContainerDescribe{
+		Name:    ctn.Name,
+		Image:   ctn.Image,
+		Command: ctn.Command,
+		Args:    ctn.Args,
+		State:   ContainerState{State: "Waiting"},
+	}
+	for _, p := range ctn.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = v1.ProtocolTCP
+		}
+		d.Ports = append(d.Ports, fmt.Sprintf("%d/%s", p.ContainerPort, protocol))
+	}
+	d.Requests = resourceListToMap(ctn.Resources.Requests)
+	d.Limits = resourceListToMap(ctn.Resources.Limits)
+	for _, m := range ctn.VolumeMounts {
+		mount := fmt.Sprintf("%s from %s", m.MountPath, m.Name)
+		if m.SubPath != "" {
+			mount += fmt.Sprintf(" (path=%q)", m.SubPath)
+		}
+		if m.ReadOnly {
+			mount += " (ro)"
+		} else {
+			mount += " (rw)"
+		}
+		d.Mounts = append(d.Mounts, mount)
+	}
+
+	for _, st := range statuses {
+		if st.Name != ctn.Name {
+			continue
+		}
+		d.ImageID = st.ImageID
+		d.ContainerID = st.ContainerID
+		d.Ready = st.Ready
+		d.RestartCount = st.RestartCount
+		d.State = toContainerState(st.State)
+		if st.LastTerminationState.Terminated != nil {
+			last := toContainerState(st.LastTerminationState)
+			d.LastState = &last
+		}
+		break
+	}
+	return d
+}
+
+func toContainerState(s v1.ContainerState) ContainerState {
+	switch {
+	case s.Running != nil:
+		t := s.Running.StartedAt.Time
+		return ContainerState{State: "Running", StartedAt: &t}
+	case s.Terminated != nil:
+		started, finished := s.Terminated.StartedAt.Time, s.Terminated.FinishedAt.Time
+		exitCode := s.Terminated.ExitCode
+		return ContainerState{
+			State:      "Terminated",
+			Reason:     s.Terminated.Reason,
+			Message:    s.Terminated.Message,
+			ExitCode:   &exitCode,
+			Signal:     s.Terminated.Signal,
+			StartedAt:  &started,
+			FinishedAt: &finished,
+		}
+	case s.Waiting != nil:
+		return ContainerState{State: "Waiting", Reason: s.Waiting.Reason, Message: s.Waiting.Message}
+	}
+	return ContainerState{State: "Waiting"}
+}
+
+func resourceListToMap(list v1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(list))
+	for k, v := range list {
+		m[string(k)] = v.String()
+	}
+	return m
+}
+
+// buildVolumeDescribe 识别卷来源类型，并提取 kubectl describe 中展示的关键信息
+func buildVolumeDescribe(vol v1.Volume) VolumeDescribe {
+	d := VolumeDescribe{Name: vol.Name}
+	src := vol.VolumeSource
+	switch {
+	case src.ConfigMap != nil:
+		d.Type, d.Source = "ConfigMap", src.ConfigMap.Name
+	case src.Secret != nil:
+		d.Type, d.Source = "Secret", src.Secret.SecretName
+	case src.PersistentVolumeClaim != nil:
+		d.Type, d.Source = "PersistentVolumeClaim", src.PersistentVolumeClaim.ClaimName
+	case src.EmptyDir != nil:
+		d.Type = "EmptyDir"
+		if src.EmptyDir.Medium != "" {
+			d.Source = "medium=" + string(src.EmptyDir.Medium)
+		}
+		if src.EmptyDir.SizeLimit != nil {
+			d.Source = strings.TrimPrefix(d.Source+",sizeLimit="+src.EmptyDir.SizeLimit.String(), ",")
+		}
+	case src.HostPath != nil:
+		d.Type, d.Source = "HostPath", src.HostPath.Path
+	case src.Projected != nil:
+		d.Type = "Projected"
+		var parts []string
+		for _, p := range src.Projected.Sources {
+			switch {
+			case p.ServiceAccountToken != nil:
+				parts = append(parts, "ServiceAccountToken")
+			case p.ConfigMap != nil:
+				parts = append(parts, "ConfigMap/"+p.ConfigMap.Name)
+			case p.Secret != nil:
+				parts = append(parts, "Secret/"+p.Secret.Name)
+			case p.DownwardAPI != nil:
+				parts = append(parts, "DownwardAPI")
+			}
+		}
+		d.Source = strings.Join(parts, ",")
+	case src.DownwardAPI != nil:
+		d.Type = "DownwardAPI"
+	case src.NFS != nil:
+		d.Type, d.Source = "NFS", src.NFS.Server+":"+src.NFS.Path
+	case src.CSI != nil:
+		d.Type, d.Source = "CSI", src.CSI.Driver
+	case src.Ephemeral != nil:
+		d.Type = "Ephemeral"
+	default:
+		d.Type = "Other"
+	}
+	return d
+}
+
+// buildEventDescribes 筛选属于当前 Pod 的事件，按最近发生时间倒序排列。
+// 同名 Pod 被重建后旧事件仍可能存在，通过 UID 过滤。
+func buildEventDescribes(events []v1.Event, uid string) []EventDescribe {
+	result := []EventDescribe{}
+	for _, e := range events {
+		if e.InvolvedObject.UID != "" && string(e.InvolvedObject.UID) != uid {
+			continue
+		}
+		from := e.Source.Component
+		if from == "" {
+			from = e.ReportingController
+		}
+		last := e.LastTimestamp.Time
+		if last.IsZero() {
+			last = e.EventTime.Time
+		}
+		first := e.FirstTimestamp.Time
+		if first.IsZero() {
+			first = last
+		}
+		count := e.Count
+		if count == 0 {
+			count = 1
+		}
+		result = append(result, EventDescribe{
+			Type:           e.Type,
+			Reason:         e.Reason,
+			From:           from,
+			Message:        e.Message,
+			Count:          count,
+			FirstTimestamp: first,
+			LastTimestamp:  last,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastTimestamp.After(result[j].LastTimestamp)
+	})
+	return result
+}
@@ -0,0 +1,86 @@
+package pod
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/bundle"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type DiagnosticsController struct{}
+
+func RegisterDiagnosticsRoutes(api chi.Router) {
+	ctrl := &DiagnosticsController{}
+	api.Get("/pod/diagnostics/ns/{ns}/name/{name}", response.Adapter(ctrl.Download))
+	api.Get("/pod/diagnostics/file_globs", response.Adapter(ctrl.FileGlobs))
+}
+
+// @Summary 下载 Pod 诊断包
+// @Description 将 Pod YAML、describe、事件、重启诊断、各容器最近日志（有重启时包含上一次运行的日志）、环境变量（Secret 值已掩码）与容器内匹配的文件打包为 tar.gz，用于附加到工单。
+// @Description 各项尽力收集，失败原因记录在包内 errors.txt；收集容器内文件需要 Exec 权限
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Param tail query int false "每个容器读取的日志行数，默认1000，最大10000"
+// @Param files query string false "容器内收集的文件匹配模式，逗号分隔，如 /var/log/*.log；不传时使用平台配置，传空字符串表示不收集文件"
+// @Success 200 {file} file
+// @Router /k8s/cluster/{cluster}/pod/diagnostics/ns/{ns}/name/{name} [get]
+func (dc *DiagnosticsController) Download(c *response.Context) {
+	ns := c.Param("ns")
+	name := c.Param("name")
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	opts := &service.PodDiagnosticsOptions{}
+	if tail := c.Query("tail"); tail != "" {
+		if opts.TailLines, err = strconv.ParseInt(tail, 10, 64); err != nil {
+			amis.WriteJsonError(c, fmt.Errorf("tail 参数无效: %s", tail))
+			return
+		}
+	}
+	if files, ok := c.Request.URL.Query()["files"]; ok {
+		globs, err := bundle.ParseGlobs(files[0])
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		opts.FileGlobs = append([]string{}, globs...)
+	}
+
+	// 先写入本地临时文件，收集失败时仍可返回 JSON 错误
+	tmp, err := os.CreateTemp(service.UploadStagingService().Root(), "k8m-diagnostics-*")
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := service.PodService().CollectDiagnostics(ctx, selectedCluster, ns, name, opts, tmp); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	fileName := fmt.Sprintf("%s-diagnostics-%s.tar.gz", name, time.Now().Format("20060102-150405"))
+	amis.WriteDownload(c, fileName, "application/gzip", "", time.Time{}, tmp)
+}
+
+// @Summary 诊断包默认收集的文件
+// @Description 返回平台配置的容器内文件匹配模式，用于下载诊断包时预填
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/pod/diagnostics/file_globs [get]
+func (dc *DiagnosticsController) FileGlobs(c *response.Context) {
+	amis.WriteJsonData(c, response.H{"files": service.PodService().DiagnosticsFileGlobs()})
+}
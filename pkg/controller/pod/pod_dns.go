@@ -0,0 +1,51 @@
+package pod
+
+import (
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type DNSController struct{}
+
+func RegisterDNSRoutes(api chi.Router) {
+	ctrl := &DNSController{}
+	api.Get("/pod/dns/ns/{ns}/name/{name}", response.Adapter(ctrl.Diagnose))
+}
+
+// @Summary 诊断 Pod 的 DNS
+// @Description 读取容器内的 /etc/resolv.conf，在容器内解析 kubernetes.default 的完整域名与短名称、外部域名及指定的域名，
+// @Description 并与集群 DNS Service、CoreDNS 的 Corefile 与就绪情况对照，返回结构化的诊断结论
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Param container query string false "容器名称，默认第一个容器"
+// @Param external query string false "用于测试的外部域名，默认 kubernetes.io"
+// @Param names query string false "额外解析的域名，多个用逗号分隔，最多 10 个"
+// @Success 200 {object} service.DNSDiagnosis
+// @Router /k8s/cluster/{cluster}/pod/dns/ns/{ns}/name/{name} [get]
+func (dc *DNSController) Diagnose(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var names []string
+	for _, n := range strings.Split(c.Query("names"), ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	ctx := amis.GetContextWithUser(c)
+	result, err := service.PodService().DiagnoseDNS(ctx, selectedCluster, c.Param("ns"), c.Param("name"), c.Query("container"),
+		strings.TrimSpace(c.Query("external")), names)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
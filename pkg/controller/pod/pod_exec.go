@@ -0,0 +1,64 @@
+package pod
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type ExecController struct{}
+
+func RegisterExecRoutes(api chi.Router) {
+	ctrl := &ExecController{}
+	api.Post("/pod/exec-command/ns/{ns}/name/{name}", response.Adapter(ctrl.Exec))
+}
+
+// ExecCommandRequest 一次性命令请求，command 与 script 二选一
+type ExecCommandRequest struct {
+	Container string   `json:"container"`
+	Command   []string `json:"command"`         // 命令及参数，如 ["nginx","-t"]
+	Script    string   `json:"script"`          // 通过 sh -c 执行的脚本
+	Timeout   int      `json:"timeout_seconds"` // 超时时间，默认 30 秒，最长 600 秒
+}
+
+// @Summary 在容器中执行一次性命令
+// @Description 以非 TTY 方式执行命令，返回 stdout、stderr 与退出码，超时后中止。非平台管理员的命令需匹配管理员配置的允许列表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Param body body ExecCommandRequest true "命令"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/pod/exec-command/ns/{ns}/name/{name} [post]
+func (ec *ExecController) Exec(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req ExecCommandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	command := req.Command
+	if req.Script != "" {
+		if len(command) > 0 {
+			amis.WriteJsonError(c, fmt.Errorf("command 与 script 只能填写一个"))
+			return
+		}
+		command = []string{"sh", "-c", req.Script}
+	}
+	ctx := amis.GetContextWithUser(c)
+	result, err := service.PodService().ExecCommand(ctx, selectedCluster, c.Param("ns"), c.Param("name"), req.Container,
+		command, time.Duration(req.Timeout)*time.Second)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
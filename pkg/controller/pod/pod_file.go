@@ -1,20 +1,25 @@
 package pod
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
-	"mime/multipart"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/duke-git/lancet/v2/slice"
 	"github.com/go-chi/chi/v5"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/i18n"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/telemetry"
 	"github.com/weibaohui/kom/kom"
 	"k8s.io/klog/v2"
 )
@@ -29,8 +34,28 @@ func RegisterPodFileRoutes(api chi.Router) {
 	api.Post("/file/show", response.Adapter(ctrl.Show))
 	api.Post("/file/save", response.Adapter(ctrl.Save))
 	api.Get("/file/download", response.Adapter(ctrl.Download))
+	api.Post("/file/batch-download", response.Adapter(ctrl.BatchDownload))
 	api.Post("/file/upload", response.Adapter(ctrl.Upload))
+	api.Post("/file/upload/batch", response.Adapter(ctrl.UploadBatch))
+	api.Post("/file/upload/workload", response.Adapter(ctrl.UploadWorkload))
 	api.Post("/file/delete", response.Adapter(ctrl.Delete))
+	api.Post("/file/copy", response.Adapter(ctrl.Copy))
+	api.Post("/file/s3/push", response.Adapter(ctrl.S3Push))
+	api.Post("/file/s3/pull", response.Adapter(ctrl.S3Pull))
+	api.Post("/file/to_config", response.Adapter(ctrl.ToConfig))
+	api.Post("/file/from_config", response.Adapter(ctrl.FromConfig))
+	api.Get("/file/trash/list", response.Adapter(ctrl.TrashList))
+	api.Post("/file/trash/restore/{id}", response.Adapter(ctrl.TrashRestore))
+	api.Post("/file/trash/delete/{ids}", response.Adapter(ctrl.TrashDelete))
+	api.Get("/file/watch/list", response.Adapter(ctrl.WatchList))
+	api.Post("/file/watch/save", response.Adapter(ctrl.WatchSave))
+	api.Post("/file/watch/delete/{ids}", response.Adapter(ctrl.WatchDelete))
+	api.Post("/file/watch/id/{id}/check", response.Adapter(ctrl.WatchCheck))
+}
+
+// fileContext 标记文件管理执行的底层命令，适用 file 范围的命令策略
+func fileContext(c *response.Context) context.Context {
+	return context.WithValue(amis.GetContextWithUser(c), constants.CommandScope, constants.CommandScopeFile)
 }
 
 type info struct {
@@ -43,6 +68,20 @@ type info struct {
 	FileName      string `json:"fileName,omitempty"`
 	Size          int64  `json:"size,omitempty"`
 	FileType      string `json:"type,omitempty"` // 只有file类型可以查、下载
+
+	// 保存大文件时使用，内容较大或含二进制时以 base64 编码并分片提交
+	Encoding   string `json:"encoding,omitempty"`   // 空或 base64
+	UploadID   string `json:"uploadId,omitempty"`   // 分片上传标识，由客户端生成
+	ChunkIndex int    `json:"chunkIndex,omitempty"` // 从 0 开始
+	ChunkTotal int    `json:"chunkTotal,omitempty"` // 大于 1 时启用分片
+
+	// 保存前校验，发现问题时不写入并返回警告，force 为 true 时仍然保存
+	Validate        bool   `json:"validate,omitempty"`        // 按扩展名校验 YAML、JSON、XML、properties、ini、env 语法
+	ValidateCommand string `json:"validateCommand,omitempty"` // 在容器中执行的校验命令，{file} 替换为待保存内容的临时文件，如 nginx -t -c {file}
+	Force           bool   `json:"force,omitempty"`
+
+	// 删除时先备份到回收站，可在保留期内恢复
+	Trash bool `json:"trash,omitempty"`
 }
 
 // List  处理获取文件列表的 HTTP 请求
@@ -65,7 +104,7 @@ func (fc *FileController) List(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	ctx := amis.GetContextWithUser(c)
+	ctx := service.WithReadOnlyExec(fileContext(c))
 	poder := kom.Cluster(selectedCluster).WithContext(ctx).
 		Namespace(info.Namespace).
 		Name(info.PodName).Ctl().Pod().
@@ -77,7 +116,7 @@ func (fc *FileController) List(c *response.Context) {
 	// 获取文件列表
 	nodes, err := poder.ListAllFiles(info.Path)
 	if err != nil {
-		amis.WriteJsonError(c, fmt.Errorf("获取文件列表失败,容器内没有shell或者没有ls命令"))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileListFailed))
 		return
 	}
 	// 作为文件树，应该去掉. .. 两个条目
@@ -108,21 +147,21 @@ func (fc *FileController) Show(c *response.Context) {
 		return
 	}
 
-	ctx := amis.GetContextWithUser(c)
+	ctx := service.WithReadOnlyExec(fileContext(c))
 	poder := kom.Cluster(selectedCluster).WithContext(ctx).
 		Namespace(info.Namespace).
 		Name(info.PodName).Ctl().Pod().
 		ContainerName(info.ContainerName)
 	if info.FileType != "" && info.FileType != "file" && info.FileType != "directory" {
-		amis.WriteJsonError(c, fmt.Errorf("无法查看%s类型文件", info.FileType))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileNotViewable, info.FileType))
 		return
 	}
 	if info.Path == "" {
-		amis.WriteJsonError(c, fmt.Errorf("路径不能为空"))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFilePathRequired))
 		return
 	}
 	if info.IsDir {
-		amis.WriteJsonError(c, fmt.Errorf("无法保存目录"))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileIsDir))
 		return
 	}
 
@@ -138,7 +177,7 @@ func (fc *FileController) Show(c *response.Context) {
 		return
 	}
 	if !isText {
-		amis.WriteJsonError(c, fmt.Errorf("%s包含非文本内容，请下载后查看", info.Path))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileBinaryContent, info.Path))
 		return
 	}
 
@@ -148,6 +187,8 @@ func (fc *FileController) Show(c *response.Context) {
 }
 
 // @Summary 保存文件
+// @Description 内容较大或含非文本字符时，以 encoding=base64 编码并按 uploadId、chunkIndex、chunkTotal 顺序分片提交，最后一个分片到达后写入容器，单个文件上限 50MiB
+// @Description validate=true 时按扩展名校验语法，并可通过 validateCommand 在容器内执行校验命令，发现问题时不保存并返回 warnings，force=true 时仍然保存
 // @Security BearerAuth
 // @Param cluster query string true "集群名称"
 // @Param body body info true "文件信息"
@@ -168,38 +209,126 @@ func (fc *FileController) Save(c *response.Context) {
 	}
 	klog.V(6).Infof("info \n%v\n", utils.ToJSON(info))
 
-	ctx := amis.GetContextWithUser(c)
+	ctx := fileContext(c)
 	poder := kom.Cluster(selectedCluster).WithContext(ctx).
 		Namespace(info.Namespace).
 		Name(info.PodName).Ctl().Pod().
 		ContainerName(info.ContainerName)
 
 	if info.Path == "" {
-		amis.WriteJsonError(c, fmt.Errorf("路径不能为空"))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFilePathRequired))
 		return
 	}
 	if info.IsDir {
-		amis.WriteJsonError(c, fmt.Errorf("无法保存目录"))
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileIsDir))
+		return
+	}
+
+	content := []byte(info.FileContext)
+	if info.Encoding == "base64" {
+		content, err = base64.StdEncoding.DecodeString(info.FileContext)
+		if err != nil {
+			amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileInvalidBase64, err))
+			return
+		}
+	} else if info.Encoding != "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileUnsupportedEncoding, info.Encoding))
+		return
+	}
+
+	// 分片模式：暂存到服务端，最后一个分片到达后一次性写入容器
+	if info.ChunkTotal > 1 {
+		received, tmpPath, err := service.FileChunkService().Append(info.UploadID, amis.GetLoginUser(c), info.ChunkIndex, info.ChunkTotal, content)
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		if tmpPath == "" {
+			amis.WriteJsonData(c, response.H{"received": received, "total": info.ChunkTotal})
+			return
+		}
+		defer service.FileChunkService().Discard(info.UploadID)
+		content, err = os.ReadFile(tmpPath)
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	} else if len(content) > service.FileSaveMaxBytes {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileTooLarge, service.FileSaveMaxBytes>>20))
+		return
+	}
+	if info.Size > 0 && int64(len(content)) != info.Size {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileSizeMismatch, info.Size, len(content)))
 		return
 	}
 
+	var warnings []string
+	if info.Validate {
+		warnings = utils.CheckSyntax(info.Path, content)
+		if info.ValidateCommand != "" {
+			warnings = append(warnings, validateInPod(ctx, selectedCluster, info, content)...)
+		}
+		if len(warnings) > 0 && !info.Force {
+			amis.WriteJsonData(c, response.H{"saved": false, "warnings": warnings})
+			return
+		}
+	}
+
 	// 上传文件
-	if err := poder.SaveFile(info.Path, info.FileContext); err != nil {
+	if err := poder.SaveFile(info.Path, string(content)); err != nil {
 		klog.V(6).Infof("Error uploading file: %v", err)
 		amis.WriteJsonError(c, err)
 		return
 	}
 
+	if info.Validate {
+		amis.WriteJsonData(c, response.H{"saved": true, "warnings": warnings})
+		return
+	}
 	amis.WriteJsonOK(c)
 }
 
+// validateInPod 将内容写入容器中的临时文件并执行校验命令，命令以非 0 退出码结束时返回其输出
+func validateInPod(ctx context.Context, selectedCluster string, info *info, content []byte) []string {
+	tmpPath := info.Path + ".k8m-check"
+	poder := kom.Cluster(selectedCluster).WithContext(ctx).
+		Namespace(info.Namespace).
+		Name(info.PodName).Ctl().Pod().
+		ContainerName(info.ContainerName)
+	if err := poder.SaveFile(utils.ShellQuote(tmpPath), string(content)); err != nil {
+		return []string{fmt.Sprintf("写入校验临时文件失败: %v", err)}
+	}
+	defer func() {
+		if _, err := poder.DeleteFile(tmpPath); err != nil {
+			klog.V(6).Infof("删除校验临时文件 %s 失败: %v", tmpPath, err)
+		}
+	}()
+
+	script := strings.ReplaceAll(info.ValidateCommand, "{file}", utils.ShellQuote(tmpPath))
+	result, err := service.PodService().ExecCommand(ctx, selectedCluster, info.Namespace, info.PodName, info.ContainerName,
+		[]string{"sh", "-c", script}, 0)
+	if err != nil {
+		return []string{fmt.Sprintf("执行校验命令失败: %v", err)}
+	}
+	if result.TimedOut {
+		return []string{"校验命令执行超时"}
+	}
+	if result.ExitCode != 0 {
+		output := strings.TrimSpace(result.Stderr + "\n" + result.Stdout)
+		return []string{fmt.Sprintf("校验命令退出码 %d: %s", result.ExitCode, output)}
+	}
+	return nil
+}
+
 // @Summary 下载文件
+// @Description 支持 Range、If-Range 断点续传；type=tar 时将目录打包下载；inline=true 时按识别出的类型在浏览器中直接查看图片、PDF、文本等
 // @Security BearerAuth
 // @Param cluster query string true "集群名称"
 // @Param podName query string true "Pod名称"
 // @Param path query string true "文件路径"
 // @Param containerName query string true "容器名称"
 // @Param namespace query string true "命名空间"
+// @Param inline query bool false "在浏览器中直接查看"
 // @Success 200 {object} string
 // @Router /k8s/cluster/{cluster}/file/download [get]
 func (fc *FileController) Download(c *response.Context) {
@@ -215,34 +344,77 @@ func (fc *FileController) Download(c *response.Context) {
 	info.ContainerName = c.Query("containerName")
 	info.Namespace = c.Query("namespace")
 
-	ctx := amis.GetContextWithUser(c)
+	ctx := service.WithReadOnlyExec(fileContext(c))
 	poder := kom.Cluster(selectedCluster).WithContext(ctx).
 		Namespace(info.Namespace).
 		Name(info.PodName).Ctl().Pod().
 		ContainerName(info.ContainerName)
 
-	// 从容器中下载文件
-	var fileContent []byte
-	var finalFileName string
-	if c.Query("type") == "tar" {
-		fileContent, err = poder.DownloadTarFile(info.Path)
-		// 从路径中提取文件名作为下载时的文件名，并添加.tar后缀
-		fileName := filepath.Base(info.Path)
-		fileNameWithoutExt := strings.TrimSuffix(fileName, filepath.Ext(fileName))
-		finalFileName = fileNameWithoutExt + ".tar"
-	} else {
-		fileContent, err = poder.DownloadFile(info.Path)
-		finalFileName = filepath.Base(info.Path)
+	// 普通文件按需从容器读取，支持断点续传
+	if c.Query("type") != "tar" {
+		reader, err := service.PodService().OpenFile(ctx, &service.PodFileRef{
+			Cluster:       selectedCluster,
+			Namespace:     info.Namespace,
+			PodName:       info.PodName,
+			ContainerName: info.ContainerName,
+			Path:          info.Path,
+		})
+		name := filepath.Base(info.Path)
+		inline := c.Query("inline") == "true"
+		if err == nil {
+			defer reader.Close()
+			contentType := utils.ContentTypeByExtension(name)
+			if contentType == "" {
+				// 扩展名无法识别时读取文件头部识别类型
+				head := make([]byte, 512)
+				n, _ := io.ReadFull(reader, head)
+				contentType = utils.DetectContentType(name, head[:n])
+				if _, err := reader.Seek(0, io.SeekStart); err != nil {
+					amis.WriteJsonError(c, err)
+					return
+				}
+			}
+			writeFile(c, name, contentType, inline, reader.ETag(), reader.ModTime, reader)
+			telemetry.AddDownloadBytes(reader.BytesRead())
+			return
+		}
+		// 容器内没有 stat 等命令时整体读取
+		klog.V(6).Infof("按需读取文件失败，改为整体下载: %v", err)
+		fileContent, err := poder.DownloadFile(info.Path)
+		if err != nil {
+			klog.V(6).Infof("下载文件错误: %v", err)
+			amis.WriteJsonError(c, err)
+			return
+		}
+		telemetry.AddDownloadBytes(int64(len(fileContent)))
+		contentType := utils.DetectContentType(name, fileContent[:min(len(fileContent), 512)])
+		writeFile(c, name, contentType, inline, amis.ContentETag(fileContent), time.Time{}, bytes.NewReader(fileContent))
+		return
 	}
+
+	// 目录打包后整体返回，按内容摘要支持断点续传
+	fileContent, err := poder.DownloadTarFile(info.Path)
 	if err != nil {
 		klog.V(6).Infof("下载文件错误: %v", err)
 		amis.WriteJsonError(c, err)
 		return
 	}
-	// 设置响应头，指定文件名和类型
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", finalFileName))
-	c.Data(http.StatusOK, "application/octet-stream", fileContent)
+	telemetry.AddDownloadBytes(int64(len(fileContent)))
+	// 从路径中提取文件名作为下载时的文件名，并添加.tar后缀
+	fileName := filepath.Base(info.Path)
+	finalFileName := strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".tar"
+	amis.WriteDownloadData(c, finalFileName, "application/octet-stream", fileContent)
+}
 
+// writeFile 以附件下载，inline 为 true 且类型适合直接查看时在浏览器中展示
+func writeFile(c *response.Context, name, contentType string, inline bool, etag string, modtime time.Time, content io.ReadSeeker) {
+	if inline {
+		if ct := utils.InlineContentType(contentType); ct != "" {
+			amis.WriteInline(c, name, ct, etag, modtime, content)
+			return
+		}
+	}
+	amis.WriteDownload(c, name, contentType, etag, modtime, content)
 }
 
 // Upload 处理上传文件的 HTTP 请求
@@ -254,6 +426,8 @@ func (fc *FileController) Download(c *response.Context) {
 // @Param podName formData string true "Pod名称"
 // @Param path formData string true "文件路径"
 // @Param fileName formData string true "文件名"
+// @Param preflight formData bool false "上传前检查目标目录是否存在、可写及剩余空间"
+// @Param createDir formData bool false "预检时目标目录不存在则创建"
 // @Param file formData file true "上传文件"
 // @Success 200 {object} string
 // @Router /k8s/cluster/{cluster}/file/upload [post]
@@ -278,7 +452,7 @@ func (fc *FileController) Upload(c *response.Context) {
 				"uid":    -1,
 				"name":   info.FileName,
 				"status": "error",
-				"error":  "文件名不能为空",
+				"error":  i18n.Message(i18n.LangFromRequest(c.Request), i18n.CodeFileNameRequired),
 			},
 		})
 		return
@@ -289,7 +463,7 @@ func (fc *FileController) Upload(c *response.Context) {
 				"uid":    -1,
 				"name":   info.FileName,
 				"status": "error",
-				"error":  "路径不能为空",
+				"error":  i18n.Message(i18n.LangFromRequest(c.Request), i18n.CodeFilePathRequired),
 			},
 		})
 		return
@@ -297,7 +471,7 @@ func (fc *FileController) Upload(c *response.Context) {
 	// 替换FileName中非法字符
 	info.FileName = utils.SanitizeFileName(info.FileName)
 
-	ctx := amis.GetContextWithUser(c)
+	ctx := fileContext(c)
 	// 获取上传的文件
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -312,8 +486,22 @@ func (fc *FileController) Upload(c *response.Context) {
 		return
 	}
 
+	if c.PostForm("preflight") == "true" {
+		if err := service.PodService().UploadPreflight(ctx, selectedCluster, info.Namespace, info.PodName, info.ContainerName, info.Path, c.PostForm("createDir") == "true", file.Size); err != nil {
+			amis.WriteJsonData(c, response.H{
+				"file": response.H{
+					"uid":    -1,
+					"name":   info.FileName,
+					"status": "error",
+					"error":  err.Error(),
+				},
+			})
+			return
+		}
+	}
+
 	// 保存上传文件
-	tempFilePath, err := saveUploadedFile(file)
+	tempFilePath, release, err := service.UploadStagingService().Stage(amis.GetLoginUser(c), file)
 	if err != nil {
 		amis.WriteJsonData(c, response.H{
 			"file": response.H{
@@ -325,7 +513,7 @@ func (fc *FileController) Upload(c *response.Context) {
 		})
 		return
 	}
-	defer os.Remove(tempFilePath) // 请求结束时删除临时文件
+	defer release() // 请求结束时删除临时文件
 
 	// 上传文件到 Pod 中
 	if err := uploadToPod(ctx, selectedCluster, info, tempFilePath); err != nil {
@@ -339,6 +527,7 @@ func (fc *FileController) Upload(c *response.Context) {
 		})
 		return
 	}
+	telemetry.AddUploadBytes(file.Size)
 
 	// 	{
 	//    uid: 'uid',      // 文件唯一标识，建议设置为负数，防止和内部产生的 id 冲突
@@ -358,6 +547,7 @@ func (fc *FileController) Upload(c *response.Context) {
 }
 
 // @Summary 删除文件
+// @Description trash=true 时先将文件打包备份到回收站，保留 7 天
 // @Security BearerAuth
 // @Param cluster query string true "集群名称"
 // @Param body body info true "文件信息"
@@ -377,11 +567,20 @@ func (fc *FileController) Delete(c *response.Context) {
 		return
 	}
 
-	ctx := amis.GetContextWithUser(c)
+	ctx := fileContext(c)
 	poder := kom.Cluster(selectedCluster).WithContext(ctx).
 		Namespace(info.Namespace).
 		Name(info.PodName).Ctl().Pod().
 		ContainerName(info.ContainerName)
+	msg := "删除成功"
+	if info.Trash {
+		item, err := service.FileTrashService().MoveToTrash(ctx, selectedCluster, info.Namespace, info.PodName, info.ContainerName, info.Path, info.IsDir)
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		msg = fmt.Sprintf("已移入回收站，%s 前可恢复", item.ExpiresAt.Format("2006-01-02 15:04"))
+	}
 	// 从容器中下载文件
 	result, err := poder.DeleteFile(info.Path)
 	if err != nil {
@@ -390,38 +589,7 @@ func (fc *FileController) Delete(c *response.Context) {
 		return
 	}
 
-	amis.WriteJsonOKMsg(c, "删除成功"+string(result))
-}
-
-// saveUploadedFile 保存上传文件并返回临时文件路径
-func saveUploadedFile(file *multipart.FileHeader) (string, error) {
-	// 创建临时目录
-	tempDir, err := os.MkdirTemp("", "upload-*")
-	if err != nil {
-		return "", fmt.Errorf("创建临时目录错误: %v", err)
-	}
-
-	// 使用原始文件名生成临时文件路径
-	tempFilePath := filepath.Join(tempDir, file.Filename)
-
-	// 创建并保存文件
-	tempFile, err := os.Create(tempFilePath)
-	if err != nil {
-		return "", fmt.Errorf("创建临时文件错误: %v", err)
-	}
-	defer tempFile.Close()
-
-	src, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("打开上传文件错误: %v", err)
-	}
-	defer src.Close()
-
-	if _, err := io.Copy(tempFile, src); err != nil {
-		return "", fmt.Errorf("无法写入临时文件: %v", err)
-	}
-
-	return tempFilePath, nil
+	amis.WriteJsonOKMsg(c, msg+string(result))
 }
 
 // uploadToPod 上传文件到 Pod
@@ -0,0 +1,266 @@
+package pod
+
+import (
+	"context"
+	"mime/multipart"
+	"strconv"
+	"sync"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// FileUploadResult 批量上传中单个文件的结果
+type FileUploadResult struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Status   string `json:"status"` // done 或 error
+	Error    string `json:"error,omitempty"`
+	Attempts int    `json:"attempts"` // 实际尝试次数，含首次
+}
+
+// UploadBatch 批量上传文件到容器的同一目录
+// @Summary 批量上传文件
+// @Description 并发数与重试次数未指定时使用平台参数设置，网络类错误按指数退避重试，命令执行失败不重试
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param containerName formData string true "容器名称"
+// @Param namespace formData string true "命名空间"
+// @Param podName formData string true "Pod名称"
+// @Param path formData string true "目标目录"
+// @Param concurrency formData int false "并发数，最大20"
+// @Param retries formData int false "单个文件重试次数，最大5"
+// @Param preflight formData bool false "上传前检查目标目录是否存在、可写及剩余空间是否够全部文件"
+// @Param createDir formData bool false "预检时目标目录不存在则创建"
+// @Param files formData file true "上传文件，可多个"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/upload/batch [post]
+func (fc *FileController) UploadBatch(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	info := &info{
+		ContainerName: c.PostForm("containerName"),
+		Namespace:     c.PostForm("namespace"),
+		PodName:       c.PostForm("podName"),
+		Path:          c.PostForm("path"),
+	}
+	if info.Path == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFilePathRequired))
+		return
+	}
+	files := c.Request.MultipartForm.File["files"]
+	if len(files) == 0 {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileNoUpload))
+		return
+	}
+
+	cfg := flag.Init()
+	concurrency := min(max(formInt(c, "concurrency", cfg.FileUploadConcurrency), 1), service.FileUploadMaxConcurrency)
+	retries := min(max(formInt(c, "retries", cfg.FileUploadRetries), 0), service.FileUploadMaxRetries)
+
+	ctx := fileContext(c)
+	if c.PostForm("preflight") == "true" {
+		var total int64
+		for _, fh := range files {
+			total += fh.Size
+		}
+		if err := service.PodService().UploadPreflight(ctx, selectedCluster, info.Namespace, info.PodName, info.ContainerName, info.Path, c.PostForm("createDir") == "true", total); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
+
+	results := processBatchUpload(ctx, selectedCluster, amis.GetLoginUser(c), info, files, concurrency, retries)
+	failed := 0
+	for _, r := range results {
+		if r.Status != "done" {
+			failed++
+		}
+	}
+	amis.WriteJsonData(c, response.H{
+		"results":     results,
+		"total":       len(results),
+		"failed":      failed,
+		"concurrency": concurrency,
+		"retries":     retries,
+	})
+}
+
+// processBatchUpload 按并发数上传文件，结果顺序与请求中的文件顺序一致
+func processBatchUpload(ctx context.Context, selectedCluster, username string, info *info, files []*multipart.FileHeader, concurrency, retries int) []*FileUploadResult {
+	results := make([]*FileUploadResult, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, fh := range files {
+		fh.Filename = utils.SanitizeFileName(fh.Filename)
+		results[i] = &FileUploadResult{Name: fh.Filename, Size: fh.Size}
+		wg.Add(1)
+		go func(result *FileUploadResult, fh *multipart.FileHeader) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			tempFilePath, release, err := service.UploadStagingService().Stage(username, fh)
+			if err != nil {
+				result.Status, result.Error = "error", err.Error()
+				return
+			}
+			defer release()
+
+			result.Attempts, err = service.RetryTransient(ctx, retries, func() error {
+				return uploadToPod(ctx, selectedCluster, info, tempFilePath)
+			})
+			if err != nil {
+				klog.V(6).Infof("批量上传 %s 失败，尝试 %d 次: %v", fh.Filename, result.Attempts, err)
+				result.Status, result.Error = "error", err.Error()
+				return
+			}
+			result.Status = "done"
+			telemetry.AddUploadBytes(fh.Size)
+		}(results[i], fh)
+	}
+	wg.Wait()
+	return results
+}
+
+// formInt 读取整数表单参数，缺省或格式错误时返回默认值
+func formInt(c *response.Context, key string, def int) int {
+	if v, err := strconv.Atoi(c.PostForm(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// PodUploadResult 工作负载分发中单个 Pod 的结果
+type PodUploadResult struct {
+	PodName  string `json:"podName"`
+	Status   string `json:"status"` // done、error 或 skipped
+	Error    string `json:"error,omitempty"`
+	Attempts int    `json:"attempts"`
+}
+
+// UploadWorkload 将同一个文件上传到工作负载的全部 Pod
+// @Summary 向工作负载的全部 Pod 分发文件
+// @Description 适用于向所有副本推送热修复配置或证书，未运行的 Pod 跳过，并发数与重试次数规则同批量上传
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param kind formData string true "Deployment、StatefulSet 或 DaemonSet"
+// @Param name formData string true "工作负载名称"
+// @Param namespace formData string true "命名空间"
+// @Param containerName formData string false "容器名称，为空时使用 Pod 的第一个容器"
+// @Param path formData string true "目标目录"
+// @Param concurrency formData int false "并发数，最大20"
+// @Param retries formData int false "单个 Pod 重试次数，最大5"
+// @Param file formData file true "上传文件"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/upload/workload [post]
+func (fc *FileController) UploadWorkload(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileUploadRead, err))
+		return
+	}
+	kind, name, ns := c.PostForm("kind"), c.PostForm("name"), c.PostForm("namespace")
+	container, dir := c.PostForm("containerName"), c.PostForm("path")
+	if name == "" || ns == "" || dir == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileWorkloadRequired))
+		return
+	}
+
+	ctx := fileContext(c)
+	kk := kom.Cluster(selectedCluster).WithContext(ctx).Namespace(ns).Name(name)
+	var pods []*v1.Pod
+	switch kind {
+	case "Deployment":
+		pods, err = kk.Resource(&appsv1.Deployment{}).Ctl().Deployment().ManagedPods()
+	case "StatefulSet":
+		pods, err = kk.Resource(&appsv1.StatefulSet{}).Ctl().StatefulSet().ManagedPods()
+	case "DaemonSet":
+		pods, err = kk.Resource(&appsv1.DaemonSet{}).Ctl().DaemonSet().ManagedPods()
+	default:
+		err = i18n.NewError(i18n.CodeFileWorkloadKind, kind)
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if len(pods) == 0 {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileWorkloadNoPods, kind, ns, name))
+		return
+	}
+
+	file.Filename = utils.SanitizeFileName(file.Filename)
+	tempFilePath, release, err := service.UploadStagingService().Stage(amis.GetLoginUser(c), file)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	defer release()
+
+	cfg := flag.Init()
+	concurrency := min(max(formInt(c, "concurrency", cfg.FileUploadConcurrency), 1), service.FileUploadMaxConcurrency)
+	retries := min(max(formInt(c, "retries", cfg.FileUploadRetries), 0), service.FileUploadMaxRetries)
+
+	results := make([]*PodUploadResult, len(pods))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		results[i] = &PodUploadResult{PodName: pod.Name}
+		if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			results[i].Status, results[i].Error = "skipped", "Pod 未运行"
+			continue
+		}
+		wg.Add(1)
+		go func(result *PodUploadResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			target := &info{Namespace: ns, PodName: result.PodName, ContainerName: container, Path: dir}
+			var err error
+			result.Attempts, err = service.RetryTransient(ctx, retries, func() error {
+				return uploadToPod(ctx, selectedCluster, target, tempFilePath)
+			})
+			if err != nil {
+				result.Status, result.Error = "error", err.Error()
+				return
+			}
+			result.Status = "done"
+			telemetry.AddUploadBytes(file.Size)
+		}(results[i])
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if r.Status != "done" {
+			failed++
+		}
+	}
+	amis.WriteJsonData(c, response.H{
+		"results": results,
+		"total":   len(results),
+		"failed":  failed,
+	})
+}
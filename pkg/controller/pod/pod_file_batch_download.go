@@ -0,0 +1,99 @@
+package pod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"k8s.io/klog/v2"
+)
+
+// FileBatchDownloadRequest 批量下载同一容器中的多个文件或目录
+type FileBatchDownloadRequest struct {
+	Namespace     string   `json:"namespace"`
+	PodName       string   `json:"podName"`
+	ContainerName string   `json:"containerName"`
+	Paths         []string `json:"paths"`
+	FileName      string   `json:"fileName,omitempty"` // 下载文件名，默认为 Pod 名称加时间
+}
+
+// failedPathsTrailer 响应结束后在 trailer 中给出失败的路径数
+const failedPathsTrailer = "X-K8M-Failed-Paths"
+
+// batchDownloadWriter 首次写入时才输出下载响应头，全部路径失败时仍可返回 JSON 错误
+type batchDownloadWriter struct {
+	c        *response.Context
+	fileName string
+	n        int64
+}
+
+func (b *batchDownloadWriter) Write(p []byte) (int, error) {
+	if b.n == 0 {
+		b.c.Header("Content-Type", "application/gzip")
+		b.c.Header("Content-Disposition", "attachment; filename="+b.fileName)
+		b.c.Header("Trailer", failedPathsTrailer)
+	}
+	n, err := b.c.Writer.Write(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// BatchDownload 批量下载容器中的文件
+// @Summary 批量下载容器文件
+// @Description 将所选文件与目录在服务端打包为一个 tar.gz 边打包边返回，条目保留完整路径。
+// @Description 单个路径失败不影响其余路径，失败详情写入压缩包中的 k8m-download-report.json，失败数在 X-K8M-Failed-Paths trailer 中给出；全部失败时返回错误信息
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param body body FileBatchDownloadRequest true "容器与路径列表，最多100个"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/batch-download [post]
+func (fc *FileController) BatchDownload(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req FileBatchDownloadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	fileName := utils.SanitizeFileName(req.FileName)
+	if fileName == "" {
+		fileName = fmt.Sprintf("%s-%s", req.PodName, time.Now().Format("20060102150405"))
+	}
+	if !strings.HasSuffix(fileName, ".tar.gz") {
+		fileName += ".tar.gz"
+	}
+
+	out := &batchDownloadWriter{c: c, fileName: fileName}
+	results, err := service.PodService().DownloadBatch(fileContext(c), &service.PodFileRef{
+		Cluster:       selectedCluster,
+		Namespace:     req.Namespace,
+		PodName:       req.PodName,
+		ContainerName: req.ContainerName,
+	}, req.Paths, out)
+	telemetry.AddDownloadBytes(out.n)
+	if err != nil && out.n == 0 {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err != nil {
+		// 响应已经开始，只能中断
+		klog.V(6).Infof("批量下载 %s/%s 中断: %v", req.Namespace, req.PodName, err)
+		return
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Status != "done" {
+			failed++
+		}
+	}
+	c.Writer.Header().Set(failedPathsTrailer, strconv.Itoa(failed))
+}
@@ -0,0 +1,94 @@
+package pod
+
+import (
+	"fmt"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// FileConfigRequest 容器文件与 ConfigMap、Secret 之间的同步请求
+type FileConfigRequest struct {
+	Namespace     string                  `json:"namespace"`
+	PodName       string                  `json:"podName"`
+	ContainerName string                  `json:"containerName"`
+	Path          string                  `json:"path"`
+	Config        service.ConfigObjectRef `json:"config"` // namespace 为空时与 Pod 相同
+}
+
+func (r *FileConfigRequest) ref(cluster string) *service.PodFileRef {
+	return &service.PodFileRef{
+		Cluster:       cluster,
+		Namespace:     r.Namespace,
+		PodName:       r.PodName,
+		ContainerName: r.ContainerName,
+		Path:          r.Path,
+	}
+}
+
+// ToConfig 将容器文件写入 ConfigMap 或 Secret
+// @Summary 从容器文件创建或更新 ConfigMap/Secret
+// @Description 对象不存在时创建，已存在时只更新指定键，键名为空时使用文件名
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param body body FileConfigRequest true "容器文件与目标对象"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/to_config [post]
+func (fc *FileController) ToConfig(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req FileConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Path == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFilePathRequired))
+		return
+	}
+	created, err := service.PodService().FileToConfig(fileContext(c), req.ref(selectedCluster), &req.Config)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	action := "已更新"
+	if created {
+		action = "已创建"
+	}
+	amis.WriteJsonOKMsg(c, fmt.Sprintf("%s %s %s/%s，键 %s", action, req.Config.Kind, req.Config.Namespace, req.Config.Name, req.Config.Key))
+}
+
+// FromConfig 将 ConfigMap 或 Secret 的键写入容器文件
+// @Summary 将 ConfigMap/Secret 键内容写入容器文件
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param body body FileConfigRequest true "来源对象与容器文件"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/from_config [post]
+func (fc *FileController) FromConfig(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req FileConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Path == "" || req.Config.Key == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileConfigKeyRequired))
+		return
+	}
+	written, err := service.PodService().ConfigToFile(fileContext(c), &req.Config, req.ref(selectedCluster))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOKMsg(c, fmt.Sprintf("已写入 %s，%d 字节", req.Path, written))
+}
@@ -0,0 +1,52 @@
+package pod
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// FileCopyRequest 跨容器复制请求，源位于当前选中的集群
+type FileCopyRequest struct {
+	Source service.PodFileRef `json:"source"`
+	Target service.PodFileRef `json:"target"` // path 为目标目录，为空时与源文件所在目录相同；cluster 为空时为当前集群
+}
+
+// Copy 复制容器中的文件或目录到另一个容器，支持跨集群
+// @Summary 跨集群复制容器文件
+// @Description 经 k8m 以 tar 流方式转发，不落盘，适用于在环境间同步配置文件；两端容器均需要 tar 命令
+// @Security BearerAuth
+// @Param cluster query string true "源集群名称"
+// @Param body body FileCopyRequest true "源与目标"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/copy [post]
+func (fc *FileController) Copy(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req FileCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	req.Source.Cluster = selectedCluster
+	if req.Target.Cluster == "" {
+		req.Target.Cluster = selectedCluster
+	}
+	if req.Source.PodName == "" || req.Target.PodName == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileCopyPodRequired))
+		return
+	}
+	written, err := service.PodService().CopyFile(fileContext(c), &req.Source, &req.Target)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"bytes":  written,
+		"target": req.Target,
+	})
+}
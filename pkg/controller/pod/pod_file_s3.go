@@ -0,0 +1,91 @@
+package pod
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// ObjectTransferRequest 容器与对象存储之间的传输请求
+type ObjectTransferRequest struct {
+	Namespace     string `json:"namespace"`
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+	Path          string `json:"path"`
+	IsDir         bool   `json:"isDir,omitempty"`   // 推送目录时以 tar 打包
+	Key           string `json:"key,omitempty"`     // 对象键，限定在 k8m-pod-files/集群/命名空间/ 之下，推送时为空则自动生成
+	Extract       bool   `json:"extract,omitempty"` // 拉取时按 tar 解包到 path 目录
+}
+
+func (r *ObjectTransferRequest) ref(cluster string) *service.PodFileRef {
+	return &service.PodFileRef{
+		Cluster:       cluster,
+		Namespace:     r.Namespace,
+		PodName:       r.PodName,
+		ContainerName: r.ContainerName,
+		Path:          r.Path,
+	}
+}
+
+// S3Push 将容器文件推送到对象存储
+// @Summary 推送容器文件到对象存储
+// @Description 使用平台参数设置中的 S3 兼容存储，目录以 tar 打包上传。对象键限定在 k8m-pod-files/集群/命名空间/ 之下
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param body body ObjectTransferRequest true "容器文件与对象键"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/s3/push [post]
+func (fc *FileController) S3Push(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req ObjectTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Path == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFilePathRequired))
+		return
+	}
+	result, err := service.ObjectStorageService().Push(fileContext(c), req.ref(selectedCluster), req.IsDir, req.Key)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
+
+// S3Pull 从对象存储拉取对象到容器
+// @Summary 从对象存储拉取文件到容器
+// @Description extract=true 时将 tar 对象解包到 path 目录，否则写入 path 文件。只能拉取 k8m-pod-files/集群/命名空间/ 之下的对象
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param body body ObjectTransferRequest true "对象键与容器路径"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/s3/pull [post]
+func (fc *FileController) S3Pull(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req ObjectTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Path == "" || req.Key == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileObjectKeyRequired))
+		return
+	}
+	result, err := service.ObjectStorageService().Pull(fileContext(c), req.Key, req.ref(selectedCluster), req.Extract)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
@@ -0,0 +1,153 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+// trashNamespaces 返回集群回收站中当前用户有权查看的命名空间
+func trashNamespaces(ctx context.Context, cluster string) ([]string, error) {
+	var list []string
+	err := dao.DB().Model(&models.FileTrash{}).Where("cluster = ?", cluster).Distinct().Pluck("namespace", &list).Error
+	if err != nil {
+		return nil, err
+	}
+	allowed := namespaceChecker(ctx, cluster)
+	result := make([]string, 0, len(list))
+	for _, ns := range list {
+		if allowed(ns) {
+			result = append(result, ns)
+		}
+	}
+	return result, nil
+}
+
+// trashItem 读取回收站记录，并按记录所在的命名空间校验当前用户的权限，不以请求中的 Pod、容器为准
+func trashItem(ctx context.Context, cluster string, id uint, action string) (*models.FileTrash, error) {
+	var item models.FileTrash
+	if err := dao.DB().Omit("content").Where("id = ? and cluster = ?", id, cluster).First(&item).Error; err != nil {
+		return nil, fmt.Errorf("回收站记录不存在: %w", err)
+	}
+	if err := comm.CheckPermissionLogic(ctx, cluster, []string{item.Namespace}, item.Namespace, item.PodName, action); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// @Summary 文件回收站列表
+// @Description 只返回当前用户有权查看的命名空间中的记录
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param namespace query string false "命名空间"
+// @Param pod_name query string false "Pod名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/trash/list [get]
+func (fc *FileController) TrashList(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	namespaces, err := trashNamespaces(amis.GetContextWithUser(c), selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := &models.FileTrash{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Omit("content").Where("cluster = ? and namespace in ?", selectedCluster, namespaces)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// TrashRestoreRequest 恢复请求，原 Pod 已重建时可指定新的 Pod
+type TrashRestoreRequest struct {
+	PodName       string `json:"podName,omitempty"`
+	ContainerName string `json:"containerName,omitempty"`
+}
+
+// @Summary 从回收站恢复文件
+// @Description 将备份解压回原路径，默认恢复到删除时的 Pod 与容器，恢复后从回收站移除。需要记录所在命名空间的 exec 权限
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param id path int true "回收站记录ID"
+// @Param body body TrashRestoreRequest false "目标 Pod"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/trash/restore/{id} [post]
+func (fc *FileController) TrashRestore(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := fileContext(c)
+	if _, err := trashItem(ctx, selectedCluster, uint(id), "exec"); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req TrashRestoreRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
+	item, err := service.FileTrashService().Restore(ctx, selectedCluster, uint(id), req.PodName, req.ContainerName)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOKMsg(c, "已恢复 "+item.Path)
+}
+
+// @Summary 清除回收站记录
+// @Description 需要每条记录所在命名空间的删除权限
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param ids path string true "回收站记录ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/trash/delete/{ids} [post]
+func (fc *FileController) TrashDelete(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	for _, id := range utils.ToInt64Slice(c.Param("ids")) {
+		if _, err := trashItem(ctx, selectedCluster, uint(id), "delete"); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
+	params := dao.BuildParams(c)
+	m := &models.FileTrash{}
+	err = m.Delete(params, c.Param("ids"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
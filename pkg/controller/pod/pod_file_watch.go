@@ -0,0 +1,139 @@
+package pod
+
+import (
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+// @Summary 文件监视列表
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/watch/list [get]
+func (fc *FileController) WatchList(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	m := &models.FileWatch{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// @Summary 保存文件监视
+// @Description 新建或编辑容器文件监视，保存后立即以当前用户权限检查一次并记录基线；编辑后重新记录基线。webhooks 为 webhook 接收者 ID，逗号分隔
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param body body models.FileWatch true "文件监视"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/watch/save [post]
+func (fc *FileController) WatchSave(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := models.FileWatch{}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	m.Cluster = selectedCluster
+	if m.Namespace == "" || m.PodName == "" || m.Path == "" {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileWatchRequired))
+		return
+	}
+	if m.CheckInterval < 1 {
+		m.CheckInterval = 5
+	}
+	// 新建与编辑都重新记录基线
+	m.Baseline, m.Checksum, m.PodUID, m.RestartCount, m.Status, m.Message = "", "", "", 0, "", ""
+	m.LastCheckedAt, m.LastChangedAt = nil, nil
+
+	if m.ID == 0 {
+		err = m.Save(params)
+	} else {
+		var count int64
+		dao.DB().Model(&models.FileWatch{}).Where("id = ? and cluster = ?", m.ID, selectedCluster).Count(&count)
+		if count == 0 {
+			amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileWatchNotFound))
+			return
+		}
+		err = m.Save(params, func(db *gorm.DB) *gorm.DB {
+			return db.Select("namespace", "pod_name", "container_name", "path", "check_interval", "webhooks",
+				"baseline", "checksum", "pod_uid", "restart_count", "status", "message", "last_checked_at", "last_changed_at", "updated_at")
+		})
+	}
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := service.FileWatchService().Check(fileContext(c), &m); err != nil {
+		amis.WriteJsonOKMsg(c, "已保存，首次检查失败: "+err.Error())
+		return
+	}
+	amis.WriteJsonData(c, response.H{"id": m.ID, "checksum": m.Checksum})
+}
+
+// @Summary 删除文件监视
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param ids path string true "文件监视ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/watch/delete/{ids} [post]
+func (fc *FileController) WatchDelete(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	m := &models.FileWatch{}
+	err = m.Delete(params, c.Param("ids"), func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonOK(c)
+}
+
+// @Summary 立即检查文件监视
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param id path int true "文件监视ID"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/file/watch/id/{id}/check [post]
+func (fc *FileController) WatchCheck(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var m models.FileWatch
+	if err := dao.DB().Where("id = ? and cluster = ?", c.Param("id"), selectedCluster).First(&m).Error; err != nil {
+		amis.WriteJsonError(c, i18n.NewError(i18n.CodeFileWatchNotFound))
+		return
+	}
+	if err := service.FileWatchService().Check(fileContext(c), &m); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, m)
+}
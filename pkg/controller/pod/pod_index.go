@@ -0,0 +1,141 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/podindex"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type IndexController struct{}
+
+// RegisterIndexRoutes 注册 Pod 反向查询路由：节点上的 Pod、引用 ConfigMap/Secret/PVC 的 Pod、使用镜像的工作负载
+func RegisterIndexRoutes(api chi.Router) {
+	ctrl := &IndexController{}
+	api.Get("/pod/index/node/{node}", response.Adapter(ctrl.PodsOnNode))
+	api.Get("/pod/index/{kind}/ns/{ns}/name/{name}", response.Adapter(ctrl.PodsUsing))
+	api.Get("/pod/index/image", response.Adapter(ctrl.WorkloadsUsingImage))
+}
+
+// @Summary 节点上的 Pod
+// @Description 从资源监听维护的索引中查询，未启用资源监听时直接读取集群。只返回当前用户有权限的命名空间中的 Pod
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param node path string true "节点名称"
+// @Success 200 {object} []podindex.PodRef
+// @Router /k8s/cluster/{cluster}/pod/index/node/{node} [get]
+func (ic *IndexController) PodsOnNode(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	x, err := service.PodIndexService().Index(ctx, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, allowedPods(ctx, selectedCluster, x.PodsOnNode(c.Param("node"))))
+}
+
+// @Summary 引用 ConfigMap、Secret 或 PVC 的 Pod
+// @Description 包括卷（含 projected 卷）、env、envFrom 引用，Secret 还包括 imagePullSecrets；PVC 包括通用临时卷创建的 PVC
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param kind path string true "引用类型：configmap、secret、pvc"
+// @Param ns path string true "命名空间"
+// @Param name path string true "对象名称"
+// @Success 200 {object} []podindex.PodRef
+// @Router /k8s/cluster/{cluster}/pod/index/{kind}/ns/{ns}/name/{name} [get]
+func (ic *IndexController) PodsUsing(c *response.Context) {
+	kind := c.Param("kind")
+	ns := c.Param("ns")
+	switch kind {
+	case podindex.RefConfigMap, podindex.RefSecret, podindex.RefPVC:
+	default:
+		amis.WriteJsonError(c, fmt.Errorf("不支持的引用类型: %s，可选 configmap、secret、pvc", kind))
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if err := comm.CheckPermissionLogic(ctx, selectedCluster, []string{ns}, ns, "", "list"); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	x, err := service.PodIndexService().Index(ctx, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, x.PodsUsing(kind, ns, c.Param("name")))
+}
+
+// @Summary 使用镜像的工作负载
+// @Description 镜像指定标签或摘要时精确匹配，否则匹配该仓库的所有标签；由 Deployment 创建的 Pod 归到 Deployment，无控制者的 Pod 单独列出
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param image query string true "镜像，如 nginx、nginx:1.25、ghcr.io/org/app@sha256:xxx"
+// @Success 200 {object} []podindex.WorkloadRef
+// @Router /k8s/cluster/{cluster}/pod/index/image [get]
+func (ic *IndexController) WorkloadsUsingImage(c *response.Context) {
+	image := c.Query("image")
+	if image == "" {
+		amis.WriteJsonError(c, fmt.Errorf("镜像不能为空"))
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	x, err := service.PodIndexService().Index(ctx, selectedCluster)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	list := x.WorkloadsUsingImage(image)
+	allowed := namespaceChecker(ctx, selectedCluster)
+	result := make([]*podindex.WorkloadRef, 0, len(list))
+	for _, w := range list {
+		if allowed(w.Namespace) {
+			result = append(result, w)
+		}
+	}
+	amis.WriteJsonList(c, result)
+}
+
+// allowedPods 过滤掉当前用户无权限的命名空间中的 Pod，索引以管理员身份维护，返回前需要按用户权限过滤
+func allowedPods(ctx context.Context, cluster string, pods []*podindex.PodRef) []*podindex.PodRef {
+	allowed := namespaceChecker(ctx, cluster)
+	result := make([]*podindex.PodRef, 0, len(pods))
+	for _, p := range pods {
+		if allowed(p.Namespace) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// namespaceChecker 返回按命名空间缓存结果的权限检查函数
+func namespaceChecker(ctx context.Context, cluster string) func(ns string) bool {
+	checked := map[string]bool{}
+	return func(ns string) bool {
+		ok, found := checked[ns]
+		if !found {
+			ok = comm.CheckPermissionLogic(ctx, cluster, []string{ns}, ns, "", "list") == nil
+			checked[ns] = ok
+		}
+		return ok
+	}
+}
@@ -0,0 +1,40 @@
+package pod
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type InitController struct{}
+
+func RegisterInitRoutes(api chi.Router) {
+	ctrl := &InitController{}
+	api.Get("/pod/containers/ns/{ns}/name/{name}", response.Adapter(ctrl.Containers))
+}
+
+// @Summary Pod 容器初始化详情
+// @Description 按顺序列出 init 容器、原生 sidecar（restartPolicy: Always 的 init 容器）与业务容器的状态，
+// @Description 给出当前执行或阻塞的 init 步骤、失败原因与上一次退出码，状态与 kubectl 的 Init:1/3、Init:CrashLoopBackOff 一致。
+// @Description Pod 列表与 JSON 详情中以注解 init.status、init.progress、init.current、init.reason、sidecars、sidecars.ready 返回摘要
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Success 200 {object} podinit.Insight
+// @Router /k8s/cluster/{cluster}/pod/containers/ns/{ns}/name/{name} [get]
+func (ic *InitController) Containers(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	insight, err := service.PodService().ContainerInsight(ctx, selectedCluster, c.Param("ns"), c.Param("name"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, insight)
+}
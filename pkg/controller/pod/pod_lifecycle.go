@@ -0,0 +1,46 @@
+package pod
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type LifecycleController struct{}
+
+func RegisterLifecycleRoutes(api chi.Router) {
+	ctrl := &LifecycleController{}
+	api.Post("/pod/lifecycle/ns/{ns}/name/{name}/action/{action}", response.Adapter(ctrl.Action))
+}
+
+// @Summary Pod 生命周期操作
+// @Description action 可选 force_delete（按指定宽限期强制删除）、reschedule（隔离所在节点后删除，节点保持隔离）、recreate_elsewhere（临时隔离所在节点，替代 Pod 调度到其他节点后自动解除隔离）。
+// @Description confirm 为 false 时只返回确认信息（归属控制器、节点影响、风险提示），页面确认后以 confirm=true 再次调用执行
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Param action path string true "操作"
+// @Param body body service.PodActionOptions true "操作参数"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/pod/lifecycle/ns/{ns}/name/{name}/action/{action} [post]
+func (lc *LifecycleController) Action(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var opts service.PodActionOptions
+	if err := c.ShouldBindJSON(&opts); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	plan, err := service.PodService().RunAction(ctx, selectedCluster, c.Param("ns"), c.Param("name"), c.Param("action"), &opts)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, plan)
+}
@@ -0,0 +1,54 @@
+package pod
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	v1 "k8s.io/api/core/v1"
+)
+
+type ProbeController struct{}
+
+func RegisterProbeRoutes(api chi.Router) {
+	ctrl := &ProbeController{}
+	api.Post("/pod/probe/ns/{ns}/name/{name}/container/{container_name}/type/{type}", response.Adapter(ctrl.Test))
+}
+
+// ProbeTestRequest 探针测试请求，probe 为空时测试容器中已配置的探针
+type ProbeTestRequest struct {
+	Probe *v1.Probe `json:"probe,omitempty"`
+}
+
+// @Summary 测试容器探针
+// @Description 立即执行一次容器的 liveness、readiness 或 startup 探针，返回是否成功、耗时、HTTP 状态码或命令输出，以及 kubelet 最近记录的同类探针失败事件。
+// @Description 请求体中传入 probe 时使用该定义测试，可在不重新部署的情况下调试探针；exec 探针受命令允许列表约束，httpGet、tcpSocket 探针经 API Server 的 Pod 代理访问
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Param container_name path string true "容器名称"
+// @Param type path string true "探针类型：liveness、readiness、startup"
+// @Param body body ProbeTestRequest true "探针定义，测试已配置的探针时传 {}"
+// @Success 200 {object} service.ProbeTestResult
+// @Router /k8s/cluster/{cluster}/pod/probe/ns/{ns}/name/{name}/container/{container_name}/type/{type} [post]
+func (pc *ProbeController) Test(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req ProbeTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	result, err := service.PodService().TestProbe(ctx, selectedCluster, c.Param("ns"), c.Param("name"), c.Param("container_name"),
+		c.Param("type"), req.Probe)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
@@ -0,0 +1,39 @@
+package pod
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type VolumeController struct{}
+
+func RegisterVolumeRoutes(api chi.Router) {
+	ctrl := &VolumeController{}
+	api.Get("/pod/volumes/ns/{ns}/name/{name}", response.Adapter(ctrl.Usage))
+}
+
+// @Summary Pod 卷与磁盘用量
+// @Description 列出 Pod 的全部卷及来源（PVC、ConfigMap、Secret、hostPath 等）、挂载路径，并读取 kubelet 卷统计；du=true 时在容器内执行 du 列出占用最大的目录，需要 Exec 权限
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "Pod名称"
+// @Param du query bool false "是否在容器内执行 du 统计"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/pod/volumes/ns/{ns}/name/{name} [get]
+func (vc *VolumeController) Usage(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ctx := amis.GetContextWithUser(c)
+	list, err := service.PodService().VolumeUsage(ctx, selectedCluster, c.Param("ns"), c.Param("name"), c.Query("du") == "true")
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, list)
+}
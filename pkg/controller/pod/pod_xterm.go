@@ -20,9 +20,11 @@ import (
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/comm/xterm"
+	"github.com/weibaohui/k8m/pkg/constants"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/response"
 	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/telemetry"
 	"github.com/weibaohui/kom/kom"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/remotecommand"
@@ -128,7 +130,7 @@ func (xc *XtermController) Xterm(c *response.Context) {
 	ns := c.Param("ns")
 	podName := c.Param("pod_name")
 	containerName := c.Query("container_name")
-	ctx := amis.GetContextWithUser(c)
+	ctx := context.WithValue(amis.GetContextWithUser(c), constants.CommandScope, constants.CommandScopeTerminal)
 	selectedCluster, err := amis.GetSelectedCluster(c)
 	if err != nil {
 		amis.WriteJsonError(c, err)
@@ -185,6 +187,7 @@ func (xc *XtermController) Xterm(c *response.Context) {
 		return
 	}
 	defer conn.Close()
+	defer telemetry.TrackWebSocketSession("xterm")()
 	klog.V(6).Infof("ws Client connected")
 
 	// 创建一个写锁，用于保护WebSocket写操作
@@ -331,6 +334,29 @@ func (xc *XtermController) Xterm(c *response.Context) {
 				}
 			}
 
+			// 回车或换行提交命令前，逐行校验命令策略，任一行命中时整条消息都不写入并发送 Ctrl+C 取消当前输入。
+			// 命令由输入按键拼接而来，已处理退格等编辑按键，经过补全、历史切换的命令只能尽力识别
+			cmdBufferMutex.Lock()
+			lines, rest := utils.SplitTerminalInput(cmdBuffer.String(), data)
+			cmdBufferMutex.Unlock()
+			var denied error
+			for _, line := range lines {
+				if err := service.CommandPolicyService().Check(selectedCluster, constants.CommandScopeTerminal, line); err != nil {
+					denied = err
+					go service.CommandPolicyService().RecordViolation(selectedCluster, ns, podName, containerName,
+						amis.GetLoginUser(c), constants.CommandScopeTerminal, line, err)
+					break
+				}
+			}
+			if denied != nil {
+				_, _ = inWriter.Write([]byte{0x03})
+				_ = safeWriteMessage(websocket.TextMessage, []byte("\r\n"+denied.Error()+"\r\n"))
+				cmdBufferMutex.Lock()
+				cmdBuffer.Reset()
+				cmdBufferMutex.Unlock()
+				continue
+			}
+
 			// write to tty
 			// 普通输入
 			bytesWritten, err := inWriter.Write(data)
@@ -339,21 +365,15 @@ func (xc *XtermController) Xterm(c *response.Context) {
 				continue
 			}
 
-			// 使用互斥锁保护 cmdBuffer 的读写操作
+			// 使用互斥锁保护 cmdBuffer 的读写操作，只保留尚未提交的输入
 			cmdBufferMutex.Lock()
-			cmdBuffer.Write(data)
-			if bytes.Contains(data, []byte("\r")) {
-				// 获取完整命令并去除回车符
-				cmd := strings.TrimSuffix(cmdBuffer.String(), "\r")
-				// 只有当命令不为空时才记录
-				if strings.TrimSpace(cmd) != "" {
-					klog.V(8).Infof("收到完整命令: %s", cmd)
-					go cmdLogger(c, cmd)
-				}
-				// 清空缓冲区,准备接收新命令
-				cmdBuffer.Reset()
-			}
+			cmdBuffer.Reset()
+			cmdBuffer.WriteString(rest)
 			cmdBufferMutex.Unlock()
+			for _, cmd := range lines {
+				klog.V(8).Infof("收到完整命令: %s", cmd)
+				go cmdLogger(c, cmd)
+			}
 			klog.V(6).Infof("Wrote %d bytes to inBuffer: %q", bytesWritten, string(data))
 		}
 	}()
@@ -0,0 +1,70 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type Controller struct{}
+
+// RegisterProjectRoutes 注册当前用户的项目路由
+func RegisterProjectRoutes(mgm chi.Router) {
+	ctrl := &Controller{}
+	mgm.Get("/project/my", response.Adapter(ctrl.My))
+	mgm.Post("/project/{id}/members/save", response.Adapter(ctrl.SaveMembers))
+}
+
+// @Summary 我的项目
+// @Description 获取当前用户所在的项目及其成员、包含的集群与命名空间，平台管理员返回全部项目。
+// @Description 资源列表查询条件中传入 project 即可将列表限定在该项目的命名空间内
+// @Security BearerAuth
+// @Success 200 {object} []service.ProjectView
+// @Router /mgm/project/my [get]
+func (pc *Controller) My(c *response.Context) {
+	items, err := service.ProjectService().UserProjects(amis.GetLoginUser(c))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, items)
+}
+
+// @Summary 项目负责人保存成员
+// @Description 项目负责人（owner）整体替换项目成员，不能移除自己的负责人角色
+// @Security BearerAuth
+// @Param id path int true "项目ID"
+// @Param data body object true "成员列表，格式为 {members:[{username,role}]}"
+// @Success 200 {object} string
+// @Router /mgm/project/{id}/members/save [post]
+func (pc *Controller) SaveMembers(c *response.Context) {
+	username := amis.GetLoginUser(c)
+	id := utils.ToUInt(c.Param("id"))
+	if !service.ProjectService().CanManage(username, id) {
+		amis.WriteJsonError(c, fmt.Errorf("仅项目负责人可以管理成员"))
+		return
+	}
+	var req struct {
+		Members []*models.ProjectMember `json:"members"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if !service.UserService().IsUserPlatformAdmin(username) {
+		kept := false
+		for _, m := range req.Members {
+			kept = kept || (m.Username == username && m.Role == models.ProjectRoleOwner)
+		}
+		if !kept {
+			amis.WriteJsonError(c, fmt.Errorf("不能移除自己的负责人角色"))
+			return
+		}
+	}
+	amis.WriteJsonErrorOrOK(c, service.ProjectService().SaveMembers(id, req.Members))
+}
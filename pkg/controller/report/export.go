@@ -0,0 +1,87 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/report"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type ExportController struct{}
+
+// RegisterExportRoutes 注册报表预览与导出路由，以当前用户身份读取资源
+func RegisterExportRoutes(api chi.Router) {
+	ctrl := &ExportController{}
+	api.Get("/reports/types", response.Adapter(ctrl.Types))
+	api.Get("/reports/data", response.Adapter(ctrl.Data))
+	api.Get("/reports/export", response.Adapter(ctrl.Export))
+}
+
+// @Summary 报表类型列表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} []service.ReportType
+// @Router /k8s/cluster/{cluster}/reports/types [get]
+func (ec *ExportController) Types(c *response.Context) {
+	amis.WriteJsonData(c, service.ReportService().Types())
+}
+
+// @Summary 预览报表
+// @Description 返回表格数据，用于页面展示
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param type query string true "报表类型：inventory、rbac、images、quota"
+// @Success 200 {object} report.Table
+// @Router /k8s/cluster/{cluster}/reports/data [get]
+func (ec *ExportController) Data(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	table, err := service.ReportService().Generate(amis.GetContextWithUser(c), selectedCluster, c.Query("type"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, table)
+}
+
+// @Summary 导出报表
+// @Description xlsx 可一次导出多种报表，每种报表一个工作表；csv 一次只能导出一种报表
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param types query string false "报表类型，逗号分隔，为空表示全部"
+// @Param format query string false "导出格式：xlsx（默认）或 csv"
+// @Success 200 {file} file
+// @Router /k8s/cluster/{cluster}/reports/export [get]
+func (ec *ExportController) Export(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	types, err := service.ReportService().ParseTypes(c.Query("types"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	format := c.Query("format")
+	if format == "" {
+		format = report.FormatXLSX
+	}
+	if format == report.FormatCSV && len(types) != 1 {
+		amis.WriteJsonError(c, fmt.Errorf("csv 一次只能导出一种报表，多种报表请使用 xlsx"))
+		return
+	}
+	files, err := service.ReportService().Export(amis.GetContextWithUser(c), selectedCluster, types, format)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	f := files[0]
+	amis.WriteDownloadData(c, f.Filename, f.ContentType, f.Data)
+}
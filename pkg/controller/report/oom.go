@@ -0,0 +1,79 @@
+package report
+
+import (
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type OOMController struct{}
+
+func RegisterOOMRoutes(api chi.Router) {
+	ctrl := &OOMController{}
+	api.Get("/reports/oom", response.Adapter(ctrl.Report))
+	api.Get("/reports/oom/list", response.Adapter(ctrl.List))
+}
+
+// @Summary OOMKilled 与驱逐趋势
+// @Description 按天统计后台采集的 OOMKilled 与节点驱逐次数，按工作负载或命名空间汇总
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param namespace query string false "命名空间"
+// @Param days query int false "统计天数，默认7，最大90"
+// @Param group_by query string false "汇总维度：workload（默认）或 namespace"
+// @Success 200 {object} service.OOMReport
+// @Router /k8s/cluster/{cluster}/reports/oom [get]
+func (oc *OOMController) Report(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	days, _ := strconv.Atoi(c.Query("days"))
+	if days <= 0 {
+		days = 7
+	}
+	if days > 90 {
+		days = 90
+	}
+	report, err := service.OOMTrackerService().Report(selectedCluster, c.Query("namespace"), days, c.Query("group_by"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, report)
+}
+
+// @Summary OOMKilled 与驱逐记录列表
+// @Description 分页查询后台采集的 OOMKilled 与驱逐明细，可按 kind、namespace、workload、pod 过滤
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/reports/oom/list [get]
+func (oc *OOMController) List(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	params.UserName = ""
+	if c.Query("orderBy") == "" {
+		params.OrderBy = "occurred_at"
+	}
+	m := &models.OOMEvent{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("cluster = ?", selectedCluster)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
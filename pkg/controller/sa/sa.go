@@ -0,0 +1,148 @@
+package sa
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// 令牌有效期范围，API Server 要求不少于 10 分钟
+const (
+	minTokenTTL     = 10 * time.Minute
+	maxTokenTTL     = 24 * time.Hour
+	defaultTokenTTL = time.Hour
+)
+
+type Controller struct{}
+
+// RegisterRBACRoutes 注册 ServiceAccount 权限分析与令牌签发路由
+func RegisterRBACRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/sa/permissions/ns/{ns}/name/{name}", response.Adapter(ctrl.Permissions))
+	api.Post("/sa/token/ns/{ns}/name/{name}", response.Adapter(ctrl.CreateToken))
+	api.Get("/rbac/who_can", response.Adapter(ctrl.WhoCan))
+}
+
+// @Summary ServiceAccount 有效权限
+// @Description 汇总 ServiceAccount 直接绑定及经由 system:serviceaccounts 等组获得的全部 RBAC 规则，按生效范围合并
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "ServiceAccount名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/sa/permissions/ns/{ns}/name/{name} [get]
+func (sc *Controller) Permissions(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	result, err := service.RBACService().ServiceAccountPermissions(amis.GetContextWithUser(c), selectedCluster, c.Param("ns"), c.Param("name"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
+
+// @Summary 反查权限
+// @Description 列出可以对指定资源执行指定动作的主体及授予该权限的绑定。namespace 为空时只查询集群级授权
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param verb query string true "动作，如 get、delete"
+// @Param resource query string false "资源，如 pods、pods/exec"
+// @Param group query string false "API 组，核心组留空"
+// @Param namespace query string false "命名空间"
+// @Param name query string false "资源名称"
+// @Param non_resource query string false "非资源地址，如 /metrics"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/rbac/who_can [get]
+func (sc *Controller) WhoCan(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	q := service.WhoCanQuery{
+		Verb:        c.Query("verb"),
+		Group:       c.Query("group"),
+		Resource:    c.Query("resource"),
+		Namespace:   c.Query("namespace"),
+		Name:        c.Query("name"),
+		NonResource: c.Query("non_resource"),
+	}
+	list, err := service.RBACService().WhoCan(amis.GetContextWithUser(c), selectedCluster, q)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonList(c, list)
+}
+
+// TokenRequest 签发令牌请求
+type TokenRequest struct {
+	ExpirationSeconds int64  `json:"expiration_seconds"` // 有效期，默认 3600，范围 600~86400
+	Audiences         string `json:"audiences"`          // 逗号分隔，为空时使用 API Server 默认受众
+}
+
+// @Summary 签发 ServiceAccount 令牌
+// @Description 通过 TokenRequest 签发短期令牌用于调试，需要该命名空间的变更权限，签发记录写入操作日志
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "ServiceAccount名称"
+// @Param body body TokenRequest false "有效期与受众"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/sa/token/ns/{ns}/name/{name} [post]
+func (sc *Controller) CreateToken(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	ns := c.Param("ns")
+	name := c.Param("name")
+	var req TokenRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
+	ttl := defaultTokenTTL
+	if req.ExpirationSeconds > 0 {
+		ttl = time.Duration(req.ExpirationSeconds) * time.Second
+	}
+	if ttl < minTokenTTL || ttl > maxTokenTTL {
+		amis.WriteJsonError(c, fmt.Errorf("有效期需在 %d 到 %d 秒之间", int64(minTokenTTL.Seconds()), int64(maxTokenTTL.Seconds())))
+		return
+	}
+	var audiences []string
+	for _, a := range strings.Split(req.Audiences, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			audiences = append(audiences, a)
+		}
+	}
+
+	ctx := amis.GetContextWithUser(c)
+	// 令牌代表 ServiceAccount 的全部权限，按变更操作校验
+	if err := comm.CheckPermissionLogic(ctx, selectedCluster, []string{ns}, ns, name, "create"); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	status, err := service.RBACService().CreateToken(ctx, selectedCluster, ns, name, ttl, audiences)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"token":                status.Token,
+		"expiration_timestamp": status.ExpirationTimestamp.Time,
+	})
+}
@@ -1,11 +1,16 @@
 package sse
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"os"
+	"time"
 
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
 	"k8s.io/klog/v2"
 )
 
@@ -18,9 +23,14 @@ func DownloadLog(c *response.Context, containerName string, stream io.ReadCloser
 	}()
 
 	name := fmt.Sprintf("%s.log", containerName)
+	if c.GetHeader("Range") != "" {
+		downloadLogRange(c, name, stream)
+		return
+	}
 	// 设置响应头信息，指定文件下载
 	c.Writer.Header().Set("Content-Disposition", "attachment; filename="+name)
 	c.Writer.Header().Set("Content-Type", "text/plain")
+	c.Writer.Header().Set("Accept-Ranges", "bytes")
 
 	// 将日志直接写入响应流
 	_, err := io.Copy(c.Writer, stream)
@@ -29,3 +39,23 @@ func DownloadLog(c *response.Context, containerName string, stream io.ReadCloser
 		return
 	}
 }
+
+// downloadLogRange 续传请求需要知道总长度，先将日志暂存到本地文件再按 Range 输出。
+// ETag 取日志内容摘要，已结束容器的日志不变，可以正确续传；运行中容器的日志持续增长，If-Range 不匹配时返回完整内容。
+func downloadLogRange(c *response.Context, name string, stream io.Reader) {
+	tmp, err := os.CreateTemp(service.UploadStagingService().Root(), "k8m-log-*")
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), stream); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	amis.WriteDownload(c, name, "text/plain", etag, time.Time{}, tmp)
+}
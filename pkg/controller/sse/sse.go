@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/sashabaranov/go-openai"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/telemetry"
 	"k8s.io/klog/v2"
 )
 
@@ -85,6 +86,7 @@ func WriteWebSocketChatCompletionStream(c *response.Context, stream *openai.Chat
 		return
 	}
 	defer conn.Close()
+	defer telemetry.TrackWebSocketSession("ai_stream")()
 	klog.V(6).Infof("ws Client connected")
 
 	defer func() {
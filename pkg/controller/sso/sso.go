@@ -17,6 +17,11 @@ import (
 	"k8s.io/klog/v2"
 )
 
+const (
+	oidcStateKey = "sso:oidc:state:" // 共享状态表中保存 OIDC 登录 state 的键前缀
+	oidcStateTTL = 10 * time.Minute
+)
+
 // @Summary 获取SSO配置列表
 // @Description 获取所有已启用的SSO配置项
 // @Security BearerAuth
@@ -52,7 +57,12 @@ func (au *AuthController) GetAuthCodeURL(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	state := utils.RandNLengthString(8)
+	// state 保存在多实例共享的状态存储中，回调可能由负载均衡后的其他实例处理
+	state := utils.RandNLengthString(32)
+	if err = service.StateStoreService().Put(oidcStateKey+state, name, oidcStateTTL); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
 	url := client.OAuth2Config.AuthCodeURL(state)
 	klog.Infof("url: %s", url)
 	c.Redirect(http.StatusFound, url)
@@ -63,6 +73,7 @@ func (au *AuthController) GetAuthCodeURL(c *response.Context) {
 // @Security BearerAuth
 // @Param name path string true "SSO名称"
 // @Param code query string true "认证代码"
+// @Param state query string true "发起登录时签发的 state"
 // @Success 200 {string} string
 // @Router /auth/oidc/{name}/callback [get]
 func (au *AuthController) HandleCallback(c *response.Context) {
@@ -73,6 +84,15 @@ func (au *AuthController) HandleCallback(c *response.Context) {
 		amis.WriteJsonError(c, err)
 		return
 	}
+	// 校验 state 由本系统签发且只能使用一次，防止登录 CSRF
+	state := c.Query("state")
+	var stateName string
+	if ok, err := service.StateStoreService().Get(oidcStateKey+state, &stateName); err != nil || !ok || stateName != name {
+		amis.WriteJsonError(c, fmt.Errorf("登录状态无效或已过期，请重新登录"))
+		return
+	}
+	_ = service.StateStoreService().Delete(oidcStateKey + state)
+
 	code := c.Query("code")
 	oauth2Token, err := client.OAuth2Config.Exchange(ctx, code)
 	if err != nil {
@@ -102,9 +122,10 @@ func (au *AuthController) HandleCallback(c *response.Context) {
 	// claims["groups"] = []string{"CRM开发组", "bdd", "c", "d"}
 
 	username := GetUsername(claims, strings.Split(client.DBConfig.PreferUserNameKeys, ","))
-	groups := GetUserGroups(claims)
-	_ = service.UserService().CheckAndCreateUser(username, name, groups)
-	userLoginToken, err := service.UserService().GenerateJWTTokenOnlyUserName(username, 24*time.Hour)
+	// 按外部用户组映射转换为 k8m 用户组，未配置映射的组名原样使用
+	groups := service.UserService().MapExternalGroups(models.ExternalGroupSourceOIDC, strings.Split(GetUserGroups(claims), ","), true)
+	_ = service.UserService().CheckAndCreateUser(username, name, strings.Join(groups, ","))
+	userLoginToken, err := service.UserService().GenerateLoginToken(username, 24*time.Hour, false)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
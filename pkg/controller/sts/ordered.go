@@ -0,0 +1,124 @@
+package sts
+
+import (
+	"fmt"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/task"
+)
+
+// @Summary 获取StatefulSet版本分布
+// @Description 返回更新策略、分区、当前版本与目标版本，以及各版本上的 Pod 序号
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "StatefulSet名称"
+// @Success 200 {object} service.StatefulSetRevisionStatus
+// @Router /k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/revisions [get]
+func (cc *Controller) Revisions(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	status, err := service.StatefulSetService().Revisions(ctx, selectedCluster, c.Param("ns"), c.Param("name"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, status)
+}
+
+// @Summary 设置StatefulSet滚动更新分区
+// @Description 序号不小于分区的 Pod 更新到目标版本，其余保持当前版本；逐步调小分区即可分批发布
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "StatefulSet名称"
+// @Param partition body int true "分区，0 到副本数之间"
+// @Success 200 {object} service.StatefulSetRevisionStatus
+// @Router /k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/partition [post]
+func (cc *Controller) Partition(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	var req struct {
+		Partition *int32 `json:"partition"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	if req.Partition == nil {
+		amis.WriteJsonError(c, fmt.Errorf("分区不能为空"))
+		return
+	}
+	status, err := service.StatefulSetService().SetPartition(ctx, selectedCluster, c.Param("ns"), c.Param("name"), *req.Partition)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, status)
+}
+
+// @Summary 按序号逐个重启StatefulSet的Pod
+// @Description 作为异步任务执行，逐个删除 Pod 并等待重建的 Pod 就绪后再处理下一个，某个 Pod 超时未就绪时停止。返回任务ID，可在 /mgm/tasks 查看进度与取消
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "StatefulSet名称"
+// @Param body body service.StatefulSetRestartOptions true "重启参数"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/ordered_restart [post]
+func (cc *Controller) OrderedRestart(c *response.Context) {
+	ns := c.Param("ns")
+	name := c.Param("name")
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	opts := &service.StatefulSetRestartOptions{}
+	if err := c.ShouldBindJSON(opts); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	title := fmt.Sprintf("按序重启 StatefulSet %s/%s", ns, name)
+	t, err := service.TaskService().Submit(amis.GetContextWithUser(c), "statefulset_restart", title, selectedCluster, func(run *task.Run) (string, error) {
+		return service.StatefulSetService().Restart(run, selectedCluster, ns, name, opts)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"task_id": t.ID})
+}
+
+// @Summary 保留Pod删除StatefulSet
+// @Description 以 Orphan 级联策略删除，Pod 与 PVC 继续运行。返回删除前的定义，修改后重新创建即可接管原有 Pod
+// @Security BearerAuth
+// @Param cluster query string true "集群名称"
+// @Param ns path string true "命名空间"
+// @Param name path string true "StatefulSet名称"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/orphan_delete [post]
+func (cc *Controller) OrphanDelete(c *response.Context) {
+	ctx := amis.GetContextWithUser(c)
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	manifest, err := service.StatefulSetService().OrphanDelete(ctx, selectedCluster, c.Param("ns"), c.Param("name"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{"yaml": manifest})
+}
@@ -25,6 +25,10 @@ func RegisterRoutes(r chi.Router) {
 	r.Post("/statefulset/batch/restore", response.Adapter(ctrl.BatchRestore))
 	r.Post("/statefulset/ns/{ns}/name/{name}/scale/replica/{replica}", response.Adapter(ctrl.Scale))
 	r.Get("/statefulset/ns/{ns}/name/{name}/hpa", response.Adapter(ctrl.HPA))
+	r.Get("/statefulset/ns/{ns}/name/{name}/revisions", response.Adapter(ctrl.Revisions))
+	r.Post("/statefulset/ns/{ns}/name/{name}/partition", response.Adapter(ctrl.Partition))
+	r.Post("/statefulset/ns/{ns}/name/{name}/ordered_restart", response.Adapter(ctrl.OrderedRestart))
+	r.Post("/statefulset/ns/{ns}/name/{name}/orphan_delete", response.Adapter(ctrl.OrphanDelete))
 
 }
 
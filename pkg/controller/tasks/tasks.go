@@ -0,0 +1,164 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/k8m/pkg/task"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"gorm.io/gorm"
+	"k8s.io/klog/v2"
+)
+
+type Controller struct{}
+
+// RegisterTaskRoutes 注册异步任务路由，用户只能查看、取消自己提交的任务，平台管理员可查看全部任务
+func RegisterTaskRoutes(r chi.Router) {
+	ctrl := &Controller{}
+	r.Get("/tasks/list", response.Adapter(ctrl.List))
+	r.Get("/tasks/id/{id}", response.Adapter(ctrl.Get))
+	r.Post("/tasks/id/{id}/cancel", response.Adapter(ctrl.Cancel))
+	r.Get("/tasks/id/{id}/ws", response.Adapter(ctrl.Stream))
+}
+
+// @Summary 异步任务列表
+// @Description 支持按 type、status、cluster 过滤，列表不包含日志
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/tasks/list [get]
+func (tc *Controller) List(c *response.Context) {
+	params := dao.BuildParams(c)
+	username := params.UserName
+	params.UserName = ""
+	admin := service.UserService().IsUserPlatformAdmin(username)
+	m := &models.Task{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		db = db.Omit("log")
+		if !admin {
+			db = db.Where("created_by = ?", username)
+		}
+		return db
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// visibleTask 读取当前用户可见的任务
+func visibleTask(c *response.Context) (*models.Task, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("任务ID格式错误")
+	}
+	t, err := service.TaskService().Get(uint(id))
+	if err != nil {
+		return nil, err
+	}
+	username := amis.GetLoginUser(c)
+	if t.CreatedBy != username && !service.UserService().IsUserPlatformAdmin(username) {
+		return nil, fmt.Errorf("任务 %d 不存在", id)
+	}
+	return t, nil
+}
+
+// @Summary 异步任务详情
+// @Description 返回任务状态、进度与日志
+// @Security BearerAuth
+// @Param id path int true "任务ID"
+// @Success 200 {object} string
+// @Router /mgm/tasks/id/{id} [get]
+func (tc *Controller) Get(c *response.Context) {
+	t, err := visibleTask(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, t)
+}
+
+// @Summary 取消异步任务
+// @Security BearerAuth
+// @Param id path int true "任务ID"
+// @Success 200 {object} string
+// @Router /mgm/tasks/id/{id}/cancel [post]
+func (tc *Controller) Cancel(c *response.Context) {
+	t, err := visibleTask(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonErrorOrOK(c, service.TaskService().Cancel(t.ID))
+}
+
+// TaskEvent WebSocket 推送的任务进度，log 为自上次推送以来新增的日志
+type TaskEvent struct {
+	ID       uint   `json:"id"`
+	Status   string `json:"status"`
+	Progress int    `json:"progress"`
+	Message  string `json:"message,omitempty"`
+	Log      string `json:"log,omitempty"`
+	LogSize  int    `json:"log_size"`
+}
+
+// @Summary 订阅异步任务进度
+// @Description WebSocket 连接，任务进度或日志变化时推送 TaskEvent，任务结束后关闭连接
+// @Security BearerAuth
+// @Param id path int true "任务ID"
+// @Router /mgm/tasks/id/{id}/ws [get]
+func (tc *Controller) Stream(c *response.Context) {
+	t, err := visibleTask(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		klog.Errorf("WebSocket Upgrade Error:%v", err)
+		return
+	}
+	defer conn.Close()
+	defer telemetry.TrackWebSocketSession("task")()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	// 客户端断开时结束推送，客户端无需发送消息
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	sent := 0
+	for t = range service.TaskService().Watch(ctx, t.ID) {
+		event := &TaskEvent{
+			ID:       t.ID,
+			Status:   t.Status,
+			Progress: t.Progress,
+			Message:  t.Message,
+			Log:      task.LogDelta(t.Log, t.LogSize, sent),
+			LogSize:  t.LogSize,
+		}
+		sent = t.LogSize
+		if err := conn.WriteJSON(event); err != nil {
+			return
+		}
+	}
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
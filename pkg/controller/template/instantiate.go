@@ -0,0 +1,50 @@
+package template
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/kom/kom"
+)
+
+// RegisterTemplateInstantiateRoutes 注册模板实例化路由，需挂载在集群路由下
+func RegisterTemplateInstantiateRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Post("/custom/template/{id}/instantiate", response.Adapter(ctrl.Instantiate))
+}
+
+// @Summary 实例化模板
+// @Description 使用给定变量渲染模板，并应用到当前集群
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param id path string true "模板ID"
+// @Param body body RenderRequest true "渲染参数"
+// @Success 200 {object} string
+// @Router /k8s/cluster/{cluster}/custom/template/{id}/instantiate [post]
+func (t *Controller) Instantiate(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params := dao.BuildParams(c)
+	req := &RenderRequest{}
+	if err = c.ShouldBindJSON(req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	rendered, item, err := renderTemplate(params, c.Param("id"), req)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+
+	ctx := amis.GetContextWithUser(c)
+	result := kom.Cluster(selectedCluster).WithContext(ctx).Applier().Apply(rendered)
+	amis.WriteJsonData(c, response.H{
+		"version": item.Version,
+		"yaml":    rendered,
+		"result":  result,
+	})
+}
@@ -1,11 +1,17 @@
 package template
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
 )
 
 type Controller struct {
@@ -19,18 +25,64 @@ func RegisterTemplateRoutes(r chi.Router) {
 	r.Get("/custom/template/list", response.Adapter(ctrl.List))
 	r.Post("/custom/template/save", response.Adapter(ctrl.Save))
 	r.Post("/custom/template/delete/{ids}", response.Adapter(ctrl.Delete))
+	r.Get("/custom/template/{id}/versions", response.Adapter(ctrl.ListVersions))
+	r.Post("/custom/template/{id}/render", response.Adapter(ctrl.Render))
+}
+
+// visibleScope 限定当前用户可见的模板范围：
+// 平台管理员可见全部；其他用户可见自己创建的、全局共享的以及共享给所在用户组、所在项目的模板
+func visibleScope(username string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if service.UserService().IsUserPlatformAdmin(username) {
+			return db
+		}
+		cond := dao.DB().Where("created_by = ?", username).Or("is_global = ?", true)
+		groups, _ := service.UserService().GetGroupNames(username)
+		for _, g := range groups {
+			cond = cond.Or(listContains("group_names", g))
+		}
+		for _, p := range service.ProjectService().UserProjectNames(username) {
+			cond = cond.Or(listContains("projects", p))
+		}
+		return db.Where(cond)
+	}
+}
+
+// likeEscaper 转义 LIKE 通配符，配合 ESCAPE '!' 使用，sqlite、mysql、postgresql 均支持
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// listContains 判断逗号分隔的列中是否包含完整的 value，避免子串匹配使 dev 命中 dev-ops
+func listContains(column, value string) *gorm.DB {
+	v := likeEscaper.Replace(value)
+	return dao.DB().Where(column+" = ?", value).
+		Or(column+" like ? escape '!'", v+",%").
+		Or(column+" like ? escape '!'", "%,"+v).
+		Or(column+" like ? escape '!'", "%,"+v+",%")
+}
+
+// getVisibleTemplate 获取当前用户可见的模板
+func getVisibleTemplate(params *dao.Params, id string) (*models.CustomTemplate, error) {
+	m := &models.CustomTemplate{}
+	item, err := m.GetOne(nil, visibleScope(params.UserName), func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", utils.ToUInt(id))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("模板不存在或无权访问: %v", err)
+	}
+	return item, nil
 }
 
 // @Summary 模板列表
-// @Description 获取所有自定义模板信息
+// @Description 获取当前用户可见的自定义模板信息，指定 projects 时仅返回共享给该项目的模板
 // @Security BearerAuth
+// @Param projects query string false "项目名称"
 // @Success 200 {object} string
 // @Router /mgm/custom/template/list [get]
 func (t *Controller) List(c *response.Context) {
 	params := dao.BuildParams(c)
 	m := &models.CustomTemplate{}
 
-	items, total, err := m.List(params)
+	items, total, err := m.List(params, visibleScope(params.UserName))
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
@@ -39,7 +91,7 @@ func (t *Controller) List(c *response.Context) {
 }
 
 // @Summary 保存模板
-// @Description 新增或更新自定义模板
+// @Description 新增或更新自定义模板，内容变更时自动递增版本号并保存历史版本
 // @Security BearerAuth
 // @Param template body models.CustomTemplate true "模板信息"
 // @Success 200 {object} string "返回模板ID"
@@ -57,19 +109,58 @@ func (t *Controller) Save(c *response.Context) {
 	if m.Kind == "" {
 		m.Kind = "未分类"
 	}
+	if m.Engine != "" && m.Engine != utils.TemplateEngineGo && m.Engine != utils.TemplateEngineEnvsubst {
+		amis.WriteJsonError(c, fmt.Errorf("不支持的模板引擎: %s", m.Engine))
+		return
+	}
+
+	changed := true
+	if m.ID > 0 {
+		old, err := getVisibleTemplate(params, fmt.Sprintf("%d", m.ID))
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		if old.CreatedBy != params.UserName && !service.UserService().IsUserPlatformAdmin(params.UserName) {
+			amis.WriteJsonError(c, fmt.Errorf("仅模板创建者可以修改模板"))
+			return
+		}
+		m.CreatedBy = old.CreatedBy
+		m.Version = old.Version
+		changed = old.Content != m.Content || old.Engine != m.Engine
+		if changed {
+			m.Version++
+		}
+	} else {
+		m.Version = 1
+	}
 
 	err = m.Save(params)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
+
+	if changed {
+		v := &models.CustomTemplateVersion{
+			TemplateID: m.ID,
+			Version:    m.Version,
+			Content:    m.Content,
+			Engine:     m.Engine,
+		}
+		if err = v.Save(params); err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+	}
 	amis.WriteJsonData(c, response.H{
-		"id": m.ID,
+		"id":      m.ID,
+		"version": m.Version,
 	})
 }
 
 // @Summary 删除模板
-// @Description 删除一个或多个自定义模板
+// @Description 删除一个或多个自定义模板，仅模板创建者或平台管理员可以删除
 // @Security BearerAuth
 // @Param ids path string true "要删除的模板ID，多个用逗号分隔"
 // @Success 200 {object} string "操作成功"
@@ -77,6 +168,20 @@ func (t *Controller) Save(c *response.Context) {
 func (t *Controller) Delete(c *response.Context) {
 	ids := c.Param("ids")
 	params := dao.BuildParams(c)
+	isAdmin := service.UserService().IsUserPlatformAdmin(params.UserName)
+	for _, id := range utils.ToInt64Slice(ids) {
+		item, err := getVisibleTemplate(params, fmt.Sprintf("%d", id))
+		if err != nil {
+			amis.WriteJsonError(c, err)
+			return
+		}
+		if item.CreatedBy != params.UserName && !isAdmin {
+			amis.WriteJsonError(c, fmt.Errorf("仅模板创建者可以删除模板 %s", item.Name))
+			return
+		}
+	}
+	// 已逐个校验权限，平台管理员可删除他人创建的模板，不再按创建者过滤
+	params.UserName = ""
 	m := &models.CustomTemplate{}
 	err := m.Delete(params, ids)
 	if err != nil {
@@ -85,3 +190,86 @@ func (t *Controller) Delete(c *response.Context) {
 	}
 	amis.WriteJsonOK(c)
 }
+
+// @Summary 模板历史版本列表
+// @Description 获取模板的全部历史版本，按版本号倒序
+// @Security BearerAuth
+// @Param id path string true "模板ID"
+// @Success 200 {object} string
+// @Router /mgm/custom/template/{id}/versions [get]
+func (t *Controller) ListVersions(c *response.Context) {
+	params := dao.BuildParams(c)
+	item, err := getVisibleTemplate(params, c.Param("id"))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	params.OrderBy = "version"
+	params.OrderDir = "desc"
+	// 历史版本可能由不同用户修改，不按创建者过滤
+	params.UserName = ""
+	params.Queries = map[string]any{}
+	m := &models.CustomTemplateVersion{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("template_id = ?", item.ID)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// RenderRequest 模板渲染请求
+type RenderRequest struct {
+	Version   int               `json:"version,omitempty"` // 指定渲染的版本，为空使用最新版本
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// renderTemplate 按请求渲染模板，返回渲染后的YAML
+func renderTemplate(params *dao.Params, id string, req *RenderRequest) (string, *models.CustomTemplate, error) {
+	item, err := getVisibleTemplate(params, id)
+	if err != nil {
+		return "", nil, err
+	}
+	content, engine := item.Content, item.Engine
+	if req.Version > 0 && req.Version != item.Version {
+		v := &models.CustomTemplateVersion{}
+		ver, err := v.GetOne(nil, func(db *gorm.DB) *gorm.DB {
+			return db.Where("template_id = ? and version = ?", item.ID, req.Version)
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("模板版本 %d 不存在", req.Version)
+		}
+		content, engine = ver.Content, ver.Engine
+	}
+	rendered, err := utils.RenderTemplate(engine, content, req.Variables)
+	if err != nil {
+		return "", nil, err
+	}
+	return rendered, item, nil
+}
+
+// @Summary 预览渲染模板
+// @Description 使用给定变量渲染模板，仅返回渲染结果，不应用到集群
+// @Security BearerAuth
+// @Param id path string true "模板ID"
+// @Param body body RenderRequest true "渲染参数"
+// @Success 200 {object} string
+// @Router /mgm/custom/template/{id}/render [post]
+func (t *Controller) Render(c *response.Context) {
+	params := dao.BuildParams(c)
+	req := &RenderRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	rendered, _, err := renderTemplate(params, c.Param("id"), req)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"yaml": rendered,
+	})
+}
@@ -0,0 +1,46 @@
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+type Controller struct{}
+
+// RegisterUpgradeRoutes 注册集群升级检查路由
+func RegisterUpgradeRoutes(api chi.Router) {
+	ctrl := &Controller{}
+	api.Get("/upgrade/readiness", response.Adapter(ctrl.Readiness))
+}
+
+// @Summary 集群升级就绪检查
+// @Description 针对目标 Kubernetes 版本，检查仍在请求或仍以旧版本应用的弃用/移除 API、准入 Webhook 兼容性与后端可用性、
+// @Description 节点 kubelet 版本偏差及是否跨越多个次版本，返回包含阻塞项的升级就绪报告
+// @Security BearerAuth
+// @Param cluster path string true "集群名称"
+// @Param target query string true "目标版本，如 1.30 或 v1.30.2"
+// @Success 200 {object} service.UpgradeReadinessReport
+// @Router /k8s/cluster/{cluster}/upgrade/readiness [get]
+func (uc *Controller) Readiness(c *response.Context) {
+	selectedCluster, err := amis.GetSelectedCluster(c)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	target := strings.TrimSpace(c.Query("target"))
+	if target == "" {
+		amis.WriteJsonError(c, fmt.Errorf("请指定目标版本 target"))
+		return
+	}
+	result, err := service.UpgradeService().Readiness(amis.GetContextWithUser(c), selectedCluster, target)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, result)
+}
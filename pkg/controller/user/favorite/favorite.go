@@ -0,0 +1,123 @@
+package favorite
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"gorm.io/gorm"
+)
+
+type Controller struct{}
+
+func RegisterFavoriteRoutes(mgm chi.Router) {
+	ctrl := &Controller{}
+	mgm.Get("/user/favorites/list", response.Adapter(ctrl.FavoriteList))
+	mgm.Post("/user/favorites/add", response.Adapter(ctrl.FavoriteAdd))
+	mgm.Post("/user/favorites/delete/{ids}", response.Adapter(ctrl.FavoriteDelete))
+	mgm.Get("/user/recent/list", response.Adapter(ctrl.RecentList))
+	mgm.Post("/user/recent/clear", response.Adapter(ctrl.RecentClear))
+	mgm.Get("/home/dashboard", response.Adapter(ctrl.Dashboard))
+}
+
+// FavoriteList 列出当前用户收藏的资源
+// @Summary 收藏的资源列表
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/user/favorites/list [get]
+func (fc *Controller) FavoriteList(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ResourceFavorite{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("created_by = ?", params.UserName)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// FavoriteAdd 收藏资源
+// @Summary 收藏资源
+// @Description 重复收藏同一资源时返回已有记录，每个用户最多收藏 200 个资源
+// @Security BearerAuth
+// @Param request body models.ResourceRef true "资源"
+// @Success 200 {object} string
+// @Router /mgm/user/favorites/add [post]
+func (fc *Controller) FavoriteAdd(c *response.Context) {
+	var ref models.ResourceRef
+	if err := c.ShouldBindJSON(&ref); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	item, err := service.UserResourceService().AddFavorite(amis.GetLoginUser(c), &ref)
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, item)
+}
+
+// FavoriteDelete 取消收藏
+// @Summary 取消收藏
+// @Security BearerAuth
+// @Param ids path string true "收藏ID，多个用逗号分隔"
+// @Success 200 {object} string
+// @Router /mgm/user/favorites/delete/{ids} [post]
+func (fc *Controller) FavoriteDelete(c *response.Context) {
+	params := dao.BuildParams(c)
+	m := &models.ResourceFavorite{}
+	err := m.Delete(params, c.Param("ids"))
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// RecentList 列出当前用户最近访问的资源
+// @Summary 最近访问的资源
+// @Description 查看资源详情、YAML、描述时自动记录，每个用户保留最近 50 条
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/user/recent/list [get]
+func (fc *Controller) RecentList(c *response.Context) {
+	params := dao.BuildParams(c)
+	params.OrderBy, params.OrderDir = "accessed_at", "desc"
+	m := &models.RecentResource{}
+	items, total, err := m.List(params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("created_by = ?", params.UserName)
+	})
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonListWithTotal(c, total, items)
+}
+
+// RecentClear 清空最近访问记录
+// @Summary 清空最近访问记录
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/user/recent/clear [post]
+func (fc *Controller) RecentClear(c *response.Context) {
+	err := service.UserResourceService().ClearRecent(amis.GetLoginUser(c))
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// Dashboard 首页数据：收藏与最近访问的资源及其实时健康状态
+// @Summary 首页收藏与最近访问
+// @Description 以当前用户身份读取各资源的实时状态，health 取值 Healthy、Progressing、Degraded、Missing、Unknown
+// @Security BearerAuth
+// @Success 200 {object} string
+// @Router /mgm/home/dashboard [get]
+func (fc *Controller) Dashboard(c *response.Context) {
+	favorites, recents, err := service.UserResourceService().Dashboard(amis.GetContextWithUser(c), amis.GetLoginUser(c))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, response.H{
+		"favorites": favorites,
+		"recents":   recents,
+	})
+}
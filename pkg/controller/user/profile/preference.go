@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+// Preferences 获取当前用户的偏好设置
+// @Summary 获取用户偏好
+// @Description 获取当前登录用户的语言、主题、分页大小、收藏的集群与命名空间、固定资源及界面状态，未保存过时返回空偏好
+// @Security BearerAuth
+// @Success 200 {object} models.UserPreference
+// @Router /mgm/user/preferences [get]
+func (uc *Controller) Preferences(c *response.Context) {
+	pref, err := service.UserPreferenceService().Get(amis.GetLoginUser(c))
+	if err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	amis.WriteJsonData(c, pref)
+}
+
+// SavePreferences 整体保存当前用户的偏好设置
+// @Summary 保存用户偏好
+// @Description 整体覆盖当前登录用户的偏好设置。language 取值 zh、en，设置后 API 错误信息按该语言返回；theme 取值 light、dark、auto
+// @Security BearerAuth
+// @Param request body models.UserPreference true "用户偏好"
+// @Success 200 {object} string "操作成功"
+// @Router /mgm/user/preferences [post]
+func (uc *Controller) SavePreferences(c *response.Context) {
+	var pref models.UserPreference
+	if err := c.ShouldBindJSON(&pref); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	err := service.UserPreferenceService().Save(amis.GetLoginUser(c), &pref)
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// SaveUIState 保存单项界面状态，不影响其他偏好设置
+// @Summary 保存单项界面状态
+// @Description 请求体为任意 JSON 值，写入 ui_state 中对应的 key；请求体为 null 时删除该 key
+// @Security BearerAuth
+// @Param key path string true "界面状态标识"
+// @Success 200 {object} string "操作成功"
+// @Router /mgm/user/preferences/ui_state/{key} [post]
+func (uc *Controller) SaveUIState(c *response.Context) {
+	var value any
+	if err := c.ShouldBindJSON(&value); err != nil {
+		amis.WriteJsonError(c, err)
+		return
+	}
+	err := service.UserPreferenceService().SaveUIState(amis.GetLoginUser(c), c.Param("key"), value)
+	amis.WriteJsonErrorOrOK(c, err)
+}
+
+// ResetPreferences 清除当前用户的偏好设置
+// @Summary 重置用户偏好
+// @Description 删除当前登录用户保存的全部偏好设置，恢复默认值
+// @Security BearerAuth
+// @Success 200 {object} string "操作成功"
+// @Router /mgm/user/preferences/reset [post]
+func (uc *Controller) ResetPreferences(c *response.Context) {
+	err := service.UserPreferenceService().Reset(amis.GetLoginUser(c))
+	amis.WriteJsonErrorOrOK(c, err)
+}
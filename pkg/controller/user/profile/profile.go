@@ -1,8 +1,8 @@
 package profile
 
 import (
-	"encoding/base64"
 	"fmt"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/weibaohui/k8m/internal/dao"
@@ -21,9 +21,14 @@ func RegisterProfileRoutes(mgm chi.Router) {
 	mgm.Get("/user/profile", response.Adapter(ctrl.Profile))
 	mgm.Get("/user/profile/cluster/permissions/list", response.Adapter(ctrl.ListUserPermissions))
 	mgm.Post("/user/profile/update_psw", response.Adapter(ctrl.UpdatePsw))
+	mgm.Get("/user/profile/password_policy", response.Adapter(ctrl.PasswordPolicy))
 	mgm.Post("/user/profile/2fa/generate", response.Adapter(ctrl.Generate2FASecret))
 	mgm.Post("/user/profile/2fa/disable", response.Adapter(ctrl.Disable2FA))
 	mgm.Post("/user/profile/2fa/enable", response.Adapter(ctrl.Enable2FA))
+	mgm.Get("/user/preferences", response.Adapter(ctrl.Preferences))
+	mgm.Post("/user/preferences", response.Adapter(ctrl.SavePreferences))
+	mgm.Post("/user/preferences/ui_state/{key}", response.Adapter(ctrl.SaveUIState))
+	mgm.Post("/user/preferences/reset", response.Adapter(ctrl.ResetPreferences))
 }
 
 // @Summary 获取用户信息
@@ -74,7 +79,8 @@ type PasswordUpdateRequest struct {
 }
 
 // @Summary 修改密码
-// @Description 修改当前登录用户的密码，需要验证原密码并两次输入新密码确认
+// @Description 修改当前登录用户的密码，需要验证原密码并两次输入新密码确认，新密码须符合密码策略。
+// @Description 修改后该用户此前的登录会话全部失效，返回新的 Token
 // @Security BearerAuth
 // @Param request body PasswordUpdateRequest true "密码修改请求"
 // @Success 200 {object} string "操作成功"
@@ -104,13 +110,12 @@ func (uc *Controller) UpdatePsw(c *response.Context) {
 	}
 
 	// 验证原密码是否正确
-	oldPswWithSalt := fmt.Sprintf("%s%s", string(oldPswBytes), currentUser.Salt)
-	encryptedOldPsw, err := utils.AesEncrypt([]byte(oldPswWithSalt))
+	encryptedOldPsw, err := service.UserService().EncryptPassword(string(oldPswBytes), currentUser.Salt)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	if currentUser.Password != base64.StdEncoding.EncodeToString(encryptedOldPsw) {
+	if currentUser.Password != encryptedOldPsw {
 		amis.WriteJsonError(c, fmt.Errorf("原密码不正确"))
 		return
 	}
@@ -133,24 +138,26 @@ func (uc *Controller) UpdatePsw(c *response.Context) {
 		return
 	}
 
-	// 密码 + 盐重新计算
-	m := models.User{}
-	m.Salt = utils.RandNLengthString(8)
-	psw, err := utils.AesEncrypt([]byte(fmt.Sprintf("%s%s", string(pswBytes), m.Salt)))
+	// 用户名是从token中获取的，不能使用用户前端传递过来的用户名
+	err = service.UserService().ChangePassword(params.UserName, string(pswBytes), false)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
-
-	m.Password = base64.StdEncoding.EncodeToString(psw)
-	m.Username = params.UserName // 用户名是从token中获取的，不能使用用户前端传递过来的用户名
-	params.UserName = ""         // 避免增加CreatedBy字段,因为查询用户集群权限，是管理员授权的，所以不需要CreatedBy字段
-
-	err = dao.DB().Select([]string{"password", "salt"}).Where("username=?", m.Username).Updates(m).Error
-
+	// 旧 Token 已失效，为当前会话签发新的 Token
+	token, err := service.UserService().GenerateLoginToken(params.UserName, 24*time.Hour, false)
 	if err != nil {
 		amis.WriteJsonError(c, err)
 		return
 	}
-	amis.WriteJsonOK(c)
+	amis.WriteJsonData(c, response.H{"token": token})
+}
+
+// @Summary 获取密码策略
+// @Description 获取本地账户的密码策略，字段为 0 表示不限制，用于修改密码前提示
+// @Security BearerAuth
+// @Success 200 {object} service.PasswordPolicy
+// @Router /mgm/user/profile/password_policy [get]
+func (uc *Controller) PasswordPolicy(c *response.Context) {
+	amis.WriteJsonData(c, service.UserService().PasswordPolicy())
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/weibaohui/k8m/internal/dao"
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/comm/utils/totp"
+	"github.com/weibaohui/k8m/pkg/flag"
 	"github.com/weibaohui/k8m/pkg/models"
 	"github.com/weibaohui/k8m/pkg/response"
 )
@@ -150,7 +151,7 @@ func (uc *Controller) Enable2FA(c *response.Context) {
 	}
 
 	// 验证TOTP代码
-	if !totp.ValidateCode(user.TwoFASecret, req.Code) {
+	if !totp.ValidateCode(user.TwoFASecret, req.Code, uint(flag.Init().TOTPSkew)) {
 		amis.WriteJsonError(c, fmt.Errorf("验证码无效"))
 		return
 	}
@@ -0,0 +1,140 @@
+// Package davfs 以 WebDAV 暴露容器文件系统，供本地编辑器、rclone、rsync（配合 WebDAV 挂载）直接访问容器中的文件。
+// 路径为 /dav/{集群}/{命名空间}/{Pod}/{容器}/{容器内路径}，集群名称与 HTTP 接口相同，使用 URL 安全的 Base64 编码。
+// 所有操作以登录用户身份在容器中执行命令完成，与文件管理接口一样受集群权限、命令策略、只读模式约束并记录操作日志。
+package davfs
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/listener"
+	"github.com/weibaohui/k8m/pkg/service"
+	"golang.org/x/net/webdav"
+	"k8s.io/klog/v2"
+)
+
+// Prefix WebDAV 网关的路径前缀
+const Prefix = "/dav"
+
+// webdavMethods chi 默认不识别的 WebDAV 方法，需要在注册路由前登记
+var webdavMethods = []string{"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"}
+
+// RegisterRoutes 在 /dav/ 下挂载 WebDAV 网关。网关不经过 AuthMiddleware，由 Handler 自行认证
+func RegisterRoutes(r chi.Router) {
+	for _, m := range webdavMethods {
+		chi.RegisterMethod(m)
+	}
+	h := Handler()
+	r.Handle(Prefix, h)
+	r.Handle(Prefix+"/*", h)
+}
+
+// Handler 返回认证后交给 WebDAV 处理的 http.Handler
+func Handler() http.Handler {
+	dav := &webdav.Handler{
+		Prefix:     Prefix,
+		FileSystem: &FileSystem{},
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				klog.V(6).Infof("WebDAV %s %s: %v", r.Method, r.URL.Path, err)
+			}
+		},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, status, msg := authenticate(r)
+		if status != http.StatusOK {
+			if status == http.StatusUnauthorized {
+				w.Header().Set("WWW-Authenticate", `Basic realm="k8m", charset="UTF-8"`)
+			}
+			http.Error(w, msg, status)
+			return
+		}
+		// 网关不经过 AuthMiddleware，访问规则中间件也就取不到用户，在这里按相同规则校验
+		req := &service.AccessRequest{
+			Username:   username,
+			IP:         listener.ClientIP(r),
+			Country:    listener.Country(r),
+			Operations: operations(r.Method),
+			Time:       time.Now(),
+		}
+		if loc, err := parse(strings.TrimPrefix(r.URL.Path, Prefix)); err == nil {
+			req.Cluster = loc.Cluster
+		}
+		if err := service.AccessRuleService().Check(req); err != nil {
+			service.AccessRuleService().RecordViolation(req, r.Method, r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		ctx := context.WithValue(r.Context(), constants.JwtUserName, username)
+		ctx = context.WithValue(ctx, statCacheKey{}, &sync.Map{})
+		dav.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// operations 将 WebDAV 方法对应到访问规则的操作类别，与文件管理接口一致
+func operations(method string) []string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND", "LOCK", "UNLOCK":
+		return nil
+	case http.MethodDelete:
+		return []string{"file_delete", "write"}
+	}
+	return []string{"file_write", "write"}
+}
+
+// authenticate 识别请求用户：客户端证书或可信代理头，或 Authorization 中的 Token。
+// WebDAV 客户端通常只支持 Basic 认证，此时密码填写 API 密钥，用户名填写 k8m 用户名或留空
+func authenticate(r *http.Request) (string, int, string) {
+	if username, source, ok := listener.ExternalUser(r); ok {
+		if err := service.UserService().EnsureExternalUser(username, source); err != nil {
+			return "", http.StatusUnauthorized, err.Error()
+		}
+		return username, http.StatusOK, ""
+	}
+
+	basicUser, token := credentials(r.Header.Get("Authorization"))
+	if token == "" {
+		return "", http.StatusUnauthorized, "未提供 Token"
+	}
+	cfg := flag.Init()
+	claims, err := utils.GetJwtMapClaimsFromToken(token, cfg.JwtTokenSecret, cfg.JwtLeeway())
+	if err != nil {
+		return "", http.StatusUnauthorized, err.Error()
+	}
+	username, _ := claims[constants.JwtUserName].(string)
+	if username == "" || basicUser != "" && basicUser != username {
+		return "", http.StatusUnauthorized, "用户名与 Token 不匹配"
+	}
+	if iat, ok := claims["iat"].(float64); ok && service.UserService().TokenRevoked(username, int64(iat)) {
+		return "", http.StatusUnauthorized, "登录已失效，请重新登录"
+	}
+	if must, _ := claims[constants.JwtMustChangePassword].(bool); must {
+		return "", http.StatusForbidden, "密码已过期或被要求修改，请先修改密码"
+	}
+	return username, http.StatusOK, ""
+}
+
+// credentials 从 Authorization 头中取出 Basic 认证的用户名与作为密码的 Token，或 Bearer Token
+func credentials(header string) (string, string) {
+	switch {
+	case strings.HasPrefix(header, "Bearer "):
+		return "", strings.TrimSpace(header[len("Bearer "):])
+	case strings.HasPrefix(header, "Basic "):
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(header[len("Basic "):]))
+		if err != nil {
+			return "", ""
+		}
+		user, password, _ := strings.Cut(string(raw), ":")
+		return user, password
+	}
+	return "", ""
+}
@@ -0,0 +1,138 @@
+package davfs
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// 引入 service 包会在当前目录创建数据库文件
+func TestMain(m *testing.M) {
+	code := m.Run()
+	_ = os.RemoveAll("data")
+	os.Exit(code)
+}
+
+func TestParse(t *testing.T) {
+	cluster := utils.UrlSafeBase64Encode("config/ctx-a")
+	cases := []struct {
+		name string
+		want location
+	}{
+		{"/", location{}},
+		{"", location{}},
+		{"/" + cluster, location{Cluster: "config/ctx-a", Path: "/", depth: depthCluster}},
+		{"/" + cluster + "/default/web-0/", location{Cluster: "config/ctx-a", Namespace: "default", Pod: "web-0", Path: "/", depth: depthPod}},
+		{"/" + cluster + "/default/web-0/app", location{Cluster: "config/ctx-a", Namespace: "default", Pod: "web-0", Container: "app", Path: "/", depth: depthContainer}},
+		{"/" + cluster + "/default/web-0/app/etc/my file.conf", location{Cluster: "config/ctx-a", Namespace: "default", Pod: "web-0", Container: "app", Path: "/etc/my file.conf", depth: depthContainer}},
+		{"/" + cluster + "/default/web-0/app/tmp/../etc/", location{Cluster: "config/ctx-a", Namespace: "default", Pod: "web-0", Container: "app", Path: "/etc", depth: depthContainer}},
+	}
+	for _, tc := range cases {
+		got, err := parse(tc.name)
+		if err != nil || *got != tc.want {
+			t.Errorf("parse(%q) = %+v, %v, want %+v", tc.name, got, err, tc.want)
+		}
+	}
+	if loc, _ := parse("/" + cluster + "/default/web-0/app/etc"); !loc.inContainer() {
+		t.Error("/etc should be in container")
+	}
+	if loc, _ := parse("/" + cluster + "/default/web-0/app/"); loc.inContainer() {
+		t.Error("container root should not be writable")
+	}
+	if _, err := parse("/not*base64/default"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("invalid cluster err = %v", err)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	basic := func(s string) string { return "Basic " + base64.StdEncoding.EncodeToString([]byte(s)) }
+	cases := []struct {
+		header, user, token string
+	}{
+		{"Bearer abc.def ", "", "abc.def"},
+		{basic("admin:abc.def"), "admin", "abc.def"},
+		{basic(":abc.def"), "", "abc.def"},
+		{basic("admin"), "admin", ""},
+		{"Basic !!!", "", ""},
+		{"Digest x", "", ""},
+	}
+	for _, tc := range cases {
+		if user, token := credentials(tc.header); user != tc.user || token != tc.token {
+			t.Errorf("credentials(%q) = %q, %q", tc.header, user, token)
+		}
+	}
+}
+
+func TestOperations(t *testing.T) {
+	if ops := operations("PROPFIND"); ops != nil {
+		t.Errorf("PROPFIND = %v", ops)
+	}
+	if ops := operations("DELETE"); !slices.Contains(ops, "file_delete") {
+		t.Errorf("DELETE = %v", ops)
+	}
+	for _, m := range []string{"PUT", "MKCOL", "MOVE", "COPY"} {
+		if ops := operations(m); !slices.Contains(ops, "file_write") {
+			t.Errorf("%s = %v", m, ops)
+		}
+	}
+}
+
+func TestParseStatList(t *testing.T) {
+	out := "4096|1700000000|directory|/etc/./nginx\n" +
+		"12|1700000100|regular file|/etc/./my|file.conf\n" +
+		"0|1700000200|regular empty file|/etc/./empty\n" +
+		"9|1700000300|symbolic link|/etc/./localtime\n" +
+		"stat: cannot stat 'x'\n"
+	infos := parseStatList(out)
+	if len(infos) != 4 {
+		t.Fatalf("infos = %d", len(infos))
+	}
+	if !infos[0].IsDir() || infos[0].Name() != "nginx" {
+		t.Errorf("dir = %+v", infos[0])
+	}
+	if infos[1].Name() != "my|file.conf" || infos[1].Size() != 12 || !infos[1].ModTime().Equal(time.Unix(1700000100, 0)) {
+		t.Errorf("file = %+v", infos[1])
+	}
+	if infos[2].IsDir() || infos[3].Mode()&os.ModeSymlink == 0 {
+		t.Errorf("types = %v %v", infos[2].Mode(), infos[3].Mode())
+	}
+}
+
+func TestContentType(t *testing.T) {
+	for name, want := range map[string]string{"a.json": "application/json", "data.bin": "application/octet-stream", "Makefile": "application/octet-stream"} {
+		if got, _ := (&fileInfo{name: name}).ContentType(context.Background()); got != want {
+			t.Errorf("%s = %s", name, got)
+		}
+	}
+}
+
+func TestToFSError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+	cases := []struct {
+		err  error
+		want error
+	}{
+		{apierrors.NewNotFound(gr, "x"), os.ErrNotExist},
+		{fmt.Errorf("/x: %w", os.ErrNotExist), os.ErrNotExist},
+		{errors.New("error executing command: rm: can't remove '/x': No such file or directory"), os.ErrNotExist},
+		{apierrors.NewForbidden(gr, "x", errors.New("no")), os.ErrPermission},
+		{errors.New("mkdir: can't create directory '/proc/x': Permission denied"), os.ErrPermission},
+	}
+	for _, tc := range cases {
+		if got := toFSError(tc.err); got != tc.want {
+			t.Errorf("toFSError(%v) = %v", tc.err, got)
+		}
+	}
+	if err := errors.New("other"); toFSError(err) != err || toFSError(nil) != nil {
+		t.Error("other errors should be unchanged")
+	}
+}
@@ -0,0 +1,258 @@
+package davfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/kom/kom"
+	"golang.org/x/net/webdav"
+	v1 "k8s.io/api/core/v1"
+)
+
+// fileInfo 实现 os.FileInfo。同时实现 webdav.ContentTyper，
+// 否则 PROPFIND 会为每个文件读取前 512 字节判断类型，在容器中逐个执行读取命令
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+var _ webdav.ContentTyper = &fileInfo{}
+
+func dirInfo(name string, modTime time.Time) *fileInfo {
+	return &fileInfo{name: name, mode: os.ModeDir | 0755, modTime: modTime}
+}
+
+func statInfo(name string, st *service.PodFileStat) *fileInfo {
+	if st.IsDir {
+		return dirInfo(name, st.ModTime)
+	}
+	return &fileInfo{name: name, size: st.Size, mode: 0644, modTime: st.ModTime}
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *fileInfo) Sys() any           { return nil }
+
+// ContentType 按扩展名判断，无法判断时返回 application/octet-stream
+func (fi *fileInfo) ContentType(ctx context.Context) (string, error) {
+	if t := mime.TypeByExtension(path.Ext(fi.name)); t != "" {
+		return t, nil
+	}
+	return "application/octet-stream", nil
+}
+
+// parseStatList 解析 stat -c '%s|%Y|%F|%n' 的输出。前三项不含 |，文件名取剩余部分的最后一段。
+// 符号链接不跟随，交由之后的 Stat 单独解析，失效的链接只影响其自身
+func parseStatList(out string) []os.FileInfo {
+	var infos []os.FileInfo
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		size, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		mtime, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		fi := &fileInfo{name: path.Base(parts[3]), size: size, mode: 0644, modTime: time.Unix(mtime, 0)}
+		switch parts[2] {
+		case "directory":
+			fi.mode = os.ModeDir | 0755
+		case "symbolic link":
+			fi.mode = os.ModeSymlink | 0777
+		}
+		infos = append(infos, fi)
+	}
+	return infos
+}
+
+// readDir 列出容器中的目录。不使用 ls -l，其输出中含空格的文件名、修改时间都无法可靠解析。
+// 路径后加 /. 使指向目录的符号链接也能列出内容
+func readDir(ctx context.Context, loc *location) ([]os.FileInfo, error) {
+	var out []byte
+	err := kom.Cluster(loc.Cluster).WithContext(readContext(ctx)).Resource(&v1.Pod{}).Namespace(loc.Namespace).Name(loc.Pod).
+		Ctl().Pod().ContainerName(loc.Container).
+		Command("find", strings.TrimSuffix(loc.Path, "/")+"/.", "-mindepth", "1", "-maxdepth", "1",
+			"-exec", "stat", "-c", "%s|%Y|%F|%n", "{}", "+").Execute(&out).Error
+	if err != nil {
+		return nil, toFSError(err)
+	}
+	return parseStatList(string(out)), nil
+}
+
+// list 按路径层级列出集群、命名空间、Pod、容器或容器中的文件
+func list(ctx context.Context, loc *location) ([]os.FileInfo, error) {
+	var infos []os.FileInfo
+	switch loc.depth {
+	case depthRoot:
+		clusters, err := allowedClusters(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range clusters {
+			infos = append(infos, dirInfo(utils.UrlSafeBase64Encode(c), time.Time{}))
+		}
+	case depthCluster:
+		var items []*v1.Namespace
+		if err := kom.Cluster(loc.Cluster).WithContext(ctx).Resource(&v1.Namespace{}).List(&items).Error; err != nil {
+			return nil, toFSError(err)
+		}
+		for _, item := range items {
+			infos = append(infos, dirInfo(item.Name, item.CreationTimestamp.Time))
+		}
+	case depthNamespace:
+		var items []*v1.Pod
+		if err := kom.Cluster(loc.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(loc.Namespace).List(&items).Error; err != nil {
+			return nil, toFSError(err)
+		}
+		for _, item := range items {
+			infos = append(infos, dirInfo(item.Name, item.CreationTimestamp.Time))
+		}
+	case depthPod:
+		pod, err := getPod(ctx, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range containerNames(pod) {
+			infos = append(infos, dirInfo(name, pod.CreationTimestamp.Time))
+		}
+	default:
+		return readDir(ctx, loc)
+	}
+	return infos, nil
+}
+
+// dirFile 目录，Readdir 首次调用时才列出内容
+type dirFile struct {
+	ctx     context.Context
+	name    string
+	loc     *location
+	info    os.FileInfo
+	entries []os.FileInfo
+	loaded  bool
+	pos     int
+}
+
+func (d *dirFile) Close() error                                 { return nil }
+func (d *dirFile) Read(p []byte) (int, error)                   { return 0, os.ErrInvalid }
+func (d *dirFile) Seek(offset int64, whence int) (int64, error) { return 0, os.ErrInvalid }
+func (d *dirFile) Write(p []byte) (int, error)                  { return 0, os.ErrPermission }
+func (d *dirFile) Stat() (os.FileInfo, error)                   { return d.info, nil }
+
+func (d *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !d.loaded {
+		entries, err := list(d.ctx, d.loc)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.loaded = entries, true
+		var cacheable []os.FileInfo
+		for _, fi := range entries {
+			if fi.Mode()&os.ModeSymlink == 0 {
+				cacheable = append(cacheable, fi)
+			}
+		}
+		cacheStat(d.ctx, d.name, cacheable)
+	}
+	rest := d.entries[d.pos:]
+	if count <= 0 {
+		d.pos = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n := min(count, len(rest))
+	d.pos += n
+	return rest[:n], nil
+}
+
+// readFile 容器中的普通文件，按需从读取位置开始传输
+type readFile struct {
+	*service.PodFileReader
+	info os.FileInfo
+}
+
+func (f *readFile) Readdir(count int) ([]os.FileInfo, error) { return nil, os.ErrInvalid }
+func (f *readFile) Write(p []byte) (int, error)              { return 0, os.ErrPermission }
+func (f *readFile) Stat() (os.FileInfo, error)               { return f.info, nil }
+
+// writeFile 写入的内容先保存在本地暂存目录，Close 时计入用户的上传暂存配额，再上传到容器
+type writeFile struct {
+	ctx     context.Context
+	loc     *location
+	tmp     *os.File
+	written int64
+}
+
+func newWriteFile(ctx context.Context, loc *location) (*writeFile, error) {
+	root := service.UploadStagingService().Root()
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(root, "dav-*")
+	if err != nil {
+		return nil, err
+	}
+	return &writeFile{ctx: ctx, loc: loc, tmp: tmp}, nil
+}
+
+func (f *writeFile) Write(p []byte) (int, error) {
+	if quota := int64(flag.Init().UploadStagingQuotaMB) << 20; quota > 0 && f.written+int64(len(p)) > quota {
+		return 0, errors.New("写入内容超过上传暂存配额")
+	}
+	n, err := f.tmp.Write(p)
+	f.written += int64(n)
+	return n, err
+}
+
+func (f *writeFile) Read(p []byte) (int, error)                   { return 0, os.ErrInvalid }
+func (f *writeFile) Seek(offset int64, whence int) (int64, error) { return 0, os.ErrInvalid }
+func (f *writeFile) Readdir(count int) ([]os.FileInfo, error)     { return nil, os.ErrInvalid }
+
+func (f *writeFile) Stat() (os.FileInfo, error) {
+	return &fileInfo{name: path.Base(f.loc.Path), size: f.written, mode: 0644, modTime: time.Now()}, nil
+}
+
+func (f *writeFile) Close() error {
+	defer os.Remove(f.tmp.Name())
+	defer f.tmp.Close()
+	if _, err := f.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	username, _ := f.ctx.Value(constants.JwtUserName).(string)
+	staged, release, err := service.UploadStagingService().StageReader(username, path.Base(f.loc.Path), f.written, f.tmp)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	src, err := os.Open(staged)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	err = kom.Cluster(f.loc.Cluster).WithContext(fileContext(f.ctx)).Resource(&v1.Pod{}).Namespace(f.loc.Namespace).Name(f.loc.Pod).
+		Ctl().Pod().ContainerName(f.loc.Container).UploadFile(path.Dir(f.loc.Path), src)
+	return toFSError(err)
+}
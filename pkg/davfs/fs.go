@@ -0,0 +1,329 @@
+package davfs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/podinit"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/kom/kom"
+	"golang.org/x/net/webdav"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// 路径层级：/{集群}/{命名空间}/{Pod}/{容器}/{容器内路径}
+const (
+	depthRoot = iota
+	depthCluster
+	depthNamespace
+	depthPod
+	depthContainer // 容器根目录，更深的层级为容器内的文件
+)
+
+// location 解析后的 WebDAV 路径
+type location struct {
+	Cluster   string
+	Namespace string
+	Pod       string
+	Container string
+	Path      string // 容器内的绝对路径，depth 不小于 depthContainer 时有效
+	depth     int
+}
+
+// parse 解析 WebDAV 路径，集群名称为 URL 安全的 Base64 编码
+func parse(name string) (*location, error) {
+	parts := strings.SplitN(strings.Trim(path.Clean("/"+name), "/"), "/", 5)
+	if parts[0] == "" {
+		return &location{}, nil
+	}
+	loc := &location{depth: min(len(parts), depthContainer), Path: "/"}
+	cluster, err := utils.UrlSafeBase64Decode(parts[0])
+	if err != nil || cluster == "" {
+		return nil, os.ErrNotExist
+	}
+	loc.Cluster = cluster
+	if len(parts) > 1 {
+		loc.Namespace = parts[1]
+	}
+	if len(parts) > 2 {
+		loc.Pod = parts[2]
+	}
+	if len(parts) > 3 {
+		loc.Container = parts[3]
+	}
+	if len(parts) > 4 {
+		loc.Path = "/" + parts[4]
+	}
+	return loc, nil
+}
+
+// inContainer 是否为容器内的文件，容器根目录本身不算
+func (l *location) inContainer() bool {
+	return l.depth == depthContainer && l.Path != "/"
+}
+
+func (l *location) ref() *service.PodFileRef {
+	return &service.PodFileRef{Cluster: l.Cluster, Namespace: l.Namespace, PodName: l.Pod, ContainerName: l.Container, Path: l.Path}
+}
+
+// FileSystem 以容器命令实现 webdav.FileSystem，ctx 中需携带登录用户
+type FileSystem struct{}
+
+var _ webdav.FileSystem = &FileSystem{}
+
+// statCacheKey 单个请求内的文件信息缓存。PROPFIND 列目录后会逐个 Stat 子项，
+// 缓存列目录时得到的信息，避免每个子项再在容器中执行一次命令
+type statCacheKey struct{}
+
+func cachedStat(ctx context.Context, name string) (os.FileInfo, bool) {
+	cache, ok := ctx.Value(statCacheKey{}).(*sync.Map)
+	if !ok {
+		return nil, false
+	}
+	v, ok := cache.Load(path.Clean("/" + name))
+	if !ok {
+		return nil, false
+	}
+	return v.(os.FileInfo), true
+}
+
+func cacheStat(ctx context.Context, dir string, infos []os.FileInfo) {
+	cache, ok := ctx.Value(statCacheKey{}).(*sync.Map)
+	if !ok {
+		return
+	}
+	for _, fi := range infos {
+		cache.Store(path.Join("/", dir, fi.Name()), fi)
+	}
+}
+
+// forget 写操作后清除请求内缓存
+func forget(ctx context.Context) {
+	if cache, ok := ctx.Value(statCacheKey{}).(*sync.Map); ok {
+		cache.Clear()
+	}
+}
+
+// fileContext 标记执行的命令属于文件管理，适用 file 范围的命令策略
+func fileContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constants.CommandScope, constants.CommandScopeFile)
+}
+
+func readContext(ctx context.Context) context.Context {
+	return service.WithReadOnlyExec(fileContext(ctx))
+}
+
+// allowedClusters 返回用户有权访问且已连接的集群
+func allowedClusters(ctx context.Context) ([]string, error) {
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	var names []string
+	for _, c := range service.ClusterService().ConnectedClusters() {
+		names = append(names, service.ClusterService().ClusterID(c))
+	}
+	if service.UserService().IsUserPlatformAdmin(username) {
+		return names, nil
+	}
+	granted, err := service.UserService().GetClusterNames(username)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(names, func(n string) bool { return !slices.Contains(granted, n) }), nil
+}
+
+func checkCluster(ctx context.Context, cluster string) error {
+	clusters, err := allowedClusters(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(clusters, cluster) {
+		return os.ErrNotExist
+	}
+	return nil
+}
+
+// toFSError 将集群返回的错误转换为 WebDAV 能识别的文件系统错误。
+// webdav 使用 os.IsNotExist 等判断状态码，它们不展开 %w，因此返回哨兵错误本身，原始错误记录到日志
+func toFSError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case apierrors.IsNotFound(err), errors.Is(err, os.ErrNotExist), strings.Contains(err.Error(), "No such file"):
+		klog.V(6).Infof("WebDAV: %v", err)
+		return os.ErrNotExist
+	case apierrors.IsForbidden(err), errors.Is(err, os.ErrPermission), strings.Contains(err.Error(), "Permission denied"):
+		klog.V(6).Infof("WebDAV: %v", err)
+		return os.ErrPermission
+	}
+	return err
+}
+
+func (fs *FileSystem) locate(ctx context.Context, name string) (*location, error) {
+	loc, err := parse(name)
+	if err != nil {
+		return nil, err
+	}
+	if loc.depth >= depthCluster {
+		if err := checkCluster(ctx, loc.Cluster); err != nil {
+			return nil, err
+		}
+	}
+	return loc, nil
+}
+
+// Stat 的错误包装为 *os.PathError，PROPFIND 遍历目录时会跳过这类子项（如失效的符号链接），而不是中断整个响应
+func (fs *FileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	fi, err := fs.stat(ctx, name)
+	if err != nil {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return fi, nil
+}
+
+func (fs *FileSystem) stat(ctx context.Context, name string) (os.FileInfo, error) {
+	if fi, ok := cachedStat(ctx, name); ok {
+		return fi, nil
+	}
+	loc, err := fs.locate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	base := path.Base(path.Clean("/" + name))
+	switch {
+	case loc.depth < depthPod:
+		return dirInfo(base, time.Time{}), nil
+	case loc.depth == depthPod:
+		pod, err := getPod(ctx, loc)
+		if err != nil {
+			return nil, err
+		}
+		return dirInfo(base, pod.CreationTimestamp.Time), nil
+	case !loc.inContainer():
+		pod, err := getPod(ctx, loc)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(containerNames(pod), loc.Container) {
+			return nil, os.ErrNotExist
+		}
+		return dirInfo(base, pod.CreationTimestamp.Time), nil
+	}
+	st, err := service.PodService().StatFile(readContext(ctx), loc.ref())
+	if err != nil {
+		return nil, toFSError(err)
+	}
+	return statInfo(base, st), nil
+}
+
+func (fs *FileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	loc, err := fs.locate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		if !loc.inContainer() {
+			return nil, os.ErrPermission
+		}
+		if flag&os.O_APPEND != 0 {
+			return nil, os.ErrPermission
+		}
+		forget(ctx)
+		return newWriteFile(ctx, loc)
+	}
+
+	fi, err := fs.Stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return &dirFile{ctx: ctx, name: name, loc: loc, info: fi}, nil
+	}
+	reader, err := service.PodService().OpenFile(readContext(ctx), loc.ref())
+	if err != nil {
+		return nil, toFSError(err)
+	}
+	return &readFile{PodFileReader: reader, info: fi}, nil
+}
+
+func (fs *FileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	loc, err := fs.locate(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !loc.inContainer() {
+		return os.ErrPermission
+	}
+	forget(ctx)
+	return toFSError(execute(fileContext(ctx), loc, "mkdir", loc.Path))
+}
+
+func (fs *FileSystem) RemoveAll(ctx context.Context, name string) error {
+	loc, err := fs.locate(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !loc.inContainer() {
+		return os.ErrPermission
+	}
+	forget(ctx)
+	return toFSError(execute(fileContext(ctx), loc, "rm", "-rf", loc.Path))
+}
+
+func (fs *FileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	src, err := fs.locate(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	dst, err := fs.locate(ctx, newName)
+	if err != nil {
+		return err
+	}
+	if !src.inContainer() || !dst.inContainer() {
+		return os.ErrPermission
+	}
+	if src.Cluster != dst.Cluster || src.Namespace != dst.Namespace || src.Pod != dst.Pod || src.Container != dst.Container {
+		return errors.New("不支持跨容器移动，请使用复制")
+	}
+	forget(ctx)
+	return toFSError(execute(fileContext(ctx), src, "mv", "-f", src.Path, dst.Path))
+}
+
+func execute(ctx context.Context, loc *location, command string, args ...string) error {
+	var out []byte
+	return kom.Cluster(loc.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(loc.Namespace).Name(loc.Pod).
+		Ctl().Pod().ContainerName(loc.Container).
+		Command(command, args...).Execute(&out).Error
+}
+
+func getPod(ctx context.Context, loc *location) (*v1.Pod, error) {
+	var pod v1.Pod
+	err := kom.Cluster(loc.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(loc.Namespace).Name(loc.Pod).Get(&pod).Error
+	if err != nil {
+		return nil, toFSError(err)
+	}
+	return &pod, nil
+}
+
+// containerNames 可进入的容器：业务容器与原生 sidecar
+func containerNames(pod *v1.Pod) []string {
+	var names []string
+	for i := range pod.Spec.InitContainers {
+		if podinit.IsSidecar(&pod.Spec.InitContainers[i]) {
+			names = append(names, pod.Spec.InitContainers[i].Name)
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
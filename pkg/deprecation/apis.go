@@ -0,0 +1,187 @@
+// Package deprecation 记录 Kubernetes 内置资源已弃用、已移除的 API 版本，
+// 用于升级前检查集群中仍在使用的旧版本 API，以及在应用清单时提示将被移除的 apiVersion。
+package deprecation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// API 一个已弃用的 API 版本
+type API struct {
+	Group        string `json:"group"`
+	Version      string `json:"version"`
+	Kind         string `json:"kind"`
+	Resource     string `json:"resource"`      // 小写复数形式，与 apiserver_requested_deprecated_apis 指标中的 resource 对应
+	DeprecatedIn string `json:"deprecated_in"` // 开始弃用的版本，如 1.19
+	RemovedIn    string `json:"removed_in"`    // 停止提供的版本，如 1.22
+	Replacement  string `json:"replacement"`   // 替代的 apiVersion，为空表示没有直接替代
+}
+
+// APIVersion 返回 group/version 形式的 apiVersion
+func (a *API) APIVersion() string {
+	if a.Group == "" {
+		return a.Version
+	}
+	return a.Group + "/" + a.Version
+}
+
+// Message 说明该 API 的弃用情况与迁移方式
+func (a *API) Message() string {
+	msg := fmt.Sprintf("%s %s 自 %s 起弃用，%s 起移除", a.APIVersion(), a.Kind, a.DeprecatedIn, a.RemovedIn)
+	if a.Replacement != "" {
+		msg += "，请改用 " + a.Replacement
+	}
+	return msg
+}
+
+// RemovedBy 判断该 API 在目标版本中是否已被移除
+func (a *API) RemovedBy(target string) bool {
+	return !MinorLess(target, a.RemovedIn)
+}
+
+// DeprecatedBy 判断该 API 在目标版本中是否已弃用
+func (a *API) DeprecatedBy(target string) bool {
+	return !MinorLess(target, a.DeprecatedIn)
+}
+
+// APIs 内置资源已弃用的 API 版本，来自 Kubernetes 官方的弃用 API 迁移指南
+var APIs = []*API{
+	{"extensions", "v1beta1", "Deployment", "deployments", "1.9", "1.16", "apps/v1"},
+	{"extensions", "v1beta1", "DaemonSet", "daemonsets", "1.9", "1.16", "apps/v1"},
+	{"extensions", "v1beta1", "ReplicaSet", "replicasets", "1.9", "1.16", "apps/v1"},
+	{"extensions", "v1beta1", "NetworkPolicy", "networkpolicies", "1.9", "1.16", "networking.k8s.io/v1"},
+	{"extensions", "v1beta1", "PodSecurityPolicy", "podsecuritypolicies", "1.10", "1.16", "policy/v1beta1"},
+	{"extensions", "v1beta1", "Ingress", "ingresses", "1.14", "1.22", "networking.k8s.io/v1"},
+	{"apps", "v1beta1", "Deployment", "deployments", "1.9", "1.16", "apps/v1"},
+	{"apps", "v1beta1", "StatefulSet", "statefulsets", "1.9", "1.16", "apps/v1"},
+	{"apps", "v1beta2", "Deployment", "deployments", "1.9", "1.16", "apps/v1"},
+	{"apps", "v1beta2", "StatefulSet", "statefulsets", "1.9", "1.16", "apps/v1"},
+	{"apps", "v1beta2", "DaemonSet", "daemonsets", "1.9", "1.16", "apps/v1"},
+	{"apps", "v1beta2", "ReplicaSet", "replicasets", "1.9", "1.16", "apps/v1"},
+	{"networking.k8s.io", "v1beta1", "Ingress", "ingresses", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"networking.k8s.io", "v1beta1", "IngressClass", "ingressclasses", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"admissionregistration.k8s.io", "v1beta1", "MutatingWebhookConfiguration", "mutatingwebhookconfigurations", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"admissionregistration.k8s.io", "v1beta1", "ValidatingWebhookConfiguration", "validatingwebhookconfigurations", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"apiextensions.k8s.io", "v1beta1", "CustomResourceDefinition", "customresourcedefinitions", "1.16", "1.22", "apiextensions.k8s.io/v1"},
+	{"apiregistration.k8s.io", "v1beta1", "APIService", "apiservices", "1.19", "1.22", "apiregistration.k8s.io/v1"},
+	{"authentication.k8s.io", "v1beta1", "TokenReview", "tokenreviews", "1.19", "1.22", "authentication.k8s.io/v1"},
+	{"authorization.k8s.io", "v1beta1", "SubjectAccessReview", "subjectaccessreviews", "1.19", "1.22", "authorization.k8s.io/v1"},
+	{"authorization.k8s.io", "v1beta1", "LocalSubjectAccessReview", "localsubjectaccessreviews", "1.19", "1.22", "authorization.k8s.io/v1"},
+	{"authorization.k8s.io", "v1beta1", "SelfSubjectAccessReview", "selfsubjectaccessreviews", "1.19", "1.22", "authorization.k8s.io/v1"},
+	{"certificates.k8s.io", "v1beta1", "CertificateSigningRequest", "certificatesigningrequests", "1.19", "1.22", "certificates.k8s.io/v1"},
+	{"coordination.k8s.io", "v1beta1", "Lease", "leases", "1.19", "1.22", "coordination.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "ClusterRole", "clusterroles", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "ClusterRoleBinding", "clusterrolebindings", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "Role", "roles", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "RoleBinding", "rolebindings", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io", "v1beta1", "PriorityClass", "priorityclasses", "1.14", "1.22", "scheduling.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "CSIDriver", "csidrivers", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "CSINode", "csinodes", "1.17", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "StorageClass", "storageclasses", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "VolumeAttachment", "volumeattachments", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"batch", "v1beta1", "CronJob", "cronjobs", "1.21", "1.25", "batch/v1"},
+	{"discovery.k8s.io", "v1beta1", "EndpointSlice", "endpointslices", "1.21", "1.25", "discovery.k8s.io/v1"},
+	{"events.k8s.io", "v1beta1", "Event", "events", "1.19", "1.25", "events.k8s.io/v1"},
+	{"autoscaling", "v2beta1", "HorizontalPodAutoscaler", "horizontalpodautoscalers", "1.22", "1.25", "autoscaling/v2"},
+	{"policy", "v1beta1", "PodDisruptionBudget", "poddisruptionbudgets", "1.21", "1.25", "policy/v1"},
+	{"policy", "v1beta1", "PodSecurityPolicy", "podsecuritypolicies", "1.21", "1.25", ""},
+	{"node.k8s.io", "v1beta1", "RuntimeClass", "runtimeclasses", "1.20", "1.25", "node.k8s.io/v1"},
+	{"autoscaling", "v2beta2", "HorizontalPodAutoscaler", "horizontalpodautoscalers", "1.23", "1.26", "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta1", "FlowSchema", "flowschemas", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta1", "PriorityLevelConfiguration", "prioritylevelconfigurations", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "CSIStorageCapacity", "csistoragecapacities", "1.24", "1.27", "storage.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta2", "FlowSchema", "flowschemas", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta2", "PriorityLevelConfiguration", "prioritylevelconfigurations", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta3", "FlowSchema", "flowschemas", "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta3", "PriorityLevelConfiguration", "prioritylevelconfigurations", "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+// lossless 新旧版本字段与默认值完全一致、只需修改 apiVersion 即可转换的 API
+var lossless = map[string]bool{
+	"batch/v1beta1/CronJob":                                true,
+	"autoscaling/v2beta2/HorizontalPodAutoscaler":          true,
+	"rbac.authorization.k8s.io/v1beta1/ClusterRole":        true,
+	"rbac.authorization.k8s.io/v1beta1/ClusterRoleBinding": true,
+	"rbac.authorization.k8s.io/v1beta1/Role":               true,
+	"rbac.authorization.k8s.io/v1beta1/RoleBinding":        true,
+	"scheduling.k8s.io/v1beta1/PriorityClass":              true,
+	"storage.k8s.io/v1beta1/StorageClass":                  true,
+	"storage.k8s.io/v1beta1/CSIStorageCapacity":            true,
+	"coordination.k8s.io/v1beta1/Lease":                    true,
+	"networking.k8s.io/v1beta1/IngressClass":               true,
+	"node.k8s.io/v1beta1/RuntimeClass":                     true,
+	"flowcontrol.apiserver.k8s.io/v1beta3/FlowSchema":      true,
+	"events.k8s.io/v1beta1/Event":                          true,
+}
+
+// Lossless 判断能否只修改 apiVersion 就无损地转换为替代版本。
+// Ingress、PodDisruptionBudget、工作负载等在新版本中字段结构或默认行为有变化，需人工迁移
+func (a *API) Lossless() bool {
+	return a.Replacement != "" && lossless[a.APIVersion()+"/"+a.Kind]
+}
+
+// Convert 将使用可无损转换的弃用 API 的对象改为替代版本，返回对象原本使用的弃用 API 及是否已转换
+func Convert(obj *unstructured.Unstructured) (*API, bool) {
+	a := Lookup(obj.GetAPIVersion(), obj.GetKind())
+	if a == nil || !a.Lossless() {
+		return a, false
+	}
+	obj.SetAPIVersion(a.Replacement)
+	return a, true
+}
+
+// Lookup 按 apiVersion 与 kind 查找已弃用的 API，未弃用时返回 nil
+func Lookup(apiVersion, kind string) *API {
+	group, version := "", apiVersion
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		group, version = apiVersion[:i], apiVersion[i+1:]
+	}
+	for _, a := range APIs {
+		if a.Group == group && a.Version == version && a.Kind == kind {
+			return a
+		}
+	}
+	return nil
+}
+
+// LookupResource 按 group、version 与资源名查找已弃用的 API，未弃用时返回 nil
+func LookupResource(group, version, resource string) *API {
+	for _, a := range APIs {
+		if a.Group == group && a.Version == version && a.Resource == resource {
+			return a
+		}
+	}
+	return nil
+}
+
+// Minor 将 v1.28.3、1.28、v1.28.3-eks-1234 等版本号规范为 1.28 形式
+func Minor(version string) string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// MinorLess 判断 a 的次版本号是否小于 b
+func MinorLess(a, b string) bool {
+	a, b = Minor(a), Minor(b)
+	return a != b && utils.CompareVersions(b, a)
+}
+
+// MinorDiff 返回 b 与 a 相差的次版本数，主版本号不同时按次版本号直接相减
+func MinorDiff(a, b string) int {
+	pa, pb := utils.ParseVersion(Minor(a)), utils.ParseVersion(Minor(b))
+	if len(pa) < 2 || len(pb) < 2 {
+		return 0
+	}
+	return pb[1] - pa[1]
+}
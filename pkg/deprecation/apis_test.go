@@ -0,0 +1,98 @@
+package deprecation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMinor(t *testing.T) {
+	cases := map[string]string{
+		"v1.28.3":             "1.28",
+		"1.28":                "1.28",
+		"v1.27.8-eks-8cb36c9": "1.27",
+		"v1.30.1+k3s1":        "1.30",
+		" v1.9.0 ":            "1.9",
+		"1":                   "1",
+	}
+	for in, want := range cases {
+		if got := Minor(in); got != want {
+			t.Errorf("Minor(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMinorCompare(t *testing.T) {
+	if !MinorLess("v1.9.3", "1.16") {
+		t.Error("1.9 should be less than 1.16")
+	}
+	if MinorLess("1.25", "v1.25.4") {
+		t.Error("equal minors should not be less")
+	}
+	if MinorLess("1.30", "1.29") {
+		t.Error("1.30 should not be less than 1.29")
+	}
+	if d := MinorDiff("v1.26.1", "1.29"); d != 3 {
+		t.Errorf("MinorDiff = %d, want 3", d)
+	}
+	if d := MinorDiff("1.29", "1.27"); d != -2 {
+		t.Errorf("MinorDiff = %d, want -2", d)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	a := Lookup("policy/v1beta1", "PodDisruptionBudget")
+	if a == nil {
+		t.Fatal("policy/v1beta1 PodDisruptionBudget should be deprecated")
+	}
+	if a.RemovedBy("1.24") {
+		t.Error("should not be removed in 1.24")
+	}
+	if !a.RemovedBy("v1.25.0") || !a.RemovedBy("1.30") {
+		t.Error("should be removed in 1.25 and later")
+	}
+	if !a.DeprecatedBy("1.21") || a.DeprecatedBy("1.20") {
+		t.Error("should be deprecated since 1.21")
+	}
+	if Lookup("apps/v1", "Deployment") != nil {
+		t.Error("apps/v1 Deployment is not deprecated")
+	}
+	if Lookup("v1", "Pod") != nil {
+		t.Error("v1 Pod is not deprecated")
+	}
+	if LookupResource("batch", "v1beta1", "cronjobs") == nil {
+		t.Error("batch/v1beta1 cronjobs should be deprecated")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	cron := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "batch/v1beta1",
+		"kind":       "CronJob",
+		"metadata":   map[string]any{"name": "backup"},
+	}}
+	a, converted := Convert(cron)
+	if a == nil || !converted {
+		t.Fatal("batch/v1beta1 CronJob should be converted")
+	}
+	if cron.GetAPIVersion() != "batch/v1" {
+		t.Errorf("apiVersion = %s, want batch/v1", cron.GetAPIVersion())
+	}
+
+	ing := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "extensions/v1beta1",
+		"kind":       "Ingress",
+	}}
+	a, converted = Convert(ing)
+	if a == nil || converted {
+		t.Fatal("extensions/v1beta1 Ingress is deprecated but not losslessly convertible")
+	}
+	if ing.GetAPIVersion() != "extensions/v1beta1" {
+		t.Errorf("apiVersion should be unchanged, got %s", ing.GetAPIVersion())
+	}
+
+	dep := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "apps/v1", "kind": "Deployment"}}
+	if a, converted = Convert(dep); a != nil || converted {
+		t.Error("apps/v1 Deployment should be left untouched")
+	}
+}
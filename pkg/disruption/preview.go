@@ -0,0 +1,239 @@
+// Package disruption 评估驱逐一组 Pod（如排空节点）时 PodDisruptionBudget 的影响：
+// 哪些 PDB 适用、剩余可中断数是否足够、哪些 Pod 的驱逐会被拒绝。
+// 规则与 apiserver 的 Eviction 处理及 kom 的节点驱逐一致，只做计算，不访问集群。
+package disruption
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Pod 的处理方式
+const (
+	ActionEvict = "evict" // 将被驱逐
+	ActionSkip  = "skip"  // 驱逐时忽略，如 DaemonSet、静态 Pod
+)
+
+// mirrorPodAnnotation 静态 Pod 在 apiserver 中的镜像 Pod 带有该注解
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// PodImpact 单个 Pod 的驱逐影响
+type PodImpact struct {
+	Namespace    string   `json:"namespace"`
+	Name         string   `json:"name"`
+	Node         string   `json:"node,omitempty"`
+	Owner        string   `json:"owner,omitempty"` // 控制者，如 ReplicaSet/nginx-7d9f
+	Phase        string   `json:"phase"`
+	Ready        bool     `json:"ready"`
+	Action       string   `json:"action"`
+	PDBs         []string `json:"pdbs,omitempty"`
+	Blocked      bool     `json:"blocked"` // 驱逐会被拒绝
+	Reason       string   `json:"reason,omitempty"`
+	Unmanaged    bool     `json:"unmanaged"`     // 无控制者，驱逐后不会重建
+	LocalStorage bool     `json:"local_storage"` // 使用 emptyDir，驱逐后数据丢失
+}
+
+// BudgetImpact 单个 PDB 的当前状态及本次驱逐的消耗
+type BudgetImpact struct {
+	Namespace                  string `json:"namespace"`
+	Name                       string `json:"name"`
+	MinAvailable               string `json:"min_available,omitempty"`
+	MaxUnavailable             string `json:"max_unavailable,omitempty"`
+	UnhealthyPodEvictionPolicy string `json:"unhealthy_pod_eviction_policy"`
+	ExpectedPods               int32  `json:"expected_pods"`
+	CurrentHealthy             int32  `json:"current_healthy"`
+	DesiredHealthy             int32  `json:"desired_healthy"`
+	DisruptionsAllowed         int32  `json:"disruptions_allowed"`
+	Matched                    int    `json:"matched"`  // 本次涉及的 Pod 数
+	Consumed                   int    `json:"consumed"` // 本次驱逐需要消耗的可中断数，即其中健康的 Pod 数
+	Blocked                    bool   `json:"blocked"`
+	Reason                     string `json:"reason,omitempty"`
+}
+
+// Preview 驱逐预览结果
+type Preview struct {
+	Pods       []*PodImpact    `json:"pods"`
+	Budgets    []*BudgetImpact `json:"budgets"`
+	Evict      int             `json:"evict"`
+	Skipped    int             `json:"skipped"`
+	Blocked    int             `json:"blocked"`
+	WouldBlock bool            `json:"would_block"` // 驱逐会因 PDB 失败
+	Warnings   []string        `json:"warnings,omitempty"`
+}
+
+type budget struct {
+	pdb      *policyv1.PodDisruptionBudget
+	selector labels.Selector
+	impact   *BudgetImpact
+}
+
+// Evaluate 评估驱逐 pods 的影响，pdbs 为这些 Pod 所在命名空间中的全部 PDB。
+// 依次驱逐时每个健康 Pod 消耗一次 PDB 的可中断数，替代 Pod 就绪前不会恢复，因此消耗超过剩余可中断数的 Pod 会被拒绝；
+// 未运行或已在删除中的 Pod 不受 PDB 限制；运行但未就绪的 Pod 在 PDB 健康或策略为 AlwaysAllow 时允许驱逐；
+// 同时匹配多个 PDB 的 Pod 无法驱逐
+func Evaluate(pods []*v1.Pod, pdbs []*policyv1.PodDisruptionBudget) *Preview {
+	pods = append([]*v1.Pod{}, pods...)
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	var budgets []*budget
+	for _, pdb := range pdbs {
+		b := &budget{pdb: pdb, impact: newBudgetImpact(pdb)}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			b.impact.Reason = fmt.Sprintf("选择器无效: %v", err)
+			selector = labels.Nothing()
+		}
+		b.selector = selector
+		budgets = append(budgets, b)
+	}
+
+	preview := &Preview{Pods: []*PodImpact{}, Budgets: []*BudgetImpact{}}
+	used := map[*budget]bool{}
+	for _, pod := range pods {
+		p := newPodImpact(pod)
+		preview.Pods = append(preview.Pods, p)
+		if reason := skipReason(pod); reason != "" {
+			p.Action, p.Reason = ActionSkip, reason
+			preview.Skipped++
+			continue
+		}
+		p.Action = ActionEvict
+		preview.Evict++
+
+		var matched []*budget
+		for _, b := range budgets {
+			if b.pdb.Namespace == pod.Namespace && b.selector.Matches(labels.Set(pod.Labels)) {
+				matched = append(matched, b)
+				p.PDBs = append(p.PDBs, b.pdb.Name)
+				b.impact.Matched++
+				used[b] = true
+			}
+		}
+		switch {
+		case len(matched) == 0:
+		case len(matched) > 1:
+			p.Blocked, p.Reason = true, "同时匹配多个 PDB，apiserver 拒绝驱逐"
+		case ignoresBudget(pod):
+			p.Reason = "Pod 未运行或正在删除，不受 PDB 限制"
+		case !p.Ready:
+			b := matched[0].impact
+			if b.UnhealthyPodEvictionPolicy == string(policyv1.AlwaysAllow) || b.CurrentHealthy >= b.DesiredHealthy {
+				p.Reason = "Pod 未就绪，当前允许驱逐"
+			} else {
+				p.Blocked = true
+				p.Reason = fmt.Sprintf("Pod 未就绪且 PDB %s 健康数 %d 低于期望 %d", b.Name, b.CurrentHealthy, b.DesiredHealthy)
+			}
+		default:
+			b := matched[0].impact
+			b.Consumed++
+			if int32(b.Consumed) > b.DisruptionsAllowed {
+				p.Blocked = true
+				p.Reason = fmt.Sprintf("PDB %s 剩余可中断数 %d 已用完", b.Name, b.DisruptionsAllowed)
+			}
+		}
+		if p.Blocked {
+			preview.Blocked++
+			for _, b := range matched {
+				b.impact.Blocked = true
+			}
+		}
+		if p.Unmanaged {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("%s/%s 无控制者，驱逐后不会重建", p.Namespace, p.Name))
+		}
+		if p.LocalStorage {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("%s/%s 使用 emptyDir，驱逐后数据丢失", p.Namespace, p.Name))
+		}
+	}
+	for _, b := range budgets {
+		if !used[b] {
+			continue
+		}
+		if b.impact.Blocked && b.impact.Reason == "" {
+			b.impact.Reason = fmt.Sprintf("需要中断 %d 个健康 Pod，剩余可中断数 %d", b.impact.Consumed, b.impact.DisruptionsAllowed)
+		}
+		preview.Budgets = append(preview.Budgets, b.impact)
+	}
+	preview.WouldBlock = preview.Blocked > 0
+	return preview
+}
+
+func newBudgetImpact(pdb *policyv1.PodDisruptionBudget) *BudgetImpact {
+	b := &BudgetImpact{
+		Namespace:                  pdb.Namespace,
+		Name:                       pdb.Name,
+		UnhealthyPodEvictionPolicy: string(policyv1.IfHealthyBudget),
+		ExpectedPods:               pdb.Status.ExpectedPods,
+		CurrentHealthy:             pdb.Status.CurrentHealthy,
+		DesiredHealthy:             pdb.Status.DesiredHealthy,
+		DisruptionsAllowed:         pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		b.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		b.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	if pdb.Spec.UnhealthyPodEvictionPolicy != nil {
+		b.UnhealthyPodEvictionPolicy = string(*pdb.Spec.UnhealthyPodEvictionPolicy)
+	}
+	return b
+}
+
+func newPodImpact(pod *v1.Pod) *PodImpact {
+	p := &PodImpact{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Node:      pod.Spec.NodeName,
+		Phase:     string(pod.Status.Phase),
+		Ready:     podReady(pod),
+	}
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		p.Owner = ref.Kind + "/" + ref.Name
+	} else {
+		p.Unmanaged = true
+	}
+	for _, vol := range pod.Spec.Volumes {
+		if vol.EmptyDir != nil {
+			p.LocalStorage = true
+		}
+	}
+	return p
+}
+
+// skipReason 驱逐时忽略的 Pod 返回原因
+func skipReason(pod *v1.Pod) string {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "静态 Pod，驱逐时忽略"
+	}
+	if ref := metav1.GetControllerOf(pod); ref != nil && ref.Kind == "DaemonSet" {
+		return "DaemonSet 管理，驱逐时忽略"
+	}
+	return ""
+}
+
+// ignoresBudget apiserver 对已结束、等待中或正在删除的 Pod 不检查 PDB
+func ignoresBudget(pod *v1.Pod) bool {
+	switch pod.Status.Phase {
+	case v1.PodSucceeded, v1.PodFailed, v1.PodPending:
+		return true
+	}
+	return pod.DeletionTimestamp != nil
+}
+
+func podReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
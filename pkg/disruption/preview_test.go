@@ -0,0 +1,107 @@
+package disruption
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func testPod(name, app string, ready bool, ownerKind string) *v1.Pod {
+	yes := true
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"app": app}},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+		Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, Status: status},
+		}},
+	}
+	if ownerKind != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: app, Controller: &yes}}
+	}
+	return pod
+}
+
+func testPDB(name, app string, allowed, healthy, desired int32) *policyv1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt32(desired)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed, CurrentHealthy: healthy, DesiredHealthy: desired},
+	}
+}
+
+func impact(p *Preview, name string) *PodImpact {
+	for _, pod := range p.Pods {
+		if pod.Name == name {
+			return pod
+		}
+	}
+	return nil
+}
+
+func TestEvaluateBudgetConsumption(t *testing.T) {
+	pods := []*v1.Pod{
+		testPod("web-1", "web", true, "ReplicaSet"),
+		testPod("web-2", "web", true, "ReplicaSet"),
+		testPod("agent", "agent", true, "DaemonSet"),
+		testPod("debug", "debug", true, ""),
+	}
+	pods[3].Spec.Volumes = []v1.Volume{{Name: "tmp", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+	p := Evaluate(pods, []*policyv1.PodDisruptionBudget{testPDB("web", "web", 1, 3, 2), testPDB("unused", "none", 1, 1, 1)})
+
+	if p.Evict != 3 || p.Skipped != 1 || p.Blocked != 1 || !p.WouldBlock {
+		t.Fatalf("unexpected summary: evict=%d skipped=%d blocked=%d", p.Evict, p.Skipped, p.Blocked)
+	}
+	if impact(p, "web-1").Blocked || !impact(p, "web-2").Blocked {
+		t.Fatalf("expected only second web pod blocked: %+v %+v", impact(p, "web-1"), impact(p, "web-2"))
+	}
+	if a := impact(p, "agent"); a.Action != ActionSkip {
+		t.Fatalf("daemonset pod should be skipped: %+v", a)
+	}
+	if d := impact(p, "debug"); !d.Unmanaged || !d.LocalStorage || d.Blocked {
+		t.Fatalf("unexpected debug pod impact: %+v", d)
+	}
+	if len(p.Budgets) != 1 || p.Budgets[0].Name != "web" || p.Budgets[0].Consumed != 2 || !p.Budgets[0].Blocked {
+		t.Fatalf("unexpected budgets: %+v", p.Budgets)
+	}
+	if len(p.Warnings) != 2 {
+		t.Fatalf("unexpected warnings: %v", p.Warnings)
+	}
+}
+
+func TestEvaluateUnhealthyAndOverlapping(t *testing.T) {
+	unready := testPod("db-0", "db", false, "StatefulSet")
+	pending := testPod("db-1", "db", false, "StatefulSet")
+	pending.Status.Phase = v1.PodPending
+
+	// PDB 不健康时，未就绪 Pod 的驱逐被拒绝，Pending 的 Pod 不受限制
+	p := Evaluate([]*v1.Pod{unready, pending}, []*policyv1.PodDisruptionBudget{testPDB("db", "db", 0, 1, 2)})
+	if !impact(p, "db-0").Blocked || impact(p, "db-1").Blocked {
+		t.Fatalf("unexpected impacts: %+v %+v", impact(p, "db-0"), impact(p, "db-1"))
+	}
+
+	// AlwaysAllow 允许驱逐未就绪 Pod
+	pdb := testPDB("db", "db", 0, 1, 2)
+	always := policyv1.AlwaysAllow
+	pdb.Spec.UnhealthyPodEvictionPolicy = &always
+	if p := Evaluate([]*v1.Pod{unready}, []*policyv1.PodDisruptionBudget{pdb}); p.WouldBlock {
+		t.Fatalf("AlwaysAllow should not block: %+v", p.Pods[0])
+	}
+
+	// 匹配多个 PDB
+	p = Evaluate([]*v1.Pod{testPod("web-1", "web", true, "ReplicaSet")},
+		[]*policyv1.PodDisruptionBudget{testPDB("a", "web", 5, 3, 1), testPDB("b", "web", 5, 3, 1)})
+	if w := impact(p, "web-1"); !w.Blocked || len(w.PDBs) != 2 {
+		t.Fatalf("overlapping PDBs should block: %+v", w)
+	}
+}
@@ -0,0 +1,258 @@
+// Package drift 比较基线清单与集群中的实际对象，找出被修改的字段。
+// 只比较基线中声明的字段，由 API Server 填充的默认值、状态等不会被视为漂移。
+package drift
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// FieldDrift 单个字段的漂移
+type FieldDrift struct {
+	Path     string `json:"path"` // 形如 spec.template.spec.containers[name=app].image
+	Expected any    `json:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty"`
+}
+
+// maskedValue Secret 数据在结果中的占位
+const maskedValue = "******"
+
+// 比较时忽略的注解，由客户端工具或控制器维护
+var ignoredAnnotations = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+	"deployment.kubernetes.io/revision":                true,
+}
+
+// Desired 从基线对象中提取参与比较的部分：去掉 status，metadata 只保留 labels、annotations
+func Desired(obj map[string]any) map[string]any {
+	desired := map[string]any{}
+	for k, v := range obj {
+		switch k {
+		case "status", "apiVersion", "kind":
+			continue
+		case "metadata":
+			meta, _ := v.(map[string]any)
+			kept := map[string]any{}
+			for _, key := range []string{"labels", "annotations"} {
+				if m, ok := meta[key].(map[string]any); ok && len(m) > 0 {
+					kept[key] = m
+				}
+			}
+			if ann, ok := kept["annotations"].(map[string]any); ok {
+				filtered := map[string]any{}
+				for ak, av := range ann {
+					if !ignoredAnnotations[ak] {
+						filtered[ak] = av
+					}
+				}
+				kept["annotations"] = filtered
+			}
+			if len(kept) > 0 {
+				desired["metadata"] = kept
+			}
+		default:
+			desired[k] = v
+		}
+	}
+	// Secret 的 stringData 在集群中以 base64 存于 data
+	if obj["kind"] == "Secret" {
+		if sd, ok := desired["stringData"].(map[string]any); ok {
+			data, _ := desired["data"].(map[string]any)
+			merged := map[string]any{}
+			for k, v := range data {
+				merged[k] = v
+			}
+			for k, v := range sd {
+				merged[k] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(v)))
+			}
+			desired["data"] = merged
+			delete(desired, "stringData")
+		}
+	}
+	return desired
+}
+
+// Compare 比较基线对象与实际对象，返回漂移字段，Secret 的数据值会被遮盖
+func Compare(desiredObj, liveObj map[string]any) []FieldDrift {
+	var drifts []FieldDrift
+	walk("", Desired(desiredObj), liveObj, &drifts)
+	if desiredObj["kind"] == "Secret" {
+		for i := range drifts {
+			if strings.HasPrefix(drifts[i].Path, "data.") || strings.HasPrefix(drifts[i].Path, "stringData.") {
+				if drifts[i].Expected != nil {
+					drifts[i].Expected = maskedValue
+				}
+				if drifts[i].Actual != nil {
+					drifts[i].Actual = maskedValue
+				}
+			}
+		}
+	}
+	return drifts
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func walk(path string, desired, live any, drifts *[]FieldDrift) {
+	switch d := desired.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			if len(d) == 0 && isEmpty(live) {
+				return
+			}
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: d, Actual: live})
+			return
+		}
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			walk(join(path, k), d[k], l[k], drifts)
+		}
+	case []any:
+		l, ok := live.([]any)
+		if !ok {
+			if len(d) == 0 && isEmpty(live) {
+				return
+			}
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: d, Actual: live})
+			return
+		}
+		if names, ok := namesOf(d); ok {
+			walkNamedList(path, d, l, names, drifts)
+			return
+		}
+		if len(d) != len(l) {
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: d, Actual: l})
+			return
+		}
+		for i := range d {
+			walk(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], drifts)
+		}
+	default:
+		if !scalarEqual(path, desired, live) {
+			*drifts = append(*drifts, FieldDrift{Path: path, Expected: desired, Actual: live})
+		}
+	}
+}
+
+// namesOf 列表元素均为带 name 字段的对象时（容器、环境变量、端口、卷等），返回各元素的 name
+func namesOf(list []any) ([]string, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// walkNamedList 按 name 匹配列表元素，不受顺序影响；实际对象中多出的元素同样视为漂移
+func walkNamedList(path string, desired, live []any, names []string, drifts *[]FieldDrift) {
+	liveByName := map[string]any{}
+	for _, item := range live {
+		if m, ok := item.(map[string]any); ok {
+			if name, ok := m["name"].(string); ok {
+				liveByName[name] = m
+			}
+		}
+	}
+	wanted := map[string]bool{}
+	for i, name := range names {
+		wanted[name] = true
+		p := fmt.Sprintf("%s[name=%s]", path, name)
+		l, ok := liveByName[name]
+		if !ok {
+			*drifts = append(*drifts, FieldDrift{Path: p, Expected: desired[i]})
+			continue
+		}
+		walk(p, desired[i], l, drifts)
+	}
+	for _, item := range live {
+		m, _ := item.(map[string]any)
+		name, _ := m["name"].(string)
+		if !wanted[name] {
+			*drifts = append(*drifts, FieldDrift{Path: fmt.Sprintf("%s[name=%s]", path, name), Actual: item})
+		}
+	}
+}
+
+// isEmpty 空值与字段缺失等价，API Server 会省略空对象、空列表和零值
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	}
+	if f, ok := toFloat(v); ok {
+		return f == 0
+	}
+	return false
+}
+
+func scalarEqual(path string, desired, live any) bool {
+	if isEmpty(desired) && isEmpty(live) {
+		return true
+	}
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	// YAML 解析出的 int 与 JSON 解析出的 int64/float64 视为相同
+	df, dok := toFloat(desired)
+	lf, lok := toFloat(live)
+	if dok && lok {
+		return df == lf
+	}
+	// 资源量会被 API Server 规范化，如 1000m 变为 1，0.5Gi 变为 512Mi
+	if strings.Contains(path, "resources.") || strings.Contains(path, "hard.") {
+		dq, err1 := resource.ParseQuantity(fmt.Sprint(desired))
+		lq, err2 := resource.ParseQuantity(fmt.Sprint(live))
+		if err1 == nil && err2 == nil {
+			return dq.Cmp(lq) == 0
+		}
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,140 @@
+package drift
+
+import (
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func mustParse(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return m
+}
+
+func TestCompare(t *testing.T) {
+	baseline := mustParse(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        resources:
+          limits:
+            cpu: 1000m
+            memory: 0.5Gi
+      - name: sidecar
+        image: busybox
+`)
+	live := mustParse(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+spec:
+  replicas: 3
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: sidecar
+        image: busybox
+        imagePullPolicy: Always
+      - name: app
+        image: nginx:1.26
+        resources:
+          limits:
+            cpu: "1"
+            memory: 512Mi
+status:
+  replicas: 3
+`)
+	drifts := Compare(baseline, live)
+	want := map[string]bool{
+		"spec.replicas": true,
+		"spec.template.spec.containers[name=app].image": true,
+	}
+	if len(drifts) != len(want) {
+		t.Fatalf("got %d drifts, want %d: %+v", len(drifts), len(want), drifts)
+	}
+	for _, d := range drifts {
+		if !want[d.Path] {
+			t.Errorf("unexpected drift %+v", d)
+		}
+	}
+}
+
+func TestCompareNamedListExtraAndMissing(t *testing.T) {
+	baseline := mustParse(t, `
+kind: ConfigMap
+spec:
+  env:
+  - name: A
+    value: "1"
+  - name: B
+    value: "2"
+`)
+	live := mustParse(t, `
+kind: ConfigMap
+spec:
+  env:
+  - name: A
+    value: "1"
+  - name: C
+    value: "3"
+`)
+	drifts := Compare(baseline, live)
+	if len(drifts) != 2 {
+		t.Fatalf("got %+v", drifts)
+	}
+	if drifts[0].Path != "spec.env[name=B]" || drifts[0].Actual != nil {
+		t.Errorf("missing element: %+v", drifts[0])
+	}
+	if drifts[1].Path != "spec.env[name=C]" || drifts[1].Expected != nil {
+		t.Errorf("extra element: %+v", drifts[1])
+	}
+}
+
+func TestCompareSecretMasked(t *testing.T) {
+	baseline := mustParse(t, `
+kind: Secret
+stringData:
+  password: secret
+`)
+	live := mustParse(t, `
+kind: Secret
+data:
+  password: b3RoZXI=
+`)
+	drifts := Compare(baseline, live)
+	if len(drifts) != 1 || drifts[0].Path != "data.password" {
+		t.Fatalf("got %+v", drifts)
+	}
+	if drifts[0].Expected != maskedValue || drifts[0].Actual != maskedValue {
+		t.Errorf("secret value not masked: %+v", drifts[0])
+	}
+
+	live = mustParse(t, `
+kind: Secret
+data:
+  password: c2VjcmV0
+`)
+	if drifts := Compare(baseline, live); len(drifts) != 0 {
+		t.Errorf("expected in sync, got %+v", drifts)
+	}
+}
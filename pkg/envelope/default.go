@@ -0,0 +1,126 @@
+package envelope
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"k8s.io/klog/v2"
+)
+
+var (
+	defaultOnce    sync.Once
+	defaultKeyring *Keyring
+	defaultErr     error
+)
+
+// Default 按启动配置创建的密钥环。
+// 配置了 Vault Transit 时以 Vault 为当前主密钥，环境变量中的本地主密钥只用于解密；
+// 未配置任何主密钥时返回 nil，敏感字段沿用内置静态密钥加密
+func Default() (*Keyring, error) {
+	defaultOnce.Do(func() {
+		defaultKeyring, defaultErr = fromConfig(flag.Init())
+		switch {
+		case defaultErr != nil:
+			klog.Errorf("加载主密钥失败，敏感字段无法加解密: %v", defaultErr)
+		case defaultKeyring == nil:
+			klog.Warningf("未配置主密钥（MASTER_KEY 或 KMS_VAULT_*），敏感字段仍使用内置静态密钥加密，生产环境请配置主密钥")
+		default:
+			klog.V(2).Infof("敏感字段使用信封加密，当前主密钥版本 %s", defaultKeyring.ActiveID())
+		}
+	})
+	return defaultKeyring, defaultErr
+}
+
+func fromConfig(cfg *flag.Config) (*Keyring, error) {
+	var keys []KeyWrapper
+	if cfg.MasterKey != "" {
+		k, err := NewLocalKey(cfg.MasterKeyID, cfg.MasterKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	previous, err := ParseLocalKeys(cfg.MasterKeyPrevious)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.KMSVaultAddr != "" {
+		vault, err := NewVaultTransit(cfg.KMSVaultAddr, cfg.KMSVaultToken, cfg.KMSVaultKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append([]KeyWrapper{vault}, keys...)
+	}
+	if len(keys) == 0 {
+		if len(previous) > 0 {
+			return nil, fmt.Errorf("配置了 MASTER_KEY_PREVIOUS 但未配置当前主密钥 MASTER_KEY")
+		}
+		return nil, nil
+	}
+	return NewKeyring(keys[0], append(keys[1:], previous...)...)
+}
+
+// Encrypt 加密敏感字段。未配置主密钥时使用内置静态密钥，与历史数据格式一致；已是信封密文时原样返回
+func Encrypt(plaintext string) (string, error) {
+	if plaintext == "" || IsSealed(plaintext) {
+		return plaintext, nil
+	}
+	k, err := Default()
+	if err != nil {
+		return "", err
+	}
+	if k == nil {
+		encrypted, err := utils.AesEncrypt([]byte(plaintext))
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(encrypted), nil
+	}
+	return k.Seal([]byte(plaintext))
+}
+
+// Decrypt 解密 Encrypt 的结果，兼容内置静态密钥加密的历史数据
+func Decrypt(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	if !IsSealed(ciphertext) {
+		decrypted, err := utils.AesDecrypt(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		return string(decrypted), nil
+	}
+	k, err := Default()
+	if err != nil {
+		return "", err
+	}
+	if k == nil {
+		return "", fmt.Errorf("数据已使用主密钥 %s 加密，但未配置主密钥", KeyID(ciphertext))
+	}
+	plaintext, err := k.Open(ciphertext)
+	return string(plaintext), err
+}
+
+// SealIfEnabled 加密原先以明文保存的字段，未配置主密钥时保持明文；已是信封密文时原样返回
+func SealIfEnabled(plaintext string) (string, error) {
+	if plaintext == "" || IsSealed(plaintext) {
+		return plaintext, nil
+	}
+	k, err := Default()
+	if err != nil || k == nil {
+		return plaintext, err
+	}
+	return k.Seal([]byte(plaintext))
+}
+
+// OpenIfSealed 解密 SealIfEnabled 的结果，明文原样返回
+func OpenIfSealed(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	return Decrypt(value)
+}
@@ -0,0 +1,194 @@
+// Package envelope 提供数据库中敏感字段的信封加密。
+//
+// 每个值使用随机生成的数据密钥（DEK）以 AES-256-GCM 加密，DEK 再由主密钥（KEK）加密后与密文一起保存。
+// 主密钥可以来自环境变量，也可以是 KMS（如 Vault Transit）。密文中记录主密钥版本，
+// 更换主密钥后旧密文仍可用保留的旧主密钥解密，并可通过重新加密迁移到新主密钥。
+//
+// 密文格式：k8m-enc.v1.<主密钥版本>.<加密后的DEK>.<nonce+密文>，后两段为 base64url 编码。
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	prefix        = "k8m-enc"
+	formatVersion = "v1"
+	dekSize       = 32
+	// dekCacheSize 解密后的 DEK 缓存条数，避免 KMS 场景下每次读取都访问 KMS
+	dekCacheSize = 1024
+)
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_:-]+$`)
+
+var b64 = base64.RawURLEncoding
+
+// KeyWrapper 主密钥，负责加密、解密数据密钥
+type KeyWrapper interface {
+	// ID 主密钥版本，写入密文，用于解密时选择主密钥
+	ID() string
+	Wrap(dek []byte) ([]byte, error)
+	Unwrap(wrapped []byte) ([]byte, error)
+}
+
+// Keyring 当前主密钥及用于解密旧密文的历史主密钥
+type Keyring struct {
+	active KeyWrapper
+	keys   map[string]KeyWrapper
+
+	mu   sync.Mutex
+	deks map[string][]byte
+}
+
+// NewKeyring 创建密钥环，新密文使用 active 加密，previous 仅用于解密
+func NewKeyring(active KeyWrapper, previous ...KeyWrapper) (*Keyring, error) {
+	if active == nil {
+		return nil, fmt.Errorf("未设置主密钥")
+	}
+	k := &Keyring{active: active, keys: map[string]KeyWrapper{}, deks: map[string][]byte{}}
+	for _, w := range append([]KeyWrapper{active}, previous...) {
+		if !keyIDPattern.MatchString(w.ID()) {
+			return nil, fmt.Errorf("主密钥版本 %q 只能包含字母、数字、下划线、中划线和冒号", w.ID())
+		}
+		if _, ok := k.keys[w.ID()]; ok {
+			return nil, fmt.Errorf("主密钥版本 %s 重复", w.ID())
+		}
+		k.keys[w.ID()] = w
+	}
+	return k, nil
+}
+
+// ActiveID 当前主密钥版本
+func (k *Keyring) ActiveID() string {
+	return k.active.ID()
+}
+
+// Seal 使用当前主密钥信封加密
+func (k *Keyring) Seal(plaintext []byte) (string, error) {
+	dek := make([]byte, dekSize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+	wrapped, err := k.active.Wrap(dek)
+	if err != nil {
+		return "", fmt.Errorf("加密数据密钥失败: %w", err)
+	}
+	data, err := gcmSeal(dek, plaintext)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{prefix, formatVersion, k.active.ID(), b64.EncodeToString(wrapped), b64.EncodeToString(data)}, "."), nil
+}
+
+// Open 解密信封密文，密文的主密钥版本须在密钥环中
+func (k *Keyring) Open(ciphertext string) ([]byte, error) {
+	keyID, wrapped, data, err := parse(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	w, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("未配置版本为 %s 的主密钥", keyID)
+	}
+	dek, err := k.unwrap(w, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("解密数据密钥失败: %w", err)
+	}
+	return gcmOpen(dek, data)
+}
+
+func (k *Keyring) unwrap(w KeyWrapper, wrapped []byte) ([]byte, error) {
+	cacheKey := w.ID() + "/" + string(wrapped)
+	k.mu.Lock()
+	dek, ok := k.deks[cacheKey]
+	k.mu.Unlock()
+	if ok {
+		return dek, nil
+	}
+	dek, err := w.Unwrap(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	if len(k.deks) >= dekCacheSize {
+		k.deks = map[string][]byte{}
+	}
+	k.deks[cacheKey] = dek
+	k.mu.Unlock()
+	return dek, nil
+}
+
+// IsSealed 是否为信封密文
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, prefix+".")
+}
+
+// KeyID 返回信封密文使用的主密钥版本，非信封密文返回空
+func KeyID(value string) string {
+	keyID, _, _, err := parse(value)
+	if err != nil {
+		return ""
+	}
+	return keyID
+}
+
+func parse(value string) (keyID string, wrapped, data []byte, err error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 5 || parts[0] != prefix {
+		return "", nil, nil, fmt.Errorf("不是信封加密的密文")
+	}
+	if parts[1] != formatVersion {
+		return "", nil, nil, fmt.Errorf("不支持的密文格式版本 %s", parts[1])
+	}
+	if wrapped, err = b64.DecodeString(parts[3]); err != nil {
+		return "", nil, nil, fmt.Errorf("密文格式错误: %w", err)
+	}
+	if data, err = b64.DecodeString(parts[4]); err != nil {
+		return "", nil, nil, fmt.Errorf("密文格式错误: %w", err)
+	}
+	return parts[2], wrapped, data, nil
+}
+
+// gcmSeal AES-GCM 加密，返回 nonce+密文
+func gcmSeal(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// gcmOpen 解密 gcmSeal 的结果
+func gcmOpen(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("密文长度错误")
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("解密失败，主密钥不匹配或密文已损坏")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,169 @@
+package envelope
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func mustLocal(t *testing.T, id, secret string) KeyWrapper {
+	t.Helper()
+	k, err := NewLocalKey(id, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestSealOpen(t *testing.T) {
+	k, err := NewKeyring(mustLocal(t, "1", "passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := k.Seal([]byte("kubeconfig-content"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSealed(sealed) || KeyID(sealed) != "1" {
+		t.Fatalf("unexpected ciphertext %s", sealed)
+	}
+	again, _ := k.Seal([]byte("kubeconfig-content"))
+	if again == sealed {
+		t.Fatal("每次加密应使用不同的数据密钥与 nonce")
+	}
+	plain, err := k.Open(sealed)
+	if err != nil || string(plain) != "kubeconfig-content" {
+		t.Fatalf("Open() = %q, %v", plain, err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	oldKey := mustLocal(t, "1", "old")
+	oldRing, _ := NewKeyring(oldKey)
+	sealed, _ := oldRing.Seal([]byte("secret"))
+
+	newOnly, _ := NewKeyring(mustLocal(t, "2", "new"))
+	if _, err := newOnly.Open(sealed); err == nil {
+		t.Fatal("未保留旧主密钥时应无法解密")
+	}
+
+	rotated, err := NewKeyring(mustLocal(t, "2", "new"), oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := rotated.Open(sealed)
+	if err != nil || string(plain) != "secret" {
+		t.Fatalf("Open() = %q, %v", plain, err)
+	}
+	resealed, _ := rotated.Seal(plain)
+	if KeyID(resealed) != "2" {
+		t.Fatalf("新密文应使用当前主密钥，得到 %s", KeyID(resealed))
+	}
+
+	// 同一版本号换了密钥
+	wrongKey, _ := NewKeyring(mustLocal(t, "1", "other"))
+	if _, err := wrongKey.Open(sealed); err == nil {
+		t.Fatal("主密钥不匹配时应解密失败")
+	}
+}
+
+func TestNewKeyringValidate(t *testing.T) {
+	if _, err := NewKeyring(mustLocal(t, "a.b", "x")); err == nil {
+		t.Error("版本号包含点号应报错")
+	}
+	if _, err := NewKeyring(mustLocal(t, "1", "x"), mustLocal(t, "1", "y")); err == nil {
+		t.Error("版本号重复应报错")
+	}
+	if _, err := NewKeyring(nil); err == nil {
+		t.Error("未设置主密钥应报错")
+	}
+}
+
+func TestNewLocalKey(t *testing.T) {
+	raw := make([]byte, 32)
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+	k := mustLocal(t, "1", base64.StdEncoding.EncodeToString(raw)).(*localKey)
+	if string(k.key) != string(raw) {
+		t.Error("base64 编码的32字节密钥应直接使用")
+	}
+	p := mustLocal(t, "1", "short").(*localKey)
+	if len(p.key) != 32 {
+		t.Error("口令应派生为32字节密钥")
+	}
+	if _, err := NewLocalKey("1", ""); err == nil {
+		t.Error("空密钥应报错")
+	}
+}
+
+func TestParseLocalKeys(t *testing.T) {
+	keys, err := ParseLocalKeys(" 1:aaa , 2:bbb,")
+	if err != nil || len(keys) != 2 || keys[0].ID() != "1" || keys[1].ID() != "2" {
+		t.Fatalf("ParseLocalKeys() = %v, %v", keys, err)
+	}
+	if keys, err := ParseLocalKeys(""); err != nil || len(keys) != 0 {
+		t.Fatalf("空配置应返回空列表, %v", err)
+	}
+	if _, err := ParseLocalKeys("nokey"); err == nil {
+		t.Error("缺少版本号应报错")
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, v := range []string{"", "plain", "k8m-enc.v2.1.aa.bb", "k8m-enc.v1.1.!!.bb", "k8m-enc.v1.1.aa"} {
+		if KeyID(v) != "" {
+			t.Errorf("KeyID(%q) 应为空", v)
+		}
+	}
+}
+
+func TestVaultTransit(t *testing.T) {
+	// 模拟 Vault Transit：密文为 vault:v1: 加 base64 明文
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/k8m":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"ciphertext": "vault:v1:" + body["plaintext"]}})
+		case "/v1/transit/decrypt/k8m":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	vault, err := NewVaultTransit(srv.URL, "token", "k8m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := NewKeyring(vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := k.Seal([]byte("registry-password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if KeyID(sealed) != "vault:k8m" {
+		t.Fatalf("KeyID() = %s", KeyID(sealed))
+	}
+	plain, err := k.Open(sealed)
+	if err != nil || string(plain) != "registry-password" {
+		t.Fatalf("Open() = %q, %v", plain, err)
+	}
+
+	denied, _ := NewVaultTransit(srv.URL, "bad", "k8m")
+	if _, err := denied.Wrap([]byte("x")); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("Wrap() error = %v", err)
+	}
+}
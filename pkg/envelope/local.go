@@ -0,0 +1,61 @@
+package envelope
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// localKey 本地主密钥，以 AES-256-GCM 加密数据密钥
+type localKey struct {
+	id  string
+	key []byte
+}
+
+// NewLocalKey 创建本地主密钥。
+// secret 为 base64 编码的 32 字节密钥时直接使用，否则视为口令，取其 SHA-256 作为密钥
+func NewLocalKey(id, secret string) (KeyWrapper, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("主密钥 %s 不能为空", id)
+	}
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil || len(key) != 32 {
+		sum := sha256.Sum256([]byte(secret))
+		key = sum[:]
+	}
+	return &localKey{id: id, key: key}, nil
+}
+
+func (l *localKey) ID() string {
+	return l.id
+}
+
+func (l *localKey) Wrap(dek []byte) ([]byte, error) {
+	return gcmSeal(l.key, dek)
+}
+
+func (l *localKey) Unwrap(wrapped []byte) ([]byte, error) {
+	return gcmOpen(l.key, wrapped)
+}
+
+// ParseLocalKeys 解析 "版本:密钥,版本:密钥" 格式的多个本地主密钥
+func ParseLocalKeys(s string) ([]KeyWrapper, error) {
+	var keys []KeyWrapper
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(item, ":")
+		if !ok || strings.TrimSpace(id) == "" {
+			return nil, fmt.Errorf("主密钥格式错误，应为 版本:密钥")
+		}
+		k, err := NewLocalKey(strings.TrimSpace(id), strings.TrimSpace(secret))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
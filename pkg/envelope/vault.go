@@ -0,0 +1,87 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// vaultTransit 使用 HashiCorp Vault Transit 引擎作为 KMS 加密数据密钥，主密钥不离开 Vault
+type vaultTransit struct {
+	addr   string
+	token  string
+	key    string
+	client *http.Client
+}
+
+// NewVaultTransit 创建 Vault Transit 主密钥，addr 如 https://vault:8200，key 为 transit 引擎中的密钥名称。
+// Vault 的密钥轮换由 Vault 自身管理，密文中已带 Vault 的密钥版本，因此主密钥版本固定为 vault:<key>
+func NewVaultTransit(addr, token, key string) (KeyWrapper, error) {
+	if addr == "" || token == "" || key == "" {
+		return nil, fmt.Errorf("Vault 地址、Token 与密钥名称均不能为空")
+	}
+	return &vaultTransit{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		key:    key,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (v *vaultTransit) ID() string {
+	return "vault:" + v.key
+}
+
+func (v *vaultTransit) Wrap(dek []byte) ([]byte, error) {
+	var out struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := v.call("encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dek)}, &out); err != nil {
+		return nil, err
+	}
+	return []byte(out.Data.Ciphertext), nil
+}
+
+func (v *vaultTransit) Unwrap(wrapped []byte) ([]byte, error) {
+	var out struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := v.call("decrypt", map[string]string{"ciphertext": string(wrapped)}, &out); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.Data.Plaintext)
+}
+
+func (v *vaultTransit) call(op string, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/transit/%s/%s", v.addr, op, v.key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("访问 Vault 失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("Vault transit %s 失败: %s %s", op, resp.Status, strings.Join(e.Errors, "; "))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
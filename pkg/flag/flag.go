@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/joho/godotenv"
@@ -19,9 +20,11 @@ var config *Config
 var once sync.Once
 
 type Config struct {
-	Port       int    // chi 监听端口
-	Host       string // chi 监听地址
-	KubeConfig string // KUBECONFIG文件路径
+	Port         int    // chi 监听端口
+	Host         string // chi 监听地址
+	GRPCPort     int    // gRPC 监听端口，0 为不启用
+	EnableWebDAV bool   // 是否在 /dav/ 下提供容器文件的 WebDAV 访问
+	KubeConfig   string // KUBECONFIG文件路径
 
 	Debug             bool   // 调试模式，同步修改所有的debug模式
 	LogV              int    // klog的日志级别klog.V(this)
@@ -44,6 +47,23 @@ type Config struct {
 	ConnectCluster       bool   // 启动程序后，是否自动连接发现的集群，默认关闭
 	ProductName          string // 产品名称，默认为K8M
 	ResourceCacheTimeout int    // 资源缓存时间（秒）
+	ListCacheTTL         int    // 列表接口响应缓存时间（秒），0 为关闭
+
+	ExecAllowlistPodExec      string // 命令执行接口允许列表（Exec 权限用户），来自数据库配置
+	ExecAllowlistClusterAdmin string // 命令执行接口允许列表（集群管理员），来自数据库配置
+	FileUploadConcurrency     int    // 批量上传默认并发数，来自数据库配置
+	FileUploadRetries         int    // 批量上传单个文件的重试次数，来自数据库配置
+	UploadStagingQuotaMB      int    // 每个用户上传暂存空间上限（MiB），来自数据库配置
+	DiagnosticsFileGlobs      string // Pod 诊断包默认收集的容器内文件匹配模式，来自数据库配置
+	PasswordMinLength         int    // 本地账户密码最小长度，来自数据库配置
+	PasswordMinClasses        int    // 本地账户密码至少包含的字符种类数，来自数据库配置
+	PasswordMaxAgeDays        int    // 本地账户密码有效期（天），来自数据库配置
+	PasswordHistory           int    // 本地账户不能重复使用的最近密码个数，来自数据库配置
+	TOTPSkew                  int    // 2FA 验证码前后各容忍的周期数，来自数据库配置
+	JwtLeewaySeconds          int    // 校验 Token 有效期时容忍的时钟偏差（秒），来自数据库配置
+	ClockSkewThreshold        int    // 时钟偏差告警阈值（秒），来自数据库配置
+	NTPServer                 string // 时钟检查使用的 NTP 服务器，为空不检查，来自数据库配置
+	EnableGraphQL             bool   // 是否开启 GraphQL 组合查询接口，来自数据库配置
 
 	DBDriver   string // 数据库驱动类型: sqlite、mysql、postgresql等
 	SqlitePath string // sqlite 数据库路径
@@ -86,6 +106,46 @@ type Config struct {
 	LeaseDurationSeconds      int    // Lease 有效时长（秒），默认60
 	LeaseRenewIntervalSeconds int    // Lease 续约间隔（秒），默认20
 	HostClusterID             string // 宿主集群ID
+
+	// 多实例选主参数
+	LeaderLockName             string // 选主使用的锁名称
+	LeaderLeaseDurationSeconds int    // 选主租约有效时长（秒）
+	LeaderRenewDeadlineSeconds int    // 选主续约截止时间（秒）
+	LeaderRetryPeriodSeconds   int    // 选主重试周期（秒）
+
+	// 限流配置
+	RateLimitUserQPS      int // 每个用户每秒访问 apiserver 的请求数，0 为不限制
+	RateLimitUserBurst    int // 每个用户访问 apiserver 允许的突发请求数
+	RateLimitClusterQPS   int // 每个集群每秒接受用户发起的请求数，0 为不限制
+	RateLimitClusterBurst int // 每个集群接受用户发起的突发请求数
+	KubeClientQPS         int // 访问 apiserver 的客户端 QPS，0 使用 client-go 默认值
+	KubeClientBurst       int // 访问 apiserver 的客户端 Burst，0 使用 client-go 默认值
+
+	// 可观测性配置
+	AccessLog       bool   // 是否输出 JSON 格式的访问日志
+	EnableMetrics   bool   // 是否开启 /metrics Prometheus 指标端点
+	MetricsToken    string // 访问 /metrics 所需的 Bearer 令牌，为空不校验
+	OtelEndpoint    string // OpenTelemetry OTLP/HTTP 链路导出地址，为空不导出
+	OtelServiceName string // OpenTelemetry 服务名称
+
+	// 监听与外部认证
+	TLSCertFile          string // 主监听的服务端证书，与 TLSKeyFile 同时设置时启用 HTTPS
+	TLSKeyFile           string // 主监听的服务端私钥
+	ClientCAFile         string // 主监听校验客户端证书的 CA
+	ClientCertRequired   bool   // 主监听是否强制要求客户端证书
+	ClientCertAuth       bool   // 主监听是否以客户端证书 CN 作为登录用户
+	TrustedHeader        string // 主监听可信代理传递用户名的请求头
+	TrustedProxies       string // 主监听的可信代理地址，逗号分隔
+	TrustedCountryHeader string // 主监听可信代理传递客户端国家/地区代码的请求头
+	ExtraListeners       string // 附加监听，JSON 数组
+
+	// 敏感字段加密主密钥
+	MasterKey         string // 当前主密钥，为空时使用内置静态密钥
+	MasterKeyID       string // 当前主密钥版本
+	MasterKeyPrevious string // 旧主密钥，版本:密钥，逗号分隔，仅用于解密
+	KMSVaultAddr      string // Vault Transit 地址，设置后以 Vault 作为主密钥
+	KMSVaultToken     string // Vault Token
+	KMSVaultKey       string // Vault Transit 密钥名称
 }
 
 func Init() *Config {
@@ -108,6 +168,11 @@ func (c *Config) ShowConfigInfo() {
 func (c *Config) ShowConfigCloseMethod() {
 	klog.Infof("关闭打印选项方法：\n1. %s\n2. %s \n3. %s  \n", color.RedString("平台管理-参数设置-打印配置，选择关闭"), color.RedString("启动参数 --print-config = false"), color.RedString("env PRINT_CONFIG=false"))
 }
+
+// JwtLeeway 校验 Token 有效期时容忍的时钟偏差
+func (c *Config) JwtLeeway() time.Duration {
+	return time.Duration(c.JwtLeewaySeconds) * time.Second
+}
 func loadEnv() {
 	env := os.Getenv("K8M_ENV")
 	if env == "" {
@@ -207,6 +272,8 @@ func (c *Config) InitFlags() {
 	pflag.BoolVarP(&c.Debug, "debug", "d", defaultDebug, "调试模式")
 	pflag.IntVarP(&c.Port, "port", "p", defaultPort, "监听端口,默认3618")
 	pflag.StringVarP(&c.Host, "host", "h", defaultHost, "监听地址,默认0.0.0.0")
+	pflag.IntVar(&c.GRPCPort, "grpc-port", getEnvAsInt("GRPC_PORT", 0), "gRPC 监听端口，与 --host 使用相同的监听地址，默认 0 不启用")
+	pflag.BoolVar(&c.EnableWebDAV, "enable-webdav", getEnvAsBool("ENABLE_WEBDAV", false), "是否在 /dav/ 下提供容器文件的 WebDAV 访问，供本地编辑器、rclone 等工具挂载，默认关闭")
 
 	pflag.StringVar(&c.ProductName, "product-name", defaultProductName, "产品名称，默认为K8M")
 
@@ -226,9 +293,10 @@ func (c *Config) InitFlags() {
 	pflag.BoolVar(&c.InCluster, "in-cluster", defaultInCluster, "是否自动注册纳管宿主集群，默认启用")
 	pflag.BoolVar(&c.ConnectCluster, "connect-cluster", defaultConnectCluster, "启动程序后，是否自动连接发现的集群，默认关闭  ")
 	pflag.IntVar(&c.ResourceCacheTimeout, "resource-cache-timeout", defaultResourceCacheTimeout, "资源缓存时间（秒），默认60秒")
+	pflag.IntVar(&c.ListCacheTTL, "list-cache-ttl", getEnvAsInt("LIST_CACHE_TTL", 5), "资源列表接口响应缓存时间（秒），资源变更时自动失效，0为关闭，默认5秒")
 
 	// 数据库配置
-	pflag.StringVar(&c.DBDriver, "db-driver", getEnv("DB_DRIVER", "sqlite"), "数据库驱动类型: sqlite、mysql、postgresql等")
+	pflag.StringVar(&c.DBDriver, "db-driver", getEnv("DB_DRIVER", "sqlite"), "数据库驱动类型: sqlite、mysql、postgresql，兼容 sqlite3、postgres、pg 等别名")
 	// 数据库-sqlite
 	pflag.StringVar(&c.SqlitePath, "sqlite-path", defaultSqlitePath, "sqlite数据库文件路径，默认./data/k8m.db")
 	pflag.StringVar(&c.SqliteDSN, "sqlite-dsn", defaultSqliteDSN, "sqlite DSN参数配置（优先于 --sqlite-path），例如：file:./data/app.db?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
@@ -259,6 +327,46 @@ func (c *Config) InitFlags() {
 	pflag.IntVar(&c.LeaseDurationSeconds, "lease-duration-seconds", getEnvAsInt("LEASE_DURATION_SECONDS", 60), "Lease 有效时长（秒），默认60")
 	pflag.IntVar(&c.LeaseRenewIntervalSeconds, "lease-renew-interval-seconds", getEnvAsInt("LEASE_RENEW_INTERVAL_SECONDS", 20), "Lease 续约间隔（秒），默认20")
 
+	// 多实例选主参数
+	pflag.StringVar(&c.LeaderLockName, "leader-lock-name", getEnv("LEADER_LOCK_NAME", "k8m-leader-lock"), "多实例选主使用的锁名称，默认k8m-leader-lock")
+	pflag.IntVar(&c.LeaderLeaseDurationSeconds, "leader-lease-duration-seconds", getEnvAsInt("LEADER_LEASE_DURATION_SECONDS", 30), "选主租约有效时长（秒），默认30")
+	pflag.IntVar(&c.LeaderRenewDeadlineSeconds, "leader-renew-deadline-seconds", getEnvAsInt("LEADER_RENEW_DEADLINE_SECONDS", 20), "选主续约截止时间（秒），默认20")
+	pflag.IntVar(&c.LeaderRetryPeriodSeconds, "leader-retry-period-seconds", getEnvAsInt("LEADER_RETRY_PERIOD_SECONDS", 5), "选主重试周期（秒），默认5")
+
+	// 限流配置
+	pflag.IntVar(&c.RateLimitUserQPS, "rate-limit-user-qps", getEnvAsInt("RATE_LIMIT_USER_QPS", 0), "每个用户每秒访问 apiserver 的请求数，页面、gRPC、webhook、定时任务均计入，短暂超出时排队，持续超出返回429，0为不限制，默认0")
+	pflag.IntVar(&c.RateLimitUserBurst, "rate-limit-user-burst", getEnvAsInt("RATE_LIMIT_USER_BURST", 0), "每个用户访问 apiserver 允许的突发请求数，0为QPS的2倍")
+	pflag.IntVar(&c.RateLimitClusterQPS, "rate-limit-cluster-qps", getEnvAsInt("RATE_LIMIT_CLUSTER_QPS", 0), "每个集群每秒接受用户发起的 apiserver 请求数，持续超出返回429，0为不限制，默认0")
+	pflag.IntVar(&c.RateLimitClusterBurst, "rate-limit-cluster-burst", getEnvAsInt("RATE_LIMIT_CLUSTER_BURST", 0), "每个集群接受用户发起的突发请求数，0为QPS的2倍")
+	pflag.IntVar(&c.KubeClientQPS, "kube-client-qps", getEnvAsInt("KUBE_CLIENT_QPS", 0), "访问 apiserver 的客户端 QPS，0为使用client-go默认值")
+	pflag.IntVar(&c.KubeClientBurst, "kube-client-burst", getEnvAsInt("KUBE_CLIENT_BURST", 0), "访问 apiserver 的客户端 Burst，0为使用client-go默认值")
+
+	// 可观测性配置
+	pflag.BoolVar(&c.AccessLog, "access-log", getEnvAsBool("ACCESS_LOG", true), "是否输出 JSON 格式的访问日志，默认开启")
+	pflag.BoolVar(&c.EnableMetrics, "enable-metrics", getEnvAsBool("ENABLE_METRICS", false), "是否开启 /metrics Prometheus 指标端点，默认关闭")
+	pflag.StringVar(&c.MetricsToken, "metrics-token", getEnv("METRICS_TOKEN", ""), "访问 /metrics 所需的 Bearer 令牌，为空不校验，开启指标端点时建议设置")
+	pflag.StringVar(&c.OtelEndpoint, "otel-endpoint", getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""), "OpenTelemetry OTLP/HTTP 链路导出地址，如 http://otel-collector:4318，为空不导出")
+	pflag.StringVar(&c.OtelServiceName, "otel-service-name", getEnv("OTEL_SERVICE_NAME", "k8m"), "OpenTelemetry 服务名称，默认k8m")
+
+	// 监听与外部认证配置
+	pflag.StringVar(&c.TLSCertFile, "tls-cert-file", getEnv("TLS_CERT_FILE", ""), "主监听的 HTTPS 服务端证书文件，与 --tls-key-file 同时设置时启用 HTTPS")
+	pflag.StringVar(&c.TLSKeyFile, "tls-key-file", getEnv("TLS_KEY_FILE", ""), "主监听的 HTTPS 服务端私钥文件")
+	pflag.StringVar(&c.ClientCAFile, "client-ca-file", getEnv("CLIENT_CA_FILE", ""), "主监听校验客户端证书的 CA 文件，设置后启用 mTLS")
+	pflag.BoolVar(&c.ClientCertRequired, "client-cert-required", getEnvAsBool("CLIENT_CERT_REQUIRED", false), "主监听是否拒绝未提供有效客户端证书的连接，默认关闭")
+	pflag.BoolVar(&c.ClientCertAuth, "client-cert-auth", getEnvAsBool("CLIENT_CERT_AUTH", false), "主监听是否以客户端证书的 CN 作为登录用户，默认关闭")
+	pflag.StringVar(&c.TrustedHeader, "trusted-header", getEnv("TRUSTED_HEADER", ""), "主监听可信代理传递用户名的请求头，配置了 --trusted-proxies 时默认为 X-Forwarded-User")
+	pflag.StringVar(&c.TrustedProxies, "trusted-proxies", getEnv("TRUSTED_PROXIES", ""), "主监听允许传递用户名请求头的代理地址，IP 或 CIDR，逗号分隔，为空不启用可信代理头认证")
+	pflag.StringVar(&c.TrustedCountryHeader, "trusted-country-header", getEnv("TRUSTED_COUNTRY_HEADER", ""), "主监听可信代理传递客户端国家/地区代码的请求头，如 CF-IPCountry，用于访问规则按地区限制，须同时配置 --trusted-proxies")
+	pflag.StringVar(&c.ExtraListeners, "extra-listeners", getEnv("EXTRA_LISTENERS", ""), `附加监听，JSON 数组，每项可单独配置认证，如 [{"addr":":3619","tls_cert":"/certs/tls.crt","tls_key":"/certs/tls.key","client_ca":"/certs/ca.crt","client_cert_auth":true}]`)
+
+	// 敏感字段加密主密钥配置
+	pflag.StringVar(&c.MasterKey, "master-key", getEnv("MASTER_KEY", ""), "敏感字段信封加密的主密钥，base64 编码的32字节密钥或任意口令，为空时使用内置静态密钥")
+	pflag.StringVar(&c.MasterKeyID, "master-key-id", getEnv("MASTER_KEY_ID", "1"), "当前主密钥的版本，写入密文用于轮换，默认1")
+	pflag.StringVar(&c.MasterKeyPrevious, "master-key-previous", getEnv("MASTER_KEY_PREVIOUS", ""), "轮换前使用的旧主密钥，格式为 版本:密钥，多个用逗号分隔，仅用于解密")
+	pflag.StringVar(&c.KMSVaultAddr, "kms-vault-addr", getEnv("KMS_VAULT_ADDR", ""), "使用 Vault Transit 作为 KMS 时的 Vault 地址，设置后优先于 --master-key")
+	pflag.StringVar(&c.KMSVaultToken, "kms-vault-token", getEnv("KMS_VAULT_TOKEN", ""), "访问 Vault Transit 的 Token")
+	pflag.StringVar(&c.KMSVaultKey, "kms-vault-key", getEnv("KMS_VAULT_KEY", "k8m"), "Vault Transit 中的密钥名称，默认k8m")
+
 	// 其他配置-打印配置信息
 	pflag.BoolVar(&c.PrintConfig, "print-config", defaultPrintConfig, "是否打印配置信息，默认关闭")
 
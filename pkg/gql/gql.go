@@ -0,0 +1,140 @@
+// Package gql 提供 GraphQL 查询接口，一次请求即可按需获取 Deployment、Pod、事件、指标等相互关联的资源。
+// 数据由调用方提供的 Source 读取，同一请求内按命名空间复用列表结果，嵌套字段不会逐个对象访问集群。
+package gql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+// 查询复杂度限制
+const (
+	MaxDepth       = 8
+	MaxParallelism = 10
+)
+
+// PodUsage Pod 的实时资源用量
+type PodUsage struct {
+	CPUMillicores int64
+	MemoryBytes   int64
+}
+
+// Source 读取集群资源。namespace 为空时表示全部命名空间，Get 在对象不存在时返回 nil, nil
+type Source interface {
+	ListDeployments(ctx context.Context, namespace, labelSelector string) ([]*appsv1.Deployment, error)
+	GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error)
+	ListPods(ctx context.Context, namespace, labelSelector, fieldSelector string) ([]*corev1.Pod, error)
+	GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error)
+	ListEvents(ctx context.Context, namespace, fieldSelector string) ([]*corev1.Event, error)
+	// ListPodMetrics 返回命名空间中各 Pod 的用量，以 Pod 名称为 key
+	ListPodMetrics(ctx context.Context, namespace string) (map[string]*PodUsage, error)
+}
+
+var (
+	schemaOnce sync.Once
+	schema     *graphql.Schema
+)
+
+// Schema 返回解析后的 GraphQL schema
+func Schema() *graphql.Schema {
+	schemaOnce.Do(func() {
+		schema = graphql.MustParseSchema(schemaString, &query{},
+			graphql.MaxDepth(MaxDepth),
+			graphql.MaxParallelism(MaxParallelism),
+		)
+	})
+	return schema
+}
+
+// Request GraphQL 请求体
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Execute 使用 source 执行查询，返回标准的 GraphQL 响应（data 与 errors）
+func Execute(ctx context.Context, source Source, req *Request) *graphql.Response {
+	ctx = context.WithValue(ctx, loaderKey{}, newLoader(source))
+	return Schema().Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+type loaderKey struct{}
+
+func loaderFrom(ctx context.Context) (*loader, error) {
+	l, ok := ctx.Value(loaderKey{}).(*loader)
+	if !ok {
+		return nil, fmt.Errorf("GraphQL 请求缺少数据源")
+	}
+	return l, nil
+}
+
+// loader 在单个请求内缓存按命名空间列出的 Pod、事件与指标，供嵌套字段过滤使用
+type loader struct {
+	source Source
+	lock   sync.Mutex
+	memo   map[string]*memoEntry
+}
+
+type memoEntry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+func newLoader(source Source) *loader {
+	return &loader{source: source, memo: map[string]*memoEntry{}}
+}
+
+func (l *loader) load(key string, fn func() (any, error)) (any, error) {
+	l.lock.Lock()
+	e, ok := l.memo[key]
+	if !ok {
+		e = &memoEntry{}
+		l.memo[key] = e
+	}
+	l.lock.Unlock()
+	e.once.Do(func() {
+		e.value, e.err = fn()
+	})
+	return e.value, e.err
+}
+
+func (l *loader) pods(ctx context.Context, namespace string) ([]*corev1.Pod, error) {
+	v, err := l.load("pods/"+namespace, func() (any, error) {
+		return l.source.ListPods(ctx, namespace, "", "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*corev1.Pod), nil
+}
+
+func (l *loader) events(ctx context.Context, namespace string) ([]*corev1.Event, error) {
+	v, err := l.load("events/"+namespace, func() (any, error) {
+		return l.source.ListEvents(ctx, namespace, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*corev1.Event), nil
+}
+
+func (l *loader) metrics(ctx context.Context, namespace string) (map[string]*PodUsage, error) {
+	v, err := l.load("metrics/"+namespace, func() (any, error) {
+		return l.source.ListPodMetrics(ctx, namespace)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]*PodUsage), nil
+}
@@ -0,0 +1,438 @@
+package gql
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type query struct{}
+
+type listArgs struct {
+	Namespace     *string
+	LabelSelector *string
+}
+
+type podListArgs struct {
+	Namespace     *string
+	LabelSelector *string
+	FieldSelector *string
+}
+
+type eventListArgs struct {
+	Namespace     *string
+	FieldSelector *string
+}
+
+type getArgs struct {
+	Namespace string
+	Name      string
+}
+
+type eventTypeArgs struct {
+	Type *string
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func formatTime(t metav1.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
+
+func labelList(m map[string]string) []*labelResolver {
+	result := make([]*labelResolver, 0, len(m))
+	for k, v := range m {
+		result = append(result, &labelResolver{key: k, value: v})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].key < result[j].key })
+	return result
+}
+
+func (q *query) Deployments(ctx context.Context, args listArgs) ([]*deploymentResolver, error) {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.source.ListDeployments(ctx, str(args.Namespace), str(args.LabelSelector))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*deploymentResolver, 0, len(list))
+	for _, d := range list {
+		result = append(result, &deploymentResolver{d: d})
+	}
+	return result, nil
+}
+
+func (q *query) Deployment(ctx context.Context, args getArgs) (*deploymentResolver, error) {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := l.source.GetDeployment(ctx, args.Namespace, args.Name)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	return &deploymentResolver{d: d}, nil
+}
+
+func (q *query) Pods(ctx context.Context, args podListArgs) ([]*podResolver, error) {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.source.ListPods(ctx, str(args.Namespace), str(args.LabelSelector), str(args.FieldSelector))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*podResolver, 0, len(list))
+	for _, p := range list {
+		result = append(result, &podResolver{p: p})
+	}
+	return result, nil
+}
+
+func (q *query) Pod(ctx context.Context, args getArgs) (*podResolver, error) {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := l.source.GetPod(ctx, args.Namespace, args.Name)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	return &podResolver{p: p}, nil
+}
+
+func (q *query) Events(ctx context.Context, args eventListArgs) ([]*eventResolver, error) {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.source.ListEvents(ctx, str(args.Namespace), str(args.FieldSelector))
+	if err != nil {
+		return nil, err
+	}
+	return eventList(list, ""), nil
+}
+
+// relatedEvents 从命名空间的事件列表中筛选关联到指定对象的事件
+func relatedEvents(ctx context.Context, namespace, kind, name string, args eventTypeArgs) ([]*eventResolver, error) {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.events(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*corev1.Event
+	for _, e := range list {
+		if e.InvolvedObject.Kind == kind && e.InvolvedObject.Name == name {
+			matched = append(matched, e)
+		}
+	}
+	return eventList(matched, str(args.Type)), nil
+}
+
+// eventList 按类型过滤后按最近发生时间倒序排列
+func eventList(list []*corev1.Event, eventType string) []*eventResolver {
+	result := make([]*eventResolver, 0, len(list))
+	for _, e := range list {
+		if eventType != "" && !strings.EqualFold(e.Type, eventType) {
+			continue
+		}
+		result = append(result, &eventResolver{e: e})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return lastSeen(result[i].e).After(lastSeen(result[j].e))
+	})
+	return result
+}
+
+func lastSeen(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+type labelResolver struct {
+	key, value string
+}
+
+func (r *labelResolver) Key() string   { return r.key }
+func (r *labelResolver) Value() string { return r.value }
+
+type conditionResolver struct {
+	typ, status, reason, message string
+}
+
+func (r *conditionResolver) Type() string     { return r.typ }
+func (r *conditionResolver) Status() string   { return r.status }
+func (r *conditionResolver) Reason() *string  { return optional(r.reason) }
+func (r *conditionResolver) Message() *string { return optional(r.message) }
+
+type ownerResolver struct {
+	kind, name string
+}
+
+func (r *ownerResolver) Kind() string { return r.kind }
+func (r *ownerResolver) Name() string { return r.name }
+
+type deploymentResolver struct {
+	d *appsv1.Deployment
+}
+
+func (r *deploymentResolver) Name() string             { return r.d.Name }
+func (r *deploymentResolver) Namespace() string        { return r.d.Namespace }
+func (r *deploymentResolver) UID() string              { return string(r.d.UID) }
+func (r *deploymentResolver) Labels() []*labelResolver { return labelList(r.d.Labels) }
+func (r *deploymentResolver) CreatedAt() string        { return str(formatTime(r.d.CreationTimestamp)) }
+func (r *deploymentResolver) ReadyReplicas() int32     { return r.d.Status.ReadyReplicas }
+func (r *deploymentResolver) AvailableReplicas() int32 { return r.d.Status.AvailableReplicas }
+func (r *deploymentResolver) UpdatedReplicas() int32   { return r.d.Status.UpdatedReplicas }
+
+func (r *deploymentResolver) Replicas() int32 {
+	if r.d.Spec.Replicas == nil {
+		return 1
+	}
+	return *r.d.Spec.Replicas
+}
+
+func (r *deploymentResolver) Images() []string {
+	images := []string{}
+	for _, c := range r.d.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+	return images
+}
+
+func (r *deploymentResolver) Selector() string {
+	selector, err := metav1.LabelSelectorAsSelector(r.d.Spec.Selector)
+	if err != nil {
+		return ""
+	}
+	return selector.String()
+}
+
+func (r *deploymentResolver) Conditions() []*conditionResolver {
+	result := []*conditionResolver{}
+	for _, c := range r.d.Status.Conditions {
+		result = append(result, &conditionResolver{typ: string(c.Type), status: string(c.Status), reason: c.Reason, message: c.Message})
+	}
+	return result
+}
+
+// Pods 复用请求内缓存的命名空间 Pod 列表，按标签选择器在内存中过滤
+func (r *deploymentResolver) Pods(ctx context.Context) ([]*podResolver, error) {
+	selector, err := metav1.LabelSelectorAsSelector(r.d.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	result := []*podResolver{}
+	if selector.Empty() {
+		return result, nil
+	}
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.pods(ctx, r.d.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range list {
+		if selector.Matches(labels.Set(p.Labels)) {
+			result = append(result, &podResolver{p: p})
+		}
+	}
+	return result, nil
+}
+
+func (r *deploymentResolver) Events(ctx context.Context, args eventTypeArgs) ([]*eventResolver, error) {
+	return relatedEvents(ctx, r.d.Namespace, "Deployment", r.d.Name, args)
+}
+
+type podResolver struct {
+	p *corev1.Pod
+}
+
+func (r *podResolver) Name() string             { return r.p.Name }
+func (r *podResolver) Namespace() string        { return r.p.Namespace }
+func (r *podResolver) UID() string              { return string(r.p.UID) }
+func (r *podResolver) Labels() []*labelResolver { return labelList(r.p.Labels) }
+func (r *podResolver) CreatedAt() string        { return str(formatTime(r.p.CreationTimestamp)) }
+func (r *podResolver) Phase() string            { return string(r.p.Status.Phase) }
+func (r *podResolver) NodeName() *string        { return optional(r.p.Spec.NodeName) }
+func (r *podResolver) PodIP() *string           { return optional(r.p.Status.PodIP) }
+
+func (r *podResolver) Ready() bool {
+	for _, c := range r.p.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *podResolver) Restarts() int32 {
+	var total int32
+	for _, s := range r.p.Status.ContainerStatuses {
+		total += s.RestartCount
+	}
+	return total
+}
+
+func (r *podResolver) Owner() *ownerResolver {
+	ref := metav1.GetControllerOf(r.p)
+	if ref == nil {
+		return nil
+	}
+	return &ownerResolver{kind: ref.Kind, name: ref.Name}
+}
+
+func (r *podResolver) Containers() []*containerResolver {
+	statuses := map[string]corev1.ContainerStatus{}
+	for _, s := range r.p.Status.InitContainerStatuses {
+		statuses["init/"+s.Name] = s
+	}
+	for _, s := range r.p.Status.ContainerStatuses {
+		statuses[s.Name] = s
+	}
+	result := []*containerResolver{}
+	for _, c := range r.p.Spec.InitContainers {
+		result = append(result, newContainerResolver(c, true, statuses["init/"+c.Name]))
+	}
+	for _, c := range r.p.Spec.Containers {
+		result = append(result, newContainerResolver(c, false, statuses[c.Name]))
+	}
+	return result
+}
+
+func (r *podResolver) Events(ctx context.Context, args eventTypeArgs) ([]*eventResolver, error) {
+	return relatedEvents(ctx, r.p.Namespace, "Pod", r.p.Name, args)
+}
+
+// Metrics 指标获取失败（如未安装 metrics-server）时返回 null，不影响其他字段
+func (r *podResolver) Metrics(ctx context.Context) *podMetricsResolver {
+	l, err := loaderFrom(ctx)
+	if err != nil {
+		return nil
+	}
+	usage, err := l.metrics(ctx, r.p.Namespace)
+	if err != nil {
+		return nil
+	}
+	u, ok := usage[r.p.Name]
+	if !ok || u == nil {
+		return nil
+	}
+	return &podMetricsResolver{u: u}
+}
+
+type containerResolver struct {
+	c      corev1.Container
+	init   bool
+	status corev1.ContainerStatus
+}
+
+func newContainerResolver(c corev1.Container, init bool, status corev1.ContainerStatus) *containerResolver {
+	return &containerResolver{c: c, init: init, status: status}
+}
+
+func (r *containerResolver) Name() string        { return r.c.Name }
+func (r *containerResolver) Image() string       { return r.c.Image }
+func (r *containerResolver) Init() bool          { return r.init }
+func (r *containerResolver) Ready() bool         { return r.status.Ready }
+func (r *containerResolver) RestartCount() int32 { return r.status.RestartCount }
+
+func (r *containerResolver) State() string {
+	switch s := r.status.State; {
+	case s.Running != nil:
+		return "Running"
+	case s.Terminated != nil:
+		return "Terminated"
+	case s.Waiting != nil:
+		return "Waiting"
+	default:
+		return "Unknown"
+	}
+}
+
+func (r *containerResolver) Reason() *string {
+	switch s := r.status.State; {
+	case s.Terminated != nil:
+		return optional(s.Terminated.Reason)
+	case s.Waiting != nil:
+		return optional(s.Waiting.Reason)
+	default:
+		return nil
+	}
+}
+
+type podMetricsResolver struct {
+	u *PodUsage
+}
+
+func (r *podMetricsResolver) CpuMillicores() float64 { return float64(r.u.CPUMillicores) }
+func (r *podMetricsResolver) MemoryBytes() float64   { return float64(r.u.MemoryBytes) }
+
+type eventResolver struct {
+	e *corev1.Event
+}
+
+func (r *eventResolver) Type() string            { return r.e.Type }
+func (r *eventResolver) Reason() string          { return r.e.Reason }
+func (r *eventResolver) Message() string         { return r.e.Message }
+func (r *eventResolver) ObjectKind() string      { return r.e.InvolvedObject.Kind }
+func (r *eventResolver) ObjectName() string      { return r.e.InvolvedObject.Name }
+func (r *eventResolver) ObjectNamespace() string { return r.e.InvolvedObject.Namespace }
+func (r *eventResolver) FirstTimestamp() *string { return formatTime(r.e.FirstTimestamp) }
+
+func (r *eventResolver) LastTimestamp() *string {
+	if t := lastSeen(r.e); !t.IsZero() {
+		return formatTime(metav1.NewTime(t))
+	}
+	return nil
+}
+
+func (r *eventResolver) Count() int32 {
+	if r.e.Count == 0 {
+		return 1
+	}
+	return r.e.Count
+}
+
+func (r *eventResolver) Source() *string {
+	if r.e.Source.Component != "" {
+		return optional(r.e.Source.Component)
+	}
+	return optional(r.e.ReportingController)
+}
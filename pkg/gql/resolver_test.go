@@ -0,0 +1,206 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeSource struct {
+	lock        sync.Mutex
+	calls       map[string]int
+	deployments []*appsv1.Deployment
+	pods        []*corev1.Pod
+	events      []*corev1.Event
+	metrics     map[string]*PodUsage
+	metricsErr  error
+}
+
+func (f *fakeSource) count(name string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.calls[name]++
+}
+
+func (f *fakeSource) ListDeployments(ctx context.Context, namespace, labelSelector string) ([]*appsv1.Deployment, error) {
+	f.count("deployments/" + namespace)
+	return f.deployments, nil
+}
+
+func (f *fakeSource) GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	for _, d := range f.deployments {
+		if d.Namespace == namespace && d.Name == name {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSource) ListPods(ctx context.Context, namespace, labelSelector, fieldSelector string) ([]*corev1.Pod, error) {
+	f.count("pods/" + namespace)
+	return f.pods, nil
+}
+
+func (f *fakeSource) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	return nil, nil
+}
+
+func (f *fakeSource) ListEvents(ctx context.Context, namespace, fieldSelector string) ([]*corev1.Event, error) {
+	f.count("events/" + namespace)
+	return f.events, nil
+}
+
+func (f *fakeSource) ListPodMetrics(ctx context.Context, namespace string) (map[string]*PodUsage, error) {
+	f.count("metrics/" + namespace)
+	return f.metrics, f.metricsErr
+}
+
+func newFakeSource() *fakeSource {
+	replicas := int32(2)
+	deploy := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			},
+		}
+	}
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: app + ":1"}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "main", Ready: true, RestartCount: 3, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}},
+			},
+		}
+	}
+	event := func(kind, name, typ, reason string) *corev1.Event {
+		return &corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name, Namespace: "default"},
+			Type:           typ,
+			Reason:         reason,
+		}
+	}
+	return &fakeSource{
+		calls:       map[string]int{},
+		deployments: []*appsv1.Deployment{deploy("web"), deploy("api")},
+		pods:        []*corev1.Pod{pod("web-1", "web"), pod("web-2", "web"), pod("api-1", "api")},
+		events: []*corev1.Event{
+			event("Deployment", "web", "Normal", "ScalingReplicaSet"),
+			event("Pod", "web-1", "Warning", "BackOff"),
+			event("Pod", "web-1", "Normal", "Pulled"),
+		},
+		metrics: map[string]*PodUsage{"web-1": {CPUMillicores: 250, MemoryBytes: 64 << 20}},
+	}
+}
+
+func run(t *testing.T, src Source, q string) map[string]any {
+	t.Helper()
+	resp := Execute(context.Background(), src, &Request{Query: q})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("query errors: %v", resp.Errors)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSchemaParses(t *testing.T) {
+	if Schema() == nil {
+		t.Fatal("schema is nil")
+	}
+}
+
+func TestNestedQueryListsNamespaceOnce(t *testing.T) {
+	src := newFakeSource()
+	data := run(t, src, `{
+		deployments(namespace: "default") {
+			name
+			replicas
+			pods { name ready restarts metrics { cpuMillicores memoryBytes } events(type: "Warning") { reason } }
+			events { reason }
+		}
+	}`)
+
+	for _, key := range []string{"pods/default", "events/default", "metrics/default"} {
+		if src.calls[key] != 1 {
+			t.Errorf("%s listed %d times, want 1", key, src.calls[key])
+		}
+	}
+
+	deployments := data["deployments"].([]any)
+	if len(deployments) != 2 {
+		t.Fatalf("got %d deployments", len(deployments))
+	}
+	web := deployments[0].(map[string]any)
+	pods := web["pods"].([]any)
+	if len(pods) != 2 {
+		t.Fatalf("web has %d pods, want 2", len(pods))
+	}
+	web1 := pods[0].(map[string]any)
+	if web1["name"] != "web-1" || web1["ready"] != true || web1["restarts"] != float64(3) {
+		t.Errorf("unexpected pod: %v", web1)
+	}
+	if m := web1["metrics"].(map[string]any); m["cpuMillicores"] != float64(250) {
+		t.Errorf("cpuMillicores = %v", m["cpuMillicores"])
+	}
+	if events := web1["events"].([]any); len(events) != 1 || events[0].(map[string]any)["reason"] != "BackOff" {
+		t.Errorf("unexpected warning events: %v", events)
+	}
+	if pods[1].(map[string]any)["metrics"] != nil {
+		t.Errorf("pod without metrics should be null")
+	}
+	if events := web["events"].([]any); len(events) != 1 {
+		t.Errorf("web has %d events, want 1", len(events))
+	}
+	api := deployments[1].(map[string]any)
+	if n := len(api["pods"].([]any)); n != 1 {
+		t.Errorf("api has %d pods, want 1", n)
+	}
+}
+
+func TestMetricsErrorIsNull(t *testing.T) {
+	src := newFakeSource()
+	src.metricsErr = fmt.Errorf("metrics-server not installed")
+	data := run(t, src, `{ pods(namespace: "default") { name metrics { cpuMillicores } } }`)
+	for _, p := range data["pods"].([]any) {
+		if p.(map[string]any)["metrics"] != nil {
+			t.Errorf("metrics should be null: %v", p)
+		}
+	}
+}
+
+func TestDeploymentNotFound(t *testing.T) {
+	resp := Execute(context.Background(), newFakeSource(), &Request{
+		Query:     `query($name: String!) { deployment(namespace: "default", name: $name) { name } }`,
+		Variables: map[string]any{"name": "missing"},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("query errors: %v", resp.Errors)
+	}
+	if string(resp.Data) != `{"deployment":null}` {
+		t.Errorf("data = %s, want null deployment", resp.Data)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	resp := Execute(context.Background(), newFakeSource(), &Request{Query: `{ deployments { pods { events { reason } } } }`})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	resp = Execute(context.Background(), newFakeSource(), &Request{Query: `{ deployments { nope } }`})
+	if len(resp.Errors) == 0 {
+		t.Fatal("expected validation error for unknown field")
+	}
+}
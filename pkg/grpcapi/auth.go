@@ -0,0 +1,169 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"slices"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// authenticate 校验 metadata 中的 authorization Token，与 HTTP 接口的 AuthMiddleware 规则一致，
+// 通过后返回携带用户名的 ctx，kom 回调据此校验资源权限
+func authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if v := md.Get("authorization"); len(v) > 0 {
+		token = v[0]
+	}
+	cfg := flag.Init()
+	claims, err := utils.GetJwtMapClaimsFromToken(token, cfg.JwtTokenSecret, cfg.JwtLeeway())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	username, _ := claims[constants.JwtUserName].(string)
+	if username == "" {
+		return nil, status.Error(codes.Unauthenticated, "无效的用户名")
+	}
+	if iat, ok := claims["iat"].(float64); ok && service.UserService().TokenRevoked(username, int64(iat)) {
+		return nil, status.Error(codes.Unauthenticated, "登录已失效，请重新登录")
+	}
+	if must, _ := claims[constants.JwtMustChangePassword].(bool); must {
+		return nil, status.Error(codes.PermissionDenied, "密码已过期或被要求修改，请先修改密码")
+	}
+	return context.WithValue(ctx, constants.JwtUserName, username), nil
+}
+
+// checkCluster 校验集群已连接且当前用户有权访问，规则与 EnsureSelectedClusterMiddleware 一致
+func checkCluster(ctx context.Context, cluster string) error {
+	if cluster == "" {
+		return status.Error(codes.InvalidArgument, "未指定集群")
+	}
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	if !service.UserService().IsUserPlatformAdmin(username) {
+		clusters, err := service.UserService().GetClusterNames(username)
+		if err != nil {
+			return status.Error(codes.Internal, "获取集群授权失败")
+		}
+		if !slices.Contains(clusters, cluster) {
+			return status.Errorf(codes.PermissionDenied, "无权限访问集群: %s", cluster)
+		}
+	}
+	if !service.ClusterService().IsConnected(cluster) {
+		return status.Errorf(codes.FailedPrecondition, "集群未连接，请先连接集群: %s", cluster)
+	}
+	return nil
+}
+
+// checkWritable 集群处于只读模式时拒绝变更操作
+func checkWritable(cluster string) error {
+	if err := service.ReadOnlyService().Check(cluster); err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return nil
+}
+
+// methodOperations gRPC 方法对应的访问规则操作类别，与 HTTP 接口经 accessrule.Classify 得到的类别一致，未列出的方法为只读
+var methodOperations = map[string][]string{
+	k8mv1.K8M_ApplyResource_FullMethodName:  {"write"},
+	k8mv1.K8M_DeleteResource_FullMethodName: {"delete", "write"},
+	k8mv1.K8M_UploadFile_FullMethodName:     {"file_write", "write"},
+	k8mv1.K8M_Exec_FullMethodName:           {"exec", "write"},
+}
+
+// requestCluster 读取请求消息中的集群
+func requestCluster(m any) string {
+	switch v := m.(type) {
+	case interface{ GetCluster() string }:
+		return v.GetCluster()
+	case interface{ GetRef() *k8mv1.ResourceRef }:
+		return v.GetRef().GetCluster()
+	case interface{ GetContainer() *k8mv1.ContainerRef }:
+		return v.GetContainer().GetCluster()
+	case interface {
+		GetHeader() *k8mv1.UploadFileHeader
+	}:
+		return v.GetHeader().GetContainer().GetCluster()
+	case interface{ GetStart() *k8mv1.ExecStart }:
+		return v.GetStart().GetContainer().GetCluster()
+	}
+	return ""
+}
+
+// checkAccess 按访问规则校验来源 IP 与时间段，规则与 AccessRuleMiddleware 一致。
+// gRPC 直连不经过反向代理，来源为连接的对端地址，无法确定地区
+func checkAccess(ctx context.Context, method, cluster string) error {
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	req := &service.AccessRequest{
+		Username:   username,
+		Cluster:    cluster,
+		Operations: methodOperations[method],
+		Time:       time.Now(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(req.IP); err == nil {
+			req.IP = host
+		}
+	}
+	if err := service.AccessRuleService().Check(req); err != nil {
+		klog.V(6).Infof("访问规则拒绝 gRPC 请求 %s: %v", method, err)
+		service.AccessRuleService().RecordViolation(req, "GRPC", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+func unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccess(ctx, info.FullMethod, requestCluster(req)); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStream 替换流的 ctx，使处理函数取到携带用户名的 ctx；
+// 集群在首条消息中，收到首条消息时校验访问规则
+type authStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	method  string
+	checked bool
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if !s.checked {
+		s.checked = true
+		return checkAccess(s.ctx, s.method, requestCluster(m))
+	}
+	return nil
+}
+
+func streamAuthInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx, method: info.FullMethod})
+}
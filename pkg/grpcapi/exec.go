@@ -0,0 +1,223 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// execSender 串行化 stdout、stderr 的发送，gRPC 流不支持并发 Send
+type execSender struct {
+	lock   sync.Mutex
+	stream k8mv1.K8M_ExecServer
+}
+
+func (e *execSender) send(resp *k8mv1.ExecResponse) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.stream.Send(resp)
+}
+
+// execWriter 将命令输出转发为 stdout 或 stderr 消息
+type execWriter struct {
+	sender *execSender
+	stderr bool
+}
+
+func (w *execWriter) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+	resp := &k8mv1.ExecResponse{Payload: &k8mv1.ExecResponse_Stdout{Stdout: data}}
+	if w.stderr {
+		resp.Payload = &k8mv1.ExecResponse_Stderr{Stderr: data}
+	}
+	if err := w.sender.send(resp); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// sizeQueue 向容器传递终端大小变化，队列满时丢弃新的变化
+type sizeQueue struct {
+	ctx   context.Context
+	sizes chan remotecommand.TerminalSize
+}
+
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.ctx.Done():
+		return nil
+	}
+}
+
+func (q *sizeQueue) push(size *k8mv1.TerminalSize) {
+	if size == nil || size.Width == 0 || size.Height == 0 {
+		return
+	}
+	select {
+	case q.sizes <- remotecommand.TerminalSize{Width: uint16(size.Width), Height: uint16(size.Height)}:
+	default:
+	}
+}
+
+// stdinGuard 按交互式终端的命令策略逐行校验标准输入，与网页终端一致。
+// TTY 模式下输入原样转发以保留回显与补全，命中策略时改为发送 Ctrl+C 取消当前行；
+// 非 TTY 模式下只转发已提交的完整行，避免结束输入时残留的半行绕过校验被执行
+type stdinGuard struct {
+	ctx     context.Context
+	ref     *k8mv1.ContainerRef
+	tty     bool
+	pending string
+}
+
+// filter 返回可以写入容器的数据，任一行命中策略时返回错误并丢弃尚未提交的输入
+func (g *stdinGuard) filter(data []byte) ([]byte, error) {
+	input := g.pending + string(data)
+	lines, rest := utils.SplitTerminalInput(g.pending, data)
+	for _, line := range lines {
+		if err := g.check(line); err != nil {
+			g.pending = ""
+			if g.tty {
+				return []byte{0x03}, err
+			}
+			return nil, err
+		}
+	}
+	g.pending = rest
+	if g.tty {
+		return data, nil
+	}
+	return []byte(input[:len(input)-len(rest)]), nil
+}
+
+// flush 结束输入时校验并返回非 TTY 模式下暂存的最后半行
+func (g *stdinGuard) flush() ([]byte, error) {
+	rest := g.pending
+	g.pending = ""
+	if g.tty || rest == "" {
+		return nil, nil
+	}
+	if err := g.check(utils.TerminalLine(rest)); err != nil {
+		return nil, err
+	}
+	return []byte(rest), nil
+}
+
+func (g *stdinGuard) check(line string) error {
+	err := service.CommandPolicyService().Check(g.ref.Cluster, constants.CommandScopeTerminal, line)
+	if err != nil {
+		username, _ := g.ctx.Value(constants.JwtUserName).(string)
+		service.CommandPolicyService().RecordViolation(g.ref.Cluster, g.ref.Namespace, g.ref.Pod, g.ref.Container,
+			username, constants.CommandScopeTerminal, line, err)
+	}
+	return err
+}
+
+func (s *server) Exec(stream k8mv1.K8M_ExecServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "第一条消息必须为 start")
+	}
+	ref := start.GetContainer()
+	if err := checkContainer(ctx, ref); err != nil {
+		return err
+	}
+	if err := checkWritable(ref.Cluster); err != nil {
+		return err
+	}
+
+	sender := &execSender{stream: stream}
+	opt := &remotecommand.StreamOptions{
+		Stdout: &execWriter{sender: sender},
+		Tty:    start.Tty,
+	}
+	// TTY 模式下标准错误合并到标准输出
+	if !start.Tty {
+		opt.Stderr = &execWriter{sender: sender, stderr: true}
+	}
+	sizes := &sizeQueue{ctx: ctx, sizes: make(chan remotecommand.TerminalSize, 4)}
+	if start.Tty {
+		sizes.push(start.Size)
+		opt.TerminalSizeQueue = sizes
+	}
+	var stdin *io.PipeWriter
+	guard := &stdinGuard{ctx: ctx, ref: ref, tty: start.Tty}
+	// 命中策略时提示客户端，TTY 模式下标准错误已合并到标准输出
+	denied := &execWriter{sender: sender, stderr: !start.Tty}
+	closeStdin := func() {
+		if stdin == nil {
+			return
+		}
+		if data, err := guard.flush(); err != nil {
+			_, _ = denied.Write([]byte("\r\n" + err.Error() + "\r\n"))
+		} else if len(data) > 0 {
+			_, _ = stdin.Write(data)
+		}
+		_ = stdin.Close()
+	}
+	if start.Stdin {
+		var pr *io.PipeReader
+		pr, stdin = io.Pipe()
+		defer pr.Close()
+		opt.Stdin = pr
+	}
+
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				// 客户端结束发送时关闭标准输入，其余错误说明连接已断开，丢弃未提交的输入
+				if err != io.EOF {
+					cancel()
+					if stdin != nil {
+						_ = stdin.Close()
+					}
+					return
+				}
+				closeStdin()
+				return
+			}
+			switch p := msg.Payload.(type) {
+			case *k8mv1.ExecRequest_Stdin:
+				if stdin == nil {
+					continue
+				}
+				data, err := guard.filter(p.Stdin)
+				if err != nil {
+					_, _ = denied.Write([]byte("\r\n" + err.Error() + "\r\n"))
+				}
+				if len(data) > 0 {
+					_, _ = stdin.Write(data)
+				}
+			case *k8mv1.ExecRequest_Resize:
+				sizes.push(p.Resize)
+			case *k8mv1.ExecRequest_CloseStdin:
+				if p.CloseStdin {
+					closeStdin()
+				}
+			}
+		}
+	}()
+
+	err = service.PodService().StreamExec(ctx, ref.Cluster, ref.Namespace, ref.Pod, ref.Container, start.Command, opt)
+	code, ok := service.ExecExitCode(err)
+	if !ok {
+		return toStatus(err)
+	}
+	return sender.send(&k8mv1.ExecResponse{Payload: &k8mv1.ExecResponse_ExitCode{ExitCode: int32(code)}})
+}
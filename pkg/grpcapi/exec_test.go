@@ -0,0 +1,53 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/service"
+)
+
+func TestStdinGuard(t *testing.T) {
+	policy := &models.CommandPolicy{Name: "no-rm", Pattern: `^rm\s`, Action: service.CommandPolicyDeny, Scopes: "terminal", Enabled: true}
+	if err := dao.DB().Create(policy).Error; err != nil {
+		t.Fatal(err)
+	}
+	service.CommandPolicyService().Invalidate()
+	t.Cleanup(func() {
+		dao.DB().Delete(policy)
+		service.CommandPolicyService().Invalidate()
+	})
+	ref := &k8mv1.ContainerRef{Cluster: "c1", Namespace: "default", Pod: "p", Container: "c"}
+
+	// 非 TTY：只转发完整的行，分多帧输入的禁止命令整行丢弃
+	g := &stdinGuard{ctx: context.Background(), ref: ref}
+	if data, err := g.filter([]byte("ls\nrm -r")); err != nil || string(data) != "ls\n" {
+		t.Fatalf("filter = %q, %v", data, err)
+	}
+	if data, err := g.filter([]byte("f /tmp\n")); err == nil || len(data) != 0 {
+		t.Fatalf("denied line: filter = %q, %v", data, err)
+	}
+	if data, err := g.filter([]byte("echo ok\n")); err != nil || string(data) != "echo ok\n" {
+		t.Fatalf("filter after deny = %q, %v", data, err)
+	}
+	// 结束输入时残留的半行同样校验
+	_, _ = g.filter([]byte("rm -rf /"))
+	if data, err := g.flush(); err == nil || len(data) != 0 {
+		t.Fatalf("flush = %q, %v", data, err)
+	}
+
+	// TTY：按键原样转发，提交禁止命令时改为发送 Ctrl+C
+	g = &stdinGuard{ctx: context.Background(), ref: ref, tty: true}
+	if data, err := g.filter([]byte("rm -rf /")); err != nil || string(data) != "rm -rf /" {
+		t.Fatalf("tty filter = %q, %v", data, err)
+	}
+	if data, err := g.filter([]byte("\r")); err == nil || string(data) != "\x03" {
+		t.Fatalf("tty denied = %q, %v", data, err)
+	}
+	if data, err := g.filter([]byte("ls\r")); err != nil || string(data) != "ls\r" {
+		t.Fatalf("tty filter after deny = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,145 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/service"
+	"github.com/weibaohui/kom/kom"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func checkContainer(ctx context.Context, ref *k8mv1.ContainerRef) error {
+	if ref == nil || ref.GetNamespace() == "" || ref.GetPod() == "" {
+		return status.Error(codes.InvalidArgument, "namespace、pod 不能为空")
+	}
+	return checkCluster(ctx, ref.GetCluster())
+}
+
+// fileContext 标记文件传输执行的底层命令，适用 file 范围的命令策略
+func fileContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constants.CommandScope, constants.CommandScopeFile)
+}
+
+func (s *server) DownloadFile(req *k8mv1.DownloadFileRequest, stream k8mv1.K8M_DownloadFileServer) error {
+	ctx := stream.Context()
+	ref := req.GetContainer()
+	if err := checkContainer(ctx, ref); err != nil {
+		return err
+	}
+	if !path.IsAbs(req.GetPath()) {
+		return status.Error(codes.InvalidArgument, "path 必须为绝对路径")
+	}
+	reader, err := service.PodService().OpenFile(fileContext(ctx), &service.PodFileRef{
+		Cluster:       ref.Cluster,
+		Namespace:     ref.Namespace,
+		PodName:       ref.Pod,
+		ContainerName: ref.Container,
+		Path:          req.Path,
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	defer reader.Close()
+	if req.Offset < 0 || req.Offset > reader.Size {
+		return status.Errorf(codes.OutOfRange, "偏移量 %d 超出文件大小 %d", req.Offset, reader.Size)
+	}
+	if _, err := reader.Seek(req.Offset, io.SeekStart); err != nil {
+		return toStatus(err)
+	}
+
+	size := reader.Size
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := reader.Read(buf)
+		// 空文件也发送一条消息，告知客户端文件大小
+		if n > 0 || size >= 0 {
+			if sendErr := stream.Send(&k8mv1.FileChunk{Data: buf[:n], Size: size}); sendErr != nil {
+				return sendErr
+			}
+			size = -1
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+	}
+}
+
+// uploadReader 将上传流中的 data 消息拼接为连续的内容
+type uploadReader struct {
+	stream k8mv1.K8M_UploadFileServer
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetHeader() != nil {
+			return 0, status.Error(codes.InvalidArgument, "header 只能出现在第一条消息")
+		}
+		r.buf = msg.GetData()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (s *server) UploadFile(stream k8mv1.K8M_UploadFileServer) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	header := first.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "第一条消息必须为 header")
+	}
+	ref := header.GetContainer()
+	if err := checkContainer(ctx, ref); err != nil {
+		return err
+	}
+	if err := checkWritable(ref.Cluster); err != nil {
+		return err
+	}
+	if !path.IsAbs(header.GetDir()) {
+		return status.Error(codes.InvalidArgument, "dir 必须为绝对路径")
+	}
+	name := utils.SanitizeFileName(header.GetFileName())
+	if name == "" || header.GetSize() < 0 {
+		return status.Error(codes.InvalidArgument, "file_name 与 size 无效")
+	}
+
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	tempFilePath, release, err := service.UploadStagingService().StageReader(username, name, header.Size, &uploadReader{stream: stream})
+	if err != nil {
+		return toStatus(err)
+	}
+	defer release()
+
+	f, err := os.Open(tempFilePath)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer f.Close()
+	err = kom.Cluster(ref.Cluster).WithContext(fileContext(ctx)).
+		Namespace(ref.Namespace).Name(ref.Pod).Ctl().Pod().
+		ContainerName(ref.Container).UploadFile(header.Dir, f)
+	if err != nil {
+		return toStatus(fmt.Errorf("上传文件到Pod中错误: %w", err))
+	}
+	return stream.SendAndClose(&k8mv1.UploadFileResponse{Path: path.Join(header.Dir, name), Bytes: header.Size})
+}
@@ -0,0 +1,51 @@
+package grpcapi
+
+import (
+	"errors"
+	"io"
+
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/service"
+	v1 "k8s.io/api/core/v1"
+)
+
+func (s *server) StreamLogs(req *k8mv1.StreamLogsRequest, stream k8mv1.K8M_StreamLogsServer) error {
+	ctx := stream.Context()
+	ref := req.GetContainer()
+	if err := checkContainer(ctx, ref); err != nil {
+		return err
+	}
+	opt := &v1.PodLogOptions{
+		Container:  ref.Container,
+		Follow:     req.Follow,
+		Timestamps: req.Timestamps,
+		Previous:   req.Previous,
+	}
+	if req.TailLines > 0 {
+		opt.TailLines = &req.TailLines
+	}
+	if req.SinceSeconds > 0 {
+		opt.SinceSeconds = &req.SinceSeconds
+	}
+	logs, err := service.PodService().StreamPodLogs(ctx, ref.Cluster, ref.Namespace, ref.Pod, opt)
+	if err != nil {
+		return toStatus(err)
+	}
+	defer logs.Close()
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := logs.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&k8mv1.LogChunk{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/kom/kom"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func checkRef(ctx context.Context, ref *k8mv1.ResourceRef) error {
+	if ref == nil || ref.GetVersion() == "" || ref.GetKind() == "" || ref.GetName() == "" {
+		return status.Error(codes.InvalidArgument, "version、kind、name 不能为空")
+	}
+	return checkCluster(ctx, ref.GetCluster())
+}
+
+func toResource(obj *unstructured.Unstructured) (*k8mv1.Resource, error) {
+	b, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &k8mv1.Resource{Json: b}, nil
+}
+
+func (s *server) GetResource(ctx context.Context, req *k8mv1.GetResourceRequest) (*k8mv1.Resource, error) {
+	ref := req.GetRef()
+	if err := checkRef(ctx, ref); err != nil {
+		return nil, err
+	}
+	var obj *unstructured.Unstructured
+	err := kom.Cluster(ref.Cluster).WithContext(ctx).RemoveManagedFields().
+		Name(ref.Name).Namespace(ref.Namespace).CRD(ref.Group, ref.Version, ref.Kind).Get(&obj).Error
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toResource(obj)
+}
+
+func (s *server) ListResources(ctx context.Context, req *k8mv1.ListResourcesRequest) (*k8mv1.ListResourcesResponse, error) {
+	if req.GetVersion() == "" || req.GetKind() == "" {
+		return nil, status.Error(codes.InvalidArgument, "version、kind 不能为空")
+	}
+	if err := checkCluster(ctx, req.GetCluster()); err != nil {
+		return nil, err
+	}
+	k := kom.Cluster(req.Cluster).WithContext(ctx).RemoveManagedFields().CRD(req.Group, req.Version, req.Kind)
+	if len(req.Namespaces) == 0 {
+		k = k.AllNamespace()
+	} else {
+		k = k.Namespace(req.Namespaces...)
+	}
+	if req.LabelSelector != "" {
+		k = k.WithLabelSelector(req.LabelSelector)
+	}
+	if req.FieldSelector != "" {
+		k = k.WithFieldSelector(req.FieldSelector)
+	}
+	var list []*unstructured.Unstructured
+	if err := k.List(&list).Error; err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &k8mv1.ListResourcesResponse{Items: make([]*k8mv1.Resource, 0, len(list))}
+	for _, obj := range list {
+		item, err := toResource(obj)
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+func (s *server) ApplyResource(ctx context.Context, req *k8mv1.ApplyResourceRequest) (*k8mv1.ApplyResourceResponse, error) {
+	if req.GetYaml() == "" {
+		return nil, status.Error(codes.InvalidArgument, "yaml 不能为空")
+	}
+	if err := checkCluster(ctx, req.GetCluster()); err != nil {
+		return nil, err
+	}
+	if err := checkWritable(req.Cluster); err != nil {
+		return nil, err
+	}
+	results := kom.Cluster(req.Cluster).WithContext(ctx).Applier().Apply(req.Yaml)
+	return &k8mv1.ApplyResourceResponse{Results: results}, nil
+}
+
+func (s *server) DeleteResource(ctx context.Context, req *k8mv1.DeleteResourceRequest) (*k8mv1.DeleteResourceResponse, error) {
+	ref := req.GetRef()
+	if err := checkRef(ctx, ref); err != nil {
+		return nil, err
+	}
+	if err := checkWritable(ref.Cluster); err != nil {
+		return nil, err
+	}
+	k := kom.Cluster(ref.Cluster).WithContext(ctx).Name(ref.Name).Namespace(ref.Namespace).CRD(ref.Group, ref.Version, ref.Kind)
+	var err error
+	if req.Force {
+		err = k.ForceDelete().Error
+	} else {
+		err = k.Delete().Error
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &k8mv1.DeleteResourceResponse{}, nil
+}
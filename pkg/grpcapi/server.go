@@ -0,0 +1,102 @@
+// Package grpcapi 以 gRPC 提供资源增删改查、文件传输、日志与命令执行等核心操作，
+// 供其他平台服务集成。接口定义见 k8mv1/k8m.proto，认证与权限规则与 HTTP 接口一致。
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// 文件与日志流每条消息的最大数据量
+const chunkSize = 64 << 10
+
+type server struct {
+	k8mv1.UnimplementedK8MServer
+}
+
+// NewServer 创建注册了 k8m 服务与反射服务的 gRPC Server，所有方法均需认证
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryAuthInterceptor),
+		grpc.ChainStreamInterceptor(streamAuthInterceptor),
+	)
+	s := grpc.NewServer(opts...)
+	k8mv1.RegisterK8MServer(s, &server{})
+	reflection.Register(s)
+	return s
+}
+
+// Start 在 --grpc-port 指定的端口启动 gRPC 服务，端口为 0 时不启动。
+// 配置了主监听的 TLS 证书时使用相同证书启用 TLS
+func Start() {
+	cfg := flag.Init()
+	if cfg.GRPCPort <= 0 {
+		return
+	}
+	var opts []grpc.ServerOption
+	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			klog.Errorf("加载 gRPC TLS 证书失败: %v", err)
+			return
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.GRPCPort))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		klog.Errorf("gRPC 监听 %s 失败: %v", addr, err)
+		return
+	}
+	s := NewServer(opts...)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			klog.Errorf("gRPC 服务异常退出: %v", err)
+		}
+	}()
+	klog.Infof("gRPC 服务已启动，监听 %s", addr)
+}
+
+// toStatus 将集群返回的错误转换为对应的 gRPC 状态码
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if _, ok := ratelimit.RetryAfter(err); ok {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case apierrors.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case apierrors.IsAlreadyExists(err):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case apierrors.IsConflict(err):
+		return status.Error(codes.Aborted, err.Error())
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
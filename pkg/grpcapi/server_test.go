@@ -0,0 +1,112 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/grpcapi/k8mv1"
+	"github.com/weibaohui/k8m/pkg/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TestMain 测试结束后清理导入 service 包时在当前目录初始化的 sqlite 数据库
+func TestMain(m *testing.M) {
+	code := m.Run()
+	_ = os.RemoveAll("data")
+	os.Exit(code)
+}
+
+func TestToStatus(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+	cases := []struct {
+		err  error
+		want codes.Code
+	}{
+		{apierrors.NewNotFound(gr, "x"), codes.NotFound},
+		{apierrors.NewAlreadyExists(gr, "x"), codes.AlreadyExists},
+		{apierrors.NewConflict(gr, "x", errors.New("changed")), codes.Aborted},
+		{apierrors.NewForbidden(gr, "x", errors.New("denied")), codes.PermissionDenied},
+		{apierrors.NewBadRequest("bad"), codes.InvalidArgument},
+		{fmt.Errorf("wrap: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{status.Error(codes.OutOfRange, "keep"), codes.OutOfRange},
+		{fmt.Errorf("list: %w", &ratelimit.Error{Err: errors.New("too many"), RetryAfter: time.Second}), codes.ResourceExhausted},
+		{errors.New("other"), codes.Unknown},
+	}
+	for _, c := range cases {
+		if got := status.Code(toStatus(c.err)); got != c.want {
+			t.Errorf("toStatus(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+	if toStatus(nil) != nil {
+		t.Error("toStatus(nil) should be nil")
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer()
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := k8mv1.NewK8MClient(conn)
+
+	_, err = client.GetResource(context.Background(), &k8mv1.GetResourceRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("GetResource without token: got %v, want Unauthenticated", err)
+	}
+	stream, err := client.StreamLogs(context.Background(), &k8mv1.StreamLogsRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("StreamLogs without token: got %v, want Unauthenticated", err)
+	}
+}
+
+func TestRequestCluster(t *testing.T) {
+	ref := &k8mv1.ContainerRef{Cluster: "c1"}
+	cases := []any{
+		&k8mv1.ListResourcesRequest{Cluster: "c1"},
+		&k8mv1.ApplyResourceRequest{Cluster: "c1"},
+		&k8mv1.GetResourceRequest{Ref: &k8mv1.ResourceRef{Cluster: "c1"}},
+		&k8mv1.DeleteResourceRequest{Ref: &k8mv1.ResourceRef{Cluster: "c1"}},
+		&k8mv1.DownloadFileRequest{Container: ref},
+		&k8mv1.StreamLogsRequest{Container: ref},
+		&k8mv1.UploadFileRequest{Payload: &k8mv1.UploadFileRequest_Header{Header: &k8mv1.UploadFileHeader{Container: ref}}},
+		&k8mv1.ExecRequest{Payload: &k8mv1.ExecRequest_Start{Start: &k8mv1.ExecStart{Container: ref}}},
+	}
+	for _, m := range cases {
+		if got := requestCluster(m); got != "c1" {
+			t.Errorf("requestCluster(%T) = %q", m, got)
+		}
+	}
+	if got := requestCluster(&k8mv1.ExecRequest{Payload: &k8mv1.ExecRequest_Stdin{Stdin: []byte("x")}}); got != "" {
+		t.Errorf("stdin message cluster = %q", got)
+	}
+	if ops := methodOperations[k8mv1.K8M_Exec_FullMethodName]; !slices.Contains(ops, "exec") {
+		t.Errorf("Exec operations = %v", ops)
+	}
+	if ops := methodOperations[k8mv1.K8M_GetResource_FullMethodName]; ops != nil {
+		t.Errorf("GetResource operations = %v", ops)
+	}
+}
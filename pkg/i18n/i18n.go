@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// 支持的语言
+const (
+	LangZh = "zh"
+	LangEn = "en"
+
+	DefaultLang = LangZh
+
+	// LangHeader 前端按用户选择的界面语言设置的请求头
+	LangHeader = "X-K8M-Lang"
+)
+
+// Code 错误码，在消息目录中对应各语言的消息模板，前端与 API 调用方可按错误码分支处理
+type Code string
+
+// CodeUnknown 未登记错误码的错误统一返回该错误码
+const CodeUnknown Code = "unknown"
+
+// Error 带错误码的错误，Error() 返回默认语言的消息，响应时按请求语言翻译
+type Error struct {
+	Code Code
+	Args []any
+}
+
+// NewError 创建带错误码的错误，args 按消息模板中的占位符顺序传入
+func NewError(code Code, args ...any) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+func (e *Error) Error() string {
+	return e.Localize(DefaultLang)
+}
+
+// Localize 返回指定语言的消息
+func (e *Error) Localize(lang string) string {
+	return Message(lang, e.Code, e.Args...)
+}
+
+// Message 按语言查找消息模板并格式化，缺少该语言时回退到默认语言，未登记的错误码直接返回错误码。
+// 参数为 Code 时替换为该错误码对应语言的消息，用于需要翻译的参数
+func Message(lang string, code Code, args ...any) string {
+	texts, ok := catalog[code]
+	if !ok {
+		return string(code)
+	}
+	tpl, ok := texts[lang]
+	if !ok {
+		tpl = texts[DefaultLang]
+	}
+	if len(args) == 0 {
+		return tpl
+	}
+	localized := make([]any, len(args))
+	for i, arg := range args {
+		if c, ok := arg.(Code); ok {
+			arg = Message(lang, c)
+		}
+		localized[i] = arg
+	}
+	return fmt.Sprintf(tpl, localized...)
+}
+
+// userLangResolver 读取登录用户保存的语言偏好，由用户偏好模块注册
+var userLangResolver func(r *http.Request) string
+
+// SetUserLangResolver 注册用户语言偏好的读取方法
+func SetUserLangResolver(fn func(r *http.Request) string) {
+	userLangResolver = fn
+}
+
+// LangFromRequest 解析请求语言，依次使用 X-K8M-Lang 请求头、用户语言偏好、Accept-Language，均未设置时使用默认语言
+func LangFromRequest(r *http.Request) string {
+	if lang := normalize(r.Header.Get(LangHeader)); lang != "" {
+		return lang
+	}
+	if userLangResolver != nil {
+		if lang := normalize(userLangResolver(r)); lang != "" {
+			return lang
+		}
+	}
+	return parseAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// parseAcceptLanguage 按 q 值选出第一个支持的语言
+func parseAcceptLanguage(header string) string {
+	best, bestQ := "", -1.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if lang := normalize(tag); lang != "" && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	if best == "" {
+		return DefaultLang
+	}
+	return best
+}
+
+// normalize 将 zh-CN、en_US 等语言标签归一为支持的语言，不支持时返回空
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	switch {
+	case strings.HasPrefix(tag, "zh"):
+		return LangZh
+	case strings.HasPrefix(tag, "en"):
+		return LangEn
+	}
+	return ""
+}
+
+// Localize 返回错误码与指定语言的消息。错误链中没有 *Error 时返回 CodeUnknown 与原始消息；
+// 默认语言保留完整的错误链，其他语言只返回 *Error 本身的翻译
+func Localize(err error, lang string) (Code, string) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return CodeUnknown, err.Error()
+	}
+	if lang == DefaultLang {
+		return e.Code, err.Error()
+	}
+	return e.Code, e.Localize(lang)
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLangFromRequest(t *testing.T) {
+	cases := []struct {
+		header, accept, want string
+	}{
+		{"", "", LangZh},
+		{"", "en-US,en;q=0.9,zh-CN;q=0.8", LangEn},
+		{"", "fr-FR,zh-CN;q=0.5,en;q=0.9", LangEn},
+		{"", "de-DE", LangZh},
+		{"zh", "en-US", LangZh},
+	}
+	for _, c := range cases {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-K8M-Lang", c.header)
+		r.Header.Set("Accept-Language", c.accept)
+		if got := LangFromRequest(r); got != c.want {
+			t.Errorf("LangFromRequest(%q, %q) = %q, want %q", c.header, c.accept, got, c.want)
+		}
+	}
+}
+
+func TestErrorLocalize(t *testing.T) {
+	err := fmt.Errorf("上传失败: %w", NewError(CodeFilePreflightNotExist, "/data"))
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatal("errors.As should find *Error")
+	}
+	if got := e.Localize(LangEn); got != "target directory /data does not exist; create it first or enable createDir" {
+		t.Errorf("unexpected en message: %s", got)
+	}
+	if got := NewError(CodeFilePathRequired).Error(); got != "路径不能为空" {
+		t.Errorf("unexpected default message: %s", got)
+	}
+	for code, texts := range catalog {
+		if texts[LangZh] == "" || texts[LangEn] == "" {
+			t.Errorf("code %s is missing a translation", code)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	wrapped := fmt.Errorf("上传失败: %w", NewError(CodeFilePathRequired))
+	if code, msg := Localize(wrapped, LangZh); code != CodeFilePathRequired || msg != "上传失败: 路径不能为空" {
+		t.Errorf("zh: got %s %q", code, msg)
+	}
+	if code, msg := Localize(wrapped, LangEn); code != CodeFilePathRequired || msg != "path is required" {
+		t.Errorf("en: got %s %q", code, msg)
+	}
+	if code, msg := Localize(errors.New("boom"), LangEn); code != CodeUnknown || msg != "boom" {
+		t.Errorf("uncoded: got %s %q", code, msg)
+	}
+}
@@ -0,0 +1,330 @@
+package i18n
+
+// 通用错误码，API Server 错误按原因映射到这些错误码，消息保留 API Server 返回的原文
+const (
+	CodeInvalidParam    Code = "invalid_param"
+	CodeNotFound        Code = "not_found"
+	CodeUnauthorized    Code = "unauthorized"
+	CodeForbidden       Code = "forbidden"
+	CodeConflict        Code = "conflict"
+	CodeTimeout         Code = "timeout"
+	CodeTooManyRequests Code = "too_many_requests"
+)
+
+// 平台权限错误码
+const (
+	CodePermissionNoUser       Code = "permission.no_user"
+	CodePermissionLookupFailed Code = "permission.lookup_failed"
+	CodePermissionNoCluster    Code = "permission.no_cluster"
+	CodePermissionCluster      Code = "permission.cluster_denied"
+	CodePermissionReadonly     Code = "permission.readonly_denied"
+	CodePermissionExec         Code = "permission.exec_denied"
+	CodePermissionExecNsBlack  Code = "permission.exec_namespace_blacklisted"
+	CodePermissionExecNsWhite  Code = "permission.exec_namespace_not_whitelisted"
+	CodePermissionWrite        Code = "permission.write_denied"
+	CodePermissionWriteNsBlack Code = "permission.write_namespace_blacklisted"
+	CodePermissionWriteNsWhite Code = "permission.write_namespace_not_whitelisted"
+	CodePermissionRead         Code = "permission.read_denied"
+	CodePermissionReadNsBlack  Code = "permission.read_namespace_blacklisted"
+	CodePermissionReadNsWhite  Code = "permission.read_namespace_not_whitelisted"
+)
+
+// 限流错误码
+const CodeClusterTooManyRequests Code = "cluster_too_many_requests"
+
+// 只读模式错误码
+const (
+	CodeReadOnly         Code = "read_only"
+	CodeClusterReadOnly  Code = "cluster_read_only"
+	CodeReadOnlyNoReason Code = "read_only.no_reason"
+	CodeReadOnlyManual   Code = "read_only.manual"
+)
+
+// 变更审批错误码
+const (
+	CodeApprovalRequired    Code = "approval.required"
+	CodeApprovalNotPending  Code = "approval.not_pending"
+	CodeApprovalSelfReview  Code = "approval.self_review"
+	CodeApprovalNotApprover Code = "approval.not_approver"
+	CodeApprovalNotOwner    Code = "approval.not_owner"
+)
+
+// LDAP 错误码
+const CodeLDAPBindFailed Code = "ldap.bind_failed"
+
+// 文件管理错误码
+const (
+	CodeFilePathRequired        Code = "file.path_required"
+	CodeFileNameRequired        Code = "file.name_required"
+	CodeFileListFailed          Code = "file.list_failed"
+	CodeFileNotViewable         Code = "file.not_viewable"
+	CodeFileIsDir               Code = "file.is_dir"
+	CodeFileBinaryContent       Code = "file.binary_content"
+	CodeFileInvalidBase64       Code = "file.invalid_base64"
+	CodeFileUnsupportedEncoding Code = "file.unsupported_encoding"
+	CodeFileTooLarge            Code = "file.too_large"
+	CodeFileSizeMismatch        Code = "file.size_mismatch"
+	CodeFileNoUpload            Code = "file.no_upload"
+	CodeFileUploadRead          Code = "file.upload_read_failed"
+	CodeFileWorkloadRequired    Code = "file.workload_required"
+	CodeFileWorkloadKind        Code = "file.workload_kind_unsupported"
+	CodeFileWorkloadNoPods      Code = "file.workload_no_pods"
+	CodeFileCopyPodRequired     Code = "file.copy_pod_required"
+	CodeFileObjectKeyRequired   Code = "file.object_key_required"
+	CodeFileConfigKeyRequired   Code = "file.config_key_required"
+	CodeFileWatchRequired       Code = "file.watch_required"
+	CodeFileWatchNotFound       Code = "file.watch_not_found"
+	CodeFileStagingQuota        Code = "file.staging_quota_exceeded"
+	CodeFilePreflightNotDir     Code = "file.preflight_not_dir"
+	CodeFilePreflightNotExist   Code = "file.preflight_not_exist"
+	CodeFilePreflightMkdir      Code = "file.preflight_mkdir_failed"
+	CodeFilePreflightReadOnly   Code = "file.preflight_readonly"
+	CodeFilePreflightNoSpace    Code = "file.preflight_no_space"
+	CodeFileBatchPathsRequired  Code = "file.batch_paths_required"
+	CodeFileBatchTooMany        Code = "file.batch_too_many"
+	CodeFileBatchAllFailed      Code = "file.batch_all_failed"
+	CodeCapabilityUnsupported   Code = "capability.unsupported"
+	CodeCapabilityDisabled      Code = "capability.disabled"
+)
+
+// catalog 消息目录，模板中的占位符与 NewError 的参数顺序一致
+var catalog = map[Code]map[string]string{
+	CodeInvalidParam: {
+		LangZh: "参数错误: %v",
+		LangEn: "invalid parameter: %v",
+	},
+	CodeNotFound: {
+		LangZh: "%s不存在",
+		LangEn: "%s not found",
+	},
+	CodeTooManyRequests: {
+		LangZh: "请求过于频繁，请稍后重试",
+		LangEn: "too many requests, please retry later",
+	},
+	CodeClusterTooManyRequests: {
+		LangZh: "集群[%s]请求过于频繁，请稍后重试",
+		LangEn: "too many requests to cluster [%s], please retry later",
+	},
+	CodeReadOnly: {
+		LangZh: "平台处于只读模式，已暂停全部变更操作。原因：%s，预计解除：%s",
+		LangEn: "k8m is in read-only mode and all changes are paused. Reason: %s. Expected end: %s",
+	},
+	CodeClusterReadOnly: {
+		LangZh: "集群[%s]处于只读模式，已暂停全部变更操作。原因：%s，预计解除：%s",
+		LangEn: "cluster [%s] is in read-only mode and all changes are paused. Reason: %s. Expected end: %s",
+	},
+	CodeReadOnlyNoReason: {
+		LangZh: "维护中",
+		LangEn: "maintenance",
+	},
+	CodeReadOnlyManual: {
+		LangZh: "管理员手动关闭后",
+		LangEn: "when an administrator turns it off",
+	},
+	CodeApprovalRequired: {
+		LangZh: "该变更需要审批，已提交变更申请 #%d（规则：%s），审批通过后自动执行",
+		LangEn: "this change requires approval; change request #%d has been submitted (rule: %s) and will be applied once approved",
+	},
+	CodeApprovalNotPending: {
+		LangZh: "变更申请 #%d 当前状态为 %s，无法操作",
+		LangEn: "change request #%d is %s and can no longer be changed",
+	},
+	CodeApprovalSelfReview: {
+		LangZh: "不能审批自己提交的变更申请",
+		LangEn: "you cannot review your own change request",
+	},
+	CodeApprovalNotApprover: {
+		LangZh: "用户[%s]不是变更申请 #%d 的审批人",
+		LangEn: "user [%s] is not an approver of change request #%d",
+	},
+	CodeApprovalNotOwner: {
+		LangZh: "只能撤回自己提交的变更申请",
+		LangEn: "you can only cancel your own change requests",
+	},
+	CodeLDAPBindFailed: {
+		LangZh: "管理员账号或密码错误",
+		LangEn: "invalid bind DN or password",
+	},
+
+	CodePermissionNoUser: {
+		LangZh: "用户为空，默认阻止",
+		LangEn: "no user in request, denied by default",
+	},
+	CodePermissionLookupFailed: {
+		LangZh: "用户[%s]获取集群授权错误，默认阻止",
+		LangEn: "failed to load cluster grants for user [%s], denied by default",
+	},
+	CodePermissionNoCluster: {
+		LangZh: "用户[%s]没有集群授权",
+		LangEn: "user [%s] has no cluster grants",
+	},
+	CodePermissionCluster: {
+		LangZh: "用户[%s]没有集群[%s]访问权限",
+		LangEn: "user [%s] has no access to cluster [%s]",
+	},
+	CodePermissionReadonly: {
+		LangZh: "用户[%s]没有集群[%s] 只读权限",
+		LangEn: "user [%s] has no read-only permission on cluster [%s]",
+	},
+	CodePermissionExec: {
+		LangZh: "用户[%s]没有集群[%s] Exec权限",
+		LangEn: "user [%s] has no exec permission on cluster [%s]",
+	},
+	CodePermissionExecNsBlack: {
+		LangZh: "用户[%s]没有集群[%s] [%s] Exec权限-进入命名空间黑名单",
+		LangEn: "user [%s] has no exec permission on cluster [%s] [%s]: namespace is blacklisted",
+	},
+	CodePermissionExecNsWhite: {
+		LangZh: "用户[%s]没有集群[%s] [%s] Exec权限-不在命名空间白名单",
+		LangEn: "user [%s] has no exec permission on cluster [%s] [%s]: namespace is not whitelisted",
+	},
+	CodePermissionWrite: {
+		LangZh: "用户[%s]没有集群[%s] 操作权限",
+		LangEn: "user [%s] has no write permission on cluster [%s]",
+	},
+	CodePermissionWriteNsBlack: {
+		LangZh: "用户[%s]没有集群[%s] [%s] 操作权限-进入命名空间黑名单",
+		LangEn: "user [%s] has no write permission on cluster [%s] [%s]: namespace is blacklisted",
+	},
+	CodePermissionWriteNsWhite: {
+		LangZh: "用户[%s]没有集群[%s] [%s] 操作权限-不在命名空间白名单",
+		LangEn: "user [%s] has no write permission on cluster [%s] [%s]: namespace is not whitelisted",
+	},
+	CodePermissionRead: {
+		LangZh: "用户[%s]没有集群[%s] 读取/管理员 权限",
+		LangEn: "user [%s] has no read or admin permission on cluster [%s]",
+	},
+	CodePermissionReadNsBlack: {
+		LangZh: "用户[%s]没有集群[%s] [%s] 读取权限-进入命名空间黑名单",
+		LangEn: "user [%s] has no read permission on cluster [%s] [%s]: namespace is blacklisted",
+	},
+	CodePermissionReadNsWhite: {
+		LangZh: "用户[%s]没有集群[%s] [%s] 读取权限-不在命名空间白名单",
+		LangEn: "user [%s] has no read permission on cluster [%s] [%s]: namespace is not whitelisted",
+	},
+
+	CodeFilePathRequired: {
+		LangZh: "路径不能为空",
+		LangEn: "path is required",
+	},
+	CodeFileNameRequired: {
+		LangZh: "文件名不能为空",
+		LangEn: "file name is required",
+	},
+	CodeFileListFailed: {
+		LangZh: "获取文件列表失败,容器内没有shell或者没有ls命令",
+		LangEn: "failed to list files: the container has no shell or ls command",
+	},
+	CodeFileNotViewable: {
+		LangZh: "无法查看%s类型文件",
+		LangEn: "cannot view files of type %s",
+	},
+	CodeFileIsDir: {
+		LangZh: "无法保存目录",
+		LangEn: "cannot save a directory",
+	},
+	CodeFileBinaryContent: {
+		LangZh: "%s包含非文本内容，请下载后查看",
+		LangEn: "%s contains non-text content; download it to view",
+	},
+	CodeFileInvalidBase64: {
+		LangZh: "内容不是有效的 base64 编码: %v",
+		LangEn: "content is not valid base64: %v",
+	},
+	CodeFileUnsupportedEncoding: {
+		LangZh: "不支持的编码: %s",
+		LangEn: "unsupported encoding: %s",
+	},
+	CodeFileTooLarge: {
+		LangZh: "文件超过 %d MiB 上限",
+		LangEn: "file exceeds the %d MiB limit",
+	},
+	CodeFileSizeMismatch: {
+		LangZh: "文件大小不一致，声明 %d 字节，实际收到 %d 字节",
+		LangEn: "file size mismatch: declared %d bytes, received %d bytes",
+	},
+	CodeFileNoUpload: {
+		LangZh: "未选择上传文件",
+		LangEn: "no file selected for upload",
+	},
+	CodeFileUploadRead: {
+		LangZh: "获取上传文件错误: %v",
+		LangEn: "failed to read the uploaded file: %v",
+	},
+	CodeFileWorkloadRequired: {
+		LangZh: "工作负载名称、命名空间与路径不能为空",
+		LangEn: "workload name, namespace and path are required",
+	},
+	CodeFileWorkloadKind: {
+		LangZh: "不支持的工作负载类型: %s",
+		LangEn: "unsupported workload kind: %s",
+	},
+	CodeFileWorkloadNoPods: {
+		LangZh: "%s %s/%s 下没有 Pod",
+		LangEn: "%s %s/%s has no pods",
+	},
+	CodeFileCopyPodRequired: {
+		LangZh: "源与目标 Pod 不能为空",
+		LangEn: "source and target pods are required",
+	},
+	CodeFileObjectKeyRequired: {
+		LangZh: "路径与对象键不能为空",
+		LangEn: "path and object key are required",
+	},
+	CodeFileConfigKeyRequired: {
+		LangZh: "路径与键名不能为空",
+		LangEn: "path and key are required",
+	},
+	CodeFileWatchRequired: {
+		LangZh: "命名空间、Pod 与文件路径不能为空",
+		LangEn: "namespace, pod and file path are required",
+	},
+	CodeFileWatchNotFound: {
+		LangZh: "文件监视不存在",
+		LangEn: "file watch not found",
+	},
+	CodeFileStagingQuota: {
+		LangZh: "上传暂存空间不足：正在进行的上传已占用 %s，本次需要 %s，每个用户上限 %s，请等待其他上传完成后重试",
+		LangEn: "upload staging quota exceeded: %s in use by ongoing uploads, %s required, per-user limit %s; retry after other uploads finish",
+	},
+	CodeFilePreflightNotDir: {
+		LangZh: "目标路径 %s 已存在且不是目录",
+		LangEn: "target path %s exists and is not a directory",
+	},
+	CodeFilePreflightNotExist: {
+		LangZh: "目标目录 %s 不存在，请先创建或勾选自动创建目录",
+		LangEn: "target directory %s does not exist; create it first or enable createDir",
+	},
+	CodeFilePreflightMkdir: {
+		LangZh: "创建目标目录 %s 失败，请检查上级目录权限或是否为只读文件系统",
+		LangEn: "failed to create target directory %s; check parent directory permissions or read-only filesystems",
+	},
+	CodeFilePreflightReadOnly: {
+		LangZh: "目标目录 %s 不可写，可能是只读挂载或容器用户没有写权限",
+		LangEn: "target directory %s is not writable; it may be a read-only mount or the container user lacks write permission",
+	},
+	CodeFilePreflightNoSpace: {
+		LangZh: "目标目录 %s 剩余空间 %s，不足以写入 %s",
+		LangEn: "target directory %s has %s free, not enough to write %s",
+	},
+	CodeFileBatchPathsRequired: {
+		LangZh: "请选择要下载的文件",
+		LangEn: "no files selected for download",
+	},
+	CodeFileBatchTooMany: {
+		LangZh: "一次最多下载 %d 个路径",
+		LangEn: "at most %d paths can be downloaded at once",
+	},
+	CodeFileBatchAllFailed: {
+		LangZh: "所选文件均下载失败: %s",
+		LangEn: "all selected files failed to download: %s",
+	},
+	CodeCapabilityUnsupported: {
+		LangZh: "集群 %s 不支持 %s: %s",
+		LangEn: "cluster %s does not support %s: %s",
+	},
+	CodeCapabilityDisabled: {
+		LangZh: "集群 %s 已关闭 %s",
+		LangEn: "cluster %s has %s disabled",
+	},
+}
@@ -0,0 +1,148 @@
+// Package inbound 入站 webhook 的签名校验与请求体解析。
+// 签名为请求体的 HMAC-SHA256，格式为 sha256=<hex>，与 GitHub 的 X-Hub-Signature-256 兼容。
+// 发送方可额外携带 X-K8m-Timestamp（Unix 秒），此时签名内容为 "<timestamp>.<body>"，超出容忍窗口的请求被拒绝
+package inbound
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeaders 依次读取的签名请求头
+var SignatureHeaders = []string{"X-K8m-Signature-256", "X-Hub-Signature-256"}
+
+// TimestampHeader 可选的签名时间戳请求头，值为 Unix 秒
+const TimestampHeader = "X-K8m-Timestamp"
+
+// DeliveryHeaders 依次读取的投递标识请求头，同一标识只接受一次
+var DeliveryHeaders = []string{"X-K8m-Delivery", "X-GitHub-Delivery"}
+
+// TimestampTolerance 签名时间戳与服务器时间允许的偏差
+const TimestampTolerance = 5 * time.Minute
+
+const signaturePrefix = "sha256="
+
+// Sign 计算请求体签名
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignWithTimestamp 计算带时间戳的签名，签名内容为 "<timestamp>.<body>"
+func SignWithTimestamp(secret, timestamp string, body []byte) string {
+	return Sign(secret, append([]byte(timestamp+"."), body...))
+}
+
+// Verify 校验请求体签名。timestamp 不为空时按带时间戳的方式校验，并要求与 now 的偏差在 TimestampTolerance 之内
+func Verify(secret string, body []byte, signature, timestamp string, now time.Time) error {
+	if secret == "" {
+		return fmt.Errorf("webhook 未设置签名密钥")
+	}
+	if signature == "" {
+		return fmt.Errorf("缺少签名请求头 %s", SignatureHeaders[0])
+	}
+	expected := Sign(secret, body)
+	if timestamp = strings.TrimSpace(timestamp); timestamp != "" {
+		sec, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("请求头 %s 不是有效的 Unix 时间戳", TimestampHeader)
+		}
+		if d := now.Sub(time.Unix(sec, 0)); d > TimestampTolerance || d < -TimestampTolerance {
+			return fmt.Errorf("请求时间戳超出允许的 %s 偏差", TimestampTolerance)
+		}
+		expected = SignWithTimestamp(secret, timestamp, body)
+	}
+	if !hmac.Equal([]byte(strings.TrimSpace(signature)), []byte(expected)) {
+		return fmt.Errorf("签名校验失败")
+	}
+	return nil
+}
+
+// NewSecret 生成随机签名密钥
+func NewSecret() (string, error) {
+	return randomHex(32)
+}
+
+// NewKey 生成 webhook 地址中的随机标识
+func NewKey() (string, error) {
+	return randomHex(16)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Lookup 按以 . 分隔的路径读取请求体中的字符串值，数组使用下标，如 event_data.resources.0.resource_url
+func Lookup(payload any, fieldPath string) (string, bool) {
+	cur := payload
+	for _, key := range strings.Split(fieldPath, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			cur = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			cur = v[i]
+		default:
+			return "", false
+		}
+	}
+	switch v := cur.(type) {
+	case string:
+		return v, v != ""
+	case float64, bool:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
+
+// Repository 返回镜像去掉标签与摘要后的仓库地址
+func Repository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	// 最后一段中的冒号为标签，之前的冒号属于仓库端口
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// ResolveImage 由当前镜像与请求体给出的值得到新镜像。
+// 值不含 / 与 : 时视为标签，沿用当前镜像仓库；pattern 为空时只允许更换标签，否则新镜像须匹配 pattern（支持 * 通配）
+func ResolveImage(current, value, pattern string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("请求体中未找到镜像")
+	}
+	image := value
+	if !strings.ContainsAny(value, "/:@") {
+		image = Repository(current) + ":" + value
+	}
+	if pattern == "" {
+		if Repository(image) != Repository(current) {
+			return "", fmt.Errorf("镜像 %s 与当前镜像仓库 %s 不一致", image, Repository(current))
+		}
+		return image, nil
+	}
+	for _, p := range strings.Split(pattern, ",") {
+		if ok, _ := path.Match(strings.TrimSpace(p), image); ok {
+			return image, nil
+		}
+	}
+	return "", fmt.Errorf("镜像 %s 不在允许范围 %s 内", image, pattern)
+}
@@ -0,0 +1,105 @@
+package inbound
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"image":"nginx:1.27"}`)
+	now := time.Unix(1700000000, 0)
+	// 与 GitHub 文档中的计算方式一致：HMAC-SHA256(secret, body)
+	sig := Sign("s3cret", body)
+	if err := Verify("s3cret", body, sig, "", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify("s3cret", []byte(`{"image":"nginx:latest"}`), sig, "", now); err == nil {
+		t.Error("请求体被篡改时应校验失败")
+	}
+	if err := Verify("other", body, sig, "", now); err == nil {
+		t.Error("密钥不一致时应校验失败")
+	}
+	if err := Verify("s3cret", body, "", "", now); err == nil {
+		t.Error("缺少签名时应校验失败")
+	}
+	if err := Verify("", body, Sign("", body), "", now); err == nil {
+		t.Error("未设置密钥时应拒绝")
+	}
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	body := []byte(`{"image":"nginx:1.27"}`)
+	now := time.Unix(1700000000, 0)
+	ts := "1699999900"
+	sig := SignWithTimestamp("s3cret", ts, body)
+	if err := Verify("s3cret", body, sig, ts, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify("s3cret", body, sig, "1699999901", now); err == nil {
+		t.Error("时间戳被篡改时应校验失败")
+	}
+	if err := Verify("s3cret", body, Sign("s3cret", body), ts, now); err == nil {
+		t.Error("携带时间戳时签名应包含时间戳")
+	}
+	if err := Verify("s3cret", body, sig, ts, now.Add(TimestampTolerance+time.Minute)); err == nil {
+		t.Error("时间戳过旧时应拒绝")
+	}
+	future := "1700000600"
+	if err := Verify("s3cret", body, SignWithTimestamp("s3cret", future, body), future, now); err == nil {
+		t.Error("时间戳超前过多时应拒绝")
+	}
+	if err := Verify("s3cret", body, sig, "yesterday", now); err == nil {
+		t.Error("无效时间戳应拒绝")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	var payload any
+	_ = json.Unmarshal([]byte(`{
+		"type": "PUSH_ARTIFACT",
+		"event_data": {"resources": [{"tag": "v2", "resource_url": "harbor.local/app/web:v2"}]},
+		"push_data": {"tag": "v3", "pushed_at": 1700000000}
+	}`), &payload)
+
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"event_data.resources.0.resource_url", "harbor.local/app/web:v2", true},
+		{"push_data.tag", "v3", true},
+		{"push_data.pushed_at", "1.7e+09", true},
+		{"event_data.resources.1.tag", "", false},
+		{"event_data.resources", "", false},
+		{"missing", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Lookup(payload, tt.path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Lookup(%s) = %q %v, want %q %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestResolveImage(t *testing.T) {
+	tests := []struct {
+		current, value, pattern string
+		want                    string
+		wantErr                 bool
+	}{
+		{"harbor.local:5000/app/web:v1", "v2", "", "harbor.local:5000/app/web:v2", false},
+		{"harbor.local:5000/app/web:v1", "harbor.local:5000/app/web:v2", "", "harbor.local:5000/app/web:v2", false},
+		{"harbor.local:5000/app/web@sha256:abc", "v2", "", "harbor.local:5000/app/web:v2", false},
+		{"harbor.local:5000/app/web:v1", "evil.io/app/web:v2", "", "", true},
+		{"nginx:1.25", "registry.local/mirror/nginx:1.27", "registry.local/mirror/*", "registry.local/mirror/nginx:1.27", false},
+		{"nginx:1.25", "docker.io/library/nginx:1.27", "registry.local/mirror/*", "", true},
+		{"nginx:1.25", "", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveImage(tt.current, tt.value, tt.pattern)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ResolveImage(%s, %s, %s) = %q, %v", tt.current, tt.value, tt.pattern, got, err)
+		}
+	}
+}
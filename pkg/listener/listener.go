@@ -0,0 +1,311 @@
+// Package listener 管理 k8m 的 HTTP 监听，每个监听可以单独配置 TLS、客户端证书认证与可信代理头认证，
+// 便于 k8m 部署在企业网关之后，由网关完成登录而不必在 k8m 中再次登录。
+package listener
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/flag"
+	"k8s.io/klog/v2"
+)
+
+// 外部身份来源，同时作为自动创建用户的来源
+const (
+	SourceClientCert    = "x509"
+	SourceTrustedHeader = "proxy"
+)
+
+// DefaultTrustedHeader 可信代理头认证默认读取的用户名请求头
+const DefaultTrustedHeader = "X-Forwarded-User"
+
+// Config 单个监听的配置
+type Config struct {
+	Name string `json:"name,omitempty"`
+	Addr string `json:"addr"` // 监听地址，如 :3619
+
+	TLSCert string `json:"tls_cert,omitempty"` // 服务端证书文件，与 TLSKey 同时设置时启用 HTTPS
+	TLSKey  string `json:"tls_key,omitempty"`
+	// ClientCA 校验客户端证书的 CA 文件，设置后启用 mTLS
+	ClientCA string `json:"client_ca,omitempty"`
+	// ClientCertRequired 为 true 时拒绝未提供有效客户端证书的连接，否则客户端证书可选
+	ClientCertRequired bool `json:"client_cert_required,omitempty"`
+	// ClientCertAuth 为 true 时以已校验客户端证书的 CN 作为登录用户
+	ClientCertAuth bool `json:"client_cert_auth,omitempty"`
+
+	// TrustedHeader 可信代理传递用户名的请求头，配置了 TrustedProxies 时默认为 X-Forwarded-User
+	TrustedHeader string `json:"trusted_header,omitempty"`
+	// TrustedProxies 允许设置 TrustedHeader 的代理地址，IP 或 CIDR，为空不启用；其他来源的该请求头会被移除。
+	// 来自可信代理的请求按 X-Forwarded-For 识别客户端 IP
+	TrustedProxies []string `json:"trusted_proxies,omitempty"`
+	// CountryHeader 可信代理传递客户端国家/地区代码的请求头，如 CF-IPCountry，用于按地区限制访问
+	CountryHeader string `json:"country_header,omitempty"`
+
+	trustedNets []*net.IPNet
+}
+
+// Load 读取启动配置中的监听：主监听为 --host:--port，另有 --extra-listeners 中的附加监听
+func Load(cfg *flag.Config) ([]*Config, error) {
+	primary := &Config{
+		Name:               "main",
+		Addr:               fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		TLSCert:            cfg.TLSCertFile,
+		TLSKey:             cfg.TLSKeyFile,
+		ClientCA:           cfg.ClientCAFile,
+		ClientCertRequired: cfg.ClientCertRequired,
+		ClientCertAuth:     cfg.ClientCertAuth,
+		TrustedHeader:      cfg.TrustedHeader,
+		TrustedProxies:     splitList(cfg.TrustedProxies),
+		CountryHeader:      cfg.TrustedCountryHeader,
+	}
+	listeners := []*Config{primary}
+	if strings.TrimSpace(cfg.ExtraListeners) != "" {
+		var extra []*Config
+		if err := json.Unmarshal([]byte(cfg.ExtraListeners), &extra); err != nil {
+			return nil, fmt.Errorf("附加监听配置格式错误，应为 JSON 数组: %w", err)
+		}
+		for i, l := range extra {
+			if l.Name == "" {
+				l.Name = fmt.Sprintf("extra-%d", i+1)
+			}
+		}
+		listeners = append(listeners, extra...)
+	}
+	for _, l := range listeners {
+		if err := l.validate(); err != nil {
+			return nil, fmt.Errorf("监听 %s: %w", l.Name, err)
+		}
+	}
+	return listeners, nil
+}
+
+func (l *Config) validate() error {
+	if l.Addr == "" {
+		return fmt.Errorf("监听地址不能为空")
+	}
+	if (l.TLSCert == "") != (l.TLSKey == "") {
+		return fmt.Errorf("tls_cert 与 tls_key 须同时设置")
+	}
+	if l.ClientCA != "" && l.TLSCert == "" {
+		return fmt.Errorf("启用客户端证书校验须同时配置服务端证书")
+	}
+	if (l.ClientCertAuth || l.ClientCertRequired) && l.ClientCA == "" {
+		return fmt.Errorf("客户端证书认证须配置 client_ca")
+	}
+	if l.TrustedHeader == "" && len(l.TrustedProxies) > 0 {
+		l.TrustedHeader = DefaultTrustedHeader
+	}
+	if l.CountryHeader != "" && len(l.TrustedProxies) == 0 {
+		return fmt.Errorf("使用地区请求头须配置 trusted_proxies，否则任何客户端都可伪造地区")
+	}
+	if l.TrustedHeader != "" {
+		if len(l.TrustedProxies) == 0 {
+			return fmt.Errorf("启用可信代理头认证须配置 trusted_proxies，否则任何客户端都可伪造用户")
+		}
+		l.trustedNets = nil
+		for _, p := range l.TrustedProxies {
+			n, err := parseNet(p)
+			if err != nil {
+				return err
+			}
+			l.trustedNets = append(l.trustedNets, n)
+		}
+	}
+	return nil
+}
+
+// TLSConfig 生成监听的 TLS 配置，未启用 HTTPS 时返回 nil
+func (l *Config) TLSConfig() (*tls.Config, error) {
+	if l.TLSCert == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(l.TLSCert, l.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("加载服务端证书失败: %w", err)
+	}
+	tc := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if l.ClientCA != "" {
+		pem, err := os.ReadFile(l.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("读取客户端 CA 失败: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("客户端 CA 文件 %s 中没有有效证书", l.ClientCA)
+		}
+		tc.ClientCAs = pool
+		tc.ClientAuth = tls.VerifyClientCertIfGiven
+		if l.ClientCertRequired {
+			tc.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return tc, nil
+}
+
+// Serve 按配置启动全部监听，任一监听退出即返回其错误
+func Serve(listeners []*Config, handler http.Handler) error {
+	errCh := make(chan error, len(listeners))
+	for _, l := range listeners {
+		tc, err := l.TLSConfig()
+		if err != nil {
+			return fmt.Errorf("监听 %s: %w", l.Name, err)
+		}
+		srv := &http.Server{Addr: l.Addr, Handler: l.Wrap(handler), TLSConfig: tc}
+		go func(l *Config) {
+			scheme := "http"
+			if tc != nil {
+				scheme = "https"
+			}
+			klog.V(2).Infof("监听 %s 启动: %s://%s，客户端证书认证=%v，可信代理头=%q", l.Name, scheme, l.Addr, l.ClientCertAuth, l.TrustedHeader)
+			if tc != nil {
+				errCh <- fmt.Errorf("监听 %s: %w", l.Name, srv.ListenAndServeTLS("", ""))
+				return
+			}
+			errCh <- fmt.Errorf("监听 %s: %w", l.Name, srv.ListenAndServe())
+		}(l)
+	}
+	return <-errCh
+}
+
+type clientKey struct{}
+
+// client 监听识别出的请求来源
+type client struct {
+	ip      string
+	country string
+	user    string // 外部身份，为空表示需要 Token 认证
+	source  string
+}
+
+// Wrap 识别请求来源与外部身份：已校验的客户端证书 CN，或来自可信代理的用户名请求头。
+// 来自可信代理的请求按 X-Forwarded-For 识别客户端 IP；非可信来源携带的用户名、地区请求头会被移除，避免被后续处理误用
+func (l *Config) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := &client{ip: remoteIP(r)}
+		trusted := l.fromTrustedProxy(info.ip)
+		if trusted {
+			info.ip = l.forwardedFor(r, info.ip)
+		}
+		for _, h := range []string{l.TrustedHeader, l.CountryHeader} {
+			if h != "" && !trusted {
+				r.Header.Del(h)
+			}
+		}
+		if l.CountryHeader != "" {
+			info.country = strings.ToUpper(strings.TrimSpace(r.Header.Get(l.CountryHeader)))
+		}
+		if l.TrustedHeader != "" {
+			if name := strings.TrimSpace(r.Header.Get(l.TrustedHeader)); name != "" {
+				info.user, info.source = name, SourceTrustedHeader
+			}
+		}
+		if info.user == "" && l.ClientCertAuth && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			if cn := r.TLS.VerifiedChains[0][0].Subject.CommonName; cn != "" {
+				info.user, info.source = cn, SourceClientCert
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey{}, info)))
+	})
+}
+
+// ExternalUser 返回监听识别出的外部身份
+func ExternalUser(r *http.Request) (name, source string, ok bool) {
+	info, _ := r.Context().Value(clientKey{}).(*client)
+	if info == nil || info.user == "" {
+		return "", "", false
+	}
+	return info.user, info.source, true
+}
+
+// ClientIP 返回客户端 IP，经可信代理转发时取 X-Forwarded-For 中最后一个非可信代理的地址
+func ClientIP(r *http.Request) string {
+	if info, _ := r.Context().Value(clientKey{}).(*client); info != nil {
+		return info.ip
+	}
+	return remoteIP(r)
+}
+
+// Country 返回可信代理传递的客户端国家/地区代码，未配置或未传递时为空
+func Country(r *http.Request) string {
+	if info, _ := r.Context().Value(clientKey{}).(*client); info != nil {
+		return info.country
+	}
+	return ""
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// forwardedFor 从右向左跳过可信代理，返回第一个非可信地址；客户端自行添加的左侧地址不可信，不予采用
+func (l *Config) forwardedFor(r *http.Request, peer string) string {
+	var hops []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		if !l.fromTrustedProxy(hop) {
+			return hop
+		}
+		peer = hop
+	}
+	return peer
+}
+
+func (l *Config) fromTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range l.trustedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNet(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("可信代理地址 %s 格式错误", s)
+		}
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("可信代理地址 %s 格式错误: %w", s, err)
+	}
+	return n, nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
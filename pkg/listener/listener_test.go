@@ -0,0 +1,159 @@
+package listener
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weibaohui/k8m/pkg/flag"
+)
+
+func identify(t *testing.T, l *Config, r *http.Request) (string, string, bool, *http.Request) {
+	t.Helper()
+	var name, source string
+	var ok bool
+	var seen *http.Request
+	l.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, source, ok = ExternalUser(r)
+		seen = r
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	return name, source, ok, seen
+}
+
+func TestTrustedHeader(t *testing.T) {
+	l := &Config{Name: "test", Addr: ":0", TrustedProxies: []string{"10.0.0.0/8", "192.168.1.10"}}
+	if err := l.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if l.TrustedHeader != DefaultTrustedHeader {
+		t.Fatalf("默认请求头应为 %s", DefaultTrustedHeader)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.1.2.3:5555"
+	r.Header.Set("X-Forwarded-User", "alice")
+	if name, source, ok, _ := identify(t, l, r); !ok || name != "alice" || source != SourceTrustedHeader {
+		t.Fatalf("可信代理: got %q %q %v", name, source, ok)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "192.168.1.10:5555"
+	r.Header.Set("X-Forwarded-User", "bob")
+	if name, _, ok, _ := identify(t, l, r); !ok || name != "bob" {
+		t.Fatalf("单个 IP 的可信代理: got %q %v", name, ok)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "172.16.0.1:5555"
+	r.Header.Set("X-Forwarded-User", "mallory")
+	_, _, ok, seen := identify(t, l, r)
+	if ok {
+		t.Fatal("非可信来源不应识别用户")
+	}
+	if seen.Header.Get("X-Forwarded-User") != "" {
+		t.Fatal("非可信来源的用户名请求头应被移除")
+	}
+}
+
+func TestClientCert(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "carol"}}
+	newReq := func(verified bool) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		if verified {
+			r.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return r
+	}
+
+	l := &Config{ClientCertAuth: true}
+	if name, source, ok, _ := identify(t, l, newReq(true)); !ok || name != "carol" || source != SourceClientCert {
+		t.Fatalf("已校验证书: got %q %q %v", name, source, ok)
+	}
+	if _, _, ok, _ := identify(t, l, newReq(false)); ok {
+		t.Fatal("未校验的证书不应识别用户")
+	}
+	if _, _, ok, _ := identify(t, &Config{}, newReq(true)); ok {
+		t.Fatal("未启用客户端证书认证时不应识别用户")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		l       Config
+		wantErr bool
+	}{
+		{name: "普通 HTTP", l: Config{Addr: ":3618"}},
+		{name: "缺少地址", l: Config{}, wantErr: true},
+		{name: "证书不完整", l: Config{Addr: ":1", TLSCert: "a"}, wantErr: true},
+		{name: "CA 无服务端证书", l: Config{Addr: ":1", ClientCA: "ca"}, wantErr: true},
+		{name: "证书认证缺少 CA", l: Config{Addr: ":1", TLSCert: "a", TLSKey: "b", ClientCertAuth: true}, wantErr: true},
+		{name: "请求头缺少可信代理", l: Config{Addr: ":1", TrustedHeader: "X-User"}, wantErr: true},
+		{name: "可信代理格式错误", l: Config{Addr: ":1", TrustedProxies: []string{"bad"}}, wantErr: true},
+		{name: "mTLS", l: Config{Addr: ":1", TLSCert: "a", TLSKey: "b", ClientCA: "ca", ClientCertAuth: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.l.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cfg := &flag.Config{
+		Host:           "0.0.0.0",
+		Port:           3618,
+		TrustedProxies: "127.0.0.1",
+		ExtraListeners: `[{"addr":":3619","trusted_header":"X-Remote-User","trusted_proxies":["10.0.0.0/8"]}]`,
+	}
+	listeners, err := Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listeners) != 2 || listeners[0].Addr != "0.0.0.0:3618" || listeners[1].Name != "extra-1" || listeners[1].TrustedHeader != "X-Remote-User" {
+		t.Fatalf("unexpected listeners %+v %+v", listeners[0], listeners[1])
+	}
+	cfg.ExtraListeners = "{"
+	if _, err := Load(cfg); err == nil {
+		t.Fatal("格式错误应报错")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	l := &Config{Name: "test", Addr: ":0", TrustedProxies: []string{"10.0.0.0/8"}, CountryHeader: "CF-IPCountry"}
+	if err := l.validate(); err != nil {
+		t.Fatal(err)
+	}
+	source := func(r *http.Request) (ip, country string) {
+		l.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, country = ClientIP(r), Country(r)
+		})).ServeHTTP(httptest.NewRecorder(), r)
+		return
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.2:5555"
+	r.Header.Set("X-Forwarded-For", "1.1.1.1, 203.0.113.7, 10.0.0.9")
+	r.Header.Set("CF-IPCountry", "cn")
+	if ip, country := source(r); ip != "203.0.113.7" || country != "CN" {
+		t.Fatalf("可信代理: got %q %q", ip, country)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "172.16.0.1:5555"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7")
+	r.Header.Set("CF-IPCountry", "CN")
+	if ip, country := source(r); ip != "172.16.0.1" || country != "" {
+		t.Fatalf("非可信来源不应采用转发头: got %q %q", ip, country)
+	}
+
+	if err := (&Config{Addr: ":1", CountryHeader: "CF-IPCountry"}).validate(); err == nil {
+		t.Fatal("未配置可信代理时不应允许地区请求头")
+	}
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	chim "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"k8s.io/klog/v2"
+)
+
+// accessLogger 访问日志以 JSON 行输出到标准输出，便于日志采集系统解析
+var accessLogger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+// accessInfo 由后续中间件补充的访问信息（登录用户、集群），请求结束时写入访问日志
+type accessInfo struct {
+	User    string
+	Cluster string
+}
+
+type accessInfoKey struct{}
+
+// setAccessUser 记录当前请求的登录用户
+func setAccessUser(ctx context.Context, user string) {
+	if info, ok := ctx.Value(accessInfoKey{}).(*accessInfo); ok {
+		info.User = user
+	}
+}
+
+// setAccessCluster 记录当前请求的集群
+func setAccessCluster(ctx context.Context, cluster string) {
+	if info, ok := ctx.Value(accessInfoKey{}).(*accessInfo); ok {
+		info.Cluster = cluster
+	}
+}
+
+// AccessLogMiddleware 为每个请求分配请求ID并记录结构化访问日志。
+// 请求ID优先沿用客户端传入的 X-Request-ID，写回响应头，并注入到上下文中的 klog Logger，
+// 使用 klog.FromContext 输出的日志会自动携带 requestID。
+func AccessLogMiddleware(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(response.RequestIDHeader)
+			if id == "" || len(id) > 128 {
+				id = uuid.NewString()
+			}
+			w.Header().Set(response.RequestIDHeader, id)
+
+			info := &accessInfo{}
+			ctx := response.WithRequestID(r.Context(), id)
+			ctx = context.WithValue(ctx, accessInfoKey{}, info)
+			ctx = klog.NewContext(ctx, klog.LoggerWithValues(klog.Background(), "requestID", id))
+			r = r.WithContext(ctx)
+
+			if !enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ww := chim.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			accessLogger.LogAttrs(r.Context(), slog.LevelInfo, "access",
+				slog.String("request_id", id),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", telemetry.RoutePattern(r)),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Int64("latency_ms", time.Since(start).Milliseconds()),
+				slog.String("user", info.User),
+				slog.String("cluster", info.Cluster),
+				slog.String("remote_ip", r.RemoteAddr),
+			)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/accessrule"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/listener"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"k8s.io/klog/v2"
+)
+
+// AccessRuleMiddleware 按访问规则校验已登录用户的来源 IP、地区与时间段，在控制器处理之前拒绝不满足的请求
+func AccessRuleMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, _ := r.Context().Value(constants.JwtUserName).(string)
+			if username == "" || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cluster, _ := r.Context().Value("cluster").(string)
+			req := &service.AccessRequest{
+				Username:   username,
+				Cluster:    cluster,
+				IP:         listener.ClientIP(r),
+				Country:    listener.Country(r),
+				Operations: accessrule.Classify(r.Method, r.URL.Path),
+				Time:       time.Now(),
+			}
+			if err := service.AccessRuleService().Check(req); err != nil {
+				klog.V(6).Infof("访问规则拒绝请求 %s %s: %v", r.Method, r.URL.Path, err)
+				service.AccessRuleService().RecordViolation(req, r.Method, r.URL.Path, err)
+				amis.WriteError(response.New(w, r), http.StatusForbidden, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// APIVersionPrefix 版本化接口前缀
+const APIVersionPrefix = "/api/v1"
+
+// APIVersionMiddleware 返回版本化前缀的兼容中间件。
+// /api/v1/xxx 的请求去掉前缀后按原有路由处理，新旧路径访问的是同一套接口；
+// 需放在所有依赖请求路径的中间件之前。
+func APIVersionMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := r.URL.Path
+			if path == APIVersionPrefix || strings.HasPrefix(path, APIVersionPrefix+"/") {
+				trimmed := strings.TrimPrefix(path, APIVersionPrefix)
+				if trimmed == "" {
+					trimmed = "/"
+				}
+				r2 := r.Clone(r.Context())
+				r2.URL.Path = trimmed
+				if r.URL.RawPath != "" {
+					r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, APIVersionPrefix)
+				}
+				r2.RequestURI = r2.URL.RequestURI()
+				w.Header().Set("X-API-Version", "v1")
+				next.ServeHTTP(w, r2)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/constants"
 	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/listener"
 	"github.com/weibaohui/k8m/pkg/response"
 	"github.com/weibaohui/k8m/pkg/service"
 )
@@ -24,13 +25,18 @@ func AuthMiddleware() func(http.Handler) http.Handler {
 			if path == "/" ||
 				path == "/favicon.ico" ||
 				path == "/ping" ||
+				path == "/metrics" ||
 				path == "/healthz" ||
 				strings.HasPrefix(path, "/monacoeditorwork/") ||
+				path == "/api/openapi.json" ||
 				strings.HasPrefix(path, "/swagger/") ||
 				strings.HasPrefix(path, "/debug/") ||
 				strings.HasPrefix(path, "/health/") ||
 				strings.HasPrefix(path, "/mcp/") ||
 				strings.HasPrefix(path, "/auth/") ||
+				strings.HasPrefix(path, "/agent/") || // agent 隧道使用签发的 agent 令牌认证
+				path == "/dav" || strings.HasPrefix(path, "/dav/") || // WebDAV 网关自行认证，集群在路径中
+				strings.HasPrefix(path, "/hooks/") || // 入站 webhook 使用请求体签名认证
 				strings.HasPrefix(path, "/assets/") ||
 				strings.HasPrefix(path, "/public/") {
 				next.ServeHTTP(w, r)
@@ -38,33 +44,71 @@ func AuthMiddleware() func(http.Handler) http.Handler {
 
 			}
 
+			// 监听已通过客户端证书或可信代理头识别出用户，无需 Token
+			if username, source, ok := listener.ExternalUser(r); ok {
+				if err := service.UserService().EnsureExternalUser(username, source); err != nil {
+					c.JSON(http.StatusUnauthorized, response.H{"message": err.Error()})
+					return
+				}
+				ctx := context.WithValue(r.Context(), constants.JwtUserName, username)
+				setAccessUser(ctx, username)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			cfg := flag.Init()
-			claims, err := utils.GetJWTClaims(c, cfg.JwtTokenSecret)
+			claims, err := utils.GetJWTClaims(c, cfg.JwtTokenSecret, cfg.JwtLeeway())
 			if err != nil {
 				c.JSON(http.StatusUnauthorized, response.H{"message": err.Error()})
 				return
 			}
 
+			if username, ok := claims[constants.JwtUserName].(string); ok {
+				// 修改密码或被强制轮换后，此前签发的登录 Token 失效
+				if iat, ok := claims["iat"].(float64); ok && service.UserService().TokenRevoked(username, int64(iat)) {
+					c.JSON(http.StatusUnauthorized, response.H{"message": "登录已失效，请重新登录"})
+					return
+				}
+				if must, _ := claims[constants.JwtMustChangePassword].(bool); must && !passwordChangeAllowed(path) {
+					c.JSON(http.StatusForbidden, response.H{"message": "密码已过期或被要求修改，请先修改密码"})
+					return
+				}
+			}
+
 			// 设置信息传递，后面才能从ctx中获取到用户信息
 			ctx := context.WithValue(r.Context(), constants.JwtUserName, claims[constants.JwtUserName])
+			if username, ok := claims[constants.JwtUserName].(string); ok {
+				setAccessUser(ctx, username)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// passwordChangeAllowed 需要先修改密码的 Token 可以访问的路径：修改密码、查看个人信息与密码策略、前端基础参数
+func passwordChangeAllowed(path string) bool {
+	return path == "/mgm/user/profile" ||
+		path == "/mgm/user/profile/update_psw" ||
+		path == "/mgm/user/profile/password_policy" ||
+		strings.HasPrefix(path, "/params/")
+}
+
 // PlatformAuthMiddleware 平台管理员角色校验 - Gin到Chi迁移
 func PlatformAuthMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			c := response.New(w, r)
-			cfg := flag.Init()
-			claims, err := utils.GetJWTClaims(c, cfg.JwtTokenSecret)
-			if err != nil {
-				c.JSON(http.StatusUnauthorized, response.H{"message": err.Error()})
-				return
+			// AuthMiddleware 已完成认证（Token 或外部身份），直接使用其识别出的用户
+			username, ok := r.Context().Value(constants.JwtUserName).(string)
+			if !ok || username == "" {
+				cfg := flag.Init()
+				claims, err := utils.GetJWTClaims(c, cfg.JwtTokenSecret, cfg.JwtLeeway())
+				if err != nil {
+					c.JSON(http.StatusUnauthorized, response.H{"message": err.Error()})
+					return
+				}
+				username, ok = claims[constants.JwtUserName].(string)
 			}
-
-			username, ok := claims[constants.JwtUserName].(string)
 			if !ok || username == "" {
 				c.JSON(http.StatusUnauthorized, response.H{"error": "无效的用户名"})
 				return
@@ -81,7 +125,7 @@ func PlatformAuthMiddleware() func(http.Handler) http.Handler {
 			}
 
 			// 设置信息传递，后面才能从ctx中获取到用户信息
-			ctx := context.WithValue(r.Context(), constants.JwtUserName, claims[constants.JwtUserName])
+			ctx := context.WithValue(r.Context(), constants.JwtUserName, username)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
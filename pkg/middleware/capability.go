@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/weibaohui/k8m/pkg/capability"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"k8s.io/klog/v2"
+)
+
+// CapabilityMiddleware 集群未安装或管理员已关闭接口依赖的功能时，直接返回明确的提示，而不是集群返回的原始错误
+func CapabilityMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			names := capability.ForPath(r.URL.Path)
+			if len(names) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cluster, _ := r.Context().Value("cluster").(string)
+			for _, name := range names {
+				if err := service.CapabilityService().Check(cluster, name); err != nil {
+					klog.V(6).Infof("集群 %s 功能 %s 不可用，拒绝请求 %s %s", cluster, name, r.Method, r.URL.Path)
+					amis.WriteError(response.New(w, r), http.StatusOK, err)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
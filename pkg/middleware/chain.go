@@ -0,0 +1,15 @@
+package middleware
+
+import "net/http"
+
+// RequestChain 按顺序返回登录、集群、访问规则、只读模式与功能开关校验中间件，
+// main 注册路由与路由级测试共用，保证测试经过的中间件与线上一致
+func RequestChain() []func(http.Handler) http.Handler {
+	return []func(http.Handler) http.Handler{
+		AuthMiddleware(),
+		EnsureSelectedClusterMiddleware(),
+		AccessRuleMiddleware(),
+		ReadOnlyMiddleware(),
+		CapabilityMiddleware(),
+	}
+}
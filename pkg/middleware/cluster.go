@@ -43,12 +43,17 @@ func EnsureSelectedClusterMiddleware() func(http.Handler) http.Handler {
 				path == "/favicon.ico" ||
 				path == "/healthz" ||
 				path == "/ping" ||
+				path == "/metrics" ||
 				strings.HasPrefix(path, "/health/") ||
 				strings.HasPrefix(path, "/monacoeditorwork/") ||
+				path == "/api/openapi.json" ||
 				strings.HasPrefix(path, "/swagger/") ||
 				strings.HasPrefix(path, "/debug/") ||
 				strings.HasPrefix(path, "/mcp/") ||
 				strings.HasPrefix(path, "/auth/") ||
+				strings.HasPrefix(path, "/agent/") || // agent 隧道使用签发的 agent 令牌认证
+				path == "/dav" || strings.HasPrefix(path, "/dav/") || // WebDAV 网关自行认证，集群在路径中
+				strings.HasPrefix(path, "/hooks/") || // 入站 webhook 的集群来自 webhook 配置
 				strings.HasPrefix(path, "/assets/") ||
 				strings.HasPrefix(path, "/ai/") || // ai 聊天不带cluster
 				strings.HasPrefix(path, "/params/") || // 配置参数
@@ -107,6 +112,7 @@ func EnsureSelectedClusterMiddleware() func(http.Handler) http.Handler {
 			}
 			// 使用 context.WithValue 设置集群信息
 			ctx := context.WithValue(r.Context(), "cluster", clusterID)
+			setAccessCluster(ctx, clusterID)
 
 			// 继续处理下一个中间件或最终路由
 			next.ServeHTTP(w, r.WithContext(ctx))
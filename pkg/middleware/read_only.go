@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
+	"k8s.io/klog/v2"
+)
+
+// readOnlyBlockedPaths 只读模式下提前拒绝的集群接口。
+// 变更最终由 kom 回调拦截，这里用于在读取上传内容、批量处理之前直接返回明确的提示
+var readOnlyBlockedPaths = regexp.MustCompile(`^/k8s/cluster/[^/]+(/.*)?/(` +
+	`update[^/]*|remove|batch/remove|force_remove|create|import|apply|` +
+	`restart|restore|stop|pause|resume|rollout/undo|scale/replica/[^/]+|batch_update_images|` +
+	`drain|cordon|uncordon|[a-z_]*taints|set_default|exec-command|create_[a-z]+_shell|` +
+	`file/(save|delete|upload(/batch|/workload)?|copy|to_config|from_config|s3/pull|trash/restore/[^/]+)` +
+	`)(/.*)?$`)
+
+// ReadOnlyMiddleware 全局或当前集群处于只读模式时，拒绝已知的变更接口
+func ReadOnlyMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions ||
+				!readOnlyBlockedPaths.MatchString(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cluster, _ := r.Context().Value("cluster").(string)
+			if err := service.ReadOnlyService().Check(cluster); err != nil {
+				klog.V(6).Infof("只读模式拒绝请求 %s %s", r.Method, r.URL.Path)
+				amis.WriteError(response.New(w, r), http.StatusOK, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
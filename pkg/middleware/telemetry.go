@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	chim "github.com/go-chi/chi/v5/middleware"
+	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TelemetryMiddleware 为每个 API 请求创建 OpenTelemetry Span，并记录请求数、耗时等 Prometheus 指标。
+// 路由模板在请求处理完成后才能从 chi 上下文中获取，因此 Span 名称与指标标签在结束时设置。
+func TelemetryMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/metrics" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := telemetry.Tracer().Start(ctx, r.Method+" "+r.URL.Path,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+				))
+			defer span.End()
+
+			ww := chim.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			r = r.WithContext(ctx)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := telemetry.RoutePattern(r)
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(
+				attribute.String("k8m.request_id", response.RequestID(r.Context())),
+				attribute.String("http.route", route),
+				attribute.Int("http.response.status_code", status),
+			)
+			if status >= 500 {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			telemetry.ObserveHTTPRequest(r.Method, route, status, time.Since(start))
+		})
+	}
+}
+
+// MetricsTokenAuth 校验 /metrics 请求的 Bearer 令牌。该端点不经过登录校验，token 为空时不限制
+func MetricsTokenAuth(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if token == "" {
+			return next
+		}
+		expected := []byte("Bearer " + token)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
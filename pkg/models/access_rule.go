@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// AccessRule 访问规则，限制指定角色执行指定操作时的来源 IP、地区与时间段。
+// 适用于同一请求的多条规则须全部满足；列表字段以逗号分隔。
+type AccessRule struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string    `gorm:"size:100" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Roles       string    `gorm:"size:255" json:"roles,omitempty"`      // 平台或集群角色，为空或 * 表示全部用户
+	Operations  string    `gorm:"size:255" json:"operations,omitempty"` // exec、file_delete、file_write、delete、node、write、admin，为空表示全部请求
+	Clusters    string    `gorm:"size:1024" json:"clusters,omitempty"`  // 逗号分隔，支持 * 通配，为空表示全部
+	CIDRs       string    `gorm:"column:cidrs;type:text" json:"cidrs,omitempty"`
+	Countries   string    `gorm:"size:255" json:"countries,omitempty"` // 国家/地区代码，需监听配置可信代理地区请求头
+	Days        string    `gorm:"size:50" json:"days,omitempty"`       // 0-6，0 为周日，支持 1-5 区间
+	StartTime   string    `gorm:"size:5" json:"start_time,omitempty"`  // HH:MM
+	EndTime     string    `gorm:"size:5" json:"end_time,omitempty"`    // HH:MM，早于开始时间表示跨越午夜
+	Timezone    string    `gorm:"size:64" json:"timezone,omitempty"`   // 为空使用服务器时区
+	Enabled     bool      `json:"enabled"`
+	CreatedBy   string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *AccessRule) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*AccessRule, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *AccessRule) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *AccessRule) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *AccessRule) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*AccessRule, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
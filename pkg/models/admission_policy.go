@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// AdmissionPolicy 平台侧准入策略，在经由 k8m 的创建、更新、删除操作执行前评估，
+// 与集群内的准入 Webhook 相互独立。
+// Operations、Kinds、Clusters、Namespaces 为逗号分隔的列表，支持 * 通配，为空表示全部。
+type AdmissionPolicy struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string    `gorm:"size:100" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Expression  string    `gorm:"type:text" json:"expression"`           // 返回 true 表示命中，如 any(images(object), {# endsWith ":latest"})
+	Message     string    `gorm:"type:text" json:"message,omitempty"`    // 命中时返回给用户的提示
+	Action      string    `gorm:"size:10" json:"action"`                 // deny 或 warn
+	Operations  string    `gorm:"size:100" json:"operations,omitempty"`  // create,update,patch,delete
+	Kinds       string    `gorm:"size:1024" json:"kinds,omitempty"`      // 如 Deployment,StatefulSet
+	Clusters    string    `gorm:"size:1024" json:"clusters,omitempty"`   // 集群ID
+	Namespaces  string    `gorm:"size:1024" json:"namespaces,omitempty"` // 如 prod-*
+	Enabled     bool      `json:"enabled"`
+	CreatedBy   string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (a *AdmissionPolicy) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*AdmissionPolicy, int64, error) {
+	return dao.GenericQuery(params, a, queryFuncs...)
+}
+
+func (a *AdmissionPolicy) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, a, queryFuncs...)
+}
+
+func (a *AdmissionPolicy) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, a, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (a *AdmissionPolicy) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*AdmissionPolicy, error) {
+	return dao.GenericGetOne(params, a, queryFuncs...)
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// ApprovalRule 变更审批规则，命中规则的变更操作需由另一名审批人批准后才会执行
+type ApprovalRule struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string    `gorm:"size:100" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Clusters    string    `gorm:"size:1024" json:"clusters,omitempty"`  // 逗号分隔，支持 * 通配，为空表示全部
+	Namespaces  string    `gorm:"size:1024" json:"namespaces"`          // 受保护的命名空间，逗号分隔，支持 * 通配
+	Kinds       string    `gorm:"size:1024" json:"kinds,omitempty"`     // 逗号分隔，为空表示全部
+	Operations  string    `gorm:"size:100" json:"operations,omitempty"` // create,update,patch,delete，为空表示全部
+	Approvers   string    `gorm:"size:1024" json:"approvers,omitempty"` // 审批人用户名，逗号分隔，为空时由平台管理员或该集群的集群管理员审批
+	Webhooks    string    `gorm:"type:text" json:"webhooks,omitempty"`  // 通知的 webhook 接收者 ID，逗号分隔
+	Enabled     bool      `json:"enabled"`
+	CreatedBy   string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (a *ApprovalRule) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ApprovalRule, int64, error) {
+	return dao.GenericQuery(params, a, queryFuncs...)
+}
+
+func (a *ApprovalRule) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, a, queryFuncs...)
+}
+
+func (a *ApprovalRule) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, a, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (a *ApprovalRule) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ApprovalRule, error) {
+	return dao.GenericGetOne(params, a, queryFuncs...)
+}
+
+// ChangeRequest 待审批的变更申请，保存完整的操作内容，批准后以申请人身份执行
+type ChangeRequest struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	RuleID        uint       `gorm:"index" json:"rule_id"`
+	Cluster       string     `gorm:"size:255;index" json:"cluster"`
+	Group         string     `gorm:"size:255" json:"group,omitempty"`
+	Version       string     `gorm:"size:50" json:"version"`
+	Kind          string     `gorm:"size:255" json:"kind"`
+	Namespace     string     `gorm:"size:255" json:"namespace,omitempty"`
+	Name          string     `gorm:"size:255" json:"name"`
+	Operation     string     `gorm:"size:20" json:"operation"`              // create、update、patch 或 delete
+	PatchType     string     `gorm:"size:100" json:"patch_type,omitempty"`  // patch 操作的补丁类型
+	Payload       string     `gorm:"type:text" json:"payload,omitempty"`    // create、update 为对象 JSON，patch 为补丁内容
+	Force         bool       `json:"force,omitempty"`                       // 强制删除
+	Diff          string     `gorm:"type:text" json:"diff,omitempty"`       // 与提交时集群中对象的字段差异，JSON 数组
+	Status        string     `gorm:"size:20;index" json:"status"`           // pending、approved、rejected、cancelled、applied 或 failed
+	Message       string     `gorm:"type:text" json:"message,omitempty"`    // 执行结果
+	RequestedBy   string     `gorm:"size:100;index" json:"requested_by"`    // 申请人，批准后以该用户身份执行
+	ReviewedBy    string     `gorm:"size:100" json:"reviewed_by,omitempty"` // 审批人
+	ReviewComment string     `gorm:"type:text" json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt     time.Time  `json:"updated_at,omitempty"`
+}
+
+func (c *ChangeRequest) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ChangeRequest, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *ChangeRequest) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ChangeRequest, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
+
+// BeforeSave Secret 的变更内容加密存储
+func (c *ChangeRequest) BeforeSave(tx *gorm.DB) error {
+	if c.Kind == "Secret" && c.Payload != "" {
+		encrypted, err := encryptField(c.Payload)
+		if err != nil {
+			return err
+		}
+		c.Payload = encrypted
+	}
+	return nil
+}
+
+// AfterFind 在查询后解密 Secret 的变更内容
+func (c *ChangeRequest) AfterFind(tx *gorm.DB) error {
+	if c.Kind == "Secret" && c.Payload != "" {
+		decrypted, err := decryptField(c.Payload)
+		if err != nil {
+			return err
+		}
+		c.Payload = decrypted
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// ArtifactPolicy 制品存储中某一类制品的保留策略，均为 0 表示不自动清理
+type ArtifactPolicy struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Category      string    `gorm:"size:100;uniqueIndex" json:"category"` // 制品类别，即对象 key 的第一段，如 support-bundle
+	RetentionDays int       `json:"retention_days"`                       // 超过天数的制品自动删除
+	MaxCount      int       `json:"max_count"`                            // 只保留最新的 N 个
+	MaxSizeMB     int       `json:"max_size_mb"`                          // 总大小上限（MiB），超过时从最旧的开始删除
+	CreatedBy     string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *ArtifactPolicy) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ArtifactPolicy, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *ArtifactPolicy) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *ArtifactPolicy) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *ArtifactPolicy) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ArtifactPolicy, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
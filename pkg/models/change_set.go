@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// ChangeSet 变更集，将一组清单作为整体在指定时间按依赖顺序应用，任一资源失败时整体回滚。
+// 以创建人身份执行，仍需通过只读模式、权限、准入策略与变更审批校验。
+type ChangeSet struct {
+	ID          uint             `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string           `gorm:"size:100" json:"name"`
+	Description string           `gorm:"type:text" json:"description,omitempty"`
+	Cluster     string           `gorm:"size:255;index" json:"cluster"`
+	Manifests   string           `gorm:"type:text" json:"manifests,omitempty"`  // 多文档YAML
+	ScheduledAt *time.Time       `gorm:"index" json:"scheduled_at,omitempty"`   // 计划应用时间，为空时需手动应用
+	Status      string           `gorm:"size:20;index" json:"status,omitempty"` // scheduled、running、applied、rolled_back、failed 或 cancelled
+	Message     string           `gorm:"type:text" json:"message,omitempty"`
+	Results     []*ChangeSetItem `gorm:"type:text;serializer:json" json:"results,omitempty"` // 最近一次执行中每个资源的结果
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	FinishedAt  *time.Time       `json:"finished_at,omitempty"`
+	CreatedBy   string           `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time        `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time        `json:"updated_at,omitempty"`
+}
+
+// ChangeSetItem 变更集中单个资源的执行结果
+type ChangeSetItem struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Action     string `json:"action,omitempty"` // created、updated，预演时为 create、update
+	Status     string `json:"status"`           // valid、invalid、applied、failed、skipped 或 rolled_back
+	Message    string `json:"message,omitempty"`
+}
+
+func (c *ChangeSet) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ChangeSet, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *ChangeSet) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *ChangeSet) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *ChangeSet) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ChangeSet, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
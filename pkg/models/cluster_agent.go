@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// ClusterAgent 集群 agent 接入凭据。每个 agent 使用单独签发的令牌，令牌与集群名称绑定，
+// agent 只能以签发时登记的名称接入，无法冒充其他集群。令牌只保存摘要，仅在签发与重置时返回一次。
+type ClusterAgent struct {
+	ID              uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name            string     `gorm:"size:63;uniqueIndex" json:"name"` // 集群名称，k8m 中显示为 Agent/<Name>
+	Description     string     `gorm:"type:text" json:"description,omitempty"`
+	TokenHash       string     `gorm:"size:64;uniqueIndex" json:"-"`
+	Enabled         bool       `json:"enabled"`
+	Online          bool       `gorm:"-" json:"online"` // 隧道是否在线，列表查询时填充
+	LastConnectedAt *time.Time `json:"last_connected_at,omitempty"`
+	LastAddr        string     `gorm:"size:255" json:"last_addr,omitempty"` // 最近一次接入的来源地址
+	CreatedBy       string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt       time.Time  `json:"updated_at,omitempty"`
+}
+
+func (c *ClusterAgent) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ClusterAgent, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *ClusterAgent) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *ClusterAgent) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *ClusterAgent) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ClusterAgent, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
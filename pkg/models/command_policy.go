@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// CommandPolicy 容器命令策略，作用于交互式终端、一次性命令接口与文件管理。
+// deny 策略命中即阻止；某范围内存在启用的 allow 策略时，该范围的命令必须命中其中之一。
+type CommandPolicy struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string    `gorm:"size:100" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Pattern     string    `gorm:"type:text" json:"pattern"`            // 正则表达式，匹配完整命令行的任意部分
+	Action      string    `gorm:"size:10" json:"action"`               // deny 或 allow
+	Scopes      string    `gorm:"size:100" json:"scopes,omitempty"`    // terminal,exec,file，为空表示全部
+	Clusters    string    `gorm:"size:1024" json:"clusters,omitempty"` // 逗号分隔，支持 * 通配，为空表示全部
+	Enabled     bool      `json:"enabled"`
+	CreatedBy   string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *CommandPolicy) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*CommandPolicy, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *CommandPolicy) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *CommandPolicy) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *CommandPolicy) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*CommandPolicy, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
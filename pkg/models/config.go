@@ -9,17 +9,47 @@ import (
 )
 
 type Config struct {
-	ID                   uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
-	ProductName          string    `json:"product_name,omitempty"` // 产品名称
-	LoginType            string    `json:"login_type,omitempty"`
-	JwtTokenSecret       string    `json:"jwt_token_secret,omitempty"`
-	NodeShellImage       string    `json:"node_shell_image,omitempty"`
-	KubectlShellImage    string    `json:"kubectl_shell_image,omitempty"`
-	ImagePullTimeout     int       `gorm:"default:30" json:"image_pull_timeout,omitempty"` // 镜像拉取超时时间（秒）
-	PrintConfig          bool      `json:"print_config"`
-	ResourceCacheTimeout int       `gorm:"default:60" json:"resource_cache_timeout,omitempty"` // 资源缓存时间（秒）
-	CreatedAt            time.Time `json:"created_at,omitempty" gorm:"<-:create"`
-	UpdatedAt            time.Time `json:"updated_at,omitempty"` // Automatically managed by GORM for update time
+	ID                   uint   `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	ProductName          string `json:"product_name,omitempty"` // 产品名称
+	LoginType            string `json:"login_type,omitempty"`
+	JwtTokenSecret       string `json:"jwt_token_secret,omitempty"`
+	NodeShellImage       string `json:"node_shell_image,omitempty"`
+	KubectlShellImage    string `json:"kubectl_shell_image,omitempty"`
+	ImagePullTimeout     int    `gorm:"default:30" json:"image_pull_timeout,omitempty"` // 镜像拉取超时时间（秒）
+	PrintConfig          bool   `json:"print_config"`
+	ResourceCacheTimeout int    `gorm:"default:60" json:"resource_cache_timeout,omitempty"` // 资源缓存时间（秒）
+	// 命令执行接口的允许列表，每行一个命令模式，* 匹配任意字符，为空表示不限制；平台管理员不受限制
+	ExecAllowlistPodExec      string `gorm:"type:text" json:"exec_allowlist_pod_exec,omitempty"`      // 仅具备 Exec 权限的用户
+	ExecAllowlistClusterAdmin string `gorm:"type:text" json:"exec_allowlist_cluster_admin,omitempty"` // 集群管理员
+	FileUploadConcurrency     int    `gorm:"default:5" json:"file_upload_concurrency,omitempty"`      // 批量上传默认并发数
+	UploadStagingQuotaMB      int    `gorm:"default:1024" json:"upload_staging_quota_mb"`             // 每个用户同时进行的上传在 k8m 本地暂存的空间上限（MiB），0 为不限制
+	FileUploadRetries         int    `gorm:"default:2" json:"file_upload_retries"`                    // 批量上传单个文件遇到网络类错误时的重试次数
+	// Pod 诊断包默认收集的容器内文件，每行一个绝对路径匹配模式，如 /var/log/*.log
+	DiagnosticsFileGlobs string `gorm:"type:text" json:"diagnostics_file_globs,omitempty"`
+	// 本地账户密码策略，均为 0 表示不限制
+	PasswordMinLength  int `json:"password_min_length"`   // 密码最小长度
+	PasswordMinClasses int `json:"password_min_classes"`  // 至少包含的字符种类数（大写、小写、数字、符号），1-4
+	PasswordMaxAgeDays int `json:"password_max_age_days"` // 密码有效期（天），过期后登录须先修改密码
+	PasswordHistory    int `json:"password_history"`      // 不能与最近 N 次使用过的密码相同
+	// 时钟偏差容忍与检查
+	TOTPSkew           int    `gorm:"default:1" json:"totp_skew"`             // 2FA 验证码前后各容忍的周期数（每周期 30 秒）
+	JwtLeewaySeconds   int    `json:"jwt_leeway_seconds"`                     // 校验 Token 有效期时容忍的时钟偏差（秒）
+	ClockSkewThreshold int    `gorm:"default:30" json:"clock_skew_threshold"` // 与集群、NTP 时间相差超过该秒数时告警
+	NTPServer          string `json:"ntp_server,omitempty"`                   // 时钟检查使用的 NTP 服务器，为空不检查
+	EnableGraphQL      bool   `json:"enable_graphql"`                         // 开启集群 GraphQL 组合查询接口
+	// S3 兼容对象存储，用于容器文件与存储桶之间直接传输
+	S3Endpoint  string `json:"s3_endpoint,omitempty"`
+	S3Region    string `json:"s3_region,omitempty"`
+	S3Bucket    string `json:"s3_bucket,omitempty"`
+	S3AccessKey string `json:"s3_access_key,omitempty"`
+	S3SecretKey string `json:"s3_secret_key,omitempty"`
+	S3PathStyle bool   `json:"s3_path_style"` // MinIO 等自建存储通常需要开启
+	// 制品存储，保存支持包、备份等 k8m 生成的文件
+	ArtifactDriver   string    `gorm:"default:local" json:"artifact_driver,omitempty"` // local 或 s3，s3 使用上面的对象存储配置
+	ArtifactLocalDir string    `json:"artifact_local_dir,omitempty"`                   // 本地存储目录，为空时使用数据库文件所在目录下的 artifacts
+	ArtifactS3Prefix string    `json:"artifact_s3_prefix,omitempty"`                   // 存储桶中的 key 前缀，为空时使用 k8m-artifacts/
+	CreatedAt        time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt        time.Time `json:"updated_at,omitempty"` // Automatically managed by GORM for update time
 }
 
 func (c *Config) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*Config, int64, error) {
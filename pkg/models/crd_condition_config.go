@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// CRDConditionConfig 自定义资源状态看板配置，按 CRD 的 group 与 kind 生效，对所有集群通用
+type CRDConditionConfig struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Group     string    `gorm:"size:255;uniqueIndex:idx_crd_condition_gk" json:"group"`
+	Kind      string    `gorm:"size:255;uniqueIndex:idx_crd_condition_gk" json:"kind"`
+	Types     string    `gorm:"type:text" json:"types,omitempty"`  // 展示的条件类型，逗号分隔，为空时展示全部
+	Primary   string    `gorm:"size:255" json:"primary,omitempty"` // 判断对象是否健康的主条件，默认 Ready
+	Fields    string    `gorm:"type:text" json:"fields,omitempty"` // 额外统计的状态字段路径，逗号分隔，如 status.health.status
+	CreatedBy string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *CRDConditionConfig) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*CRDConditionConfig, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *CRDConditionConfig) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *CRDConditionConfig) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *CRDConditionConfig) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*CRDConditionConfig, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
@@ -10,15 +10,19 @@ import (
 
 // CustomTemplate 表示用户自定义模板表的结构体
 type CustomTemplate struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"` // 模板 ID，主键，自增
-	Name      string    `gorm:"index" json:"name,omitempty"`                  // 模板名称，非空，最大长度 255
-	Content   string    `gorm:"type:text" json:"content,omitempty"`           // 模板内容，支持大文本存储
-	Kind      string    `gorm:"index" json:"kind,omitempty"`                  // 模板类型，最大长度 100
-	Cluster   string    `gorm:"index" json:"cluster,omitempty"`               // 模板类型，最大长度 100
-	IsGlobal  bool      `gorm:"index" json:"is_global,omitempty"`             // 模板类型，最大长度 100
-	CreatedBy string    `gorm:"index" json:"created_by,omitempty"`            // 创建者
-	CreatedAt time.Time `json:"created_at,omitempty" gorm:"<-:create"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"` // Automatically managed by GORM for update time
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"` // 模板 ID，主键，自增
+	Name       string    `gorm:"index" json:"name,omitempty"`                  // 模板名称，非空，最大长度 255
+	Content    string    `gorm:"type:text" json:"content,omitempty"`           // 模板内容，支持大文本存储
+	Kind       string    `gorm:"index" json:"kind,omitempty"`                  // 模板类型，最大长度 100
+	Cluster    string    `gorm:"index" json:"cluster,omitempty"`               // 模板类型，最大长度 100
+	IsGlobal   bool      `gorm:"index" json:"is_global,omitempty"`             // 模板类型，最大长度 100
+	GroupNames string    `json:"group_names,omitempty"`                        // 可见的用户组，逗号分隔，为空仅创建者可见
+	Projects   string    `json:"projects,omitempty"`                           // 可见的项目，逗号分隔，项目成员均可见
+	Engine     string    `json:"engine,omitempty"`                             // 变量替换引擎：go、envsubst，为空不做替换
+	Version    int       `gorm:"default:1" json:"version,omitempty"`           // 模板版本号，内容变更时递增
+	CreatedBy  string    `gorm:"index" json:"created_by,omitempty"`            // 创建者
+	CreatedAt  time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"` // Automatically managed by GORM for update time
 }
 
 func (c *CustomTemplate) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*CustomTemplate, int64, error) {
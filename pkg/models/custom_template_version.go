@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"gorm.io/gorm"
+)
+
+// CustomTemplateVersion 自定义模板的历史版本，每次模板内容变更时保存一份快照
+type CustomTemplateVersion struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	TemplateID uint      `gorm:"index" json:"template_id,omitempty"` // 所属模板ID
+	Version    int       `gorm:"index" json:"version,omitempty"`     // 版本号
+	Content    string    `gorm:"type:text" json:"content,omitempty"` // 该版本的模板内容
+	Engine     string    `json:"engine,omitempty"`                   // 该版本使用的变量替换引擎
+	CreatedBy  string    `gorm:"index" json:"created_by,omitempty"`  // 修改人
+	CreatedAt  time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+}
+
+func (c *CustomTemplateVersion) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*CustomTemplateVersion, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *CustomTemplateVersion) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *CustomTemplateVersion) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*CustomTemplateVersion, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
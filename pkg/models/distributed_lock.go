@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// DistributedLock 基于数据库的分布式锁，多实例共享同一个 MySQL/PostgreSQL 时用于互斥与选主
+type DistributedLock struct {
+	Name      string    `gorm:"primaryKey;size:128" json:"name,omitempty"` // 锁名称
+	Holder    string    `gorm:"size:255" json:"holder,omitempty"`          // 当前持有者实例ID
+	ExpiresAt time.Time `gorm:"index" json:"expires_at,omitempty"`         // 过期时间，过期后其他实例可抢占
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// SharedState 多实例共享的短期状态，如会话、上传进度等，带过期时间
+type SharedState struct {
+	Key       string    `gorm:"primaryKey;size:255" json:"key,omitempty"`
+	Value     string    `gorm:"type:text" json:"value,omitempty"`
+	ExpiresAt time.Time `gorm:"index" json:"expires_at,omitempty"` // 过期时间，零值表示不过期
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
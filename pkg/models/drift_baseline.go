@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// DriftBaseline 命名空间的期望状态基线。
+// 清单来自上传的快照，或从 Git 仓库的原始文件、归档地址拉取，定期与集群中的实际对象比对。
+type DriftBaseline struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name          string     `gorm:"size:100" json:"name"`
+	Cluster       string     `gorm:"size:255;index" json:"cluster"`
+	Namespace     string     `gorm:"size:255" json:"namespace"`
+	Source        string     `gorm:"size:20" json:"source"`                // upload 或 git
+	GitURL        string     `gorm:"size:1024" json:"git_url,omitempty"`   // 原始文件或归档地址，如 https://github.com/org/repo/archive/refs/heads/main.tar.gz
+	GitPath       string     `gorm:"size:255" json:"git_path,omitempty"`   // 仅比对归档内该目录下的清单，为空表示全部
+	GitToken      string     `gorm:"type:text" json:"git_token,omitempty"` // 访问私有仓库的令牌（加密存储）
+	Manifests     string     `gorm:"type:text" json:"manifests,omitempty"` // 上传的清单，Git 来源时为最近一次拉取的内容
+	CheckInterval int        `json:"check_interval"`                       // 定期比对间隔（分钟），0 表示不定期比对
+	Status        string     `gorm:"size:20" json:"status,omitempty"`      // in_sync、drifted 或 error
+	Message       string     `gorm:"type:text" json:"message,omitempty"`   // 比对失败原因
+	DriftCount    int        `json:"drift_count"`                          // 最近一次比对中漂移或缺失的资源数量
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	CreatedBy     string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt     time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt     time.Time  `json:"updated_at,omitempty"`
+}
+
+func (d *DriftBaseline) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*DriftBaseline, int64, error) {
+	return dao.GenericQuery(params, d, queryFuncs...)
+}
+
+func (d *DriftBaseline) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, d, queryFuncs...)
+}
+
+func (d *DriftBaseline) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, d, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (d *DriftBaseline) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*DriftBaseline, error) {
+	return dao.GenericGetOne(params, d, queryFuncs...)
+}
+
+// BeforeSave 在保存前加密令牌
+func (d *DriftBaseline) BeforeSave(tx *gorm.DB) error {
+	if d.GitToken != "" {
+		encrypted, err := encryptField(d.GitToken)
+		if err != nil {
+			return err
+		}
+		d.GitToken = encrypted
+	}
+	return nil
+}
+
+// AfterFind 在查询后解密令牌
+func (d *DriftBaseline) AfterFind(tx *gorm.DB) error {
+	if d.GitToken != "" {
+		decrypted, err := decryptField(d.GitToken)
+		if err != nil {
+			return err
+		}
+		d.GitToken = decrypted
+	}
+	return nil
+}
+
+// DriftResult 基线中单个资源最近一次的比对结果，每次比对后整体替换
+type DriftResult struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	BaselineID uint      `gorm:"index" json:"baseline_id"`
+	APIVersion string    `gorm:"size:255" json:"api_version"`
+	Kind       string    `gorm:"size:255" json:"kind"`
+	Namespace  string    `gorm:"size:255" json:"namespace,omitempty"`
+	Name       string    `gorm:"size:255" json:"name"`
+	Status     string    `gorm:"size:20" json:"status"`             // in_sync、drifted、missing 或 error
+	Fields     string    `gorm:"type:text" json:"fields,omitempty"` // 漂移字段，JSON 数组
+	Message    string    `gorm:"type:text" json:"message,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+func (d *DriftResult) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*DriftResult, int64, error) {
+	return dao.GenericQuery(params, d, queryFuncs...)
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// FileTrash 删除前备份的容器文件，内容为 tar 格式，可在过期前恢复
+type FileTrash struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Cluster       string    `gorm:"index" json:"cluster"`
+	Namespace     string    `gorm:"index" json:"namespace"`
+	PodName       string    `json:"pod_name"`
+	ContainerName string    `json:"container_name"`
+	Path          string    `gorm:"type:text" json:"path"`
+	IsDir         bool      `json:"is_dir"`
+	Size          int64     `json:"size"` // 备份内容大小
+	Content       []byte    `json:"-"`
+	CreatedBy     string    `gorm:"index" json:"created_by,omitempty"`
+	ExpiresAt     time.Time `gorm:"index" json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+}
+
+func (f *FileTrash) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*FileTrash, int64, error) {
+	return dao.GenericQuery(params, f, queryFuncs...)
+}
+
+func (f *FileTrash) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, f, queryFuncs...)
+}
+
+func (f *FileTrash) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, f, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (f *FileTrash) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*FileTrash, error) {
+	return dao.GenericGetOne(params, f, queryFuncs...)
+}
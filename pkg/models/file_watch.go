@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// FileWatch 容器文件监视，定期计算校验和，内容变化或 Pod 重启后文件被还原时发送通知
+type FileWatch struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Cluster       string     `gorm:"size:255;index" json:"cluster"`
+	Namespace     string     `gorm:"size:255" json:"namespace"`
+	PodName       string     `gorm:"size:255" json:"pod_name"`
+	ContainerName string     `gorm:"size:255" json:"container_name"`
+	Path          string     `gorm:"type:text" json:"path"`
+	CheckInterval int        `json:"check_interval"`                      // 检查间隔（分钟），最小 1
+	Webhooks      string     `gorm:"type:text" json:"webhooks,omitempty"` // webhook 接收者 ID，逗号分隔
+	Baseline      string     `gorm:"size:64" json:"baseline,omitempty"`   // 首次检查时的校验和，用于判断重启后是否被还原
+	Checksum      string     `gorm:"size:64" json:"checksum,omitempty"`   // 最近一次检查的校验和
+	PodUID        string     `gorm:"size:64" json:"pod_uid,omitempty"`    // 用于识别 Pod 重建
+	RestartCount  int32      `json:"restart_count"`                       // 用于识别容器重启
+	Status        string     `gorm:"size:20" json:"status,omitempty"`     // unchanged、changed、reverted 或 error
+	Message       string     `gorm:"type:text" json:"message,omitempty"`  // 最近一次检查的说明
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	LastChangedAt *time.Time `json:"last_changed_at,omitempty"`
+	CreatedBy     string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt     time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt     time.Time  `json:"updated_at,omitempty"`
+}
+
+func (f *FileWatch) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*FileWatch, int64, error) {
+	return dao.GenericQuery(params, f, queryFuncs...)
+}
+
+func (f *FileWatch) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, f, queryFuncs...)
+}
+
+func (f *FileWatch) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, f, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (f *FileWatch) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*FileWatch, error) {
+	return dao.GenericGetOne(params, f, queryFuncs...)
+}
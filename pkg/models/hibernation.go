@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// Hibernation 环境休眠计划：将若干命名空间中的 Deployment、StatefulSet 缩容到 0，之后恢复到原副本数。
+// 原副本数记录在工作负载的注解中；可按 cron 定时休眠与唤醒，如工作日 20 点休眠、8 点唤醒，以创建人身份执行。
+type Hibernation struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string     `gorm:"size:100" json:"name"`
+	Cluster     string     `gorm:"size:255;index" json:"cluster"`
+	Namespaces  string     `gorm:"type:text" json:"namespaces"`          // 命名空间，逗号分隔
+	SleepCron   string     `gorm:"size:100" json:"sleep_cron"`           // 休眠时间，标准 5 段 cron 表达式，如 0 20 * * 1-5，为空表示不定时休眠
+	WakeCron    string     `gorm:"size:100" json:"wake_cron"`            // 唤醒时间，如 0 8 * * 1-5，为空表示不定时唤醒
+	Enabled     bool       `json:"enabled"`                              // 是否启用定时休眠与唤醒
+	State       string     `gorm:"size:20" json:"state,omitempty"`       // 最近一次执行后的状态：awake 或 sleeping
+	NextSleepAt *time.Time `gorm:"index" json:"next_sleep_at,omitempty"` // 下次休眠时间，保存或执行后按 cron 计算
+	NextWakeAt  *time.Time `gorm:"index" json:"next_wake_at,omitempty"`  // 下次唤醒时间
+	LastAction  string     `gorm:"size:10" json:"last_action,omitempty"` // 最近一次执行的操作：sleep 或 wake
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	LastStatus  string     `gorm:"size:20" json:"last_status,omitempty"` // success 或 failed
+	LastMessage string     `gorm:"type:text" json:"last_message,omitempty"`
+	LastTaskID  uint       `json:"last_task_id,omitempty"` // 最近一次执行的异步任务，可查看进度与日志
+	CreatedBy   string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time  `json:"updated_at,omitempty"`
+}
+
+func (h *Hibernation) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*Hibernation, int64, error) {
+	return dao.GenericQuery(params, h, queryFuncs...)
+}
+
+func (h *Hibernation) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, h, queryFuncs...)
+}
+
+func (h *Hibernation) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, h, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (h *Hibernation) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*Hibernation, error) {
+	return dao.GenericGetOne(params, h, queryFuncs...)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// InboundWebhook 入站 webhook，供 CI 流水线、镜像仓库等外部系统触发预定义的操作。
+// 请求须携带请求体的 HMAC-SHA256 签名；操作对象在配置中固定，请求体只能提供镜像、模板变量等参数。
+// 以创建人身份执行，仍需通过只读模式、权限、准入策略与变更审批校验。
+type InboundWebhook struct {
+	ID              uint              `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name            string            `gorm:"size:100" json:"name"`
+	Description     string            `gorm:"type:text" json:"description,omitempty"`
+	Key             string            `gorm:"size:64;uniqueIndex" json:"key,omitempty"` // 地址 /hooks/{key} 中的标识，创建时生成
+	Secret          string            `gorm:"type:text" json:"-"`                       // 签名密钥，加密保存
+	Action          string            `gorm:"size:30" json:"action"`                    // restart、set_image、apply_template
+	Cluster         string            `gorm:"size:255;index" json:"cluster"`
+	Namespace       string            `gorm:"size:255" json:"namespace,omitempty"`
+	Kind            string            `gorm:"size:50" json:"kind,omitempty"`                        // Deployment、StatefulSet、DaemonSet
+	Target          string            `gorm:"size:255" json:"target,omitempty"`                     // 工作负载名称
+	Container       string            `gorm:"size:255" json:"container,omitempty"`                  // set_image 更新的容器
+	ImagePath       string            `gorm:"size:255" json:"image_path,omitempty"`                 // 请求体中镜像或标签的路径，默认 image
+	ImagePattern    string            `gorm:"size:1024" json:"image_pattern,omitempty"`             // 允许的镜像，逗号分隔，支持 * 通配；为空只允许更换标签
+	TemplateID      uint              `json:"template_id,omitempty"`                                // apply_template 使用的模板
+	Variables       map[string]string `gorm:"type:text;serializer:json" json:"variables,omitempty"` // 模板变量默认值，请求体 variables 只能覆盖其中声明的变量
+	Enabled         bool              `json:"enabled"`
+	LastTriggeredAt *time.Time        `json:"last_triggered_at,omitempty"`
+	LastStatus      string            `gorm:"size:20" json:"last_status,omitempty"` // success 或 failed
+	LastMessage     string            `gorm:"type:text" json:"last_message,omitempty"`
+	CreatedBy       string            `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt       time.Time         `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt       time.Time         `json:"updated_at,omitempty"`
+}
+
+func (c *InboundWebhook) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*InboundWebhook, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *InboundWebhook) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *InboundWebhook) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *InboundWebhook) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*InboundWebhook, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
@@ -1,11 +1,11 @@
 package models
 
 import (
-	"encoding/base64"
 	"time"
 
 	"github.com/weibaohui/k8m/internal/dao"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/envelope"
 	"gorm.io/gorm"
 )
 
@@ -58,64 +58,49 @@ func (c *KubeConfig) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *go
 	return dao.GenericGetOne(params, c, queryFuncs...)
 }
 
-// BeforeSave 在保存前加密敏感字段
+// BeforeSave 在保存前加密敏感字段。
+// kubeconfig 内容与 Token 早期以明文保存，只在配置了主密钥时加密，读取时兼容明文
 func (c *KubeConfig) BeforeSave(tx *gorm.DB) error {
-	if c.AccessKey != "" {
-		encrypted, err := encryptField(c.AccessKey)
-		if err != nil {
-			return err
-		}
-		c.AccessKey = encrypted
+	var err error
+	if c.AccessKey, err = encryptField(c.AccessKey); err != nil {
+		return err
 	}
-	if c.SecretAccessKey != "" {
-		encrypted, err := encryptField(c.SecretAccessKey)
-		if err != nil {
-			return err
-		}
-		c.SecretAccessKey = encrypted
+	if c.SecretAccessKey, err = encryptField(c.SecretAccessKey); err != nil {
+		return err
+	}
+	if c.Content, err = envelope.SealIfEnabled(c.Content); err != nil {
+		return err
+	}
+	if c.Token, err = envelope.SealIfEnabled(c.Token); err != nil {
+		return err
 	}
 	return nil
 }
 
 // AfterFind 在查询后解密敏感字段
 func (c *KubeConfig) AfterFind(tx *gorm.DB) error {
-	if c.AccessKey != "" {
-		decrypted, err := decryptField(c.AccessKey)
-		if err != nil {
-			return err
-		}
-		c.AccessKey = decrypted
+	var err error
+	if c.AccessKey, err = decryptField(c.AccessKey); err != nil {
+		return err
+	}
+	if c.SecretAccessKey, err = decryptField(c.SecretAccessKey); err != nil {
+		return err
+	}
+	if c.Content, err = envelope.OpenIfSealed(c.Content); err != nil {
+		return err
 	}
-	if c.SecretAccessKey != "" {
-		decrypted, err := decryptField(c.SecretAccessKey)
-		if err != nil {
-			return err
-		}
-		c.SecretAccessKey = decrypted
+	if c.Token, err = envelope.OpenIfSealed(c.Token); err != nil {
+		return err
 	}
 	return nil
 }
 
-// encryptField 加密字段
+// encryptField 加密字段，配置了主密钥时使用信封加密
 func encryptField(plaintext string) (string, error) {
-	if plaintext == "" {
-		return "", nil
-	}
-	encrypted, err := utils.AesEncrypt([]byte(plaintext))
-	if err != nil {
-		return "", err
-	}
-	return base64.StdEncoding.EncodeToString(encrypted), nil
+	return envelope.Encrypt(plaintext)
 }
 
-// decryptField 解密字段
+// decryptField 解密字段，兼容内置静态密钥加密的历史数据
 func decryptField(ciphertext string) (string, error) {
-	if ciphertext == "" {
-		return "", nil
-	}
-	decrypted, err := utils.AesDecrypt(ciphertext)
-	if err != nil {
-		return "", err
-	}
-	return string(decrypted), nil
+	return envelope.Decrypt(ciphertext)
 }
@@ -16,6 +16,10 @@ func init() {
 	}
 	klog.V(4).Info("数据库自动迁移完成")
 
+	if err := dao.RunMigrations(schemaMigrations); err != nil {
+		klog.Errorf("数据库版本化迁移失败: %v", err.Error())
+	}
+
 	_ = FixClusterName()
 	_ = FixRoleName()
 	_ = InitConfigTable()
@@ -32,12 +36,18 @@ func AutoMigrate() error {
 	if err := dao.DB().AutoMigrate(&CustomTemplate{}); err != nil {
 		errs = append(errs, err)
 	}
+	if err := dao.DB().AutoMigrate(&CustomTemplateVersion{}); err != nil {
+		errs = append(errs, err)
+	}
 	if err := dao.DB().AutoMigrate(&KubeConfig{}); err != nil {
 		errs = append(errs, err)
 	}
 	if err := dao.DB().AutoMigrate(&User{}); err != nil {
 		errs = append(errs, err)
 	}
+	if err := dao.DB().AutoMigrate(&PasswordHistory{}); err != nil {
+		errs = append(errs, err)
+	}
 	if err := dao.DB().AutoMigrate(&ClusterUserRole{}); err != nil {
 		errs = append(errs, err)
 	}
@@ -56,6 +66,9 @@ func AutoMigrate() error {
 	if err := dao.DB().AutoMigrate(&UserGroup{}); err != nil {
 		errs = append(errs, err)
 	}
+	if err := dao.DB().AutoMigrate(&ExternalGroupMapping{}); err != nil {
+		errs = append(errs, err)
+	}
 
 	if err := dao.DB().AutoMigrate(&Config{}); err != nil {
 		errs = append(errs, err)
@@ -63,6 +76,9 @@ func AutoMigrate() error {
 	if err := dao.DB().AutoMigrate(&ConditionReverse{}); err != nil {
 		errs = append(errs, err)
 	}
+	if err := dao.DB().AutoMigrate(&CRDConditionConfig{}); err != nil {
+		errs = append(errs, err)
+	}
 
 	if err := dao.DB().AutoMigrate(&SSOConfig{}); err != nil {
 		errs = append(errs, err)
@@ -74,6 +90,89 @@ func AutoMigrate() error {
 	if err := dao.DB().AutoMigrate(&Menu{}); err != nil {
 		errs = append(errs, err)
 	}
+	if err := dao.DB().AutoMigrate(&RegistryCredential{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&RegistryCredentialSync{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&OOMEvent{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&DriftBaseline{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&DriftResult{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&AdmissionPolicy{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&CommandPolicy{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&AccessRule{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&InboundWebhook{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ClusterAgent{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&Task{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ReportSchedule{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&FileTrash{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ArtifactPolicy{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&FileWatch{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&UserPreference{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ResourceFavorite{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&RecentResource{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ApprovalRule{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ChangeRequest{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ChangeSet{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&Project{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ProjectMember{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&ProjectNamespace{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&Hibernation{}); err != nil {
+		errs = append(errs, err)
+	}
+
+	// 多实例部署使用的分布式锁、共享状态表
+	if err := dao.DB().AutoMigrate(&DistributedLock{}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := dao.DB().AutoMigrate(&SharedState{}); err != nil {
+		errs = append(errs, err)
+	}
 
 	// 插件配置表
 	if err := dao.DB().AutoMigrate(&PluginConfig{}); err != nil {
@@ -101,6 +200,61 @@ func AutoMigrate() error {
 	return nil
 }
 
+// schemaMigrations 版本化迁移列表，按顺序执行，已执行的迁移记录在 schema_migrations 表中。
+// 新增迁移只能追加到末尾，已发布的迁移不要修改。
+var schemaMigrations = []dao.Migration{
+	{
+		// 为模板版本功能上线前创建的模板补齐版本号和初始版本快照
+		ID: "20261001_custom_template_initial_version",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Model(&CustomTemplate{}).Where("version = 0 or version is null").Update("version", 1).Error; err != nil {
+				return err
+			}
+			var templates []*CustomTemplate
+			if err := tx.Find(&templates).Error; err != nil {
+				return err
+			}
+			for _, t := range templates {
+				var count int64
+				if err := tx.Model(&CustomTemplateVersion{}).Where("template_id = ?", t.ID).Count(&count).Error; err != nil {
+					return err
+				}
+				if count > 0 {
+					continue
+				}
+				v := &CustomTemplateVersion{
+					TemplateID: t.ID,
+					Version:    t.Version,
+					Content:    t.Content,
+					Engine:     t.Engine,
+					CreatedBy:  t.CreatedBy,
+				}
+				if err := tx.Create(v).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// 内置常见的高危命令策略，nc 类工具默认不启用
+		ID: "20261017_command_policy_builtin",
+		Migrate: func(tx *gorm.DB) error {
+			policies := []*CommandPolicy{
+				{Name: "禁止删除根目录", Pattern: `\brm\s+(-\S+\s+)*/\*?(\s|;|$)`, Action: "deny", Enabled: true, CreatedBy: "system"},
+				{Name: "禁止访问云厂商元数据服务", Pattern: `169\.254\.169\.254|metadata\.google\.internal|100\.100\.100\.200`, Action: "deny", Enabled: true, CreatedBy: "system"},
+				{Name: "禁止使用 netcat", Pattern: `(^|[\s;|&])(nc|ncat|netcat)(\s|$)`, Action: "deny", Enabled: false, CreatedBy: "system"},
+			}
+			for _, p := range policies {
+				if err := tx.Create(p).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+}
+
 func FixRoleName() error {
 	// 将用户组表中角色进行统一，除了平台管理员以外，都更新为普通用户guest
 	err := dao.DB().Model(&UserGroup{}).Where("role != ?", "platform_admin").Update("role", "guest").Error
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// OOMEvent 类型
+const (
+	OOMEventKindOOM      = "oom"      // 容器因内存超限被 OOMKilled
+	OOMEventKindEviction = "eviction" // Pod 因节点资源压力被驱逐
+)
+
+// OOMEvent 后台采集的容器 OOMKilled 与节点驱逐记录，用于按工作负载、命名空间统计趋势
+type OOMEvent struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	EvtKey      string    `gorm:"size:255;uniqueIndex" json:"-"` // 去重键，同一次终止或驱逐只记录一次
+	Cluster     string    `gorm:"size:255;index" json:"cluster"`
+	Kind        string    `gorm:"size:20;index" json:"kind"` // oom 或 eviction
+	Namespace   string    `gorm:"size:255;index" json:"namespace"`
+	Workload    string    `gorm:"size:255" json:"workload,omitempty"` // 形如 Deployment/nginx，裸 Pod 为空
+	Pod         string    `gorm:"size:255" json:"pod"`
+	Container   string    `gorm:"size:255" json:"container,omitempty"`
+	Node        string    `gorm:"size:255" json:"node,omitempty"`
+	MemoryLimit string    `gorm:"size:50" json:"memory_limit,omitempty"`
+	Message     string    `gorm:"type:text" json:"message,omitempty"`
+	OccurredAt  time.Time `gorm:"index" json:"occurred_at"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+}
+
+func (o *OOMEvent) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*OOMEvent, int64, error) {
+	return dao.GenericQuery(params, o, queryFuncs...)
+}
+
+func (o *OOMEvent) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, o, queryFuncs...)
+}
+
+func (o *OOMEvent) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, o, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (o *OOMEvent) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*OOMEvent, error) {
+	return dao.GenericGetOne(params, o, queryFuncs...)
+}
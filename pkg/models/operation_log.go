@@ -16,13 +16,15 @@ type OperationLog struct {
 	Cluster      string    `gorm:"index" json:"cluster,omitempty"`
 	Namespace    string    `json:"namespace,omitempty"`
 	Name         string    `json:"name,omitempty"`
-	Group        string    `json:"group,omitempty"`                       // 资源group
-	Kind         string    `json:"kind,omitempty"`                        // 资源kind
-	Action       string    `json:"action,omitempty"`                      // 操作类型
-	Params       string    `gorm:"type:text" json:"params,omitempty"`     // 操作参数
-	ActionResult string    `json:"action_result,omitempty"`               // 操作结果
-	CreatedAt    time.Time `json:"created_at,omitempty" gorm:"<-:create"` // Automatically managed by GORM for creation time
-	UpdatedAt    time.Time `json:"updated_at,omitempty"`                  // Automatically managed by GORM for update time
+	Group        string    `json:"group,omitempty"`                          // 资源group
+	Kind         string    `json:"kind,omitempty"`                           // 资源kind
+	Action       string    `json:"action,omitempty"`                         // 操作类型
+	Params       string    `gorm:"type:text" json:"params,omitempty"`        // 操作参数
+	ActionResult string    `json:"action_result,omitempty"`                  // 操作结果
+	PolicyWarn   string    `gorm:"type:text" json:"policy_warn,omitempty"`   // 命中的平台准入策略警告
+	ChangeID     string    `gorm:"size:32;index" json:"change_id,omitempty"` // 写入资源注解 k8m.io/change-id 的变更ID，用于关联之后的事件与发布
+	CreatedAt    time.Time `json:"created_at,omitempty" gorm:"<-:create"`    // Automatically managed by GORM for creation time
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`                     // Automatically managed by GORM for update time
 
 }
 
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// PasswordHistory 本地账户使用过的密码，按密码策略校验新密码不能与最近 N 次相同
+type PasswordHistory struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Username  string    `gorm:"size:255;index" json:"username"`
+	Salt      string    `json:"-"`
+	Password  string    `json:"-"`
+	CreatedAt time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+}
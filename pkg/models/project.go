@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// 项目成员角色
+const (
+	ProjectRoleOwner  = "owner"  // 可管理项目成员与命名空间
+	ProjectRoleMember = "member" // 仅在项目范围内查看与操作
+)
+
+// Project 项目（团队），将若干集群、命名空间、成员、模板与配额归为一组，列表可按“我的项目”限定范围
+type Project struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string    `gorm:"size:100;uniqueIndex" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Quota       string    `gorm:"type:text" json:"quota,omitempty"` // 项目内每个命名空间的 ResourceQuota hard，JSON 对象，如 {"requests.cpu":"4","limits.memory":"8Gi"}
+	CreatedBy   string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (p *Project) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*Project, int64, error) {
+	return dao.GenericQuery(params, p, queryFuncs...)
+}
+
+func (p *Project) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, p, queryFuncs...)
+}
+
+func (p *Project) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, p, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (p *Project) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*Project, error) {
+	return dao.GenericGetOne(params, p, queryFuncs...)
+}
+
+// ProjectMember 项目成员
+type ProjectMember struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	ProjectID uint      `gorm:"index" json:"project_id"`
+	Username  string    `gorm:"size:100;index" json:"username"`
+	Role      string    `gorm:"size:20" json:"role"` // owner、member
+	CreatedAt time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+}
+
+func (p *ProjectMember) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ProjectMember, int64, error) {
+	return dao.GenericQuery(params, p, queryFuncs...)
+}
+
+// ProjectNamespace 项目包含的集群与命名空间，Namespace 为空表示整个集群
+type ProjectNamespace struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	ProjectID uint      `gorm:"index" json:"project_id"`
+	Cluster   string    `gorm:"size:255;index" json:"cluster"`
+	Namespace string    `gorm:"size:255" json:"namespace,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+}
+
+func (p *ProjectNamespace) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ProjectNamespace, int64, error) {
+	return dao.GenericQuery(params, p, queryFuncs...)
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// RegistryCredential 镜像仓库凭据，用于从私有仓库读取镜像元数据。
+// Cluster、Namespace 为空表示对所有集群、所有命名空间生效，匹配时越具体的配置优先级越高。
+type RegistryCredential struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name      string    `gorm:"size:100" json:"name,omitempty"`      // 显示名称
+	Registry  string    `gorm:"size:255;index" json:"registry"`      // 仓库地址，如 harbor.example.com、docker.io
+	Cluster   string    `gorm:"size:255" json:"cluster,omitempty"`   // 生效集群ID，为空表示所有集群
+	Namespace string    `gorm:"size:255" json:"namespace,omitempty"` // 生效命名空间，为空表示所有命名空间
+	Username  string    `gorm:"size:255" json:"username,omitempty"`
+	Password  string    `gorm:"type:text" json:"password,omitempty"` // 密码或访问令牌（加密存储）
+	Insecure  bool      `json:"insecure,omitempty"`                  // 使用 HTTP 访问仓库
+	CreatedBy string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+func (r *RegistryCredential) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*RegistryCredential, int64, error) {
+	return dao.GenericQuery(params, r, queryFuncs...)
+}
+
+func (r *RegistryCredential) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, r, queryFuncs...)
+}
+
+func (r *RegistryCredential) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, r, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (r *RegistryCredential) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*RegistryCredential, error) {
+	return dao.GenericGetOne(params, r, queryFuncs...)
+}
+
+// BeforeSave 在保存前加密密码
+func (r *RegistryCredential) BeforeSave(tx *gorm.DB) error {
+	if r.Password != "" {
+		encrypted, err := encryptField(r.Password)
+		if err != nil {
+			return err
+		}
+		r.Password = encrypted
+	}
+	return nil
+}
+
+// AfterFind 在查询后解密密码
+func (r *RegistryCredential) AfterFind(tx *gorm.DB) error {
+	if r.Password != "" {
+		decrypted, err := decryptField(r.Password)
+		if err != nil {
+			return err
+		}
+		r.Password = decrypted
+	}
+	return nil
+}
+
+// RegistryCredentialSync 仓库凭据同步到集群命名空间中的 imagePullSecret 记录。
+// 凭据修改后按记录重新同步，保证集群中的 Secret 与平台配置一致。
+type RegistryCredentialSync struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	CredentialID   uint       `gorm:"index" json:"credential_id"`
+	Cluster        string     `gorm:"size:255" json:"cluster"`
+	Namespace      string     `gorm:"size:255" json:"namespace"`
+	SecretName     string     `gorm:"size:253" json:"secret_name"`
+	ServiceAccount string     `gorm:"size:253" json:"service_account,omitempty"` // 同步后绑定到该 ServiceAccount 的 imagePullSecrets，为空不绑定
+	Status         string     `gorm:"size:20" json:"status,omitempty"`           // synced 或 failed
+	Message        string     `gorm:"type:text" json:"message,omitempty"`        // 失败原因
+	SyncedAt       *time.Time `json:"synced_at,omitempty"`
+	CreatedBy      string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt      time.Time  `json:"updated_at,omitempty"`
+}
+
+func (r *RegistryCredentialSync) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*RegistryCredentialSync, int64, error) {
+	return dao.GenericQuery(params, r, queryFuncs...)
+}
+
+func (r *RegistryCredentialSync) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, r, queryFuncs...)
+}
+
+func (r *RegistryCredentialSync) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, r, utils.ToInt64Slice(ids), queryFuncs...)
+}
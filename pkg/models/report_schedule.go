@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// ReportSchedule 定期生成报表并通过 webhook 接收者发送。
+// 邮件接收者收到 CSV/XLSX 附件，其他接收者只收到汇总消息；以创建人身份读取集群资源。
+type ReportSchedule struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Name        string     `gorm:"size:100" json:"name"`
+	Clusters    string     `gorm:"type:text" json:"clusters"`           // 集群，逗号分隔
+	Types       string     `gorm:"size:255" json:"types"`               // 报表类型 inventory、rbac、images、quota，逗号分隔，为空表示全部
+	Format      string     `gorm:"size:10" json:"format"`               // csv 或 xlsx
+	Cron        string     `gorm:"size:100" json:"cron"`                // 标准 5 段 cron 表达式，如 0 8 * * 1 表示每周一 8 点
+	Webhooks    string     `gorm:"type:text" json:"webhooks,omitempty"` // webhook 接收者 ID，逗号分隔
+	Enabled     bool       `json:"enabled"`
+	NextRunAt   *time.Time `gorm:"index" json:"next_run_at,omitempty"` // 下次执行时间，保存或执行后按 cron 计算
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	LastStatus  string     `gorm:"size:20" json:"last_status,omitempty"` // success 或 failed
+	LastMessage string     `gorm:"type:text" json:"last_message,omitempty"`
+	LastTaskID  uint       `json:"last_task_id,omitempty"` // 最近一次执行的异步任务，可查看进度与日志
+	CreatedBy   string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time  `json:"updated_at,omitempty"`
+}
+
+func (r *ReportSchedule) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ReportSchedule, int64, error) {
+	return dao.GenericQuery(params, r, queryFuncs...)
+}
+
+func (r *ReportSchedule) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, r, queryFuncs...)
+}
+
+func (r *ReportSchedule) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, r, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (r *ReportSchedule) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ReportSchedule, error) {
+	return dao.GenericGetOne(params, r, queryFuncs...)
+}
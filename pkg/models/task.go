@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// Task 异步执行的长耗时任务，如节点驱逐、批量上传、多集群操作。
+// 执行中的任务定期写回进度与日志，updated_at 同时作为心跳，长时间未更新的任务视为执行实例已退出。
+type Task struct {
+	ID              uint       `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Type            string     `gorm:"size:50;index" json:"type"` // 任务类型，如 node_drain
+	Title           string     `gorm:"size:255" json:"title"`
+	Cluster         string     `gorm:"size:255;index" json:"cluster,omitempty"`
+	Status          string     `gorm:"size:20;index" json:"status"` // pending、running、succeeded、failed 或 cancelled
+	Progress        int        `json:"progress"`                    // 0-100
+	Message         string     `gorm:"type:text" json:"message,omitempty"`
+	Log             string     `gorm:"type:text" json:"log,omitempty"`
+	LogSize         int        `json:"log_size"` // 累计写入的日志字节数，超出上限时早期日志被丢弃
+	CancelRequested bool       `json:"cancel_requested,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	CreatedBy       string     `gorm:"size:100;index" json:"created_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt       time.Time  `json:"updated_at,omitempty"`
+}
+
+func (c *Task) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*Task, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *Task) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *Task) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (c *Task) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*Task, error) {
+	return dao.GenericGetOne(params, c, queryFuncs...)
+}
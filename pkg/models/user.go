@@ -24,6 +24,15 @@ type User struct {
 	TwoFABackupCodes string    `gorm:"size:500" json:"two_fa_backup_codes,omitempty"` // 备用恢复码，逗号分隔
 	TwoFAAppName     string    `gorm:"size:100" json:"two_fa_app_name,omitempty"`     // 2FA应用名称，用于提醒用户使用的是哪个软件
 	Disabled         bool      `gorm:"default:false" json:"disabled,omitempty"`       // 是否启用
+	// 密码轮换
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`                       // 最近一次修改密码的时间，为空时按创建时间计算有效期
+	MustChangePassword bool       `gorm:"default:false" json:"must_change_password,omitempty"` // 管理员要求下次登录时修改密码
+	TokensValidAfter   int64      `gorm:"default:0" json:"-"`                                  // 早于该时间（Unix 秒）签发的登录 Token 全部失效
+}
+
+// IsLocal 是否为本地账户，LDAP、SSO 用户的密码不由 k8m 管理
+func (c *User) IsLocal() bool {
+	return c.Source == "" || c.Source == "db"
 }
 
 func (c *User) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*User, int64, error) {
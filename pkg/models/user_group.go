@@ -33,3 +33,32 @@ func (c *UserGroup) Delete(params *dao.Params, ids string, queryFuncs ...func(*g
 func (c *UserGroup) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*UserGroup, error) {
 	return dao.GenericGetOne(params, c, queryFuncs...)
 }
+
+// 外部用户组来源
+const (
+	ExternalGroupSourceOIDC = "oidc"
+	ExternalGroupSourceLDAP = "ldap"
+)
+
+// ExternalGroupMapping 外部身份源（OIDC、LDAP）的用户组与 k8m 用户组的映射，登录时按映射同步用户所在的用户组
+type ExternalGroupMapping struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Source        string    `gorm:"size:20;index" json:"source"`    // oidc、ldap
+	ExternalGroup string    `gorm:"size:255" json:"external_group"` // OIDC groups 声明中的组名；LDAP memberOf 中组的 DN 或 CN
+	GroupName     string    `gorm:"size:255" json:"group_name"`     // 映射到的 k8m 用户组
+	Description   string    `gorm:"size:255" json:"description,omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *ExternalGroupMapping) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ExternalGroupMapping, int64, error) {
+	return dao.GenericQuery(params, c, queryFuncs...)
+}
+
+func (c *ExternalGroupMapping) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, c, queryFuncs...)
+}
+
+func (c *ExternalGroupMapping) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, c, utils.ToInt64Slice(ids), queryFuncs...)
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// UserPreference 用户偏好与界面状态，每个用户一条记录，前端在不同浏览器、设备间共享
+type UserPreference struct {
+	ID                 uint                `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	Username           string              `gorm:"size:100;uniqueIndex" json:"username"`
+	Language           string              `gorm:"size:10" json:"language"`                              // zh、en，为空时使用浏览器语言
+	Theme              string              `gorm:"size:20" json:"theme"`                                 // light、dark、auto，为空时使用默认主题
+	DefaultPageSize    int                 `json:"default_page_size"`                                    // 列表默认分页大小，0 表示使用页面默认值
+	PageSizes          map[string]int      `gorm:"type:text;serializer:json" json:"page_sizes"`          // 各页面的分页大小，key 为页面标识
+	FavoriteClusters   []string            `gorm:"type:text;serializer:json" json:"favorite_clusters"`   // 收藏的集群
+	FavoriteNamespaces map[string][]string `gorm:"type:text;serializer:json" json:"favorite_namespaces"` // 收藏的命名空间，key 为集群
+	PinnedResources    []*ResourceRef      `gorm:"type:text;serializer:json" json:"pinned_resources"`    // 固定在首页的资源
+	UIState            map[string]any      `gorm:"type:text;serializer:json" json:"ui_state"`            // 前端自定义的界面状态，如折叠的面板、列表列设置
+	CreatedAt          time.Time           `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt          time.Time           `json:"updated_at,omitempty"`
+}
+
+func (u *UserPreference) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*UserPreference, int64, error) {
+	return dao.GenericQuery(params, u, queryFuncs...)
+}
+
+func (u *UserPreference) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, u, queryFuncs...)
+}
+
+func (u *UserPreference) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, u, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (u *UserPreference) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*UserPreference, error) {
+	return dao.GenericGetOne(params, u, queryFuncs...)
+}
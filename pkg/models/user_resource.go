@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"gorm.io/gorm"
+)
+
+// ResourceRef 指向集群中的一个资源
+type ResourceRef struct {
+	Cluster   string `gorm:"size:255" json:"cluster"`
+	Group     string `gorm:"size:255" json:"group,omitempty"`
+	Version   string `gorm:"size:50" json:"version"`
+	Kind      string `gorm:"size:100" json:"kind"`
+	Namespace string `gorm:"size:255" json:"namespace,omitempty"`
+	Name      string `gorm:"size:255" json:"name"`
+}
+
+// ResourceFavorite 用户收藏的资源
+type ResourceFavorite struct {
+	ID          uint `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	ResourceRef `gorm:"embedded"`
+	CreatedBy   string    `gorm:"size:100;index" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (r *ResourceFavorite) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*ResourceFavorite, int64, error) {
+	return dao.GenericQuery(params, r, queryFuncs...)
+}
+
+func (r *ResourceFavorite) Save(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericSave(params, r, queryFuncs...)
+}
+
+func (r *ResourceFavorite) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, r, utils.ToInt64Slice(ids), queryFuncs...)
+}
+
+func (r *ResourceFavorite) GetOne(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) (*ResourceFavorite, error) {
+	return dao.GenericGetOne(params, r, queryFuncs...)
+}
+
+// RecentResource 用户最近访问的资源，由资源详情等接口在访问成功后记录
+type RecentResource struct {
+	ID          uint `gorm:"primaryKey;autoIncrement" json:"id,omitempty"`
+	ResourceRef `gorm:"embedded"`
+	AccessCount int       `json:"access_count"`
+	AccessedAt  time.Time `gorm:"index" json:"accessed_at"`
+	CreatedBy   string    `gorm:"size:100;index" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" gorm:"<-:create"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+func (r *RecentResource) List(params *dao.Params, queryFuncs ...func(*gorm.DB) *gorm.DB) ([]*RecentResource, int64, error) {
+	return dao.GenericQuery(params, r, queryFuncs...)
+}
+
+func (r *RecentResource) Delete(params *dao.Params, ids string, queryFuncs ...func(*gorm.DB) *gorm.DB) error {
+	return dao.GenericDelete(params, r, utils.ToInt64Slice(ids), queryFuncs...)
+}
@@ -0,0 +1,404 @@
+// Package nsclone 将一个命名空间中的资源转换为另一个命名空间或集群中的副本：清理服务端字段，
+// 按规则改写名称与标签并同步更新资源之间的引用，Secret 可原样复制、排除或重新加密为 SealedSecret。
+// 只做转换，不访问集群。
+package nsclone
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SecretMode Secret 的处理方式
+type SecretMode string
+
+const (
+	SecretCopy    SecretMode = "copy"    // 原样复制
+	SecretExclude SecretMode = "exclude" // 不复制
+	SecretSeal    SecretMode = "seal"    // 使用目标集群 sealed-secrets 控制器的证书重新加密为 SealedSecret
+)
+
+// Kind 待克隆的资源类型
+type Kind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// DefaultKinds 未指定资源类型时克隆的资源
+var DefaultKinds = []Kind{
+	{"", "v1", "ServiceAccount"},
+	{"", "v1", "ConfigMap"},
+	{"", "v1", "Secret"},
+	{"", "v1", "PersistentVolumeClaim"},
+	{"", "v1", "Service"},
+	{"", "v1", "ResourceQuota"},
+	{"", "v1", "LimitRange"},
+	{"rbac.authorization.k8s.io", "v1", "Role"},
+	{"rbac.authorization.k8s.io", "v1", "RoleBinding"},
+	{"apps", "v1", "Deployment"},
+	{"apps", "v1", "StatefulSet"},
+	{"apps", "v1", "DaemonSet"},
+	{"batch", "v1", "Job"},
+	{"batch", "v1", "CronJob"},
+	{"networking.k8s.io", "v1", "Ingress"},
+	{"networking.k8s.io", "v1", "NetworkPolicy"},
+	{"autoscaling", "v2", "HorizontalPodAutoscaler"},
+	{"policy", "v1", "PodDisruptionBudget"},
+}
+
+// Replace 名称中的子串替换
+type Replace struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NameRewrite 名称改写规则，先按顺序替换子串，再添加前缀与后缀
+type NameRewrite struct {
+	Prefix  string    `json:"prefix"`
+	Suffix  string    `json:"suffix"`
+	Replace []Replace `json:"replace"`
+}
+
+func (r NameRewrite) apply(name string) string {
+	for _, rep := range r.Replace {
+		if rep.From != "" {
+			name = strings.ReplaceAll(name, rep.From, rep.To)
+		}
+	}
+	return r.Prefix + name + r.Suffix
+}
+
+// Options 克隆选项
+type Options struct {
+	SourceNamespace string
+	TargetNamespace string
+	Names           NameRewrite
+	// SetLabels 为所有副本添加或覆盖的标签
+	SetLabels map[string]string
+	// RemoveLabels 从所有副本中移除的标签
+	RemoveLabels []string
+	Secrets      SecretMode
+	// SealKey SecretSeal 模式下目标集群 sealed-secrets 控制器的公钥
+	SealKey *rsa.PublicKey
+}
+
+// Skipped 未克隆的资源及原因
+type Skipped struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Clone 源资源与其在目标位置的副本
+type Clone struct {
+	Source *unstructured.Unstructured
+	Object *unstructured.Unstructured
+}
+
+// 集群在每个命名空间中自动创建的资源：值为空时照常克隆但保留原名，否则按值给出的原因跳过
+var builtinNames = map[string]string{
+	"ServiceAccount/default":     "",
+	"ConfigMap/kube-root-ca.crt": "由集群自动创建",
+}
+
+// Transform 生成目标命名空间中的副本，返回的对象已改写名称、标签与引用，可直接应用
+func Transform(objs []*unstructured.Unstructured, opt *Options) ([]*Clone, []Skipped, error) {
+	if opt.Secrets == SecretSeal && opt.SealKey == nil {
+		return nil, nil, fmt.Errorf("重新加密 Secret 需要提供 sealed-secrets 证书")
+	}
+
+	var kept []*unstructured.Unstructured
+	var skipped []Skipped
+	renames := map[string]string{}
+	for _, obj := range objs {
+		if reason := skipReason(obj, opt.Secrets); reason != "" {
+			skipped = append(skipped, Skipped{Kind: obj.GetKind(), Name: obj.GetName(), Reason: reason})
+			continue
+		}
+		key := obj.GetKind() + "/" + obj.GetName()
+		if _, builtin := builtinNames[key]; !builtin {
+			name := opt.Names.apply(obj.GetName())
+			if err := validateName(obj.GetKind(), name); err != nil {
+				return nil, nil, err
+			}
+			renames[key] = name
+		}
+		kept = append(kept, obj)
+	}
+
+	r := &rewriter{opt: opt, renames: renames}
+	out := make([]*Clone, 0, len(kept))
+	for _, obj := range kept {
+		clone, err := r.clone(obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, &Clone{Source: obj, Object: clone})
+	}
+	return out, skipped, nil
+}
+
+// skipReason 返回资源不参与克隆的原因，为空时克隆
+func skipReason(obj *unstructured.Unstructured, secrets SecretMode) string {
+	kind := obj.GetKind()
+	if reason := builtinNames[kind+"/"+obj.GetName()]; reason != "" {
+		return reason
+	}
+	if kind == "SealedSecret" {
+		return "SealedSecret 与原命名空间、名称绑定，无法在目标位置解密，由其生成的 Secret 按 Secret 处理方式克隆"
+	}
+	if kind == "Secret" {
+		if secrets == SecretExclude {
+			return "已选择不复制 Secret"
+		}
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		if secretType == "kubernetes.io/service-account-token" {
+			return "ServiceAccount Token 由集群自动生成"
+		}
+	}
+	if owner := metav1.GetControllerOfNoCopy(obj); owner != nil {
+		// SealedSecret 生成的 Secret 需要保留，其他由控制器生成的资源会随上级资源重新生成
+		if kind == "Secret" && owner.Kind == "SealedSecret" {
+			return ""
+		}
+		return fmt.Sprintf("由 %s/%s 管理", owner.Kind, owner.Name)
+	}
+	return ""
+}
+
+func validateName(kind, name string) error {
+	var errs []string
+	switch kind {
+	case "Service":
+		errs = validation.IsDNS1035Label(name)
+	default:
+		errs = validation.IsDNS1123Subdomain(name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s 改名后的名称 %q 无效: %s", kind, name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+type rewriter struct {
+	opt     *Options
+	renames map[string]string
+}
+
+// name 返回被引用资源在目标位置的名称，未参与克隆的资源保持原名
+func (r *rewriter) name(kind, name string) string {
+	if renamed, ok := r.renames[kind+"/"+name]; ok {
+		return renamed
+	}
+	return name
+}
+
+// renameField 改写 m[field] 中对 kind 类型资源的引用
+func (r *rewriter) renameField(m map[string]any, field, kind string) {
+	if name, ok := m[field].(string); ok && name != "" {
+		m[field] = r.name(kind, name)
+	}
+}
+
+func (r *rewriter) clone(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	out := utils.CleanupForExport(obj)
+	out.SetNamespace(r.opt.TargetNamespace)
+	out.SetName(r.name(out.GetKind(), out.GetName()))
+	out.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(out.Object, "metadata", "ownerReferences")
+	r.rewriteLabels(out)
+
+	switch out.GetKind() {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		r.rewritePodSpec(out.Object, "spec", "template", "spec")
+		if out.GetKind() == "StatefulSet" {
+			r.renameField(nestedMap(out.Object, "spec"), "serviceName", "Service")
+		}
+	case "Job":
+		// selector 与模板中的 controller-uid 标签由集群按新 Job 重新生成
+		unstructured.RemoveNestedField(out.Object, "spec", "selector")
+		if labels := nestedMap(out.Object, "spec", "template", "metadata", "labels"); labels != nil {
+			for _, k := range []string{"controller-uid", "batch.kubernetes.io/controller-uid", "job-name", "batch.kubernetes.io/job-name"} {
+				delete(labels, k)
+			}
+		}
+		r.rewritePodSpec(out.Object, "spec", "template", "spec")
+	case "CronJob":
+		r.rewritePodSpec(out.Object, "spec", "jobTemplate", "spec", "template", "spec")
+	case "Pod":
+		r.rewritePodSpec(out.Object, "spec")
+	case "Service":
+		// nodePort 在集群内唯一，由目标集群重新分配
+		unstructured.RemoveNestedField(out.Object, "spec", "healthCheckNodePort")
+		for _, p := range nestedSlice(out.Object, "spec", "ports") {
+			delete(p, "nodePort")
+		}
+	case "PersistentVolumeClaim":
+		// 副本创建新的空卷，不绑定原 PV
+		unstructured.RemoveNestedField(out.Object, "spec", "volumeName")
+		annotations := out.GetAnnotations()
+		for _, k := range []string{"pv.kubernetes.io/bind-completed", "pv.kubernetes.io/bound-by-controller",
+			"volume.beta.kubernetes.io/storage-provisioner", "volume.kubernetes.io/storage-provisioner", "volume.kubernetes.io/selected-node"} {
+			delete(annotations, k)
+		}
+		out.SetAnnotations(annotations)
+	case "Ingress":
+		spec := nestedMap(out.Object, "spec")
+		r.renameField(nestedMap(spec, "defaultBackend", "service"), "name", "Service")
+		for _, rule := range nestedSlice(spec, "rules") {
+			for _, path := range nestedSlice(rule, "http", "paths") {
+				r.renameField(nestedMap(path, "backend", "service"), "name", "Service")
+			}
+		}
+		for _, tls := range nestedSlice(spec, "tls") {
+			r.renameField(tls, "secretName", "Secret")
+		}
+	case "RoleBinding":
+		if roleRef := nestedMap(out.Object, "roleRef"); roleRef != nil && roleRef["kind"] == "Role" {
+			r.renameField(roleRef, "name", "Role")
+		}
+		for _, subject := range nestedSlice(out.Object, "subjects") {
+			if subject["kind"] != "ServiceAccount" {
+				continue
+			}
+			ns, _ := subject["namespace"].(string)
+			if ns == r.opt.SourceNamespace || ns == "" {
+				subject["namespace"] = r.opt.TargetNamespace
+				r.renameField(subject, "name", "ServiceAccount")
+			}
+		}
+	case "HorizontalPodAutoscaler":
+		if target := nestedMap(out.Object, "spec", "scaleTargetRef"); target != nil {
+			kind, _ := target["kind"].(string)
+			r.renameField(target, "name", kind)
+		}
+	case "NetworkPolicy":
+		r.rewriteNetworkPolicy(out)
+	case "Secret":
+		if r.opt.Secrets == SecretSeal {
+			return Seal(out, r.opt.SealKey)
+		}
+	}
+	return out, nil
+}
+
+func (r *rewriter) rewriteLabels(obj *unstructured.Unstructured) {
+	if len(r.opt.SetLabels) == 0 && len(r.opt.RemoveLabels) == 0 {
+		return
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, k := range r.opt.RemoveLabels {
+		delete(labels, k)
+	}
+	for k, v := range r.opt.SetLabels {
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+}
+
+// rewritePodSpec 改写 Pod 模板中对 ServiceAccount、ConfigMap、Secret、PVC 的引用
+func (r *rewriter) rewritePodSpec(obj map[string]any, fields ...string) {
+	spec := nestedMap(obj, fields...)
+	if spec == nil {
+		return
+	}
+	r.renameField(spec, "serviceAccountName", "ServiceAccount")
+	r.renameField(spec, "serviceAccount", "ServiceAccount")
+	for _, s := range nestedSlice(spec, "imagePullSecrets") {
+		r.renameField(s, "name", "Secret")
+	}
+	for _, v := range nestedSlice(spec, "volumes") {
+		r.renameField(nestedMap(v, "configMap"), "name", "ConfigMap")
+		r.renameField(nestedMap(v, "secret"), "secretName", "Secret")
+		r.renameField(nestedMap(v, "persistentVolumeClaim"), "claimName", "PersistentVolumeClaim")
+		for _, src := range nestedSlice(v, "projected", "sources") {
+			r.renameField(nestedMap(src, "configMap"), "name", "ConfigMap")
+			r.renameField(nestedMap(src, "secret"), "name", "Secret")
+		}
+	}
+	for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		for _, c := range nestedSlice(spec, field) {
+			for _, env := range nestedSlice(c, "env") {
+				r.renameField(nestedMap(env, "valueFrom", "configMapKeyRef"), "name", "ConfigMap")
+				r.renameField(nestedMap(env, "valueFrom", "secretKeyRef"), "name", "Secret")
+			}
+			for _, from := range nestedSlice(c, "envFrom") {
+				r.renameField(nestedMap(from, "configMapRef"), "name", "ConfigMap")
+				r.renameField(nestedMap(from, "secretRef"), "name", "Secret")
+			}
+		}
+	}
+}
+
+// rewriteNetworkPolicy 将按 kubernetes.io/metadata.name 选择原命名空间的规则改为选择目标命名空间
+func (r *rewriter) rewriteNetworkPolicy(obj *unstructured.Unstructured) {
+	for _, direction := range []string{"ingress", "egress"} {
+		peerField := "from"
+		if direction == "egress" {
+			peerField = "to"
+		}
+		for _, rule := range nestedSlice(obj.Object, "spec", direction) {
+			for _, peer := range nestedSlice(rule, peerField) {
+				matchLabels := nestedMap(peer, "namespaceSelector", "matchLabels")
+				if matchLabels != nil && matchLabels[corev1.LabelMetadataName] == r.opt.SourceNamespace {
+					matchLabels[corev1.LabelMetadataName] = r.opt.TargetNamespace
+				}
+			}
+		}
+	}
+}
+
+// Namespace 生成目标命名空间对象，沿用源命名空间的标签与注解并按选项改写标签
+func Namespace(source *unstructured.Unstructured, opt *Options) *unstructured.Unstructured {
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(opt.TargetNamespace)
+	if source != nil {
+		labels := source.GetLabels()
+		delete(labels, corev1.LabelMetadataName)
+		ns.SetLabels(labels)
+		cleaned := utils.CleanupForExport(source)
+		ns.SetAnnotations(cleaned.GetAnnotations())
+	}
+	(&rewriter{opt: opt}).rewriteLabels(ns)
+	return ns
+}
+
+// nestedMap 返回嵌套的 map，路径不存在或类型不符时返回 nil
+func nestedMap(obj map[string]any, fields ...string) map[string]any {
+	cur := obj
+	for _, f := range fields {
+		if cur == nil {
+			return nil
+		}
+		cur, _ = cur[f].(map[string]any)
+	}
+	return cur
+}
+
+// nestedSlice 返回嵌套列表中的 map 元素
+func nestedSlice(obj map[string]any, fields ...string) []map[string]any {
+	parent := nestedMap(obj, fields[:len(fields)-1]...)
+	if parent == nil {
+		return nil
+	}
+	list, _ := parent[fields[len(fields)-1]].([]any)
+	out := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -0,0 +1,283 @@
+package nsclone
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"testing"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func parse(t *testing.T, docs ...string) []*unstructured.Unstructured {
+	t.Helper()
+	var objs []*unstructured.Unstructured
+	for _, doc := range docs {
+		var m map[string]any
+		if err := yaml.Unmarshal([]byte(doc), &m); err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: m})
+	}
+	return objs
+}
+
+func find(clones []*Clone, kind, name string) *unstructured.Unstructured {
+	for _, c := range clones {
+		if c.Object.GetKind() == kind && c.Object.GetName() == name {
+			return c.Object
+		}
+	}
+	return nil
+}
+
+func str(t *testing.T, obj *unstructured.Unstructured, fields ...any) string {
+	t.Helper()
+	var cur any = obj.Object
+	for _, f := range fields {
+		switch k := f.(type) {
+		case string:
+			cur = cur.(map[string]any)[k]
+		case int:
+			cur = cur.([]any)[k]
+		}
+	}
+	s, _ := cur.(string)
+	return s
+}
+
+const source = `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web, namespace: prod, uid: u1, resourceVersion: "9", labels: {app: web, env: prod}}
+spec:
+  selector: {matchLabels: {app: web}}
+  template:
+    metadata: {labels: {app: web}}
+    spec:
+      serviceAccountName: web
+      volumes:
+      - {name: cfg, configMap: {name: web-config}}
+      - {name: data, persistentVolumeClaim: {claimName: web-data}}
+      - {name: ext, configMap: {name: external}}
+      containers:
+      - name: app
+        envFrom: [{secretRef: {name: web-secret}}]
+        env: [{name: A, valueFrom: {configMapKeyRef: {name: web-config, key: a}}}]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: web-config, namespace: prod}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: kube-root-ca.crt, namespace: prod}
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata: {name: web, namespace: prod}
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata: {name: default, namespace: prod}
+---
+apiVersion: v1
+kind: Secret
+metadata: {name: web-secret, namespace: prod}
+type: Opaque
+data: {password: c2VjcmV0}
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata: {name: web-data, namespace: prod, annotations: {pv.kubernetes.io/bind-completed: "yes"}}
+spec: {volumeName: pv-1, accessModes: [ReadWriteOnce]}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: web, namespace: prod}
+spec: {type: NodePort, clusterIP: 10.0.0.1, ports: [{port: 80, nodePort: 30080}]}
+---
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: web-abc
+  namespace: prod
+  ownerReferences: [{apiVersion: apps/v1, kind: Deployment, name: web, uid: u1, controller: true}]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: {name: web, namespace: prod}
+roleRef: {apiGroup: rbac.authorization.k8s.io, kind: Role, name: web}
+subjects:
+- {kind: ServiceAccount, name: web, namespace: prod}
+- {kind: ServiceAccount, name: monitor, namespace: monitoring}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata: {name: web, namespace: prod}
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata: {name: web, namespace: prod}
+spec:
+  tls: [{secretName: web-secret}]
+  rules: [{http: {paths: [{path: /, backend: {service: {name: web, port: {number: 80}}}}]}}]
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata: {name: allow, namespace: prod}
+spec:
+  ingress: [{from: [{namespaceSelector: {matchLabels: {kubernetes.io/metadata.name: prod}}}]}]
+`
+
+func TestTransform(t *testing.T) {
+	opt := &Options{
+		SourceNamespace: "prod",
+		TargetNamespace: "test",
+		Names:           NameRewrite{Suffix: "-copy"},
+		SetLabels:       map[string]string{"env": "test"},
+		Secrets:         SecretCopy,
+	}
+	out, skipped, err := Transform(parse(t, utils.SplitYAMLDocuments(source)...), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skipped) != 2 || find(out, "ReplicaSet", "web-abc-copy") != nil || find(out, "ConfigMap", "kube-root-ca.crt") != nil {
+		t.Fatalf("skipped = %+v", skipped)
+	}
+	for _, c := range out {
+		if o := c.Object; o.GetNamespace() != "test" {
+			t.Errorf("%s/%s namespace = %s", o.GetKind(), o.GetName(), o.GetNamespace())
+		}
+		if c.Source.GetNamespace() != "prod" {
+			t.Errorf("source of %s should be unchanged", c.Object.GetName())
+		}
+	}
+	if find(out, "ServiceAccount", "default") == nil {
+		t.Error("default ServiceAccount should keep its name")
+	}
+
+	deploy := find(out, "Deployment", "web-copy")
+	if deploy == nil {
+		t.Fatal("deployment not renamed")
+	}
+	if deploy.GetUID() != "" || deploy.GetResourceVersion() != "" {
+		t.Error("server fields not removed")
+	}
+	if deploy.GetLabels()["env"] != "test" || deploy.GetLabels()["app"] != "web" {
+		t.Errorf("labels = %v", deploy.GetLabels())
+	}
+	podSpec := []any{"spec", "template", "spec"}
+	checks := map[string][]any{
+		"web-copy":        append(podSpec[:3:3], "serviceAccountName"),
+		"web-config-copy": append(podSpec[:3:3], "volumes", 0, "configMap", "name"),
+		"web-data-copy":   append(podSpec[:3:3], "volumes", 1, "persistentVolumeClaim", "claimName"),
+		"external":        append(podSpec[:3:3], "volumes", 2, "configMap", "name"),
+		"web-secret-copy": append(podSpec[:3:3], "containers", 0, "envFrom", 0, "secretRef", "name"),
+	}
+	for want, path := range checks {
+		if got := str(t, deploy, path...); got != want {
+			t.Errorf("%v = %q, want %q", path, got, want)
+		}
+	}
+	if got := str(t, deploy, append(podSpec[:3:3], "containers", 0, "env", 0, "valueFrom", "configMapKeyRef", "name")...); got != "web-config-copy" {
+		t.Errorf("env configMapKeyRef = %q", got)
+	}
+
+	pvc := find(out, "PersistentVolumeClaim", "web-data-copy")
+	if str(t, pvc, "spec", "volumeName") != "" || len(pvc.GetAnnotations()) != 0 {
+		t.Errorf("pvc binding not removed: %v", pvc.Object)
+	}
+	svc := find(out, "Service", "web-copy")
+	if port := svc.Object["spec"].(map[string]any)["ports"].([]any)[0].(map[string]any); port["nodePort"] != nil {
+		t.Error("nodePort not removed")
+	}
+
+	rb := find(out, "RoleBinding", "web-copy")
+	if str(t, rb, "roleRef", "name") != "web-copy" ||
+		str(t, rb, "subjects", 0, "name") != "web-copy" || str(t, rb, "subjects", 0, "namespace") != "test" ||
+		str(t, rb, "subjects", 1, "name") != "monitor" || str(t, rb, "subjects", 1, "namespace") != "monitoring" {
+		t.Errorf("rolebinding = %v", rb.Object)
+	}
+	ing := find(out, "Ingress", "web-copy")
+	if str(t, ing, "spec", "tls", 0, "secretName") != "web-secret-copy" ||
+		str(t, ing, "spec", "rules", 0, "http", "paths", 0, "backend", "service", "name") != "web-copy" {
+		t.Errorf("ingress = %v", ing.Object)
+	}
+	np := find(out, "NetworkPolicy", "allow-copy")
+	if str(t, np, "spec", "ingress", 0, "from", 0, "namespaceSelector", "matchLabels", "kubernetes.io/metadata.name") != "test" {
+		t.Errorf("networkpolicy = %v", np.Object)
+	}
+}
+
+func TestTransformSecretModes(t *testing.T) {
+	objs := parse(t, utils.SplitYAMLDocuments(source)...)
+	out, skipped, err := Transform(objs, &Options{SourceNamespace: "prod", TargetNamespace: "test", Secrets: SecretExclude})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if find(out, "Secret", "web-secret") != nil || len(skipped) != 3 {
+		t.Errorf("secret should be excluded, skipped = %+v", skipped)
+	}
+	if _, _, err := Transform(objs, &Options{TargetNamespace: "test", Secrets: SecretSeal}); err == nil {
+		t.Error("seal without key should fail")
+	}
+	if _, _, err := Transform(objs, &Options{TargetNamespace: "test", Names: NameRewrite{Prefix: "Bad_"}}); err == nil {
+		t.Error("invalid renamed name should fail")
+	}
+}
+
+func TestSeal(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ParseSealCert(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, _, err := Transform(parse(t, utils.SplitYAMLDocuments(source)...), &Options{
+		SourceNamespace: "prod", TargetNamespace: "test", Secrets: SecretSeal, SealKey: pub,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed := find(out, "SealedSecret", "web-secret")
+	if sealed == nil || sealed.GetNamespace() != "test" || str(t, sealed, "spec", "template", "type") != "Opaque" {
+		t.Fatalf("sealed = %v", sealed)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(str(t, sealed, "spec", "encryptedData", "password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 按 sealed-secrets 的 HybridDecrypt 解密
+	n := int(binary.BigEndian.Uint16(ciphertext))
+	sessionKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, priv, ciphertext[2:2+n], []byte("test/web-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := aes.NewCipher(sessionKey)
+	aead, _ := cipher.NewGCM(block)
+	plain, err := aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[2+n:], nil)
+	if err != nil || string(plain) != "secret" {
+		t.Fatalf("decrypt = %q, %v", plain, err)
+	}
+
+	if _, err := ParseSealCert([]byte("not pem")); err == nil {
+		t.Error("invalid cert should fail")
+	}
+}
@@ -0,0 +1,141 @@
+package nsclone
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// sealed-secrets 的加密范围注解
+const (
+	annotationNamespaceWide = "sealedsecrets.bitnami.com/namespace-wide"
+	annotationClusterWide   = "sealedsecrets.bitnami.com/cluster-wide"
+)
+
+// ParseSealCert 解析 sealed-secrets 控制器的证书（kubeseal --fetch-cert 的输出），也接受 PEM 格式的 RSA 公钥
+func ParseSealCert(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("证书不是有效的 PEM 格式")
+	}
+	var key any
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析证书失败: %w", err)
+		}
+		key = cert.PublicKey
+	case "PUBLIC KEY":
+		var err error
+		if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("解析公钥失败: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的 PEM 类型 %s", block.Type)
+	}
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("sealed-secrets 证书必须使用 RSA 公钥")
+	}
+	return pub, nil
+}
+
+// Seal 将 Secret 转换为 bitnami.com/v1alpha1 SealedSecret，加密格式与 kubeseal 一致，
+// 加密范围沿用 Secret 上的 sealed-secrets 注解，默认绑定命名空间与名称
+func Seal(secret *unstructured.Unstructured, key *rsa.PublicKey) (*unstructured.Unstructured, error) {
+	label := []byte(secret.GetNamespace() + "/" + secret.GetName())
+	annotations := secret.GetAnnotations()
+	switch {
+	case annotations[annotationClusterWide] == "true":
+		label = nil
+	case annotations[annotationNamespaceWide] == "true":
+		label = []byte(secret.GetNamespace())
+	}
+
+	data, _, _ := unstructured.NestedStringMap(secret.Object, "data")
+	encrypted := make(map[string]any, len(data))
+	for k, v := range data {
+		plain, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("Secret %s 的 %s 不是有效的 base64: %w", secret.GetName(), k, err)
+		}
+		ciphertext, err := hybridEncrypt(rand.Reader, key, plain, label)
+		if err != nil {
+			return nil, fmt.Errorf("加密 Secret %s 的 %s 失败: %w", secret.GetName(), k, err)
+		}
+		encrypted[k] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	templateMeta := map[string]any{
+		"name":      secret.GetName(),
+		"namespace": secret.GetNamespace(),
+	}
+	if labels := secret.GetLabels(); len(labels) > 0 {
+		templateMeta["labels"] = toAnyMap(labels)
+	}
+	if len(annotations) > 0 {
+		templateMeta["annotations"] = toAnyMap(annotations)
+	}
+	template := map[string]any{"metadata": templateMeta}
+	if secretType, found, _ := unstructured.NestedString(secret.Object, "type"); found {
+		template["type"] = secretType
+	}
+
+	sealed := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "bitnami.com/v1alpha1",
+		"kind":       "SealedSecret",
+		"metadata":   map[string]any{"name": secret.GetName(), "namespace": secret.GetNamespace()},
+		"spec": map[string]any{
+			"encryptedData": encrypted,
+			"template":      template,
+		},
+	}}
+	sealed.SetLabels(secret.GetLabels())
+	sealed.SetAnnotations(annotations)
+	return sealed, nil
+}
+
+// hybridEncrypt 使用随机会话密钥以 AES-GCM 加密内容，会话密钥以 RSA-OAEP 加密，
+// 输出为 2 字节长度 + RSA 密文 + AES 密文，与 sealed-secrets 的 HybridEncrypt 兼容
+func hybridEncrypt(rnd io.Reader, key *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	sessionKey := make([]byte, 32)
+	if _, err := io.ReadFull(rnd, sessionKey); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	rsaCiphertext, err := rsa.EncryptOAEP(sha256.New(), rnd, key, sessionKey, label)
+	if err != nil {
+		return nil, err
+	}
+	out := binary.BigEndian.AppendUint16(nil, uint16(len(rsaCiphertext)))
+	out = append(out, rsaCiphertext...)
+	// 每个会话密钥只使用一次，sealed-secrets 固定使用全零 nonce
+	zeroNonce := make([]byte, aead.NonceSize())
+	return aead.Seal(out, zeroNonce, plaintext, nil), nil
+}
+
+func toAnyMap(m map[string]string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
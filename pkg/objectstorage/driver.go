@@ -0,0 +1,247 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Object 存储中的一个对象
+type Object struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// Driver 对象存储驱动，key 以 / 分隔，不以 / 开头
+type Driver interface {
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	DeleteObject(ctx context.Context, key string) error
+	ListObjects(ctx context.Context, prefix string) ([]*Object, error)
+}
+
+var _ Driver = (*S3Client)(nil)
+var _ Driver = (*LocalDriver)(nil)
+
+// CleanKey 规范化对象 key，拒绝包含 .. 等试图跳出根目录的 key
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.Contains(key, "\\") || strings.ContainsRune(key, 0) {
+		return "", fmt.Errorf("对象 key 无效: %q", key)
+	}
+	for _, seg := range strings.Split(strings.Trim(key, "/"), "/") {
+		if seg == ".." {
+			return "", fmt.Errorf("对象 key 无效: %q", key)
+		}
+	}
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" {
+		return "", fmt.Errorf("对象 key 无效: %q", key)
+	}
+	return cleaned, nil
+}
+
+// LocalDriver 将对象保存为本地目录下的文件
+type LocalDriver struct {
+	root string
+}
+
+// NewLocalDriver 创建本地磁盘驱动，目录不存在时自动创建
+func NewLocalDriver(root string) (*LocalDriver, error) {
+	if root == "" {
+		return nil, fmt.Errorf("本地存储目录不能为空")
+	}
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, fmt.Errorf("创建本地存储目录 %s 失败: %w", root, err)
+	}
+	return &LocalDriver{root: root}, nil
+}
+
+// Root 返回本地存储目录
+func (l *LocalDriver) Root() string {
+	return l.root
+}
+
+func (l *LocalDriver) file(key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(cleaned)), nil
+}
+
+// PutObject 先写入同目录的临时文件再改名，写入中断时不会留下不完整的对象
+func (l *LocalDriver) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	name, err := l.file(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := io.Copy(tmp, readerWithContext(ctx, body))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("写入 %d 字节，与声明的 %d 字节不一致", n, size)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// GetObject 打开对象文件，调用方负责关闭
+func (l *LocalDriver) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	name, err := l.file(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		_ = f.Close()
+		return nil, 0, fmt.Errorf("对象 %s 不存在", key)
+	}
+	return f, info.Size(), nil
+}
+
+// DeleteObject 删除对象文件，对象不存在时同样返回成功
+func (l *LocalDriver) DeleteObject(ctx context.Context, key string) error {
+	name, err := l.file(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// ListObjects 列出 key 以 prefix 开头的对象，按 key 排序，忽略写入中的临时文件
+func (l *LocalDriver) ListObjects(ctx context.Context, prefix string) ([]*Object, error) {
+	result := []*Object{}
+	err := filepath.WalkDir(l.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		result = append(result, &Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+func readerWithContext(ctx context.Context, r io.Reader) io.Reader {
+	return &ctxReader{ctx: ctx, r: r}
+}
+
+// prefixDriver 将所有 key 加上固定前缀，用于在共享的存储桶中隔离 k8m 的对象
+type prefixDriver struct {
+	Driver
+	prefix string
+}
+
+// WithPrefix 返回在 key 前加上 prefix 的驱动，列出的对象 key 去掉该前缀
+func WithPrefix(d Driver, prefix string) Driver {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return d
+	}
+	return &prefixDriver{Driver: d, prefix: prefix + "/"}
+}
+
+func (p *prefixDriver) key(key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return p.prefix + cleaned, nil
+}
+
+func (p *prefixDriver) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	k, err := p.key(key)
+	if err != nil {
+		return err
+	}
+	return p.Driver.PutObject(ctx, k, body, size, contentType)
+}
+
+func (p *prefixDriver) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	k, err := p.key(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return p.Driver.GetObject(ctx, k)
+}
+
+func (p *prefixDriver) DeleteObject(ctx context.Context, key string) error {
+	k, err := p.key(key)
+	if err != nil {
+		return err
+	}
+	return p.Driver.DeleteObject(ctx, k)
+}
+
+func (p *prefixDriver) ListObjects(ctx context.Context, prefix string) ([]*Object, error) {
+	list, err := p.Driver.ListObjects(ctx, p.prefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range list {
+		o.Key = strings.TrimPrefix(o.Key, p.prefix)
+	}
+	return list, nil
+}
@@ -0,0 +1,93 @@
+package objectstorage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCleanKey(t *testing.T) {
+	for key, want := range map[string]string{
+		"support-bundle/a.tar.gz": "support-bundle/a.tar.gz",
+		"/backup//x/./y":          "backup/x/y",
+	} {
+		got, err := CleanKey(key)
+		if err != nil || got != want {
+			t.Errorf("CleanKey(%q) = %q, %v, want %q", key, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "/", "../etc/passwd", "a/../../b", "a\\b"} {
+		if _, err := CleanKey(bad); err == nil {
+			t.Errorf("CleanKey(%q) should fail", bad)
+		}
+	}
+}
+
+func TestLocalDriver(t *testing.T) {
+	ctx := context.Background()
+	d, err := NewLocalDriver(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, content := range map[string]string{"bundle/a.txt": "aaa", "bundle/sub/b.txt": "bb", "trash/c.txt": "c"} {
+		if err := d.PutObject(ctx, key, strings.NewReader(content), int64(len(content)), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.PutObject(ctx, "bundle/bad.txt", strings.NewReader("x"), 5, ""); err == nil {
+		t.Fatal("size mismatch should fail")
+	}
+
+	objects, err := d.ListObjects(ctx, "bundle/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 || objects[0].Key != "bundle/a.txt" || objects[1].Key != "bundle/sub/b.txt" || objects[0].Size != 3 {
+		t.Fatalf("unexpected objects: %+v %+v", objects[0], objects[len(objects)-1])
+	}
+
+	body, size, err := d.GetObject(ctx, "bundle/sub/b.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(body)
+	_ = body.Close()
+	if string(raw) != "bb" || size != 2 {
+		t.Fatalf("GetObject = %q, %d", raw, size)
+	}
+
+	if err := d.DeleteObject(ctx, "bundle/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.DeleteObject(ctx, "bundle/a.txt"); err != nil {
+		t.Fatalf("deleting a missing object should succeed: %v", err)
+	}
+	if _, _, err := d.GetObject(ctx, "bundle/a.txt"); err == nil {
+		t.Fatal("deleted object should not be readable")
+	}
+	if _, _, err := d.GetObject(ctx, "../outside"); err == nil {
+		t.Fatal("path traversal should be rejected")
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocalDriver(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := WithPrefix(local, "/k8m-artifacts/")
+	if err := d.PutObject(ctx, "bundle/a", strings.NewReader("a"), 1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if raw, err := local.ListObjects(ctx, ""); err != nil || len(raw) != 1 || raw[0].Key != "k8m-artifacts/bundle/a" {
+		t.Fatalf("object should be stored under prefix: %+v %v", raw, err)
+	}
+	if list, err := d.ListObjects(ctx, "bundle/"); err != nil || len(list) != 1 || list[0].Key != "bundle/a" {
+		t.Fatalf("listed key should not contain prefix: %+v %v", list, err)
+	}
+	if WithPrefix(local, "") != Driver(local) {
+		t.Fatal("empty prefix should return the driver itself")
+	}
+}
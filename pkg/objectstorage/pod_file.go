@@ -0,0 +1,41 @@
+package objectstorage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PodFilePrefix 容器文件传输在存储桶中使用的根前缀，与平台制品等其他用途的对象隔离
+const PodFilePrefix = "k8m-pod-files"
+
+// PodFileScope 返回集群、命名空间在存储桶中的前缀，容器文件传输只能读写该前缀下的对象
+func PodFileScope(cluster, namespace string) (string, error) {
+	if cluster == "" || namespace == "" || strings.Contains(namespace, "/") {
+		return "", fmt.Errorf("集群或命名空间无效")
+	}
+	scope, err := CleanKey(PodFilePrefix + "/" + cluster + "/" + namespace)
+	if err != nil {
+		return "", err
+	}
+	return scope + "/", nil
+}
+
+// PodFileKey 将用户给出的对象 key 限定在 PodFileScope 之下。
+// key 已带有该前缀时原样使用（如推送结果中返回的 key），否则视为相对该前缀的路径；拒绝绝对路径与包含 .. 的 key
+func PodFileKey(cluster, namespace, key string) (string, error) {
+	scope, err := PodFileScope(cluster, namespace)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(key, "/") {
+		return "", fmt.Errorf("对象 key 不能以 / 开头: %q", key)
+	}
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(cleaned, scope) {
+		cleaned = scope + cleaned
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,32 @@
+package objectstorage
+
+import "testing"
+
+func TestPodFileKey(t *testing.T) {
+	tests := []struct {
+		cluster, namespace, key string
+		want                    string
+		wantErr                 bool
+	}{
+		{"prod", "app", "backup/data.tar", "k8m-pod-files/prod/app/backup/data.tar", false},
+		{"config/prod", "app", "a.log", "k8m-pod-files/config/prod/app/a.log", false},
+		// 推送结果返回的完整 key 可直接用于拉取
+		{"prod", "app", "k8m-pod-files/prod/app/web/a.log", "k8m-pod-files/prod/app/web/a.log", false},
+		// 其他命名空间、平台制品的 key 被限定到本命名空间下
+		{"prod", "app", "k8m-pod-files/prod/other/a.log", "k8m-pod-files/prod/app/k8m-pod-files/prod/other/a.log", false},
+		{"prod", "app", "k8m-artifacts/support-bundle/prod/x.tar.gz", "k8m-pod-files/prod/app/k8m-artifacts/support-bundle/prod/x.tar.gz", false},
+		{"prod", "app", "/k8m-artifacts/x", "", true},
+		{"prod", "app", "../other/a.log", "", true},
+		{"prod", "app", "a/../../b", "", true},
+		{"prod", "app", "", "", true},
+		{"prod", "", "a.log", "", true},
+		{"prod", "a/b", "a.log", "", true},
+		{"prod", "..", "a.log", "", true},
+	}
+	for _, tt := range tests {
+		got, err := PodFileKey(tt.cluster, tt.namespace, tt.key)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("PodFileKey(%q, %q, %q) = %q, %v, want %q", tt.cluster, tt.namespace, tt.key, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package objectstorage
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy 某个前缀下对象的保留策略，均为 0 表示不清理
+type RetentionPolicy struct {
+	Prefix        string
+	RetentionDays int // 超过天数的对象删除
+	MaxCount      int // 只保留最新的 N 个对象
+	MaxSizeMB     int // 总大小超过时从最旧的对象开始删除
+}
+
+// Expired 按策略返回应删除的对象，objects 中不以 Prefix 开头的对象忽略
+func Expired(objects []*Object, p RetentionPolicy, now time.Time) []*Object {
+	var matched []*Object
+	for _, o := range objects {
+		if strings.HasPrefix(o.Key, p.Prefix) {
+			matched = append(matched, o)
+		}
+	}
+	// 新的在前，保留靠前的对象
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].LastModified.Equal(matched[j].LastModified) {
+			return matched[i].LastModified.After(matched[j].LastModified)
+		}
+		return matched[i].Key > matched[j].Key
+	})
+	var expired []*Object
+	var total int64
+	limit := int64(p.MaxSizeMB) << 20
+	for i, o := range matched {
+		total += o.Size
+		switch {
+		case p.RetentionDays > 0 && now.Sub(o.LastModified) > time.Duration(p.RetentionDays)*24*time.Hour,
+			p.MaxCount > 0 && i >= p.MaxCount,
+			limit > 0 && total > limit:
+			expired = append(expired, o)
+		}
+	}
+	return expired
+}
+
+// Usage 前缀下的对象用量
+type Usage struct {
+	Prefix string     `json:"prefix"`
+	Count  int        `json:"count"`
+	Bytes  int64      `json:"bytes"`
+	Oldest *time.Time `json:"oldest,omitempty"`
+	Newest *time.Time `json:"newest,omitempty"`
+}
+
+// Summarize 统计前缀下的对象数、总大小及最早、最新的修改时间
+func Summarize(objects []*Object, prefix string) *Usage {
+	u := &Usage{Prefix: prefix}
+	for _, o := range objects {
+		if !strings.HasPrefix(o.Key, prefix) {
+			continue
+		}
+		u.Count++
+		u.Bytes += o.Size
+		t := o.LastModified
+		if u.Oldest == nil || t.Before(*u.Oldest) {
+			u.Oldest = &t
+		}
+		if u.Newest == nil || t.After(*u.Newest) {
+			u.Newest = &t
+		}
+	}
+	return u
+}
@@ -0,0 +1,58 @@
+package objectstorage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	objects := []*Object{
+		{Key: "bundle/1", Size: 4 << 20, LastModified: now.Add(-10 * day)},
+		{Key: "bundle/2", Size: 4 << 20, LastModified: now.Add(-3 * day)},
+		{Key: "bundle/3", Size: 4 << 20, LastModified: now.Add(-2 * day)},
+		{Key: "bundle/4", Size: 4 << 20, LastModified: now.Add(-1 * day)},
+		{Key: "trash/1", Size: 1, LastModified: now.Add(-30 * day)},
+	}
+	keys := func(list []*Object) []string {
+		var r []string
+		for _, o := range list {
+			r = append(r, o.Key)
+		}
+		return r
+	}
+	cases := []struct {
+		p    RetentionPolicy
+		want []string
+	}{
+		{RetentionPolicy{Prefix: "bundle/"}, nil},
+		{RetentionPolicy{Prefix: "bundle/", RetentionDays: 7}, []string{"bundle/1"}},
+		{RetentionPolicy{Prefix: "bundle/", MaxCount: 2}, []string{"bundle/2", "bundle/1"}},
+		{RetentionPolicy{Prefix: "bundle/", MaxSizeMB: 9}, []string{"bundle/2", "bundle/1"}},
+		{RetentionPolicy{Prefix: "trash/", RetentionDays: 7}, []string{"trash/1"}},
+	}
+	for _, c := range cases {
+		got := keys(Expired(objects, c.p, now))
+		if len(got) != len(c.want) {
+			t.Fatalf("Expired(%+v) = %v, want %v", c.p, got, c.want)
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Fatalf("Expired(%+v) = %v, want %v", c.p, got, c.want)
+			}
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Now()
+	u := Summarize([]*Object{
+		{Key: "a/1", Size: 10, LastModified: now.Add(-time.Hour)},
+		{Key: "a/2", Size: 5, LastModified: now},
+		{Key: "b/1", Size: 100, LastModified: now},
+	}, "a/")
+	if u.Count != 2 || u.Bytes != 15 || !u.Oldest.Equal(now.Add(-time.Hour)) || !u.Newest.Equal(now) {
+		t.Fatalf("unexpected usage: %+v", u)
+	}
+}
@@ -0,0 +1,193 @@
+package objectstorage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// unsignedPayload 不对请求体计算摘要，便于流式上传，依赖 HTTPS 保证完整性
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config S3 兼容存储的连接配置，MinIO 等自建存储通常需要开启 PathStyle
+type S3Config struct {
+	Endpoint  string // 形如 https://s3.amazonaws.com、http://minio:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool // 使用 endpoint/bucket/key 形式的地址，否则使用 bucket.endpoint/key
+}
+
+// S3Client 使用 SigV4 签名直接调用 S3 REST 接口，仅实现对象的上传、下载、删除与列出
+type S3Client struct {
+	cfg        S3Config
+	signer     *v4.Signer
+	HTTPClient *http.Client
+}
+
+// NewS3Client 创建 S3 客户端
+func NewS3Client(cfg S3Config) (*S3Client, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("对象存储未配置 endpoint 或 bucket")
+	}
+	if !strings.Contains(cfg.Endpoint, "://") {
+		cfg.Endpoint = "https://" + cfg.Endpoint
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &S3Client{
+		cfg:    cfg,
+		signer: v4.NewSigner(),
+		// 大文件传输耗时较长，由调用方通过 context 控制超时
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// Bucket 返回配置的存储桶
+func (s *S3Client) Bucket() string {
+	return s.cfg.Bucket
+}
+
+// objectURL 按寻址方式生成对象地址，key 中的各段分别转义
+func (s *S3Client) objectURL(key string) (string, error) {
+	u, err := url.Parse(s.cfg.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("对象存储 endpoint 无效: %w", err)
+	}
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	escaped := strings.Join(segments, "/")
+	if s.cfg.PathStyle {
+		return fmt.Sprintf("%s://%s/%s/%s", u.Scheme, u.Host, url.PathEscape(s.cfg.Bucket), escaped), nil
+	}
+	return fmt.Sprintf("%s://%s.%s/%s", u.Scheme, s.cfg.Bucket, u.Host, escaped), nil
+}
+
+func (s *S3Client) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	target, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	cred := aws.Credentials{AccessKeyID: s.cfg.AccessKey, SecretAccessKey: s.cfg.SecretKey}
+	if err := s.signer.SignHTTP(ctx, cred, req, unsignedPayload, "s3", s.cfg.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("对象存储请求签名失败: %w", err)
+	}
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, parseS3Error(resp)
+	}
+	return resp, nil
+}
+
+// PutObject 上传对象，S3 单次 PUT 需要预先知道内容长度
+func (s *S3Client) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, body, size, contentType)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// GetObject 下载对象，调用方负责关闭返回的 Body
+func (s *S3Client) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, 0, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// DeleteObject 删除对象，对象不存在时 S3 同样返回成功
+func (s *S3Client) DeleteObject(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, 0, "")
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// listBucketResult ListObjectsV2 的响应
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects 使用 ListObjectsV2 列出前缀下的全部对象，自动翻页
+func (s *S3Client) ListObjects(ctx context.Context, prefix string) ([]*Object, error) {
+	var result []*Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("解析对象列表失败: %w", err)
+		}
+		for _, c := range page.Contents {
+			result = append(result, &Object{Key: c.Key, Size: c.Size, LastModified: c.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return result, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// parseS3Error 解析 S3 返回的 XML 错误
+func parseS3Error(resp *http.Response) error {
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := xml.Unmarshal(raw, &e); err == nil && e.Code != "" {
+		return fmt.Errorf("对象存储返回 %d %s: %s", resp.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Errorf("对象存储返回 %s", resp.Status)
+}
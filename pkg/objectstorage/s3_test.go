@@ -0,0 +1,62 @@
+package objectstorage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestObjectURL(t *testing.T) {
+	cases := []struct {
+		cfg  S3Config
+		key  string
+		want string
+	}{
+		{S3Config{Endpoint: "https://s3.amazonaws.com", Bucket: "k8m"}, "dev/app.tar", "https://k8m.s3.amazonaws.com/dev/app.tar"},
+		{S3Config{Endpoint: "http://minio:9000/", Bucket: "k8m", PathStyle: true}, "/dev/a b.txt", "http://minio:9000/k8m/dev/a%20b.txt"},
+		{S3Config{Endpoint: "minio.local", Bucket: "k8m", PathStyle: true}, "x", "https://minio.local/k8m/x"},
+	}
+	for _, c := range cases {
+		client, err := NewS3Client(c.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := client.objectURL(c.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("objectURL(%q) = %q, want %q", c.key, got, c.want)
+		}
+	}
+}
+
+func TestListObjects(t *testing.T) {
+	pages := map[string]string{
+		"": `<ListBucketResult><Contents><Key>bundle/a</Key><Size>3</Size><LastModified>2026-03-01T08:00:00.000Z</LastModified></Contents>` +
+			`<IsTruncated>true</IsTruncated><NextContinuationToken>next</NextContinuationToken></ListBucketResult>`,
+		"next": `<ListBucketResult><Contents><Key>bundle/b</Key><Size>5</Size><LastModified>2026-03-02T08:00:00.000Z</LastModified></Contents>` +
+			`<IsTruncated>false</IsTruncated></ListBucketResult>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/k8m/" || r.URL.Query().Get("list-type") != "2" || r.URL.Query().Get("prefix") != "bundle/" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("continuation-token")]))
+	}))
+	defer srv.Close()
+
+	client, err := NewS3Client(S3Config{Endpoint: srv.URL, Bucket: "k8m", PathStyle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects, err := client.ListObjects(context.Background(), "bundle/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 || objects[1].Key != "bundle/b" || objects[1].Size != 5 || objects[0].LastModified.Day() != 1 {
+		t.Fatalf("unexpected objects: %+v", objects)
+	}
+}
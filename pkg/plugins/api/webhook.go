@@ -14,10 +14,19 @@ type SendResult struct {
 	Error      error  `json:"-"`
 }
 
+// Attachment 随消息发送的附件，仅邮件等支持附件的接收者会收到。
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
 // Webhook 抽象 webhook 能力，对调用方隐藏具体插件实现和内部逻辑。
 type Webhook interface {
 	// PushMsgToAllTargetByIDs 中文函数注释：向指定接收者ID列表批量推送消息。
 	PushMsgToAllTargetByIDs(msg string, raw string, receiverIDs []string) []*SendResult
+	// PushMsgWithAttachmentsByIDs 中文函数注释：向指定接收者ID列表批量推送带附件的消息，不支持附件的接收者只收到消息。
+	PushMsgWithAttachmentsByIDs(msg string, raw string, attachments []Attachment, receiverIDs []string) []*SendResult
 	// GetNamesByIds 中文函数注释：根据接收者ID列表查询名称列表。
 	GetNamesByIds(ids []string) ([]string, error)
 }
@@ -30,6 +39,11 @@ func (noopWebhook) PushMsgToAllTargetByIDs(msg string, raw string, receiverIDs [
 	return nil
 }
 
+func (noopWebhook) PushMsgWithAttachmentsByIDs(msg string, raw string, attachments []Attachment, receiverIDs []string) []*SendResult {
+	klog.V(4).Infof("Webhook 插件未开启,PushMsgWithAttachmentsByIDs 方法未执行 ")
+	return nil
+}
+
 func (noopWebhook) GetNamesByIds(ids []string) ([]string, error) {
 	klog.V(4).Infof("Webhook 插件未开启,GetNamesById 方法未执行")
 	return []string{}, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/weibaohui/k8m/pkg/plugins/modules"
 	"github.com/weibaohui/k8m/pkg/plugins/modules/ai/service"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/telemetry"
 	"k8s.io/klog/v2"
 )
 
@@ -75,6 +76,7 @@ func (cc *Controller) GPTShell(c *response.Context) {
 		return
 	}
 	defer conn.Close()
+	defer telemetry.TrackWebSocketSession("ai_chat")()
 	klog.V(6).Infof("ws Client connected")
 
 	// 创建一个写锁，用于保护WebSocket写操作
@@ -65,7 +65,7 @@ func createServerConfig(basePath string) *mcp.ServerConfig {
 			auth = after
 		}
 		klog.V(6).Infof("Authorization: %v", auth)
-		if username, err := utils.GetUsernameFromToken(auth, cfg.JwtTokenSecret); err == nil {
+		if username, err := utils.GetUsernameFromToken(auth, cfg.JwtTokenSecret, cfg.JwtLeeway()); err == nil {
 			klog.V(6).Infof("Extracted username from token: %v", username)
 			newCtx = context.WithValue(newCtx, constants.JwtUserName, username)
 		} else {
@@ -95,6 +95,7 @@ func (k *K8sWatchLifecycle) startWatch() {
 		service.PVCService().Watch()
 		service.PVService().Watch()
 		service.IngressService().Watch()
+		service.OOMTrackerService().Watch()
 	})
 }
 
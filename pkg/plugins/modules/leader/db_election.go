@@ -0,0 +1,70 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/service"
+	"k8s.io/klog/v2"
+)
+
+// runWithDBLock 使用数据库锁进行Leader选举，阻塞直到 ctx 结束。
+// 每个重试周期尝试获取或续约锁；持有期间超过 RenewDeadline 未能续约成功则主动放弃Leader身份。
+func runWithDBLock(ctx context.Context, cfg Config, id string) {
+	klog.V(6).Infof("当前实例选举ID: %s", id)
+
+	var (
+		leading     bool
+		lastRenew   time.Time
+		leaderStop  context.CancelFunc
+		stopLeading = func() {
+			leading = false
+			if leaderStop != nil {
+				leaderStop()
+				leaderStop = nil
+			}
+			klog.V(6).Infof("停止作为Leader运行")
+			if cfg.OnStoppedLeading != nil {
+				cfg.OnStoppedLeading()
+			}
+		}
+	)
+
+	ticker := time.NewTicker(cfg.RetryPeriod)
+	defer ticker.Stop()
+	for {
+		acquired, err := service.LockService().TryAcquire(cfg.LockName, id, cfg.LeaseDuration)
+		if err != nil {
+			klog.V(6).Infof("获取数据库选举锁失败: %v", err)
+		}
+
+		switch {
+		case acquired:
+			lastRenew = time.Now()
+			if !leading {
+				leading = true
+				leaderCtx, cancel := context.WithCancel(ctx)
+				leaderStop = cancel
+				klog.V(6).Infof("我成为新的Leader：%s", id)
+				if cfg.OnStartedLeading != nil {
+					go cfg.OnStartedLeading(leaderCtx)
+				}
+			}
+		case leading && (err == nil || time.Since(lastRenew) > cfg.RenewDeadline):
+			// 锁已被其他实例抢占，或长时间无法续约
+			stopLeading()
+		}
+
+		select {
+		case <-ctx.Done():
+			if leading {
+				if err := service.LockService().Release(cfg.LockName, id); err != nil {
+					klog.V(6).Infof("释放数据库选举锁失败: %v", err)
+				}
+				stopLeading()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/weibaohui/k8m/internal/dao"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,7 +31,8 @@ type Config struct {
 }
 
 // Run 启动Leader选举逻辑
-// 选择优先级：指定ClusterID -> InCluster -> 本地kubeconfig；均不可用时退化为本地Leader（不进行选举）
+// 选择优先级：指定ClusterID -> InCluster -> 本地kubeconfig；
+// 均不可用时，若使用 MySQL/PostgreSQL 则通过数据库锁选举，使用 sqlite 则退化为本地Leader（不进行选举）
 func Run(ctx context.Context, cfg Config) error {
 	clientset, hasCluster, err := utils.GetClientSet(cfg.ClusterID)
 	if err != nil {
@@ -41,16 +43,6 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.Namespace = utils.DetectNamespace()
 	}
 
-	// 非集群模式：没有指定集群、不是InCluster、也没有本地kubeconfig
-	if !hasCluster {
-		klog.V(6).Infof("无可用的K8s集群，直接作为Leader运行（不进行选举）")
-		_ = patchPodRoleLabel(ctx, clientset, "leader")
-		if cfg.OnStartedLeading != nil {
-			cfg.OnStartedLeading(ctx)
-		}
-		return nil
-	}
-
 	// 默认参数
 	if cfg.LeaseDuration == 0 {
 		cfg.LeaseDuration = 15 * time.Second
@@ -62,6 +54,23 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.RetryPeriod = 2 * time.Second
 	}
 
+	// 非集群模式，但多个实例共享 MySQL/PostgreSQL：使用数据库锁进行选举
+	if !hasCluster && dao.Driver() != dao.DriverSqlite {
+		klog.V(6).Infof("无可用的K8s集群，使用数据库锁进行Leader选举（锁=%s）", cfg.LockName)
+		runWithDBLock(ctx, cfg, utils.GenerateInstanceID())
+		return nil
+	}
+
+	// 非集群模式：没有指定集群、不是InCluster、也没有本地kubeconfig，且使用本地sqlite
+	if !hasCluster {
+		klog.V(6).Infof("无可用的K8s集群，直接作为Leader运行（不进行选举）")
+		_ = patchPodRoleLabel(ctx, clientset, "leader")
+		if cfg.OnStartedLeading != nil {
+			cfg.OnStartedLeading(ctx)
+		}
+		return nil
+	}
+
 	id := utils.GenerateInstanceID()
 	klog.V(6).Infof("当前实例选举ID: %s", id)
 	lock := &resourcelock.LeaseLock{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/weibaohui/k8m/pkg/flag"
 	"github.com/weibaohui/k8m/pkg/plugins"
 	"github.com/weibaohui/k8m/pkg/plugins/eventbus"
 	"github.com/weibaohui/k8m/pkg/service"
@@ -61,18 +62,21 @@ func (l *LeaderLifecycle) Start(ctx plugins.BaseContext) error {
 	l.cleanupCancel = cancel
 
 	go func() {
-		//TODO 改为配置项
+		cfg := flag.Init()
 		leaderCfg := Config{
-			LockName:      "k8m-leader-lock",
-			LeaseDuration: 30 * time.Second,
-			RenewDeadline: 20 * time.Second,
-			RetryPeriod:   5 * time.Second,
+			Namespace:     cfg.LeaseNamespace,
+			ClusterID:     cfg.HostClusterID,
+			LockName:      cfg.LeaderLockName,
+			LeaseDuration: time.Duration(cfg.LeaderLeaseDurationSeconds) * time.Second,
+			RenewDeadline: time.Duration(cfg.LeaderRenewDeadlineSeconds) * time.Second,
+			RetryPeriod:   time.Duration(cfg.LeaderRetryPeriodSeconds) * time.Second,
 			OnStartedLeading: func(c context.Context) {
 				klog.V(6).Infof("成为Leader")
 				service.LeaderService().SetCurrentLeader(true)
 				ctx.Bus().Publish(eventbus.Event{
 					Type: eventbus.EventLeaderElected,
 				})
+				go cleanSharedState(c)
 			},
 			OnStoppedLeading: func() {
 				klog.V(6).Infof("不再是Leader")
@@ -89,6 +93,20 @@ func (l *LeaderLifecycle) Start(ctx plugins.BaseContext) error {
 	return nil
 }
 
+// cleanSharedState 作为Leader期间定期清理过期的共享状态，失去Leader后停止
+func cleanSharedState(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+	for {
+		service.StateStoreService().CleanExpired()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // StartCron 该插件不使用定时任务，留空实现
 func (l *LeaderLifecycle) StartCron(ctx plugins.BaseContext, spec string) error {
 	return nil
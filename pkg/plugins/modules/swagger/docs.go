@@ -24,21 +24,19 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/ai_prompt/delete/{ids}": {
+        "/admin/access/rule/check": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "删除AI提示词",
+                "description": "按当前已启用的规则校验指定用户在给定来源、时间执行操作是否被允许，用于确认规则效果",
+                "summary": "校验访问",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "提示词ID，多个用逗号分隔",
-                        "name": "ids",
-                        "in": "path",
-                        "required": true
+                        "description": "访问请求",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config.CheckAccessRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -48,29 +46,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/ai_prompt/id/{id}/enabled/{enabled}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "快捷保存AI提示词启用状态",
+                ]
+            }
+        },
+        "/admin/access/rule/delete/{ids}": {
+            "post": {
+                "summary": "删除访问规则",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "提示词ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "启用状态，true或false",
-                        "name": "enabled",
+                        "description": "规则ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -82,17 +73,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/ai_prompt/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取AI提示词列表",
+                ]
+            }
+        },
+        "/admin/access/rule/list": {
+            "get": {
+                "summary": "访问规则列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -100,17 +91,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/ai_prompt/load": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "加载内置AI提示词",
+                ]
+            }
+        },
+        "/admin/access/rule/operations": {
+            "get": {
+                "summary": "访问规则可限制的操作类别",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -118,35 +109,29 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/ai_prompt/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取AI提示词选项列表",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/admin/ai_prompt/save": {
+        "/admin/access/rule/save": {
             "post": {
-                "security": [
+                "description": "roles 为平台或集群角色，为空或 * 表示全部用户；operations 为空表示全部请求；cidrs、countries、days、start_time/end_time 至少设置一项。\n启用的规则若会阻止当前管理员访问管理接口，将拒绝保存",
+                "summary": "保存访问规则",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "访问规则",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AccessRule"
+                        }
                     }
                 ],
-                "summary": "保存AI提示词",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -154,22 +139,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/ai_prompt/toggle/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "启用/禁用AI提示词",
+                ]
+            }
+        },
+        "/admin/admission/policy/delete/{ids}": {
+            "post": {
+                "summary": "删除准入策略",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "提示词ID",
-                        "name": "id",
+                        "description": "策略ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -181,17 +166,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/ai_prompt/types": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取AI提示词类型列表",
+                ]
+            }
+        },
+        "/admin/admission/policy/list": {
+            "get": {
+                "summary": "准入策略列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -199,65 +184,56 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/aws/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "保存AWS EKS集群配置到数据库并注册集群",
-                "summary": "保存AWS EKS集群配置",
+                ]
+            }
+        },
+        "/admin/admission/policy/save": {
+            "post": {
+                "description": "新建或编辑策略。expression 为返回布尔值的 expr 表达式，可用变量：cluster、user、operation、group、version、kind、namespace、name、object、patch，函数 images(object) 返回全部容器镜像",
+                "summary": "保存准入策略",
                 "parameters": [
                     {
-                        "description": "AWS EKS配置信息",
-                        "name": "request",
+                        "description": "准入策略",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.AdmissionPolicy"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "保存成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/config/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "保存集群的kom相关配置参数",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cluster"
-                ],
-                "summary": "保存集群配置参数",
+                ]
+            }
+        },
+        "/admin/admission/policy/test": {
+            "post": {
+                "description": "使用给定资源评估表达式，返回是否命中，不影响已保存的策略",
+                "summary": "试运行准入策略",
                 "parameters": [
                     {
-                        "description": "集群配置参数",
-                        "name": "config",
+                        "description": "表达式与资源",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/config.TestPolicyRequest"
                         }
                     }
                 ],
@@ -268,32 +244,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/config/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据集群ID获取kom相关配置参数",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cluster"
-                ],
-                "summary": "获取集群配置参数",
+                ]
+            }
+        },
+        "/admin/ai_prompt/delete/{ids}": {
+            "post": {
+                "summary": "删除AI提示词",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群ID",
-                        "name": "id",
+                        "description": "提示词ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -302,21 +268,36 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.KubeConfig"
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/file/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/ai_prompt/id/{id}/enabled/{enabled}": {
+            "post": {
+                "summary": "快捷保存AI提示词启用状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "提示词ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "启用状态，true或false",
+                        "name": "enabled",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "description": "获取所有已发现集群的kubeconfig文件名列表，用于下拉选项",
-                "summary": "获取文件类型的集群选项",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -324,18 +305,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/kubeconfig/remove": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "从数据库中删除KubeConfig配置",
-                "summary": "删除KubeConfig",
+                ]
+            }
+        },
+        "/admin/ai_prompt/list": {
+            "get": {
+                "summary": "获取AI提示词列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -343,18 +323,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/kubeconfig/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "保存KubeConfig配置到数据库",
-                "summary": "保存KubeConfig",
+                ]
+            }
+        },
+        "/admin/ai_prompt/load": {
+            "post": {
+                "summary": "加载内置AI提示词",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -362,18 +341,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/scan": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "扫描本地Kubeconfig文件目录以发现新的集群",
-                "summary": "扫描集群",
+                ]
+            }
+        },
+        "/admin/ai_prompt/option_list": {
+            "get": {
+                "summary": "获取AI提示词选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -381,84 +359,62 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/token/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "保存Token方式集群配置到数据库并注册集群",
-                "summary": "保存Token方式集群配置",
-                "parameters": [
-                    {
-                        "description": "Token集群配置信息",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/ai_prompt/save": {
+            "post": {
+                "summary": "保存AI提示词",
                 "responses": {
                     "200": {
-                        "description": "保存成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster/{cluster}/disconnect": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "断开一个正在运行的集群的连接",
-                "summary": "断开集群连接",
+                ]
+            }
+        },
+        "/admin/ai_prompt/toggle/{id}": {
+            "post": {
+                "summary": "启用/禁用AI提示词",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Base64编码的集群ID",
-                        "name": "cluster",
+                        "description": "提示词ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "已执行，请稍后刷新",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/cluster/{cluster}/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取指定集群下所有用户的权限角色列表",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群ID(base64)",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/ai_prompt/types": {
+            "get": {
+                "summary": "获取AI提示词类型列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -466,22 +422,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/cluster/{cluster}/ns/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取指定集群下所有命名空间名称",
+                ]
+            }
+        },
+        "/admin/approval/rule/delete/{ids}": {
+            "post": {
+                "description": "删除规则不影响已提交的变更申请",
+                "summary": "删除变更审批规则",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群ID(base64)",
-                        "name": "cluster",
+                        "description": "规则ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -493,33 +450,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/cluster/{cluster}/role/{role}/user/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取指定集群指定角色的用户权限列表",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群ID(base64)",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "角色",
-                        "name": "role",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/approval/rule/list": {
+            "get": {
+                "summary": "变更审批规则列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -527,38 +468,27 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/cluster/{cluster}/role/{role}/{authorization_type}/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量为指定集群添加用户角色权限",
+                ]
+            }
+        },
+        "/admin/approval/rule/save": {
+            "post": {
+                "description": "namespaces 为受保护的命名空间，逗号分隔，支持 * 通配；命中规则的变更操作将转为变更申请，由另一名审批人批准后执行",
+                "summary": "保存变更审批规则",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群ID(base64)",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "角色",
-                        "name": "role",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "授权类型",
-                        "name": "authorization_type",
-                        "in": "path",
-                        "required": true
+                        "description": "变更审批规则",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApprovalRule"
+                        }
                     }
                 ],
                 "responses": {
@@ -568,26 +498,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除集群权限",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "权限ID，多个用逗号分隔",
-                        "name": "ids",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/artifact/cleanup": {
+            "post": {
+                "summary": "按保留策略立即清理制品",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -595,24 +516,26 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/update_blacklist_namespaces/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新指定集群用户角色的黑名单命名空间字段",
+                ]
+            }
+        },
+        "/admin/artifact/delete": {
+            "post": {
+                "summary": "删除制品",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "权限ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "{keys: 制品 key 列表}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -622,23 +545,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/update_namespaces/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新指定集群用户角色的命名空间字段",
+                ]
+            }
+        },
+        "/admin/artifact/download": {
+            "get": {
+                "summary": "下载制品",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "权限ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "制品 key",
+                        "name": "key",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -646,27 +569,26 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     }
-                }
-            }
-        },
-        "/admin/cluster_permissions/user/{username}/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取指定用户已获得授权的集群",
+                ]
+            }
+        },
+        "/admin/artifact/list": {
+            "get": {
+                "summary": "列出制品",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "用户名",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
+                        "description": "制品类别，如 support-bundle，为空时列出全部",
+                        "name": "category",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -676,21 +598,21 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/condition/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除条件",
+                ]
+            }
+        },
+        "/admin/artifact/policy/delete/{ids}": {
+            "post": {
+                "summary": "删除制品保留策略",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "条件ID，多个用逗号分隔",
+                        "description": "策略ID，多个用逗号分隔",
                         "name": "ids",
                         "in": "path",
                         "required": true
@@ -703,17 +625,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/condition/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取条件列表",
+                ]
+            }
+        },
+        "/admin/artifact/policy/list": {
+            "get": {
+                "summary": "获取制品保留策略列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -721,49 +643,27 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/condition/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建或更新条件",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/admin/condition/save/id/{id}/status/{status}": {
+        "/admin/artifact/policy/save": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "快速保存条件状态",
+                "description": "按类别新增或更新，保留天数、数量、总大小均为 0 时不自动清理",
+                "summary": "保存制品保留策略",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "条件ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "状态，例如：true、false",
-                        "name": "status",
-                        "in": "path",
-                        "required": true
+                        "description": "保留策略",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ArtifactPolicy"
+                        }
                     }
                 ],
                 "responses": {
@@ -773,17 +673,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/config/all": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取系统配置",
+                ]
+            }
+        },
+        "/admin/artifact/usage": {
+            "get": {
+                "description": "返回存储驱动、存储位置，以及各类别制品的数量、占用空间、最早与最新时间和保留策略",
+                "summary": "制品存储用量报表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -791,26 +692,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/config/sso/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除SSO配置",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "SSO配置ID，多个用逗号分隔",
-                        "name": "ids",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/clock/check": {
+            "post": {
+                "summary": "立即检查时钟偏差",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -818,17 +710,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/config/sso/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取SSO配置列表",
+                ]
+            }
+        },
+        "/admin/clock/status": {
+            "get": {
+                "description": "返回最近一次检查中各集群 apiserver 与 NTP 服务器相对 k8m 服务器的时间偏差",
+                "summary": "获取时钟偏差检查结果",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -836,77 +729,65 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/config/sso/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建或更新SSO配置",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/admin/config/sso/save/id/{id}/status/{enabled}": {
+        "/admin/cluster/aws/save": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "快速更新SSO配置状态",
+                "description": "保存AWS EKS集群配置到数据库并注册集群",
+                "summary": "保存AWS EKS集群配置",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "SSO配置ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "状态，例如：true、false",
-                        "name": "enabled",
-                        "in": "path",
-                        "required": true
+                        "description": "AWS EKS配置信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "保存成功",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/config/update": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/cluster/config/save": {
+            "post": {
+                "description": "保存集群的kom相关配置参数",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "更新系统配置",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cluster"
+                ],
+                "summary": "保存集群配置参数",
                 "parameters": [
                     {
-                        "description": "配置信息",
+                        "description": "集群配置参数",
                         "name": "config",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Config"
+                            "type": "object"
                         }
                     }
                 ],
@@ -917,23 +798,32 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/menu/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/cluster/config/{id}": {
+            "get": {
+                "description": "根据集群ID获取kom相关配置参数",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "根据ID批量删除菜单版本",
-                "summary": "删除菜单",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cluster"
+                ],
+                "summary": "获取集群配置参数",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "菜单ID，多个用逗号分隔",
-                        "name": "ids",
+                        "description": "集群ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -942,52 +832,40 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.KubeConfig"
                         }
                     }
-                }
-            }
-        },
-        "/admin/menu/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取菜单修改历史记录，按时间倒序排列",
-                "summary": "获取菜单历史记录",
+                ]
+            }
+        },
+        "/admin/cluster/file/option_list": {
+            "get": {
+                "description": "获取所有已发现集群的kubeconfig文件名列表，用于下拉选项",
+                "summary": "获取文件类型的集群选项",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Menu"
-                            }
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/menu/history/delete/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID删除单个菜单历史记录",
-                "summary": "删除菜单历史记录",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "菜单历史记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/cluster/kubeconfig/remove": {
+            "post": {
+                "description": "从数据库中删除KubeConfig配置",
+                "summary": "删除KubeConfig",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -995,80 +873,98 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/menu/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有菜单版本信息",
-                "summary": "获取菜单列表",
+                ]
+            }
+        },
+        "/admin/cluster/kubeconfig/save": {
+            "post": {
+                "description": "保存KubeConfig配置到数据库",
+                "summary": "保存KubeConfig",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Menu"
-                            }
+                            "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/menu/save": {
+        "/admin/cluster/scan": {
             "post": {
+                "description": "扫描本地Kubeconfig文件目录以发现新的集群",
+                "summary": "扫描集群",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "新增或更新菜单（每次操作生成新版本）",
-                "consumes": [
-                    "application/json"
-                ],
-                "summary": "保存菜单",
+                ]
+            }
+        },
+        "/admin/cluster/token/save": {
+            "post": {
+                "description": "保存Token方式集群配置到数据库并注册集群",
+                "summary": "保存Token方式集群配置",
                 "parameters": [
                     {
-                        "description": "菜单内容",
-                        "name": "data",
+                        "description": "Token集群配置信息",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Menu"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "保存成功",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/ai/model/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除AI模型配置",
+                ]
+            }
+        },
+        "/admin/cluster/{cluster}/capabilities": {
+            "get": {
+                "summary": "获取集群能力与功能开关",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "模型ID，多个用逗号分隔",
-                        "name": "ids",
+                        "description": "base64编码的集群ID",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "为 true 时重新探测",
+                        "name": "refresh",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1078,69 +974,138 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/ai/model/id/{id}/think/{status}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "快速保存AI模型思考状态",
+                ]
+            }
+        },
+        "/admin/cluster/{cluster}/capabilities/save": {
+            "post": {
+                "description": "开关优先于探测结果，可关闭已安装的功能，或在探测不准时强制开启",
+                "summary": "设置集群功能开关",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "模型ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "base64编码的集群ID",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "功能名称与开关",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cluster.CapabilityModeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/cluster/{cluster}/disconnect": {
+            "post": {
+                "description": "断开一个正在运行的集群的连接",
+                "summary": "断开集群连接",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "状态，例如：true、false",
-                        "name": "status",
+                        "description": "Base64编码的集群ID",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "已执行，请稍后刷新",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/plugins/ai/model/list": {
+        "/admin/cluster/{cluster}/support_bundle": {
             "get": {
-                "security": [
+                "description": "将节点条件、控制面 Pod 状态与日志、异常工作负载、Warning 事件以及 k8m 记录的该集群操作审计与终端命令打包为 tar.gz，用于提交工单。\n文本中的口令、令牌等敏感值已掩码；各项尽力收集，失败原因记录在包内 errors.txt",
+                "summary": "下载集群支持包",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "base64编码的集群ID",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件与审计记录的时间范围，如 6h、48h，默认24h，最大168h",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每个控制面容器读取的日志行数，默认500，最大5000",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "为 true 时同时保存到制品存储",
+                        "name": "save",
+                        "in": "query"
                     }
                 ],
-                "summary": "获取AI模型配置列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/plugins/ai/model/save": {
+        "/admin/cluster_agent/delete/{ids}": {
             "post": {
-                "security": [
+                "description": "删除后令牌失效，在线隧道立即断开",
+                "summary": "删除集群 agent",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "agent ID，多个用逗号分隔",
+                        "name": "ids",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "summary": "创建或更新AI模型配置",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1148,21 +1113,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/ai/model/test/id/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "测试AI模型连接",
+                ]
+            }
+        },
+        "/admin/cluster_agent/id/{id}/reset_token": {
+            "post": {
+                "description": "签发新令牌并返回，原令牌立即失效，在线隧道随之断开，agent 需使用新令牌重新接入",
+                "summary": "重置集群 agent 令牌",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "模型ID",
+                        "description": "agent ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1175,41 +1141,44 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/ai/run_config": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取AI运行配置",
+                ]
+            }
+        },
+        "/admin/cluster_agent/list": {
+            "get": {
+                "summary": "集群 agent 列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AIRunConfig"
+                            "type": "string"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新AI运行配置",
+                ]
+            }
+        },
+        "/admin/cluster_agent/save": {
+            "post": {
+                "description": "新建时签发接入令牌，令牌与名称绑定，仅在新建与重置时返回一次。名称创建后不可修改，停用后在线隧道立即断开",
+                "summary": "保存集群 agent",
                 "parameters": [
                     {
-                        "description": "AI运行配置",
-                        "name": "config",
+                        "description": "集群 agent",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AIRunConfig"
+                            "$ref": "#/definitions/models.ClusterAgent"
                         }
                     }
                 ],
@@ -1220,46 +1189,53 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/helm/repo/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除一个或多个Helm仓库",
-                "summary": "删除Helm仓库",
+                ]
+            }
+        },
+        "/admin/cluster_permissions/cluster/{cluster}/list": {
+            "get": {
+                "summary": "获取指定集群下所有用户的权限角色列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "要删除的仓库ID，多个用逗号分隔",
-                        "name": "ids",
+                        "description": "集群ID(base64)",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/plugins/helm/repo/list": {
+        "/admin/cluster_permissions/cluster/{cluster}/ns/list": {
             "get": {
-                "security": [
+                "summary": "获取指定集群下所有命名空间名称",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群ID(base64)",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取所有Helm仓库信息",
-                "summary": "Helm仓库列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1267,18 +1243,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/plugins/helm/repo/option_list": {
+        "/admin/cluster_permissions/cluster/{cluster}/role/{role}/user/list": {
             "get": {
-                "security": [
+                "summary": "获取指定集群指定角色的用户权限列表",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群ID(base64)",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取所有Helm仓库名称，用于下拉选项",
-                "summary": "Helm仓库选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1286,77 +1277,94 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/helm/repo/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "添加或更新一个Helm仓库信息",
-                "summary": "添加或更新Helm仓库",
+                ]
+            }
+        },
+        "/admin/cluster_permissions/cluster/{cluster}/role/{role}/{authorization_type}/save": {
+            "post": {
+                "summary": "批量为指定集群添加用户角色权限",
                 "parameters": [
                     {
-                        "description": "Helm仓库信息",
-                        "name": "repo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.HelmRepository"
-                        }
-                    }
-                ],
-                "responses": {
+                        "type": "string",
+                        "description": "集群ID(base64)",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权类型",
+                        "name": "authorization_type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/helm/repo/update_index": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "更新指定Helm仓库的索引信息",
-                "summary": "更新Helm仓库索引",
+                ]
+            }
+        },
+        "/admin/cluster_permissions/delete/{ids}": {
+            "post": {
+                "summary": "删除集群权限",
                 "parameters": [
                     {
-                        "description": "要更新索引的仓库ID，多个用逗号分隔",
+                        "type": "string",
+                        "description": "权限ID，多个用逗号分隔",
                         "name": "ids",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/event/status/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/cluster_permissions/update_blacklist_namespaces/{id}": {
+            "post": {
+                "summary": "更新指定集群用户角色的黑名单命名空间字段",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "权限ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取巡检事件状态选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1364,24 +1372,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/record/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据巡检计划ID获取对应的巡检记录列表",
-                "summary": "获取巡检记录列表",
+                ]
+            }
+        },
+        "/admin/cluster_permissions/update_namespaces/{id}": {
+            "post": {
+                "summary": "更新指定集群用户角色的命名空间字段",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "巡检计划ID",
+                        "type": "integer",
+                        "description": "权限ID",
                         "name": "id",
-                        "in": "path"
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1391,22 +1399,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除巡检计划",
+                ]
+            }
+        },
+        "/admin/cluster_permissions/user/{username}/list": {
+            "get": {
+                "summary": "获取指定用户已获得授权的集群",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "巡检计划ID，多个用逗号分隔",
-                        "name": "ids",
+                        "description": "用户名",
+                        "name": "username",
                         "in": "path",
                         "required": true
                     }
@@ -1418,42 +1426,27 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/id/{id}/summary/cluster/{cluster}/start_time/{start_time}/end_time/{end_time}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "统计指定巡检计划的执行情况，支持按时间范围和集群过滤",
-                "summary": "统计巡检计划执行情况",
+                ]
+            }
+        },
+        "/admin/command/policy/check": {
+            "post": {
+                "description": "按当前已启用的策略校验命令，用于确认策略效果，不会执行命令",
+                "summary": "校验命令",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "巡检计划ID",
-                        "name": "id",
-                        "in": "path"
-                    },
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path"
-                    },
-                    {
-                        "type": "string",
-                        "description": "开始时间(RFC3339格式)",
-                        "name": "start_time",
-                        "in": "path"
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束时间(RFC3339格式)",
-                        "name": "end_time",
-                        "in": "path"
+                        "description": "命令",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config.CheckCommandRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1463,33 +1456,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/id/{id}/update_script_code": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新巡检脚本代码",
+                ]
+            }
+        },
+        "/admin/command/policy/delete/{ids}": {
+            "post": {
+                "summary": "删除命令策略",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "巡检计划ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "策略ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "脚本代码",
-                        "name": "script_codes",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 ],
                 "responses": {
@@ -1499,17 +1483,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取巡检计划列表",
+                ]
+            }
+        },
+        "/admin/command/policy/list": {
+            "get": {
+                "summary": "命令策略列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1517,24 +1501,27 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/record/id/{id}/event/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取巡检事件列表",
+                ]
+            }
+        },
+        "/admin/command/policy/save": {
+            "post": {
+                "description": "pattern 为正则表达式，匹配完整命令行的任意部分；scopes 可选 terminal、exec、file，为空表示全部",
+                "summary": "保存命令策略",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "巡检记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "命令策略",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CommandPolicy"
+                        }
                     }
                 ],
                 "responses": {
@@ -1544,22 +1531,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/record/id/{id}/output/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取巡检脚本输出列表",
+                ]
+            }
+        },
+        "/admin/condition/delete/{ids}": {
+            "post": {
+                "summary": "删除条件",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "巡检记录ID",
-                        "name": "id",
+                        "description": "条件ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -1571,27 +1558,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/record/id/{id}/push": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "将指定巡检记录的AI总结推送到所有配置的Webhook接收器",
-                "summary": "推送巡检记录",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "巡检记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/condition/list": {
+            "get": {
+                "summary": "获取条件列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1599,45 +1576,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/record/id/{id}/summary": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "为指定巡检记录生成AI总结",
-                "summary": "生成巡检记录AI总结",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "巡检记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/admin/plugins/inspection/schedule/save": {
+        "/admin/condition/save": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "保存巡检计划",
+                "summary": "创建或更新条件",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1645,21 +1594,21 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/save/id/{id}/status/{enabled}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "快速更新巡检计划状态",
+                ]
+            }
+        },
+        "/admin/condition/save/id/{id}/status/{status}": {
+            "post": {
+                "summary": "快速保存条件状态",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "巡检计划ID",
+                        "description": "条件ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1667,7 +1616,7 @@ var doc = `{
                     {
                         "type": "string",
                         "description": "状态，例如：true、false",
-                        "name": "enabled",
+                        "name": "status",
                         "in": "path",
                         "required": true
                     }
@@ -1679,26 +1628,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/schedule/start/id/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "启动巡检计划，马上执行一次",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "巡检计划ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/config/all": {
+            "get": {
+                "summary": "获取系统配置",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1706,21 +1646,21 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/script/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除Lua脚本",
+                ]
+            }
+        },
+        "/admin/config/sso/delete/{ids}": {
+            "post": {
+                "summary": "删除SSO配置",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "脚本ID，多个用逗号分隔",
+                        "description": "SSO配置ID，多个用逗号分隔",
                         "name": "ids",
                         "in": "path",
                         "required": true
@@ -1733,17 +1673,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/script/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Lua脚本列表",
+                ]
+            }
+        },
+        "/admin/config/sso/list": {
+            "get": {
+                "summary": "获取SSO配置列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1751,17 +1691,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/script/load": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "加载内置Lua脚本",
+                ]
+            }
+        },
+        "/admin/config/sso/save": {
+            "post": {
+                "summary": "创建或更新SSO配置",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1769,17 +1709,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/inspection/script/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/config/sso/save/id/{id}/status/{enabled}": {
+            "post": {
+                "summary": "快速更新SSO配置状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "SSO配置ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态，例如：true、false",
+                        "name": "enabled",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取Lua脚本选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1787,17 +1743,28 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/plugins/inspection/script/save": {
+        "/admin/config/update": {
             "post": {
-                "security": [
+                "summary": "更新系统配置",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "配置信息",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Config"
+                        }
                     }
                 ],
-                "summary": "保存Lua脚本",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1805,22 +1772,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/server/connect/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "连接指定MCP服务器",
+                ]
+            }
+        },
+        "/admin/crd_condition/delete/{ids}": {
+            "post": {
+                "description": "删除后恢复默认配置：展示全部条件，以 Ready 为主条件",
+                "summary": "删除自定义资源状态看板配置",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP服务器名称",
-                        "name": "name",
+                        "description": "配置ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -1832,25 +1800,44 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/plugins/mcp_runtime/server/delete": {
-            "post": {
+        "/admin/crd_condition/list": {
+            "get": {
+                "summary": "获取自定义资源状态看板配置列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除MCP服务器",
+                ]
+            }
+        },
+        "/admin/crd_condition/save": {
+            "post": {
+                "description": "按 group、kind 新增或更新，types、fields 为逗号分隔的条件类型与状态字段路径",
+                "summary": "保存自定义资源状态看板配置",
                 "parameters": [
                     {
-                        "description": "删除请求体包含IDs数组",
-                        "name": "request",
+                        "description": "看板配置",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.CRDConditionConfig"
                         }
                     }
                 ],
@@ -1861,62 +1848,65 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/server/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取MCP服务器列表",
+                ]
+            }
+        },
+        "/admin/encryption/rotate": {
+            "post": {
+                "description": "使用当前主密钥重新加密所有未使用当前主密钥的敏感字段，包括旧主密钥、内置静态密钥加密的数据以及明文。\n轮换主密钥的步骤：将旧主密钥移入 MASTER_KEY_PREVIOUS，设置新的 MASTER_KEY 与 MASTER_KEY_ID 后重启，调用本接口，确认状态中不再有旧版本后移除旧主密钥",
+                "summary": "重新加密敏感字段",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.EncryptionRotateResult"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/server/log/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取MCP服务器日志列表",
+                ]
+            }
+        },
+        "/admin/encryption/status": {
+            "get": {
+                "description": "当前主密钥版本，以及 kubeconfig、镜像仓库凭据、令牌等敏感字段按主密钥版本统计的记录数。legacy 为内置静态密钥加密，plaintext 为明文",
+                "summary": "敏感字段加密状态",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.EncryptionStatus"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/server/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建或更新MCP服务器",
+                ]
+            }
+        },
+        "/admin/inbound_webhook/delete/{ids}": {
+            "post": {
+                "summary": "删除入站 webhook",
                 "parameters": [
                     {
-                        "description": "MCP服务器配置信息",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.MCPServerConfig"
-                        }
+                        "type": "string",
+                        "description": "webhook ID，多个用逗号分隔",
+                        "name": "ids",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1926,31 +1916,25 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/server/save/id/{id}/status/{status}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "快速更新MCP服务器状态",
+                ]
+            }
+        },
+        "/admin/inbound_webhook/id/{id}/reset_secret": {
+            "post": {
+                "description": "生成新的签名密钥并返回，原密钥立即失效",
+                "summary": "重置入站 webhook 签名密钥",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "MCP服务器ID",
+                        "description": "webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "服务器状态(true/false)",
-                        "name": "status",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1960,33 +1944,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/tool/save/id/{id}/status/{status}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "快速更新MCP工具状态",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "工具ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "状态，例如：true、false",
-                        "name": "status",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/inbound_webhook/list": {
+            "get": {
+                "summary": "入站 webhook 列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1994,24 +1962,27 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/plugins/mcp_runtime/tool/server/{name}/tools/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取指定MCP服务器的工具列表",
+                ]
+            }
+        },
+        "/admin/inbound_webhook/save": {
+            "post": {
+                "description": "action 可选 restart、set_image、apply_template。新建时生成地址标识与签名密钥，签名密钥仅在新建与重置时返回一次。\n以创建人身份执行操作",
+                "summary": "保存入站 webhook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "MCP服务器名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "入站 webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.InboundWebhook"
+                        }
                     }
                 ],
                 "responses": {
@@ -2021,23 +1992,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user/2fa/disable/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "禁用指定用户的二步验证",
-                "summary": "禁用用户2FA",
+                ]
+            }
+        },
+        "/admin/menu/delete/{ids}": {
+            "post": {
+                "description": "根据ID批量删除菜单版本",
+                "summary": "删除菜单",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "用户ID",
-                        "name": "id",
+                        "description": "菜单ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -2049,23 +2020,45 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/user/delete/{ids}": {
-            "post": {
+        "/admin/menu/history": {
+            "get": {
+                "description": "获取菜单修改历史记录，按时间倒序排列",
+                "summary": "获取菜单历史记录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Menu"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID批量删除用户",
-                "summary": "删除用户",
+                ]
+            }
+        },
+        "/admin/menu/history/delete/{id}": {
+            "delete": {
+                "description": "根据ID删除单个菜单历史记录",
+                "summary": "删除菜单历史记录",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "用户ID，多个用逗号分隔",
-                        "name": "ids",
+                        "type": "integer",
+                        "description": "菜单历史记录ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2077,71 +2070,51 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有用户信息",
-                "summary": "获取用户列表",
+                ]
+            }
+        },
+        "/admin/menu/list": {
+            "get": {
+                "description": "获取所有菜单版本信息",
+                "summary": "获取菜单列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.User"
+                                "$ref": "#/definitions/models.Menu"
                             }
                         }
                     }
-                }
-            }
-        },
-        "/admin/user/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取用户选项列表，用于下拉选择",
-                "summary": "获取用户选项列表",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/admin/user/save": {
+        "/admin/menu/save": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "新增或更新用户信息",
+                "description": "新增或更新菜单（每次操作生成新版本）",
                 "consumes": [
                     "application/json"
                 ],
-                "summary": "保存用户",
+                "summary": "保存菜单",
                 "parameters": [
                     {
-                        "description": "用户信息",
+                        "description": "菜单内容",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Menu"
                         }
                     }
                 ],
@@ -2153,30 +2126,22 @@ var doc = `{
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/admin/user/save/id/{id}/status/{disabled}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID快速更新用户启用/禁用状态",
-                "summary": "快速更新用户状态",
+                ]
+            }
+        },
+        "/admin/plugins/ai/model/delete/{ids}": {
+            "post": {
+                "summary": "删除AI模型配置",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "状态，例如：true、false",
-                        "name": "disabled",
+                        "description": "模型ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -2188,37 +2153,31 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user/update_psw/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID更新用户密码",
-                "consumes": [
-                    "application/json"
-                ],
-                "summary": "更新用户密码",
+                ]
+            }
+        },
+        "/admin/plugins/ai/model/id/{id}/think/{status}": {
+            "post": {
+                "summary": "快速保存AI模型思考状态",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "用户ID",
+                        "type": "integer",
+                        "description": "模型ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "新密码信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.User"
-                        }
+                        "type": "string",
+                        "description": "状态，例如：true、false",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2228,27 +2187,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user_group/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "根据ID批量删除用户组",
-                "summary": "删除用户组",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "用户组ID，多个用逗号分隔",
-                        "name": "ids",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/plugins/ai/model/list": {
+            "get": {
+                "summary": "获取AI模型配置列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2256,76 +2205,86 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user_group/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有用户组信息",
-                "summary": "获取用户组列表",
+                ]
+            }
+        },
+        "/admin/plugins/ai/model/save": {
+            "post": {
+                "summary": "创建或更新AI模型配置",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.UserGroup"
-                            }
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user_group/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/plugins/ai/model/test/id/{id}": {
+            "post": {
+                "summary": "测试AI模型连接",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "模型ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "description": "获取所有用户组的选项（仅ID和名称）",
-                "summary": "用户组选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": {
-                                    "type": "string"
-                                }
-                            }
+                            "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/user_group/save": {
-            "post": {
+        "/admin/plugins/ai/run_config": {
+            "get": {
+                "summary": "获取AI运行配置",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AIRunConfig"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "新增或更新用户组信息",
-                "consumes": [
-                    "application/json"
-                ],
-                "summary": "保存用户组",
+                ]
+            },
+            "post": {
+                "summary": "更新AI运行配置",
                 "parameters": [
                     {
-                        "description": "用户组信息",
-                        "name": "data",
+                        "description": "AI运行配置",
+                        "name": "config",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserGroup"
+                            "$ref": "#/definitions/models.AIRunConfig"
                         }
                     }
                 ],
@@ -2333,57 +2292,68 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/admin/user_group/save_menu": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "保存用户组的菜单配置数据",
-                "consumes": [
-                    "application/json"
-                ],
-                "summary": "保存用户组菜单配置",
+                ]
+            }
+        },
+        "/admin/plugins/helm/repo/delete/{ids}": {
+            "post": {
+                "description": "删除一个或多个Helm仓库",
+                "summary": "删除Helm仓库",
                 "parameters": [
                     {
-                        "description": "菜单配置信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "type": "string",
+                        "description": "要删除的仓库ID，多个用逗号分隔",
+                        "name": "ids",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "操作成功",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/ldap/config": {
+        "/admin/plugins/helm/repo/list": {
             "get": {
+                "description": "获取所有Helm仓库信息",
+                "summary": "Helm仓库列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取系统LDAP登录开关状态",
-                "summary": "获取LDAP开关状态",
+                ]
+            }
+        },
+        "/admin/plugins/helm/repo/option_list": {
+            "get": {
+                "description": "获取所有Helm仓库名称，用于下拉选项",
+                "summary": "Helm仓库选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2391,44 +2361,54 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/login": {
+        "/admin/plugins/helm/repo/save": {
             "post": {
-                "description": "用户通过用户名、密码和2FA验证码登录，支持普通和LDAP登录",
-                "summary": "用户登录",
+                "description": "添加或更新一个Helm仓库信息",
+                "summary": "添加或更新Helm仓库",
                 "parameters": [
                     {
-                        "description": "用户名",
-                        "name": "username",
+                        "description": "Helm仓库信息",
+                        "name": "repo",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.HelmRepository"
                         }
-                    },
-                    {
-                        "description": "密码（加密）",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
                         "schema": {
                             "type": "string"
                         }
-                    },
+                    }
+                },
+                "security": [
                     {
-                        "description": "登录类型 0:普通 1:LDAP",
-                        "name": "loginType",
-                        "in": "body",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/plugins/helm/repo/update_index": {
+            "post": {
+                "description": "更新指定Helm仓库的索引信息",
+                "summary": "更新Helm仓库索引",
+                "parameters": [
                     {
-                        "description": "2FA验证码",
-                        "name": "code",
+                        "description": "要更新索引的仓库ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "body",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
@@ -2436,43 +2416,66 @@ var doc = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "登录成功，返回JWT Token",
+                        "description": "操作成功",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "401": {
-                        "description": "登录失败",
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/plugins/inspection/event/status/option_list": {
+            "get": {
+                "summary": "获取巡检事件状态选项列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/oidc/{name}/callback": {
+        "/admin/plugins/inspection/record/list": {
             "get": {
+                "description": "根据巡检计划ID获取对应的巡检记录列表",
+                "summary": "获取巡检记录列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "处理OIDC认证后的回调，完成用户登录",
-                "summary": "处理OIDC回调",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/delete/{ids}": {
+            "post": {
+                "summary": "删除巡检计划",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SSO名称",
-                        "name": "name",
+                        "description": "巡检计划ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "认证代码",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2482,56 +2485,53 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/auth/oidc/{name}/sso": {
-            "get": {
+                },
                 "security": [
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取指定SSO名称的OIDC认证跳转URL",
-                "summary": "获取认证URL",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/id/{id}/record/list": {
+            "get": {
+                "description": "根据巡检计划ID获取对应的巡检记录列表",
+                "summary": "获取巡检记录列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "SSO名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "巡检计划ID",
+                        "name": "id",
+                        "in": "path"
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/auth/sso/config": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/id/{id}/summary": {
+            "post": {
+                "description": "统计指定巡检计划的执行情况，支持按时间范围和集群过滤",
+                "summary": "统计巡检计划执行情况",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "巡检计划ID",
+                        "name": "id",
+                        "in": "path"
+                    }
                 ],
-                "description": "获取所有已启用的SSO配置项",
-                "summary": "获取SSO配置列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2539,33 +2539,42 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/LimitRange/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建限制范围",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/id/{id}/summary/cluster/{cluster}/start_time/{start_time}/end_time/{end_time}": {
+            "post": {
+                "description": "统计指定巡检计划的执行情况，支持按时间范围和集群过滤",
+                "summary": "统计巡检计划执行情况",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "巡检计划ID",
+                        "name": "id",
+                        "in": "path"
+                    },
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
+                        "in": "path"
                     },
                     {
-                        "description": "限制范围配置",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "开始时间(RFC3339格式)",
+                        "name": "start_time",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间(RFC3339格式)",
+                        "name": "end_time",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -2575,32 +2584,32 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/configmap/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建ConfigMap",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/id/{id}/update_script_code": {
+            "post": {
+                "summary": "更新巡检脚本代码",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "type": "integer",
+                        "description": "巡检计划ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "请求体，包含metadata和data字段",
-                        "name": "request",
+                        "description": "脚本代码",
+                        "name": "script_codes",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "type": "string"
                         }
                     }
                 ],
@@ -2611,52 +2620,42 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/configmap/ns/{ns}/name/{name}/import": {
-            "post": {
+        "/admin/plugins/inspection/schedule/list": {
+            "get": {
+                "summary": "获取巡检计划列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "导入文件到ConfigMap",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/record/id/{id}/event/list": {
+            "get": {
+                "summary": "获取巡检事件列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ConfigMap名称",
-                        "name": "name",
+                        "description": "巡检记录ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件名",
-                        "name": "fileName",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "上传文件",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2666,54 +2665,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/configmap/ns/{ns}/name/{name}/{key}/update_configmap": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新ConfigMap中的文件内容",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/record/id/{id}/output/list": {
+            "get": {
+                "summary": "获取巡检脚本输出列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ConfigMap名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件名",
-                        "name": "key",
+                        "description": "巡检记录ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "请求体，包含update_configmap字段",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
@@ -2723,23 +2692,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/crd/group/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取CRD组选项列表",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/record/id/{id}/push": {
+            "post": {
+                "description": "将指定巡检记录的AI总结推送到所有配置的Webhook接收器",
+                "summary": "推送巡检记录",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "巡检记录ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -2750,30 +2720,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/crd/kind/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取指定组的CRD类型选项列表",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/record/id/{id}/summary": {
+            "post": {
+                "description": "为指定巡检记录生成AI总结",
+                "summary": "生成巡检记录AI总结",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "CRD组名称",
-                        "name": "spec[group]",
-                        "in": "query",
+                        "description": "巡检记录ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -2784,26 +2748,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/crd/status": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取CRD状态信息",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/save": {
+            "post": {
+                "summary": "保存巡检计划",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2811,33 +2766,31 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/cronjob/batch/pause": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量暂停 CronJob",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/save/id/{id}/status/{enabled}": {
+            "post": {
+                "summary": "快速更新巡检计划状态",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "巡检计划ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "批量暂停请求体，包含 name_list 和 ns_list",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "状态，例如：true、false",
+                        "name": "enabled",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2847,33 +2800,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/cronjob/batch/resume": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量恢复 CronJob",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/schedule/start/id/{id}": {
+            "post": {
+                "summary": "启动巡检计划，马上执行一次",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "巡检计划ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "批量恢复请求体，包含 name_list 和 ns_list",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
@@ -2883,36 +2827,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/cronjob/pause/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "暂停 CronJob",
+                ]
+            }
+        },
+        "/admin/plugins/inspection/script/delete/{ids}": {
+            "post": {
+                "summary": "删除Lua脚本",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "CronJob 名称",
-                        "name": "name",
+                        "description": "脚本ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -2924,40 +2854,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/cronjob/resume/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "恢复 CronJob",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "CronJob 名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/plugins/inspection/script/list": {
+            "get": {
+                "summary": "获取Lua脚本列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2965,35 +2872,35 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/daemonset/batch/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量重启DaemonSet",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "包含name_list和ns_list的请求体",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                ]
+            }
+        },
+        "/admin/plugins/inspection/script/load": {
+            "post": {
+                "summary": "加载内置Lua脚本",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "type": "string"
                         }
                     }
-                ],
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/plugins/inspection/script/option_list": {
+            "get": {
+                "summary": "获取Lua脚本选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3001,33 +2908,42 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/daemonset/batch/restore": {
+        "/admin/plugins/inspection/script/save": {
             "post": {
+                "summary": "保存Lua脚本",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量恢复DaemonSet",
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/server/connect/{name}": {
+            "post": {
+                "summary": "连接指定MCP服务器",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "MCP服务器名称",
+                        "name": "name",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "包含name_list和ns_list的请求体",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
@@ -3037,28 +2953,21 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/daemonset/batch/stop": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量停止DaemonSet",
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/server/delete": {
+            "post": {
+                "summary": "删除MCP服务器",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "包含name_list和ns_list的请求体",
-                        "name": "body",
+                        "description": "删除请求体包含IDs数组",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -3073,38 +2982,62 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/daemonset/ns/{ns}/name/{name}/restart": {
-            "post": {
+        "/admin/plugins/mcp_runtime/server/list": {
+            "get": {
+                "summary": "获取MCP服务器列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "重启DaemonSet",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/server/log/list": {
+            "get": {
+                "summary": "获取MCP服务器日志列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/server/save": {
+            "post": {
+                "summary": "创建或更新MCP服务器",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "DaemonSet名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "MCP服务器配置信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServerConfig"
+                        }
                     }
                 ],
                 "responses": {
@@ -3114,43 +3047,29 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/daemonset/ns/{ns}/name/{name}/revision/{revision}/rollout/undo": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "回滚DaemonSet到指定版本",
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/server/save/id/{id}/status/{status}": {
+            "post": {
+                "summary": "快速更新MCP服务器状态",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "DaemonSet名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "MCP服务器ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "回滚版本",
-                        "name": "revision",
+                        "description": "服务器状态(true/false)",
+                        "name": "status",
                         "in": "path",
                         "required": true
                     }
@@ -3162,36 +3081,29 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/daemonset/ns/{ns}/name/{name}/rollout/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取DaemonSet回滚历史",
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/tool/save/id/{id}/status/{status}": {
+            "post": {
+                "summary": "快速更新MCP工具状态",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "type": "integer",
+                        "description": "工具ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "DaemonSet名称",
-                        "name": "name",
+                        "description": "状态，例如：true、false",
+                        "name": "status",
                         "in": "path",
                         "required": true
                     }
@@ -3203,48 +3115,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/batch/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量重启Deployment",
+                ]
+            }
+        },
+        "/admin/plugins/mcp_runtime/tool/server/{name}/tools/list": {
+            "get": {
+                "summary": "获取指定MCP服务器的工具列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "description": "MCP服务器名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Deployment名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
                     }
                 ],
                 "responses": {
@@ -3254,47 +3142,76 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/batch/restore": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量恢复Deployment",
+                ]
+            }
+        },
+        "/admin/project/delete/{ids}": {
+            "post": {
+                "description": "删除一个或多个项目及其成员、命名空间，已同步到集群的 ResourceQuota 不会删除",
+                "summary": "删除项目",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "description": "项目ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "description": "Deployment名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/project/list": {
+            "get": {
+                "description": "获取全部项目（团队）",
+                "summary": "项目列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/models.Project"
                             }
                         }
-                    },
+                    }
+                },
+                "security": [
                     {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/project/save": {
+            "post": {
+                "description": "新增或更新项目，项目名称创建后不能修改；quota 为项目内每个命名空间的 ResourceQuota hard，JSON 对象",
+                "summary": "保存项目",
+                "parameters": [
+                    {
+                        "description": "项目信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Project"
                         }
                     }
                 ],
@@ -3302,50 +3219,65 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/batch/stop": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量停止Deployment",
+                ]
+            }
+        },
+        "/admin/project/{id}": {
+            "get": {
+                "description": "获取项目及其成员、包含的集群与命名空间",
+                "summary": "项目详情",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Deployment名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/service.ProjectView"
                         }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/project/{id}/members/save": {
+            "post": {
+                "description": "整体替换项目成员，角色为 owner（负责人，可管理成员）或 member",
+                "summary": "保存项目成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
+                        "description": "成员列表",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/project.MembersRequest"
                         }
                     }
                 ],
@@ -3356,32 +3288,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建Deployment",
+                ]
+            }
+        },
+        "/admin/project/{id}/namespaces/save": {
+            "post": {
+                "description": "整体替换项目包含的集群与命名空间，namespace 为空表示整个集群",
+                "summary": "保存项目命名空间",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Deployment配置",
-                        "name": "body",
+                        "description": "命名空间列表",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/project.NamespacesRequest"
                         }
                     }
                 ],
@@ -3392,36 +3325,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/events/all": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Deployment相关事件",
+                ]
+            }
+        },
+        "/admin/project/{id}/quota/sync": {
+            "post": {
+                "description": "将项目配额以名为 k8m-project-quota 的 ResourceQuota 创建或更新到项目内的每个命名空间，返回每个命名空间的结果",
+                "summary": "同步项目配额",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3430,41 +3350,52 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.ProjectQuotaResult"
+                            }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/hpa": {
+        "/admin/readonly/list": {
             "get": {
+                "description": "列出当前生效的全局与集群只读设置",
+                "summary": "只读模式列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Deployment的HPA信息",
+                ]
+            }
+        },
+        "/admin/readonly/save": {
+            "post": {
+                "description": "cluster 为空时设置全局开关；until 为到期时间，到期后自动解除。开启后阻止保存、上传、删除、应用、Exec 等变更操作",
+                "summary": "开启或关闭只读模式",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "只读模式设置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.ReadOnlyState"
+                        }
                     }
                 ],
                 "responses": {
@@ -3474,36 +3405,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "重启单个Deployment",
+                ]
+            }
+        },
+        "/admin/registry/credential/delete/{ids}": {
+            "post": {
+                "summary": "删除镜像仓库凭据",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
+                        "description": "凭据ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -3515,45 +3432,34 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/revision/{revision}/rollout/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Deployment版本差异",
+                ]
+            }
+        },
+        "/admin/registry/credential/id/{id}/sync": {
+            "post": {
+                "description": "在所选命名空间中创建或更新 imagePullSecret，并可绑定到 ServiceAccount，凭据修改后自动重新同步",
+                "summary": "同步凭据到命名空间",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "凭据ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "版本号",
-                        "name": "revision",
-                        "in": "path",
-                        "required": true
+                        "description": "同步目标",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config.SyncRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3563,43 +3469,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/revision/{revision}/rollout/undo": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "回滚Deployment到指定版本",
+                ]
+            }
+        },
+        "/admin/registry/credential/id/{id}/sync/list": {
+            "get": {
+                "summary": "凭据同步记录列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "版本号",
-                        "name": "revision",
+                        "type": "integer",
+                        "description": "凭据ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3611,38 +3496,45 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/rollout/history": {
+        "/admin/registry/credential/list": {
             "get": {
+                "summary": "镜像仓库凭据列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Deployment历史版本",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                ]
+            }
+        },
+        "/admin/registry/credential/save": {
+            "post": {
+                "description": "新建或编辑凭据，编辑时密码留空表示不修改",
+                "summary": "保存镜像仓库凭据",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "仓库凭据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredential"
+                        }
                     }
                 ],
                 "responses": {
@@ -3652,38 +3544,30 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/rollout/pause": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "暂停Deployment滚动更新",
+                ]
+            }
+        },
+        "/admin/registry/credential/sync/delete/{ids}": {
+            "post": {
+                "summary": "删除凭据同步记录",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "同步记录ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "是否同时删除集群中由 k8m 创建的 Secret",
+                        "name": "delete_secret",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3693,38 +3577,26 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/rollout/resume": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "恢复Deployment滚动更新",
+                ]
+            }
+        },
+        "/admin/registry/credential/test": {
+            "post": {
+                "summary": "测试镜像仓库登录",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "仓库凭据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config.TestLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3734,43 +3606,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/scale/replica/{replica}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "扩缩容Deployment",
+                ]
+            }
+        },
+        "/admin/report_schedule/delete/{ids}": {
+            "post": {
+                "summary": "删除报表计划",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "副本数",
-                        "name": "replica",
+                        "description": "计划ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -3782,33 +3633,25 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/deployment/batch_update_images": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量更新Deployment镜像",
+                ]
+            }
+        },
+        "/admin/report_schedule/id/{id}/run": {
+            "post": {
+                "description": "以异步任务执行，返回任务ID，可通过 /mgm/tasks/id/{id} 查看进度",
+                "summary": "立即执行报表计划",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "type": "integer",
+                        "description": "计划ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Deployment镜像更新配置",
-                        "name": "deployments",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
@@ -3818,32 +3661,44 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/doc/detail": {
-            "post": {
+        "/admin/report_schedule/list": {
+            "get": {
+                "summary": "报表计划列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取文档详情(含翻译)",
+                ]
+            }
+        },
+        "/admin/report_schedule/save": {
+            "post": {
+                "description": "按 cron 定期生成报表并发送给 webhook 接收者，邮件接收者收到附件，其他接收者只收到汇总消息。以创建人身份读取集群资源",
+                "summary": "保存报表计划",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "请求体，包含description字段",
-                        "name": "request",
+                        "description": "报表计划",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/doc.DetailReq"
+                            "$ref": "#/definitions/models.ReportSchedule"
                         }
                     }
                 ],
@@ -3851,46 +3706,44 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/doc.DetailReq"
+                            "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/doc/kind/{kind}/group/{group}/version/{version}": {
+        "/admin/report_schedule/types": {
             "get": {
+                "summary": "报表类型选项",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Kubernetes资源文档信息",
+                ]
+            }
+        },
+        "/admin/user/2fa/disable/{id}": {
+            "post": {
+                "description": "禁用指定用户的二步验证",
+                "summary": "禁用用户2FA",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "用户ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3902,33 +3755,25 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/file/delete": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除文件",
+                ]
+            }
+        },
+        "/admin/user/delete/{ids}": {
+            "post": {
+                "description": "根据ID批量删除用户",
+                "summary": "删除用户",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "用户ID，多个用逗号分隔",
+                        "name": "ids",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "文件信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pod.info"
-                        }
                     }
                 ],
                 "responses": {
@@ -3938,87 +3783,71 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/file/download": {
+        "/admin/user/list": {
             "get": {
+                "description": "获取所有用户信息",
+                "summary": "获取用户列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "下载文件",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件路径",
-                        "name": "path",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "containerName",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "namespace",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/admin/user/option_list": {
+            "get": {
+                "description": "获取用户选项列表，用于下拉选择",
+                "summary": "获取用户选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/file/list": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/user/save": {
+            "post": {
+                "description": "新增或更新用户信息",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "获取文件列表",
+                "summary": "保存用户",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "文件信息",
-                        "name": "body",
+                        "description": "用户信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pod.info"
+                            "$ref": "#/definitions/models.User"
                         }
                     }
                 ],
@@ -4026,36 +3855,36 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/file/save": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "保存文件",
+                ]
+            }
+        },
+        "/admin/user/save/id/{id}/status/{disabled}": {
+            "post": {
+                "description": "根据ID快速更新用户启用/禁用状态",
+                "summary": "快速更新用户状态",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "文件信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pod.info"
-                        }
+                        "type": "string",
+                        "description": "状态，例如：true、false",
+                        "name": "disabled",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4065,32 +3894,36 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/file/show": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/user/update_psw/{id}": {
+            "post": {
+                "description": "根据ID重置本地用户密码，新密码须符合密码策略，重置后该用户的登录会话全部失效。\nmust_change_password 为 true 时用户下次登录须再修改密码，适用于下发临时密码",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "查看文件内容",
+                "summary": "更新用户密码",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "文件信息",
-                        "name": "body",
+                        "description": "新密码信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pod.info"
+                            "$ref": "#/definitions/models.User"
                         }
                     }
                 ],
@@ -4101,65 +3934,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/file/upload": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "上传文件",
+                ]
+            }
+        },
+        "/admin/user/{id}/force_rotation": {
+            "post": {
+                "description": "要求本地用户下次登录时修改密码，并使其已登录的会话立即失效",
+                "summary": "强制用户轮换密码",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "containerName",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "namespace",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件路径",
-                        "name": "path",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件名",
-                        "name": "fileName",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "上传文件",
-                        "name": "file",
-                        "in": "formData",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4170,24 +3962,37 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/ingress_class/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/user/{id}/groups": {
+            "post": {
+                "description": "整体替换用户所在的用户组，用户组必须已存在，传空列表表示移出全部用户组",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "获取 IngressClass 选项列表",
+                "summary": "设置用户所在的用户组",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "用户组列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.AssignGroupsRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -4197,29 +4002,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/ingress_class/set_default/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "设置默认的 IngressClass",
+                ]
+            }
+        },
+        "/admin/user_group/delete/{ids}": {
+            "post": {
+                "description": "根据ID批量删除用户组",
+                "summary": "删除用户组",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "IngressClass 名称",
-                        "name": "name",
+                        "description": "用户组ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -4231,40 +4030,47 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/node/add_taints/name/{name}": {
-            "post": {
+        "/admin/user_group/list": {
+            "get": {
+                "description": "获取所有用户组信息",
+                "summary": "获取用户组列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UserGroup"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "添加污点",
+                ]
+            }
+        },
+        "/admin/user_group/mapping/delete/{ids}": {
+            "post": {
+                "description": "根据ID批量删除映射，已同步的用户组不会变化",
+                "summary": "删除外部用户组映射",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
+                        "description": "映射ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "污点信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/node.TaintInfo"
-                        }
                     }
                 ],
                 "responses": {
@@ -4274,35 +4080,51 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/node/batch/cordon": {
-            "post": {
+        "/admin/user_group/mapping/list": {
+            "get": {
+                "description": "获取 OIDC、LDAP 用户组与 k8m 用户组的映射",
+                "summary": "外部用户组映射列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ExternalGroupMapping"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/user_group/mapping/save": {
+            "post": {
+                "description": "新增或更新映射。OIDC 用户登录时 groups 声明中的组按映射转换，未配置映射的组名原样使用；\nLDAP 用户登录时按 memberOf 中组的 DN 或 CN 转换，并与默认用户组合并",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "批量将指定的 Kubernetes 节点设置为不可调度（cordon）",
+                "summary": "保存外部用户组映射",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "节点名称列表",
-                        "name": "name_list",
+                        "description": "映射信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ExternalGroupMapping"
                         }
                     }
                 ],
@@ -4310,38 +4132,58 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/node/batch/drain": {
-            "post": {
+        "/admin/user_group/option_list": {
+            "get": {
+                "description": "获取所有用户组的选项（仅ID和名称）",
+                "summary": "用户组选项列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/user_group/save": {
+            "post": {
+                "description": "新增或更新用户组信息",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "批量驱逐指定的 Kubernetes 节点",
+                "summary": "保存用户组",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "节点名称列表",
-                        "name": "name_list",
+                        "description": "用户组信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.UserGroup"
                         }
                     }
                 ],
@@ -4349,38 +4191,34 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/batch/uncordon": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/user_group/save_menu": {
+            "post": {
+                "description": "保存用户组的菜单配置数据",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "批量解除指定节点的隔离状态（Uncordon），使其重新可调度",
+                "summary": "保存用户组菜单配置",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "节点名称列表",
-                        "name": "name_list",
+                        "description": "菜单配置信息",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 ],
@@ -4388,39 +4226,31 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/cordon/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "隔离指定节点",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                ]
+            }
+        },
+        "/auth/external": {
+            "get": {
+                "description": "使用监听识别出的客户端证书 CN 或可信代理传递的用户名登录，返回写入 Token 并跳转首页的页面",
+                "summary": "外部身份登录",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "登录成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "未识别到外部身份",
                         "schema": {
                             "type": "string"
                         }
@@ -4428,42 +4258,74 @@ var doc = `{
                 }
             }
         },
-        "/k8s/cluster/{cluster}/node/delete_taints/name/{name}": {
-            "post": {
+        "/auth/ldap/config": {
+            "get": {
+                "description": "获取系统LDAP登录开关状态",
+                "summary": "获取LDAP开关状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除污点",
+                ]
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "用户通过用户名、密码和2FA验证码登录，支持普通和LDAP登录",
+                "summary": "用户登录",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
-                        "required": true
+                        "description": "用户名",
+                        "name": "username",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "密码（加密）",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     {
-                        "description": "污点信息",
-                        "name": "body",
+                        "description": "登录类型 0:普通 1:LDAP",
+                        "name": "loginType",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/node.TaintInfo"
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "2FA验证码",
+                        "name": "code",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "登录成功，返回JWT Token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "登录失败",
                         "schema": {
                             "type": "string"
                         }
@@ -4471,27 +4333,30 @@ var doc = `{
                 }
             }
         },
-        "/k8s/cluster/{cluster}/node/drain/name/{name}": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "驱逐指定节点",
+        "/auth/oidc/{name}/callback": {
+            "get": {
+                "description": "处理OIDC认证后的回调，完成用户登录",
+                "summary": "处理OIDC回调",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
+                        "description": "SSO名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "认证代码",
+                        "name": "code",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "发起登录时签发的 state",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -4502,50 +4367,81 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/labels/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取所有节点上的标签",
+                ]
+            }
+        },
+        "/auth/oidc/{name}/sso": {
+            "get": {
+                "description": "获取指定SSO名称的OIDC认证跳转URL",
+                "summary": "获取认证URL",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SSO名称",
+                        "name": "name",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "302": {
+                        "description": "Found",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/k8s/cluster/{cluster}/node/labels/unique_labels": {
+        "/auth/sso/config": {
             "get": {
+                "description": "获取所有已启用的SSO配置项",
+                "summary": "获取SSO配置列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取选定集群中所有唯一的节点标签键，并以选项列表形式返回",
+                ]
+            }
+        },
+        "/hooks/{key}": {
+            "post": {
+                "description": "外部系统触发预定义的操作。请求头 X-K8m-Signature-256（或 X-Hub-Signature-256）为请求体的 HMAC-SHA256 签名，格式 sha256=<hex>。\n可选请求头 X-K8m-Timestamp 为 Unix 秒，携带时签名内容为 \"<timestamp>.<body>\"，与服务器时间相差超过 5 分钟的请求被拒绝。\n请求头 X-K8m-Delivery（或 X-GitHub-Delivery）为投递标识，24 小时内重复的标识返回 409\n请求体为 JSON：set_image 从配置的路径读取镜像或标签，apply_template 可通过 variables 覆盖已声明的模板变量",
+                "summary": "接收入站 webhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
-                        "name": "cluster",
-                        "in": "query",
+                        "description": "webhook 标识",
+                        "name": "key",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4559,14 +4455,9 @@ var doc = `{
                 }
             }
         },
-        "/k8s/cluster/{cluster}/node/list_taints/name/{name}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "获取某个节点上的污点",
+        "/k8s/cluster/{cluster}/LimitRange/create": {
+            "post": {
+                "summary": "创建限制范围",
                 "parameters": [
                     {
                         "type": "string",
@@ -4576,11 +4467,13 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "限制范围配置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -4590,24 +4483,34 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/name/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取节点名称选项列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/admission/preview": {
+            "post": {
+                "description": "以服务端 dryRun 方式逐个提交清单中的资源，返回各准入 Webhook、Gatekeeper、Kyverno、ValidatingAdmissionPolicy 的拒绝原因与警告，不会修改集群",
+                "summary": "准入策略预演",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "清单",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admission.PreviewRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -4617,38 +4520,31 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/name/{node_name}/create_kubectl_shell": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建kubectl shell",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/capabilities": {
+            "get": {
+                "description": "返回集群是否安装 metrics-server、Gateway API、卷快照等组件以及 k8m 能否在容器中执行命令，并合并管理员设置的功能开关，enabled 为 false 的功能前端应隐藏。探测结果缓存 10 分钟",
+                "summary": "获取集群能力",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "node_name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "集群ID，base64编码",
-                        "name": "cluster_id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "为 true 时重新探测",
+                        "name": "refresh",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4658,29 +4554,30 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/name/{node_name}/create_node_shell": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建节点shell",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/delete/{ids}": {
+            "post": {
+                "description": "只能删除自己创建且不在应用中的变更集，删除不会撤销已应用的变更",
+                "summary": "删除变更集",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "节点名称",
-                        "name": "node_name",
+                        "description": "变更集ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -4692,23 +4589,31 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/taints/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取所有节点上的污点",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/id/{id}": {
+            "get": {
+                "description": "包含清单与最近一次执行中每个资源的结果",
+                "summary": "变更集详情",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "变更集ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4719,23 +4624,31 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/top/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "返回所有节点的资源使用率（top指标），包括CPU和内存的用量及其数值化表示，便于前端排序和展示",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/id/{id}/apply": {
+            "post": {
+                "description": "不等待计划时间立即应用，任一资源失败时逆序回滚：新创建的资源被删除，更新过的资源恢复为应用前的定义",
+                "summary": "立即应用变更集",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "变更集ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4746,29 +4659,30 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/uncordon/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "解除指定节点隔离",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/id/{id}/cancel": {
+            "post": {
+                "description": "取消尚未开始应用的变更集",
+                "summary": "取消变更集",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "变更集ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4780,40 +4694,32 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/update_taints/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "修改污点",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/id/{id}/dry_run": {
+            "post": {
+                "description": "以 dryRun=All 方式逐个预演，返回每个资源的结果，不修改集群",
+                "summary": "预演变更集",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "变更集ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "污点信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/node.TaintInfo"
-                        }
                     }
                 ],
                 "responses": {
@@ -4823,29 +4729,22 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/node/usage/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取节点资源使用情况",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/list": {
+            "get": {
+                "summary": "变更集列表",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "节点名称",
-                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -4857,32 +4756,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/ns/create_resource_quota": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建资源配额",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/changeset/save": {
+            "post": {
+                "description": "新建或编辑变更集，manifests 为多文档YAML。scheduled_at 为计划应用时间，到期后按依赖顺序应用，为空时需手动应用；只能编辑尚未应用的变更集",
+                "summary": "保存变更集",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "资源配额配置",
+                        "description": "变更集",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.ChangeSet"
                         }
                     }
                 ],
@@ -4893,23 +4793,24 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/ns/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取命名空间选项列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/component/config": {
+            "get": {
+                "description": "汇总 CoreDNS 的 Corefile 与就绪情况、kube-proxy 的代理模式与配置、以静态 Pod 运行的控制面组件启动参数及 apiserver 准入插件列表",
+                "summary": "集群核心组件配置",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4917,35 +4818,43 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.ClusterComponents"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/batch/uninstall": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量卸载Helm Release",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/component/{component}/config": {
+            "post": {
+                "description": "component 可选 coredns（修改 Corefile，启用 reload 插件时自动生效）、kube-proxy（修改 config.conf，需重建 kube-proxy Pod 后生效）。\n仅平台管理员与集群管理员可以修改；需传入读取时的 resource_version 并确认，配置被他人修改或校验不通过时拒绝写入",
+                "summary": "修改集群核心组件配置",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "批量卸载参数",
+                        "type": "string",
+                        "description": "组件：coredns、kube-proxy",
+                        "name": "component",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "配置内容",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/component.UpdateRequest"
                         }
                     }
                 ],
@@ -4956,24 +4865,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Release列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/configmap/create": {
+            "post": {
+                "summary": "创建ConfigMap",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "请求体，包含metadata和data字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -4983,23 +4901,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/history/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Release的历史版本",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/configmap/ns/{ns}/name/{name}/import": {
+            "post": {
+                "summary": "导入文件到ConfigMap",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
@@ -5011,10 +4929,24 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "Release名称",
+                        "description": "ConfigMap名称",
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名",
+                        "name": "fileName",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5024,23 +4956,23 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/revision/{revision}/install_log": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Release安装Log",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/configmap/ns/{ns}/name/{name}/{key}/update_configmap": {
+            "post": {
+                "summary": "更新ConfigMap中的文件内容",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
@@ -5052,17 +4984,26 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "Release名称",
+                        "description": "ConfigMap名称",
                         "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "版本号",
-                        "name": "revision",
+                        "description": "文件名",
+                        "name": "key",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "请求体，包含update_configmap字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -5072,17 +5013,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/revision/{revision}/notes": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取ReleaseNote",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/crd/group/option_list": {
+            "get": {
+                "summary": "获取CRD组选项列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -5090,26 +5031,39 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/crd/kind/option_list": {
+            "get": {
+                "summary": "获取指定组的CRD类型选项列表",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Release名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "版本号",
-                        "name": "revision",
-                        "in": "path",
+                        "description": "CRD组名称",
+                        "name": "spec[group]",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -5120,17 +5074,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/revision/{revision}/values": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取安装yaml",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/crd/status": {
+            "get": {
+                "summary": "获取CRD状态信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -5138,27 +5092,6 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Release名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "版本号",
-                        "name": "revision",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -5168,17 +5101,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/uninstall": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "卸载Helm Release",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/cronjob/batch/pause": {
+            "post": {
+                "summary": "批量暂停 CronJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -5188,18 +5121,13 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Release名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "批量暂停请求体，包含 name_list 和 ns_list",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -5209,17 +5137,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/upgrade": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "升级Helm Release",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/cronjob/batch/resume": {
+            "post": {
+                "summary": "批量恢复 CronJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -5229,8 +5157,8 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "升级参数",
-                        "name": "body",
+                        "description": "批量恢复请求体，包含 name_list 和 ns_list",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -5245,17 +5173,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/plugins/helm/release/{release}/repo/{repo}/chart/{chart}/version/{version}/install": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "安装Helm Release",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/cronjob/pause/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "暂停 CronJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -5266,40 +5194,17 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "Release名称",
-                        "name": "release",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "仓库名称",
-                        "name": "repo",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Chart名称",
-                        "name": "chart",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "版本号",
-                        "name": "version",
+                        "description": "CronJob 名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "安装参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
@@ -5309,17 +5214,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/labels/unique_labels": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod唯一标签键列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/cronjob/resume/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "恢复 CronJob",
                 "parameters": [
                     {
                         "type": "string",
@@ -5327,6 +5232,20 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CronJob 名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5336,17 +5255,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/logs/download/ns/{ns}/pod_name/{pod_name}/container/{container_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "下载Pod日志",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/custom/template/{id}/instantiate": {
+            "post": {
+                "description": "使用给定变量渲染模板，并应用到当前集群",
+                "summary": "实例化模板",
                 "parameters": [
                     {
                         "type": "string",
@@ -5357,92 +5277,75 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "pod_name",
+                        "description": "模板ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
-                        "required": true
+                        "description": "渲染参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/template.RenderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "日志文件",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/logs/sse/ns/{ns}/pod_name/{pod_name}/container/{container_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "流式获取Pod日志",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/daemonset/batch/restart": {
+            "post": {
+                "summary": "批量重启DaemonSet",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "pod_name",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
-                        "required": true
+                        "description": "包含name_list和ns_list的请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "日志流",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/name/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod名称选项列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/daemonset/batch/restore": {
+            "post": {
+                "summary": "批量恢复DaemonSet",
                 "parameters": [
                     {
                         "type": "string",
@@ -5452,10 +5355,13 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间，为空则返回所有命名空间的Pod",
-                        "name": "ns",
-                        "in": "query"
+                        "description": "包含name_list和ns_list的请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -5465,17 +5371,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/port_forward/ns/{ns}/name/{name}/container/{container_name}/pod_port/{pod_port}/local_port/{local_port}/start": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "开始端口转发",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/daemonset/batch/stop": {
+            "post": {
+                "summary": "批量停止DaemonSet",
                 "parameters": [
                     {
                         "type": "string",
@@ -5485,37 +5391,52 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                        "description": "包含name_list和ns_list的请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/daemonset/ns/{ns}/name/{name}/restart": {
+            "post": {
+                "summary": "重启DaemonSet",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Pod端口",
-                        "name": "pod_port",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "本地端口",
-                        "name": "local_port",
+                        "description": "DaemonSet名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -5527,17 +5448,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/port_forward/ns/{ns}/name/{name}/container/{container_name}/pod_port/{pod_port}/stop": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "停止端口转发",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/daemonset/ns/{ns}/name/{name}/revision/{revision}/rollout/undo": {
+            "post": {
+                "summary": "回滚DaemonSet到指定版本",
                 "parameters": [
                     {
                         "type": "string",
@@ -5555,22 +5476,15 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
+                        "description": "DaemonSet名称",
                         "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pod端口",
-                        "name": "pod_port",
+                        "description": "回滚版本",
+                        "name": "revision",
                         "in": "path",
                         "required": true
                     }
@@ -5582,17 +5496,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/port_forward/ns/{ns}/name/{name}/port/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "列出端口转发信息",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/daemonset/ns/{ns}/name/{name}/rollout/history": {
+            "get": {
+                "summary": "获取DaemonSet回滚历史",
                 "parameters": [
                     {
                         "type": "string",
@@ -5610,7 +5524,7 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
+                        "description": "DaemonSet名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -5623,31 +5537,48 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/top/ns/{ns}/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod资源使用情况列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/batch/restart": {
+            "post": {
+                "summary": "批量重启Deployment",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间，多个用逗号分隔",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "Deployment名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
@@ -5657,38 +5588,48 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/usage/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod资源使用情况",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/batch/restore": {
+            "post": {
+                "summary": "批量恢复Deployment",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "Deployment名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     },
                     {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
@@ -5698,70 +5639,68 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/pod/xterm/ns/{ns}/pod_name/{pod_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "提供Pod容器的交互式终端会话",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/batch/stop": {
+            "post": {
+                "summary": "批量停止Deployment",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Pod名称",
-                        "name": "pod_name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "容器名称，默认为第一个容器",
-                        "name": "container_name",
-                        "in": "query"
+                        "description": "Deployment名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     },
                     {
-                        "type": "boolean",
-                        "description": "会话结束后是否删除Pod",
-                        "name": "remove",
-                        "in": "query"
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "WebSocket连接成功",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/replicaset/batch/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量重启ReplicaSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/create": {
+            "post": {
+                "summary": "创建Deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -5771,7 +5710,7 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "包含name_list和ns_list的请求体",
+                        "description": "Deployment配置",
                         "name": "body",
                         "in": "body",
                         "required": true,
@@ -5787,17 +5726,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/replicaset/batch/restore": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量恢复ReplicaSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/events/all": {
+            "get": {
+                "summary": "获取Deployment相关事件",
                 "parameters": [
                     {
                         "type": "string",
@@ -5807,13 +5746,18 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "包含name_list和ns_list的请求体",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5823,17 +5767,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/replicaset/batch/stop": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量停止ReplicaSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/hpa": {
+            "get": {
+                "summary": "获取Deployment的HPA信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -5843,13 +5787,18 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "包含name_list和ns_list的请求体",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5859,17 +5808,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/replicaset/ns/{ns}/name/{name}/events/all": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取ReplicaSet相关事件列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/restart": {
+            "post": {
+                "summary": "重启单个Deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -5887,7 +5836,7 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "ReplicaSet名称",
+                        "description": "Deployment名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -5900,17 +5849,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/replicaset/ns/{ns}/name/{name}/hpa": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取ReplicaSet相关HPA列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/revision/{revision}/rollout/history": {
+            "get": {
+                "summary": "获取Deployment版本差异",
                 "parameters": [
                     {
                         "type": "string",
@@ -5928,10 +5877,17 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "ReplicaSet名称",
+                        "description": "Deployment名称",
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "版本号",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5941,17 +5897,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/replicaset/ns/{ns}/name/{name}/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "重启指定的ReplicaSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/revision/{revision}/rollout/undo": {
+            "post": {
+                "summary": "回滚Deployment到指定版本",
                 "parameters": [
                     {
                         "type": "string",
@@ -5969,10 +5925,17 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "ReplicaSet名称",
+                        "description": "Deployment名称",
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "版本号",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5982,33 +5945,38 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/service/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "创建Service",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/rollout/history": {
+            "get": {
+                "summary": "获取Deployment历史版本",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Service创建参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -6018,48 +5986,38 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/batch/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量重启StatefulSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/rollout/pause": {
+            "post": {
+                "summary": "暂停Deployment滚动更新",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "StatefulSet名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -6069,48 +6027,38 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/batch/restore": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量恢复StatefulSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/rollout/resume": {
+            "post": {
+                "summary": "恢复Deployment滚动更新",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "StatefulSet名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -6120,48 +6068,45 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/batch/stop": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量停止StatefulSet",
-                "parameters": [
-                    {
-                        "type": "string",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deploy/ns/{ns}/name/{name}/scale/replica/{replica}": {
+            "post": {
+                "summary": "扩缩容Deployment",
+                "parameters": [
+                    {
+                        "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "StatefulSet名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "副本数",
+                        "name": "replica",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -6171,38 +6116,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/hpa": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取StatefulSet的HPA列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/deployment/batch_update_images": {
+            "post": {
+                "summary": "批量更新Deployment镜像",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "StatefulSet名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Deployment镜像更新配置",
+                        "name": "deployments",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
@@ -6212,17 +6152,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/restart": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "重启StatefulSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/doc/detail": {
+            "post": {
+                "summary": "获取文档详情(含翻译)",
                 "parameters": [
                     {
                         "type": "string",
@@ -6232,38 +6172,33 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "StatefulSet名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "请求体，包含description字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc.DetailReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/doc.DetailReq"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/revision/{revision}/rollout/undo": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "回滚StatefulSet到指定版本",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/doc/kind/{kind}/group/{group}/version/{version}": {
+            "get": {
+                "summary": "获取Kubernetes资源文档信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -6274,22 +6209,22 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "资源类型",
+                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "StatefulSet名称",
-                        "name": "name",
+                        "description": "API组",
+                        "name": "group",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "版本号",
-                        "name": "revision",
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
                         "in": "path",
                         "required": true
                     }
@@ -6301,36 +6236,29 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/rollout/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取StatefulSet滚动历史",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/drift/baseline/delete/{ids}": {
+            "post": {
+                "summary": "删除基线",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "StatefulSet名称",
-                        "name": "name",
+                        "description": "基线ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
                     }
@@ -6342,43 +6270,30 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/scale/replica/{replica}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "扩缩容StatefulSet",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/drift/baseline/id/{id}/check": {
+            "post": {
+                "description": "立即比对基线与集群中的实际对象，返回每个资源的结果",
+                "summary": "执行漂移检测",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "StatefulSet名称",
-                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "副本数",
-                        "name": "replica",
+                        "description": "基线ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -6390,17 +6305,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/status/resource_count/cache_seconds/{cache}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取集群资源数量统计",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/drift/baseline/id/{id}/results": {
+            "get": {
+                "summary": "最近一次漂移检测结果",
                 "parameters": [
                     {
                         "type": "string",
@@ -6410,11 +6325,17 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "缓存时间（秒）",
-                        "name": "cache",
+                        "type": "integer",
+                        "description": "基线ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "按状态过滤：in_sync、drifted、missing、error",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -6424,17 +6345,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/storage_class/option_list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取存储类选项列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/drift/baseline/id/{id}/revert": {
+            "post": {
+                "description": "资源不存在时按基线重新创建，否则将基线中声明的字段写回集群，完成后重新执行检测",
+                "summary": "恢复资源为基线定义",
                 "parameters": [
                     {
                         "type": "string",
@@ -6442,6 +6364,22 @@ var doc = `{
                         "name": "cluster",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "基线ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "资源",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/drift.RevertRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -6451,17 +6389,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/storage_class/set_default/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "设置默认存储类",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/drift/baseline/list": {
+            "get": {
+                "summary": "基线列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -6469,13 +6407,6 @@ var doc = `{
                         "name": "cluster",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "存储类名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -6485,32 +6416,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/yaml/apply": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "应用YAML配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/drift/baseline/save": {
+            "post": {
+                "description": "新建或编辑命名空间基线。source 为 upload 时 manifests 填写多文档YAML；为 git 时填写 git_url（原始文件或 tar.gz、zip 归档地址），编辑时令牌留空表示不修改",
+                "summary": "保存基线",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "YAML配置请求",
+                        "description": "基线",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.yamlRequest"
+                            "$ref": "#/definitions/models.DriftBaseline"
                         }
                     }
                 ],
@@ -6521,32 +6453,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/yaml/delete": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除YAML配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/export": {
+            "post": {
+                "description": "导出一个或多个资源，移除 status、uid、resourceVersion、managedFields、creationTimestamp 等服务端字段，便于提交到Git或重新应用",
+                "summary": "导出资源YAML",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "YAML配置请求",
+                        "description": "导出参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.yamlRequest"
+                            "$ref": "#/definitions/dynamic.ExportRequest"
                         }
                     }
                 ],
@@ -6557,17 +6490,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/yaml/upload": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "上传YAML文件并应用",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/batch-download": {
+            "post": {
+                "description": "将所选文件与目录在服务端打包为一个 tar.gz 边打包边返回，条目保留完整路径。\n单个路径失败不影响其余路径，失败详情写入压缩包中的 k8m-download-report.json，失败数在 X-K8M-Failed-Paths trailer 中给出；全部失败时返回错误信息",
+                "summary": "批量下载容器文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -6577,11 +6511,13 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "YAML文件",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "容器与路径列表，最多100个",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.FileBatchDownloadRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -6591,67 +6527,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_node_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "添加节点亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/copy": {
+            "post": {
+                "description": "经 k8m 以 tar 流方式转发，不落盘，适用于在环境间同步配置文件；两端容器均需要 tar 命令",
+                "summary": "跨集群复制容器文件",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "集群名称",
+                        "description": "源集群名称",
                         "name": "cluster",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "节点亲和性配置",
-                        "name": "nodeAffinity",
+                        "description": "源与目标",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.nodeAffinity"
+                            "$ref": "#/definitions/pod.FileCopyRequest"
                         }
                     }
                 ],
@@ -6662,17 +6564,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_pod_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "添加Pod亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/delete": {
+            "post": {
+                "description": "trash=true 时先将文件打包备份到回收站，保留 7 天",
+                "summary": "删除文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -6682,47 +6585,12 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Pod亲和性配置",
-                        "name": "podAffinity",
+                        "description": "文件信息",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.podAffinity"
+                            "$ref": "#/definitions/pod.info"
                         }
                     }
                 ],
@@ -6733,17 +6601,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_pod_anti_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "添加Pod反亲和性",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/download": {
+            "get": {
+                "description": "支持 Range、If-Range 断点续传；type=tar 时将目录打包下载；inline=true 时按识别出的类型在浏览器中直接查看图片、PDF、文本等",
+                "summary": "下载文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -6754,47 +6623,37 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
+                        "description": "文件路径",
+                        "name": "path",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "容器名称",
+                        "name": "containerName",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
                         "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "name": "namespace",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Pod反亲和性配置",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.podAffinity"
-                        }
+                        "type": "boolean",
+                        "description": "在浏览器中直接查看",
+                        "name": "inline",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -6804,17 +6663,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_tolerations/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "添加资源容忍度",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/from_config": {
+            "post": {
+                "summary": "将 ConfigMap/Secret 键内容写入容器文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -6824,47 +6683,12 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "容忍度配置信息",
+                        "description": "来源对象与容器文件",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.Tolerations"
+                            "$ref": "#/definitions/pod.FileConfigRequest"
                         }
                     }
                 ],
@@ -6875,17 +6699,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/annotations/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "列出资源注解",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/list": {
+            "post": {
+                "summary": "获取文件列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -6895,62 +6719,108 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
+                        "description": "文件信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.info"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/s3/pull": {
+            "post": {
+                "description": "extract=true 时将 tar 对象解包到 path 目录，否则写入 path 文件。只能拉取 k8m-pod-files/集群/命名空间/ 之下的对象",
+                "summary": "从对象存储拉取文件到容器",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
+                    {
+                        "description": "对象键与容器路径",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.ObjectTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/s3/push": {
+            "post": {
+                "description": "使用平台参数设置中的 S3 兼容存储，目录以 tar 打包上传。对象键限定在 k8m-pod-files/集群/命名空间/ 之下",
+                "summary": "推送容器文件到对象存储",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "容器文件与对象键",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.ObjectTransferRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/batch/remove": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量删除资源",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/save": {
+            "post": {
+                "description": "内容较大或含非文本字符时，以 encoding=base64 编码并按 uploadId、chunkIndex、chunkTotal 顺序分片提交，最后一个分片到达后写入容器，单个文件上限 50MiB\nvalidate=true 时按扩展名校验语法，并可通过 validateCommand 在容器内执行校验命令，发现问题时不保存并返回 warnings，force=true 时仍然保存",
+                "summary": "保存文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -6960,48 +6830,85 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
+                        "description": "文件信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.info"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/show": {
+            "post": {
+                "summary": "查看文件内容",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "资源名称列表",
-                        "name": "name_list",
+                        "description": "文件信息",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/pod.info"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
                         }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/to_config": {
+            "post": {
+                "description": "对象不存在时创建，已存在时只更新指定键，键名为空时使用文件名",
+                "summary": "从容器文件创建或更新 ConfigMap/Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
+                        "description": "容器文件与目标对象",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/pod.FileConfigRequest"
                         }
                     }
                 ],
@@ -7012,17 +6919,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_env/ns/{ns}/name/{name}/container/{container_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取容器环境变量信息",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/trash/delete/{ids}": {
+            "post": {
+                "description": "需要每条记录所在命名空间的删除权限",
+                "summary": "清除回收站记录",
                 "parameters": [
                     {
                         "type": "string",
@@ -7033,45 +6941,50 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "回收站记录ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/trash/list": {
+            "get": {
+                "description": "只返回当前用户有权查看的命名空间中的记录",
+                "summary": "文件回收站列表",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
                         "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "name": "namespace",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
-                        "required": true
+                        "description": "Pod名称",
+                        "name": "pod_name",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -7081,17 +6994,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_health_checks/ns/{ns}/name/{name}/container/{container_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取容器健康检查信息",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/trash/restore/{id}": {
+            "post": {
+                "description": "将备份解压回原路径，默认恢复到删除时的 Pod 与容器，恢复后从回收站移除。需要记录所在命名空间的 exec 权限",
+                "summary": "从回收站恢复文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -7101,46 +7015,19 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "回收站记录ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
-                        "required": true
+                        "description": "目标 Pod",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/pod.TrashRestoreRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -7150,17 +7037,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_info/ns/{ns}/name/{name}/container/{container_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取容器基本信息",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/upload": {
+            "post": {
+                "summary": "上传文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -7171,44 +7058,56 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
+                        "description": "容器名称",
+                        "name": "containerName",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
+                        "description": "文件路径",
+                        "name": "path",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "文件名",
+                        "name": "fileName",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
+                        "type": "boolean",
+                        "description": "上传前检查目标目录是否存在、可写及剩余空间",
+                        "name": "preflight",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "预检时目标目录不存在则创建",
+                        "name": "createDir",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -7219,17 +7118,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_resources_info/ns/{ns}/name/{name}/container/{container_name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取容器资源信息",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/upload/batch": {
+            "post": {
+                "description": "并发数与重试次数未指定时使用平台参数设置，网络类错误按指数退避重试，命令执行失败不重试",
+                "summary": "批量上传文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -7240,44 +7140,61 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
+                        "description": "容器名称",
+                        "name": "containerName",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
+                        "description": "目标目录",
+                        "name": "path",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "并发数，最大20",
+                        "name": "concurrency",
+                        "in": "formData"
                     },
                     {
-                        "type": "string",
-                        "description": "容器名称",
-                        "name": "container_name",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "单个文件重试次数，最大5",
+                        "name": "retries",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "上传前检查目标目录是否存在、可写及剩余空间是否够全部文件",
+                        "name": "preflight",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "预检时目标目录不存在则创建",
+                        "name": "createDir",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件，可多个",
+                        "name": "files",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -7288,17 +7205,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_node_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除节点亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/upload/workload": {
+            "post": {
+                "description": "适用于向所有副本推送热修复配置或证书，未运行的 Pod 跳过，并发数与重试次数规则同批量上传",
+                "summary": "向工作负载的全部 Pod 分发文件",
                 "parameters": [
                     {
                         "type": "string",
@@ -7309,47 +7227,56 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
+                        "description": "Deployment、StatefulSet 或 DaemonSet",
                         "name": "kind",
-                        "in": "path",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
+                        "description": "工作负载名称",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "容器名称，为空时使用 Pod 的第一个容器",
+                        "name": "containerName",
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "目标目录",
+                        "name": "path",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "节点亲和性配置",
-                        "name": "nodeAffinity",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.nodeAffinity"
-                        }
+                        "type": "integer",
+                        "description": "并发数，最大20",
+                        "name": "concurrency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "单个 Pod 重试次数，最大5",
+                        "name": "retries",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -7359,17 +7286,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_pod_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除Pod亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/watch/delete/{ids}": {
+            "post": {
+                "summary": "删除文件监视",
                 "parameters": [
                     {
                         "type": "string",
@@ -7380,47 +7307,44 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "文件监视ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/watch/id/{id}/check": {
+            "post": {
+                "summary": "立即检查文件监视",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "type": "integer",
+                        "description": "文件监视ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Pod亲和性配置",
-                        "name": "podAffinity",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.podAffinity"
-                        }
                     }
                 ],
                 "responses": {
@@ -7430,17 +7354,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_pod_anti_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除Pod反亲和性",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/watch/list": {
+            "get": {
+                "summary": "文件监视列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -7448,49 +7372,42 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/file/watch/save": {
+            "post": {
+                "description": "新建或编辑容器文件监视，保存后立即以当前用户权限检查一次并记录基线；编辑后重新记录基线。webhooks 为 webhook 接收者 ID，逗号分隔",
+                "summary": "保存文件监视",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Pod反亲和性配置",
+                        "description": "文件监视",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.podAffinity"
+                            "$ref": "#/definitions/models.FileWatch"
                         }
                     }
                 ],
@@ -7501,67 +7418,33 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_tolerations/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除资源容忍度",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/graphql": {
+            "post": {
+                "description": "一次请求按需获取 Deployment、Pod、事件与实时指标等关联资源，需在平台参数中开启。schema 见 pkg/gql/schema.graphql",
+                "summary": "GraphQL 组合查询",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "容忍度配置信息",
+                        "description": "GraphQL 请求，包含 query、operationName、variables",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.Tolerations"
+                            "$ref": "#/definitions/gql.Request"
                         }
                     }
                 ],
@@ -7572,57 +7455,65 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/describe/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "描述资源",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/hibernation/delete/{ids}": {
+            "post": {
+                "description": "只删除计划，已休眠的工作负载保持当前副本数，可通过注解 k8m.io/hibernated-replicas 查看原副本数",
+                "summary": "删除休眠计划",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
+                        "description": "计划ID，多个用逗号分隔",
+                        "name": "ids",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/hibernation/id/{id}/sleep": {
+            "post": {
+                "description": "将计划中命名空间下的 Deployment、StatefulSet 缩容到 0，原副本数记录在注解 k8m.io/hibernated-replicas 中。\n带有注解 k8m.io/hibernate: \"false\" 的工作负载不参与休眠。以异步任务执行，返回任务ID",
+                "summary": "立即休眠",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "计划ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -7634,69 +7525,32 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/force_remove": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "批量强制删除资源",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/hibernation/id/{id}/wake": {
+            "post": {
+                "description": "将休眠的工作负载恢复到原副本数并移除注解；休眠期间已手动调整副本数的保留当前值。以异步任务执行，返回任务ID",
+                "summary": "立即唤醒",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
+                        "type": "integer",
+                        "description": "计划ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "资源名称列表",
-                        "name": "name_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    {
-                        "description": "命名空间列表",
-                        "name": "ns_list",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
                     }
                 ],
                 "responses": {
@@ -7706,59 +7560,99 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/image_pull_secrets/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取容器镜像拉取密钥选项",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/hibernation/id/{id}/workloads": {
+            "get": {
+                "description": "列出计划中命名空间下的 Deployment、StatefulSet，包括当前副本数与休眠前记录的副本数",
+                "summary": "休眠计划涉及的工作负载",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "type": "integer",
+                        "description": "计划ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.HibernationWorkload"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/hibernation/list": {
+            "get": {
+                "summary": "休眠计划列表",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/hibernation/save": {
+            "post": {
+                "description": "新建或编辑休眠计划。namespaces 为逗号分隔的命名空间；enabled 为 true 时按 sleep_cron、wake_cron 定时休眠与唤醒，\n如工作日 20 点休眠（0 20 * * 1-5）、8 点唤醒（0 8 * * 1-5）。定时执行以最近一次保存计划的用户身份进行",
+                "summary": "保存休眠计划",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "休眠计划",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Hibernation"
+                        }
                     }
                 ],
                 "responses": {
@@ -7768,114 +7662,84 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list/ns/{ns}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取资源列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/image/inspect": {
+            "get": {
+                "description": "从镜像仓库读取镜像的层、大小、入口命令、环境变量、端口、标签、创建时间等信息，私有仓库使用平台配置的仓库凭据",
+                "summary": "查询镜像元数据",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
+                        "description": "镜像名称，如 nginx:1.25",
+                        "name": "image",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
+                        "description": "命名空间，用于匹配命名空间级别的仓库凭据",
+                        "name": "namespace",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "多架构镜像选择的平台，默认 linux/amd64",
+                        "name": "platform",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/registry.ImageInfo"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_node_affinity/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取节点亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/image/pull_failures": {
+            "get": {
+                "description": "列出处于 ImagePullBackOff/ErrImagePull 状态的容器，分析是否由缺少仓库凭据导致，并给出可同步的平台凭据",
+                "summary": "镜像拉取失败分析",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "命名空间，为空查询全部",
+                        "name": "namespace",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "按原因过滤：missing_credentials、invalid_credentials、image_not_found、other",
+                        "name": "cause",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -7884,47 +7748,34 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/service.ImagePullFailure"
                             }
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_pod_affinity/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/image/workload/kind/{kind}/ns/{ns}/name/{name}": {
+            "get": {
+                "description": "读取工作负载所有容器（含 initContainer）使用的镜像元数据，私有仓库依次使用平台配置的仓库凭据、工作负载的 imagePullSecrets",
+                "summary": "查询工作负载使用的镜像元数据",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
+                        "description": "资源类型，支持 Pod、Deployment、StatefulSet、DaemonSet、ReplicaSet、Job、CronJob",
+                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
@@ -7941,6 +7792,12 @@ var doc = `{
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "多架构镜像选择的平台，默认 linux/amd64",
+                        "name": "platform",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -7949,61 +7806,55 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/image.ContainerImage"
                             }
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_pod_anti_affinity/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod反亲和性列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/image/workload/kind/{kind}/ns/{ns}/name/{name}/container/{container}/rollback": {
+            "post": {
+                "description": "将容器镜像恢复为上一次通过镜像更新接口修改前的镜像，回滚前的镜像同样被记录，再次回滚即可撤销",
+                "summary": "回滚工作负载容器镜像",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API组",
-                        "name": "group",
+                        "description": "资源类型，支持 Deployment、StatefulSet、DaemonSet",
+                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "资源名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
+                        "description": "容器名称",
+                        "name": "container",
                         "in": "path",
                         "required": true
                     }
@@ -8012,46 +7863,33 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.WorkloadImageResult"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_tolerations/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取资源容忍度列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/image/workload/kind/{kind}/ns/{ns}/name/{name}/update": {
+            "post": {
+                "description": "只修改一个容器的镜像，无需编辑完整YAML。image 可带标签或摘要；pin_digest=true 时从镜像仓库解析标签对应的摘要，写入 image:tag@sha256:xxx。更新前的镜像记录在工作负载注解 previous-image.k8m.io/<容器名> 中，可一键回滚",
+                "summary": "更新工作负载容器镜像",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "资源类型，支持 Deployment、StatefulSet、DaemonSet",
+                        "name": "kind",
                         "in": "path",
                         "required": true
                     },
@@ -8068,68 +7906,67 @@ var doc = `{
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "镜像更新内容",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.WorkloadImageUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.WorkloadImageResult"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取资源YAML",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/import": {
+            "post": {
+                "description": "上传 tar、tar.gz、zip 压缩包或多文档YAML，解析校验全部文档并逐个服务端预演，全部通过后按依赖顺序（Namespace、CRD优先）应用。\n应用过程中任一资源失败时逆序回滚：本次新创建的资源被删除，更新过的资源恢复为导入前的定义，并返回每个资源的处理结果。\n同时按集群版本检查已弃用或已移除的 apiVersion，在 deprecations 中返回建议的替代版本。",
+                "summary": "导入清单",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "清单文件或压缩包",
+                        "name": "file",
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
+                        "description": "多文档YAML文本，与file二选一",
+                        "name": "yaml",
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "为true时仅预演不应用",
+                        "name": "dry_run",
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "为true时将使用已弃用 apiVersion 且可无损转换的资源自动改为替代版本",
+                        "name": "convert_deprecated",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -8139,17 +7976,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/event": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取资源事件",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/ingress_class/option_list": {
+            "get": {
+                "summary": "获取 IngressClass 选项列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -8157,41 +7994,6 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -8201,17 +8003,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/hpa": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取资源HPA信息",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/ingress_class/set_default/name/{name}": {
+            "post": {
+                "summary": "设置默认的 IngressClass",
                 "parameters": [
                     {
                         "type": "string",
@@ -8222,35 +8024,7 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
+                        "description": "IngressClass 名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -8263,17 +8037,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/json": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取资源JSON",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/add_taints/name/{name}": {
+            "post": {
+                "summary": "添加污点",
                 "parameters": [
                     {
                         "type": "string",
@@ -8284,38 +8058,19 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "节点名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "污点信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/node.TaintInfo"
+                        }
                     }
                 ],
                 "responses": {
@@ -8325,17 +8080,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/configmap": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的ConfigMap",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/batch/cordon": {
+            "post": {
+                "summary": "批量将指定的 Kubernetes 节点设置为不可调度（cordon）",
                 "parameters": [
                     {
                         "type": "string",
@@ -8345,39 +8100,16 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "节点名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
@@ -8387,17 +8119,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/endpoints": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的端点",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/batch/drain": {
+            "post": {
+                "summary": "批量驱逐指定的 Kubernetes 节点",
                 "parameters": [
                     {
                         "type": "string",
@@ -8407,39 +8139,22 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
+                        "description": "节点名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     },
                     {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "为 true 时作为异步任务执行，返回任务ID，可在 /mgm/tasks 查看进度与取消",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -8449,17 +8164,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/env": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的环境变量",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/batch/uncordon": {
+            "post": {
+                "summary": "批量解除指定节点的隔离状态（Uncordon），使其重新可调度",
                 "parameters": [
                     {
                         "type": "string",
@@ -8469,36 +8184,47 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "description": "节点名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/cordon/name/{name}": {
+            "post": {
+                "summary": "隔离指定节点",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
+                        "description": "节点名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -8511,17 +8237,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/envFromPod": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的来自其他Pod的环境变量",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/delete_taints/name/{name}": {
+            "post": {
+                "summary": "删除污点",
                 "parameters": [
                     {
                         "type": "string",
@@ -8532,38 +8258,19 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "节点名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "污点信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/node.TaintInfo"
+                        }
                     }
                 ],
                 "responses": {
@@ -8573,17 +8280,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/ingress": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的Ingress",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/drain/name/{name}": {
+            "post": {
+                "summary": "驱逐指定节点",
                 "parameters": [
                     {
                         "type": "string",
@@ -8594,38 +8301,16 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "节点名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "为 true 时作为异步任务执行，返回任务ID，可在 /mgm/tasks 查看进度与取消",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -8635,17 +8320,18 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/node": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的节点",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/drain/preview": {
+            "post": {
+                "description": "节点上的 Pod 与指定的 Pod 合并评估，同一 PDB 的可中断数按全部待驱逐 Pod 累计消耗",
+                "summary": "预览驱逐多个节点或指定 Pod 的影响",
                 "parameters": [
                     {
                         "type": "string",
@@ -8655,59 +8341,47 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "节点名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Pod 列表",
+                        "name": "pods",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.DrainPodKey"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/disruption.Preview"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/pv": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的PV",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/drain/preview/name/{name}": {
+            "get": {
+                "description": "列出节点上将被驱逐与忽略的 Pod、适用的 PDB 及其剩余可中断数，并判断驱逐是否会因 PDB 被拒绝，不做任何变更",
+                "summary": "预览驱逐节点的影响",
                 "parameters": [
                     {
                         "type": "string",
@@ -8718,35 +8392,7 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
+                        "description": "节点名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -8756,20 +8402,20 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/disruption.Preview"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/pvc": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的PVC",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/labels/list": {
+            "get": {
+                "summary": "获取所有节点上的标签",
                 "parameters": [
                     {
                         "type": "string",
@@ -8777,41 +8423,6 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -8821,17 +8432,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/secret": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的Secret",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/labels/unique_labels": {
+            "get": {
+                "summary": "获取选定集群中所有唯一的节点标签键，并以选项列表形式返回",
                 "parameters": [
                     {
                         "type": "string",
@@ -8839,41 +8450,6 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -8883,17 +8459,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/services": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取Pod关联的服务",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/list_taints/name/{name}": {
+            "get": {
+                "summary": "获取某个节点上的污点",
                 "parameters": [
                     {
                         "type": "string",
@@ -8904,35 +8480,7 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
+                        "description": "节点名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -8945,17 +8493,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/scale/replica/{replica}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "扩缩容资源",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/name/option_list": {
+            "get": {
+                "summary": "获取节点名称选项列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -8963,46 +8511,45 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/name/{node_name}/create_kubectl_shell": {
+            "post": {
+                "summary": "创建kubectl shell",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
+                        "description": "节点名称",
+                        "name": "node_name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "副本数",
-                        "name": "replica",
+                        "description": "集群ID，base64编码",
+                        "name": "cluster_id",
                         "in": "path",
                         "required": true
                     }
@@ -9014,17 +8561,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/remove/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "删除单个资源",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/name/{node_name}/create_node_shell": {
+            "post": {
+                "summary": "创建节点shell",
                 "parameters": [
                     {
                         "type": "string",
@@ -9035,37 +8582,36 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "节点名称",
+                        "name": "node_name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/taints/list": {
+            "get": {
+                "summary": "获取所有节点上的污点",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -9076,17 +8622,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新资源",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/top/list": {
+            "get": {
+                "summary": "返回所有节点的资源使用率（top指标），包括CPU和内存的用量及其数值化表示，便于前端排序和展示",
                 "parameters": [
                     {
                         "type": "string",
@@ -9094,49 +8640,82 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/uncordon/name/{name}": {
+            "post": {
+                "summary": "解除指定节点隔离",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
+                        "description": "节点名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/update_taints/name/{name}": {
+            "post": {
+                "summary": "修改污点",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
+                        "description": "节点名称",
                         "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "资源YAML内容",
-                        "name": "yaml",
+                        "description": "污点信息",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/node.TaintInfo"
                         }
                     }
                 ],
@@ -9147,17 +8726,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_annotations/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新资源注解",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/node/usage/name/{name}": {
+            "get": {
+                "summary": "获取节点资源使用情况",
                 "parameters": [
                     {
                         "type": "string",
@@ -9168,47 +8747,53 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "节点名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/ns/clone/ns/{ns}": {
+            "post": {
+                "description": "将命名空间中选定类型的资源克隆到新的命名空间或其他集群，用于快速搭建测试环境。\n可按前缀、后缀、子串替换改写资源名称，引用被改名资源的字段（卷、环境变量、ServiceAccount、Ingress 后端、RoleBinding 等）同步改写；\nSecret 可原样复制（copy）、不复制（exclude）或使用目标集群 sealed-secrets 证书重新加密为 SealedSecret（seal）。\n由控制器生成的资源（如 ReplicaSet、Pod）不克隆，PVC 只克隆声明不复制数据。dry_run 为 true 时仅在目标集群预演",
+                "summary": "克隆命名空间",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
+                        "description": "源命名空间",
                         "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "注解键值对",
-                        "name": "annotations",
+                        "description": "克隆参数",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/service.NamespaceCloneRequest"
                         }
                     }
                 ],
@@ -9216,20 +8801,20 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.NamespaceCloneResult"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_env/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新容器环境变量",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/ns/create_resource_quota": {
+            "post": {
+                "summary": "创建资源配额",
                 "parameters": [
                     {
                         "type": "string",
@@ -9239,47 +8824,12 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "容器环境变量信息",
+                        "description": "资源配额配置",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.ContainerEnv"
+                            "type": "object"
                         }
                     }
                 ],
@@ -9290,17 +8840,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_health_checks/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新容器健康检查配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/ns/option_list": {
+            "get": {
+                "summary": "获取命名空间选项列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -9308,50 +8858,6 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "健康检查配置信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.HealthCheckInfo"
-                        }
                     }
                 ],
                 "responses": {
@@ -9361,67 +8867,91 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_image/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新容器镜像标签",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/ns/timeline/ns/{ns}": {
+            "get": {
+                "description": "合并平台操作日志、Kubernetes 事件与工作负载发布记录，按时间倒序返回，用于排查\"最近一小时改了什么\"。\n经由 k8m 的变更会在资源上记录注解 k8m.io/change-id，之后的 Warning 事件、发布与发布失败沿属主链关联到该变更，在 change 中返回变更ID、操作人与操作",
+                "summary": "获取命名空间变更时间线",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
+                        "description": "开始时间，时长（如 1h、30m，表示距今）或 RFC3339 时间，默认 1h",
+                        "name": "since",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
+                        "description": "结束时间，RFC3339 时间，默认当前时间",
+                        "name": "until",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
+                        "description": "来源过滤，逗号分隔：audit、event、rollout",
+                        "name": "source",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "变更ID，只返回该变更的操作日志及由其引起的 Warning 事件与发布",
+                        "name": "change",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/batch/uninstall": {
+            "post": {
+                "summary": "批量卸载Helm Release",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "镜像信息",
+                        "description": "批量卸载参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.imageInfo"
+                            "type": "object"
                         }
                     }
                 ],
@@ -9432,17 +8962,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_labels/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新资源标签",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/list": {
+            "get": {
+                "summary": "获取Release列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -9450,26 +8980,32 @@ var doc = `{
                         "name": "cluster",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/history/list": {
+            "get": {
+                "summary": "获取Release的历史版本",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
@@ -9481,22 +9017,10 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
+                        "description": "Release名称",
                         "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "标签键值对",
-                        "name": "labels",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 ],
                 "responses": {
@@ -9506,17 +9030,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_node_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新节点亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/revision/{revision}/install_log": {
+            "get": {
+                "summary": "获取Release安装Log",
                 "parameters": [
                     {
                         "type": "string",
@@ -9527,24 +9051,51 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
+                        "description": "Release名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
+                        "description": "版本号",
+                        "name": "revision",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/revision/{revision}/notes": {
+            "get": {
+                "summary": "获取ReleaseNote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -9555,19 +9106,17 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
+                        "description": "Release名称",
                         "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "节点亲和性配置",
-                        "name": "nodeAffinity",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.nodeAffinity"
-                        }
+                        "type": "string",
+                        "description": "版本号",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -9577,17 +9126,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_pod_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新Pod亲和性配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/revision/{revision}/values": {
+            "get": {
+                "summary": "获取安装yaml",
                 "parameters": [
                     {
                         "type": "string",
@@ -9598,47 +9147,24 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
+                        "description": "Release名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
+                        "description": "版本号",
+                        "name": "revision",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Pod亲和性配置",
-                        "name": "podAffinity",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.podAffinity"
-                        }
                     }
                 ],
                 "responses": {
@@ -9648,17 +9174,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_pod_anti_affinity/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新Pod反亲和性",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/ns/{ns}/name/{name}/uninstall": {
+            "post": {
+                "summary": "卸载Helm Release",
                 "parameters": [
                     {
                         "type": "string",
@@ -9669,46 +9195,52 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "Release名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
-                        "in": "path",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/upgrade": {
+            "post": {
+                "summary": "升级Helm Release",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Pod反亲和性配置",
+                        "description": "升级参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.podAffinity"
+                            "type": "object"
                         }
                     }
                 ],
@@ -9719,17 +9251,17 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_resources/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新容器资源配置",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/plugins/helm/release/{release}/repo/{repo}/chart/{chart}/version/{version}/install": {
+            "post": {
+                "summary": "安装Helm Release",
                 "parameters": [
                     {
                         "type": "string",
@@ -9740,46 +9272,39 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API组",
-                        "name": "group",
+                        "description": "Release名称",
+                        "name": "release",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "仓库名称",
+                        "name": "repo",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "ns",
+                        "description": "Chart名称",
+                        "name": "chart",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
+                        "description": "版本号",
+                        "name": "version",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "资源配置信息",
+                        "description": "安装参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dynamic.resourceInfo"
+                            "type": "object"
                         }
                     }
                 ],
@@ -9790,43 +9315,65 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_tolerations/ns/{ns}/name/{name}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "更新资源容忍度",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/containers/ns/{ns}/name/{name}": {
+            "get": {
+                "description": "按顺序列出 init 容器、原生 sidecar（restartPolicy: Always 的 init 容器）与业务容器的状态，\n给出当前执行或阻塞的 init 步骤、失败原因与上一次退出码，状态与 kubectl 的 Init:1/3、Init:CrashLoopBackOff 一致。\nPod 列表与 JSON 详情中以注解 init.status、init.progress、init.current、init.reason、sidecars、sidecars.ready 返回摘要",
+                "summary": "Pod 容器初始化详情",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API组",
-                        "name": "group",
+                        "description": "Pod名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/podinit.Insight"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/crashloop/ns/{ns}": {
+            "get": {
+                "description": "检查处于 CrashLoopBackOff 等状态的容器，汇总上次终止退出码、OOMKilled、探针失败事件与上一次运行的日志末尾，给出诊断类别：oom、bad_command、failed_probe、missing_config、app_error、unknown",
+                "summary": "诊断重启循环的容器",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "API版本",
-                        "name": "version",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     },
@@ -9839,19 +9386,15 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
+                        "description": "Pod名称，为空时诊断整个命名空间",
                         "name": "name",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
-                        "description": "容忍度配置信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dynamic.Tolerations"
-                        }
+                        "type": "string",
+                        "description": "按诊断类别过滤",
+                        "name": "cause",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -9861,184 +9404,232 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/cluster/{cluster}/reconnect": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "重新连接一个已断开的集群",
-                "summary": "重新连接集群",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/describe/ns/{ns}/name/{name}": {
+            "get": {
+                "description": "一次返回 kubectl describe pod 展示的全部信息：状态条件、容忍、卷、事件、容器状态及上次终止原因",
+                "summary": "获取Pod描述信息",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Base64编码的集群ID",
+                        "description": "集群名称",
                         "name": "cluster",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "已执行，请稍后刷新",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/pod.PodDescribe"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/custom/template/delete/{ids}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除一个或多个自定义模板",
-                "summary": "删除模板",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/diagnostics/file_globs": {
+            "get": {
+                "description": "返回平台配置的容器内文件匹配模式，用于下载诊断包时预填",
+                "summary": "诊断包默认收集的文件",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "要删除的模板ID，多个用逗号分隔",
-                        "name": "ids",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/custom/template/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有自定义模板信息",
-                "summary": "模板列表",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/mgm/custom/template/save": {
-            "post": {
-                "security": [
+        "/k8s/cluster/{cluster}/pod/diagnostics/ns/{ns}/name/{name}": {
+            "get": {
+                "description": "将 Pod YAML、describe、事件、重启诊断、各容器最近日志（有重启时包含上一次运行的日志）、环境变量（Secret 值已掩码）与容器内匹配的文件打包为 tar.gz，用于附加到工单。\n各项尽力收集，失败原因记录在包内 errors.txt；收集容器内文件需要 Exec 权限",
+                "summary": "下载 Pod 诊断包",
+                "parameters": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "新增或更新自定义模板",
-                "summary": "保存模板",
-                "parameters": [
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
                     {
-                        "description": "模板信息",
-                        "name": "template",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CustomTemplate"
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每个容器读取的日志行数，默认1000，最大10000",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器内收集的文件匹配模式，逗号分隔，如 /var/log/*.log；不传时使用平台配置，传空字符串表示不收集文件",
+                        "name": "files",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "返回模板ID",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/log/operation/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取所有操作日志",
-                "summary": "操作日志列表",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/mgm/log/shell/list": {
+        "/k8s/cluster/{cluster}/pod/dns/ns/{ns}/name/{name}": {
             "get": {
-                "security": [
+                "description": "读取容器内的 /etc/resolv.conf，在容器内解析 kubernetes.default 的完整域名与短名称、外部域名及指定的域名，\n并与集群 DNS Service、CoreDNS 的 Corefile 与就绪情况对照，返回结构化的诊断结论",
+                "summary": "诊断 Pod 的 DNS",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称，默认第一个容器",
+                        "name": "container",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用于测试的外部域名，默认 kubernetes.io",
+                        "name": "external",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "额外解析的域名，多个用逗号分隔，最多 10 个",
+                        "name": "names",
+                        "in": "query"
                     }
                 ],
-                "description": "获取所有Shell操作日志",
-                "summary": "Shell日志列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.DNSDiagnosis"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/any_question": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "回答K8s相关问题",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/exec-command/ns/{ns}/name/{name}": {
+            "post": {
+                "description": "以非 TTY 方式执行命令，返回 stdout、stderr 与退出码，超时后中止。非平台管理员的命令需匹配管理员配置的允许列表",
+                "summary": "在容器中执行一次性命令",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "query"
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "query"
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "问题内容",
-                        "name": "question",
-                        "in": "query"
+                        "description": "命令",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.ExecCommandRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -10048,149 +9639,152 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/any_selection": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "解释选择内容",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/index/image": {
+            "get": {
+                "description": "镜像指定标签或摘要时精确匹配，否则匹配该仓库的所有标签；由 Deployment 创建的 Pod 归到 Deployment，无控制者的 Pod 单独列出",
+                "summary": "使用镜像的工作负载",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "要解释的内容",
-                        "name": "question",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "镜像，如 nginx、nginx:1.25、ghcr.io/org/app@sha256:xxx",
+                        "name": "image",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/podindex.WorkloadRef"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/cron": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "分析Cron表达式",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/index/node/{node}": {
+            "get": {
+                "description": "从资源监听维护的索引中查询，未启用资源监听时直接读取集群。只返回当前用户有权限的命名空间中的 Pod",
+                "summary": "节点上的 Pod",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cron表达式",
-                        "name": "cron",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "节点名称",
+                        "name": "node",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/podindex.PodRef"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/describe": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "分析K8s资源描述",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/index/{kind}/ns/{ns}/name/{name}": {
+            "get": {
+                "description": "包括卷（含 projected 卷）、env、envFrom 引用，Secret 还包括 imagePullSecrets；PVC 包括通用临时卷创建的 PVC",
+                "summary": "引用 ConfigMap、Secret 或 PVC 的 Pod",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
+                        "description": "引用类型：configmap、secret、pvc",
                         "name": "kind",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "query"
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "命名空间",
-                        "name": "namespace",
-                        "in": "query"
+                        "description": "对象名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/podindex.PodRef"
+                            }
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/event": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "分析K8s事件",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/labels/unique_labels": {
+            "get": {
+                "summary": "获取Pod唯一标签键列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "事件备注",
-                        "name": "note",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "事件来源",
-                        "name": "source",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "事件原因",
-                        "name": "reason",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "事件类型",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "相关资源类型",
-                        "name": "regardingKind",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -10200,35 +9794,55 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/example": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取K8s资源使用示例",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/lifecycle/ns/{ns}/name/{name}/action/{action}": {
+            "post": {
+                "description": "action 可选 force_delete（按指定宽限期强制删除）、reschedule（隔离所在节点后删除，节点保持隔离）、recreate_elsewhere（临时隔离所在节点，替代 Pod 调度到其他节点后自动解除隔离）。\nconfirm 为 false 时只返回确认信息（归属控制器、节点影响、风险提示），页面确认后以 confirm=true 再次调用执行",
+                "summary": "Pod 生命周期操作",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "query"
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "query"
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "操作参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.PodActionOptions"
+                        }
                     }
                 ],
                 "responses": {
@@ -10238,135 +9852,188 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/example/field": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取K8s资源字段示例",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/logs/download/ns/{ns}/pod_name/{pod_name}/container/{container_name}": {
+            "get": {
+                "summary": "下载Pod日志",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "query"
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "query"
+                        "description": "Pod名称",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "字段名称",
-                        "name": "field",
-                        "in": "query"
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "日志文件",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/gptshell": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "通过WebSocket提供GPT交互式对话终端",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/logs/sse/ns/{ns}/pod_name/{pod_name}/container/{container_name}": {
+            "get": {
+                "summary": "流式获取Pod日志",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "集群名称",
                         "name": "cluster",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
                         "description": "命名空间",
-                        "name": "namespace",
-                        "in": "query"
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Pod名称",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "resource",
-                        "in": "query"
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "日志流",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/name/option_list": {
+            "get": {
+                "summary": "获取Pod名称选项列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "对话内容",
-                        "name": "content",
+                        "description": "命名空间，为空则返回所有命名空间的Pod",
+                        "name": "ns",
                         "in": "query"
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/k8s_gpt/resource": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "K8s错误信息分析",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/port_forward/ns/{ns}/name/{name}/container/{container_name}/pod_port/{pod_port}/local_port/{local_port}/start": {
+            "post": {
+                "summary": "开始端口转发",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "错误内容",
-                        "name": "data",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源名称",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
                         "name": "name",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "query"
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "相关字段",
-                        "name": "field",
-                        "in": "query"
+                        "description": "Pod端口",
+                        "name": "pod_port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "本地端口",
+                        "name": "local_port",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -10376,61 +10043,93 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/log": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "分析日志",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/port_forward/ns/{ns}/name/{name}/container/{container_name}/pod_port/{pod_port}/stop": {
+            "post": {
+                "summary": "停止端口转发",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "日志内容",
-                        "name": "data",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod端口",
+                        "name": "pod_port",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/chat/resource": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取K8s资源使用指南",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/port_forward/ns/{ns}/name/{name}/port/list": {
+            "get": {
+                "summary": "列出端口转发信息",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "资源组",
-                        "name": "group",
-                        "in": "query"
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源版本",
-                        "name": "version",
-                        "in": "query"
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "资源类型",
-                        "name": "kind",
-                        "in": "query"
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -10440,54 +10139,98 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/ai/ws_chatgpt/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "summary": "获取聊天历史记录",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/mgm/plugins/ai/ws_chatgpt/reset": {
+        "/k8s/cluster/{cluster}/pod/probe/ns/{ns}/name/{name}/container/{container_name}/type/{type}": {
             "post": {
-                "security": [
+                "description": "立即执行一次容器的 liveness、readiness 或 startup 探针，返回是否成功、耗时、HTTP 状态码或命令输出，以及 kubelet 最近记录的同类探针失败事件。\n请求体中传入 probe 时使用该定义测试，可在不重新部署的情况下调试探针；exec 探针受命令允许列表约束，httpGet、tcpSocket 探针经 API Server 的 Pod 代理访问",
+                "summary": "测试容器探针",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "探针类型：liveness、readiness、startup",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "探针定义，测试已配置的探针时传 {}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pod.ProbeTestRequest"
+                        }
                     }
                 ],
-                "summary": "重置聊天历史记录",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.ProbeTestResult"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/mgm/plugins/helm/chart/list": {
+        "/k8s/cluster/{cluster}/pod/top/ns/{ns}/list": {
             "get": {
-                "security": [
+                "summary": "获取Pod资源使用情况列表",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间，多个用逗号分隔",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取所有Helm Chart信息",
-                "summary": "Helm Chart列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10495,95 +10238,88 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/helm/repo/{repo}/chart/{chart}/version/{version}/values": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取指定Helm仓库、Chart及版本的默认values.yaml内容",
-                "summary": "获取Chart的默认values.yaml",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/usage/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取Pod资源使用情况",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "仓库名称",
-                        "name": "repo",
-                        "in": "path",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Chart名称",
-                        "name": "chart",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Chart版本",
-                        "name": "version",
+                        "description": "Pod名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "yaml内容",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/plugins/helm/repo/{repo}/chart/{chart}/versions": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取指定Helm仓库和Chart的所有版本列表",
-                "summary": "Chart版本列表",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/pod/volumes/ns/{ns}/name/{name}": {
+            "get": {
+                "description": "列出 Pod 的全部卷及来源（PVC、ConfigMap、Secret、hostPath 等）、挂载路径，并读取 kubelet 卷统计；du=true 时在容器内执行 du 列出占用最大的目录，需要 Exec 权限",
+                "summary": "Pod 卷与磁盘用量",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "仓库名称",
-                        "name": "repo",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Chart名称",
-                        "name": "chart",
+                        "description": "命名空间",
+                        "name": "ns",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/mgm/user/profile": {
-            "get": {
-                "security": [
+                    },
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否在容器内执行 du 统计",
+                        "name": "du",
+                        "in": "query"
                     }
                 ],
-                "description": "获取当前登录用户的详细信息",
-                "summary": "获取用户信息",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10591,151 +10327,189 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/2fa/disable": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "禁用当前用户的二步验证",
-                "summary": "禁用2FA",
-                "responses": {
-                    "200": {
-                        "description": "操作成功",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/mgm/user/profile/2fa/enable": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "验证并启用当前用户的二步验证",
-                "summary": "启用2FA",
+        "/k8s/cluster/{cluster}/pod/xterm/ns/{ns}/pod_name/{pod_name}": {
+            "get": {
+                "summary": "提供Pod容器的交互式终端会话",
                 "parameters": [
                     {
-                        "description": "验证码",
-                        "name": "code",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "description": "应用名称",
-                        "name": "app_name",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称，默认为第一个容器",
+                        "name": "container_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "会话结束后是否删除Pod",
+                        "name": "remove",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "操作成功",
+                    "101": {
+                        "description": "WebSocket连接成功",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/2fa/generate": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/rbac/who_can": {
+            "get": {
+                "description": "列出可以对指定资源执行指定动作的主体及授予该权限的绑定。namespace 为空时只查询集群级授权",
+                "summary": "反查权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "动作，如 get、delete",
+                        "name": "verb",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源，如 pods、pods/exec",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API 组，核心组留空",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "非资源地址，如 /metrics",
+                        "name": "non_resource",
+                        "in": "query"
+                    }
                 ],
-                "description": "生成当前用户的二步验证密钥和二维码",
-                "summary": "生成2FA密钥",
                 "responses": {
                     "200": {
-                        "description": "返回密钥、二维码和备用码",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/api_keys/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "为当前用户创建一个新的API密钥",
-                "summary": "创建API密钥",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/replicaset/batch/restart": {
+            "post": {
+                "summary": "批量重启ReplicaSet",
                 "parameters": [
                     {
-                        "description": "密钥描述",
-                        "name": "description",
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "包含name_list和ns_list的请求体",
+                        "name": "body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/api_keys/delete/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除指定ID的API密钥",
-                "summary": "删除API密钥",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/replicaset/batch/restore": {
+            "post": {
+                "summary": "批量恢复ReplicaSet",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "API密钥ID",
-                        "name": "id",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "操作成功",
+                    },
+                    {
+                        "description": "包含name_list和ns_list的请求体",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "object"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/api_keys/list": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
                 ],
-                "description": "获取当前用户的所有API密钥",
-                "summary": "获取API密钥列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10743,94 +10517,117 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/cluster/permissions/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "列出当前登录用户所拥有的集群权限",
-                "summary": "获取用户集群权限",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/mgm/user/profile/mcp_keys/create": {
+        "/k8s/cluster/{cluster}/replicaset/batch/stop": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "为当前用户创建一个新的MCP密钥（10年有效期）",
-                "summary": "创建MCP密钥",
+                "summary": "批量停止ReplicaSet",
                 "parameters": [
                     {
-                        "description": "密钥描述",
-                        "name": "description",
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "包含name_list和ns_list的请求体",
+                        "name": "body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/mcp_keys/delete/{id}": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "删除指定ID的MCP密钥",
-                "summary": "删除MCP密钥",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/replicaset/ns/{ns}/name/{name}/events/all": {
+            "get": {
+                "summary": "获取ReplicaSet相关事件列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "MCP密钥ID",
-                        "name": "id",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ReplicaSet名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/mcp_keys/list": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/replicaset/ns/{ns}/name/{name}/hpa": {
+            "get": {
+                "summary": "获取ReplicaSet相关HPA列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ReplicaSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "description": "获取当前用户的所有MCP密钥",
-                "summary": "获取MCP密钥列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10838,133 +10635,189 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/mgm/user/profile/update_psw": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "修改当前登录用户的密码，需要验证原密码并两次输入新密码确认",
-                "summary": "修改密码",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/replicaset/ns/{ns}/name/{name}/restart": {
+            "post": {
+                "summary": "重启指定的ReplicaSet",
                 "parameters": [
                     {
-                        "description": "密码修改请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/profile.PasswordUpdateRequest"
-                        }
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ReplicaSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
                     }
-                }
-            }
-        },
-        "/params/cluster/all": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取当前登录用户可见的集群详细信息（表格）",
-                "summary": "集群表格列表",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
+                ]
             }
         },
-        "/params/cluster/option_list": {
+        "/k8s/cluster/{cluster}/reports/data": {
             "get": {
-                "security": [
+                "description": "返回表格数据，用于页面展示",
+                "summary": "预览报表",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "报表类型：inventory、rbac、images、quota",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
                     }
                 ],
-                "description": "获取当前登录用户可选的集群列表（下拉选项）",
-                "summary": "集群选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/report.Table"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/params/condition/reverse/list": {
+        "/k8s/cluster/{cluster}/reports/export": {
             "get": {
-                "security": [
+                "description": "xlsx 可一次导出多种报表，每种报表一个工作表；csv 一次只能导出一种报表",
+                "summary": "导出报表",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "报表类型，逗号分隔，为空表示全部",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "导出格式：xlsx（默认）或 csv",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
-                "description": "获取所有启用的翻转显示指标名称",
-                "summary": "翻转指标列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     }
-                }
-            }
-        },
-        "/params/config/{key}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "获取指定key的系统配置项",
-                "summary": "获取配置项",
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/reports/oom": {
+            "get": {
+                "description": "按天统计后台采集的 OOMKilled 与节点驱逐次数，按工作负载或命名空间汇总",
+                "summary": "OOMKilled 与驱逐趋势",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "配置项key",
-                        "name": "key",
+                        "description": "集群名称",
+                        "name": "cluster",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "统计天数，默认7，最大90",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "汇总维度：workload（默认）或 namespace",
+                        "name": "group_by",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.OOMReport"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/params/helm/repo/option_list": {
+        "/k8s/cluster/{cluster}/reports/oom/list": {
             "get": {
-                "security": [
+                "description": "分页查询后台采集的 OOMKilled 与驱逐明细，可按 kind、namespace、workload、pod 过滤",
+                "summary": "OOMKilled 与驱逐记录列表",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取所有Helm仓库名称，用于下拉选项",
-                "summary": "Helm仓库选项列表",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10972,37 +10825,71 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/params/user/role": {
+        "/k8s/cluster/{cluster}/reports/types": {
             "get": {
-                "security": [
+                "summary": "报表类型列表",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取当前登录用户的角色及默认集群",
-                "summary": "获取用户角色信息",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.ReportType"
+                            }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/params/version": {
+        "/k8s/cluster/{cluster}/sa/permissions/ns/{ns}/name/{name}": {
             "get": {
-                "security": [
+                "description": "汇总 ServiceAccount 直接绑定及经由 system:serviceaccounts 等组获得的全部 RBAC 规则，按生效范围合并",
+                "summary": "ServiceAccount 有效权限",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ServiceAccount名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取当前软件的版本及构建信息",
-                "summary": "获取版本信息",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -11010,613 +10897,10922 @@ var doc = `{
                             "type": "string"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "doc.DetailReq": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
                 },
-                "translate": {
-                    "type": "string"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "dynamic.ContainerEnv": {
-            "type": "object",
-            "properties": {
-                "container_name": {
+        "/k8s/cluster/{cluster}/sa/token/ns/{ns}/name/{name}": {
+            "post": {
+                "description": "通过 TokenRequest 签发短期令牌用于调试，需要该命名空间的变更权限，签发记录写入操作日志",
+                "summary": "签发 ServiceAccount 令牌",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ServiceAccount名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "有效期与受众",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/sa.TokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/service/create": {
+            "post": {
+                "summary": "创建Service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Service创建参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/batch/restart": {
+            "post": {
+                "summary": "批量重启StatefulSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "StatefulSet名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/batch/restore": {
+            "post": {
+                "summary": "批量恢复StatefulSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "StatefulSet名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/batch/stop": {
+            "post": {
+                "summary": "批量停止StatefulSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "StatefulSet名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/hpa": {
+            "get": {
+                "summary": "获取StatefulSet的HPA列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/ordered_restart": {
+            "post": {
+                "description": "作为异步任务执行，逐个删除 Pod 并等待重建的 Pod 就绪后再处理下一个，某个 Pod 超时未就绪时停止。返回任务ID，可在 /mgm/tasks 查看进度与取消",
+                "summary": "按序号逐个重启StatefulSet的Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "重启参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.StatefulSetRestartOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/orphan_delete": {
+            "post": {
+                "description": "以 Orphan 级联策略删除，Pod 与 PVC 继续运行。返回删除前的定义，修改后重新创建即可接管原有 Pod",
+                "summary": "保留Pod删除StatefulSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/partition": {
+            "post": {
+                "description": "序号不小于分区的 Pod 更新到目标版本，其余保持当前版本；逐步调小分区即可分批发布",
+                "summary": "设置StatefulSet滚动更新分区",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分区，0 到副本数之间",
+                        "name": "partition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.StatefulSetRevisionStatus"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/restart": {
+            "post": {
+                "summary": "重启StatefulSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/revision/{revision}/rollout/undo": {
+            "post": {
+                "summary": "回滚StatefulSet到指定版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/revisions": {
+            "get": {
+                "description": "返回更新策略、分区、当前版本与目标版本，以及各版本上的 Pod 序号",
+                "summary": "获取StatefulSet版本分布",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.StatefulSetRevisionStatus"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/rollout/history": {
+            "get": {
+                "summary": "获取StatefulSet滚动历史",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/statefulset/ns/{ns}/name/{name}/scale/replica/{replica}": {
+            "post": {
+                "summary": "扩缩容StatefulSet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "StatefulSet名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "副本数",
+                        "name": "replica",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/status/resource_count/cache_seconds/{cache}": {
+            "get": {
+                "summary": "获取集群资源数量统计",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "缓存时间（秒）",
+                        "name": "cache",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/storage_class/option_list": {
+            "get": {
+                "summary": "获取存储类选项列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/storage_class/set_default/name/{name}": {
+            "post": {
+                "summary": "设置默认存储类",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "存储类名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/upgrade/readiness": {
+            "get": {
+                "description": "针对目标 Kubernetes 版本，检查仍在请求或仍以旧版本应用的弃用/移除 API、准入 Webhook 兼容性与后端可用性、\n节点 kubelet 版本偏差及是否跨越多个次版本，返回包含阻塞项的升级就绪报告",
+                "summary": "集群升级就绪检查",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "目标版本，如 1.30 或 v1.30.2",
+                        "name": "target",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.UpgradeReadinessReport"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_node_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "添加节点亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "节点亲和性配置",
+                        "name": "nodeAffinity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.nodeAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_pod_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "添加Pod亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pod亲和性配置",
+                        "name": "podAffinity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.podAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_pod_anti_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "添加Pod反亲和性",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pod反亲和性配置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.podAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/add_tolerations/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "添加资源容忍度",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "容忍度配置信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.Tolerations"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/annotations/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "列出资源注解",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/batch/remove": {
+            "post": {
+                "summary": "批量删除资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "资源名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/conditions/objects": {
+            "get": {
+                "description": "按条件类型（可选状态、原因）或按状态字段取值列出对象，status 为 Missing 时列出没有该条件的对象",
+                "summary": "自定义资源状态下钻",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "条件类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "条件状态：True、False、Unknown、Missing",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "条件原因",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态字段路径，如 status.health.status",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态字段取值",
+                        "name": "value",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/conditions.Object"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/conditions/summary": {
+            "get": {
+                "description": "汇总某类资源全部对象的 status.conditions，按条件类型、状态、原因计数，并按主条件统计健康对象数；\n展示的条件类型、主条件与额外统计的状态字段按 CRD 在 /admin/crd_condition 中配置",
+                "summary": "自定义资源状态看板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间，为空时汇总全部命名空间",
+                        "name": "ns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CRConditionSummary"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_env/ns/{ns}/name/{name}/container/{container_name}": {
+            "get": {
+                "summary": "获取容器环境变量信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_env_resolved/ns/{ns}/name/{name}/container/{container_name}": {
+            "get": {
+                "description": "按 kubelet 的顺序列出 envFrom 与 env，解析 ConfigMap/Secret 引用、fieldRef 与 resourceFieldRef；Secret 中的值被掩码，被同名变量覆盖的条目标记 overridden",
+                "summary": "获取容器生效的环境变量及其来源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.EnvVarView"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_health_checks/ns/{ns}/name/{name}/container/{container_name}": {
+            "get": {
+                "summary": "获取容器健康检查信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_info/ns/{ns}/name/{name}/container/{container_name}": {
+            "get": {
+                "summary": "获取容器基本信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/container_resources_info/ns/{ns}/name/{name}/container/{container_name}": {
+            "get": {
+                "summary": "获取容器资源信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_node_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "删除节点亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "节点亲和性配置",
+                        "name": "nodeAffinity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.nodeAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_pod_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "删除Pod亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pod亲和性配置",
+                        "name": "podAffinity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.podAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_pod_anti_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "删除Pod反亲和性",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pod反亲和性配置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.podAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/delete_tolerations/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "删除资源容忍度",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "容忍度配置信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.Tolerations"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/describe/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "描述资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/force_remove": {
+            "post": {
+                "summary": "批量强制删除资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "资源名称列表",
+                        "name": "name_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "命名空间列表",
+                        "name": "ns_list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/image_pull_secrets/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取容器镜像拉取密钥选项",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list/ns/{ns}": {
+            "post": {
+                "summary": "获取资源列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "查询条件，project 为项目名称时仅查询项目包含的命名空间",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_node_affinity/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取节点亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_pod_affinity/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取Pod亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_pod_anti_affinity/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取Pod反亲和性列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/list_tolerations/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取资源容忍度列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}": {
+            "get": {
+                "summary": "获取资源YAML",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/event": {
+            "get": {
+                "summary": "获取资源事件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/hpa": {
+            "get": {
+                "summary": "获取资源HPA信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/json": {
+            "get": {
+                "summary": "获取资源JSON",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/configmap": {
+            "get": {
+                "summary": "获取Pod关联的ConfigMap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/endpoints": {
+            "get": {
+                "summary": "获取Pod关联的端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/env": {
+            "get": {
+                "summary": "获取Pod关联的环境变量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/envFromPod": {
+            "get": {
+                "summary": "获取Pod关联的来自其他Pod的环境变量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/ingress": {
+            "get": {
+                "summary": "获取Pod关联的Ingress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/node": {
+            "get": {
+                "summary": "获取Pod关联的节点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/pv": {
+            "get": {
+                "summary": "获取Pod关联的PV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/pvc": {
+            "get": {
+                "summary": "获取Pod关联的PVC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/secret": {
+            "get": {
+                "summary": "获取Pod关联的Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/links/services": {
+            "get": {
+                "summary": "获取Pod关联的服务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/ns/{ns}/name/{name}/scale/replica/{replica}": {
+            "post": {
+                "summary": "扩缩容资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "副本数",
+                        "name": "replica",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/patch_env/ns/{ns}/name/{name}/container/{container_name}": {
+            "post": {
+                "description": "只修改请求中列出的变量，其余 env 与 envFrom 保持不变；set 中的变量写为直接填写的值，remove 中的变量从 env 中删除",
+                "summary": "修改容器环境变量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "container_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "环境变量修改内容",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.EnvPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/remove/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "删除单个资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/selector/validate": {
+            "post": {
+                "description": "解析查询构造器提交的标签选择器与字段选择器，返回规范化写法与估算的匹配数量；\n语法错误返回出错的字符位置（从 0 开始）。字段选择器支持的字段由资源类型决定，不支持时在 count_error 中给出集群返回的错误",
+                "summary": "校验标签与字段选择器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "选择器",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.SelectorValidateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.SelectorValidateResult"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "资源YAML内容",
+                        "name": "yaml",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_annotations/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新资源注解",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "注解键值对",
+                        "name": "annotations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_env/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新容器环境变量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "容器环境变量信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.ContainerEnv"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_health_checks/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新容器健康检查配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "健康检查配置信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.HealthCheckInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_image/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新容器镜像标签",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "镜像信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.imageInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_labels/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新资源标签",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签键值对",
+                        "name": "labels",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_node_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新节点亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "节点亲和性配置",
+                        "name": "nodeAffinity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.nodeAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_pod_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新Pod亲和性配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pod亲和性配置",
+                        "name": "podAffinity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.podAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_pod_anti_affinity/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新Pod反亲和性",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pod反亲和性配置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.podAffinity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_resources/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新容器资源配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "资源配置信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.resourceInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/k8s/cluster/{cluster}/{kind}/group/{group}/version/{version}/update_tolerations/ns/{ns}/name/{name}": {
+            "post": {
+                "summary": "更新资源容忍度",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API组",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "ns",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "容忍度配置信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dynamic.Tolerations"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/approval/id/{id}": {
+            "get": {
+                "summary": "变更申请详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "申请ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/approval/id/{id}/approve": {
+            "post": {
+                "description": "申请人不能审批自己的申请；执行失败时申请状态为 failed，message 为失败原因",
+                "summary": "批准变更申请",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "申请ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批意见",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/approval.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/approval/id/{id}/cancel": {
+            "post": {
+                "summary": "撤回变更申请",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "申请ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/approval/id/{id}/reject": {
+            "post": {
+                "summary": "驳回变更申请",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "申请ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批意见",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/approval.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/approval/list": {
+            "get": {
+                "summary": "我的变更申请",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/approval/pending": {
+            "get": {
+                "summary": "待我审批的变更申请",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/bulk/metadata": {
+            "post": {
+                "description": "按 kind、命名空间、标签选择器在一个或多个集群中选择资源，批量新增或删除标签、注解，支持 dry_run 预览",
+                "summary": "批量编辑标签与注解",
+                "parameters": [
+                    {
+                        "description": "批量编辑参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bulk.MetadataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/cluster/{cluster}/reconnect": {
+            "post": {
+                "description": "重新连接一个已断开的集群",
+                "summary": "重新连接集群",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64编码的集群ID",
+                        "name": "cluster",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已执行，请稍后刷新",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/custom/template/delete/{ids}": {
+            "post": {
+                "description": "删除一个或多个自定义模板，仅模板创建者或平台管理员可以删除",
+                "summary": "删除模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "要删除的模板ID，多个用逗号分隔",
+                        "name": "ids",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/custom/template/list": {
+            "get": {
+                "description": "获取当前用户可见的自定义模板信息，指定 projects 时仅返回共享给该项目的模板",
+                "summary": "模板列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "项目名称",
+                        "name": "projects",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/custom/template/save": {
+            "post": {
+                "description": "新增或更新自定义模板，内容变更时自动递增版本号并保存历史版本",
+                "summary": "保存模板",
+                "parameters": [
+                    {
+                        "description": "模板信息",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CustomTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "返回模板ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/custom/template/{id}/render": {
+            "post": {
+                "description": "使用给定变量渲染模板，仅返回渲染结果，不应用到集群",
+                "summary": "预览渲染模板",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "渲染参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/template.RenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/custom/template/{id}/versions": {
+            "get": {
+                "description": "获取模板的全部历史版本，按版本号倒序",
+                "summary": "模板历史版本列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/home/dashboard": {
+            "get": {
+                "description": "以当前用户身份读取各资源的实时状态，health 取值 Healthy、Progressing、Degraded、Missing、Unknown",
+                "summary": "首页收藏与最近访问",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/log/operation/list": {
+            "get": {
+                "description": "获取所有操作日志",
+                "summary": "操作日志列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/log/shell/list": {
+            "get": {
+                "description": "获取所有Shell操作日志",
+                "summary": "Shell日志列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/any_question": {
+            "get": {
+                "summary": "回答K8s相关问题",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "问题内容",
+                        "name": "question",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/any_selection": {
+            "get": {
+                "summary": "解释选择内容",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "要解释的内容",
+                        "name": "question",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/cron": {
+            "get": {
+                "summary": "分析Cron表达式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cron表达式",
+                        "name": "cron",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/describe": {
+            "get": {
+                "summary": "分析K8s资源描述",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/event": {
+            "get": {
+                "summary": "分析K8s事件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "事件备注",
+                        "name": "note",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件来源",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件原因",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "相关资源类型",
+                        "name": "regardingKind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/example": {
+            "get": {
+                "summary": "获取K8s资源使用示例",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/example/field": {
+            "get": {
+                "summary": "获取K8s资源字段示例",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字段名称",
+                        "name": "field",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/gptshell": {
+            "get": {
+                "summary": "通过WebSocket提供GPT交互式对话终端",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群名称",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "对话内容",
+                        "name": "content",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/k8s_gpt/resource": {
+            "get": {
+                "summary": "K8s错误信息分析",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "错误内容",
+                        "name": "data",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "相关字段",
+                        "name": "field",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/log": {
+            "get": {
+                "summary": "分析日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "日志内容",
+                        "name": "data",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/chat/resource": {
+            "get": {
+                "summary": "获取K8s资源使用指南",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "资源组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源类型",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/ws_chatgpt/history": {
+            "get": {
+                "summary": "获取聊天历史记录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/ai/ws_chatgpt/reset": {
+            "post": {
+                "summary": "重置聊天历史记录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/helm/chart/list": {
+            "get": {
+                "description": "获取所有Helm Chart信息",
+                "summary": "Helm Chart列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/helm/repo/{repo}/chart/{chart}/version/{version}/values": {
+            "get": {
+                "description": "获取指定Helm仓库、Chart及版本的默认values.yaml内容",
+                "summary": "获取Chart的默认values.yaml",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "仓库名称",
+                        "name": "repo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chart名称",
+                        "name": "chart",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chart版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "yaml内容",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/plugins/helm/repo/{repo}/chart/{chart}/versions": {
+            "get": {
+                "description": "获取指定Helm仓库和Chart的所有版本列表",
+                "summary": "Chart版本列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "仓库名称",
+                        "name": "repo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chart名称",
+                        "name": "chart",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/project/my": {
+            "get": {
+                "description": "获取当前用户所在的项目及其成员、包含的集群与命名空间，平台管理员返回全部项目。\n资源列表查询条件中传入 project 即可将列表限定在该项目的命名空间内",
+                "summary": "我的项目",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.ProjectView"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/project/{id}/members/save": {
+            "post": {
+                "description": "项目负责人（owner）整体替换项目成员，不能移除自己的负责人角色",
+                "summary": "项目负责人保存成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员列表，格式为 {members:[{username,role}]}",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/tasks/id/{id}": {
+            "get": {
+                "description": "返回任务状态、进度与日志",
+                "summary": "异步任务详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/tasks/id/{id}/cancel": {
+            "post": {
+                "summary": "取消异步任务",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/tasks/id/{id}/ws": {
+            "get": {
+                "description": "WebSocket 连接，任务进度或日志变化时推送 TaskEvent，任务结束后关闭连接",
+                "summary": "订阅异步任务进度",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {},
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/tasks/list": {
+            "get": {
+                "description": "支持按 type、status、cluster 过滤，列表不包含日志",
+                "summary": "异步任务列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/favorites/add": {
+            "post": {
+                "description": "重复收藏同一资源时返回已有记录，每个用户最多收藏 200 个资源",
+                "summary": "收藏资源",
+                "parameters": [
+                    {
+                        "description": "资源",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResourceRef"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/favorites/delete/{ids}": {
+            "post": {
+                "summary": "取消收藏",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "收藏ID，多个用逗号分隔",
+                        "name": "ids",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/favorites/list": {
+            "get": {
+                "summary": "收藏的资源列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/preferences": {
+            "get": {
+                "description": "获取当前登录用户的语言、主题、分页大小、收藏的集群与命名空间、固定资源及界面状态，未保存过时返回空偏好",
+                "summary": "获取用户偏好",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreference"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "整体覆盖当前登录用户的偏好设置。language 取值 zh、en，设置后 API 错误信息按该语言返回；theme 取值 light、dark、auto",
+                "summary": "保存用户偏好",
+                "parameters": [
+                    {
+                        "description": "用户偏好",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreference"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/preferences/reset": {
+            "post": {
+                "description": "删除当前登录用户保存的全部偏好设置，恢复默认值",
+                "summary": "重置用户偏好",
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/preferences/ui_state/{key}": {
+            "post": {
+                "description": "请求体为任意 JSON 值，写入 ui_state 中对应的 key；请求体为 null 时删除该 key",
+                "summary": "保存单项界面状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "界面状态标识",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile": {
+            "get": {
+                "description": "获取当前登录用户的详细信息",
+                "summary": "获取用户信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/2fa/disable": {
+            "post": {
+                "description": "禁用当前用户的二步验证",
+                "summary": "禁用2FA",
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/2fa/enable": {
+            "post": {
+                "description": "验证并启用当前用户的二步验证",
+                "summary": "启用2FA",
+                "parameters": [
+                    {
+                        "description": "验证码",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "应用名称",
+                        "name": "app_name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/2fa/generate": {
+            "post": {
+                "description": "生成当前用户的二步验证密钥和二维码",
+                "summary": "生成2FA密钥",
+                "responses": {
+                    "200": {
+                        "description": "返回密钥、二维码和备用码",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/api_keys/create": {
+            "post": {
+                "description": "为当前用户创建一个新的API密钥",
+                "summary": "创建API密钥",
+                "parameters": [
+                    {
+                        "description": "密钥描述",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/api_keys/delete/{id}": {
+            "post": {
+                "description": "删除指定ID的API密钥",
+                "summary": "删除API密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API密钥ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/api_keys/list": {
+            "get": {
+                "description": "获取当前用户的所有API密钥",
+                "summary": "获取API密钥列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/cluster/permissions/list": {
+            "get": {
+                "description": "列出当前登录用户所拥有的集群权限",
+                "summary": "获取用户集群权限",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/mcp_keys/create": {
+            "post": {
+                "description": "为当前用户创建一个新的MCP密钥（10年有效期）",
+                "summary": "创建MCP密钥",
+                "parameters": [
+                    {
+                        "description": "密钥描述",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/mcp_keys/delete/{id}": {
+            "post": {
+                "description": "删除指定ID的MCP密钥",
+                "summary": "删除MCP密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP密钥ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/mcp_keys/list": {
+            "get": {
+                "description": "获取当前用户的所有MCP密钥",
+                "summary": "获取MCP密钥列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/password_policy": {
+            "get": {
+                "description": "获取本地账户的密码策略，字段为 0 表示不限制，用于修改密码前提示",
+                "summary": "获取密码策略",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.PasswordPolicy"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/profile/update_psw": {
+            "post": {
+                "description": "修改当前登录用户的密码，需要验证原密码并两次输入新密码确认，新密码须符合密码策略。\n修改后该用户此前的登录会话全部失效，返回新的 Token",
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "密码修改请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/profile.PasswordUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/recent/clear": {
+            "post": {
+                "summary": "清空最近访问记录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/mgm/user/recent/list": {
+            "get": {
+                "description": "查看资源详情、YAML、描述时自动记录，每个用户保留最近 50 条",
+                "summary": "最近访问的资源",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/clock/status": {
+            "get": {
+                "description": "获取最近一次 k8m 服务器与集群 apiserver、NTP 时间比对的摘要，skewed 为 true 时用于页面顶部告警",
+                "summary": "时钟偏差状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/cluster/all": {
+            "get": {
+                "description": "获取当前登录用户可见的集群详细信息（表格）",
+                "summary": "集群表格列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/cluster/option_list": {
+            "get": {
+                "description": "获取当前登录用户可选的集群列表（下拉选项）",
+                "summary": "集群选项列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/condition/reverse/list": {
+            "get": {
+                "description": "获取所有启用的翻转显示指标名称",
+                "summary": "翻转指标列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/config/{key}": {
+            "get": {
+                "description": "获取指定key的系统配置项",
+                "summary": "获取配置项",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "配置项key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/helm/repo/option_list": {
+            "get": {
+                "description": "获取所有Helm仓库名称，用于下拉选项",
+                "summary": "Helm仓库选项列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/readonly/status": {
+            "get": {
+                "description": "获取全局或指定集群当前生效的只读设置，未开启时 enabled 为 false，用于页面顶部提示",
+                "summary": "只读模式状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "集群ID",
+                        "name": "cluster",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/user/role": {
+            "get": {
+                "description": "获取当前登录用户的角色及默认集群",
+                "summary": "获取用户角色信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/params/version": {
+            "get": {
+                "description": "获取当前软件的版本及构建信息",
+                "summary": "获取版本信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "admission.PreviewRequest": {
+            "type": "object",
+            "properties": {
+                "yaml": {
+                    "description": "多文档YAML",
+                    "type": "string"
+                }
+            }
+        },
+        "approval.ReviewRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "bulk.MetadataRequest": {
+            "type": "object",
+            "properties": {
+                "add_annotations": {
+                    "description": "新增或覆盖的注解",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "add_labels": {
+                    "description": "新增或覆盖的标签",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "clusters": {
+                    "description": "目标集群ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dry_run": {
+                    "description": "仅预览受影响的资源，不实际修改",
+                    "type": "boolean"
+                },
+                "group": {
+                    "description": "资源group",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "资源kind",
+                    "type": "string"
+                },
+                "label_selector": {
+                    "description": "标签选择器",
+                    "type": "string"
+                },
+                "namespaces": {
+                    "description": "命名空间列表，为空表示全部命名空间",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove_annotations": {
+                    "description": "删除的注解key",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove_labels": {
+                    "description": "删除的标签key",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "description": "资源version",
+                    "type": "string"
+                }
+            }
+        },
+        "cluster.CapabilityModeRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "description": "enabled、disabled，为空表示按探测结果",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "component.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "必须为 true，确认已了解修改对全集群的影响",
+                    "type": "boolean"
+                },
+                "content": {
+                    "description": "完整的 Corefile 或 kube-proxy config.conf",
+                    "type": "string"
+                },
+                "resource_version": {
+                    "description": "读取配置时返回的 resource_version",
+                    "type": "string"
+                }
+            }
+        },
+        "conditions.ConditionSummary": {
+            "type": "object",
+            "properties": {
+                "false": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "没有该条件的对象数",
+                    "type": "integer"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conditions.ReasonCount"
+                    }
+                },
+                "reversed": {
+                    "description": "为 True 时表示异常",
+                    "type": "boolean"
+                },
+                "true": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unhealthy": {
+                    "description": "处于异常状态的对象数",
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "integer"
+                }
+            }
+        },
+        "conditions.FieldSummary": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conditions.ValueCount"
+                    }
+                }
+            }
+        },
+        "conditions.Object": {
+            "type": "object",
+            "properties": {
+                "last_transition_time": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "按字段下钻时的字段值",
+                    "type": "string"
+                }
+            }
+        },
+        "conditions.ReasonCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "conditions.ValueCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.CheckAccessRequest": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "operations": {
+                    "description": "逗号分隔",
+                    "type": "string"
+                },
+                "time": {
+                    "description": "RFC3339，为空使用当前时间",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.CheckCommandRequest": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "command": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.SyncRequest": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret_name": {
+                    "description": "为空时使用 k8m-registry-<凭据ID>",
+                    "type": "string"
+                },
+                "service_account": {
+                    "description": "同步后绑定到该 ServiceAccount，如 default，为空不绑定",
+                    "type": "string"
+                }
+            }
+        },
+        "config.TestLoginRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "insecure": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "registry": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.TestPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "expression": {
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "为空时按 create 评估",
+                    "type": "string"
+                },
+                "yaml": {
+                    "description": "待评估的资源",
+                    "type": "string"
+                }
+            }
+        },
+        "disruption.BudgetImpact": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "type": "boolean"
+                },
+                "consumed": {
+                    "description": "本次驱逐需要消耗的可中断数，即其中健康的 Pod 数",
+                    "type": "integer"
+                },
+                "current_healthy": {
+                    "type": "integer"
+                },
+                "desired_healthy": {
+                    "type": "integer"
+                },
+                "disruptions_allowed": {
+                    "type": "integer"
+                },
+                "expected_pods": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "description": "本次涉及的 Pod 数",
+                    "type": "integer"
+                },
+                "max_unavailable": {
+                    "type": "string"
+                },
+                "min_available": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "unhealthy_pod_eviction_policy": {
+                    "type": "string"
+                }
+            }
+        },
+        "disruption.PodImpact": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "blocked": {
+                    "description": "驱逐会被拒绝",
+                    "type": "boolean"
+                },
+                "local_storage": {
+                    "description": "使用 emptyDir，驱逐后数据丢失",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "node": {
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "控制者，如 ReplicaSet/nginx-7d9f",
+                    "type": "string"
+                },
+                "pdbs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "unmanaged": {
+                    "description": "无控制者，驱逐后不会重建",
+                    "type": "boolean"
+                }
+            }
+        },
+        "disruption.Preview": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "type": "integer"
+                },
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/disruption.BudgetImpact"
+                    }
+                },
+                "evict": {
+                    "type": "integer"
+                },
+                "pods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/disruption.PodImpact"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "would_block": {
+                    "description": "驱逐会因 PDB 失败",
+                    "type": "boolean"
+                }
+            }
+        },
+        "doc.DetailReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "translate": {
+                    "type": "string"
+                }
+            }
+        },
+        "drift.RevertRequest": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.ContainerEnv": {
+            "type": "object",
+            "properties": {
+                "container_name": {
+                    "type": "string"
+                },
+                "envs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dynamic.ExportItem": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.ExportRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "Format 导出格式：为空返回JSON包裹的YAML文本，yaml 下载多文档YAML文件，tar 下载每个资源一个文件的tar包",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dynamic.ExportItem"
+                    }
+                }
+            }
+        },
+        "dynamic.HealthCheckInfo": {
+            "type": "object",
+            "properties": {
+                "container_name": {
+                    "type": "string"
+                },
+                "liveness_probe": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "liveness_type": {
+                    "type": "string"
+                },
+                "readiness_probe": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "readiness_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.SelectorValidateRequest": {
+            "type": "object",
+            "properties": {
+                "field_selector": {
+                    "type": "string"
+                },
+                "label_selector": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace 命名空间，多个用逗号分隔，为空时统计全部命名空间",
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.SelectorValidateResult": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count 按规范化选择器从缓存中统计的匹配数量，缓存有效期内可能与实时结果存在偏差",
+                    "type": "integer"
+                },
+                "count_error": {
+                    "type": "string"
+                },
+                "field": {
+                    "$ref": "#/definitions/selector.Result"
+                },
+                "label": {
+                    "$ref": "#/definitions/selector.Result"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dynamic.Tolerations": {
+            "type": "object",
+            "properties": {
+                "effect": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "tolerationSeconds": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.imageInfo": {
+            "type": "object",
+            "properties": {
+                "container_name": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "image_pull_policy": {
+                    "type": "string"
+                },
+                "image_pull_secrets": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.nodeAffinity": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dynamic.podAffinity": {
+            "type": "object",
+            "properties": {
+                "labelSelector": {
+                    "type": "object",
+                    "properties": {
+                        "matchLabels": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "topologyKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "dynamic.resourceInfo": {
+            "type": "object",
+            "properties": {
+                "container_name": {
+                    "type": "string"
+                },
+                "limit_cpu": {
+                    "type": "string"
+                },
+                "limit_memory": {
+                    "type": "string"
+                },
+                "request_cpu": {
+                    "type": "string"
+                },
+                "request_memory": {
+                    "type": "string"
+                }
+            }
+        },
+        "gql.Request": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "image.ContainerImage": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "读取失败原因，不影响其他容器",
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "info": {
+                    "$ref": "#/definitions/registry.ImageInfo"
+                },
+                "init": {
+                    "description": "是否为 initContainer",
+                    "type": "boolean"
+                }
+            }
+        },
+        "intstr.IntOrString": {
+            "type": "object",
+            "properties": {
+                "intVal": {
+                    "type": "integer"
+                },
+                "strVal": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/intstr.Type"
+                }
+            }
+        },
+        "intstr.Type": {
+            "type": "integer",
+            "format": "int64",
+            "enum": [
+                0,
+                1
+            ],
+            "x-enum-comments": {
+                "Int": "The IntOrString holds an int.",
+                "String": "The IntOrString holds a string."
+            },
+            "x-enum-descriptions": [
+                "The IntOrString holds an int.",
+                "The IntOrString holds a string."
+            ],
+            "x-enum-varnames": [
+                "Int",
+                "String"
+            ]
+        },
+        "k8s_io_api_core_v1.ConditionStatus": {
+            "type": "string",
+            "enum": [
+                "True",
+                "False",
+                "Unknown"
+            ],
+            "x-enum-varnames": [
+                "ConditionTrue",
+                "ConditionFalse",
+                "ConditionUnknown"
+            ]
+        },
+        "k8s_io_api_core_v1.HTTPHeader": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "The header field name.\nThis will be canonicalized upon output, so case-variant names will be understood as the same header.",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "The header field value",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AIRunConfig": {
+            "type": "object",
+            "properties": {
+                "any_select": {
+                    "description": "是否开启任意选择",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "floating_window": {
+                    "description": "是否开启浮动窗口",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "max_history": {
+                    "description": "最大历史记录数",
+                    "type": "integer"
+                },
+                "max_iterations": {
+                    "description": "最大迭代次数",
+                    "type": "integer"
+                },
+                "model_id": {
+                    "description": "模型ID",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "use_built_in_model": {
+                    "description": "是否使用内置模型",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AccessRule": {
+            "type": "object",
+            "properties": {
+                "cidrs": {
+                    "type": "string"
+                },
+                "clusters": {
+                    "description": "逗号分隔，支持 * 通配，为空表示全部",
+                    "type": "string"
+                },
+                "countries": {
+                    "description": "国家/地区代码，需监听配置可信代理地区请求头",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "days": {
+                    "description": "0-6，0 为周日，支持 1-5 区间",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "end_time": {
+                    "description": "HH:MM，早于开始时间表示跨越午夜",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operations": {
+                    "description": "exec、file_delete、file_write、delete、node、write、admin，为空表示全部请求",
+                    "type": "string"
+                },
+                "roles": {
+                    "description": "平台或集群角色，为空或 * 表示全部用户",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "为空使用服务器时区",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AdmissionPolicy": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "deny 或 warn",
+                    "type": "string"
+                },
+                "clusters": {
+                    "description": "集群ID",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "expression": {
+                    "description": "返回 true 表示命中，如 any(images(object), {# endsWith \":latest\"})",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kinds": {
+                    "description": "如 Deployment,StatefulSet",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "命中时返回给用户的提示",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "description": "如 prod-*",
+                    "type": "string"
+                },
+                "operations": {
+                    "description": "create,update,patch,delete",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApprovalRule": {
+            "type": "object",
+            "properties": {
+                "approvers": {
+                    "description": "审批人用户名，逗号分隔，为空时由平台管理员或该集群的集群管理员审批",
+                    "type": "string"
+                },
+                "clusters": {
+                    "description": "逗号分隔，支持 * 通配，为空表示全部",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kinds": {
+                    "description": "逗号分隔，为空表示全部",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "description": "受保护的命名空间，逗号分隔，支持 * 通配",
+                    "type": "string"
+                },
+                "operations": {
+                    "description": "create,update,patch,delete，为空表示全部",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhooks": {
+                    "description": "通知的 webhook 接收者 ID，逗号分隔",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ArtifactPolicy": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "制品类别，即对象 key 的第一段，如 support-bundle",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_count": {
+                    "description": "只保留最新的 N 个",
+                    "type": "integer"
+                },
+                "max_size_mb": {
+                    "description": "总大小上限（MiB），超过时从最旧的开始删除",
+                    "type": "integer"
+                },
+                "retention_days": {
+                    "description": "超过天数的制品自动删除",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CRDConditionConfig": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "额外统计的状态字段路径，逗号分隔，如 status.health.status",
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "primary": {
+                    "description": "判断对象是否健康的主条件，默认 Ready",
+                    "type": "string"
+                },
+                "types": {
+                    "description": "展示的条件类型，逗号分隔，为空时展示全部",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChangeSet": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "manifests": {
+                    "description": "多文档YAML",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "results": {
+                    "description": "最近一次执行中每个资源的结果",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChangeSetItem"
+                    }
+                },
+                "scheduled_at": {
+                    "description": "计划应用时间，为空时需手动应用",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "scheduled、running、applied、rolled_back、failed 或 cancelled",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChangeSetItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created、updated，预演时为 create、update",
+                    "type": "string"
+                },
+                "apiVersion": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "valid、invalid、applied、failed、skipped 或 rolled_back",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ClusterAgent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_addr": {
+                    "description": "最近一次接入的来源地址",
+                    "type": "string"
+                },
+                "last_connected_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "集群名称，k8m 中显示为 Agent/<Name>",
+                    "type": "string"
+                },
+                "online": {
+                    "description": "隧道是否在线，列表查询时填充",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CommandPolicy": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "deny 或 allow",
+                    "type": "string"
+                },
+                "clusters": {
+                    "description": "逗号分隔，支持 * 通配，为空表示全部",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "正则表达式，匹配完整命令行的任意部分",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "terminal,exec,file，为空表示全部",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Config": {
+            "type": "object",
+            "properties": {
+                "artifact_driver": {
+                    "description": "制品存储，保存支持包、备份等 k8m 生成的文件",
+                    "type": "string"
+                },
+                "artifact_local_dir": {
+                    "description": "本地存储目录，为空时使用数据库文件所在目录下的 artifacts",
+                    "type": "string"
+                },
+                "artifact_s3_prefix": {
+                    "description": "存储桶中的 key 前缀，为空时使用 k8m-artifacts/",
+                    "type": "string"
+                },
+                "clock_skew_threshold": {
+                    "description": "与集群、NTP 时间相差超过该秒数时告警",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "diagnostics_file_globs": {
+                    "description": "Pod 诊断包默认收集的容器内文件，每行一个绝对路径匹配模式，如 /var/log/*.log",
+                    "type": "string"
+                },
+                "enable_graphql": {
+                    "description": "开启集群 GraphQL 组合查询接口",
+                    "type": "boolean"
+                },
+                "exec_allowlist_cluster_admin": {
+                    "description": "集群管理员",
+                    "type": "string"
+                },
+                "exec_allowlist_pod_exec": {
+                    "description": "命令执行接口的允许列表，每行一个命令模式，* 匹配任意字符，为空表示不限制；平台管理员不受限制",
+                    "type": "string"
+                },
+                "file_upload_concurrency": {
+                    "description": "批量上传默认并发数",
+                    "type": "integer"
+                },
+                "file_upload_retries": {
+                    "description": "批量上传单个文件遇到网络类错误时的重试次数",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_pull_timeout": {
+                    "description": "镜像拉取超时时间（秒）",
+                    "type": "integer"
+                },
+                "jwt_leeway_seconds": {
+                    "description": "校验 Token 有效期时容忍的时钟偏差（秒）",
+                    "type": "integer"
+                },
+                "jwt_token_secret": {
+                    "type": "string"
+                },
+                "kubectl_shell_image": {
+                    "type": "string"
+                },
+                "login_type": {
+                    "type": "string"
+                },
+                "node_shell_image": {
+                    "type": "string"
+                },
+                "ntp_server": {
+                    "description": "时钟检查使用的 NTP 服务器，为空不检查",
+                    "type": "string"
+                },
+                "password_history": {
+                    "description": "不能与最近 N 次使用过的密码相同",
+                    "type": "integer"
+                },
+                "password_max_age_days": {
+                    "description": "密码有效期（天），过期后登录须先修改密码",
+                    "type": "integer"
+                },
+                "password_min_classes": {
+                    "description": "至少包含的字符种类数（大写、小写、数字、符号），1-4",
+                    "type": "integer"
+                },
+                "password_min_length": {
+                    "description": "本地账户密码策略，均为 0 表示不限制",
+                    "type": "integer"
+                },
+                "print_config": {
+                    "type": "boolean"
+                },
+                "product_name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "resource_cache_timeout": {
+                    "description": "资源缓存时间（秒）",
+                    "type": "integer"
+                },
+                "s3_access_key": {
+                    "type": "string"
+                },
+                "s3_bucket": {
+                    "type": "string"
+                },
+                "s3_endpoint": {
+                    "description": "S3 兼容对象存储，用于容器文件与存储桶之间直接传输",
+                    "type": "string"
+                },
+                "s3_path_style": {
+                    "description": "MinIO 等自建存储通常需要开启",
+                    "type": "boolean"
+                },
+                "s3_region": {
+                    "type": "string"
+                },
+                "s3_secret_key": {
+                    "type": "string"
+                },
+                "totp_skew": {
+                    "description": "时钟偏差容忍与检查",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "Automatically managed by GORM for update time",
+                    "type": "string"
+                },
+                "upload_staging_quota_mb": {
+                    "description": "每个用户同时进行的上传在 k8m 本地暂存的空间上限（MiB），0 为不限制",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CustomTemplate": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "description": "模板类型，最大长度 100",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "模板内容，支持大文本存储",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "engine": {
+                    "description": "变量替换引擎：go、envsubst，为空不做替换",
+                    "type": "string"
+                },
+                "group_names": {
+                    "description": "可见的用户组，逗号分隔，为空仅创建者可见",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "模板 ID，主键，自增",
+                    "type": "integer"
+                },
+                "is_global": {
+                    "description": "模板类型，最大长度 100",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "模板类型，最大长度 100",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "模板名称，非空，最大长度 255",
+                    "type": "string"
+                },
+                "projects": {
+                    "description": "可见的项目，逗号分隔，项目成员均可见",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Automatically managed by GORM for update time",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "模板版本号，内容变更时递增",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DriftBaseline": {
+            "type": "object",
+            "properties": {
+                "check_interval": {
+                    "description": "定期比对间隔（分钟），0 表示不定期比对",
+                    "type": "integer"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "drift_count": {
+                    "description": "最近一次比对中漂移或缺失的资源数量",
+                    "type": "integer"
+                },
+                "git_path": {
+                    "description": "仅比对归档内该目录下的清单，为空表示全部",
+                    "type": "string"
+                },
+                "git_token": {
+                    "description": "访问私有仓库的令牌（加密存储）",
+                    "type": "string"
+                },
+                "git_url": {
+                    "description": "原始文件或归档地址，如 https://github.com/org/repo/archive/refs/heads/main.tar.gz",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_checked_at": {
+                    "type": "string"
+                },
+                "manifests": {
+                    "description": "上传的清单，Git 来源时为最近一次拉取的内容",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "比对失败原因",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "upload 或 git",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "in_sync、drifted 或 error",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExternalGroupMapping": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external_group": {
+                    "description": "OIDC groups 声明中的组名；LDAP memberOf 中组的 DN 或 CN",
+                    "type": "string"
+                },
+                "group_name": {
+                    "description": "映射到的 k8m 用户组",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "oidc、ldap",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FileWatch": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "description": "首次检查时的校验和，用于判断重启后是否被还原",
+                    "type": "string"
+                },
+                "check_interval": {
+                    "description": "检查间隔（分钟），最小 1",
+                    "type": "integer"
+                },
+                "checksum": {
+                    "description": "最近一次检查的校验和",
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "container_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_changed_at": {
+                    "type": "string"
+                },
+                "last_checked_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "最近一次检查的说明",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "pod_name": {
+                    "type": "string"
+                },
+                "pod_uid": {
+                    "description": "用于识别 Pod 重建",
+                    "type": "string"
+                },
+                "restart_count": {
+                    "description": "用于识别容器重启",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "unchanged、changed、reverted 或 error",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhooks": {
+                    "description": "webhook 接收者 ID，逗号分隔",
+                    "type": "string"
+                }
+            }
+        },
+        "models.HelmRepository": {
+            "type": "object",
+            "properties": {
+                "auth_type": {
+                    "type": "string"
+                },
+                "caFile": {
+                    "type": "string"
+                },
+                "certFile": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "仓库描述",
+                    "type": "string"
+                },
+                "encrypted_secret": {
+                    "type": "string"
+                },
+                "generated": {
+                    "description": "repo 索引文件创建时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "insecure_skip_tls_verify": {
+                    "type": "boolean"
+                },
+                "is_active": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "keyFile": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "仓库名称（唯一）",
+                    "type": "string"
+                },
+                "pass_credentials_all": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Automatically managed by GORM for update time",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "仓库地址（如 https://charts.example.com）",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "认证用户名（加密存储）",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Hibernation": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "是否启用定时休眠与唤醒",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_action": {
+                    "description": "最近一次执行的操作：sleep 或 wake",
+                    "type": "string"
+                },
+                "last_message": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "description": "success 或 failed",
+                    "type": "string"
+                },
+                "last_task_id": {
+                    "description": "最近一次执行的异步任务，可查看进度与日志",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "description": "命名空间，逗号分隔",
+                    "type": "string"
+                },
+                "next_sleep_at": {
+                    "description": "下次休眠时间，保存或执行后按 cron 计算",
+                    "type": "string"
+                },
+                "next_wake_at": {
+                    "description": "下次唤醒时间",
+                    "type": "string"
+                },
+                "sleep_cron": {
+                    "description": "休眠时间，标准 5 段 cron 表达式，如 0 20 * * 1-5，为空表示不定时休眠",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "最近一次执行后的状态：awake 或 sleeping",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "wake_cron": {
+                    "description": "唤醒时间，如 0 8 * * 1-5，为空表示不定时唤醒",
+                    "type": "string"
+                }
+            }
+        },
+        "models.InboundWebhook": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "restart、set_image、apply_template",
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "container": {
+                    "description": "set_image 更新的容器",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_path": {
+                    "description": "请求体中镜像或标签的路径，默认 image",
+                    "type": "string"
+                },
+                "image_pattern": {
+                    "description": "允许的镜像，逗号分隔，支持 * 通配；为空只允许更换标签",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "地址 /hooks/{key} 中的标识，创建时生成",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Deployment、StatefulSet、DaemonSet",
+                    "type": "string"
+                },
+                "last_message": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "description": "success 或 failed",
+                    "type": "string"
+                },
+                "last_triggered_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "target": {
+                    "description": "工作负载名称",
+                    "type": "string"
+                },
+                "template_id": {
+                    "description": "apply_template 使用的模板",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "模板变量默认值，请求体 variables 只能覆盖其中声明的变量",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.KubeConfig": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "description": "Burst 设置突发请求数限制，默认为 2000",
+                    "type": "integer"
+                },
+                "ca_data": {
+                    "description": "ca 证书内容，支持大文本存储",
+                    "type": "string"
+                },
+                "cluster": {
+                    "description": "类型，最大长度 100",
+                    "type": "string"
+                },
+                "cluster_name": {
+                    "description": "AWS EKS 集群名称",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "模板内容，支持大文本存储",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "模板 ID，主键，自增",
+                    "type": "integer"
+                },
+                "is_aws_eks": {
+                    "description": "标识是否为AWS EKS集群",
+                    "type": "boolean"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "proxy_url": {
+                    "description": "kom 集群注册配置项\nProxyURL 设置 HTTP 代理，例如 http://127.0.0.1:7890",
+                    "type": "string"
+                },
+                "qps": {
+                    "description": "QPS 设置每秒查询数限制，默认为 200",
+                    "type": "number"
+                },
+                "region": {
+                    "description": "AWS 区域",
+                    "type": "string"
+                },
+                "server": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "description": "Timeout 设置请求超时时间，单位为秒，默认为 30 秒",
+                    "type": "integer"
+                },
+                "token": {
+                    "description": "token 纳管相关 server\\token\\cadata",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Automatically managed by GORM for update time",
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MCPServerConfig": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Menu": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Project": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quota": {
+                    "description": "项目内每个命名空间的 ResourceQuota hard，JSON 对象，如 {\"requests.cpu\":\"4\",\"limits.memory\":\"8Gi\"}",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProjectMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "owner、member",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProjectNamespace": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RegistryCredential": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "description": "生效集群ID，为空表示所有集群",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "insecure": {
+                    "description": "使用 HTTP 访问仓库",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "显示名称",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "生效命名空间，为空表示所有命名空间",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码或访问令牌（加密存储）",
+                    "type": "string"
+                },
+                "registry": {
+                    "description": "仓库地址，如 harbor.example.com、docker.io",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReportSchedule": {
+            "type": "object",
+            "properties": {
+                "clusters": {
+                    "description": "集群，逗号分隔",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "标准 5 段 cron 表达式，如 0 8 * * 1 表示每周一 8 点",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "format": {
+                    "description": "csv 或 xlsx",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_message": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "description": "success 或 failed",
+                    "type": "string"
+                },
+                "last_task_id": {
+                    "description": "最近一次执行的异步任务，可查看进度与日志",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "description": "下次执行时间，保存或执行后按 cron 计算",
+                    "type": "string"
+                },
+                "types": {
+                    "description": "报表类型 inventory、rbac、images、quota，逗号分隔，为空表示全部",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhooks": {
+                    "description": "webhook 接收者 ID，逗号分隔",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResourceRef": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "group_names": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "must_change_password": {
+                    "description": "管理员要求下次登录时修改密码",
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "password_changed_at": {
+                    "description": "密码轮换",
+                    "type": "string"
+                },
+                "salt": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "来源，如：db, ldap_config.json, oauth",
+                    "type": "string"
+                },
+                "two_fa_app_name": {
+                    "description": "2FA应用名称，用于提醒用户使用的是哪个软件",
+                    "type": "string"
+                },
+                "two_fa_backup_codes": {
+                    "description": "备用恢复码，逗号分隔",
+                    "type": "string"
+                },
+                "two_fa_enabled": {
+                    "description": "是否启用2FA",
+                    "type": "boolean"
+                },
+                "two_fa_secret": {
+                    "description": "2FA密钥",
+                    "type": "string"
+                },
+                "two_fa_type": {
+                    "description": "2FA类型：如 'totp', 'sms', 'email'",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Automatically managed by GORM for update time",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserGroup": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "menu_data": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "管理员/只读",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserPreference": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "default_page_size": {
+                    "description": "列表默认分页大小，0 表示使用页面默认值",
+                    "type": "integer"
+                },
+                "favorite_clusters": {
+                    "description": "收藏的集群",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "favorite_namespaces": {
+                    "description": "收藏的命名空间，key 为集群",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "zh、en，为空时使用浏览器语言",
+                    "type": "string"
+                },
+                "page_sizes": {
+                    "description": "各页面的分页大小，key 为页面标识",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "pinned_resources": {
+                    "description": "固定在首页的资源",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResourceRef"
+                    }
+                },
+                "theme": {
+                    "description": "light、dark、auto，为空时使用默认主题",
+                    "type": "string"
+                },
+                "ui_state": {
+                    "description": "前端自定义的界面状态，如折叠的面板、列表列设置",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "node.TaintInfo": {
+            "type": "object",
+            "properties": {
+                "effect": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "nsclone.Kind": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "nsclone.NameRewrite": {
+            "type": "object",
+            "properties": {
+                "prefix": {
+                    "type": "string"
+                },
+                "replace": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/nsclone.Replace"
+                    }
+                },
+                "suffix": {
+                    "type": "string"
+                }
+            }
+        },
+        "nsclone.Replace": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "nsclone.SecretMode": {
+            "type": "string",
+            "enum": [
+                "copy",
+                "exclude",
+                "seal"
+            ],
+            "x-enum-comments": {
+                "SecretCopy": "原样复制",
+                "SecretExclude": "不复制",
+                "SecretSeal": "使用目标集群 sealed-secrets 控制器的证书重新加密为 SealedSecret"
+            },
+            "x-enum-descriptions": [
+                "原样复制",
+                "不复制",
+                "使用目标集群 sealed-secrets 控制器的证书重新加密为 SealedSecret"
+            ],
+            "x-enum-varnames": [
+                "SecretCopy",
+                "SecretExclude",
+                "SecretSeal"
+            ]
+        },
+        "nsclone.Skipped": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.ContainerDescribe": {
+            "type": "object",
+            "properties": {
+                "args": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "container_id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "last_state": {
+                    "description": "上一次终止的状态，用于排查重启原因",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pod.ContainerState"
+                        }
+                    ]
+                },
+                "limits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mounts": {
+                    "description": "形如 /data from data (ro)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ports": {
+                    "description": "形如 80/TCP",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "requests": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "restart_count": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/pod.ContainerState"
+                }
+            }
+        },
+        "pod.ContainerState": {
+            "type": "object",
+            "properties": {
+                "exit_code": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "signal": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.EventDescribe": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "first_timestamp": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "last_timestamp": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.ExecCommandRequest": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "命令及参数，如 [\"nginx\",\"-t\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "container": {
+                    "type": "string"
+                },
+                "script": {
+                    "description": "通过 sh -c 执行的脚本",
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "description": "超时时间，默认 30 秒，最长 600 秒",
+                    "type": "integer"
+                }
+            }
+        },
+        "pod.FileBatchDownloadRequest": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "description": "下载文件名，默认为 Pod 名称加时间",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "paths": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "podName": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.FileConfigRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "namespace 为空时与 Pod 相同",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.ConfigObjectRef"
+                        }
+                    ]
+                },
+                "containerName": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "podName": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.FileCopyRequest": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "$ref": "#/definitions/service.PodFileRef"
+                },
+                "target": {
+                    "description": "path 为目标目录，为空时与源文件所在目录相同；cluster 为空时为当前集群",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.PodFileRef"
+                        }
+                    ]
+                }
+            }
+        },
+        "pod.ObjectTransferRequest": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "type": "string"
+                },
+                "extract": {
+                    "description": "拉取时按 tar 解包到 path 目录",
+                    "type": "boolean"
+                },
+                "isDir": {
+                    "description": "推送目录时以 tar 打包",
+                    "type": "boolean"
+                },
+                "key": {
+                    "description": "对象键，限定在 k8m-pod-files/集群/命名空间/ 之下，推送时为空则自动生成",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "podName": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.PodConditionItem": {
+            "type": "object",
+            "properties": {
+                "last_transition_time": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/k8s_io_api_core_v1.ConditionStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/v1.PodConditionType"
+                }
+            }
+        },
+        "pod.PodDescribe": {
+            "type": "object",
+            "properties": {
+                "annotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pod.PodConditionItem"
+                    }
+                },
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pod.ContainerDescribe"
+                    }
+                },
+                "controlled_by": {
+                    "description": "形如 ReplicaSet/nginx-5d8f7c",
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pod.EventDescribe"
+                    }
+                },
+                "host_ip": {
+                    "type": "string"
+                },
+                "init_containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pod.ContainerDescribe"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "node": {
+                    "type": "string"
+                },
+                "node_selector": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "phase": {
+                    "$ref": "#/definitions/v1.PodPhase"
+                },
+                "pod_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "priority_class_name": {
+                    "type": "string"
+                },
+                "qos_class": {
+                    "$ref": "#/definitions/v1.PodQOSClass"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "service_account": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "tolerations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.Toleration"
+                    }
+                },
+                "uid": {
+                    "type": "string"
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pod.VolumeDescribe"
+                    }
+                }
+            }
+        },
+        "pod.ProbeTestRequest": {
+            "type": "object",
+            "properties": {
+                "probe": {
+                    "$ref": "#/definitions/v1.Probe"
+                }
+            }
+        },
+        "pod.TrashRestoreRequest": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "type": "string"
+                },
+                "podName": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.VolumeDescribe": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "pod.info": {
+            "type": "object",
+            "properties": {
+                "chunkIndex": {
+                    "description": "从 0 开始",
+                    "type": "integer"
+                },
+                "chunkTotal": {
+                    "description": "大于 1 时启用分片",
+                    "type": "integer"
+                },
+                "containerName": {
+                    "type": "string"
+                },
+                "encoding": {
+                    "description": "保存大文件时使用，内容较大或含二进制时以 base64 编码并分片提交",
+                    "type": "string"
+                },
+                "fileContext": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "force": {
+                    "type": "boolean"
+                },
+                "isDir": {
+                    "type": "boolean"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "podName": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "trash": {
+                    "description": "删除时先备份到回收站，可在保留期内恢复",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "只有file类型可以查、下载",
+                    "type": "string"
+                },
+                "uploadId": {
+                    "description": "分片上传标识，由客户端生成",
+                    "type": "string"
+                },
+                "validate": {
+                    "description": "保存前校验，发现问题时不写入并返回警告，force 为 true 时仍然保存",
+                    "type": "boolean"
+                },
+                "validateCommand": {
+                    "description": "在容器中执行的校验命令，{file} 替换为待保存内容的临时文件，如 nginx -t -c {file}",
+                    "type": "string"
+                }
+            }
+        },
+        "podindex.PodRef": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "node": {
+                    "type": "string"
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "restart_count": {
+                    "type": "integer"
+                },
+                "workload": {
+                    "description": "所属工作负载，形如 Deployment/nginx",
+                    "type": "string"
+                }
+            }
+        },
+        "podindex.WorkloadRef": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "description": "使用命中镜像的容器",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "images": {
+                    "description": "命中的镜像",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "pods": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ready_pods": {
+                    "type": "integer"
+                }
+            }
+        },
+        "podinit.Container": {
+            "type": "object",
+            "properties": {
+                "exit_code": {
+                    "type": "integer"
+                },
+                "index": {
+                    "description": "在 initContainers 或 containers 中的序号",
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restart_count": {
+                    "type": "integer"
+                },
+                "started": {
+                    "type": "boolean"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "podinit.Insight": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/podinit.Container"
+                    }
+                },
+                "current": {
+                    "description": "Current 正在执行或阻塞初始化的 init 容器",
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "boolean"
+                },
+                "init_done": {
+                    "description": "已完成的 init 容器，sidecar 启动即视为完成",
+                    "type": "integer"
+                },
+                "init_total": {
+                    "description": "init 容器总数，含 sidecar",
+                    "type": "integer"
+                },
+                "initializing": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "sidecars": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sidecars_ready": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "profile.PasswordUpdateRequest": {
+            "type": "object",
+            "required": [
+                "confirmPassword",
+                "oldPassword",
+                "password"
+            ],
+            "properties": {
+                "confirmPassword": {
+                    "description": "确认密码（加密后）",
+                    "type": "string"
+                },
+                "oldPassword": {
+                    "description": "原密码（加密后）",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "新密码（加密后）",
+                    "type": "string"
+                }
+            }
+        },
+        "project.MembersRequest": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectMember"
+                    }
+                }
+            }
+        },
+        "project.NamespacesRequest": {
+            "type": "object",
+            "properties": {
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectNamespace"
+                    }
+                }
+            }
+        },
+        "registry.ImageInfo": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string"
+                },
+                "cmd": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created": {
+                    "type": "string"
+                },
+                "digest": {
+                    "description": "manifest 摘要，即 image@sha256 中使用的值",
+                    "type": "string"
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exposed_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "image": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "layers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/registry.LayerInfo"
+                    }
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "os": {
+                    "type": "string"
+                },
+                "platforms": {
+                    "description": "多架构镜像包含的全部平台",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "registry": {
+                    "type": "string"
+                },
+                "repository": {
+                    "type": "string"
+                },
+                "size": {
+                    "description": "压缩后各层与配置的总大小，字节",
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "working_dir": {
+                    "type": "string"
+                }
+            }
+        },
+        "registry.LayerInfo": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "description": "构建该层的指令，来自镜像配置中的 history",
+                    "type": "string"
+                },
+                "digest": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "report.Table": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "英文标识，用于文件名，如 inventory",
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "title": {
+                    "description": "工作表名称，如 集群资源清单",
+                    "type": "string"
+                }
+            }
+        },
+        "sa.TokenRequest": {
+            "type": "object",
+            "properties": {
+                "audiences": {
+                    "description": "逗号分隔，为空时使用 API Server 默认受众",
+                    "type": "string"
+                },
+                "expiration_seconds": {
+                    "description": "有效期，默认 3600，范围 600~86400",
+                    "type": "integer"
+                }
+            }
+        },
+        "selector.Problem": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "selector.Result": {
+            "type": "object",
+            "properties": {
+                "normalized": {
+                    "type": "string"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/selector.Problem"
+                    }
+                }
+            }
+        },
+        "service.CRConditionSummary": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conditions.ConditionSummary"
+                    }
+                },
+                "config": {
+                    "$ref": "#/definitions/models.CRDConditionConfig"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conditions.FieldSummary"
+                    }
+                },
+                "healthy": {
+                    "description": "主条件处于正常状态",
+                    "type": "integer"
+                },
+                "no_status": {
+                    "description": "没有主条件",
+                    "type": "integer"
+                },
+                "primary": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unhealthy": {
+                    "description": "主条件处于异常状态",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.ClusterComponents": {
+            "type": "object",
+            "properties": {
+                "control_plane": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ControlPlaneComponent"
+                    }
+                },
+                "coredns": {
+                    "$ref": "#/definitions/service.CoreDNSConfig"
+                },
+                "kube_proxy": {
+                    "$ref": "#/definitions/service.KubeProxyConfig"
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.ConfigObjectRef": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "ConfigMap 或 Secret",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.ControlPlaneComponent": {
+            "type": "object",
+            "properties": {
+                "disabled_admission_plugins": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled_admission_plugins": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "flags": {
+                    "description": "名称中含 password、secret、token 的参数值被掩码，文件路径除外",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "kube-apiserver、kube-controller-manager、kube-scheduler、etcd",
+                    "type": "string"
+                },
+                "node": {
+                    "type": "string"
+                },
+                "pod": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.CoreDNSConfig": {
+            "type": "object",
+            "properties": {
+                "cluster_domain": {
+                    "description": "Corefile 中 kubernetes 插件的域",
+                    "type": "string"
+                },
+                "corefile": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ready_pods": {
+                    "type": "integer"
+                },
+                "resource_version": {
+                    "description": "修改时用于并发校验",
+                    "type": "string"
+                },
+                "service_ip": {
+                    "type": "string"
+                },
+                "total_pods": {
+                    "type": "integer"
+                },
+                "upstreams": {
+                    "description": "Corefile 中 forward 的上游",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.CoreDNSInfo": {
+            "type": "object",
+            "properties": {
+                "cluster_domain": {
+                    "description": "Corefile 中 kubernetes 插件的域",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "ready_pods": {
+                    "type": "integer"
+                },
+                "service_ip": {
+                    "type": "string"
+                },
+                "total_pods": {
+                    "type": "integer"
+                },
+                "upstreams": {
+                    "description": "Corefile 中 forward 的上游",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.DNSDiagnosis": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string"
+                },
+                "coredns": {
+                    "$ref": "#/definitions/service.CoreDNSInfo"
+                },
+                "dns_policy": {
+                    "type": "string"
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DNSFinding"
+                    }
+                },
+                "healthy": {
+                    "description": "没有 error 级别的结论",
+                    "type": "boolean"
+                },
+                "lookups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DNSLookup"
+                    }
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "pod": {
+                    "type": "string"
+                },
+                "resolv_conf": {
+                    "$ref": "#/definitions/service.ResolvConf"
+                }
+            }
+        },
+        "service.DNSFinding": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "envs": {
-                    "type": "object",
-                    "additionalProperties": {
+                "level": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.DNSLookup": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
                         "type": "string"
                     }
+                },
+                "duration_ms": {
+                    "description": "包含一次 exec 的开销",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expected": {
+                    "description": "期望解析到的地址，如 kubernetes Service 的 ClusterIP",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "cluster、short、external、custom",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "tool": {
+                    "description": "使用的解析工具：getent、nslookup",
+                    "type": "string"
                 }
             }
         },
-        "dynamic.HealthCheckInfo": {
+        "service.DrainPodKey": {
             "type": "object",
             "properties": {
-                "container_name": {
+                "name": {
                     "type": "string"
                 },
-                "liveness_probe": {
-                    "type": "object",
-                    "additionalProperties": true
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.EncryptionColumnStatus": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
                 },
-                "liveness_type": {
+                "name": {
                     "type": "string"
                 },
-                "readiness_probe": {
+                "total": {
+                    "type": "integer"
+                },
+                "versions": {
+                    "description": "主密钥版本 -> 记录数，另有 legacy、plaintext",
                     "type": "object",
-                    "additionalProperties": true
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
+        "service.EncryptionRotateResult": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
                 },
-                "readiness_type": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string"
+                },
+                "rewrapped": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "已使用当前主密钥",
+                    "type": "integer"
                 }
             }
         },
-        "dynamic.Tolerations": {
+        "service.EncryptionStatus": {
             "type": "object",
             "properties": {
-                "effect": {
+                "active_key": {
+                    "type": "string"
+                },
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.EncryptionColumnStatus"
+                    }
+                },
+                "enabled": {
+                    "description": "是否配置了主密钥",
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.EnvPatch": {
+            "type": "object",
+            "properties": {
+                "remove": {
+                    "description": "从 env 中删除，envFrom 导入的变量无法单独删除",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "set": {
+                    "description": "新增或修改，原先引用 ConfigMap/Secret 的同名变量改为直接填写的值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.EnvVarView": {
+            "type": "object",
+            "properties": {
+                "editable": {
+                    "description": "是否为 env 中直接填写的值，可通过环境变量修改接口直接修改",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "引用无法解析的原因，如 ConfigMap 不存在",
                     "type": "string"
                 },
                 "key": {
+                    "description": "引用的键、字段路径或资源名",
                     "type": "string"
                 },
-                "operator": {
+                "masked": {
+                    "description": "值来自 Secret，已掩码",
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
-                "tolerationSeconds": {
-                    "type": "integer"
+                "overridden": {
+                    "description": "被同名的后续定义覆盖，不是生效值",
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "来源，见 EnvSource 常量",
+                    "type": "string"
+                },
+                "source_name": {
+                    "description": "引用的 ConfigMap/Secret 名称",
+                    "type": "string"
                 },
                 "value": {
+                    "description": "解析后的值，Secret 中的值被掩码",
                     "type": "string"
                 }
             }
         },
-        "dynamic.imageInfo": {
+        "service.ExecResult": {
             "type": "object",
             "properties": {
-                "container_name": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "exit_code": {
+                    "type": "integer"
+                },
+                "stderr": {
+                    "type": "string"
+                },
+                "stdout": {
+                    "type": "string"
+                },
+                "timed_out": {
+                    "type": "boolean"
+                },
+                "truncated": {
+                    "description": "输出超过上限被截断",
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.HibernationWorkload": {
+            "type": "object",
+            "properties": {
+                "hibernated": {
+                    "description": "Hibernated 休眠前的副本数，未休眠时为空",
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "replicas": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.ImagePullFailure": {
+            "type": "object",
+            "properties": {
+                "cause": {
+                    "type": "string"
+                },
+                "container": {
                     "type": "string"
                 },
+                "has_pull_secret": {
+                    "description": "Pod 的 imagePullSecrets 中是否有该仓库的凭据",
+                    "type": "boolean"
+                },
                 "image": {
                     "type": "string"
                 },
-                "image_pull_policy": {
+                "message": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "pod": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "ImagePullBackOff 或 ErrImagePull",
+                    "type": "string"
+                },
+                "registry": {
+                    "type": "string"
+                },
+                "suggest_credential_id": {
+                    "description": "平台中可同步到该命名空间的仓库凭据",
+                    "type": "integer"
+                },
+                "workload": {
+                    "description": "控制者，形如 ReplicaSet/nginx-5d8f7c",
+                    "type": "string"
+                }
+            }
+        },
+        "service.KubeProxyConfig": {
+            "type": "object",
+            "properties": {
+                "cluster_cidr": {
+                    "type": "string"
+                },
+                "config": {
+                    "description": "ConfigMap 中的 config.conf",
+                    "type": "string"
+                },
+                "config_source": {
+                    "description": "configmap、args，未部署 kube-proxy 时为空",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ipvs_scheduler": {
+                    "type": "string"
+                },
+                "mode": {
+                    "description": "生效的代理模式",
+                    "type": "string"
+                },
+                "resource_version": {
                     "type": "string"
+                }
+            }
+        },
+        "service.NamespaceCloneItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.NamespaceCloneRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "kinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/nsclone.Kind"
+                    }
+                },
+                "names": {
+                    "description": "Names 名称改写规则，引用了被改名资源的字段同步改写",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/nsclone.NameRewrite"
+                        }
+                    ]
+                },
+                "overwrite": {
+                    "description": "Overwrite 目标命名空间已存在时是否继续，同名资源会被更新",
+                    "type": "boolean"
+                },
+                "remove_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seal_cert": {
+                    "description": "SealCert seal 模式下目标集群 sealed-secrets 控制器的证书，可通过 kubeseal --fetch-cert 获取",
+                    "type": "string"
+                },
+                "secrets": {
+                    "description": "Secrets Secret 处理方式：copy（默认）、exclude、seal",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/nsclone.SecretMode"
+                        }
+                    ]
+                },
+                "set_labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "target_cluster": {
+                    "description": "TargetCluster 目标集群，为空时克隆到当前集群",
+                    "type": "string"
+                },
+                "target_namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.NamespaceCloneResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.NamespaceCloneItem"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/nsclone.Skipped"
+                    }
                 },
-                "image_pull_secrets": {
+                "succeeded": {
+                    "type": "boolean"
+                },
+                "target_cluster": {
                     "type": "string"
                 },
-                "tag": {
+                "target_namespace": {
                     "type": "string"
                 }
             }
         },
-        "dynamic.nodeAffinity": {
+        "service.OOMReport": {
             "type": "object",
             "properties": {
-                "key": {
-                    "type": "string"
+                "groups": {
+                    "description": "按次数从多到少排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.OOMTrendGroup"
+                    }
                 },
-                "operator": {
+                "since": {
                     "type": "string"
                 },
-                "values": {
+                "total": {
+                    "description": "整个集群（或命名空间）每天的次数",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/service.OOMTrendPoint"
                     }
                 }
             }
         },
-        "dynamic.podAffinity": {
+        "service.OOMTrendGroup": {
             "type": "object",
             "properties": {
-                "labelSelector": {
-                    "type": "object",
-                    "properties": {
-                        "matchLabels": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.OOMTrendPoint"
                     }
                 },
-                "topologyKey": {
-                    "type": "string"
-                }
-            }
-        },
-        "dynamic.resourceInfo": {
-            "type": "object",
-            "properties": {
-                "container_name": {
-                    "type": "string"
+                "eviction": {
+                    "type": "integer"
                 },
-                "limit_cpu": {
+                "last_at": {
                     "type": "string"
                 },
-                "limit_memory": {
+                "namespace": {
                     "type": "string"
                 },
-                "request_cpu": {
-                    "type": "string"
+                "oom": {
+                    "type": "integer"
                 },
-                "request_memory": {
+                "workload": {
                     "type": "string"
                 }
             }
         },
-        "dynamic.yamlRequest": {
+        "service.OOMTrendPoint": {
             "type": "object",
-            "required": [
-                "yaml"
-            ],
             "properties": {
-                "yaml": {
+                "date": {
+                    "description": "形如 2006-01-02",
                     "type": "string"
+                },
+                "eviction": {
+                    "type": "integer"
+                },
+                "oom": {
+                    "type": "integer"
                 }
             }
         },
-        "models.AIRunConfig": {
+        "service.PasswordPolicy": {
             "type": "object",
             "properties": {
-                "any_select": {
-                    "description": "是否开启任意选择",
-                    "type": "boolean"
-                },
-                "created_at": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "floating_window": {
-                    "description": "是否开启浮动窗口",
-                    "type": "boolean"
-                },
-                "id": {
-                    "description": "主键ID",
+                "history": {
                     "type": "integer"
                 },
-                "max_history": {
-                    "description": "最大历史记录数",
+                "max_age_days": {
                     "type": "integer"
                 },
-                "max_iterations": {
-                    "description": "最大迭代次数",
+                "min_classes": {
                     "type": "integer"
                 },
-                "model_id": {
-                    "description": "模型ID",
+                "min_length": {
                     "type": "integer"
-                },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
-                },
-                "use_built_in_model": {
-                    "description": "是否使用内置模型",
-                    "type": "boolean"
                 }
             }
         },
-        "models.Config": {
+        "service.PodActionOptions": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "confirm": {
+                    "description": "为 false 时只返回确认信息，不执行",
+                    "type": "boolean"
                 },
-                "id": {
+                "grace_period_seconds": {
+                    "description": "force_delete 使用，为空时为 0",
                     "type": "integer"
                 },
-                "image_pull_timeout": {
-                    "description": "镜像拉取超时时间（秒）",
+                "wait_seconds": {
+                    "description": "recreate_elsewhere 等待替代 Pod 调度的最长时间，默认 300",
                     "type": "integer"
-                },
-                "jwt_token_secret": {
+                }
+            }
+        },
+        "service.PodFileRef": {
+            "type": "object",
+            "properties": {
+                "cluster": {
                     "type": "string"
                 },
-                "kubectl_shell_image": {
+                "containerName": {
                     "type": "string"
                 },
-                "login_type": {
+                "namespace": {
                     "type": "string"
                 },
-                "node_shell_image": {
+                "path": {
                     "type": "string"
                 },
-                "print_config": {
-                    "type": "boolean"
-                },
-                "product_name": {
-                    "description": "产品名称",
+                "podName": {
                     "type": "string"
-                },
-                "resource_cache_timeout": {
-                    "description": "资源缓存时间（秒）",
+                }
+            }
+        },
+        "service.ProbeEvent": {
+            "type": "object",
+            "properties": {
+                "count": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "description": "Automatically managed by GORM for update time",
+                "message": {
+                    "type": "string"
+                },
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "models.CustomTemplate": {
+        "service.ProbeTestResult": {
             "type": "object",
             "properties": {
-                "cluster": {
-                    "description": "模板类型，最大长度 100",
+                "body": {
+                    "description": "HTTP 响应体，最多 1KiB",
                     "type": "string"
                 },
-                "content": {
-                    "description": "模板内容，支持大文本存储",
+                "container": {
                     "type": "string"
                 },
-                "created_at": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "exec": {
+                    "$ref": "#/definitions/service.ExecResult"
+                },
+                "handler": {
+                    "description": "exec、httpGet、tcpSocket、grpc",
                     "type": "string"
                 },
-                "created_by": {
-                    "description": "创建者",
+                "message": {
+                    "description": "失败原因或成功说明",
                     "type": "string"
                 },
-                "id": {
-                    "description": "模板 ID，主键，自增",
+                "notes": {
+                    "description": "本次测试与 kubelet 实际探测方式的差异",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "overridden": {
+                    "description": "使用请求中的探针定义，而非容器中已配置的",
+                    "type": "boolean"
+                },
+                "probe": {
+                    "description": "本次测试使用的探针定义",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Probe"
+                        }
+                    ]
+                },
+                "recent_failures": {
+                    "description": "最近的探针失败事件",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ProbeEvent"
+                    }
+                },
+                "status_code": {
                     "type": "integer"
                 },
-                "is_global": {
-                    "description": "模板类型，最大长度 100",
+                "success": {
+                    "description": "按 kubelet 的判定规则是否成功",
                     "type": "boolean"
                 },
-                "kind": {
-                    "description": "模板类型，最大长度 100",
+                "target": {
+                    "description": "实际探测的命令或地址",
                     "type": "string"
                 },
-                "name": {
-                    "description": "模板名称，非空，最大长度 255",
-                    "type": "string"
+                "timed_out": {
+                    "description": "超过探针的 timeoutSeconds",
+                    "type": "boolean"
                 },
-                "updated_at": {
-                    "description": "Automatically managed by GORM for update time",
+                "type": {
+                    "description": "liveness、readiness、startup",
                     "type": "string"
                 }
             }
         },
-        "models.HelmRepository": {
+        "service.ProjectQuotaResult": {
             "type": "object",
             "properties": {
-                "auth_type": {
+                "action": {
+                    "description": "created、updated",
                     "type": "string"
                 },
-                "caFile": {
+                "cluster": {
                     "type": "string"
                 },
-                "certFile": {
+                "error": {
                     "type": "string"
                 },
-                "created_at": {
+                "namespace": {
                     "type": "string"
-                },
-                "description": {
-                    "description": "仓库描述",
+                }
+            }
+        },
+        "service.ProjectView": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "encrypted_secret": {
+                "created_by": {
                     "type": "string"
                 },
-                "generated": {
-                    "description": "repo 索引文件创建时间",
+                "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "insecure_skip_tls_verify": {
-                    "type": "boolean"
-                },
-                "is_active": {
-                    "description": "是否启用",
-                    "type": "boolean"
-                },
-                "keyFile": {
-                    "type": "string"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectMember"
+                    }
                 },
                 "name": {
-                    "description": "仓库名称（唯一）",
                     "type": "string"
                 },
-                "pass_credentials_all": {
-                    "type": "boolean"
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectNamespace"
+                    }
                 },
-                "password": {
+                "quota": {
+                    "description": "项目内每个命名空间的 ResourceQuota hard，JSON 对象，如 {\"requests.cpu\":\"4\",\"limits.memory\":\"8Gi\"}",
                     "type": "string"
                 },
-                "type": {
+                "role": {
+                    "description": "当前用户在项目中的角色，平台管理员非成员时为空",
                     "type": "string"
                 },
                 "updated_at": {
-                    "description": "Automatically managed by GORM for update time",
-                    "type": "string"
-                },
-                "url": {
-                    "description": "仓库地址（如 https://charts.example.com）",
-                    "type": "string"
-                },
-                "username": {
-                    "description": "认证用户名（加密存储）",
                     "type": "string"
                 }
             }
         },
-        "models.KubeConfig": {
+        "service.ReadOnlyState": {
             "type": "object",
             "properties": {
-                "burst": {
-                    "description": "Burst 设置突发请求数限制，默认为 2000",
-                    "type": "integer"
-                },
-                "ca_data": {
-                    "description": "ca 证书内容，支持大文本存储",
-                    "type": "string"
-                },
                 "cluster": {
-                    "description": "类型，最大长度 100",
+                    "description": "为空表示全局",
                     "type": "string"
                 },
-                "cluster_name": {
-                    "description": "AWS EKS 集群名称",
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "content": {
-                    "description": "模板内容，支持大文本存储",
+                "reason": {
+                    "description": "展示给用户的原因，如故障冻结、维护窗口",
                     "type": "string"
                 },
-                "created_at": {
+                "until": {
+                    "description": "到期后自动解除，为空时需手动关闭",
                     "type": "string"
                 },
-                "display_name": {
+                "updated_at": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "模板 ID，主键，自增",
-                    "type": "integer"
-                },
-                "is_aws_eks": {
-                    "description": "标识是否为AWS EKS集群",
-                    "type": "boolean"
-                },
-                "namespace": {
+                "updated_by": {
                     "type": "string"
-                },
-                "proxy_url": {
-                    "description": "kom 集群注册配置项\nProxyURL 设置 HTTP 代理，例如 http://127.0.0.1:7890",
+                }
+            }
+        },
+        "service.ReportType": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "qps": {
-                    "description": "QPS 设置每秒查询数限制，默认为 200",
-                    "type": "number"
-                },
-                "region": {
-                    "description": "AWS 区域",
+                "name": {
                     "type": "string"
                 },
-                "server": {
+                "title": {
                     "type": "string"
+                }
+            }
+        },
+        "service.ResolvConf": {
+            "type": "object",
+            "properties": {
+                "nameservers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "timeout": {
-                    "description": "Timeout 设置请求超时时间，单位为秒，默认为 30 秒",
+                "ndots": {
+                    "description": "未配置时为 glibc 默认值 1",
                     "type": "integer"
                 },
-                "token": {
-                    "description": "token 纳管相关 server\\token\\cadata",
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updated_at": {
-                    "description": "Automatically managed by GORM for update time",
+                "raw": {
                     "type": "string"
                 },
-                "user": {
-                    "type": "string"
+                "search": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "models.MCPServerConfig": {
+        "service.StatefulSetPod": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "name": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "node": {
+                    "type": "string"
                 },
-                "id": {
+                "ordinal": {
                     "type": "integer"
                 },
-                "name": {
+                "phase": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "ready": {
+                    "type": "boolean"
                 },
-                "url": {
+                "revision": {
                     "type": "string"
+                },
+                "updated": {
+                    "description": "已是目标版本",
+                    "type": "boolean"
                 }
             }
         },
-        "models.Menu": {
+        "service.StatefulSetRestartOptions": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "reverse": {
+                    "description": "为 true 时从最大序号开始",
+                    "type": "boolean"
                 },
-                "id": {
+                "wait_seconds": {
+                    "description": "每个 Pod 重建后等待就绪的最长时间，默认 600，最大 3600",
                     "type": "integer"
+                }
+            }
+        },
+        "service.StatefulSetRevisionCount": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "status.currentRevision",
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "ordinals": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pods": {
+                    "type": "integer"
+                },
+                "revision": {
                     "type": "string"
+                },
+                "update": {
+                    "description": "status.updateRevision，即目标版本",
+                    "type": "boolean"
                 }
             }
         },
-        "models.User": {
+        "service.StatefulSetRevisionStatus": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "current_revision": {
                     "type": "string"
                 },
-                "disabled": {
-                    "description": "是否启用",
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "group_names": {
+                "namespace": {
                     "type": "string"
                 },
-                "id": {
+                "partition": {
+                    "description": "序号不小于分区的 Pod 才会更新到目标版本",
                     "type": "integer"
                 },
-                "password": {
+                "pod_management_policy": {
                     "type": "string"
                 },
-                "salt": {
-                    "type": "string"
+                "pods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.StatefulSetPod"
+                    }
                 },
-                "source": {
-                    "description": "来源，如：db, ldap_config.json, oauth",
-                    "type": "string"
+                "ready_replicas": {
+                    "type": "integer"
                 },
-                "two_fa_app_name": {
-                    "description": "2FA应用名称，用于提醒用户使用的是哪个软件",
+                "replicas": {
+                    "type": "integer"
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.StatefulSetRevisionCount"
+                    }
+                },
+                "strategy": {
                     "type": "string"
                 },
-                "two_fa_backup_codes": {
-                    "description": "备用恢复码，逗号分隔",
+                "update_revision": {
                     "type": "string"
                 },
-                "two_fa_enabled": {
-                    "description": "是否启用2FA",
-                    "type": "boolean"
+                "updated_replicas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.UpgradeReadinessItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "version、api、webhook、node",
+                    "type": "string"
                 },
-                "two_fa_secret": {
-                    "description": "2FA密钥",
+                "message": {
                     "type": "string"
                 },
-                "two_fa_type": {
-                    "description": "2FA类型：如 'totp', 'sms', 'email'",
+                "object": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "description": "Automatically managed by GORM for update time",
+                "severity": {
+                    "description": "blocker、warning、info",
                     "type": "string"
                 },
-                "username": {
+                "suggestion": {
                     "type": "string"
                 }
             }
         },
-        "models.UserGroup": {
+        "service.UpgradeReadinessReport": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "blockers": {
+                    "type": "integer"
+                },
+                "checked_at": {
                     "type": "string"
                 },
-                "description": {
+                "cluster": {
                     "type": "string"
                 },
-                "group_name": {
+                "current_version": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "errors": {
+                    "description": "未能完成的检查，报告可能不完整",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "menu_data": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.UpgradeReadinessItem"
+                    }
                 },
-                "role": {
-                    "description": "管理员/只读",
-                    "type": "string"
+                "ready": {
+                    "description": "没有阻塞项",
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "target_version": {
                     "type": "string"
+                },
+                "warnings": {
+                    "type": "integer"
                 }
             }
         },
-        "node.TaintInfo": {
+        "service.WorkloadImageResult": {
             "type": "object",
             "properties": {
-                "effect": {
+                "container": {
                     "type": "string"
                 },
-                "key": {
+                "digest": {
                     "type": "string"
                 },
-                "value": {
+                "image": {
+                    "description": "更新后的镜像",
+                    "type": "string"
+                },
+                "init": {
+                    "type": "boolean"
+                },
+                "previous": {
+                    "description": "更新前的镜像，已记录在注解中，可一键回滚",
                     "type": "string"
                 }
             }
         },
-        "pod.info": {
+        "service.WorkloadImageUpdate": {
             "type": "object",
             "properties": {
-                "containerName": {
+                "container": {
+                    "description": "容器名，可以是 initContainer",
                     "type": "string"
                 },
-                "fileContext": {
+                "image": {
+                    "description": "完整镜像名，可带标签或摘要，如 nginx:1.25、nginx@sha256:xxx",
                     "type": "string"
                 },
-                "fileName": {
+                "pin_digest": {
+                    "description": "更新时将标签解析为摘要，写入 image:tag@sha256:xxx，避免标签被重新推送后各副本镜像不一致",
+                    "type": "boolean"
+                },
+                "platform": {
+                    "description": "解析摘要时多架构镜像选择的平台，默认 linux/amd64；写入的是多架构索引的摘要",
                     "type": "string"
+                }
+            }
+        },
+        "template.RenderRequest": {
+            "type": "object",
+            "properties": {
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "isDir": {
-                    "type": "boolean"
+                "version": {
+                    "description": "指定渲染的版本，为空使用最新版本",
+                    "type": "integer"
+                }
+            }
+        },
+        "user.AssignGroupsRequest": {
+            "type": "object",
+            "properties": {
+                "group_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.ExecAction": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "Command is the command line to execute inside the container, the working directory for the\ncommand  is root ('/') in the container's filesystem. The command is simply exec'd, it is\nnot run inside a shell, so traditional shell instructions ('|', etc) won't work. To use\na shell, you need to explicitly call out to that shell.\nExit status of 0 is treated as live/healthy and non-zero is unhealthy.\n+optional\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.GRPCAction": {
+            "type": "object",
+            "properties": {
+                "port": {
+                    "description": "Port number of the gRPC service. Number must be in the range 1 to 65535.",
+                    "type": "integer"
                 },
-                "namespace": {
+                "service": {
+                    "description": "Service is the name of the service to place in the gRPC HealthCheckRequest\n(see https://github.com/grpc/grpc/blob/master/doc/health-checking.md).\n\nIf this is not specified, the default behavior is defined by gRPC.\n+optional\n+default=\"\"",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.HTTPGetAction": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "description": "Host name to connect to, defaults to the pod IP. You probably want to set\n\"Host\" in httpHeaders instead.\n+optional",
                     "type": "string"
                 },
+                "httpHeaders": {
+                    "description": "Custom headers to set in the request. HTTP allows repeated headers.\n+optional\n+listType=atomic",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s_io_api_core_v1.HTTPHeader"
+                    }
+                },
                 "path": {
+                    "description": "Path to access on the HTTP server.\n+optional",
                     "type": "string"
                 },
-                "podName": {
-                    "type": "string"
+                "port": {
+                    "description": "Name or number of the port to access on the container.\nNumber must be in the range 1 to 65535.\nName must be an IANA_SVC_NAME.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/intstr.IntOrString"
+                        }
+                    ]
                 },
-                "size": {
+                "scheme": {
+                    "description": "Scheme to use for connecting to the host.\nDefaults to HTTP.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.URIScheme"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.PodConditionType": {
+            "type": "string",
+            "enum": [
+                "ContainersReady",
+                "Initialized",
+                "Ready",
+                "PodScheduled",
+                "DisruptionTarget",
+                "PodReadyToStartContainers",
+                "PodResizePending",
+                "PodResizeInProgress"
+            ],
+            "x-enum-varnames": [
+                "ContainersReady",
+                "PodInitialized",
+                "PodReady",
+                "PodScheduled",
+                "DisruptionTarget",
+                "PodReadyToStartContainers",
+                "PodResizePending",
+                "PodResizeInProgress"
+            ]
+        },
+        "v1.PodPhase": {
+            "type": "string",
+            "enum": [
+                "Pending",
+                "Running",
+                "Succeeded",
+                "Failed",
+                "Unknown"
+            ],
+            "x-enum-varnames": [
+                "PodPending",
+                "PodRunning",
+                "PodSucceeded",
+                "PodFailed",
+                "PodUnknown"
+            ]
+        },
+        "v1.PodQOSClass": {
+            "type": "string",
+            "enum": [
+                "Guaranteed",
+                "Burstable",
+                "BestEffort"
+            ],
+            "x-enum-varnames": [
+                "PodQOSGuaranteed",
+                "PodQOSBurstable",
+                "PodQOSBestEffort"
+            ]
+        },
+        "v1.Probe": {
+            "type": "object",
+            "properties": {
+                "exec": {
+                    "description": "Exec specifies a command to execute in the container.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ExecAction"
+                        }
+                    ]
+                },
+                "failureThreshold": {
+                    "description": "Minimum consecutive failures for the probe to be considered failed after having succeeded.\nDefaults to 3. Minimum value is 1.\n+optional",
                     "type": "integer"
                 },
-                "type": {
-                    "description": "只有file类型可以查、下载",
-                    "type": "string"
+                "grpc": {
+                    "description": "GRPC specifies a GRPC HealthCheckRequest.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.GRPCAction"
+                        }
+                    ]
+                },
+                "httpGet": {
+                    "description": "HTTPGet specifies an HTTP GET request to perform.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.HTTPGetAction"
+                        }
+                    ]
+                },
+                "initialDelaySeconds": {
+                    "description": "Number of seconds after the container has started before liveness probes are initiated.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes\n+optional",
+                    "type": "integer"
+                },
+                "periodSeconds": {
+                    "description": "How often (in seconds) to perform the probe.\nDefault to 10 seconds. Minimum value is 1.\n+optional",
+                    "type": "integer"
+                },
+                "successThreshold": {
+                    "description": "Minimum consecutive successes for the probe to be considered successful after having failed.\nDefaults to 1. Must be 1 for liveness and startup. Minimum value is 1.\n+optional",
+                    "type": "integer"
+                },
+                "tcpSocket": {
+                    "description": "TCPSocket specifies a connection to a TCP port.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.TCPSocketAction"
+                        }
+                    ]
+                },
+                "terminationGracePeriodSeconds": {
+                    "description": "Optional duration in seconds the pod needs to terminate gracefully upon probe failure.\nThe grace period is the duration in seconds after the processes running in the pod are sent\na termination signal and the time when the processes are forcibly halted with a kill signal.\nSet this value longer than the expected cleanup time for your process.\nIf this value is nil, the pod's terminationGracePeriodSeconds will be used. Otherwise, this\nvalue overrides the value provided by the pod spec.\nValue must be non-negative integer. The value zero indicates stop immediately via\nthe kill signal (no opportunity to shut down).\nThis is a beta field and requires enabling ProbeTerminationGracePeriod feature gate.\nMinimum value is 1. spec.terminationGracePeriodSeconds is used if unset.\n+optional",
+                    "type": "integer"
+                },
+                "timeoutSeconds": {
+                    "description": "Number of seconds after which the probe times out.\nDefaults to 1 second. Minimum value is 1.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes\n+optional",
+                    "type": "integer"
                 }
             }
         },
-        "profile.PasswordUpdateRequest": {
+        "v1.TCPSocketAction": {
             "type": "object",
-            "required": [
-                "confirmPassword",
-                "oldPassword",
-                "password"
-            ],
             "properties": {
-                "confirmPassword": {
-                    "description": "确认密码（加密后）",
+                "host": {
+                    "description": "Optional: Host name to connect to, defaults to the pod IP.\n+optional",
                     "type": "string"
                 },
-                "oldPassword": {
-                    "description": "原密码（加密后）",
+                "port": {
+                    "description": "Number or name of the port to access on the container.\nNumber must be in the range 1 to 65535.\nName must be an IANA_SVC_NAME.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/intstr.IntOrString"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.TaintEffect": {
+            "type": "string",
+            "enum": [
+                "NoSchedule",
+                "PreferNoSchedule",
+                "NoExecute"
+            ],
+            "x-enum-varnames": [
+                "TaintEffectNoSchedule",
+                "TaintEffectPreferNoSchedule",
+                "TaintEffectNoExecute"
+            ]
+        },
+        "v1.Toleration": {
+            "type": "object",
+            "properties": {
+                "effect": {
+                    "description": "Effect indicates the taint effect to match. Empty means match all taint effects.\nWhen specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.TaintEffect"
+                        }
+                    ]
+                },
+                "key": {
+                    "description": "Key is the taint key that the toleration applies to. Empty means match all taint keys.\nIf the key is empty, operator must be Exists; this combination means to match all values and all keys.\n+optional",
                     "type": "string"
                 },
-                "password": {
-                    "description": "新密码（加密后）",
+                "operator": {
+                    "description": "Operator represents a key's relationship to the value.\nValid operators are Exists and Equal. Defaults to Equal.\nExists is equivalent to wildcard for value, so that a pod can\ntolerate all taints of a particular category.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.TolerationOperator"
+                        }
+                    ]
+                },
+                "tolerationSeconds": {
+                    "description": "TolerationSeconds represents the period of time the toleration (which must be\nof effect NoExecute, otherwise this field is ignored) tolerates the taint. By default,\nit is not set, which means tolerate the taint forever (do not evict). Zero and\nnegative values will be treated as 0 (evict immediately) by the system.\n+optional",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Value is the taint value the toleration matches to.\nIf the operator is Exists, the value should be empty, otherwise just a regular string.\n+optional",
                     "type": "string"
                 }
             }
+        },
+        "v1.TolerationOperator": {
+            "type": "string",
+            "enum": [
+                "Exists",
+                "Equal"
+            ],
+            "x-enum-varnames": [
+                "TolerationOpExists",
+                "TolerationOpEqual"
+            ]
+        },
+        "v1.URIScheme": {
+            "type": "string",
+            "enum": [
+                "HTTP",
+                "HTTPS"
+            ],
+            "x-enum-varnames": [
+                "URISchemeHTTP",
+                "URISchemeHTTPS"
+            ]
         }
     },
     "securityDefinitions": {
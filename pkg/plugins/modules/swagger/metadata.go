@@ -10,7 +10,7 @@ var Metadata = plugins.Module{
 		Name:        modules.PluginNameSwagger,
 		Title:       "Swagger文档",
 		Version:     "1.0.0",
-		Description: "Swagger API文档查看。更新执行插件目录下的make.sh脚本生成文档。",
+		Description: "Swagger API文档查看，同时在 /api/openapi.json 提供 OpenAPI 3 文档。更新执行插件目录下的make.sh脚本生成文档。",
 	},
 	Tables: []string{},
 	Crons:  []string{},
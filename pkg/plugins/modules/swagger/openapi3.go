@@ -0,0 +1,205 @@
+package swagger
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// OpenAPI3 将 swag 生成的 Swagger 2.0 文档转换为 OpenAPI 3.0 文档。
+// servers 依次列出版本化前缀与兼容的旧路径，客户端生成工具默认使用第一个。
+func OpenAPI3(servers ...string) ([]byte, error) {
+	var v2 map[string]any
+	if err := json.Unmarshal([]byte((&s{}).ReadDoc()), &v2); err != nil {
+		return nil, err
+	}
+
+	v3 := map[string]any{
+		"openapi": "3.0.3",
+		"info":    v2["info"],
+	}
+	var serverList []map[string]any
+	for _, url := range servers {
+		serverList = append(serverList, map[string]any{"url": url})
+	}
+	if len(serverList) > 0 {
+		v3["servers"] = serverList
+	}
+
+	paths := map[string]any{}
+	if v2Paths, ok := v2["paths"].(map[string]any); ok {
+		for p, item := range v2Paths {
+			ops, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			newOps := map[string]any{}
+			for method, op := range ops {
+				if opMap, ok := op.(map[string]any); ok {
+					newOps[method] = convertOperation(opMap)
+				}
+			}
+			paths[p] = newOps
+		}
+	}
+	v3["paths"] = paths
+
+	components := map[string]any{}
+	if defs, ok := v2["definitions"].(map[string]any); ok {
+		components["schemas"] = rewriteRefs(defs)
+	}
+	if sec, ok := v2["securityDefinitions"].(map[string]any); ok {
+		components["securitySchemes"] = sec
+	}
+	v3["components"] = components
+
+	return json.MarshalIndent(v3, "", "  ")
+}
+
+// convertOperation 转换单个接口：body/formData 参数转为 requestBody，响应的 schema 放入 content
+func convertOperation(op map[string]any) map[string]any {
+	out := map[string]any{}
+	for _, k := range []string{"summary", "description", "tags", "security", "operationId", "deprecated"} {
+		if v, ok := op[k]; ok {
+			out[k] = v
+		}
+	}
+
+	var params []any
+	formProps := map[string]any{}
+	var formRequired []string
+	formType := "multipart/form-data"
+	if consumes, ok := op["consumes"].([]any); ok && len(consumes) > 0 {
+		if c, ok := consumes[0].(string); ok && c == "application/x-www-form-urlencoded" {
+			formType = c
+		}
+	}
+
+	if v2Params, ok := op["parameters"].([]any); ok {
+		for _, p := range v2Params {
+			param, ok := p.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch param["in"] {
+			case "body":
+				body := map[string]any{
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": rewriteRefs(param["schema"])},
+					},
+				}
+				if d, ok := param["description"]; ok {
+					body["description"] = d
+				}
+				if r, ok := param["required"].(bool); ok && r {
+					body["required"] = true
+				}
+				out["requestBody"] = body
+			case "formData":
+				name, _ := param["name"].(string)
+				formProps[name] = paramSchema(param)
+				if r, ok := param["required"].(bool); ok && r {
+					formRequired = append(formRequired, name)
+				}
+			default:
+				np := map[string]any{"schema": paramSchema(param)}
+				for _, k := range []string{"name", "in", "description", "required"} {
+					if v, ok := param[k]; ok {
+						np[k] = v
+					}
+				}
+				// OpenAPI 3 要求路径参数必须为 required
+				if param["in"] == "path" {
+					np["required"] = true
+				}
+				params = append(params, np)
+			}
+		}
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+	if len(formProps) > 0 {
+		schema := map[string]any{"type": "object", "properties": formProps}
+		if len(formRequired) > 0 {
+			schema["required"] = formRequired
+		}
+		out["requestBody"] = map[string]any{
+			"content": map[string]any{formType: map[string]any{"schema": schema}},
+		}
+	}
+
+	responses := map[string]any{}
+	if v2Resp, ok := op["responses"].(map[string]any); ok {
+		for code, r := range v2Resp {
+			resp, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			nr := map[string]any{"description": resp["description"]}
+			if nr["description"] == nil {
+				nr["description"] = ""
+			}
+			if schema, ok := resp["schema"].(map[string]any); ok {
+				if schema["type"] == "file" {
+					nr["content"] = map[string]any{
+						"application/octet-stream": map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}},
+					}
+				} else {
+					nr["content"] = map[string]any{
+						"application/json": map[string]any{"schema": rewriteRefs(schema)},
+					}
+				}
+			}
+			responses[code] = nr
+		}
+	}
+	if len(responses) == 0 {
+		responses["200"] = map[string]any{"description": "OK"}
+	}
+	out["responses"] = responses
+	return out
+}
+
+// paramSchema 将 Swagger 2.0 非 body 参数上的类型字段收拢为 OpenAPI 3 的 schema
+func paramSchema(param map[string]any) map[string]any {
+	schema := map[string]any{}
+	for _, k := range []string{"type", "format", "items", "enum", "default", "minimum", "maximum"} {
+		if v, ok := param[k]; ok {
+			schema[k] = rewriteRefs(v)
+		}
+	}
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+	if len(schema) == 0 {
+		schema["type"] = "string"
+	}
+	return schema
+}
+
+// rewriteRefs 递归将 #/definitions/ 引用改写为 #/components/schemas/
+func rewriteRefs(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			if k == "$ref" {
+				if ref, ok := item.(string); ok {
+					out[k] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+					continue
+				}
+			}
+			out[k] = rewriteRefs(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = rewriteRefs(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -32,6 +32,12 @@ func NewWebhookClientWithTimeout(timeout time.Duration) *WebhookClient {
 
 // Send sends a webhook message using the specified configuration and platform adapter.
 func (c *WebhookClient) Send(ctx context.Context, msg, raw string, config *WebhookConfig) (*SendResult, error) {
+	return c.SendWithAttachments(ctx, msg, raw, nil, config)
+}
+
+// SendWithAttachments sends a webhook message with attachments. Attachments are only
+// delivered by platforms implementing Deliverer, other platforms receive the message only.
+func (c *WebhookClient) SendWithAttachments(ctx context.Context, msg, raw string, attachments []Attachment, config *WebhookConfig) (*SendResult, error) {
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return &SendResult{
@@ -51,6 +57,10 @@ func (c *WebhookClient) Send(ctx context.Context, msg, raw string, config *Webho
 		}, err
 	}
 
+	if deliverer, ok := adapter.(Deliverer); ok {
+		return deliverer.Deliver(ctx, msg, raw, attachments, config)
+	}
+
 	// Format message
 	body, err := adapter.FormatMessage(msg, raw, config)
 	if err != nil {
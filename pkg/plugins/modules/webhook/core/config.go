@@ -20,7 +20,7 @@ func ensureAdaptersRegistered() {
 type WebhookConfig struct {
 	WebhookId    uint   // WebhookID of the webhook configuration
 	WebhookName  string // WebhookName of the webhook configuration
-	Platform     string // Platform identifier (feishu, dingtalk, wechat, default)
+	Platform     string // Platform identifier (feishu, dingtalk, wechat, email, default)
 	TargetURL    string // The webhook endpoint URL
 	BodyTemplate string // Message body template (optional, platform defaults will be used if empty)
 	SignSecret   string // Secret for signing requests (platform-specific)
@@ -74,7 +74,8 @@ func (c *WebhookConfig) Validate() error {
 
 	// Validate platform by registry
 	ensureAdaptersRegistered()
-	if _, err := GetAdapter(c.Platform); err != nil {
+	adapter, err := GetAdapter(c.Platform)
+	if err != nil {
 		return ErrInvalidPlatform
 	}
 
@@ -82,6 +83,11 @@ func (c *WebhookConfig) Validate() error {
 		return ErrInvalidURL
 	}
 
+	// Platforms not using HTTP validate their own target format
+	if deliverer, ok := adapter.(Deliverer); ok {
+		return deliverer.ValidateTarget(c.TargetURL)
+	}
+
 	// Validate URL format
 	parsedURL, err := url.Parse(c.TargetURL)
 	if err != nil {
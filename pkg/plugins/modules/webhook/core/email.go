@@ -0,0 +1,297 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/weibaohui/k8m/pkg/plugins/modules/webhook/models"
+	"k8s.io/klog/v2"
+)
+
+// Attachment is a file sent along with a message. Only platforms implementing
+// Deliverer (e.g. email) send attachments; HTTP platforms send the message only.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Deliverer is implemented by platforms that do not deliver messages by HTTP POST.
+type Deliverer interface {
+	// ValidateTarget checks the platform specific target URL.
+	ValidateTarget(targetURL string) error
+
+	// Deliver sends the message with optional attachments.
+	Deliver(ctx context.Context, msg, raw string, attachments []Attachment, config *WebhookConfig) (*SendResult, error)
+}
+
+// maxSubjectLen limits the subject taken from the first line of the message.
+const maxSubjectLen = 100
+
+// EmailTarget is the parsed target URL of an email receiver:
+// smtp://user@smtp.example.com:587?from=k8m@example.com&to=a@example.com,b@example.com
+// smtp uses STARTTLS when the server supports it, smtps uses implicit TLS.
+// The password is taken from the sign secret, or from the URL user info.
+type EmailTarget struct {
+	Host     string
+	Port     string
+	TLS      bool
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// ParseEmailTarget parses the target URL of an email receiver.
+func ParseEmailTarget(targetURL, secret string) (*EmailTarget, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil || (u.Scheme != "smtp" && u.Scheme != "smtps") || u.Hostname() == "" {
+		return nil, fmt.Errorf("%w: email target must be smtp://[user@]host:port?from=...&to=... or smtps://", ErrInvalidURL)
+	}
+	t := &EmailTarget{Host: u.Hostname(), Port: u.Port(), TLS: u.Scheme == "smtps", Password: secret}
+	if t.Port == "" {
+		t.Port = "25"
+		if t.TLS {
+			t.Port = "465"
+		}
+	}
+	if u.User != nil {
+		t.Username = u.User.Username()
+		if p, ok := u.User.Password(); ok && t.Password == "" {
+			t.Password = p
+		}
+	}
+
+	q := u.Query()
+	from := q.Get("from")
+	if from == "" && strings.Contains(t.Username, "@") {
+		from = t.Username
+	}
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from address %q", ErrInvalidURL, from)
+	}
+	t.From = addr.Address
+	for _, v := range q["to"] {
+		for _, to := range strings.Split(v, ",") {
+			if to = strings.TrimSpace(to); to == "" {
+				continue
+			}
+			addr, err := mail.ParseAddress(to)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid to address %q", ErrInvalidURL, to)
+			}
+			t.To = append(t.To, addr.Address)
+		}
+	}
+	if len(t.To) == 0 {
+		return nil, fmt.Errorf("%w: at least one to address is required", ErrInvalidURL)
+	}
+	return t, nil
+}
+
+// EmailAdapter implements PlatformAdapter and Deliverer for SMTP email.
+type EmailAdapter struct{}
+
+func (e *EmailAdapter) Name() string {
+	return "email"
+}
+
+func (e *EmailAdapter) GetContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (e *EmailAdapter) FormatMessage(msg, raw string, config *WebhookConfig) ([]byte, error) {
+	return []byte(msg), nil
+}
+
+func (e *EmailAdapter) SignRequest(baseURL string, body []byte, secret string) (string, error) {
+	// Email receivers use the secret as SMTP password, nothing to sign
+	return baseURL, nil
+}
+
+func (e *EmailAdapter) ValidateTarget(targetURL string) error {
+	_, err := ParseEmailTarget(targetURL, "")
+	return err
+}
+
+func (e *EmailAdapter) Deliver(ctx context.Context, msg, raw string, attachments []Attachment, config *WebhookConfig) (*SendResult, error) {
+	start := time.Now()
+	target, err := ParseEmailTarget(config.TargetURL, config.SignSecret)
+	if err == nil {
+		var data []byte
+		data, err = BuildEmail(target.From, target.To, emailSubject(msg), msg, attachments)
+		if err == nil {
+			err = sendMail(ctx, target, data)
+		}
+	}
+	saveEmailLog(config, start, len(attachments), err)
+
+	if err != nil {
+		return &SendResult{Status: "failed", RespBody: err.Error(), Error: err}, err
+	}
+	return &SendResult{Status: "success", RespBody: fmt.Sprintf("sent to %d recipient(s)", len(target.To))}, nil
+}
+
+// emailSubject uses the first line of the message as the subject.
+func emailSubject(msg string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(msg), "\n")
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return "k8m notification"
+	}
+	if utf8.RuneCountInString(subject) > maxSubjectLen {
+		subject = string([]rune(subject)[:maxSubjectLen]) + "..."
+	}
+	return subject
+}
+
+// BuildEmail builds a MIME message with a plain text body and base64 encoded attachments.
+func BuildEmail(from string, to []string, subject, body string, attachments []Attachment) ([]byte, error) {
+	var b bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&b, "%s: %s\r\n", k, v) }
+	header("From", from)
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.BEncoding.Encode("utf-8", subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if len(attachments) == 0 {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "base64")
+		b.WriteString("\r\n")
+		writeBase64(&b, []byte(body))
+		return b.Bytes(), nil
+	}
+
+	var random [12]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return nil, err
+	}
+	boundary := "k8m-" + hex.EncodeToString(random[:])
+	header("Content-Type", fmt.Sprintf(`multipart/mixed; boundary="%s"`, boundary))
+	b.WriteString("\r\n")
+
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: base64\r\n\r\n", boundary)
+	writeBase64(&b, []byte(body))
+	for _, a := range attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		name := mime.BEncoding.Encode("utf-8", a.Filename)
+		fmt.Fprintf(&b, "--%s\r\nContent-Type: %s; name=\"%s\"\r\nContent-Transfer-Encoding: base64\r\nContent-Disposition: attachment; filename=\"%s\"\r\n\r\n",
+			boundary, contentType, name, name)
+		writeBase64(&b, a.Data)
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+	return b.Bytes(), nil
+}
+
+// writeBase64 writes base64 encoded data wrapped at 76 characters per line.
+func writeBase64(b *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteString("\r\n")
+}
+
+// sendMail sends the message, using STARTTLS for smtp when offered and implicit TLS for smtps.
+func sendMail(ctx context.Context, t *EmailTarget, data []byte) error {
+	addr := net.JoinHostPort(t.Host, t.Port)
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connect smtp server %s: %w", addr, err)
+	}
+	deadline := time.Now().Add(60 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+	tlsConfig := &tls.Config{ServerName: t.Host}
+	if t.TLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	c, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok && !t.TLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if t.Username != "" {
+		// PlainAuth refuses to send credentials over an unencrypted connection except to localhost
+		if err := c.Auth(smtp.PlainAuth("", t.Username, t.Password, t.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := c.Mail(t.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, to := range t.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp rcpt %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("smtp write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return c.Quit()
+}
+
+// saveEmailLog records the delivery in the webhook log, the URL keeps no credentials.
+func saveEmailLog(config *WebhookConfig, start time.Time, attachments int, err error) {
+	target := config.TargetURL
+	if u, pErr := url.Parse(config.TargetURL); pErr == nil {
+		u.User = nil
+		target = u.String()
+	}
+	record := &models.WebhookLogRecord{
+		WebhookID:   config.WebhookId,
+		WebhookName: config.WebhookName,
+		ReceiverID:  config.Platform,
+		Method:      "SMTP",
+		URL:         target,
+		Success:     err == nil,
+		Duration:    time.Since(start).Nanoseconds(),
+		Summary:     fmt.Sprintf("[%s] SMTP %s, %d attachment(s)", config.WebhookName, target, attachments),
+		RequestTime: start,
+	}
+	if err != nil {
+		record.ErrorMessage = err.Error()
+		klog.Errorf("Email Send Failed: %s, Error: %v", record.Summary, err)
+	}
+	if sErr := record.Save(nil); sErr != nil {
+		klog.Errorf("Failed to save webhook log to database: %v", sErr)
+	}
+}
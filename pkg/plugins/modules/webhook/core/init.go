@@ -5,6 +5,7 @@ func RegisterAllAdapters() {
 	RegisterAdapter("feishu", &FeishuAdapter{})
 	RegisterAdapter("dingtalk", &DingtalkAdapter{})
 	RegisterAdapter("wechat", &WechatAdapter{})
+	RegisterAdapter("email", &EmailAdapter{})
 	RegisterAdapter("default", &DefaultAdapter{})
 	// Future adapters can be registered here
 }
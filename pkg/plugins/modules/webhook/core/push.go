@@ -13,6 +13,12 @@ var defaultClient = NewWebhookClient()
 
 // PushMsgToSingleTarget sends a message to a single webhook receiver using the new architecture.
 func PushMsgToSingleTarget(msg string, raw string, receiver *models.WebhookReceiver) *SendResult {
+	return PushMsgWithAttachmentsToSingleTarget(msg, raw, nil, receiver)
+}
+
+// PushMsgWithAttachmentsToSingleTarget sends a message with attachments to a single webhook receiver.
+// Receivers whose platform does not support attachments receive the message only.
+func PushMsgWithAttachmentsToSingleTarget(msg string, raw string, attachments []Attachment, receiver *models.WebhookReceiver) *SendResult {
 	if receiver == nil {
 		klog.Errorf("[webhook] nil receiver")
 		return &SendResult{Status: "failed", Error: ErrInvalidConfig}
@@ -20,7 +26,7 @@ func PushMsgToSingleTarget(msg string, raw string, receiver *models.WebhookRecei
 	config := NewWebhookConfig(receiver)
 
 	// Use the new WebhookClient
-	result, err := defaultClient.Send(context.Background(), msg, raw, config)
+	result, err := defaultClient.SendWithAttachments(context.Background(), msg, raw, attachments, config)
 	if err != nil {
 		klog.Errorf("[webhook] Failed to send to [%s] %s: %v",
 			receiver.Platform, receiver.TargetURL, err)
@@ -51,6 +57,11 @@ func PushMsgToAllTargets(msg string, raw string, receivers []*models.WebhookRece
 
 // PushMsgToAllTargetByIDs sends a message to multiple webhook receivers.
 func PushMsgToAllTargetByIDs(msg string, raw string, receiverIDs []string) []*SendResult {
+	return PushMsgWithAttachmentsByIDs(msg, raw, nil, receiverIDs)
+}
+
+// PushMsgWithAttachmentsByIDs sends a message with attachments to multiple webhook receivers.
+func PushMsgWithAttachmentsByIDs(msg string, raw string, attachments []Attachment, receiverIDs []string) []*SendResult {
 	var results []*SendResult
 	//根据ID 获取所有receiver
 	m := models.WebhookReceiver{}
@@ -60,7 +71,7 @@ func PushMsgToAllTargetByIDs(msg string, raw string, receiverIDs []string) []*Se
 		return results
 	}
 	for _, receiver := range receivers {
-		result := PushMsgToSingleTarget(msg, raw, receiver)
+		result := PushMsgWithAttachmentsToSingleTarget(msg, raw, attachments, receiver)
 		results = append(results, result)
 	}
 	return results
@@ -3,12 +3,20 @@ package core
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/weibaohui/k8m/pkg/plugins/modules/webhook/models"
 )
 
+// 引入 service 包会在当前目录创建数据库文件
+func TestMain(m *testing.M) {
+	code := m.Run()
+	_ = os.RemoveAll("data")
+	os.Exit(code)
+}
+
 func TestWebhookConfig(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -25,6 +25,19 @@ func (webhookAPIService) PushMsgToAllTargetByIDs(msg string, raw string, receive
 	return toAPISendResults(core.PushMsgToAllTargetByIDs(msg, raw, receiverIDs))
 }
 
+// PushMsgWithAttachmentsByIDs 中文函数注释：向指定接收者ID列表批量推送带附件的消息（统一访问层实现）。
+func (webhookAPIService) PushMsgWithAttachmentsByIDs(msg string, raw string, attachments []api.Attachment, receiverIDs []string) []*api.SendResult {
+	if !plugins.ManagerInstance().IsRunning(modules.PluginNameWebhook) {
+		klog.V(4).Infof("webhook 插件已禁用，跳过向 %d 个接收者发送消息", len(receiverIDs))
+		return nil
+	}
+	files := make([]core.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		files = append(files, core.Attachment{Filename: a.Filename, ContentType: a.ContentType, Data: a.Data})
+	}
+	return toAPISendResults(core.PushMsgWithAttachmentsByIDs(msg, raw, files, receiverIDs))
+}
+
 // GetNamesByIds 中文函数注释：根据接收者ID列表查询名称列表（统一访问层实现）。
 func (webhookAPIService) GetNamesByIds(ids []string) ([]string, error) {
 	if !plugins.ManagerInstance().IsRunning(modules.PluginNameWebhook) {
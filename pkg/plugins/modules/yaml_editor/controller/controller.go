@@ -9,13 +9,27 @@ import (
 	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
 	"github.com/weibaohui/k8m/pkg/plugins/modules/yaml_editor/models"
 	"github.com/weibaohui/k8m/pkg/response"
+	"github.com/weibaohui/k8m/pkg/service"
 	"github.com/weibaohui/kom/kom"
+	"k8s.io/klog/v2"
 )
 
 type Controller struct{}
 
 type yamlRequest struct {
-	Yaml string `json:"yaml"`
+	Yaml              string `json:"yaml"`
+	ConvertDeprecated bool   `json:"convert_deprecated"` // 将可无损转换的弃用 apiVersion 自动改为替代版本
+}
+
+// checkDeprecated 按集群版本检查清单中的弃用 API，返回需要应用的 YAML 与提示。
+// 解析失败时原样返回，由 Applier 报告具体错误
+func checkDeprecated(cluster, yamlStr string, convert bool) (string, []*service.DeprecatedAPIWarning) {
+	converted, warnings, err := service.ManifestService().CheckDeprecatedYAML(cluster, yamlStr, convert)
+	if err != nil {
+		klog.V(6).Infof("check deprecated api error: %v", err)
+		return yamlStr, nil
+	}
+	return converted, warnings
 }
 
 func (yc *Controller) UploadFile(c *response.Context) {
@@ -42,8 +56,11 @@ func (yc *Controller) UploadFile(c *response.Context) {
 		amis.WriteJsonError(c, fmt.Errorf("读取上传的文件内容错误。\n %v", err))
 		return
 	}
-	yamlStr := string(yamlBytes)
+	yamlStr, warnings := checkDeprecated(selectedCluster, string(yamlBytes), c.PostForm("convert_deprecated") == "true")
 	result := kom.Cluster(selectedCluster).WithContext(ctx).Applier().Apply(yamlStr)
+	for _, w := range warnings {
+		result = append(result, fmt.Sprintf("警告: %s %s %s", w.Kind, w.Name, w.Message))
+	}
 	amis.WriteJsonOKMsg(c, strings.Join(result, "\n"))
 }
 
@@ -60,10 +77,11 @@ func (yc *Controller) Apply(c *response.Context) {
 		amis.WriteJsonError(c, fmt.Errorf("提取yaml错误。\n %v", err))
 		return
 	}
-	yamlStr := req.Yaml
+	yamlStr, warnings := checkDeprecated(selectedCluster, req.Yaml, req.ConvertDeprecated)
 	result := kom.Cluster(selectedCluster).WithContext(ctx).Applier().Apply(yamlStr)
 	amis.WriteJsonData(c, response.H{
-		"result": result,
+		"result":   result,
+		"warnings": warnings,
 	})
 }
 
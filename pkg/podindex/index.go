@@ -0,0 +1,359 @@
+// Package podindex 维护单个集群 Pod 的反向索引：按节点、按引用的 ConfigMap/Secret/PVC、按镜像查找 Pod，
+// 由 Pod watch 事件增量更新，查询不访问 apiserver。
+package podindex
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/registry"
+	v1 "k8s.io/api/core/v1"
+)
+
+// 可反查的引用类型
+const (
+	RefConfigMap = "configmap"
+	RefSecret    = "secret"
+	RefPVC       = "pvc"
+)
+
+// PodRef 索引中保存的 Pod 摘要
+type PodRef struct {
+	Namespace    string    `json:"namespace"`
+	Name         string    `json:"name"`
+	Node         string    `json:"node,omitempty"`
+	Phase        string    `json:"phase"`
+	Ready        bool      `json:"ready"`
+	RestartCount int32     `json:"restart_count"`
+	Workload     string    `json:"workload,omitempty"` // 所属工作负载，形如 Deployment/nginx
+	Images       []string  `json:"images"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	refs map[string][]string // 引用类型 -> 对象名称
+}
+
+// WorkloadRef 使用某镜像的工作负载，无控制者的 Pod 以 Pod 自身作为工作负载
+type WorkloadRef struct {
+	Namespace  string   `json:"namespace"`
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	Images     []string `json:"images"` // 命中的镜像
+	Pods       []string `json:"pods"`
+	ReadyPods  int      `json:"ready_pods"`
+	Containers []string `json:"containers"` // 使用命中镜像的容器
+}
+
+// Index 单个集群的 Pod 反向索引，并发安全
+type Index struct {
+	mu     sync.RWMutex
+	pods   map[string]*PodRef
+	byNode map[string]map[string]struct{}
+	byRef  map[string]map[string]struct{} // 引用类型/命名空间/名称 -> Pod
+	byRepo map[string]map[string]struct{} // 镜像仓库（不含标签） -> Pod
+	// 容器名按镜像记录，用于在工作负载结果中展示
+	containers map[string]map[string][]string // Pod -> 镜像 -> 容器名
+}
+
+// New 创建空索引
+func New() *Index {
+	return &Index{
+		pods:       map[string]*PodRef{},
+		byNode:     map[string]map[string]struct{}{},
+		byRef:      map[string]map[string]struct{}{},
+		byRepo:     map[string]map[string]struct{}{},
+		containers: map[string]map[string][]string{},
+	}
+}
+
+func podKey(ns, name string) string {
+	return ns + "/" + name
+}
+
+func refKey(kind, ns, name string) string {
+	return kind + "/" + ns + "/" + name
+}
+
+// repoKey 镜像仓库，无法解析的镜像名原样使用
+func repoKey(image string) string {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return image
+	}
+	return ref.Registry + "/" + ref.Repository
+}
+
+// Len 索引中的 Pod 数量
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.pods)
+}
+
+// Update 新增或更新 Pod
+func (x *Index) Update(pod *v1.Pod) {
+	ref := newPodRef(pod)
+	key := podKey(pod.Namespace, pod.Name)
+	containers := map[string][]string{}
+	for _, c := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		containers[c.Image] = append(containers[c.Image], c.Name)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(key)
+	x.pods[key] = ref
+	x.containers[key] = containers
+	if ref.Node != "" {
+		add(x.byNode, ref.Node, key)
+	}
+	for kind, names := range ref.refs {
+		for _, name := range names {
+			add(x.byRef, refKey(kind, pod.Namespace, name), key)
+		}
+	}
+	for _, image := range ref.Images {
+		add(x.byRepo, repoKey(image), key)
+	}
+}
+
+// Delete 删除 Pod
+func (x *Index) Delete(ns, name string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(podKey(ns, name))
+}
+
+func (x *Index) remove(key string) {
+	ref, ok := x.pods[key]
+	if !ok {
+		return
+	}
+	del(x.byNode, ref.Node, key)
+	for kind, names := range ref.refs {
+		for _, name := range names {
+			del(x.byRef, refKey(kind, ref.Namespace, name), key)
+		}
+	}
+	for _, image := range ref.Images {
+		del(x.byRepo, repoKey(image), key)
+	}
+	delete(x.pods, key)
+	delete(x.containers, key)
+}
+
+func add(m map[string]map[string]struct{}, k, key string) {
+	set, ok := m[k]
+	if !ok {
+		set = map[string]struct{}{}
+		m[k] = set
+	}
+	set[key] = struct{}{}
+}
+
+func del(m map[string]map[string]struct{}, k, key string) {
+	if set, ok := m[k]; ok {
+		delete(set, key)
+		if len(set) == 0 {
+			delete(m, k)
+		}
+	}
+}
+
+// PodsOnNode 调度到节点上的 Pod
+func (x *Index) PodsOnNode(node string) []*PodRef {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.collect(x.byNode[node])
+}
+
+// PodsUsing 挂载或引用指定 ConfigMap、Secret、PVC 的 Pod。
+// ConfigMap/Secret 包括卷（含 projected 卷）、env、envFrom 引用，Secret 还包括 imagePullSecrets
+func (x *Index) PodsUsing(kind, ns, name string) []*PodRef {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.collect(x.byRef[refKey(kind, ns, name)])
+}
+
+// WorkloadsUsingImage 使用镜像的工作负载。image 指定标签或摘要时精确匹配，否则匹配该仓库的所有标签；
+// nginx 与 docker.io/library/nginx 视为同一镜像
+func (x *Index) WorkloadsUsingImage(image string) []*WorkloadRef {
+	want, err := registry.ParseReference(image)
+	if err != nil {
+		return []*WorkloadRef{}
+	}
+	exact := hasVersion(image)
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	workloads := map[string]*WorkloadRef{}
+	for _, pod := range x.collect(x.byRepo[want.Registry+"/"+want.Repository]) {
+		kind, name, _ := strings.Cut(pod.Workload, "/")
+		if pod.Workload == "" {
+			kind, name = "Pod", pod.Name
+		}
+		key := pod.Namespace + "/" + kind + "/" + name
+		matched := false
+		for _, img := range pod.Images {
+			ref, err := registry.ParseReference(img)
+			if err != nil || ref.Registry != want.Registry || ref.Repository != want.Repository {
+				continue
+			}
+			if exact && (want.Digest != "" && ref.Digest != want.Digest || want.Digest == "" && ref.Tag != want.Tag) {
+				continue
+			}
+			w, ok := workloads[key]
+			if !ok {
+				w = &WorkloadRef{Namespace: pod.Namespace, Kind: kind, Name: name}
+				workloads[key] = w
+			}
+			if !matched {
+				matched = true
+				w.Pods = append(w.Pods, pod.Name)
+				if pod.Ready {
+					w.ReadyPods++
+				}
+			}
+			w.Images = appendUnique(w.Images, img)
+			for _, c := range x.containers[podKey(pod.Namespace, pod.Name)][img] {
+				w.Containers = appendUnique(w.Containers, c)
+			}
+		}
+	}
+	result := make([]*WorkloadRef, 0, len(workloads))
+	for _, w := range workloads {
+		result = append(result, w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// hasVersion 镜像名中是否显式指定了标签或摘要
+func hasVersion(image string) bool {
+	return strings.Contains(image, "@") || strings.LastIndex(image, ":") > strings.LastIndex(image, "/")
+}
+
+// collect 按命名空间、名称排序返回 Pod，调用方持有读锁
+func (x *Index) collect(keys map[string]struct{}) []*PodRef {
+	result := make([]*PodRef, 0, len(keys))
+	for key := range keys {
+		if ref, ok := x.pods[key]; ok {
+			result = append(result, ref)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func newPodRef(pod *v1.Pod) *PodRef {
+	ref := &PodRef{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Node:      pod.Spec.NodeName,
+		Phase:     string(pod.Status.Phase),
+		Workload:  Workload(pod),
+		CreatedAt: pod.CreationTimestamp.Time,
+		refs:      map[string][]string{},
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			ref.Ready = c.Status == v1.ConditionTrue
+		}
+	}
+	for _, st := range pod.Status.ContainerStatuses {
+		ref.RestartCount += st.RestartCount
+	}
+	addRef := func(kind, name string) {
+		if name != "" {
+			ref.refs[kind] = appendUnique(ref.refs[kind], name)
+		}
+	}
+	for _, vol := range pod.Spec.Volumes {
+		switch {
+		case vol.ConfigMap != nil:
+			addRef(RefConfigMap, vol.ConfigMap.Name)
+		case vol.Secret != nil:
+			addRef(RefSecret, vol.Secret.SecretName)
+		case vol.PersistentVolumeClaim != nil:
+			addRef(RefPVC, vol.PersistentVolumeClaim.ClaimName)
+		case vol.Ephemeral != nil:
+			// 通用临时卷创建的 PVC 名称为 <Pod 名称>-<卷名称>
+			addRef(RefPVC, pod.Name+"-"+vol.Name)
+		case vol.Projected != nil:
+			for _, src := range vol.Projected.Sources {
+				if src.ConfigMap != nil {
+					addRef(RefConfigMap, src.ConfigMap.Name)
+				}
+				if src.Secret != nil {
+					addRef(RefSecret, src.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, s := range pod.Spec.ImagePullSecrets {
+		addRef(RefSecret, s.Name)
+	}
+	for _, c := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		ref.Images = appendUnique(ref.Images, c.Image)
+		for _, from := range c.EnvFrom {
+			if from.ConfigMapRef != nil {
+				addRef(RefConfigMap, from.ConfigMapRef.Name)
+			}
+			if from.SecretRef != nil {
+				addRef(RefSecret, from.SecretRef.Name)
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				addRef(RefConfigMap, env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				addRef(RefSecret, env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return ref
+}
+
+// Workload 返回 Pod 所属的工作负载，形如 Deployment/nginx。
+// 由 Deployment 创建的 ReplicaSet 按 pod-template-hash 标签还原 Deployment 名称，无控制者时返回空
+func Workload(pod *v1.Pod) string {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+		if owner.Kind == "ReplicaSet" {
+			if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+				return "Deployment/" + strings.TrimSuffix(owner.Name, "-"+hash)
+			}
+		}
+		return owner.Kind + "/" + owner.Name
+	}
+	return ""
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, item := range list {
+		if item == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
@@ -0,0 +1,116 @@
+package podindex
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPod(ns, name, node, rs, hash string, images ...string) *v1.Pod {
+	yes := true
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Labels: map[string]string{}},
+		Spec:       v1.PodSpec{NodeName: node},
+		Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, Status: v1.ConditionTrue},
+		}},
+	}
+	if rs != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: rs, Controller: &yes}}
+		pod.Labels["pod-template-hash"] = hash
+	}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: "c" + string(rune('0'+i)), Image: image})
+	}
+	return pod
+}
+
+func names(pods []*PodRef) []string {
+	var result []string
+	for _, p := range pods {
+		result = append(result, p.Namespace+"/"+p.Name)
+	}
+	return result
+}
+
+func TestIndexNodeAndRefs(t *testing.T) {
+	x := New()
+	a := testPod("default", "a", "node-1", "", "", "nginx")
+	a.Spec.Volumes = []v1.Volume{
+		{Name: "cfg", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}}},
+		{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+		{Name: "tmp", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{}}},
+		{Name: "proj", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+			{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "tls"}}},
+		}}}},
+	}
+	a.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: "regcred"}}
+	a.Spec.Containers[0].EnvFrom = []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db"}}}}
+	b := testPod("other", "b", "node-1", "", "", "nginx")
+	b.Spec.Containers[0].Env = []v1.EnvVar{{Name: "X", ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}, Key: "x"}}}}
+	x.Update(a)
+	x.Update(b)
+
+	if got := names(x.PodsOnNode("node-1")); len(got) != 2 || got[0] != "default/a" || got[1] != "other/b" {
+		t.Fatalf("PodsOnNode = %v", got)
+	}
+	cases := []struct {
+		kind, ns, name string
+		want           int
+	}{
+		{RefConfigMap, "default", "app-config", 1},
+		{RefConfigMap, "other", "app-config", 1},
+		{RefPVC, "default", "data", 1},
+		{RefPVC, "default", "a-tmp", 1},
+		{RefSecret, "default", "tls", 1},
+		{RefSecret, "default", "regcred", 1},
+		{RefSecret, "default", "db", 1},
+		{RefSecret, "other", "db", 0},
+	}
+	for _, c := range cases {
+		if got := x.PodsUsing(c.kind, c.ns, c.name); len(got) != c.want {
+			t.Errorf("PodsUsing(%s,%s,%s) = %v, want %d", c.kind, c.ns, c.name, names(got), c.want)
+		}
+	}
+
+	// 更新后旧的引用失效
+	a2 := testPod("default", "a", "node-2", "", "", "nginx")
+	x.Update(a2)
+	if got := x.PodsOnNode("node-1"); len(got) != 1 {
+		t.Fatalf("PodsOnNode after update = %v", names(got))
+	}
+	if got := x.PodsUsing(RefPVC, "default", "data"); len(got) != 0 {
+		t.Fatalf("PodsUsing after update = %v", names(got))
+	}
+	x.Delete("other", "b")
+	if x.Len() != 1 || len(x.PodsOnNode("node-1")) != 0 || len(x.byNode) != 1 {
+		t.Fatalf("unexpected index after delete: %d pods, byNode=%v", x.Len(), x.byNode)
+	}
+}
+
+func TestWorkloadsUsingImage(t *testing.T) {
+	x := New()
+	x.Update(testPod("default", "web-7d9f-abc", "n1", "web-7d9f", "7d9f", "nginx:1.25", "busybox"))
+	x.Update(testPod("default", "web-7d9f-def", "n2", "web-7d9f", "7d9f", "nginx:1.25", "busybox"))
+	x.Update(testPod("default", "legacy", "n1", "", "", "docker.io/library/nginx:1.19"))
+	x.Update(testPod("prod", "api-1", "n1", "", "", "ghcr.io/org/nginx:1.25"))
+
+	all := x.WorkloadsUsingImage("nginx")
+	if len(all) != 2 || all[0].Kind != "Deployment" || all[0].Name != "web" || all[1].Kind != "Pod" || all[1].Name != "legacy" {
+		t.Fatalf("WorkloadsUsingImage(nginx) = %+v", all)
+	}
+	if w := all[0]; len(w.Pods) != 2 || w.ReadyPods != 2 || len(w.Containers) != 1 || w.Containers[0] != "c0" {
+		t.Fatalf("unexpected workload: %+v", w)
+	}
+	exact := x.WorkloadsUsingImage("docker.io/library/nginx:1.19")
+	if len(exact) != 1 || exact[0].Name != "legacy" {
+		t.Fatalf("WorkloadsUsingImage(exact) = %+v", exact)
+	}
+	if got := x.WorkloadsUsingImage("ghcr.io/org/nginx"); len(got) != 1 || got[0].Namespace != "prod" {
+		t.Fatalf("WorkloadsUsingImage(ghcr) = %+v", got)
+	}
+	if got := x.WorkloadsUsingImage("redis"); len(got) != 0 {
+		t.Fatalf("WorkloadsUsingImage(redis) = %+v", got)
+	}
+}
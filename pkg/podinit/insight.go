@@ -0,0 +1,210 @@
+// Package podinit 分析 Pod 的 init 容器进度与原生 sidecar（restartPolicy: Always 的 init 容器），
+// 给出卡在哪一步、失败原因，与 kubectl get pod 的 Init:1/3 状态保持一致。只做计算，不访问集群。
+package podinit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// 容器类型
+const (
+	TypeInit    = "init"
+	TypeSidecar = "sidecar"
+	TypeApp     = "app"
+)
+
+// 容器状态
+const (
+	StatePending    = "pending" // 尚未创建，无状态
+	StateWaiting    = "waiting"
+	StateRunning    = "running"
+	StateTerminated = "terminated"
+)
+
+// 列表中附加的注解，供前端直接展示
+const (
+	AnnotationStatus        = "init.status"   // 与 kubectl 一致的状态，如 Init:1/3、Init:CrashLoopBackOff
+	AnnotationProgress      = "init.progress" // 已完成/总数，如 1/3
+	AnnotationCurrent       = "init.current"  // 正在执行或阻塞的 init 容器
+	AnnotationReason        = "init.reason"
+	AnnotationMessage       = "init.message"
+	AnnotationFailed        = "init.failed"
+	AnnotationSidecars      = "sidecars" // sidecar 容器名称，逗号分隔
+	AnnotationSidecarsReady = "sidecars.ready"
+)
+
+// 等待原因为这些时属于正常的初始化过程，不算失败
+var normalWaitingReasons = map[string]bool{
+	"":                  true,
+	"PodInitializing":   true,
+	"ContainerCreating": true,
+}
+
+// Container 单个容器的状态
+type Container struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Index        int    `json:"index"` // 在 initContainers 或 containers 中的序号
+	State        string `json:"state"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+	ExitCode     *int32 `json:"exit_code,omitempty"`
+	Ready        bool   `json:"ready"`
+	Started      bool   `json:"started"`
+	RestartCount int32  `json:"restart_count"`
+}
+
+// Insight Pod 的 init 与 sidecar 容器分析结果
+type Insight struct {
+	InitTotal    int  `json:"init_total"` // init 容器总数，含 sidecar
+	InitDone     int  `json:"init_done"`  // 已完成的 init 容器，sidecar 启动即视为完成
+	Initializing bool `json:"initializing"`
+	// Current 正在执行或阻塞初始化的 init 容器
+	Current       string       `json:"current,omitempty"`
+	Failed        bool         `json:"failed"`
+	Reason        string       `json:"reason,omitempty"`
+	Message       string       `json:"message,omitempty"`
+	Status        string       `json:"status,omitempty"`
+	Sidecars      []string     `json:"sidecars"`
+	SidecarsReady int          `json:"sidecars_ready"`
+	Containers    []*Container `json:"containers"`
+}
+
+// IsSidecar 判断 init 容器是否为原生 sidecar
+func IsSidecar(c *v1.Container) bool {
+	return c.RestartPolicy != nil && *c.RestartPolicy == v1.ContainerRestartPolicyAlways
+}
+
+// Analyze 分析 Pod 的容器状态。init 容器按顺序执行，第一个未完成的即为当前步骤
+func Analyze(pod *v1.Pod) *Insight {
+	in := &Insight{InitTotal: len(pod.Spec.InitContainers), Sidecars: []string{}, Containers: []*Container{}}
+	initStatus := statusByName(pod.Status.InitContainerStatuses)
+	appStatus := statusByName(pod.Status.ContainerStatuses)
+
+	var current *Container
+	for i := range pod.Spec.InitContainers {
+		spec := &pod.Spec.InitContainers[i]
+		c := newContainer(spec.Name, TypeInit, i, initStatus[spec.Name])
+		if IsSidecar(spec) {
+			c.Type = TypeSidecar
+			in.Sidecars = append(in.Sidecars, spec.Name)
+			if c.Ready {
+				in.SidecarsReady++
+			}
+		}
+		in.Containers = append(in.Containers, c)
+		if current != nil {
+			continue
+		}
+		if c.State == StateTerminated && c.ExitCode != nil && *c.ExitCode == 0 || c.Type == TypeSidecar && c.Started {
+			in.InitDone++
+			continue
+		}
+		current = c
+	}
+	for i := range pod.Spec.Containers {
+		spec := &pod.Spec.Containers[i]
+		in.Containers = append(in.Containers, newContainer(spec.Name, TypeApp, i, appStatus[spec.Name]))
+	}
+
+	// Pod 已结束或已有业务容器启动时初始化已完成，状态中 init 容器可能已被清理
+	if current == nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || appStarted(pod) {
+		return in
+	}
+	in.Initializing = true
+	in.Current = current.Name
+	in.Reason, in.Message = current.Reason, current.Message
+	in.Status = fmt.Sprintf("Init:%d/%d", in.InitDone, in.InitTotal)
+	switch current.State {
+	case StateTerminated:
+		in.Failed = true
+		if in.Reason == "" {
+			in.Reason = "ExitCode:" + strconv.Itoa(int(*current.ExitCode))
+		}
+		in.Status = "Init:" + in.Reason
+	case StateWaiting:
+		if !normalWaitingReasons[current.Reason] {
+			in.Failed = true
+			in.Status = "Init:" + current.Reason
+		}
+	}
+	return in
+}
+
+// Annotations 返回附加到列表项上的注解，没有 init 容器时为空
+func (in *Insight) Annotations() map[string]string {
+	if in.InitTotal == 0 {
+		return nil
+	}
+	m := map[string]string{
+		AnnotationProgress: fmt.Sprintf("%d/%d", in.InitDone, in.InitTotal),
+	}
+	if in.Initializing {
+		m[AnnotationStatus] = in.Status
+		m[AnnotationCurrent] = in.Current
+		m[AnnotationFailed] = strconv.FormatBool(in.Failed)
+		if in.Reason != "" {
+			m[AnnotationReason] = in.Reason
+		}
+		if in.Message != "" {
+			m[AnnotationMessage] = in.Message
+		}
+	}
+	if len(in.Sidecars) > 0 {
+		m[AnnotationSidecars] = strings.Join(in.Sidecars, ",")
+		m[AnnotationSidecarsReady] = fmt.Sprintf("%d/%d", in.SidecarsReady, len(in.Sidecars))
+	}
+	return m
+}
+
+func statusByName(list []v1.ContainerStatus) map[string]*v1.ContainerStatus {
+	m := make(map[string]*v1.ContainerStatus, len(list))
+	for i := range list {
+		m[list[i].Name] = &list[i]
+	}
+	return m
+}
+
+func newContainer(name, typ string, index int, st *v1.ContainerStatus) *Container {
+	c := &Container{Name: name, Type: typ, Index: index, State: StatePending}
+	if st == nil {
+		return c
+	}
+	c.Ready = st.Ready
+	c.Started = st.Started != nil && *st.Started
+	c.RestartCount = st.RestartCount
+	switch {
+	case st.State.Terminated != nil:
+		t := st.State.Terminated
+		exitCode := t.ExitCode
+		c.State, c.Reason, c.Message, c.ExitCode = StateTerminated, t.Reason, t.Message, &exitCode
+		if t.Reason == "" && t.Signal != 0 {
+			c.Reason = "Signal:" + strconv.Itoa(int(t.Signal))
+		}
+	case st.State.Running != nil:
+		c.State = StateRunning
+		c.Started = c.Started || st.Started == nil
+	case st.State.Waiting != nil:
+		c.State, c.Reason, c.Message = StateWaiting, st.State.Waiting.Reason, st.State.Waiting.Message
+		// 重启循环时等待原因只有 CrashLoopBackOff，补充上一次退出码便于定位
+		if t := st.LastTerminationState.Terminated; t != nil {
+			exitCode := t.ExitCode
+			c.ExitCode = &exitCode
+		}
+	}
+	return c
+}
+
+// appStarted 判断是否已有业务容器启动，kubelet 只在全部 init 容器完成后才创建业务容器
+func appStarted(pod *v1.Pod) bool {
+	for _, st := range pod.Status.ContainerStatuses {
+		if st.State.Running != nil || st.State.Terminated != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package podinit
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func testPod(initStatuses ...v1.ContainerStatus) *v1.Pod {
+	always := v1.ContainerRestartPolicyAlways
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{
+				{Name: "migrate"},
+				{Name: "proxy", RestartPolicy: &always},
+				{Name: "wait-db"},
+			},
+			Containers: []v1.Container{{Name: "app"}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodPending, InitContainerStatuses: initStatuses},
+	}
+}
+
+func terminated(name string, code int32, reason string) v1.ContainerStatus {
+	return v1.ContainerStatus{Name: name, State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: code, Reason: reason}}}
+}
+
+func running(name string, started, ready bool) v1.ContainerStatus {
+	return v1.ContainerStatus{Name: name, Started: &started, Ready: ready, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}
+}
+
+func waiting(name, reason string) v1.ContainerStatus {
+	return v1.ContainerStatus{Name: name, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}}}
+}
+
+func TestAnalyzeRunningStep(t *testing.T) {
+	in := Analyze(testPod(terminated("migrate", 0, "Completed"), running("proxy", true, true), running("wait-db", true, false)))
+	if !in.Initializing || in.Failed || in.Current != "wait-db" || in.InitDone != 2 || in.Status != "Init:2/3" {
+		t.Fatalf("insight = %+v", in)
+	}
+	if len(in.Sidecars) != 1 || in.Sidecars[0] != "proxy" || in.SidecarsReady != 1 {
+		t.Errorf("sidecars = %v, ready = %d", in.Sidecars, in.SidecarsReady)
+	}
+	if in.Containers[1].Type != TypeSidecar || in.Containers[3].Type != TypeApp || in.Containers[3].State != StatePending {
+		t.Errorf("containers = %+v", in.Containers)
+	}
+	ann := in.Annotations()
+	if ann[AnnotationProgress] != "2/3" || ann[AnnotationCurrent] != "wait-db" || ann[AnnotationSidecarsReady] != "1/1" {
+		t.Errorf("annotations = %v", ann)
+	}
+}
+
+func TestAnalyzeFailures(t *testing.T) {
+	in := Analyze(testPod(terminated("migrate", 1, "Error")))
+	if !in.Failed || in.Current != "migrate" || in.Status != "Init:Error" || in.InitDone != 0 {
+		t.Errorf("terminated = %+v", in)
+	}
+
+	in = Analyze(testPod(terminated("migrate", 2, "")))
+	if in.Status != "Init:ExitCode:2" {
+		t.Errorf("exit code status = %s", in.Status)
+	}
+
+	st := waiting("migrate", "CrashLoopBackOff")
+	st.LastTerminationState.Terminated = &v1.ContainerStateTerminated{ExitCode: 137}
+	in = Analyze(testPod(st))
+	if !in.Failed || in.Status != "Init:CrashLoopBackOff" || *in.Containers[0].ExitCode != 137 {
+		t.Errorf("crashloop = %+v", in)
+	}
+
+	// 启动探针未通过的 sidecar 阻塞后续步骤
+	in = Analyze(testPod(terminated("migrate", 0, "Completed"), running("proxy", false, false)))
+	if in.Failed || in.Current != "proxy" || in.Status != "Init:1/3" {
+		t.Errorf("sidecar not started = %+v", in)
+	}
+
+	in = Analyze(testPod(waiting("migrate", "PodInitializing")))
+	if in.Failed || in.Current != "migrate" {
+		t.Errorf("initializing = %+v", in)
+	}
+}
+
+func TestAnalyzeInitialized(t *testing.T) {
+	pod := testPod(terminated("migrate", 0, "Completed"), running("proxy", true, true), terminated("wait-db", 0, "Completed"))
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{running("app", true, true)}
+	in := Analyze(pod)
+	if in.Initializing || in.InitDone != 3 || in.Status != "" {
+		t.Fatalf("insight = %+v", in)
+	}
+	ann := in.Annotations()
+	if _, ok := ann[AnnotationStatus]; ok || ann[AnnotationSidecars] != "proxy" {
+		t.Errorf("annotations = %v", ann)
+	}
+
+	if ann := Analyze(&v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}}}}).Annotations(); ann != nil {
+		t.Errorf("pod without init containers annotations = %v", ann)
+	}
+}
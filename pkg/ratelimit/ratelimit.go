@@ -0,0 +1,109 @@
+// Package ratelimit 提供按 key 懒加载的令牌桶集合，长时间未使用的 key 会被清理，避免用户、集群数量增长后常驻内存
+package ratelimit
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// IdleTTL key 超过该时间未使用时从集合中移除，再次使用时重新创建满额的令牌桶
+const IdleTTL = 10 * time.Minute
+
+type entry struct {
+	limiter  *rate.Limiter
+	lastUsed atomic.Int64 // UnixNano
+}
+
+// Set 按 key 懒加载的令牌桶集合
+type Set struct {
+	qps       rate.Limit
+	burst     int
+	idleTTL   time.Duration
+	limiters  sync.Map // key -> *entry
+	lastSweep atomic.Int64
+	now       func() time.Time
+}
+
+// New 创建令牌桶集合，qps 为 0 时返回 nil 表示不限制；burst 为 0 时取 qps 的 2 倍
+func New(qps, burst int) *Set {
+	if qps <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = qps * 2
+	}
+	s := &Set{qps: rate.Limit(qps), burst: burst, idleTTL: IdleTTL, now: time.Now}
+	s.lastSweep.Store(s.now().UnixNano())
+	return s
+}
+
+// Reserve 为 key 预留一个令牌，集合为 nil 或 key 为空时返回 nil。
+// 调用方按 Delay 等待后执行，放弃执行时需调用 Cancel 归还令牌
+func (s *Set) Reserve(key string) *rate.Reservation {
+	if s == nil || key == "" {
+		return nil
+	}
+	now := s.now()
+	s.sweep(now)
+	v, ok := s.limiters.Load(key)
+	if !ok {
+		e := &entry{limiter: rate.NewLimiter(s.qps, s.burst)}
+		v, _ = s.limiters.LoadOrStore(key, e)
+	}
+	e := v.(*entry)
+	e.lastUsed.Store(now.UnixNano())
+	return e.limiter.ReserveN(now, 1)
+}
+
+// Len 当前保存的 key 数量
+func (s *Set) Len() int {
+	n := 0
+	s.limiters.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// sweep 每隔 idleTTL 清理一次长时间未使用的 key。
+// 空闲超过 idleTTL 的令牌桶早已回满，移除后重新创建不会改变限流效果
+func (s *Set) sweep(now time.Time) {
+	last := s.lastSweep.Load()
+	if now.UnixNano()-last < int64(s.idleTTL) || !s.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	deadline := now.Add(-s.idleTTL).UnixNano()
+	s.limiters.Range(func(k, v any) bool {
+		if v.(*entry).lastUsed.Load() < deadline {
+			s.limiters.Delete(k)
+		}
+		return true
+	})
+}
+
+// Error 超出限额的错误，RetryAfter 为建议客户端等待的时长
+type Error struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter 返回错误链中限流错误建议等待的时长，不是限流错误时返回 false
+func RetryAfter(err error) (time.Duration, bool) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return 0, false
+	}
+	return e.RetryAfter, true
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewDisabled(t *testing.T) {
+	var s *Set = New(0, 10)
+	if s != nil {
+		t.Fatal("qps 0 should disable limiting")
+	}
+	if r := s.Reserve("alice"); r != nil {
+		t.Error("nil set should not reserve")
+	}
+}
+
+func TestReserve(t *testing.T) {
+	s := New(1, 2)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	s.lastSweep.Store(now.UnixNano())
+
+	for i := 0; i < 2; i++ {
+		if r := s.Reserve("alice"); r == nil || r.DelayFrom(now) != 0 {
+			t.Fatalf("request %d within burst should not wait", i)
+		}
+	}
+	r := s.Reserve("alice")
+	if d := r.DelayFrom(now); d != time.Second {
+		t.Errorf("delay = %v, want 1s", d)
+	}
+	r.CancelAt(now)
+	if r := s.Reserve("bob"); r.DelayFrom(now) != 0 {
+		t.Error("keys should be limited separately")
+	}
+	if r := s.Reserve(""); r != nil {
+		t.Error("empty key should not be limited")
+	}
+}
+
+func TestIdleEviction(t *testing.T) {
+	s := New(1, 1)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	s.lastSweep.Store(now.UnixNano())
+
+	s.Reserve("alice")
+	s.Reserve("bob")
+	now = now.Add(IdleTTL / 2)
+	s.Reserve("bob")
+	if s.Len() != 2 {
+		t.Fatalf("len = %d, want 2", s.Len())
+	}
+
+	// alice 空闲超过 IdleTTL 被移除，bob 仍在使用
+	now = now.Add(IdleTTL/2 + time.Second)
+	s.Reserve("carol")
+	if _, ok := s.limiters.Load("alice"); ok {
+		t.Error("idle key should be evicted")
+	}
+	if _, ok := s.limiters.Load("bob"); !ok {
+		t.Error("recently used key should be kept")
+	}
+	if s.Len() != 2 {
+		t.Errorf("len = %d, want 2", s.Len())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	err := fmt.Errorf("list pods: %w", &Error{Err: errors.New("too many"), RetryAfter: 2 * time.Second})
+	if d, ok := RetryAfter(err); !ok || d != 2*time.Second {
+		t.Errorf("RetryAfter = %v, %v", d, ok)
+	}
+	if _, ok := RetryAfter(errors.New("other")); ok {
+		t.Error("plain error should not be a rate limit error")
+	}
+}
@@ -0,0 +1,442 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+)
+
+// maxBodySize manifest 与镜像配置的读取上限，防止异常仓库返回超大内容
+const maxBodySize = 8 << 20
+
+// Credential 仓库认证信息，Username 为空时匿名访问
+type Credential struct {
+	Username string
+	Password string
+}
+
+// DecodeAuth 解析 dockerconfigjson 中 base64(username:password) 形式的 auth 字段
+func DecodeAuth(auth string) (string, string) {
+	decoded, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return "", ""
+	}
+	username, password, _ := strings.Cut(string(decoded), ":")
+	return username, password
+}
+
+// Client 仓库客户端，每次检查镜像创建一个，认证令牌只在本次检查内复用
+type Client struct {
+	Credential Credential
+	Insecure   bool   // 使用 HTTP 访问仓库
+	Platform   string // 多架构镜像选择的平台，形如 linux/amd64，为空时默认 linux/amd64
+	HTTPClient *http.Client
+
+	token string
+}
+
+// NewClient 创建仓库客户端
+func NewClient(cred Credential, insecure bool) *Client {
+	return &Client{
+		Credential: cred,
+		Insecure:   insecure,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ImageInfo 镜像元数据
+type ImageInfo struct {
+	Image        string            `json:"image"`
+	Registry     string            `json:"registry"`
+	Repository   string            `json:"repository"`
+	Tag          string            `json:"tag,omitempty"`
+	Digest       string            `json:"digest,omitempty"` // manifest 摘要，即 image@sha256 中使用的值
+	MediaType    string            `json:"media_type,omitempty"`
+	Platforms    []string          `json:"platforms,omitempty"` // 多架构镜像包含的全部平台
+	OS           string            `json:"os,omitempty"`
+	Architecture string            `json:"architecture,omitempty"`
+	Created      *time.Time        `json:"created,omitempty"`
+	Size         int64             `json:"size"` // 压缩后各层与配置的总大小，字节
+	Entrypoint   []string          `json:"entrypoint,omitempty"`
+	Cmd          []string          `json:"cmd,omitempty"`
+	Env          []string          `json:"env,omitempty"`
+	WorkingDir   string            `json:"working_dir,omitempty"`
+	User         string            `json:"user,omitempty"`
+	ExposedPorts []string          `json:"exposed_ports,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Layers       []LayerInfo       `json:"layers"`
+}
+
+// LayerInfo 镜像层
+type LayerInfo struct {
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	MediaType string `json:"media_type,omitempty"`
+	CreatedBy string `json:"created_by,omitempty"` // 构建该层的指令，来自镜像配置中的 history
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	Platform  *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+		Variant      string `json:"variant,omitempty"`
+	} `json:"platform,omitempty"`
+}
+
+// manifest 同时兼容单架构 manifest 与多架构 index
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+type imageConfig struct {
+	Created      *time.Time `json:"created"`
+	OS           string     `json:"os"`
+	Architecture string     `json:"architecture"`
+	Config       struct {
+		Entrypoint   []string            `json:"Entrypoint"`
+		Cmd          []string            `json:"Cmd"`
+		Env          []string            `json:"Env"`
+		WorkingDir   string              `json:"WorkingDir"`
+		User         string              `json:"User"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+		Labels       map[string]string   `json:"Labels"`
+	} `json:"config"`
+	History []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+// Inspect 读取镜像 manifest 与配置，整理为镜像元数据
+func (c *Client) Inspect(ctx context.Context, ref *Reference) (*ImageInfo, error) {
+	m, mediaType, digest, err := c.fetchManifest(ctx, ref, ref.version())
+	if err != nil {
+		return nil, err
+	}
+	info := &ImageInfo{
+		Image:      ref.String(),
+		Registry:   ref.Registry,
+		Repository: ref.Repository,
+		Tag:        ref.Tag,
+		Digest:     digest,
+		MediaType:  mediaType,
+	}
+
+	// 多架构镜像，按平台选择具体的 manifest
+	if len(m.Manifests) > 0 {
+		platform := c.Platform
+		if platform == "" {
+			platform = "linux/amd64"
+		}
+		var selected *descriptor
+		for i, d := range m.Manifests {
+			if d.Platform == nil || d.Platform.OS == "unknown" {
+				// attestation 等非镜像条目
+				continue
+			}
+			p := d.Platform.OS + "/" + d.Platform.Architecture
+			if d.Platform.Variant != "" {
+				p += "/" + d.Platform.Variant
+			}
+			info.Platforms = append(info.Platforms, p)
+			if selected == nil && (p == platform || strings.HasPrefix(p, platform+"/")) {
+				selected = &m.Manifests[i]
+			}
+		}
+		if selected == nil {
+			return nil, fmt.Errorf("镜像 %s 不包含平台 %s，可用平台: %s", ref.String(), platform, strings.Join(info.Platforms, ", "))
+		}
+		m, _, _, err = c.fetchManifest(ctx, ref, selected.Digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if m.Config.Digest == "" {
+		return nil, fmt.Errorf("镜像 %s 的 manifest 格式不受支持: %s", ref.String(), mediaType)
+	}
+	var cfg imageConfig
+	if err := c.fetchJSON(ctx, ref, "/blobs/"+m.Config.Digest, "", &cfg); err != nil {
+		return nil, fmt.Errorf("读取镜像配置失败: %w", err)
+	}
+
+	info.OS = cfg.OS
+	info.Architecture = cfg.Architecture
+	info.Created = cfg.Created
+	info.Entrypoint = cfg.Config.Entrypoint
+	info.Cmd = cfg.Config.Cmd
+	info.Env = cfg.Config.Env
+	info.WorkingDir = cfg.Config.WorkingDir
+	info.User = cfg.Config.User
+	info.Labels = cfg.Config.Labels
+	for p := range cfg.Config.ExposedPorts {
+		info.ExposedPorts = append(info.ExposedPorts, p)
+	}
+	sort.Strings(info.ExposedPorts)
+
+	// history 中 empty_layer 为 false 的条目与 layers 一一对应
+	var createdBy []string
+	for _, h := range cfg.History {
+		if !h.EmptyLayer {
+			createdBy = append(createdBy, h.CreatedBy)
+		}
+	}
+	info.Size = m.Config.Size
+	info.Layers = []LayerInfo{}
+	for i, l := range m.Layers {
+		layer := LayerInfo{Digest: l.Digest, Size: l.Size, MediaType: l.MediaType}
+		if len(createdBy) == len(m.Layers) {
+			layer.CreatedBy = createdBy[i]
+		}
+		info.Layers = append(info.Layers, layer)
+		info.Size += l.Size
+	}
+	return info, nil
+}
+
+// Login 校验凭据能否登录仓库，与 docker login 一致访问 /v2/ 完成认证
+func (c *Client) Login(ctx context.Context, registryHost string) error {
+	ref := &Reference{Registry: NormalizeRegistry(registryHost)}
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s/v2/", scheme, ref.apiHost())
+	send := func(basic bool) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if basic && c.Credential.Username != "" {
+			req.SetBasicAuth(c.Credential.Username, c.Credential.Password)
+		}
+		return c.HTTPClient.Do(req)
+	}
+
+	resp, err := send(false)
+	if err != nil {
+		return fmt.Errorf("访问仓库 %s 失败: %w", ref.Registry, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("仓库 %s 返回异常状态(%d)，请确认地址是否正确", ref.Registry, resp.StatusCode)
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	if strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		// 令牌服务使用 Basic 认证校验用户名密码
+		if c.Credential.Username == "" {
+			return fmt.Errorf("仓库 %s 需要认证，请填写用户名和密码", ref.Registry)
+		}
+		return c.fetchToken(ctx, challenge, ref)
+	}
+	resp, err = send(true)
+	if err != nil {
+		return fmt.Errorf("访问仓库 %s 失败: %w", ref.Registry, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("仓库 %s 认证失败(%d)，请检查用户名和密码", ref.Registry, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) fetchManifest(ctx context.Context, ref *Reference, version string) (*manifest, string, string, error) {
+	accept := strings.Join([]string{mediaTypeOCIIndex, mediaTypeDockerManifestList, mediaTypeOCIManifest, mediaTypeDockerManifest}, ",")
+	resp, err := c.do(ctx, ref, "/manifests/"+version, accept)
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, "", "", err
+	}
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, "", "", fmt.Errorf("解析 manifest 失败: %w", err)
+	}
+	mediaType := m.MediaType
+	if mediaType == "" {
+		mediaType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" && strings.HasPrefix(version, "sha256:") {
+		digest = version
+	}
+	return &m, mediaType, digest, nil
+}
+
+func (c *Client) fetchJSON(ctx context.Context, ref *Reference, path, accept string, out any) error {
+	resp, err := c.do(ctx, ref, path, accept)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(out)
+}
+
+// do 发送请求，遇到 401 时按 WWW-Authenticate 完成认证后重试一次
+func (c *Client) do(ctx context.Context, ref *Reference, path, accept string) (*http.Response, error) {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s/v2/%s%s", scheme, ref.apiHost(), ref.Repository, path)
+
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		switch {
+		case c.token != "":
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		case c.Credential.Username != "":
+			req.SetBasicAuth(c.Credential.Username, c.Credential.Password)
+		}
+		return c.HTTPClient.Do(req)
+	}
+
+	resp, err := send()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			if err := c.fetchToken(ctx, challenge, ref); err != nil {
+				return nil, err
+			}
+			resp, err = send()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			return nil, fmt.Errorf("仓库 %s 认证失败，请检查凭据配置", ref.Registry)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return nil, fmt.Errorf("仓库 %s 拒绝访问(%d)，请检查凭据配置: %s", ref.Registry, resp.StatusCode, strings.TrimSpace(string(msg)))
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("镜像 %s 不存在", ref.String())
+		}
+		return nil, fmt.Errorf("访问仓库 %s 失败(%d): %s", ref.Registry, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// fetchToken 按 Bearer 认证挑战向认证服务申请拉取令牌
+func (c *Client) fetchToken(ctx context.Context, challenge string, ref *Reference) error {
+	params := parseChallenge(challenge[len("bearer "):])
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("仓库 %s 认证信息缺少 realm", ref.Registry)
+	}
+	q := url.Values{}
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	if ref.Repository != "" {
+		q.Set("scope", fmt.Sprintf("repository:%s:pull", ref.Repository))
+	}
+	tokenURL := realm
+	if strings.Contains(realm, "?") {
+		tokenURL += "&" + q.Encode()
+	} else {
+		tokenURL += "?" + q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return err
+	}
+	if c.Credential.Username != "" {
+		req.SetBasicAuth(c.Credential.Username, c.Credential.Password)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("获取仓库 %s 访问令牌失败: %w", ref.Registry, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("获取仓库 %s 访问令牌失败(%d)，请检查凭据配置", ref.Registry, resp.StatusCode)
+	}
+	var t struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&t); err != nil {
+		return fmt.Errorf("解析仓库 %s 访问令牌失败: %w", ref.Registry, err)
+	}
+	c.token = t.Token
+	if c.token == "" {
+		c.token = t.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("仓库 %s 未返回访问令牌", ref.Registry)
+	}
+	return nil
+}
+
+// parseChallenge 解析 realm="...",service="..." 形式的参数
+func parseChallenge(s string) map[string]string {
+	result := map[string]string{}
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " ,")
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = s[eq+1:]
+		var val string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				val, s = s[1:], ""
+			} else {
+				val, s = s[1:end+1], s[end+2:]
+			}
+		} else {
+			end := strings.Index(s, ",")
+			if end < 0 {
+				val, s = s, ""
+			} else {
+				val, s = s[:end], s[end:]
+			}
+		}
+		result[key] = val
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// Package registry 实现读取镜像元数据所需的 Docker Registry HTTP API V2 客户端，
+// 只读取 manifest 与镜像配置，不下载镜像层。
+package registry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DockerHub Docker Hub 在镜像名中的默认仓库地址
+const DockerHub = "docker.io"
+
+// dockerHubAPI Docker Hub 实际提供 V2 API 的地址
+const dockerHubAPI = "registry-1.docker.io"
+
+// Reference 解析后的镜像引用
+type Reference struct {
+	Registry   string `json:"registry"`         // 仓库地址，如 docker.io、harbor.example.com:5000
+	Repository string `json:"repository"`       // 仓库内路径，如 library/nginx
+	Tag        string `json:"tag,omitempty"`    // 标签，未指定摘要时默认为 latest
+	Digest     string `json:"digest,omitempty"` // 摘要，如 sha256:xxx
+}
+
+// ParseReference 按 docker 镜像命名规则解析镜像名，如 nginx、nginx:1.25、ghcr.io/org/app@sha256:xxx
+func ParseReference(image string) (*Reference, error) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return nil, fmt.Errorf("镜像名称为空")
+	}
+	ref := &Reference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.Contains(ref.Digest, ":") {
+			return nil, fmt.Errorf("镜像摘要格式错误: %s", image)
+		}
+	}
+	// 标签位于最后一个 / 之后，避免把仓库端口误认为标签
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	// 第一段包含 . 或 : 或为 localhost 时视为仓库地址，否则为 Docker Hub
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		ref.Repository = parts[1]
+	} else {
+		ref.Registry = DockerHub
+		ref.Repository = name
+	}
+	if ref.Registry == DockerHub && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	if ref.Repository == "" || strings.ToLower(ref.Repository) != ref.Repository {
+		return nil, fmt.Errorf("镜像名称格式错误: %s", image)
+	}
+	return ref, nil
+}
+
+// String 返回完整镜像名
+func (r *Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// version 用于请求 manifest 的标签或摘要，摘要优先
+func (r *Reference) version() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// apiHost 访问 V2 API 使用的地址
+func (r *Reference) apiHost() string {
+	if r.Registry == DockerHub {
+		return dockerHubAPI
+	}
+	return r.Registry
+}
+
+// NormalizeRegistry 统一仓库地址写法，去除协议与路径，Docker Hub 的各种别名统一为 docker.io，
+// 用于匹配凭据中配置的地址与 dockerconfigjson 中的 key
+func NormalizeRegistry(registry string) string {
+	registry = strings.TrimSpace(registry)
+	registry = strings.TrimPrefix(registry, "https://")
+	registry = strings.TrimPrefix(registry, "http://")
+	if i := strings.Index(registry, "/"); i >= 0 {
+		registry = registry[:i]
+	}
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return DockerHub
+	}
+	return strings.ToLower(registry)
+}
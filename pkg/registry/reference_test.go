@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		expected Reference
+	}{
+		{
+			name:     "Docker Hub official image",
+			image:    "nginx",
+			expected: Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+		},
+		{
+			name:     "Docker Hub user image with tag",
+			image:    "bitnami/redis:7.2",
+			expected: Reference{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7.2"},
+		},
+		{
+			name:     "Registry with port",
+			image:    "harbor.example.com:5000/team/app:v1",
+			expected: Reference{Registry: "harbor.example.com:5000", Repository: "team/app", Tag: "v1"},
+		},
+		{
+			name:     "Registry with port and no tag",
+			image:    "localhost:5000/app",
+			expected: Reference{Registry: "localhost:5000", Repository: "app", Tag: "latest"},
+		},
+		{
+			name:     "Digest only",
+			image:    "ghcr.io/org/app@sha256:abc",
+			expected: Reference{Registry: "ghcr.io", Repository: "org/app", Digest: "sha256:abc"},
+		},
+		{
+			name:     "Tag and digest",
+			image:    "ghcr.io/org/app:v2@sha256:abc",
+			expected: Reference{Registry: "ghcr.io", Repository: "org/app", Tag: "v2", Digest: "sha256:abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseReference(tt.image)
+			if err != nil {
+				t.Fatalf("ParseReference(%q) error: %v", tt.image, err)
+			}
+			if *ref != tt.expected {
+				t.Errorf("ParseReference(%q) = %+v, want %+v", tt.image, *ref, tt.expected)
+			}
+		})
+	}
+
+	if _, err := ParseReference("Invalid/Upper"); err == nil {
+		t.Errorf("ParseReference should reject upper case repository")
+	}
+}
+
+func TestNormalizeRegistry(t *testing.T) {
+	tests := map[string]string{
+		"https://index.docker.io/v1/": "docker.io",
+		"registry-1.docker.io":        "docker.io",
+		"Harbor.Example.com":          "harbor.example.com",
+		"http://10.0.0.1:5000":        "10.0.0.1:5000",
+	}
+	for in, want := range tests {
+		if got := NormalizeRegistry(in); got != want {
+			t.Errorf("NormalizeRegistry(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
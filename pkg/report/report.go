@@ -0,0 +1,109 @@
+// Package report 报表的表格结构与 CSV、XLSX 导出。
+// 报表内容由 service 层生成，这里只负责序列化，不依赖集群与数据库
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 导出格式
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// ContentType 导出格式对应的 MIME 类型
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Table 报表中的一张表，导出 XLSX 时对应一个工作表
+type Table struct {
+	Name    string     `json:"name"`  // 英文标识，用于文件名，如 inventory
+	Title   string     `json:"title"` // 工作表名称，如 集群资源清单
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// NewTable 创建空表
+func NewTable(name, title string, columns ...string) *Table {
+	return &Table{Name: name, Title: title, Columns: columns, Rows: [][]string{}}
+}
+
+// Add 追加一行，列数不足时补空，多余的列丢弃
+func (t *Table) Add(values ...string) {
+	row := make([]string, len(t.Columns))
+	copy(row, values)
+	t.Rows = append(t.Rows, row)
+}
+
+// Filename 导出文件名，如 inventory-prod-20240101.csv
+func Filename(name, cluster, date, format string) string {
+	parts := []string{name}
+	if cluster != "" {
+		parts = append(parts, cluster)
+	}
+	if date != "" {
+		parts = append(parts, date)
+	}
+	return sanitizeFilename(strings.Join(parts, "-")) + "." + format
+}
+
+func sanitizeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// WriteCSV 以 UTF-8 BOM 开头写出单张表，便于 Excel 正确识别中文
+func WriteCSV(w io.Writer, t *Table) error {
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		escaped := make([]string, len(row))
+		for i, v := range row {
+			escaped[i] = escapeFormula(v)
+		}
+		if err := cw.Write(escaped); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula 防止以 = + - @ 开头的值在表格软件中被当作公式执行，数字保持原样
+func escapeFormula(v string) string {
+	if v == "" || !strings.ContainsRune("=+-@\t\r", rune(v[0])) || isNumber(v) {
+		return v
+	}
+	return "'" + v
+}
+
+// Write 按格式写出报表，CSV 只支持单张表
+func Write(w io.Writer, format string, tables ...*Table) error {
+	switch format {
+	case FormatCSV:
+		if len(tables) != 1 {
+			return fmt.Errorf("CSV 只能导出一张表，当前 %d 张", len(tables))
+		}
+		return WriteCSV(w, tables[0])
+	case FormatXLSX:
+		return WriteXLSX(w, tables...)
+	}
+	return fmt.Errorf("不支持的导出格式: %s，仅支持 csv、xlsx", format)
+}
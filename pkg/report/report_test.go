@@ -0,0 +1,108 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	tbl := NewTable("images", "镜像清单", "命名空间", "镜像", "副本")
+	tbl.Add("default", "nginx:1.25", "3")
+	tbl.Add("=cmd|' /C calc'!A0", "-1")
+
+	var b bytes.Buffer
+	if err := Write(&b, FormatCSV, tbl); err != nil {
+		t.Fatal(err)
+	}
+	want := "\uFEFF命名空间,镜像,副本\ndefault,nginx:1.25,3\n'=cmd|' /C calc'!A0,-1,\n"
+	if b.String() != want {
+		t.Fatalf("unexpected csv:\n%q\nwant\n%q", b.String(), want)
+	}
+	if err := Write(&b, FormatCSV, tbl, tbl); err == nil {
+		t.Fatal("CSV 导出多张表应报错")
+	}
+	if err := Write(&b, "pdf", tbl); err == nil {
+		t.Fatal("不支持的格式应报错")
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	a := NewTable("inventory", "集群资源清单", "类型", "名称", "数量")
+	a.Add("Node", "n1 <master>", "007")
+	a.Add("Pod", "p&1", "12.5")
+	b := NewTable("quota", "集群资源清单", "名称")
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatXLSX, a, b); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("缺少 %s", name)
+		}
+	}
+	if wb := files["xl/workbook.xml"]; !strings.Contains(wb, `name="集群资源清单"`) || !strings.Contains(wb, `name="集群资源清单(2)"`) {
+		t.Fatalf("工作表名称应去重: %s", wb)
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">类型</t></is></c>`,
+		`<t xml:space="preserve">n1 &lt;master&gt;</t>`,
+		`<c r="C2" t="inlineStr"><is><t xml:space="preserve">007</t></is></c>`,
+		`<c r="C3"><v>12.5</v></c>`,
+		`<autoFilter ref="A1:C3"/>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("工作表缺少 %s:\n%s", want, sheet)
+		}
+	}
+	if err := WriteXLSX(&buf); err == nil {
+		t.Fatal("没有表时应报错")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := ColumnName(i); got != want {
+			t.Errorf("ColumnName(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestSheetNames(t *testing.T) {
+	long := strings.Repeat("报", 40)
+	names := sheetNames([]*Table{{Title: "a/b"}, {Title: ""}, {Title: long}, {Title: long}})
+	if names[0] != "a_b" || names[1] != "Sheet2" {
+		t.Fatalf("unexpected names: %v", names)
+	}
+	if n := len([]rune(names[2])); n != maxSheetNameLen {
+		t.Fatalf("名称应截断到 %d 个字符: %d", maxSheetNameLen, n)
+	}
+	if names[3] == names[2] || len([]rune(names[3])) > maxSheetNameLen || !strings.HasSuffix(names[3], "(2)") {
+		t.Fatalf("截断后重名应追加序号: %s", names[3])
+	}
+}
+
+func TestFilename(t *testing.T) {
+	if got := Filename("rbac", "prod/a:b", "20240101", FormatXLSX); got != "rbac-prod_a_b-20240101.xlsx" {
+		t.Fatalf("unexpected filename: %s", got)
+	}
+}
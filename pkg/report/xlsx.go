@@ -0,0 +1,169 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSheetNameLen Excel 工作表名称长度上限
+const maxSheetNameLen = 31
+
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]{0,14})(\.[0-9]+)?$`)
+
+// isNumber 是否按数值写入单元格，排除前导零等会丢失原文的值
+func isNumber(v string) bool {
+	return numberPattern.MatchString(v)
+}
+
+// WriteXLSX 写出 XLSX 工作簿，每张表一个工作表，首行为加粗的列名。
+// 只生成最小的 SpreadsheetML 结构，单元格使用内联字符串，不依赖第三方库
+func WriteXLSX(w io.Writer, tables ...*Table) error {
+	if len(tables) == 0 {
+		return fmt.Errorf("没有可导出的表")
+	}
+	zw := zip.NewWriter(w)
+	names := sheetNames(tables)
+
+	var sheets, rels, overrides strings.Builder
+	for i, name := range names {
+		id := i + 1
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), id, id)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, id, id)
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, id)
+	}
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(names)+1)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+		{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`},
+	}
+	for _, p := range parts {
+		if err := writeZipFile(zw, p.name, []byte(xml.Header+p.body)); err != nil {
+			return err
+		}
+	}
+	for i, t := range tables {
+		if err := writeZipFile(zw, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(t)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// worksheet 生成单个工作表，首行冻结并启用筛选
+func worksheet(t *Table) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+	writeRow(&b, 1, t.Columns, true)
+	for i, row := range t.Rows {
+		writeRow(&b, i+2, row, false)
+	}
+	b.WriteString(`</sheetData>`)
+	if len(t.Columns) > 0 {
+		fmt.Fprintf(&b, `<autoFilter ref="A1:%s%d"/>`, ColumnName(len(t.Columns)-1), len(t.Rows)+1)
+	}
+	b.WriteString(`</worksheet>`)
+	return b.Bytes()
+}
+
+func writeRow(b *bytes.Buffer, r int, values []string, header bool) {
+	fmt.Fprintf(b, `<row r="%d">`, r)
+	for i, v := range values {
+		ref := ColumnName(i) + strconv.Itoa(r)
+		switch {
+		case header:
+			fmt.Fprintf(b, `<c r="%s" s="1" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(v))
+		case v == "":
+		case isNumber(v):
+			fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, v)
+		default:
+			fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(v))
+		}
+	}
+	b.WriteString(`</row>`)
+}
+
+// ColumnName 列序号（从 0 开始）对应的列名，如 0 为 A、26 为 AA
+func ColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// sheetNames 生成合法且不重复的工作表名称
+func sheetNames(tables []*Table) []string {
+	used := map[string]bool{}
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		base := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '_'
+			}
+			return r
+		}, strings.TrimSpace(t.Title))
+		if base == "" {
+			base = "Sheet" + strconv.Itoa(i+1)
+		}
+		base = truncateRunes(base, maxSheetNameLen)
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := "(" + strconv.Itoa(n) + ")"
+			name = truncateRunes(base, maxSheetNameLen-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package response
+
+import "context"
+
+// RequestIDHeader 请求ID的HTTP头，客户端传入时沿用，否则由服务端生成
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID 将请求ID写入上下文
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID 从上下文中获取请求ID，不存在时返回空字符串
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,380 @@
+// Package selector 校验界面查询构造器提交的标签选择器与字段选择器，
+// 给出规范化写法，语法错误附带出错位置，便于在输入框中定位
+package selector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Problem 选择器中的一处错误，Position 为从 0 开始的字符位置
+type Problem struct {
+	Position int    `json:"position"`
+	Message  string `json:"message"`
+}
+
+// Result 校验结果，存在问题时 Normalized 为空
+type Result struct {
+	Normalized string    `json:"normalized"`
+	Problems   []Problem `json:"problems"`
+}
+
+// Valid 是否通过校验
+func (r *Result) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokComma
+	tokOpen
+	tokClose
+	tokOp
+	tokEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 字节偏移
+}
+
+// 标识符之外具有特殊含义的字符
+const specialChars = ",()=!<>"
+
+func tokenize(expr string) []token {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == ',':
+			tokens = append(tokens, token{tokComma, ",", i})
+			i++
+		case ch == '(':
+			tokens = append(tokens, token{tokOpen, "(", i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, token{tokClose, ")", i})
+			i++
+		case strings.ContainsRune("=!<>", rune(ch)):
+			op := string(ch)
+			if i+1 < len(expr) && expr[i+1] == '=' && (ch == '=' || ch == '!') {
+				op += "="
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(specialChars+" \t\n\r", rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, expr[start:i], start})
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(expr)})
+}
+
+type labelParser struct {
+	expr     string
+	tokens   []token
+	cur      int
+	problems []Problem
+}
+
+func (p *labelParser) peek() token {
+	return p.tokens[p.cur]
+}
+
+func (p *labelParser) next() token {
+	t := p.tokens[p.cur]
+	if t.kind != tokEOF {
+		p.cur++
+	}
+	return t
+}
+
+func (p *labelParser) fail(pos int, format string, args ...any) {
+	p.problems = append(p.problems, Problem{
+		Position: utf8.RuneCountInString(p.expr[:pos]),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// describe 用于错误信息中描述遇到的记号
+func describe(t token) string {
+	if t.kind == tokEOF {
+		return "表达式结尾"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// ValidateLabel 校验标签选择器，支持 =、==、!=、in、notin、exists、!key、>、< 全部写法
+func ValidateLabel(expr string) *Result {
+	p := &labelParser{expr: expr, tokens: tokenize(expr)}
+	var reqs labels.Requirements
+	if p.peek().kind != tokEOF {
+		for {
+			req, ok := p.parseRequirement()
+			if !ok {
+				break
+			}
+			reqs = append(reqs, *req)
+			t := p.next()
+			if t.kind == tokEOF {
+				break
+			}
+			if t.kind != tokComma {
+				p.fail(t.pos, "期望逗号，实际为 %s", describe(t))
+				break
+			}
+		}
+	}
+	if len(p.problems) > 0 {
+		return &Result{Problems: p.problems}
+	}
+	// 兜底：与 apiserver 使用的解析器结果保持一致
+	if _, err := labels.Parse(expr); err != nil {
+		return &Result{Problems: []Problem{{Message: err.Error()}}}
+	}
+	return &Result{Normalized: labels.NewSelector().Add(reqs...).String()}
+}
+
+func (p *labelParser) parseRequirement() (*labels.Requirement, bool) {
+	t := p.next()
+	if t.kind == tokOp && t.text == "!" {
+		key := p.next()
+		if key.kind != tokIdent {
+			p.fail(key.pos, "! 之后期望标签键，实际为 %s", describe(key))
+			return nil, false
+		}
+		return p.newRequirement(key, selection.DoesNotExist, nil)
+	}
+	if t.kind != tokIdent {
+		p.fail(t.pos, "期望标签键，实际为 %s", describe(t))
+		return nil, false
+	}
+	key := t
+	op := p.peek()
+	switch {
+	case op.kind == tokComma || op.kind == tokEOF:
+		return p.newRequirement(key, selection.Exists, nil)
+	case op.kind == tokOp:
+		p.next()
+		operators := map[string]selection.Operator{
+			"=":  selection.Equals,
+			"==": selection.Equals,
+			"!=": selection.NotEquals,
+			">":  selection.GreaterThan,
+			"<":  selection.LessThan,
+		}
+		operator, ok := operators[op.text]
+		if !ok {
+			p.fail(op.pos, "不支持的操作符 %q", op.text)
+			return nil, false
+		}
+		value := p.peek()
+		if value.kind == tokIdent {
+			return p.newRequirement(key, operator, []token{p.next()})
+		}
+		// 等于、不等于允许空值，如 key=
+		if operator == selection.GreaterThan || operator == selection.LessThan {
+			p.fail(value.pos, "%s 之后期望取值，实际为 %s", op.text, describe(value))
+			return nil, false
+		}
+		return p.newRequirement(key, operator, []token{{tokIdent, "", value.pos}})
+	case op.kind == tokIdent && (op.text == "in" || op.text == "notin"):
+		p.next()
+		values, ok := p.parseValueSet()
+		if !ok {
+			return nil, false
+		}
+		if op.text == "in" {
+			return p.newRequirement(key, selection.In, values)
+		}
+		return p.newRequirement(key, selection.NotIn, values)
+	}
+	p.fail(op.pos, "期望操作符 =、==、!=、in、notin、>、< 或逗号，实际为 %s", describe(op))
+	return nil, false
+}
+
+// parseValueSet 解析 in、notin 之后的 (v1, v2)，允许空值
+func (p *labelParser) parseValueSet() ([]token, bool) {
+	open := p.next()
+	if open.kind != tokOpen {
+		p.fail(open.pos, "期望左括号，实际为 %s", describe(open))
+		return nil, false
+	}
+	var values []token
+	expectValue := true
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokClose:
+			if expectValue {
+				values = append(values, token{tokIdent, "", t.pos})
+			}
+			return values, true
+		case t.kind == tokComma:
+			if expectValue {
+				values = append(values, token{tokIdent, "", t.pos})
+			}
+			expectValue = true
+		case t.kind == tokIdent && expectValue:
+			values = append(values, t)
+			expectValue = false
+		case t.kind == tokEOF:
+			p.fail(open.pos, "括号未闭合")
+			return nil, false
+		default:
+			p.fail(t.pos, "期望取值、逗号或右括号，实际为 %s", describe(t))
+			return nil, false
+		}
+	}
+}
+
+// newRequirement 分别校验键与各个取值，错误定位到对应记号
+func (p *labelParser) newRequirement(key token, op selection.Operator, values []token) (*labels.Requirement, bool) {
+	if errs := validation.IsQualifiedName(key.text); len(errs) > 0 {
+		p.fail(key.pos, "标签键 %q 无效: %s", key.text, strings.Join(errs, "; "))
+		return nil, false
+	}
+	vals := make([]string, 0, len(values))
+	for _, v := range values {
+		if op == selection.GreaterThan || op == selection.LessThan {
+			if _, err := strconv.ParseInt(v.text, 10, 64); err != nil {
+				p.fail(v.pos, "%s 的取值必须为整数，实际为 %q", op, v.text)
+				return nil, false
+			}
+		} else if errs := validation.IsValidLabelValue(v.text); len(errs) > 0 {
+			p.fail(v.pos, "标签值 %q 无效: %s", v.text, strings.Join(errs, "; "))
+			return nil, false
+		}
+		vals = append(vals, v.text)
+	}
+	req, err := labels.NewRequirement(key.text, op, vals)
+	if err != nil {
+		p.fail(key.pos, "%v", err)
+		return nil, false
+	}
+	return req, true
+}
+
+// ValidateField 校验字段选择器，支持 =、==、!=，值中的逗号、等号需用反斜杠转义。
+// 规范化结果合并 == 为 =，去重并按字典序排列
+func ValidateField(expr string) *Result {
+	var problems []Problem
+	fail := func(pos int, format string, args ...any) {
+		problems = append(problems, Problem{
+			Position: utf8.RuneCountInString(expr[:pos]),
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	if strings.TrimSpace(expr) == "" {
+		return &Result{}
+	}
+
+	seen := map[string]bool{}
+	var terms []string
+	for _, part := range splitTerms(expr) {
+		term := part.text
+		if strings.TrimSpace(term) == "" {
+			fail(part.pos, "条件不能为空")
+			continue
+		}
+		opIdx, op := findOperator(term)
+		if opIdx < 0 {
+			fail(part.pos, "条件 %q 缺少操作符 =、==、!=", term)
+			continue
+		}
+		key := strings.TrimSpace(term[:opIdx])
+		if key == "" {
+			fail(part.pos+opIdx, "操作符前缺少字段名")
+			continue
+		}
+		rawValue := term[opIdx+len(op):]
+		value, err := fields.UnescapeValue(rawValue)
+		if err != nil {
+			fail(part.pos+opIdx+len(op)+escapeErrorPos(rawValue), "取值转义无效: %v", err)
+			continue
+		}
+		if op == "==" {
+			op = "="
+		}
+		normalized := key + op + fields.EscapeValue(value)
+		if !seen[normalized] {
+			seen[normalized] = true
+			terms = append(terms, normalized)
+		}
+	}
+	if len(problems) > 0 {
+		return &Result{Problems: problems}
+	}
+	if _, err := fields.ParseSelector(expr); err != nil {
+		return &Result{Problems: []Problem{{Message: err.Error()}}}
+	}
+	sort.Strings(terms)
+	return &Result{Normalized: strings.Join(terms, ",")}
+}
+
+type fieldTerm struct {
+	text string
+	pos  int
+}
+
+// splitTerms 按未转义的逗号拆分条件，保留每个条件的起始偏移
+func splitTerms(expr string) []fieldTerm {
+	var terms []fieldTerm
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case ',':
+			terms = append(terms, fieldTerm{expr[start:i], start})
+			start = i + 1
+		}
+	}
+	return append(terms, fieldTerm{expr[start:], start})
+}
+
+// findOperator 查找第一个未转义的操作符，返回其偏移，未找到时返回 -1
+func findOperator(term string) (int, string) {
+	for i := 0; i < len(term); i++ {
+		if term[i] == '\\' {
+			i++
+			continue
+		}
+		for _, op := range []string{"!=", "==", "="} {
+			if strings.HasPrefix(term[i:], op) {
+				return i, op
+			}
+		}
+	}
+	return -1, ""
+}
+
+// escapeErrorPos 返回首个无效转义的偏移
+func escapeErrorPos(value string) int {
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			continue
+		}
+		if i+1 >= len(value) || !strings.ContainsRune(`\,=`, rune(value[i+1])) {
+			return i
+		}
+		i++
+	}
+	return 0
+}
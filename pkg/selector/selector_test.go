@@ -0,0 +1,109 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLabelNormalized(t *testing.T) {
+	cases := map[string]string{
+		"":                                  "",
+		"app==web":                          "app=web",
+		"tier notin (b, a), app = web":      "app=web,tier notin (a,b)",
+		"!canary,env in (prod,staging)":     "!canary,env in (prod,staging)",
+		"replicas > 2,version":              "replicas>2,version",
+		"app.kubernetes.io/name!=":          "app.kubernetes.io/name!=",
+		"env in ()":                         "env in ()",
+		"example.com/team=a-b_c.d, x < 10 ": "example.com/team=a-b_c.d,x<10",
+	}
+	for expr, want := range cases {
+		r := ValidateLabel(expr)
+		if !r.Valid() {
+			t.Errorf("ValidateLabel(%q) problems: %+v", expr, r.Problems)
+			continue
+		}
+		if r.Normalized != want {
+			t.Errorf("ValidateLabel(%q) = %q, want %q", expr, r.Normalized, want)
+		}
+	}
+}
+
+func TestValidateLabelProblems(t *testing.T) {
+	cases := []struct {
+		expr     string
+		position int
+		contains string
+	}{
+		{"app=web env=prod", 8, "期望逗号"},
+		{"app in (a,b", 4 + 3, "括号未闭合"},
+		{"app in a", 7, "左括号"},
+		{"app=we b", 7, "期望逗号"},
+		{"app=web,", 8, "期望标签键"},
+		{"app=-web", 4, "标签值"},
+		{"-app=web", 0, "标签键"},
+		{"x>abc", 2, "整数"},
+		{"x>", 2, "期望取值"},
+		{"app ! web", 4, "不支持的操作符"},
+		{"应用=web,a=b", 0, "标签键"},
+		{"a=b,应用=web", 4, "标签键"},
+		{"app in (a b)", 10, "期望取值、逗号或右括号"},
+	}
+	for _, c := range cases {
+		r := ValidateLabel(c.expr)
+		if r.Valid() {
+			t.Errorf("ValidateLabel(%q) should be invalid", c.expr)
+			continue
+		}
+		p := r.Problems[0]
+		if p.Position != c.position || !strings.Contains(p.Message, c.contains) {
+			t.Errorf("ValidateLabel(%q) = %+v, want position %d containing %q", c.expr, p, c.position, c.contains)
+		}
+		if r.Normalized != "" {
+			t.Errorf("ValidateLabel(%q) normalized should be empty", c.expr)
+		}
+	}
+}
+
+func TestValidateField(t *testing.T) {
+	cases := map[string]string{
+		"":                                        "",
+		"status.phase==Running":                   "status.phase=Running",
+		"spec.nodeName!=n1,metadata.name=a":       "metadata.name=a,spec.nodeName!=n1",
+		"metadata.name=a,metadata.name==a":        "metadata.name=a",
+		`metadata.annotations.x=a\,b\=c`:          `metadata.annotations.x=a\,b\=c`,
+		"metadata.namespace=,status.phase=Failed": "metadata.namespace=,status.phase=Failed",
+	}
+	for expr, want := range cases {
+		r := ValidateField(expr)
+		if !r.Valid() {
+			t.Errorf("ValidateField(%q) problems: %+v", expr, r.Problems)
+			continue
+		}
+		if r.Normalized != want {
+			t.Errorf("ValidateField(%q) = %q, want %q", expr, r.Normalized, want)
+		}
+	}
+
+	problems := []struct {
+		expr     string
+		position int
+		contains string
+	}{
+		{"status.phase", 0, "缺少操作符"},
+		{"a=b,,c=d", 4, "不能为空"},
+		{"a=b,=c", 4, "字段名"},
+		{`a=b\x`, 3, "转义"},
+		{"名称=b,x", 5, "缺少操作符"},
+	}
+	for _, c := range problems {
+		r := ValidateField(c.expr)
+		if r.Valid() {
+			t.Errorf("ValidateField(%q) should be invalid", c.expr)
+			continue
+		}
+		p := r.Problems[0]
+		if p.Position != c.position || !strings.Contains(p.Message, c.contains) {
+			t.Errorf("ValidateField(%q) = %+v, want position %d containing %q", c.expr, p, c.position, c.contains)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/accessrule"
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/models"
+	"k8s.io/klog/v2"
+)
+
+// accessRuleReload 已启用规则的缓存时间
+const accessRuleReload = 30 * time.Second
+
+type accessRuleService struct {
+	lock     sync.RWMutex
+	loadedAt time.Time
+	rules    []*compiledAccessRule
+}
+
+type compiledAccessRule struct {
+	rule     *models.AccessRule
+	compiled *accessrule.Rule
+}
+
+// AccessRequest 待校验的请求来源
+type AccessRequest struct {
+	Username   string
+	Cluster    string
+	IP         string
+	Country    string
+	Operations []string
+	Time       time.Time
+}
+
+// Invalidate 规则变更后清空缓存
+func (s *accessRuleService) Invalidate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.loadedAt = time.Time{}
+	s.rules = nil
+}
+
+// Compile 解析并校验规则配置
+func (s *accessRuleService) Compile(rule *models.AccessRule) (*accessrule.Rule, error) {
+	return accessrule.Compile(rule.Name, accessrule.Spec{
+		Roles:      rule.Roles,
+		Operations: rule.Operations,
+		CIDRs:      rule.CIDRs,
+		Countries:  rule.Countries,
+		Days:       rule.Days,
+		StartTime:  rule.StartTime,
+		EndTime:    rule.EndTime,
+		Timezone:   rule.Timezone,
+	})
+}
+
+func (s *accessRuleService) enabled() []*compiledAccessRule {
+	s.lock.RLock()
+	if time.Since(s.loadedAt) < accessRuleReload {
+		defer s.lock.RUnlock()
+		return s.rules
+	}
+	s.lock.RUnlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if time.Since(s.loadedAt) < accessRuleReload {
+		return s.rules
+	}
+	var list []*models.AccessRule
+	if err := dao.DB().Where("enabled = ?", true).Order("id").Find(&list).Error; err != nil {
+		klog.Errorf("加载访问规则失败: %v", err)
+		return s.rules
+	}
+	rules := make([]*compiledAccessRule, 0, len(list))
+	for _, r := range list {
+		compiled, err := s.Compile(r)
+		if err != nil {
+			klog.Errorf("访问规则[%s]配置无效，已跳过: %v", r.Name, err)
+			continue
+		}
+		rules = append(rules, &compiledAccessRule{rule: r, compiled: compiled})
+	}
+	s.rules = rules
+	s.loadedAt = time.Now()
+	return s.rules
+}
+
+// Check 校验请求是否满足全部适用的已启用规则
+func (s *accessRuleService) Check(req *AccessRequest) error {
+	return s.check(req, s.enabled())
+}
+
+// CheckRule 校验请求是否满足指定规则，规则不适用时返回 nil。用于保存前确认不会阻止管理员自身的访问
+func (s *accessRuleService) CheckRule(rule *models.AccessRule, req *AccessRequest) error {
+	compiled, err := s.Compile(rule)
+	if err != nil {
+		return err
+	}
+	return s.check(req, []*compiledAccessRule{{rule: rule, compiled: compiled}})
+}
+
+func (s *accessRuleService) check(req *AccessRequest, rules []*compiledAccessRule) error {
+	// 临时管理员不受访问规则限制，用于规则配置错误导致管理员无法访问时恢复
+	cfg := flag.Init()
+	if req.Username == "" || cfg.EnableTempAdmin && req.Username == cfg.AdminUserName {
+		return nil
+	}
+	var candidates []*compiledAccessRule
+	for _, cr := range rules {
+		if cr.compiled.MatchOperations(req.Operations) && admission.MatchList(cr.rule.Clusters, req.Cluster) {
+			candidates = append(candidates, cr)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	target := &accessrule.Request{
+		Roles:      s.roles(req.Username, req.Cluster),
+		Operations: req.Operations,
+		IP:         req.IP,
+		Country:    req.Country,
+		Time:       req.Time,
+	}
+	for _, cr := range candidates {
+		if !cr.compiled.MatchRoles(target.Roles) {
+			continue
+		}
+		if err := cr.compiled.Check(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roles 用户的平台角色与其在当前集群的角色
+func (s *accessRuleService) roles(username, cluster string) []string {
+	roles, err := UserService().GetRolesByUserName(username)
+	if err != nil {
+		klog.V(6).Infof("获取用户[%s]角色失败: %v", username, err)
+	}
+	if cluster == "" {
+		return roles
+	}
+	clusterRoles, err := UserService().GetClusters(username)
+	if err != nil {
+		klog.V(6).Infof("获取用户[%s]集群角色失败: %v", username, err)
+	}
+	for _, cr := range clusterRoles {
+		if cr.Cluster == cluster {
+			roles = append(roles, cr.Role)
+		}
+	}
+	return roles
+}
+
+// RecordViolation 将被访问规则拒绝的请求写入操作日志
+func (s *accessRuleService) RecordViolation(req *AccessRequest, method, path string, err error) {
+	OperationLogService().Add(&models.OperationLog{
+		Action:       "access-denied",
+		Cluster:      req.Cluster,
+		UserName:     req.Username,
+		Role:         strings.Join(s.roles(req.Username, req.Cluster), ","),
+		ActionResult: err.Error(),
+	}, map[string]string{
+		"ip":         req.IP,
+		"country":    req.Country,
+		"operations": strings.Join(req.Operations, ","),
+		"request":    method + " " + path,
+	})
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/expr-lang/expr/vm"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/k8m/pkg/models"
+	"k8s.io/klog/v2"
+)
+
+// admissionPolicyReload 已启用策略的缓存时间，多实例部署时其他实例的修改最迟在该时间后生效
+const admissionPolicyReload = 30 * time.Second
+
+type admissionPolicyService struct {
+	lock     sync.RWMutex
+	loadedAt time.Time
+	policies []*compiledPolicy
+}
+
+type compiledPolicy struct {
+	policy  *models.AdmissionPolicy
+	program *vm.Program
+}
+
+// Invalidate 策略变更后清空缓存，下次评估时重新加载
+func (s *admissionPolicyService) Invalidate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.loadedAt = time.Time{}
+	s.policies = nil
+}
+
+// enabled 返回已启用且编译通过的策略
+func (s *admissionPolicyService) enabled() []*compiledPolicy {
+	s.lock.RLock()
+	if time.Since(s.loadedAt) < admissionPolicyReload {
+		defer s.lock.RUnlock()
+		return s.policies
+	}
+	s.lock.RUnlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if time.Since(s.loadedAt) < admissionPolicyReload {
+		return s.policies
+	}
+	var list []*models.AdmissionPolicy
+	if err := dao.DB().Where("enabled = ?", true).Order("id").Find(&list).Error; err != nil {
+		// 读取失败时沿用上一次的策略，避免数据库抖动导致策略失效
+		klog.Errorf("加载准入策略失败: %v", err)
+		return s.policies
+	}
+	policies := make([]*compiledPolicy, 0, len(list))
+	for _, p := range list {
+		program, err := admission.Compile(p.Expression)
+		if err != nil {
+			klog.Errorf("准入策略[%s]编译失败，已跳过: %v", p.Name, err)
+			continue
+		}
+		policies = append(policies, &compiledPolicy{policy: p, program: program})
+	}
+	s.policies = policies
+	s.loadedAt = time.Now()
+	return s.policies
+}
+
+// Evaluate 依次评估适用于该请求的策略。命中 deny 策略时返回错误以阻止操作，命中 warn 策略时返回警告信息。
+// 表达式运行出错（如字段类型不符）视为未命中，仅记录日志。
+func (s *admissionPolicyService) Evaluate(req *admission.Request) ([]string, error) {
+	var warnings []string
+	for _, cp := range s.enabled() {
+		p := cp.policy
+		if !admission.MatchList(p.Operations, req.Operation) ||
+			!admission.MatchList(p.Kinds, req.Kind) ||
+			!admission.MatchList(p.Clusters, req.Cluster) ||
+			!admission.MatchList(p.Namespaces, req.Namespace) {
+			continue
+		}
+		matched, err := admission.Eval(cp.program, req)
+		if err != nil {
+			klog.V(4).Infof("准入策略[%s]评估 %s %s/%s 出错: %v", p.Name, req.Kind, req.Namespace, req.Name, err)
+			continue
+		}
+		if !matched {
+			continue
+		}
+		msg := p.Message
+		if msg == "" {
+			msg = p.Name
+		}
+		if p.Action == admission.ActionDeny {
+			return warnings, fmt.Errorf("策略[%s]禁止该操作: %s", p.Name, msg)
+		}
+		warnings = append(warnings, fmt.Sprintf("[%s] %s", p.Name, msg))
+	}
+	return warnings, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/kom/kom"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// AdmissionPreview 单个资源的准入预演结果
+type AdmissionPreview struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Namespace  string                `json:"namespace,omitempty"`
+	Name       string                `json:"name"`
+	Action     string                `json:"action"` // create 或 update
+	Allowed    bool                  `json:"allowed"`
+	Reason     string                `json:"reason,omitempty"` // API Server 返回的原因，如 Forbidden、Invalid
+	Code       int32                 `json:"code,omitempty"`
+	Message    string                `json:"message,omitempty"`    // 原始错误信息
+	Violations []admission.Violation `json:"violations,omitempty"` // 结构化的拒绝原因
+	Warnings   []string              `json:"warnings,omitempty"`   // 准入警告，如 Gatekeeper warn、Kyverno audit 模式
+}
+
+// warningCollector 收集 API Server 通过 Warning 响应头返回的准入警告
+type warningCollector struct {
+	lock     sync.Mutex
+	warnings []string
+}
+
+func (w *warningCollector) HandleWarningHeader(code int, agent string, text string) {
+	if code != 299 || text == "" {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.warnings = append(w.warnings, text)
+}
+
+// PreviewAdmission 以 dryRun=All 方式提交资源，经过全部准入 Webhook 与策略但不落库，返回是否允许及拒绝原因、警告
+func (m *manifestService) PreviewAdmission(ctx context.Context, cluster string, obj *unstructured.Unstructured) (*AdmissionPreview, error) {
+	k := kom.Cluster(cluster)
+	cc := ClusterService().GetClusterByID(cluster)
+	if k == nil || cc == nil {
+		return nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	gvk := obj.GroupVersionKind()
+	gvr, namespaced, ok := k.Tools().GetGVRByGVK(gvk)
+	if !ok {
+		return nil, fmt.Errorf("集群中不存在资源类型 %s", gvk.String())
+	}
+	target := obj.DeepCopy()
+	if namespaced && target.GetNamespace() == "" {
+		target.SetNamespace(metav1.NamespaceDefault)
+	}
+	result := &AdmissionPreview{
+		APIVersion: target.GetAPIVersion(),
+		Kind:       target.GetKind(),
+		Namespace:  target.GetNamespace(),
+		Name:       target.GetName(),
+		Action:     "create",
+	}
+
+	// 先以用户身份读取，完成平台权限校验，同时判断是创建还是更新
+	var existing *unstructured.Unstructured
+	err := kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).
+		Namespace(target.GetNamespace()).Name(target.GetName()).Get(&existing).Error
+	switch {
+	case err == nil && existing != nil && existing.GetName() != "":
+		result.Action = "update"
+		target.SetResourceVersion(existing.GetResourceVersion())
+	case err != nil && !apierrors.IsNotFound(err):
+		return nil, err
+	}
+
+	collector := &warningCollector{}
+	cfg := rest.CopyConfig(cc.GetRestConfig())
+	cfg.WarningHandler = collector
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var ri dynamic.ResourceInterface = client.Resource(gvr)
+	if namespaced {
+		ri = client.Resource(gvr).Namespace(target.GetNamespace())
+	}
+	if result.Action == "update" {
+		_, err = ri.Update(ctx, target, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	} else {
+		_, err = ri.Create(ctx, target, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	}
+
+	result.Allowed = err == nil
+	result.Warnings = collector.warnings
+	if err != nil {
+		result.Message = err.Error()
+		if status, ok := err.(apierrors.APIStatus); ok {
+			result.Reason = string(status.Status().Reason)
+			result.Code = status.Status().Code
+		}
+		result.Violations = admission.ParseError(err)
+	}
+	return result, nil
+}
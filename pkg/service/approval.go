@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/slice"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/drift"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/plugins/api"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// 变更申请状态
+const (
+	ChangeStatusPending   = "pending"
+	ChangeStatusApproved  = "approved" // 已批准，正在执行
+	ChangeStatusRejected  = "rejected"
+	ChangeStatusCancelled = "cancelled"
+	ChangeStatusApplied   = "applied"
+	ChangeStatusFailed    = "failed"
+)
+
+// approvalRuleReload 已启用规则的缓存时间
+const approvalRuleReload = 30 * time.Second
+
+type approvalService struct {
+	lock     sync.RWMutex
+	loadedAt time.Time
+	rules    []*models.ApprovalRule
+}
+
+// ChangeOperation 被拦截的变更操作
+type ChangeOperation struct {
+	Cluster   string
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+	Operation string // create、update、patch 或 delete
+	PatchType string
+	Payload   string // create、update 为对象 JSON，patch 为补丁内容
+	Force     bool
+}
+
+// Invalidate 规则变更后清空缓存
+func (s *approvalService) Invalidate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.loadedAt = time.Time{}
+	s.rules = nil
+}
+
+func (s *approvalService) enabled() []*models.ApprovalRule {
+	s.lock.RLock()
+	if time.Since(s.loadedAt) < approvalRuleReload {
+		defer s.lock.RUnlock()
+		return s.rules
+	}
+	s.lock.RUnlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if time.Since(s.loadedAt) < approvalRuleReload {
+		return s.rules
+	}
+	var list []*models.ApprovalRule
+	if err := dao.DB().Where("enabled = ?", true).Order("id").Find(&list).Error; err != nil {
+		klog.Errorf("加载变更审批规则失败: %v", err)
+		return s.rules
+	}
+	s.rules = list
+	s.loadedAt = time.Now()
+	return s.rules
+}
+
+// Match 返回第一条适用于该操作的规则，集群级资源没有命名空间，不受审批规则约束
+func (s *approvalService) Match(op *ChangeOperation) *models.ApprovalRule {
+	if op.Namespace == "" {
+		return nil
+	}
+	for _, rule := range s.enabled() {
+		if strings.TrimSpace(rule.Namespaces) == "" {
+			continue
+		}
+		if admission.MatchList(rule.Clusters, op.Cluster) &&
+			admission.MatchList(rule.Namespaces, op.Namespace) &&
+			admission.MatchList(rule.Kinds, op.Kind) &&
+			admission.MatchList(rule.Operations, op.Operation) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Intercept 在写操作执行前调用，命中审批规则时创建待审批的变更申请并返回错误以阻止本次操作。
+// 无用户信息的内部操作与已批准申请的执行不受审批约束。
+func (s *approvalService) Intercept(ctx context.Context, op *ChangeOperation) error {
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	if username == "" {
+		return nil
+	}
+	if id, _ := ctx.Value(constants.ApprovedChange).(uint); id != 0 {
+		return nil
+	}
+	rule := s.Match(op)
+	if rule == nil {
+		return nil
+	}
+	cr := &models.ChangeRequest{
+		RuleID:      rule.ID,
+		Cluster:     op.Cluster,
+		Group:       op.Group,
+		Version:     op.Version,
+		Kind:        op.Kind,
+		Namespace:   op.Namespace,
+		Name:        op.Name,
+		Operation:   op.Operation,
+		PatchType:   op.PatchType,
+		Payload:     op.Payload,
+		Force:       op.Force,
+		Diff:        s.diff(ctx, op),
+		Status:      ChangeStatusPending,
+		RequestedBy: username,
+	}
+	if err := dao.DB().Create(cr).Error; err != nil {
+		return fmt.Errorf("创建变更申请失败: %w", err)
+	}
+	s.notify(rule, cr, fmt.Sprintf("[k8m 变更审批] %s 申请 %s %s %s/%s（集群 %s），请审批变更申请 #%d",
+		cr.RequestedBy, cr.Operation, cr.Kind, cr.Namespace, cr.Name, cr.Cluster, cr.ID))
+	return i18n.NewError(i18n.CodeApprovalRequired, cr.ID, rule.Name)
+}
+
+// diff 计算变更内容与集群中当前对象的字段差异，JSON Patch 与删除操作不计算
+func (s *approvalService) diff(ctx context.Context, op *ChangeOperation) string {
+	if op.Operation == "delete" || types.PatchType(op.PatchType) == types.JSONPatchType {
+		return ""
+	}
+	var desired map[string]any
+	if err := json.Unmarshal([]byte(op.Payload), &desired); err != nil {
+		return ""
+	}
+	live := map[string]any{}
+	var current *unstructured.Unstructured
+	err := kom.Cluster(op.Cluster).WithContext(ctx).CRD(op.Group, op.Version, op.Kind).
+		Namespace(op.Namespace).Name(op.Name).Get(&current).Error
+	if err == nil && current != nil {
+		live = current.Object
+	}
+	fields := drift.Compare(desired, live)
+	if len(fields) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(fields)
+	return string(data)
+}
+
+// CanReview 判断用户能否审批该申请：规则指定了审批人时只能由审批人审批，
+// 否则由平台管理员或该集群的集群管理员审批，申请人不能审批自己的申请
+func (s *approvalService) CanReview(username string, cr *models.ChangeRequest) bool {
+	if username == "" || username == cr.RequestedBy {
+		return false
+	}
+	var rule models.ApprovalRule
+	if err := dao.DB().First(&rule, cr.RuleID).Error; err == nil && strings.TrimSpace(rule.Approvers) != "" {
+		for _, approver := range strings.Split(rule.Approvers, ",") {
+			if strings.TrimSpace(approver) == username {
+				return true
+			}
+		}
+		return false
+	}
+	if UserService().IsUserPlatformAdmin(username) {
+		return true
+	}
+	roles, err := UserService().GetClusters(username)
+	if err != nil {
+		return false
+	}
+	_, ok := slice.FindBy(roles, func(_ int, r *models.ClusterUserRole) bool {
+		return r.Cluster == cr.Cluster && r.Role == constants.RoleClusterAdmin
+	})
+	return ok
+}
+
+// Approve 批准变更申请并以申请人身份执行，返回执行后的申请
+func (s *approvalService) Approve(id uint, reviewer, comment string) (*models.ChangeRequest, error) {
+	cr, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReviewer(reviewer, cr); err != nil {
+		return nil, err
+	}
+	if err := s.transition(cr, ChangeStatusApproved, reviewer, comment); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cr.AppliedAt = &now
+	cr.Status, cr.Message = ChangeStatusApplied, ""
+	if err := s.execute(cr); err != nil {
+		cr.Status, cr.Message = ChangeStatusFailed, err.Error()
+	}
+	err = dao.DB().Model(&models.ChangeRequest{}).Where("id = ?", cr.ID).Updates(map[string]any{
+		"status": cr.Status, "message": cr.Message, "applied_at": cr.AppliedAt,
+	}).Error
+	if err != nil {
+		klog.Errorf("保存变更申请 #%d 执行结果失败: %v", cr.ID, err)
+	}
+	s.notifyReview(cr)
+	return cr, nil
+}
+
+// Reject 驳回变更申请
+func (s *approvalService) Reject(id uint, reviewer, comment string) (*models.ChangeRequest, error) {
+	cr, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReviewer(reviewer, cr); err != nil {
+		return nil, err
+	}
+	if err := s.transition(cr, ChangeStatusRejected, reviewer, comment); err != nil {
+		return nil, err
+	}
+	s.notifyReview(cr)
+	return cr, nil
+}
+
+// Cancel 申请人撤回尚未审批的申请
+func (s *approvalService) Cancel(id uint, username string) (*models.ChangeRequest, error) {
+	cr, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if cr.RequestedBy != username {
+		return nil, i18n.NewError(i18n.CodeApprovalNotOwner)
+	}
+	if err := s.transition(cr, ChangeStatusCancelled, "", ""); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+func (s *approvalService) get(id uint) (*models.ChangeRequest, error) {
+	var cr models.ChangeRequest
+	if err := dao.DB().First(&cr, id).Error; err != nil {
+		return nil, fmt.Errorf("变更申请 #%d 不存在: %w", id, err)
+	}
+	return &cr, nil
+}
+
+func (s *approvalService) checkReviewer(reviewer string, cr *models.ChangeRequest) error {
+	if reviewer == cr.RequestedBy {
+		return i18n.NewError(i18n.CodeApprovalSelfReview)
+	}
+	if !s.CanReview(reviewer, cr) {
+		return i18n.NewError(i18n.CodeApprovalNotApprover, reviewer, cr.ID)
+	}
+	return nil
+}
+
+// transition 仅当申请仍为待审批时更新状态，多名审批人同时操作时只有一人成功
+func (s *approvalService) transition(cr *models.ChangeRequest, status, reviewer, comment string) error {
+	now := time.Now()
+	updates := map[string]any{"status": status}
+	if reviewer != "" {
+		updates["reviewed_by"] = reviewer
+		updates["review_comment"] = comment
+		updates["reviewed_at"] = now
+	}
+	result := dao.DB().Model(&models.ChangeRequest{}).
+		Where("id = ? and status = ?", cr.ID, ChangeStatusPending).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		latest, err := s.get(cr.ID)
+		if err != nil {
+			return err
+		}
+		return i18n.NewError(i18n.CodeApprovalNotPending, cr.ID, latest.Status)
+	}
+	cr.Status = status
+	if reviewer != "" {
+		cr.ReviewedBy, cr.ReviewComment, cr.ReviewedAt = reviewer, comment, &now
+	}
+	return nil
+}
+
+// execute 以申请人身份重放变更操作，仍需通过只读模式、权限与准入策略校验
+func (s *approvalService) execute(cr *models.ChangeRequest) error {
+	if kom.Cluster(cr.Cluster) == nil {
+		return fmt.Errorf("集群 %s 不存在或未连接", cr.Cluster)
+	}
+	ctx := context.WithValue(context.Background(), constants.JwtUserName, cr.RequestedBy)
+	ctx = context.WithValue(ctx, constants.ApprovedChange, cr.ID)
+	k := kom.Cluster(cr.Cluster).WithContext(ctx).CRD(cr.Group, cr.Version, cr.Kind).Namespace(cr.Namespace).Name(cr.Name)
+
+	switch cr.Operation {
+	case "delete":
+		if cr.Force {
+			return k.ForceDelete().Error
+		}
+		return k.Delete().Error
+	case "patch":
+		var result *unstructured.Unstructured
+		return k.Patch(&result, types.PatchType(cr.PatchType), cr.Payload).Error
+	case "create", "update":
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal([]byte(cr.Payload), &obj.Object); err != nil {
+			return fmt.Errorf("解析变更内容失败: %w", err)
+		}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: cr.Group, Version: cr.Version, Kind: cr.Kind})
+		obj.SetNamespace(cr.Namespace)
+		obj.SetName(cr.Name)
+		if cr.Operation == "create" {
+			return k.Create(&obj).Error
+		}
+		return k.Update(&obj).Error
+	}
+	return fmt.Errorf("不支持的操作: %s", cr.Operation)
+}
+
+func (s *approvalService) notifyReview(cr *models.ChangeRequest) {
+	var rule models.ApprovalRule
+	if err := dao.DB().First(&rule, cr.RuleID).Error; err != nil {
+		return
+	}
+	summary := fmt.Sprintf("[k8m 变更审批] 变更申请 #%d（%s %s %s/%s）已被 %s %s", cr.ID, cr.Operation, cr.Kind,
+		cr.Namespace, cr.Name, cr.ReviewedBy, cr.Status)
+	if cr.Message != "" {
+		summary += "：" + cr.Message
+	}
+	s.notify(&rule, cr, summary)
+}
+
+func (s *approvalService) notify(rule *models.ApprovalRule, cr *models.ChangeRequest, summary string) {
+	var ids []string
+	for _, id := range strings.Split(rule.Webhooks, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	// 通知中不包含变更内容，避免泄露 Secret 等敏感数据
+	brief := *cr
+	brief.Payload, brief.Diff = "", ""
+	raw, _ := json.Marshal(brief)
+	api.WebhookService().PushMsgToAllTargetByIDs(summary, string(raw), ids)
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/objectstorage"
+	"k8s.io/klog/v2"
+)
+
+// 制品类别，即对象 key 的第一段
+const (
+	ArtifactSupportBundle = "support-bundle" // 集群支持包
+	ArtifactBackup        = "backup"         // 资源备份
+	ArtifactRecording     = "recording"      // 终端录像
+	ArtifactTrash         = "trash"          // 回收站文件
+)
+
+// ArtifactCategories 内置的制品类别，用量报表中始终列出
+var ArtifactCategories = []string{ArtifactSupportBundle, ArtifactBackup, ArtifactRecording, ArtifactTrash}
+
+// 制品存储驱动
+const (
+	ArtifactDriverLocal = "local"
+	ArtifactDriverS3    = "s3"
+)
+
+const defaultArtifactS3Prefix = "k8m-artifacts"
+
+// artifactService 保存 k8m 生成的支持包、备份、录像等文件，存储驱动按平台参数选择本地磁盘或 S3 兼容存储，
+// 并按类别的保留策略定期清理
+type artifactService struct{}
+
+// ArtifactCategoryUsage 单个类别的用量及保留策略
+type ArtifactCategoryUsage struct {
+	Category string `json:"category"`
+	*objectstorage.Usage
+	Policy *models.ArtifactPolicy `json:"policy,omitempty"`
+}
+
+// ArtifactUsage 制品存储用量报表
+type ArtifactUsage struct {
+	Driver     string                   `json:"driver"`
+	Location   string                   `json:"location"` // 本地目录或 存储桶/前缀
+	Count      int                      `json:"count"`
+	Bytes      int64                    `json:"bytes"`
+	Categories []*ArtifactCategoryUsage `json:"categories"`
+}
+
+// ArtifactCleanupResult 按保留策略清理的结果
+type ArtifactCleanupResult struct {
+	Deleted int      `json:"deleted"`
+	Bytes   int64    `json:"bytes"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// driver 每次按平台参数设置创建驱动，配置修改后立即生效，同时返回存储位置说明
+func (a *artifactService) driver() (objectstorage.Driver, string, string, error) {
+	m, err := ConfigService().GetConfig()
+	if err != nil {
+		return nil, "", "", err
+	}
+	switch m.ArtifactDriver {
+	case ArtifactDriverS3:
+		client, err := ObjectStorageService().client()
+		if err != nil {
+			return nil, "", "", err
+		}
+		prefix := strings.Trim(m.ArtifactS3Prefix, "/")
+		if prefix == "" {
+			prefix = defaultArtifactS3Prefix
+		}
+		return objectstorage.WithPrefix(client, prefix), ArtifactDriverS3, client.Bucket() + "/" + prefix, nil
+	case ArtifactDriverLocal, "":
+		dir := m.ArtifactLocalDir
+		if dir == "" {
+			dir = filepath.Join(filepath.Dir(flag.Init().SqlitePath), "artifacts")
+		}
+		local, err := objectstorage.NewLocalDriver(dir)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return local, ArtifactDriverLocal, dir, nil
+	default:
+		return nil, "", "", fmt.Errorf("不支持的制品存储驱动: %s", m.ArtifactDriver)
+	}
+}
+
+// Save 保存制品，size 未知时传 -1，S3 驱动会先暂存到本地临时文件以确定长度
+func (a *artifactService) Save(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*objectstorage.Object, error) {
+	d, driverName, _, err := a.driver()
+	if err != nil {
+		return nil, err
+	}
+	key, err = objectstorage.CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 && driverName == ArtifactDriverS3 {
+		tmp, err := os.CreateTemp(UploadStagingService().Root(), "k8m-artifact-*")
+		if err != nil {
+			return nil, fmt.Errorf("创建临时文件失败: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		if size, err = io.Copy(tmp, body); err != nil {
+			return nil, err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		body = tmp
+	}
+	if err := d.PutObject(ctx, key, body, size, contentType); err != nil {
+		return nil, fmt.Errorf("保存制品 %s 失败: %w", key, err)
+	}
+	klog.V(6).Infof("制品 %s 已保存到 %s 存储", key, driverName)
+	return &objectstorage.Object{Key: key, Size: size, LastModified: time.Now()}, nil
+}
+
+// Open 读取制品，调用方负责关闭
+func (a *artifactService) Open(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	d, _, _, err := a.driver()
+	if err != nil {
+		return nil, 0, err
+	}
+	return d.GetObject(ctx, key)
+}
+
+// Delete 删除制品
+func (a *artifactService) Delete(ctx context.Context, keys []string) error {
+	d, _, _, err := a.driver()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := d.DeleteObject(ctx, key); err != nil {
+			return fmt.Errorf("删除制品 %s 失败: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// List 列出某一类别的制品，category 为空时列出全部，按时间倒序
+func (a *artifactService) List(ctx context.Context, category string) ([]*objectstorage.Object, error) {
+	d, _, _, err := a.driver()
+	if err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if category != "" {
+		prefix = strings.Trim(category, "/") + "/"
+	}
+	list, err := d.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].LastModified.After(list[j].LastModified) })
+	return list, nil
+}
+
+func (a *artifactService) policies() (map[string]*models.ArtifactPolicy, error) {
+	var list []*models.ArtifactPolicy
+	if err := dao.DB().Find(&list).Error; err != nil {
+		return nil, err
+	}
+	result := map[string]*models.ArtifactPolicy{}
+	for _, p := range list {
+		result[p.Category] = p
+	}
+	return result, nil
+}
+
+// Usage 按类别统计对象数与占用空间，附带各类别的保留策略
+func (a *artifactService) Usage(ctx context.Context) (*ArtifactUsage, error) {
+	d, driverName, location, err := a.driver()
+	if err != nil {
+		return nil, err
+	}
+	objects, err := d.ListObjects(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	policies, err := a.policies()
+	if err != nil {
+		return nil, err
+	}
+	categories := append([]string{}, ArtifactCategories...)
+	for _, o := range objects {
+		category, _, ok := strings.Cut(o.Key, "/")
+		if ok && !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	for category := range policies {
+		if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	usage := &ArtifactUsage{Driver: driverName, Location: location, Categories: []*ArtifactCategoryUsage{}}
+	for _, category := range categories {
+		u := objectstorage.Summarize(objects, category+"/")
+		usage.Count += u.Count
+		usage.Bytes += u.Bytes
+		usage.Categories = append(usage.Categories, &ArtifactCategoryUsage{Category: category, Usage: u, Policy: policies[category]})
+	}
+	return usage, nil
+}
+
+// SavePolicy 按类别新增或更新保留策略
+func (a *artifactService) SavePolicy(m *models.ArtifactPolicy) error {
+	m.Category = strings.Trim(strings.TrimSpace(m.Category), "/")
+	if m.Category == "" || strings.Contains(m.Category, "/") {
+		return fmt.Errorf("制品类别无效: %q", m.Category)
+	}
+	if m.RetentionDays < 0 || m.MaxCount < 0 || m.MaxSizeMB < 0 {
+		return fmt.Errorf("保留天数、数量与大小不能为负数")
+	}
+	var existing models.ArtifactPolicy
+	if err := dao.DB().Where("category = ?", m.Category).Limit(1).Find(&existing).Error; err != nil {
+		return err
+	}
+	if existing.ID == 0 {
+		m.ID = 0
+		return dao.DB().Create(m).Error
+	}
+	m.ID = existing.ID
+	return dao.DB().Model(&models.ArtifactPolicy{}).Where("id = ?", m.ID).
+		Updates(map[string]any{"retention_days": m.RetentionDays, "max_count": m.MaxCount, "max_size_mb": m.MaxSizeMB}).Error
+}
+
+// Cleanup 按各类别的保留策略删除过期制品
+func (a *artifactService) Cleanup(ctx context.Context) (*ArtifactCleanupResult, error) {
+	d, _, _, err := a.driver()
+	if err != nil {
+		return nil, err
+	}
+	policies, err := a.policies()
+	if err != nil {
+		return nil, err
+	}
+	result := &ArtifactCleanupResult{}
+	now := time.Now()
+	for category, p := range policies {
+		if p.RetentionDays == 0 && p.MaxCount == 0 && p.MaxSizeMB == 0 {
+			continue
+		}
+		prefix := category + "/"
+		objects, err := d.ListObjects(ctx, prefix)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("列出 %s 失败: %v", category, err))
+			continue
+		}
+		expired := objectstorage.Expired(objects, objectstorage.RetentionPolicy{
+			Prefix: prefix, RetentionDays: p.RetentionDays, MaxCount: p.MaxCount, MaxSizeMB: p.MaxSizeMB,
+		}, now)
+		for _, o := range expired {
+			if err := d.DeleteObject(ctx, o.Key); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("删除 %s 失败: %v", o.Key, err))
+				continue
+			}
+			result.Deleted++
+			result.Bytes += o.Size
+		}
+	}
+	if result.Deleted > 0 {
+		klog.V(6).Infof("按保留策略清理制品 %d 个，共 %d 字节", result.Deleted, result.Bytes)
+	}
+	return result, nil
+}
+
+// Start 每小时按保留策略清理制品，多实例部署时通过分布式锁保证只由一个实例执行
+func (a *artifactService) Start() {
+	holder, _ := os.Hostname()
+	inst := cron.New()
+	_, err := inst.AddFunc("@hourly", func() {
+		ok, err := LockService().TryAcquire("artifact-cleanup", holder, 30*time.Minute)
+		if err != nil || !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		defer cancel()
+		if _, err := a.Cleanup(ctx); err != nil {
+			klog.V(6).Infof("按保留策略清理制品失败: %v", err)
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增制品清理定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/capability"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/kom/kom"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	capabilityModeKey      = "capability:cluster:" // 共享状态表中保存集群功能开关的键前缀
+	capabilityDetectKey    = "capability:detected:"
+	capabilityDetectTTL    = 10 * time.Minute
+	capabilityModeTTL      = 10 * time.Second // 多实例部署时其他实例最多延迟该时间生效
+	capabilityProbeTimeout = 10 * time.Second
+)
+
+// CapabilityReport 集群能力与功能开关
+type CapabilityReport struct {
+	Cluster      string                   `json:"cluster"`
+	Capabilities []*capability.Capability `json:"capabilities"`
+	DetectedAt   time.Time                `json:"detected_at"`
+}
+
+// capabilityDetection 缓存的探测结果
+type capabilityDetection struct {
+	Probes map[string]capability.Probe
+	At     time.Time
+}
+
+// capabilityService 探测集群是否安装 metrics-server、Gateway API、卷快照等组件，以及 k8m 的凭据能否执行命令，
+// 结合管理员为集群设置的功能开关，供前端按集群隐藏功能、中间件对不支持的接口直接返回提示
+type capabilityService struct{}
+
+// Get 返回集群能力，探测结果缓存 10 分钟，refresh 为 true 时重新探测
+func (s *capabilityService) Get(cluster string, refresh bool) (*CapabilityReport, error) {
+	if kom.Cluster(cluster) == nil {
+		return nil, fmt.Errorf("集群 %s 不存在或未连接", cluster)
+	}
+	key := capabilityDetectKey + cluster
+	if refresh {
+		utils.ClearCacheByKey(CacheService().CacheInstance(), key)
+	}
+	detection, err := utils.GetOrSetCache(CacheService().CacheInstance(), key, capabilityDetectTTL, func() (*capabilityDetection, error) {
+		return s.detect(cluster), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	modes, err := s.Modes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &CapabilityReport{
+		Cluster:      cluster,
+		Capabilities: capability.Resolve(detection.Probes, modes),
+		DetectedAt:   detection.At,
+	}, nil
+}
+
+// Check 检查集群是否支持 name 对应的功能，不支持时返回错误。集群未连接或读取失败时不限制，由后续处理报告具体错误
+func (s *capabilityService) Check(cluster, name string) error {
+	if cluster == "" || !ClusterService().IsConnected(cluster) {
+		return nil
+	}
+	report, err := s.Get(cluster, false)
+	if err != nil {
+		klog.V(6).Infof("读取集群 %s 能力失败: %v", cluster, err)
+		return nil
+	}
+	for _, c := range report.Capabilities {
+		if c.Name != name || c.Enabled {
+			continue
+		}
+		if c.Mode == capability.ModeDisabled {
+			return i18n.NewError(i18n.CodeCapabilityDisabled, cluster, c.Label)
+		}
+		return i18n.NewError(i18n.CodeCapabilityUnsupported, cluster, c.Label, c.Detail)
+	}
+	return nil
+}
+
+// Modes 返回管理员为集群设置的功能开关
+func (s *capabilityService) Modes(cluster string) (map[string]string, error) {
+	key := capabilityModeKey + cluster
+	return utils.GetOrSetCache(CacheService().CacheInstance(), key, capabilityModeTTL, func() (map[string]string, error) {
+		modes := map[string]string{}
+		if _, err := StateStoreService().Get(key, &modes); err != nil {
+			return nil, err
+		}
+		return modes, nil
+	})
+}
+
+// SetMode 设置集群的功能开关，mode 为空表示恢复为按探测结果
+func (s *capabilityService) SetMode(cluster, name, mode string) error {
+	if !capability.Known(name) {
+		return fmt.Errorf("未知的功能 %s", name)
+	}
+	if !capability.ValidMode(mode) {
+		return fmt.Errorf("开关取值无效: %s", mode)
+	}
+	key := capabilityModeKey + cluster
+	defer utils.ClearCacheByKey(CacheService().CacheInstance(), key)
+	modes := map[string]string{}
+	if _, err := StateStoreService().Get(key, &modes); err != nil {
+		return err
+	}
+	if mode == capability.ModeAuto {
+		delete(modes, name)
+	} else {
+		modes[name] = mode
+	}
+	if len(modes) == 0 {
+		return StateStoreService().Delete(key)
+	}
+	return StateStoreService().Put(key, modes, 0)
+}
+
+// detect 以 k8m 连接集群所用的凭据探测，不经过用户权限检查
+func (s *capabilityService) detect(cluster string) *capabilityDetection {
+	k := kom.Cluster(cluster)
+	probes := map[string]capability.Probe{}
+	crd := func(name string) capability.Probe {
+		if k.Status().IsCRDSupportedByName(name) {
+			return capability.Probe{Detected: true, Detail: name}
+		}
+		return capability.Probe{Detail: "未安装 CRD " + name}
+	}
+	probes[capability.GatewayAPI] = crd("gateways.gateway.networking.k8s.io")
+	probes[capability.VolumeSnapshot] = crd("volumesnapshots.snapshot.storage.k8s.io")
+	probes[capability.OpenKruise] = crd("daemonsets.apps.kruise.io")
+	probes[capability.Istio] = crd("sidecars.networking.istio.io")
+
+	// metrics-server 以聚合 API 提供，能够发现资源说明服务可用，而不只是 APIService 已注册
+	if list, err := k.Client().Discovery().ServerResourcesForGroupVersion("metrics.k8s.io/v1beta1"); err != nil {
+		probes[capability.Metrics] = capability.Probe{Detail: "metrics.k8s.io 不可用: " + err.Error()}
+	} else {
+		probes[capability.Metrics] = capability.Probe{Detected: len(list.APIResources) > 0, Detail: "metrics.k8s.io/v1beta1"}
+	}
+
+	// agent 隧道只转发普通 HTTP 请求，exec 等依赖协议升级的功能不可用
+	if cc := ClusterService().GetClusterByID(cluster); cc != nil && cc.IsAgent {
+		probes[capability.Exec] = capability.Probe{Detail: "经 agent 隧道接入的集群不支持协议升级，终端、执行命令、文件管理与节点 Shell 不可用"}
+		return &capabilityDetection{Probes: probes, At: time.Now()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+	defer cancel()
+	review, err := k.Client().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec"},
+		},
+	}, metav1.CreateOptions{})
+	switch {
+	case err != nil:
+		// 无法检查时不限制，执行时由集群返回具体错误
+		probes[capability.Exec] = capability.Probe{Detected: true, Detail: "无法检查 pods/exec 权限: " + err.Error()}
+	case review.Status.Allowed:
+		probes[capability.Exec] = capability.Probe{Detected: true, Detail: "允许 create pods/exec"}
+	default:
+		probes[capability.Exec] = capability.Probe{Detail: "k8m 所用凭据没有 create pods/exec 权限 " + review.Status.Reason}
+	}
+	return &capabilityDetection{Probes: probes, At: time.Now()}
+}
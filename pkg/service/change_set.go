@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// 变更集状态
+const (
+	ChangeSetStatusScheduled  = "scheduled"   // 等待到期或手动应用
+	ChangeSetStatusRunning    = "running"     // 正在应用
+	ChangeSetStatusApplied    = "applied"     // 全部应用成功
+	ChangeSetStatusRolledBack = "rolled_back" // 应用失败，已全部回滚
+	ChangeSetStatusFailed     = "failed"      // 应用失败，且部分资源回滚失败
+	ChangeSetStatusCancelled  = "cancelled"
+)
+
+// 变更集中单个资源的状态
+const (
+	changeSetItemValid      = "valid"
+	changeSetItemInvalid    = "invalid"
+	changeSetItemApplied    = "applied"
+	changeSetItemFailed     = "failed"
+	changeSetItemSkipped    = "skipped"
+	changeSetItemRolledBack = "rolled_back"
+)
+
+type changeSetService struct{}
+
+// Start 每分钟检查到期的变更集并应用，状态由 scheduled 原子地切换为 running，多实例部署时只有一个实例执行
+func (s *changeSetService) Start() {
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", func() {
+		var ids []uint
+		err := dao.DB().Model(&models.ChangeSet{}).
+			Where("status = ? and scheduled_at is not null and scheduled_at <= ?", ChangeSetStatusScheduled, time.Now()).
+			Pluck("id", &ids).Error
+		if err != nil {
+			klog.V(6).Infof("读取到期的变更集失败: %v", err)
+			return
+		}
+		for _, id := range ids {
+			if _, err := s.Run(id); err != nil {
+				klog.V(6).Infof("变更集[%d]应用失败: %v", id, err)
+			}
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增变更集定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+	klog.V(6).Infof("新增变更集定时任务【@every 1m】")
+}
+
+// objects 解析变更集中的清单并按依赖顺序排序
+func (s *changeSetService) objects(cs *models.ChangeSet) ([]*unstructured.Unstructured, error) {
+	objs, err := ManifestService().Parse(cs.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("未找到任何资源清单")
+	}
+	ManifestService().SortByDependency(objs)
+	return objs, nil
+}
+
+// Validate 校验清单与计划时间，新建或编辑时调用
+func (s *changeSetService) Validate(cs *models.ChangeSet) error {
+	if cs.Name == "" {
+		return fmt.Errorf("变更集名称不能为空")
+	}
+	if cs.ScheduledAt != nil && cs.ScheduledAt.Before(time.Now()) {
+		return fmt.Errorf("计划应用时间不能早于当前时间")
+	}
+	_, err := s.objects(cs)
+	return err
+}
+
+// DryRun 以 dryRun=All 方式逐个预演变更集中的资源，不修改集群
+func (s *changeSetService) DryRun(ctx context.Context, cs *models.ChangeSet) ([]*models.ChangeSetItem, error) {
+	objs, err := s.objects(cs)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*models.ChangeSetItem, len(objs))
+	for i, obj := range objs {
+		items[i] = newChangeSetItem(obj)
+		action, err := ManifestService().DryRun(ctx, cs.Cluster, obj)
+		items[i].Action, items[i].Status = action, changeSetItemValid
+		if err != nil {
+			items[i].Status, items[i].Message = changeSetItemInvalid, err.Error()
+		}
+	}
+	return items, nil
+}
+
+// Run 立即应用处于 scheduled 状态的变更集，以创建人身份执行，任一资源失败时逆序回滚已应用的资源
+func (s *changeSetService) Run(id uint) (*models.ChangeSet, error) {
+	now := time.Now()
+	result := dao.DB().Model(&models.ChangeSet{}).Where("id = ? and status = ?", id, ChangeSetStatusScheduled).
+		Updates(map[string]any{"status": ChangeSetStatusRunning, "started_at": now, "message": ""})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("变更集 %d 不存在或不处于待应用状态", id)
+	}
+	var cs models.ChangeSet
+	if err := dao.DB().First(&cs, id).Error; err != nil {
+		return nil, err
+	}
+
+	ctx := context.WithValue(context.Background(), constants.JwtUserName, cs.CreatedBy)
+	cs.Status, cs.Results, cs.Message = s.apply(ctx, &cs)
+	finished := time.Now()
+	cs.FinishedAt = &finished
+	err := dao.DB().Model(&models.ChangeSet{}).Where("id = ?", cs.ID).
+		Select("status", "results", "message", "finished_at").Updates(&cs).Error
+	if err != nil {
+		klog.Errorf("保存变更集[%d]执行结果失败: %v", cs.ID, err)
+	}
+	return &cs, nil
+}
+
+func (s *changeSetService) apply(ctx context.Context, cs *models.ChangeSet) (string, []*models.ChangeSetItem, string) {
+	objs, err := s.objects(cs)
+	if err != nil {
+		return ChangeSetStatusFailed, nil, err.Error()
+	}
+	items := make([]*models.ChangeSetItem, len(objs))
+	for i, obj := range objs {
+		items[i] = newChangeSetItem(obj)
+		items[i].Status = changeSetItemSkipped
+	}
+
+	previous := make([]*unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		action, prev, err := ManifestService().ApplyTracked(ctx, cs.Cluster, obj)
+		items[i].Action = action
+		if err == nil {
+			items[i].Status = changeSetItemApplied
+			previous[i] = prev
+			continue
+		}
+		items[i].Status, items[i].Message = changeSetItemFailed, err.Error()
+
+		status := ChangeSetStatusRolledBack
+		for j := i - 1; j >= 0; j-- {
+			if err := ManifestService().Rollback(ctx, cs.Cluster, objs[j], items[j].Action, previous[j]); err != nil {
+				klog.V(6).Infof("变更集[%d]回滚 %s %s/%s 失败: %v", cs.ID, items[j].Kind, items[j].Namespace, items[j].Name, err)
+				items[j].Message = fmt.Sprintf("回滚失败: %v", err)
+				status = ChangeSetStatusFailed
+				continue
+			}
+			items[j].Status = changeSetItemRolledBack
+		}
+		return status, items, fmt.Sprintf("%s %s/%s 应用失败: %v", items[i].Kind, items[i].Namespace, items[i].Name, err)
+	}
+	return ChangeSetStatusApplied, items, ""
+}
+
+// Cancel 取消尚未开始应用的变更集
+func (s *changeSetService) Cancel(id uint) error {
+	result := dao.DB().Model(&models.ChangeSet{}).Where("id = ? and status = ?", id, ChangeSetStatusScheduled).
+		Update("status", ChangeSetStatusCancelled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("变更集 %d 不存在或已开始应用，无法取消", id)
+	}
+	return nil
+}
+
+func newChangeSetItem(obj *unstructured.Unstructured) *models.ChangeSetItem {
+	return &models.ChangeSetItem{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
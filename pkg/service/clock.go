@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/pkg/clockskew"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// clockCheckTimeout 单个时间源的查询超时
+const clockCheckTimeout = 5 * time.Second
+
+// ClockSource 单个时间源的比对结果
+type ClockSource struct {
+	Kind     string `json:"kind"`      // apiserver 或 ntp
+	Name     string `json:"name"`      // 集群ID 或 NTP 服务器地址
+	OffsetMs int64  `json:"offset_ms"` // 对方时间减去 k8m 时间，为正表示 k8m 时间落后
+	RTTMs    int64  `json:"rtt_ms"`
+	Skewed   bool   `json:"skewed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ClockStatus 时钟检查结果
+type ClockStatus struct {
+	CheckedAt        time.Time      `json:"checked_at"`
+	ThresholdSeconds int            `json:"threshold_seconds"`
+	Skewed           bool           `json:"skewed"`
+	MaxOffsetMs      int64          `json:"max_offset_ms"` // 偏差绝对值最大的时间源的偏差
+	Message          string         `json:"message,omitempty"`
+	Sources          []*ClockSource `json:"sources"`
+}
+
+// clockService 定期比对 k8m 与集群 apiserver、NTP 服务器的时间。
+// k8m 时钟偏差过大时，2FA 验证码与 Token 有效期校验会莫名失败，检查结果用于页面顶部告警
+type clockService struct {
+	lock   sync.RWMutex
+	status *ClockStatus
+}
+
+// Start 启动后立即检查一次，之后每 10 分钟检查一次
+func (s *clockService) Start() {
+	go s.Check(context.Background())
+
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 10m", func() {
+		s.Check(context.Background())
+	})
+	if err != nil {
+		klog.Errorf("新增时钟检查定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+}
+
+// Status 最近一次检查结果，尚未检查时返回 nil
+func (s *clockService) Status() *ClockStatus {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.status
+}
+
+// Summary 最近一次检查结果的摘要，不含各时间源明细，供所有登录用户查看
+func (s *clockService) Summary() *ClockStatus {
+	status := s.Status()
+	if status == nil {
+		return &ClockStatus{Sources: []*ClockSource{}}
+	}
+	summary := *status
+	summary.Sources = []*ClockSource{}
+	return &summary
+}
+
+// Check 比对已连接集群的 apiserver 与配置的 NTP 服务器，保存并返回结果
+func (s *clockService) Check(ctx context.Context) *ClockStatus {
+	cfg := flag.Init()
+	threshold := cfg.ClockSkewThreshold
+	if threshold <= 0 {
+		threshold = 30
+	}
+	status := &ClockStatus{ThresholdSeconds: threshold, Sources: []*ClockSource{}}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	add := func(src *ClockSource) {
+		lock.Lock()
+		defer lock.Unlock()
+		status.Sources = append(status.Sources, src)
+	}
+	for _, cluster := range ClusterService().ConnectedClusters() {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			add(s.checkAPIServer(ctx, id))
+		}(cluster.ClusterID)
+	}
+	if cfg.NTPServer != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			add(s.checkNTP(ctx, cfg.NTPServer))
+		}()
+	}
+	wg.Wait()
+	status.CheckedAt = time.Now()
+
+	sort.Slice(status.Sources, func(i, j int) bool {
+		if status.Sources[i].Kind != status.Sources[j].Kind {
+			return status.Sources[i].Kind < status.Sources[j].Kind
+		}
+		return status.Sources[i].Name < status.Sources[j].Name
+	})
+
+	limit := time.Duration(threshold) * time.Second
+	skewed := 0
+	for _, src := range status.Sources {
+		if src.Error != "" {
+			continue
+		}
+		offset := time.Duration(src.OffsetMs) * time.Millisecond
+		if offset.Abs() > (time.Duration(status.MaxOffsetMs) * time.Millisecond).Abs() {
+			status.MaxOffsetMs = src.OffsetMs
+		}
+		if offset.Abs() > limit {
+			src.Skewed = true
+			skewed++
+			klog.Warningf("k8m 服务器时间与 %s %s 相差 %s，超过告警阈值 %d 秒", src.Kind, src.Name, offset.Round(time.Millisecond), threshold)
+		}
+	}
+	if skewed > 0 {
+		status.Skewed = true
+		status.Message = fmt.Sprintf("k8m 服务器时钟与 %d 个时间源相差超过 %d 秒（最大偏差 %s），2FA 验证码与登录会话可能校验失败，请检查服务器时间同步",
+			skewed, threshold, (time.Duration(status.MaxOffsetMs) * time.Millisecond).Round(time.Second))
+	}
+
+	s.lock.Lock()
+	s.status = status
+	s.lock.Unlock()
+	return status
+}
+
+// checkAPIServer 读取 apiserver 响应的 Date 头估算偏差
+func (s *clockService) checkAPIServer(ctx context.Context, cluster string) *ClockSource {
+	src := &ClockSource{Kind: "apiserver", Name: cluster}
+	k := kom.Cluster(cluster)
+	if k == nil {
+		src.Error = fmt.Sprintf("集群 %s 不存在", cluster)
+		return src
+	}
+	cfg := k.RestConfig()
+	client, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		src.Error = err.Error()
+		return src
+	}
+	ctx, cancel := context.WithTimeout(ctx, clockCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(cfg.Host, "/")+"/version", nil)
+	if err != nil {
+		src.Error = err.Error()
+		return src
+	}
+	sent := time.Now()
+	resp, err := client.Do(req)
+	received := time.Now()
+	if err != nil {
+		src.Error = err.Error()
+		return src
+	}
+	_ = resp.Body.Close()
+	offset, err := clockskew.HTTPDateOffset(resp.Header.Get("Date"), sent, received)
+	if err != nil {
+		src.Error = err.Error()
+		return src
+	}
+	src.OffsetMs = offset.Milliseconds()
+	src.RTTMs = received.Sub(sent).Milliseconds()
+	return src
+}
+
+func (s *clockService) checkNTP(ctx context.Context, server string) *ClockSource {
+	src := &ClockSource{Kind: "ntp", Name: server}
+	ctx, cancel := context.WithTimeout(ctx, clockCheckTimeout)
+	defer cancel()
+	r, err := clockskew.QueryNTP(ctx, server)
+	if err != nil {
+		src.Error = err.Error()
+		return src
+	}
+	src.OffsetMs = r.Offset.Milliseconds()
+	src.RTTMs = r.RTT.Milliseconds()
+	return src
+}
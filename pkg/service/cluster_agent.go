@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/tunnel"
+	"k8s.io/klog/v2"
+)
+
+// agentClusterConfig 构建 agent 集群的配置，集群ID为 Agent/<名称>
+func agentClusterConfig(name string) *ClusterConfig {
+	return &ClusterConfig{
+		FileName:             string(ClusterConfigSourceAgent),
+		ContextName:          name,
+		ClusterName:          name,
+		Server:               tunnel.AgentHost,
+		IsAgent:              true,
+		ClusterConnectStatus: constants.ClusterConnectStatusDisconnected,
+		Source:               ClusterConfigSourceAgent,
+	}
+}
+
+// ConnectAgentCluster agent 隧道建立后，登记并连接对应集群
+func (c *clusterService) ConnectAgentCluster(name string) {
+	cc := agentClusterConfig(name)
+	clusterID := cc.GetClusterID()
+	if existing := c.GetClusterByID(clusterID); existing == nil {
+		c.AddToClusterList(cc)
+		klog.V(2).Infof("登记 agent 集群 %s", clusterID)
+	}
+	c.Connect(clusterID)
+}
+
+// DisconnectAgentCluster agent 隧道断开后，断开对应集群并停止自动重连，等待 agent 重新接入
+func (c *clusterService) DisconnectAgentCluster(name string) {
+	clusterID := agentClusterConfig(name).GetClusterID()
+	klog.V(2).Infof("agent 集群 %s 隧道断开，断开集群连接", clusterID)
+	c.Disconnect(clusterID)
+}
+
+// clusterAgentService 管理 agent 接入令牌
+type clusterAgentService struct{}
+
+// Authenticate 按令牌查找已启用的 agent，令牌只以摘要比对
+func (s *clusterAgentService) Authenticate(token string) (*models.ClusterAgent, error) {
+	if token == "" {
+		return nil, fmt.Errorf("缺少 agent 令牌")
+	}
+	var agent models.ClusterAgent
+	if err := dao.DB().Where("token_hash = ? AND enabled = ?", tunnel.HashToken(token), true).First(&agent).Error; err != nil {
+		return nil, fmt.Errorf("agent 令牌无效或已停用")
+	}
+	return &agent, nil
+}
+
+// ResetToken 签发新令牌并保存摘要，返回明文，仅在此时可见。agent 已保存时原令牌立即失效，在线隧道随之断开
+func (s *clusterAgentService) ResetToken(agent *models.ClusterAgent) (string, error) {
+	token, err := tunnel.NewToken()
+	if err != nil {
+		return "", err
+	}
+	agent.TokenHash = tunnel.HashToken(token)
+	if agent.ID != 0 {
+		if err := dao.DB().Model(&models.ClusterAgent{}).Where("id = ?", agent.ID).Update("token_hash", agent.TokenHash).Error; err != nil {
+			return "", err
+		}
+		tunnel.Close(agent.Name)
+	}
+	return token, nil
+}
+
+// Touch 记录 agent 最近一次接入
+func (s *clusterAgentService) Touch(agent *models.ClusterAgent, addr string) {
+	if err := dao.DB().Model(&models.ClusterAgent{}).Where("id = ?", agent.ID).
+		Updates(map[string]any{"last_connected_at": time.Now(), "last_addr": addr}).Error; err != nil {
+		klog.V(6).Infof("记录 agent[%s] 接入时间失败: %v", agent.Name, err)
+	}
+}
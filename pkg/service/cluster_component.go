@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// 集群组件所在的命名空间与对象名称，均为 kubeadm 的默认值
+const (
+	componentNamespace      = "kube-system"
+	dnsServiceName          = "kube-dns" // CoreDNS 沿用的 Service 名称
+	dnsCorefileConfigMap    = "coredns"
+	dnsCorefileKey          = "Corefile"
+	kubeProxyName           = "kube-proxy"
+	kubeProxyConfigKey      = "config.conf"
+	controlPlaneLabel       = "tier=control-plane"
+	componentMaskedFlagMark = "******"
+)
+
+// 可修改配置的组件
+const (
+	ComponentCoreDNS   = "coredns"
+	ComponentKubeProxy = "kube-proxy"
+)
+
+var (
+	corefileKubernetes = regexp.MustCompile(`(?m)^\s*kubernetes\s+([^\s{]+)`)
+	corefileForward    = regexp.MustCompile(`(?m)^\s*(?:forward|proxy)\s+\.\s+([^{\n]+)`)
+	sensitiveFlagName  = regexp.MustCompile(`(?i)(password|secret|token)`)
+)
+
+// kubeProxyModes kube-proxy 支持的代理模式，空值为平台默认（Linux 上为 iptables）
+var kubeProxyModes = []string{"", "iptables", "ipvs", "nftables", "kernelspace"}
+
+// CoreDNSInfo 集群 DNS 服务的状态
+type CoreDNSInfo struct {
+	ServiceIP     string   `json:"service_ip,omitempty"`
+	ClusterDomain string   `json:"cluster_domain,omitempty"` // Corefile 中 kubernetes 插件的域
+	Upstreams     []string `json:"upstreams,omitempty"`      // Corefile 中 forward 的上游
+	ReadyPods     int      `json:"ready_pods"`
+	TotalPods     int      `json:"total_pods"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// CoreDNSConfig CoreDNS 的 Corefile 与运行状态
+type CoreDNSConfig struct {
+	CoreDNSInfo
+	Images          []string `json:"images,omitempty"`
+	Corefile        string   `json:"corefile"`
+	ResourceVersion string   `json:"resource_version,omitempty"` // 修改时用于并发校验
+}
+
+// KubeProxyConfig kube-proxy 的代理模式与配置
+type KubeProxyConfig struct {
+	Mode            string   `json:"mode"`          // 生效的代理模式
+	ConfigSource    string   `json:"config_source"` // configmap、args，未部署 kube-proxy 时为空
+	ClusterCIDR     string   `json:"cluster_cidr,omitempty"`
+	IPVSScheduler   string   `json:"ipvs_scheduler,omitempty"`
+	Images          []string `json:"images,omitempty"`
+	Config          string   `json:"config,omitempty"` // ConfigMap 中的 config.conf
+	ResourceVersion string   `json:"resource_version,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// ControlPlaneComponent 以静态 Pod 运行的控制面组件及其启动参数
+type ControlPlaneComponent struct {
+	Name                     string            `json:"name"` // kube-apiserver、kube-controller-manager、kube-scheduler、etcd
+	Pod                      string            `json:"pod"`
+	Node                     string            `json:"node"`
+	Image                    string            `json:"image"`
+	Ready                    bool              `json:"ready"`
+	Flags                    map[string]string `json:"flags"` // 名称中含 password、secret、token 的参数值被掩码，文件路径除外
+	EnabledAdmissionPlugins  []string          `json:"enabled_admission_plugins,omitempty"`
+	DisabledAdmissionPlugins []string          `json:"disabled_admission_plugins,omitempty"`
+}
+
+// ClusterComponents 集群核心组件的配置汇总
+type ClusterComponents struct {
+	CoreDNS      *CoreDNSConfig           `json:"coredns"`
+	KubeProxy    *KubeProxyConfig         `json:"kube_proxy"`
+	ControlPlane []*ControlPlaneComponent `json:"control_plane"`
+	Notes        []string                 `json:"notes,omitempty"`
+}
+
+type clusterComponentService struct{}
+
+// Components 汇总集群 CoreDNS、kube-proxy 与控制面组件的配置，单个组件读取失败不影响其他组件
+func (s *clusterComponentService) Components(ctx context.Context, cluster string) (*ClusterComponents, error) {
+	if kom.Cluster(cluster) == nil {
+		return nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	result := &ClusterComponents{
+		CoreDNS:   s.CoreDNS(ctx, cluster),
+		KubeProxy: s.KubeProxy(ctx, cluster),
+	}
+	result.ControlPlane = s.ControlPlane(ctx, cluster)
+	if len(result.ControlPlane) == 0 {
+		result.Notes = append(result.Notes, "kube-system 中没有以静态 Pod 运行的控制面组件，托管集群通常不暴露控制面配置")
+	}
+	if result.KubeProxy.ConfigSource == "" && result.KubeProxy.Error == "" {
+		result.Notes = append(result.Notes, "集群未部署 kube-proxy，Service 转发可能由 CNI（如 Cilium）接管")
+	}
+	return result, nil
+}
+
+// CoreDNSInfo 读取集群 DNS Service、CoreDNS 配置与 Pod 就绪情况
+func (s *clusterComponentService) CoreDNSInfo(ctx context.Context, cluster string) *CoreDNSInfo {
+	return &s.CoreDNS(ctx, cluster).CoreDNSInfo
+}
+
+// CoreDNS 读取 CoreDNS 的 Corefile、镜像与 Pod 就绪情况
+func (s *clusterComponentService) CoreDNS(ctx context.Context, cluster string) *CoreDNSConfig {
+	info := &CoreDNSConfig{}
+	k := kom.Cluster(cluster).WithContext(ctx)
+	var svc v1.Service
+	if err := k.Resource(&v1.Service{}).Namespace(componentNamespace).Name(dnsServiceName).Get(&svc).Error; err != nil {
+		info.Error = fmt.Sprintf("读取 %s/%s Service 失败: %v", componentNamespace, dnsServiceName, err)
+		return info
+	}
+	info.ServiceIP = svc.Spec.ClusterIP
+
+	var pods []v1.Pod
+	if err := k.Resource(&v1.Pod{}).Namespace(componentNamespace).WithLabelSelector(labels.SelectorFromSet(svc.Spec.Selector).String()).List(&pods).Error; err == nil {
+		info.TotalPods = len(pods)
+		for i := range pods {
+			if podReady(&pods[i]) {
+				info.ReadyPods++
+			}
+			for _, c := range pods[i].Spec.Containers {
+				info.Images = appendUnique(info.Images, c.Image)
+			}
+		}
+	}
+
+	var cm v1.ConfigMap
+	if err := k.Resource(&v1.ConfigMap{}).Namespace(componentNamespace).Name(dnsCorefileConfigMap).Get(&cm).Error; err == nil {
+		info.Corefile, info.ResourceVersion = cm.Data[dnsCorefileKey], cm.ResourceVersion
+		if m := corefileKubernetes.FindStringSubmatch(info.Corefile); m != nil {
+			info.ClusterDomain = strings.TrimSuffix(m[1], ".")
+		}
+		for _, m := range corefileForward.FindAllStringSubmatch(info.Corefile, -1) {
+			info.Upstreams = append(info.Upstreams, strings.Fields(m[1])...)
+		}
+	}
+	return info
+}
+
+// KubeProxy 读取 kube-proxy 的代理模式，优先使用 ConfigMap 中的配置，其次 DaemonSet 的启动参数
+func (s *clusterComponentService) KubeProxy(ctx context.Context, cluster string) *KubeProxyConfig {
+	info := &KubeProxyConfig{}
+	k := kom.Cluster(cluster).WithContext(ctx)
+	var ds appsv1.DaemonSet
+	dsErr := k.Resource(&appsv1.DaemonSet{}).Namespace(componentNamespace).Name(kubeProxyName).Get(&ds).Error
+	var args []string
+	if dsErr == nil {
+		for _, c := range ds.Spec.Template.Spec.Containers {
+			info.Images = appendUnique(info.Images, c.Image)
+			args = append(args, c.Command...)
+			args = append(args, c.Args...)
+		}
+	}
+
+	var cm v1.ConfigMap
+	if err := k.Resource(&v1.ConfigMap{}).Namespace(componentNamespace).Name(kubeProxyName).Get(&cm).Error; err == nil && cm.Data[kubeProxyConfigKey] != "" {
+		info.ConfigSource, info.Config, info.ResourceVersion = "configmap", cm.Data[kubeProxyConfigKey], cm.ResourceVersion
+		var cfg struct {
+			Mode        string `json:"mode"`
+			ClusterCIDR string `json:"clusterCIDR"`
+			IPVS        struct {
+				Scheduler string `json:"scheduler"`
+			} `json:"ipvs"`
+		}
+		if err := yaml.Unmarshal([]byte(info.Config), &cfg); err != nil {
+			info.Error = fmt.Sprintf("解析 kube-proxy 配置失败: %v", err)
+		}
+		info.Mode, info.ClusterCIDR, info.IPVSScheduler = cfg.Mode, cfg.ClusterCIDR, cfg.IPVS.Scheduler
+	} else if dsErr == nil {
+		info.ConfigSource = "args"
+	}
+
+	// 启动参数优先于配置文件
+	flags := parseComponentFlags(args)
+	if v, ok := flags["proxy-mode"]; ok {
+		info.Mode = v
+	}
+	if v, ok := flags["cluster-cidr"]; ok {
+		info.ClusterCIDR = v
+	}
+	if info.ConfigSource != "" && info.Mode == "" {
+		info.Mode = "iptables"
+	}
+	return info
+}
+
+// ControlPlane 读取以静态 Pod 运行的控制面组件及其启动参数，apiserver 额外解析准入插件列表
+func (s *clusterComponentService) ControlPlane(ctx context.Context, cluster string) []*ControlPlaneComponent {
+	var pods []v1.Pod
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(componentNamespace).
+		WithLabelSelector(controlPlaneLabel).List(&pods).Error
+	if err != nil {
+		return nil
+	}
+	items := make([]*ControlPlaneComponent, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		ctn := pod.Spec.Containers[0]
+		name := pod.Labels["component"]
+		if name == "" {
+			name = ctn.Name
+		}
+		item := &ControlPlaneComponent{
+			Name:  name,
+			Pod:   pod.Name,
+			Node:  pod.Spec.NodeName,
+			Image: ctn.Image,
+			Ready: podReady(pod),
+			Flags: parseComponentFlags(append(append([]string{}, ctn.Command...), ctn.Args...)),
+		}
+		for k := range item.Flags {
+			if sensitiveFlagName.MatchString(k) && !strings.HasSuffix(k, "-file") {
+				item.Flags[k] = componentMaskedFlagMark
+			}
+		}
+		if v := item.Flags["enable-admission-plugins"]; v != "" {
+			item.EnabledAdmissionPlugins = strings.Split(v, ",")
+		}
+		if v := item.Flags["disable-admission-plugins"]; v != "" {
+			item.DisabledAdmissionPlugins = strings.Split(v, ",")
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Name != items[j].Name {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].Node < items[j].Node
+	})
+	return items
+}
+
+// UpdateConfig 修改 CoreDNS 的 Corefile 或 kube-proxy 的 config.conf。
+// resourceVersion 为读取时的版本，期间被他人修改时拒绝覆盖；内容经过基本校验，避免写入导致集群 DNS 或 Service 转发中断的配置。
+// 通过 kom 更新，只读模式、审批、准入策略与操作审计均生效
+func (s *clusterComponentService) UpdateConfig(ctx context.Context, cluster, component, content, resourceVersion string) error {
+	var name, key string
+	switch component {
+	case ComponentCoreDNS:
+		name, key = dnsCorefileConfigMap, dnsCorefileKey
+		if err := validateCorefile(content); err != nil {
+			return err
+		}
+	case ComponentKubeProxy:
+		name, key = kubeProxyName, kubeProxyConfigKey
+		if err := validateKubeProxyConfig(content); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("不支持修改组件 %s 的配置", component)
+	}
+	if resourceVersion == "" {
+		return fmt.Errorf("缺少 resource_version，请先读取最新配置")
+	}
+
+	k := kom.Cluster(cluster).WithContext(ctx)
+	var cm v1.ConfigMap
+	if err := k.Resource(&v1.ConfigMap{}).Namespace(componentNamespace).Name(name).Get(&cm).Error; err != nil {
+		return err
+	}
+	if cm.ResourceVersion != resourceVersion {
+		return fmt.Errorf("%s/%s 已被修改，请刷新后重新编辑", componentNamespace, name)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[key] = content
+	return k.Resource(&cm).Namespace(componentNamespace).Name(name).Update(&cm).Error
+}
+
+// validateCorefile 校验 Corefile 的括号配对，并要求保留 kubernetes 插件，否则集群内域名将无法解析
+func validateCorefile(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("Corefile 不能为空")
+	}
+	depth := 0
+	for i, line := range strings.Split(content, "\n") {
+		if j := strings.Index(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth < 0 {
+			return fmt.Errorf("Corefile 第 %d 行存在多余的 }", i+1)
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("Corefile 的 { 与 } 不配对")
+	}
+	if !corefileKubernetes.MatchString(content) {
+		return fmt.Errorf("Corefile 中缺少 kubernetes 插件，集群内域名将无法解析")
+	}
+	return nil
+}
+
+// validateKubeProxyConfig 校验 kube-proxy 配置为合法的 KubeProxyConfiguration
+func validateKubeProxyConfig(content string) error {
+	var cfg struct {
+		Kind string `json:"kind"`
+		Mode string `json:"mode"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		return fmt.Errorf("kube-proxy 配置格式错误: %w", err)
+	}
+	if cfg.Kind != "KubeProxyConfiguration" {
+		return fmt.Errorf("kube-proxy 配置的 kind 必须为 KubeProxyConfiguration")
+	}
+	for _, m := range kubeProxyModes {
+		if cfg.Mode == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("不支持的代理模式 %s", cfg.Mode)
+}
+
+// parseComponentFlags 解析 --name=value 与 --name value 形式的启动参数，无值的参数记为 true
+func parseComponentFlags(args []string) map[string]string {
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !ok {
+			value = "true"
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				value = args[i+1]
+				i++
+			}
+		}
+		flags[name] = value
+	}
+	return flags
+}
+
+func podReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, item := range list {
+		if item == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/weibaohui/k8m/pkg/models"
 	heartbeatinterface "github.com/weibaohui/k8m/pkg/plugins/modules/heartbeat/interface"
 	"github.com/weibaohui/k8m/pkg/plugins/modules/k8sgpt/service/analysis"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"github.com/weibaohui/k8m/pkg/tunnel"
 	"github.com/weibaohui/kom/kom"
 	komaws "github.com/weibaohui/kom/kom/aws"
 	"k8s.io/apimachinery/pkg/watch"
@@ -77,6 +79,7 @@ type ClusterConfig struct {
 	NotAfter                *time.Time                     `json:"not_after,omitempty"`
 	AWSConfig               *komaws.EKSAuthConfig          `json:"aws_config,omitempty"` // AWS EKS配置信息
 	IsAWSEKS                bool                           `json:"is_aws_eks,omitempty"` // 标识是否为AWS EKS集群
+	IsAgent                 bool                           `json:"is_agent,omitempty"`   // 标识是否为通过 agent 隧道接入的集群
 
 	// kom 集群注册配置项
 	DBID     uint    `json:"id,omitempty"`        // 数据库ID
@@ -91,6 +94,7 @@ var ClusterConfigSourceFile ClusterConfigSource = "File"
 var ClusterConfigSourceDB ClusterConfigSource = "DB"
 var ClusterConfigSourceInCluster ClusterConfigSource = "InCluster"
 var ClusterConfigSourceAWS ClusterConfigSource = "AWS"
+var ClusterConfigSourceAgent ClusterConfigSource = "Agent"
 
 // 记录每个集群的watch 启动情况
 // watch 有多种类型，需要记录
@@ -718,7 +722,14 @@ func (c *clusterService) RegisterCluster(clusterConfig *ClusterConfig) (bool, er
 
 		if clusterConfig.IsInCluster {
 			// InCluster 模式
-			if _, err := kom.Clusters().RegisterInCluster(); err != nil {
+			restConfig, err := rest.InClusterConfig()
+			if err != nil {
+				klog.V(4).Infof("注册集群[%s]失败: %v", clusterID, err)
+				clusterConfig.Err = err.Error()
+				return false, err // 保持"连接中"状态
+			}
+			applyKubeClientOptions(restConfig, clusterID)
+			if _, err := kom.Clusters().RegisterByConfigWithID(restConfig, clusterID); err != nil {
 				klog.V(4).Infof("注册集群[%s]失败: %v", clusterID, err)
 				clusterConfig.Err = err.Error()
 				return false, err // 保持"连接中"状态
@@ -726,6 +737,7 @@ func (c *clusterService) RegisterCluster(clusterConfig *ClusterConfig) (bool, er
 		} else {
 			// 集群外模式
 			opts := c.buildRegisterOptions(clusterConfig)
+			applyKubeClientOptions(clusterConfig.GetRestConfig(), clusterID)
 			if _, err := kom.Clusters().RegisterByConfigWithID(clusterConfig.GetRestConfig(), clusterID, opts...); err != nil {
 				klog.V(4).Infof("注册集群[%s]失败: %v", clusterID, err)
 				clusterConfig.Err = err.Error()
@@ -771,6 +783,9 @@ func (c *clusterService) LoadRestConfig(config *ClusterConfig) error {
 			config.ClusterConnectStatus = constants.ClusterConnectStatusFailed
 			return err
 		}
+	} else if config.IsAgent {
+		// agent 模式，经反向隧道访问集群
+		restConfig = tunnel.RestConfig(config.ContextName)
 	} else {
 		// 集群外模式
 		lines := strings.Split(string(config.kubeConfig), "\n")
@@ -967,6 +982,18 @@ func (c *clusterService) RegisterAWSEKSCluster(config *komaws.EKSAuthConfig) (*C
 	return clusterConfig, nil
 }
 
+// applyKubeClientOptions 按配置限制访问 apiserver 的客户端 QPS/Burst，并为请求记录指标与链路
+func applyKubeClientOptions(restConfig *rest.Config, clusterID string) {
+	if cfg := flag.Init(); cfg.KubeClientQPS > 0 {
+		restConfig.QPS = float32(cfg.KubeClientQPS)
+		restConfig.Burst = cfg.KubeClientBurst
+		if cfg.KubeClientBurst <= 0 {
+			restConfig.Burst = cfg.KubeClientQPS * 2
+		}
+	}
+	restConfig.Wrap(telemetry.WrapKubeTransport(clusterID))
+}
+
 // buildRegisterOptions 根据 ClusterConfig 构建 kom 注册选项
 func (c *clusterService) buildRegisterOptions(clusterConfig *ClusterConfig) []kom.RegisterOption {
 	klog.V(6).Infof("开始构建集群 %s 的注册选项配置", clusterConfig.ClusterID)
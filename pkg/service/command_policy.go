@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/admission"
+	"github.com/weibaohui/k8m/pkg/models"
+	"k8s.io/klog/v2"
+)
+
+// 命令策略动作
+const (
+	CommandPolicyDeny  = "deny"
+	CommandPolicyAllow = "allow"
+)
+
+// commandPolicyReload 已启用策略的缓存时间
+const commandPolicyReload = 30 * time.Second
+
+type commandPolicyService struct {
+	lock     sync.RWMutex
+	loadedAt time.Time
+	policies []*compiledCommandPolicy
+}
+
+type compiledCommandPolicy struct {
+	policy *models.CommandPolicy
+	re     *regexp.Regexp
+}
+
+// Invalidate 策略变更后清空缓存
+func (s *commandPolicyService) Invalidate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.loadedAt = time.Time{}
+	s.policies = nil
+}
+
+func (s *commandPolicyService) enabled() []*compiledCommandPolicy {
+	s.lock.RLock()
+	if time.Since(s.loadedAt) < commandPolicyReload {
+		defer s.lock.RUnlock()
+		return s.policies
+	}
+	s.lock.RUnlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if time.Since(s.loadedAt) < commandPolicyReload {
+		return s.policies
+	}
+	var list []*models.CommandPolicy
+	if err := dao.DB().Where("enabled = ?", true).Order("id").Find(&list).Error; err != nil {
+		klog.Errorf("加载命令策略失败: %v", err)
+		return s.policies
+	}
+	policies := make([]*compiledCommandPolicy, 0, len(list))
+	for _, p := range list {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			klog.Errorf("命令策略[%s]正则无效，已跳过: %v", p.Name, err)
+			continue
+		}
+		policies = append(policies, &compiledCommandPolicy{policy: p, re: re})
+	}
+	s.policies = policies
+	s.loadedAt = time.Now()
+	return s.policies
+}
+
+// Check 校验命令是否符合适用于该集群、来源的策略。deny 策略优先；存在 allow 策略时命令必须命中其中之一
+func (s *commandPolicyService) Check(cluster, scope, cmdline string) error {
+	cmdline = strings.TrimSpace(cmdline)
+	if cmdline == "" {
+		return nil
+	}
+	hasAllow, allowed := false, false
+	for _, cp := range s.enabled() {
+		p := cp.policy
+		if !admission.MatchList(p.Scopes, scope) || !admission.MatchList(p.Clusters, cluster) {
+			continue
+		}
+		switch p.Action {
+		case CommandPolicyDeny:
+			if cp.re.MatchString(cmdline) {
+				return fmt.Errorf("命令被策略[%s]禁止: %s", p.Name, cmdline)
+			}
+		case CommandPolicyAllow:
+			hasAllow = true
+			if cp.re.MatchString(cmdline) {
+				allowed = true
+			}
+		}
+	}
+	if hasAllow && !allowed {
+		return fmt.Errorf("命令不在策略允许范围内: %s", cmdline)
+	}
+	return nil
+}
+
+// RecordViolation 将被策略拦截的命令写入操作日志
+func (s *commandPolicyService) RecordViolation(cluster, ns, pod, container, username, scope, cmdline string, err error) {
+	roles, _ := UserService().GetRolesByUserName(username)
+	OperationLogService().Add(&models.OperationLog{
+		Action:       "command-denied",
+		Cluster:      cluster,
+		Kind:         "Pod",
+		Namespace:    ns,
+		Name:         pod,
+		UserName:     username,
+		Role:         strings.Join(roles, ","),
+		ActionResult: err.Error(),
+	}, map[string]string{"scope": scope, "container": container, "command": cmdline})
+}
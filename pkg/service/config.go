@@ -1,8 +1,11 @@
 package service
 
 import (
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/bundle"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/flag"
 	"github.com/weibaohui/k8m/pkg/models"
@@ -27,6 +30,9 @@ func (s *configService) GetConfig() (*models.Config, error) {
 }
 
 func (s *configService) UpdateConfig(config *models.Config) error {
+	if _, err := bundle.ParseGlobs(config.DiagnosticsFileGlobs); err != nil {
+		return err
+	}
 
 	err := s.db.Save(config).Error
 	if err != nil {
@@ -73,6 +79,27 @@ func (s *configService) UpdateFlagFromDBConfig() error {
 	if cfg.ResourceCacheTimeout == 0 {
 		cfg.ResourceCacheTimeout = 60
 	}
+	cfg.ExecAllowlistPodExec = m.ExecAllowlistPodExec
+	cfg.ExecAllowlistClusterAdmin = m.ExecAllowlistClusterAdmin
+	cfg.FileUploadConcurrency = m.FileUploadConcurrency
+	if cfg.FileUploadConcurrency <= 0 {
+		cfg.FileUploadConcurrency = 5
+	}
+	cfg.FileUploadRetries = max(m.FileUploadRetries, 0)
+	cfg.UploadStagingQuotaMB = max(m.UploadStagingQuotaMB, 0)
+	cfg.DiagnosticsFileGlobs = m.DiagnosticsFileGlobs
+	cfg.PasswordMinLength = max(m.PasswordMinLength, 0)
+	cfg.PasswordMinClasses = min(max(m.PasswordMinClasses, 0), 4)
+	cfg.PasswordMaxAgeDays = max(m.PasswordMaxAgeDays, 0)
+	cfg.PasswordHistory = max(m.PasswordHistory, 0)
+	cfg.TOTPSkew = min(max(m.TOTPSkew, 0), 10)
+	cfg.JwtLeewaySeconds = max(m.JwtLeewaySeconds, 0)
+	cfg.ClockSkewThreshold = m.ClockSkewThreshold
+	if cfg.ClockSkewThreshold <= 0 {
+		cfg.ClockSkewThreshold = 30
+	}
+	cfg.NTPServer = strings.TrimSpace(m.NTPServer)
+	cfg.EnableGraphQL = m.EnableGraphQL
 
 	// JwtTokenSecret 暂不启用，因为前端也要处理
 	// cfg.JwtTokenSecret = m.JwtTokenSecret
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// 环境变量来源
+const (
+	EnvSourceValue            = "value"            // env 中直接填写的值
+	EnvSourceConfigMapKey     = "configMapKeyRef"  // env.valueFrom.configMapKeyRef
+	EnvSourceSecretKey        = "secretKeyRef"     // env.valueFrom.secretKeyRef
+	EnvSourceField            = "fieldRef"         // env.valueFrom.fieldRef
+	EnvSourceResourceField    = "resourceFieldRef" // env.valueFrom.resourceFieldRef
+	EnvSourceConfigMap        = "configMap"        // envFrom.configMapRef
+	EnvSourceSecret           = "secret"           // envFrom.secretRef
+	envMaskedValue            = "******"
+	envRuntimeValue           = "(运行时确定)"
+	envFromInvalidKeySkipNote = "键名不是合法的环境变量名，容器启动时会被跳过"
+)
+
+// envVarNameRegexp kubelet 接受的环境变量名
+var envVarNameRegexp = regexp.MustCompile(`^[-._a-zA-Z][-._a-zA-Z0-9]*$`)
+
+// EnvVarView 容器中一个环境变量的来源与解析后的值
+type EnvVarView struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`                 // 解析后的值，Secret 中的值被掩码
+	Source     string `json:"source"`                // 来源，见 EnvSource 常量
+	SourceName string `json:"source_name,omitempty"` // 引用的 ConfigMap/Secret 名称
+	Key        string `json:"key,omitempty"`         // 引用的键、字段路径或资源名
+	Masked     bool   `json:"masked,omitempty"`      // 值来自 Secret，已掩码
+	Editable   bool   `json:"editable"`              // 是否为 env 中直接填写的值，可通过环境变量修改接口直接修改
+	Overridden bool   `json:"overridden,omitempty"`  // 被同名的后续定义覆盖，不是生效值
+	Error      string `json:"error,omitempty"`       // 引用无法解析的原因，如 ConfigMap 不存在
+}
+
+// EnvPatch 修改容器 env 中直接填写的环境变量
+type EnvPatch struct {
+	Set    map[string]string `json:"set,omitempty"`    // 新增或修改，原先引用 ConfigMap/Secret 的同名变量改为直接填写的值
+	Remove []string          `json:"remove,omitempty"` // 从 env 中删除，envFrom 导入的变量无法单独删除
+}
+
+type containerEnvService struct{}
+
+// podTemplatePath 各工作负载中 Pod 模板的路径，Pod 本身返回空路径
+func podTemplatePath(kind string) ([]string, error) {
+	switch kind {
+	case "Pod":
+		return nil, nil
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "Job":
+		return []string{"spec", "template"}, nil
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template"}, nil
+	}
+	return nil, fmt.Errorf("不支持的资源类型: %s", kind)
+}
+
+// getPodTemplate 读取工作负载的 Pod 模板，Pod 直接使用自身的 metadata 与 spec
+func (s *containerEnvService) getPodTemplate(ctx context.Context, cluster, group, version, kind, ns, name string) (*v1.PodTemplateSpec, error) {
+	path, err := podTemplatePath(kind)
+	if err != nil {
+		return nil, err
+	}
+	var item *unstructured.Unstructured
+	err = kom.Cluster(cluster).WithContext(ctx).CRD(group, version, kind).Namespace(ns).Name(name).Get(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	raw := item.Object
+	if len(path) > 0 {
+		m, found, err := unstructured.NestedMap(item.Object, path...)
+		if err != nil || !found {
+			return nil, fmt.Errorf("%s %s/%s 中未找到 Pod 模板", kind, ns, name)
+		}
+		raw = m
+	}
+	var tpl v1.PodTemplateSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &tpl); err != nil {
+		return nil, err
+	}
+	if tpl.Namespace == "" {
+		tpl.Namespace = ns
+	}
+	return &tpl, nil
+}
+
+// findContainer 查找容器，init 表示是否为 initContainer
+func findContainer(spec *v1.PodSpec, name string) (*v1.Container, bool, error) {
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i], false, nil
+		}
+	}
+	for i := range spec.InitContainers {
+		if spec.InitContainers[i].Name == name {
+			return &spec.InitContainers[i], true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("容器 %s 不存在", name)
+}
+
+// envSourceCache 一次解析中读取过的 ConfigMap/Secret，避免重复请求
+type envSourceCache struct {
+	ctx       context.Context
+	cluster   string
+	namespace string
+	data      map[string]map[string]string
+	errs      map[string]error
+}
+
+func (c *envSourceCache) get(kind, name string) (map[string]string, error) {
+	key := kind + "/" + name
+	if data, ok := c.data[key]; ok {
+		return data, c.errs[key]
+	}
+	k := kom.Cluster(c.cluster).WithContext(c.ctx)
+	data := map[string]string{}
+	var err error
+	if kind == EnvSourceSecret {
+		var secret v1.Secret
+		err = k.Resource(&secret).Namespace(c.namespace).Name(name).Get(&secret).Error
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
+		for k, v := range secret.StringData {
+			data[k] = v
+		}
+	} else {
+		var cm v1.ConfigMap
+		err = k.Resource(&cm).Namespace(c.namespace).Name(name).Get(&cm).Error
+		for k, v := range cm.Data {
+			data[k] = v
+		}
+		for k, v := range cm.BinaryData {
+			data[k] = string(v)
+		}
+	}
+	c.data[key], c.errs[key] = data, err
+	return data, err
+}
+
+// Resolve 列出容器的环境变量及其来源，解析 ConfigMap/Secret 引用、fieldRef 与 resourceFieldRef。
+// 顺序与 kubelet 一致：先 envFrom 后 env，同名时后者生效，被覆盖的条目标记 overridden。
+// Secret 中的值一律掩码；依赖 Pod 运行时信息（如 Pod IP、节点名）的 fieldRef 在工作负载上无法解析，值标记为运行时确定
+func (s *containerEnvService) Resolve(ctx context.Context, cluster, group, version, kind, ns, name, container string) ([]*EnvVarView, error) {
+	tpl, err := s.getPodTemplate(ctx, cluster, group, version, kind, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	ctn, _, err := findContainer(&tpl.Spec, container)
+	if err != nil {
+		return nil, err
+	}
+	cache := &envSourceCache{ctx: ctx, cluster: cluster, namespace: ns, data: map[string]map[string]string{}, errs: map[string]error{}}
+
+	var items []*EnvVarView
+	for _, from := range ctn.EnvFrom {
+		items = append(items, s.resolveEnvFrom(cache, from)...)
+	}
+	for _, env := range ctn.Env {
+		items = append(items, s.resolveEnv(cache, tpl, ctn, kind == "Pod", env))
+	}
+
+	last := map[string]int{}
+	for i, item := range items {
+		last[item.Name] = i
+	}
+	for i, item := range items {
+		item.Overridden = last[item.Name] != i
+	}
+	return items, nil
+}
+
+func (s *containerEnvService) resolveEnvFrom(cache *envSourceCache, from v1.EnvFromSource) []*EnvVarView {
+	source, sourceName, optional := EnvSourceConfigMap, "", false
+	switch {
+	case from.ConfigMapRef != nil:
+		sourceName, optional = from.ConfigMapRef.Name, from.ConfigMapRef.Optional != nil && *from.ConfigMapRef.Optional
+	case from.SecretRef != nil:
+		source = EnvSourceSecret
+		sourceName, optional = from.SecretRef.Name, from.SecretRef.Optional != nil && *from.SecretRef.Optional
+	default:
+		return nil
+	}
+	data, err := cache.get(source, sourceName)
+	if err != nil {
+		msg := err.Error()
+		if optional {
+			msg = "可选引用，无法读取时不影响容器启动: " + msg
+		}
+		return []*EnvVarView{{Name: from.Prefix + "*", Source: source, SourceName: sourceName, Error: msg}}
+	}
+
+	keys := slices.Sorted(maps.Keys(data))
+	items := make([]*EnvVarView, 0, len(keys))
+	for _, key := range keys {
+		item := &EnvVarView{Name: from.Prefix + key, Value: data[key], Source: source, SourceName: sourceName, Key: key}
+		if source == EnvSourceSecret {
+			item.Value, item.Masked = envMaskedValue, true
+		}
+		if !envVarNameRegexp.MatchString(item.Name) {
+			item.Error = envFromInvalidKeySkipNote
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (s *containerEnvService) resolveEnv(cache *envSourceCache, tpl *v1.PodTemplateSpec, ctn *v1.Container, isPod bool, env v1.EnvVar) *EnvVarView {
+	item := &EnvVarView{Name: env.Name, Value: env.Value, Source: EnvSourceValue}
+	from := env.ValueFrom
+	if from == nil {
+		item.Editable = true
+		return item
+	}
+
+	switch {
+	case from.ConfigMapKeyRef != nil:
+		ref := from.ConfigMapKeyRef
+		item.Source, item.SourceName, item.Key = EnvSourceConfigMapKey, ref.Name, ref.Key
+		item.Value, item.Error = lookupEnvKey(cache, EnvSourceConfigMap, ref.Name, ref.Key, ref.Optional)
+	case from.SecretKeyRef != nil:
+		ref := from.SecretKeyRef
+		item.Source, item.SourceName, item.Key = EnvSourceSecretKey, ref.Name, ref.Key
+		_, item.Error = lookupEnvKey(cache, EnvSourceSecret, ref.Name, ref.Key, ref.Optional)
+		if item.Error == "" {
+			item.Value, item.Masked = envMaskedValue, true
+		}
+	case from.FieldRef != nil:
+		item.Source, item.Key = EnvSourceField, from.FieldRef.FieldPath
+		item.Value = resolveFieldRef(tpl, isPod, from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		item.Source, item.Key = EnvSourceResourceField, from.ResourceFieldRef.Resource
+		item.Value, item.Error = resolveResourceFieldRef(ctn, from.ResourceFieldRef)
+	}
+	return item
+}
+
+// lookupEnvKey 读取 ConfigMap/Secret 中的键，optional 的引用缺失时值为空且不报错，与 kubelet 行为一致
+func lookupEnvKey(cache *envSourceCache, source, name, key string, optional *bool) (string, string) {
+	data, err := cache.get(source, name)
+	if err != nil {
+		if optional != nil && *optional {
+			return "", ""
+		}
+		return "", err.Error()
+	}
+	v, ok := data[key]
+	if !ok && (optional == nil || !*optional) {
+		return "", fmt.Sprintf("%s 中不存在键 %s", name, key)
+	}
+	return v, ""
+}
+
+// resolveFieldRef 解析 Downward API 字段，工作负载上只能解析模板中确定的字段
+func resolveFieldRef(tpl *v1.PodTemplateSpec, isPod bool, path string) string {
+	if strings.HasPrefix(path, "metadata.labels['") && strings.HasSuffix(path, "']") {
+		return tpl.Labels[strings.TrimSuffix(strings.TrimPrefix(path, "metadata.labels['"), "']")]
+	}
+	if strings.HasPrefix(path, "metadata.annotations['") && strings.HasSuffix(path, "']") {
+		return tpl.Annotations[strings.TrimSuffix(strings.TrimPrefix(path, "metadata.annotations['"), "']")]
+	}
+	switch path {
+	case "metadata.namespace":
+		return tpl.Namespace
+	case "spec.serviceAccountName":
+		if tpl.Spec.ServiceAccountName == "" {
+			return "default"
+		}
+		return tpl.Spec.ServiceAccountName
+	}
+	if !isPod {
+		return envRuntimeValue
+	}
+
+	switch path {
+	case "metadata.name":
+		return tpl.Name
+	case "metadata.uid":
+		return string(tpl.UID)
+	case "spec.nodeName":
+		return tpl.Spec.NodeName
+	}
+	return envRuntimeValue
+}
+
+// resolveResourceFieldRef 解析容器 requests/limits，未设置 limits 时由节点可分配资源决定
+func resolveResourceFieldRef(ctn *v1.Container, ref *v1.ResourceFieldSelector) (string, string) {
+	parts := strings.SplitN(ref.Resource, ".", 2)
+	if len(parts) != 2 {
+		return "", fmt.Sprintf("不支持的资源字段 %s", ref.Resource)
+	}
+	list := ctn.Resources.Limits
+	if parts[0] == "requests" {
+		list = ctn.Resources.Requests
+	}
+	q, ok := list[v1.ResourceName(parts[1])]
+	if !ok {
+		if parts[0] == "limits" {
+			return envRuntimeValue, ""
+		}
+		return "0", ""
+	}
+	divisor := ref.Divisor
+	if divisor.IsZero() {
+		divisor = resource.MustParse("1")
+	}
+	// 与 kubelet 一致，向上取整
+	value := (q.MilliValue() + divisor.MilliValue() - 1) / divisor.MilliValue()
+	return fmt.Sprintf("%d", value), ""
+}
+
+// Patch 修改 env 中直接填写的环境变量，通过 kom 以策略合并方式更新，权限、审计、准入策略等回调均生效
+func (s *containerEnvService) Patch(ctx context.Context, cluster, group, version, kind, ns, name, container string, req *EnvPatch) error {
+	if kind == "Pod" {
+		return fmt.Errorf("Pod 的环境变量不可修改，请修改其所属的工作负载")
+	}
+	if len(req.Set) == 0 && len(req.Remove) == 0 {
+		return fmt.Errorf("没有需要修改的环境变量")
+	}
+	path, err := podTemplatePath(kind)
+	if err != nil {
+		return err
+	}
+	tpl, err := s.getPodTemplate(ctx, cluster, group, version, kind, ns, name)
+	if err != nil {
+		return err
+	}
+	ctn, init, err := findContainer(&tpl.Spec, container)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, env := range ctn.Env {
+		existing[env.Name] = true
+	}
+
+	var envs []map[string]any
+	for _, key := range slices.Sorted(maps.Keys(req.Set)) {
+		if !envVarNameRegexp.MatchString(key) {
+			return fmt.Errorf("环境变量名'%s'无效: 只能包含字母、数字、_、-或.，且不能以数字开头", key)
+		}
+		// valueFrom 显式置空，原先引用 ConfigMap/Secret 的变量改为直接填写的值
+		envs = append(envs, map[string]any{"name": key, "value": req.Set[key], "valueFrom": nil})
+	}
+	for _, key := range req.Remove {
+		if _, ok := req.Set[key]; ok {
+			return fmt.Errorf("环境变量 %s 不能同时修改和删除", key)
+		}
+		if !existing[key] {
+			return fmt.Errorf("环境变量 %s 不在 env 中，envFrom 导入的变量无法单独删除", key)
+		}
+		envs = append(envs, map[string]any{"name": key, "$patch": "delete"})
+	}
+
+	field := "containers"
+	if init {
+		field = "initContainers"
+	}
+	patch := map[string]any{
+		"spec": map[string]any{
+			field: []map[string]any{{"name": container, "env": envs}},
+		},
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		patch = map[string]any{path[i]: patch}
+	}
+	var item any
+	return kom.Cluster(cluster).WithContext(ctx).CRD(group, version, kind).Namespace(ns).Name(name).
+		Patch(&item, types.StrategicMergePatchType, utils.ToJSON(patch)).Error
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/conditions"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type crConditionService struct{}
+
+// CRConditionSummary 自定义资源状态看板，附带生效的配置
+type CRConditionSummary struct {
+	*conditions.Summary
+	Config *models.CRDConditionConfig `json:"config"`
+}
+
+// Config 读取 CRD 的看板配置，未配置时返回默认配置（ID 为 0）
+func (s *crConditionService) Config(group, kind string) (*models.CRDConditionConfig, error) {
+	var m models.CRDConditionConfig
+	// group 为保留字，使用 map 条件由 gorm 按数据库方言转义列名
+	err := dao.DB().Where(map[string]any{"group": group, "kind": kind}).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.CRDConditionConfig{Group: group, Kind: kind, Primary: conditions.DefaultPrimary}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// SaveConfig 按 group、kind 新增或更新看板配置
+func (s *crConditionService) SaveConfig(m *models.CRDConditionConfig) error {
+	if m.Kind == "" {
+		return fmt.Errorf("kind 不能为空")
+	}
+	if err := conditions.ValidateFields(conditions.SplitList(m.Fields)); err != nil {
+		return err
+	}
+	existing, err := s.Config(m.Group, m.Kind)
+	if err != nil {
+		return err
+	}
+	m.ID = existing.ID
+	if m.ID == 0 {
+		return dao.DB().Create(m).Error
+	}
+	return dao.DB().Model(&models.CRDConditionConfig{}).Where("id = ?", m.ID).
+		Updates(map[string]any{"types": m.Types, "primary": m.Primary, "fields": m.Fields}).Error
+}
+
+func (s *crConditionService) options(m *models.CRDConditionConfig) (*conditions.Options, error) {
+	var reverse []*models.ConditionReverse
+	if err := dao.DB().Model(&models.ConditionReverse{}).Select("name").Where("enabled = ?", true).Find(&reverse).Error; err != nil {
+		return nil, err
+	}
+	opts := &conditions.Options{
+		Types:   conditions.SplitList(m.Types),
+		Primary: m.Primary,
+		Fields:  conditions.SplitList(m.Fields),
+	}
+	for _, r := range reverse {
+		opts.Reverse = append(opts.Reverse, r.Name)
+	}
+	return opts, nil
+}
+
+func (s *crConditionService) list(ctx context.Context, cluster, group, version, kind, ns string) ([]*unstructured.Unstructured, error) {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	sql := k.WithContext(ctx).GVK(group, version, kind).RemoveManagedFields()
+	if ns != "" {
+		sql = sql.Namespace(ns)
+	} else {
+		sql = sql.AllNamespace()
+	}
+	var items []*unstructured.Unstructured
+	if err := sql.List(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Summary 汇总集群中某类资源的条件，ns 为空时汇总全部命名空间
+func (s *crConditionService) Summary(ctx context.Context, cluster, group, version, kind, ns string) (*CRConditionSummary, error) {
+	cfg, err := s.Config(group, kind)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := s.options(cfg)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.list(ctx, cluster, group, version, kind, ns)
+	if err != nil {
+		return nil, err
+	}
+	return &CRConditionSummary{Summary: conditions.Summarize(items, opts), Config: cfg}, nil
+}
+
+// Objects 按条件或状态字段下钻，返回对应的对象
+func (s *crConditionService) Objects(ctx context.Context, cluster, group, version, kind, ns string, q *conditions.Query) ([]*conditions.Object, error) {
+	if q.Type == "" && q.Field == "" {
+		return nil, fmt.Errorf("请指定条件类型或状态字段")
+	}
+	if q.Field != "" {
+		if err := conditions.ValidateFields([]string{q.Field}); err != nil {
+			return nil, err
+		}
+	}
+	items, err := s.list(ctx, cluster, group, version, kind, ns)
+	if err != nil {
+		return nil, err
+	}
+	return conditions.Filter(items, q), nil
+}
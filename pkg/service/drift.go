@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/drift"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// 基线来源
+const (
+	DriftSourceUpload = "upload"
+	DriftSourceGit    = "git"
+)
+
+// 比对状态
+const (
+	DriftStatusInSync  = "in_sync"
+	DriftStatusDrifted = "drifted"
+	DriftStatusMissing = "missing"
+	DriftStatusError   = "error"
+)
+
+// maxBaselineArchiveSize Git 归档下载大小上限
+const maxBaselineArchiveSize = 50 << 20
+
+type driftService struct{}
+
+// Start 每分钟检查到期的基线并执行比对，多实例部署时通过分布式锁保证同一基线只由一个实例比对
+func (d *driftService) Start() {
+	holder, _ := os.Hostname()
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", func() {
+		var list []*models.DriftBaseline
+		if err := dao.DB().Where("check_interval > 0").Find(&list).Error; err != nil {
+			klog.V(6).Infof("读取漂移检测基线失败: %v", err)
+			return
+		}
+		now := time.Now()
+		for _, b := range list {
+			interval := time.Duration(b.CheckInterval) * time.Minute
+			if b.LastCheckedAt != nil && now.Sub(*b.LastCheckedAt) < interval {
+				continue
+			}
+			if kom.Cluster(b.Cluster) == nil {
+				continue
+			}
+			ok, err := LockService().TryAcquire(fmt.Sprintf("drift-baseline-%d", b.ID), holder, interval)
+			if err != nil || !ok {
+				continue
+			}
+			if _, err := d.Check(utils.GetContextWithAdmin(), b.ID); err != nil {
+				klog.V(6).Infof("基线[%d]漂移检测失败: %v", b.ID, err)
+			}
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增漂移检测定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+	klog.V(6).Infof("新增漂移检测定时任务【@every 1m】")
+}
+
+// FetchGit 下载 Git 来源的清单，支持原始文件地址与 tar.gz、zip 归档地址
+func (d *driftService) FetchGit(ctx context.Context, b *models.DriftBaseline) (string, error) {
+	u, err := url.Parse(b.GitURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("Git 地址格式错误，应为 http(s) 原始文件或归档地址: %s", b.GitURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.GitURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if b.GitToken != "" {
+		req.Header.Set("Authorization", "Bearer "+b.GitToken)
+	}
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("下载基线清单失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("下载基线清单失败: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBaselineArchiveSize+1))
+	if err != nil {
+		return "", fmt.Errorf("下载基线清单失败: %w", err)
+	}
+	if len(data) > maxBaselineArchiveSize {
+		return "", fmt.Errorf("基线清单超过大小限制")
+	}
+
+	files, err := utils.ExtractManifestFiles(path.Base(u.Path), data)
+	if err != nil {
+		return "", err
+	}
+	// 归档中的文件带有仓库名-分支名的顶层目录，按路径片段匹配 GitPath
+	dir := strings.Trim(b.GitPath, "/")
+	var docs []string
+	for _, f := range files {
+		if dir != "" && !strings.Contains("/"+f.Name, "/"+dir+"/") {
+			continue
+		}
+		docs = append(docs, f.Content)
+	}
+	if len(docs) == 0 {
+		return "", fmt.Errorf("未找到清单文件")
+	}
+	return strings.Join(docs, "\n---\n"), nil
+}
+
+// objects 解析基线中的对象，未指定命名空间的对象归入基线命名空间
+func (d *driftService) objects(b *models.DriftBaseline) ([]*unstructured.Unstructured, error) {
+	objs, err := ManifestService().Parse(b.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	k := kom.Cluster(b.Cluster)
+	if k == nil {
+		return nil, fmt.Errorf("集群 %s 未连接", b.Cluster)
+	}
+	for _, obj := range objs {
+		_, namespaced, _ := k.Tools().GetGVRByGVK(obj.GroupVersionKind())
+		if namespaced && obj.GetNamespace() == "" {
+			obj.SetNamespace(b.Namespace)
+		}
+	}
+	return objs, nil
+}
+
+// Check 比对基线与集群中的实际对象，保存每个资源的结果并更新基线状态
+func (d *driftService) Check(ctx context.Context, id uint) ([]*models.DriftResult, error) {
+	var b models.DriftBaseline
+	if err := dao.DB().First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	results, err := d.check(ctx, &b)
+
+	now := time.Now()
+	updates := map[string]any{"last_checked_at": now, "message": "", "drift_count": 0}
+	if err != nil {
+		updates["status"] = DriftStatusError
+		updates["message"] = err.Error()
+	} else {
+		count := 0
+		for _, r := range results {
+			if r.Status != DriftStatusInSync {
+				count++
+			}
+		}
+		updates["drift_count"] = count
+		updates["status"] = DriftStatusInSync
+		if count > 0 {
+			updates["status"] = DriftStatusDrifted
+		}
+		if b.Source == DriftSourceGit {
+			updates["manifests"] = b.Manifests
+		}
+	}
+	if saveErr := dao.DB().Model(&models.DriftBaseline{}).Where("id = ?", b.ID).Updates(updates).Error; saveErr != nil {
+		klog.Errorf("更新基线[%d]状态失败: %v", b.ID, saveErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	txErr := dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("baseline_id = ?", b.ID).Delete(&models.DriftResult{}).Error; err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return nil
+		}
+		return tx.Create(&results).Error
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("保存比对结果失败: %w", txErr)
+	}
+	return results, nil
+}
+
+func (d *driftService) check(ctx context.Context, b *models.DriftBaseline) ([]*models.DriftResult, error) {
+	if b.Source == DriftSourceGit {
+		manifests, err := d.FetchGit(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		b.Manifests = manifests
+	}
+	objs, err := d.objects(b)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var results []*models.DriftResult
+	for _, obj := range objs {
+		r := &models.DriftResult{
+			BaselineID: b.ID,
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			CheckedAt:  now,
+		}
+		results = append(results, r)
+
+		if obj.GetNamespace() != "" && obj.GetNamespace() != b.Namespace {
+			r.Status = DriftStatusError
+			r.Message = fmt.Sprintf("资源不属于基线命名空间 %s", b.Namespace)
+			continue
+		}
+		gvk := obj.GroupVersionKind()
+		var live *unstructured.Unstructured
+		err := kom.Cluster(b.Cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).
+			Namespace(obj.GetNamespace()).Name(obj.GetName()).Get(&live).Error
+		switch {
+		case apierrors.IsNotFound(err):
+			r.Status = DriftStatusMissing
+			continue
+		case err != nil:
+			r.Status = DriftStatusError
+			r.Message = err.Error()
+			continue
+		}
+
+		fields := drift.Compare(obj.Object, live.Object)
+		if len(fields) == 0 {
+			r.Status = DriftStatusInSync
+			continue
+		}
+		r.Status = DriftStatusDrifted
+		data, _ := json.Marshal(fields)
+		r.Fields = string(data)
+	}
+	return results, nil
+}
+
+// Revert 将单个资源恢复为基线中的定义：资源不存在时重新创建，否则以合并补丁覆盖基线中声明的字段
+func (d *driftService) Revert(ctx context.Context, id uint, kind, namespace, name string) error {
+	var b models.DriftBaseline
+	if err := dao.DB().First(&b, id).Error; err != nil {
+		return err
+	}
+	objs, err := d.objects(&b)
+	if err != nil {
+		return err
+	}
+	var target *unstructured.Unstructured
+	for _, obj := range objs {
+		if obj.GetKind() == kind && obj.GetNamespace() == namespace && obj.GetName() == name {
+			target = obj
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("基线中不存在资源 %s %s/%s", kind, namespace, name)
+	}
+	if target.GetNamespace() != "" && target.GetNamespace() != b.Namespace {
+		return fmt.Errorf("资源不属于基线命名空间 %s", b.Namespace)
+	}
+
+	gvk := target.GroupVersionKind()
+	var live *unstructured.Unstructured
+	err = kom.Cluster(b.Cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).
+		Namespace(namespace).Name(name).Get(&live).Error
+	if apierrors.IsNotFound(err) {
+		_, err = ManifestService().Apply(ctx, b.Cluster, target.DeepCopy())
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(drift.Desired(target.Object))
+	if err != nil {
+		return err
+	}
+	return kom.Cluster(b.Cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).
+		Namespace(namespace).Name(name).Patch(&live, types.MergePatchType, string(patch)).Error
+}
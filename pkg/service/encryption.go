@@ -0,0 +1,185 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/envelope"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm"
+	"k8s.io/klog/v2"
+)
+
+type encryptionService struct{}
+
+// 密文版本统计中非信封密文的分类
+const (
+	EncryptionVersionLegacy    = "legacy"    // 内置静态密钥加密
+	EncryptionVersionPlaintext = "plaintext" // 明文
+)
+
+// sealedColumn 加密存储的敏感字段
+type sealedColumn struct {
+	Name   string // 展示名称
+	Model  any
+	Column string
+	Where  string // 额外条件，为空表示全部记录
+	// Legacy 为 true 表示该字段此前使用内置静态密钥加密，否则此前以明文保存
+	Legacy bool
+}
+
+var sealedColumns = []sealedColumn{
+	{Name: "集群 kubeconfig", Model: &models.KubeConfig{}, Column: "content"},
+	{Name: "集群 Token", Model: &models.KubeConfig{}, Column: "token"},
+	{Name: "AWS Access Key", Model: &models.KubeConfig{}, Column: "access_key", Legacy: true},
+	{Name: "AWS Secret Access Key", Model: &models.KubeConfig{}, Column: "secret_access_key", Legacy: true},
+	{Name: "镜像仓库凭据", Model: &models.RegistryCredential{}, Column: "password", Legacy: true},
+	{Name: "漂移基线 Git 令牌", Model: &models.DriftBaseline{}, Column: "git_token", Legacy: true},
+	{Name: "LDAP 管理员密码", Model: &models.LDAPConfig{}, Column: "bind_password", Legacy: true},
+	{Name: "入站 webhook 签名密钥", Model: &models.InboundWebhook{}, Column: "secret", Legacy: true},
+	{Name: "待审批的 Secret 变更", Model: &models.ChangeRequest{}, Column: "payload", Where: "kind = 'Secret'", Legacy: true},
+}
+
+// EncryptionColumnStatus 单个敏感字段按主密钥版本统计的记录数
+type EncryptionColumnStatus struct {
+	Name     string           `json:"name"`
+	Column   string           `json:"column"`
+	Total    int64            `json:"total"`
+	Versions map[string]int64 `json:"versions"` // 主密钥版本 -> 记录数，另有 legacy、plaintext
+}
+
+// EncryptionStatus 敏感字段加密状态
+type EncryptionStatus struct {
+	Enabled   bool                      `json:"enabled"` // 是否配置了主密钥
+	ActiveKey string                    `json:"active_key,omitempty"`
+	Columns   []*EncryptionColumnStatus `json:"columns"`
+}
+
+// EncryptionRotateResult 单个敏感字段重新加密的结果
+type EncryptionRotateResult struct {
+	Name      string   `json:"name"`
+	Column    string   `json:"column"`
+	Rewrapped int      `json:"rewrapped"`
+	Skipped   int      `json:"skipped"` // 已使用当前主密钥
+	Errors    []string `json:"errors,omitempty"`
+}
+
+type sealedValue struct {
+	ID    uint
+	Value string
+}
+
+// table 按表名访问，避免读写时触发模型的加解密钩子
+func (e *encryptionService) table(c sealedColumn) (*gorm.DB, error) {
+	stmt := &gorm.Statement{DB: dao.DB()}
+	if err := stmt.Parse(c.Model); err != nil {
+		return nil, err
+	}
+	return dao.DB().Table(stmt.Schema.Table), nil
+}
+
+func (e *encryptionService) values(c sealedColumn) ([]sealedValue, error) {
+	db, err := e.table(c)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sealedValue
+	db = db.Select("id, " + c.Column + " as value").Where(c.Column + " <> ''")
+	if c.Where != "" {
+		db = db.Where(c.Where)
+	}
+	err = db.Scan(&rows).Error
+	return rows, err
+}
+
+// Status 按主密钥版本统计各敏感字段的记录数，用于确认轮换是否完成
+func (e *encryptionService) Status() (*EncryptionStatus, error) {
+	k, err := envelope.Default()
+	if err != nil {
+		return nil, err
+	}
+	status := &EncryptionStatus{Enabled: k != nil}
+	if k != nil {
+		status.ActiveKey = k.ActiveID()
+	}
+	for _, c := range sealedColumns {
+		rows, err := e.values(c)
+		if err != nil {
+			return nil, err
+		}
+		s := &EncryptionColumnStatus{Name: c.Name, Column: c.Column, Total: int64(len(rows)), Versions: map[string]int64{}}
+		for _, r := range rows {
+			s.Versions[e.version(c, r.Value)]++
+		}
+		status.Columns = append(status.Columns, s)
+	}
+	return status, nil
+}
+
+func (e *encryptionService) version(c sealedColumn, value string) string {
+	if id := envelope.KeyID(value); id != "" {
+		return id
+	}
+	if c.Legacy {
+		return EncryptionVersionLegacy
+	}
+	return EncryptionVersionPlaintext
+}
+
+// Rotate 将所有未使用当前主密钥的敏感字段重新加密：旧主密钥、内置静态密钥加密的密文以及明文。
+// 完成后即可从 MASTER_KEY_PREVIOUS 中移除旧主密钥。单条记录失败不影响其他记录
+func (e *encryptionService) Rotate() ([]*EncryptionRotateResult, error) {
+	k, err := envelope.Default()
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, fmt.Errorf("未配置主密钥，请先设置 MASTER_KEY 或 KMS_VAULT_* 后重启")
+	}
+	var results []*EncryptionRotateResult
+	for _, c := range sealedColumns {
+		rows, err := e.values(c)
+		if err != nil {
+			return nil, err
+		}
+		r := &EncryptionRotateResult{Name: c.Name, Column: c.Column}
+		for _, row := range rows {
+			if envelope.KeyID(row.Value) == k.ActiveID() {
+				r.Skipped++
+				continue
+			}
+			if err := e.rewrap(k, c, row); err != nil {
+				r.Errors = append(r.Errors, fmt.Sprintf("id=%d: %v", row.ID, err))
+				continue
+			}
+			r.Rewrapped++
+		}
+		if len(r.Errors) > 0 {
+			klog.Warningf("重新加密 %s 有 %d 条失败", c.Name, len(r.Errors))
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (e *encryptionService) rewrap(k *envelope.Keyring, c sealedColumn, row sealedValue) error {
+	var plaintext string
+	var err error
+	if c.Legacy {
+		plaintext, err = envelope.Decrypt(row.Value)
+	} else {
+		plaintext, err = envelope.OpenIfSealed(row.Value)
+	}
+	if err != nil {
+		return err
+	}
+	sealed, err := k.Seal([]byte(plaintext))
+	if err != nil {
+		return err
+	}
+	db, err := e.table(c)
+	if err != nil {
+		return err
+	}
+	return db.Where("id = ?", row.ID).UpdateColumn(c.Column, sealed).Error
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// 分片保存的限制
+const (
+	FileSaveMaxBytes  = 50 << 20         // 单个文件保存上限
+	fileChunkIdleTTL  = 30 * time.Minute // 分片上传空闲超过该时间后清理
+	fileChunkMaxTotal = 10000            // 单个文件最多分片数
+)
+
+// fileChunkService 暂存 /file/save 分片上传的内容，全部分片到齐后由调用方写入容器。
+// 分片保存在本实例的临时目录中，多实例部署时同一次上传需要由同一实例处理（会话保持）。
+type fileChunkService struct {
+	lock    sync.Mutex
+	uploads map[string]*fileChunkUpload
+	once    sync.Once
+}
+
+type fileChunkUpload struct {
+	owner    string
+	path     string // 临时文件
+	total    int
+	received int
+	size     int64
+	touched  time.Time
+}
+
+// Append 追加一个分片，分片需按顺序发送，重复发送上一个分片视为重试并忽略。
+// 返回已接收分片数；全部到齐时返回临时文件路径，调用方使用后需调用 Discard 清理。
+func (f *fileChunkService) Append(uploadID, owner string, index, total int, data []byte) (int, string, error) {
+	f.once.Do(func() {
+		f.uploads = map[string]*fileChunkUpload{}
+		go f.cleanupLoop()
+	})
+	if uploadID == "" {
+		return 0, "", fmt.Errorf("分片上传缺少 upload_id")
+	}
+	if total <= 0 || total > fileChunkMaxTotal || index < 0 || index >= total {
+		return 0, "", fmt.Errorf("分片序号无效: %d/%d", index, total)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	u, ok := f.uploads[uploadID]
+	if !ok {
+		if index != 0 {
+			return 0, "", fmt.Errorf("分片上传 %s 不存在或已过期，请重新上传", uploadID)
+		}
+		tmp, err := os.CreateTemp(UploadStagingService().Root(), "k8m-save-*")
+		if err != nil {
+			return 0, "", fmt.Errorf("创建临时文件失败: %w", err)
+		}
+		_ = tmp.Close()
+		u = &fileChunkUpload{owner: owner, path: tmp.Name(), total: total}
+		f.uploads[uploadID] = u
+	}
+	if u.owner != owner || u.total != total {
+		return 0, "", fmt.Errorf("分片上传 %s 与之前的分片不一致", uploadID)
+	}
+	u.touched = time.Now()
+	switch {
+	case index == u.received-1:
+		// 客户端重试已接收的分片
+		return u.received, "", nil
+	case index != u.received:
+		return u.received, "", fmt.Errorf("分片顺序错误，期望第 %d 个，收到第 %d 个", u.received, index)
+	}
+	if u.size+int64(len(data)) > FileSaveMaxBytes {
+		f.discardLocked(uploadID)
+		return 0, "", fmt.Errorf("文件超过 %d MiB 上限", FileSaveMaxBytes>>20)
+	}
+
+	file, err := os.OpenFile(u.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return u.received, "", err
+	}
+	_, err = file.Write(data)
+	_ = file.Close()
+	if err != nil {
+		return u.received, "", fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	u.received++
+	u.size += int64(len(data))
+	if u.received < u.total {
+		return u.received, "", nil
+	}
+	return u.received, u.path, nil
+}
+
+// Discard 删除分片上传及其临时文件
+func (f *fileChunkService) Discard(uploadID string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.discardLocked(uploadID)
+}
+
+func (f *fileChunkService) discardLocked(uploadID string) {
+	if u, ok := f.uploads[uploadID]; ok {
+		_ = os.Remove(u.path)
+		delete(f.uploads, uploadID)
+	}
+}
+
+func (f *fileChunkService) cleanupLoop() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		f.lock.Lock()
+		for id, u := range f.uploads {
+			if time.Since(u.touched) > fileChunkIdleTTL {
+				klog.V(6).Infof("清理过期的分片上传 %s", id)
+				f.discardLocked(id)
+			}
+		}
+		f.lock.Unlock()
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/klog/v2"
+)
+
+// 回收站限制
+const (
+	FileTrashMaxBytes  = 20 << 20           // 单次备份上限，超过时需直接删除
+	FileTrashRetention = 7 * 24 * time.Hour // 备份保留时间
+)
+
+type fileTrashService struct{}
+
+// MoveToTrash 删除前将容器中的文件或目录打包备份到 k8m 数据库
+func (f *fileTrashService) MoveToTrash(ctx context.Context, cluster, ns, pod, container, path string, isDir bool) (*models.FileTrash, error) {
+	content, err := kom.Cluster(cluster).WithContext(ctx).Namespace(ns).Name(pod).Ctl().Pod().
+		ContainerName(container).DownloadTarFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("备份到回收站失败，容器内可能没有 tar 命令: %w", err)
+	}
+	if len(content) > FileTrashMaxBytes {
+		return nil, fmt.Errorf("备份内容 %d MiB 超过回收站 %d MiB 上限，请取消回收站选项后直接删除", len(content)>>20, FileTrashMaxBytes>>20)
+	}
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	item := &models.FileTrash{
+		Cluster:       cluster,
+		Namespace:     ns,
+		PodName:       pod,
+		ContainerName: container,
+		Path:          path,
+		IsDir:         isDir,
+		Size:          int64(len(content)),
+		Content:       content,
+		CreatedBy:     username,
+		ExpiresAt:     time.Now().Add(FileTrashRetention),
+	}
+	if err := dao.DB().Create(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Restore 将备份解压回容器的原路径，pod、container 为空时使用删除时的 Pod 与容器，恢复成功后删除备份
+func (f *fileTrashService) Restore(ctx context.Context, cluster string, id uint, pod, container string) (*models.FileTrash, error) {
+	var item models.FileTrash
+	if err := dao.DB().Where("id = ? and cluster = ?", id, cluster).First(&item).Error; err != nil {
+		return nil, fmt.Errorf("回收站记录不存在: %w", err)
+	}
+	if pod == "" {
+		pod = item.PodName
+	}
+	if container == "" {
+		container = item.ContainerName
+	}
+	// tar 打包时去掉了路径开头的 /，在根目录解压即恢复到原路径
+	var result []byte
+	err := kom.Cluster(cluster).WithContext(ctx).Namespace(item.Namespace).Name(pod).Ctl().Pod().
+		ContainerName(container).Stdin(bytes.NewReader(item.Content)).
+		Command("tar", "-xmf", "-", "-C", "/").Execute(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("恢复失败: %w", err)
+	}
+	if err := dao.DB().Delete(&models.FileTrash{}, item.ID).Error; err != nil {
+		klog.V(6).Infof("删除已恢复的回收站记录 %d 失败: %v", item.ID, err)
+	}
+	item.Content = nil
+	return &item, nil
+}
+
+// Start 定时清理过期的回收站记录
+func (f *fileTrashService) Start() {
+	inst := cron.New()
+	_, err := inst.AddFunc("@hourly", f.cleanup)
+	if err != nil {
+		klog.Errorf("新增回收站清理定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+}
+
+func (f *fileTrashService) cleanup() {
+	if err := dao.DB().Where("expires_at < ?", time.Now()).Delete(&models.FileTrash{}).Error; err != nil {
+		klog.V(6).Infof("清理过期回收站记录失败: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+)
+
+// 批量上传的并发与重试限制
+const (
+	FileUploadMaxConcurrency = 20
+	FileUploadMaxRetries     = 5
+	fileUploadRetryBackoff   = 500 * time.Millisecond
+)
+
+// transientExecErrors 网络抖动、apiserver 或 kubelet 连接中断等可重试的错误特征
+var transientExecErrors = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"http2:",
+	"stream error",
+	"error dialing backend",
+	"too many requests",
+	"the server is currently unable to handle the request",
+}
+
+// IsTransientExecError 判断容器命令执行错误是否为可重试的临时错误，命令本身的非 0 退出不重试
+func IsTransientExecError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "command terminated with exit code") {
+		return false
+	}
+	for _, s := range transientExecErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryTransient 执行 fn，遇到临时错误时按指数退避重试最多 retries 次，返回实际尝试次数
+func RetryTransient(ctx context.Context, retries int, fn func() error) (int, error) {
+	backoff := fileUploadRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > retries || !IsTransientExecError(err) {
+			return attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// UploadPreflight 上传前检查容器中的目标目录：是否存在（createDir 为 true 时自动创建）、是否可写，
+// 以及剩余空间是否足够 needBytes。容器内需要 sh，df 不可用时跳过空间检查。
+func (p *podService) UploadPreflight(ctx context.Context, cluster, ns, name, container, dir string, createDir bool, needBytes int64) error {
+	create := "0"
+	if createDir {
+		create = "1"
+	}
+	script := fmt.Sprintf(`d=%s
+if [ ! -d "$d" ]; then
+  if [ -e "$d" ]; then echo "ERR notdir"; exit 0; fi
+  if [ "%s" != 1 ]; then echo "ERR notexist"; exit 0; fi
+  mkdir -p "$d" 2>/dev/null || { echo "ERR mkdir"; exit 0; }
+fi
+t="$d/.k8m-preflight-$$"
+touch "$t" 2>/dev/null || { echo "ERR readonly"; exit 0; }
+rm -f "$t"
+df -Pk "$d" 2>/dev/null | awk 'NR==2{print "AVAIL " $4}'`, utils.ShellQuote(dir), create)
+
+	var out []byte
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name).
+		Ctl().Pod().ContainerName(container).Command("sh", "-c", script).Execute(&out).Error
+	if err != nil {
+		return fmt.Errorf("上传预检失败，容器内可能没有 sh: %w", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch key {
+		case "ERR":
+			switch value {
+			case "notdir":
+				return i18n.NewError(i18n.CodeFilePreflightNotDir, dir)
+			case "notexist":
+				return i18n.NewError(i18n.CodeFilePreflightNotExist, dir)
+			case "mkdir":
+				return i18n.NewError(i18n.CodeFilePreflightMkdir, dir)
+			case "readonly":
+				return i18n.NewError(i18n.CodeFilePreflightReadOnly, dir)
+			}
+		case "AVAIL":
+			kb, err := strconv.ParseInt(value, 10, 64)
+			if err == nil && needBytes > 0 && kb*1024 < needBytes {
+				return i18n.NewError(i18n.CodeFilePreflightNoSpace, dir, humanBytes(kb*1024), humanBytes(needBytes))
+			}
+		}
+	}
+	return nil
+}
+
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/plugins/api"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// 文件监视状态
+const (
+	FileWatchStatusUnchanged = "unchanged"
+	FileWatchStatusChanged   = "changed"
+	FileWatchStatusReverted  = "reverted"
+	FileWatchStatusError     = "error"
+)
+
+type fileWatchService struct{}
+
+// Start 每分钟检查到期的文件监视，多实例部署时通过分布式锁保证同一监视只由一个实例检查
+func (f *fileWatchService) Start() {
+	holder, _ := os.Hostname()
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", func() {
+		var list []*models.FileWatch
+		if err := dao.DB().Find(&list).Error; err != nil {
+			klog.V(6).Infof("读取文件监视失败: %v", err)
+			return
+		}
+		now := time.Now()
+		for _, w := range list {
+			interval := time.Duration(max(w.CheckInterval, 1)) * time.Minute
+			if w.LastCheckedAt != nil && now.Sub(*w.LastCheckedAt) < interval {
+				continue
+			}
+			if kom.Cluster(w.Cluster) == nil {
+				continue
+			}
+			ok, err := LockService().TryAcquire(fmt.Sprintf("file-watch-%d", w.ID), holder, interval)
+			if err != nil || !ok {
+				continue
+			}
+			if err := f.Check(utils.GetContextWithAdmin(), w); err != nil {
+				klog.V(6).Infof("文件监视[%d]检查失败: %v", w.ID, err)
+			}
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增文件监视定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+	klog.V(6).Infof("新增文件监视定时任务【@every 1m】")
+}
+
+// Check 计算文件校验和并与上次结果比较，内容变化或 Pod 重启后被还原为首次检查时的内容时发送通知。
+// 首次检查只记录基线。检查结果写回数据库。
+func (f *fileWatchService) Check(ctx context.Context, w *models.FileWatch) error {
+	ctx = WithReadOnlyExec(context.WithValue(ctx, constants.CommandScope, constants.CommandScopeFile))
+	now := time.Now()
+	w.LastCheckedAt = &now
+
+	var pod v1.Pod
+	err := kom.Cluster(w.Cluster).WithContext(ctx).Resource(&pod).Namespace(w.Namespace).Name(w.PodName).Get(&pod).Error
+	if err != nil {
+		return f.fail(w, fmt.Errorf("读取 Pod 失败: %w", err))
+	}
+	restartCount := int32(0)
+	for _, cs := range pod.Status.ContainerStatuses {
+		if w.ContainerName == "" || cs.Name == w.ContainerName {
+			restartCount = cs.RestartCount
+			break
+		}
+	}
+	restarted := w.PodUID != "" && (w.PodUID != string(pod.UID) || restartCount > w.RestartCount)
+
+	var out []byte
+	script := fmt.Sprintf("sha256sum %[1]s 2>/dev/null || md5sum %[1]s", utils.ShellQuote(w.Path))
+	err = kom.Cluster(w.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(w.Namespace).Name(w.PodName).
+		Ctl().Pod().ContainerName(w.ContainerName).Command("sh", "-c", script).Execute(&out).Error
+	if err != nil {
+		return f.fail(w, fmt.Errorf("计算文件校验和失败: %w", err))
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return f.fail(w, fmt.Errorf("计算文件校验和失败，容器内没有 sha256sum 或 md5sum"))
+	}
+	checksum := fields[0]
+
+	previous := w.Checksum
+	w.PodUID, w.RestartCount, w.Checksum = string(pod.UID), restartCount, checksum
+	w.Status, w.Message = FileWatchStatusUnchanged, ""
+	switch {
+	case w.Baseline == "":
+		w.Baseline = checksum
+	case previous != "" && checksum != previous && restarted && checksum == w.Baseline:
+		w.Status = FileWatchStatusReverted
+		w.Message = "Pod 重启后文件恢复为初始内容，运行期间的修改已丢失"
+	case previous != "" && checksum != previous:
+		w.Status = FileWatchStatusChanged
+		w.Message = "文件内容已变化"
+		if restarted {
+			w.Message += "（Pod 已重启）"
+		}
+	}
+	if w.Status != FileWatchStatusUnchanged {
+		w.LastChangedAt = &now
+		f.notify(w)
+	}
+	return f.save(w)
+}
+
+// fail 记录检查失败，状态由正常变为失败时通知一次
+func (f *fileWatchService) fail(w *models.FileWatch, err error) error {
+	notify := w.Status != FileWatchStatusError
+	w.Status, w.Message = FileWatchStatusError, err.Error()
+	if notify {
+		f.notify(w)
+	}
+	if saveErr := f.save(w); saveErr != nil {
+		klog.V(6).Infof("保存文件监视[%d]结果失败: %v", w.ID, saveErr)
+	}
+	return err
+}
+
+func (f *fileWatchService) save(w *models.FileWatch) error {
+	return dao.DB().Model(&models.FileWatch{}).Where("id = ?", w.ID).Select(
+		"baseline", "checksum", "pod_uid", "restart_count", "status", "message", "last_checked_at", "last_changed_at",
+	).Updates(w).Error
+}
+
+func (f *fileWatchService) notify(w *models.FileWatch) {
+	var ids []string
+	for _, id := range strings.Split(w.Webhooks, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	summary := fmt.Sprintf("[k8m 文件监视] %s/%s/%s 容器 %s 文件 %s：%s", w.Cluster, w.Namespace, w.PodName, w.ContainerName, w.Path, w.Message)
+	raw, _ := json.Marshal(w)
+	api.WebhookService().PushMsgToAllTargetByIDs(summary, string(raw), ids)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/gql"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// graphQLService 基于 kom 缓存读取集群资源，执行 GraphQL 组合查询
+type graphQLService struct{}
+
+// Execute 在指定集群上执行 GraphQL 查询，ctx 需携带当前用户以便 kom 校验权限
+func (g *graphQLService) Execute(ctx context.Context, cluster string, req *gql.Request) (*graphql.Response, error) {
+	if !flag.Init().EnableGraphQL {
+		return nil, fmt.Errorf("GraphQL 接口未启用，请在平台参数中开启")
+	}
+	if kom.Cluster(cluster) == nil {
+		return nil, fmt.Errorf("集群 %s 不存在或未连接", cluster)
+	}
+	return gql.Execute(ctx, &graphQLSource{cluster: cluster}, req), nil
+}
+
+// graphQLSource 实现 gql.Source，列表查询走 kom 缓存，缓存时间与资源缓存设置一致
+type graphQLSource struct {
+	cluster string
+}
+
+func (s *graphQLSource) kubectl(ctx context.Context, namespace string) *kom.Kubectl {
+	ttl := time.Minute
+	if cfg := flag.Init(); cfg.ResourceCacheTimeout > 0 {
+		ttl = time.Duration(cfg.ResourceCacheTimeout) * time.Second
+	}
+	k := kom.Cluster(s.cluster).WithContext(ctx).WithCache(ttl)
+	if namespace == "" {
+		return k.AllNamespace()
+	}
+	return k.Namespace(namespace)
+}
+
+func (s *graphQLSource) ListDeployments(ctx context.Context, namespace, labelSelector string) ([]*appsv1.Deployment, error) {
+	var list []*appsv1.Deployment
+	k := s.kubectl(ctx, namespace).Resource(&appsv1.Deployment{})
+	if labelSelector != "" {
+		k = k.WithLabelSelector(labelSelector)
+	}
+	err := k.List(&list).Error
+	return list, err
+}
+
+func (s *graphQLSource) GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	var item appsv1.Deployment
+	err := s.kubectl(ctx, namespace).Resource(&appsv1.Deployment{}).Name(name).Get(&item).Error
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *graphQLSource) ListPods(ctx context.Context, namespace, labelSelector, fieldSelector string) ([]*v1.Pod, error) {
+	var list []*v1.Pod
+	k := s.kubectl(ctx, namespace).Resource(&v1.Pod{})
+	if labelSelector != "" {
+		k = k.WithLabelSelector(labelSelector)
+	}
+	if fieldSelector != "" {
+		k = k.WithFieldSelector(fieldSelector)
+	}
+	err := k.List(&list).Error
+	return list, err
+}
+
+func (s *graphQLSource) GetPod(ctx context.Context, namespace, name string) (*v1.Pod, error) {
+	var item v1.Pod
+	err := s.kubectl(ctx, namespace).Resource(&v1.Pod{}).Name(name).Get(&item).Error
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *graphQLSource) ListEvents(ctx context.Context, namespace, fieldSelector string) ([]*v1.Event, error) {
+	var list []*v1.Event
+	k := s.kubectl(ctx, namespace).Resource(&v1.Event{})
+	if fieldSelector != "" {
+		k = k.WithFieldSelector(fieldSelector)
+	}
+	err := k.List(&list).Error
+	return list, err
+}
+
+// ListPodMetrics 读取 metrics-server 提供的 PodMetrics，未安装时返回错误，由调用方置为 null
+func (s *graphQLSource) ListPodMetrics(ctx context.Context, namespace string) (map[string]*gql.PodUsage, error) {
+	var list []*unstructured.Unstructured
+	if err := s.kubectl(ctx, namespace).CRD("metrics.k8s.io", "v1beta1", "PodMetrics").List(&list).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]*gql.PodUsage, len(list))
+	for _, item := range list {
+		m, err := kom.SummarizePodMetrics(item)
+		if err != nil {
+			continue
+		}
+		// kom 中 CPUNano 实际为毫核
+		result[item.GetName()] = &gql.PodUsage{CPUMillicores: m.Usage.CPUNano, MemoryBytes: m.Usage.MemoryByte}
+	}
+	return result, nil
+}
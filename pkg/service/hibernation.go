@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/task"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// TaskTypeHibernation 环境休眠、唤醒对应的异步任务类型
+const TaskTypeHibernation = "hibernation"
+
+const (
+	// HibernationReplicasAnnotation 休眠前的副本数，唤醒时按此恢复并移除
+	HibernationReplicasAnnotation = "k8m.io/hibernated-replicas"
+	// HibernationSkipAnnotation 值为 false 的工作负载不参与休眠
+	HibernationSkipAnnotation = "k8m.io/hibernate"
+)
+
+// 休眠计划的操作与状态
+const (
+	HibernationSleep    = "sleep"
+	HibernationWake     = "wake"
+	HibernationAwake    = "awake"
+	HibernationSleeping = "sleeping"
+)
+
+// 参与休眠的工作负载类型
+var hibernationKinds = []string{"Deployment", "StatefulSet"}
+
+type hibernationService struct{}
+
+// HibernationWorkload 休眠计划涉及的工作负载
+type HibernationWorkload struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Replicas  int64  `json:"replicas"`
+	// Hibernated 休眠前的副本数，未休眠时为空
+	Hibernated *int64 `json:"hibernated,omitempty"`
+	Skipped    bool   `json:"skipped,omitempty"`
+}
+
+// Validate 校验并规范化休眠计划，启用时按 cron 计算下次休眠与唤醒时间
+func (s *hibernationService) Validate(m *models.Hibernation) error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return fmt.Errorf("名称不能为空")
+	}
+	namespaces := splitList(m.Namespaces)
+	if len(namespaces) == 0 {
+		return fmt.Errorf("请选择命名空间")
+	}
+	m.Namespaces = strings.Join(namespaces, ",")
+
+	m.SleepCron = strings.TrimSpace(m.SleepCron)
+	m.WakeCron = strings.TrimSpace(m.WakeCron)
+	if m.Enabled && m.SleepCron == "" && m.WakeCron == "" {
+		return fmt.Errorf("启用定时需至少填写休眠或唤醒时间")
+	}
+	now := time.Now()
+	var err error
+	if m.NextSleepAt, err = nextCronTime(m.SleepCron, m.Enabled, now); err != nil {
+		return fmt.Errorf("休眠时间 cron 表达式格式错误: %w", err)
+	}
+	if m.NextWakeAt, err = nextCronTime(m.WakeCron, m.Enabled, now); err != nil {
+		return fmt.Errorf("唤醒时间 cron 表达式格式错误: %w", err)
+	}
+	return nil
+}
+
+// nextCronTime 计算 expr 在 after 之后的下次执行时间，表达式为空或未启用时返回 nil
+func nextCronTime(expr string, enabled bool, after time.Time) (*time.Time, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	sched, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, nil
+	}
+	next := sched.Next(after)
+	return &next, nil
+}
+
+// Start 每分钟检查到期的休眠、唤醒，多实例部署时通过分布式锁保证同一计划只由一个实例执行
+func (s *hibernationService) Start() {
+	holder, _ := os.Hostname()
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", func() {
+		now := time.Now()
+		var list []*models.Hibernation
+		if err := dao.DB().Where("enabled = ? and (next_sleep_at <= ? or next_wake_at <= ?)", true, now, now).Find(&list).Error; err != nil {
+			klog.V(6).Infof("读取休眠计划失败: %v", err)
+			return
+		}
+		for _, m := range list {
+			ok, err := LockService().TryAcquire(fmt.Sprintf("hibernation-%d", m.ID), holder, 10*time.Minute)
+			if err != nil || !ok {
+				continue
+			}
+			if _, err := s.Run(m, dueHibernationAction(m, now), m.CreatedBy); err != nil {
+				klog.V(6).Infof("休眠计划[%d]执行失败: %v", m.ID, err)
+			}
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增休眠计划定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+	klog.V(6).Infof("新增休眠计划定时任务【@every 1m】")
+}
+
+// dueHibernationAction 返回到期的操作。服务停止期间休眠、唤醒都已到期时，以较晚的一个为准
+func dueHibernationAction(m *models.Hibernation, now time.Time) string {
+	sleepDue := m.NextSleepAt != nil && !m.NextSleepAt.After(now)
+	wakeDue := m.NextWakeAt != nil && !m.NextWakeAt.After(now)
+	if sleepDue && wakeDue {
+		if m.NextSleepAt.After(*m.NextWakeAt) {
+			return HibernationSleep
+		}
+		return HibernationWake
+	}
+	if sleepDue {
+		return HibernationSleep
+	}
+	return HibernationWake
+}
+
+// Run 以 username 身份提交异步任务执行休眠或唤醒，定时执行时为创建人。到期的休眠、唤醒时间推进到下一次
+func (s *hibernationService) Run(m *models.Hibernation, action, username string) (*models.Task, error) {
+	if action != HibernationSleep && action != HibernationWake {
+		return nil, fmt.Errorf("不支持的操作: %s", action)
+	}
+	now := time.Now()
+	updates := map[string]any{"last_run_at": now, "last_action": action}
+	if m.NextSleepAt != nil && !m.NextSleepAt.After(now) {
+		next, _ := nextCronTime(m.SleepCron, m.Enabled, now)
+		updates["next_sleep_at"] = next
+	}
+	if m.NextWakeAt != nil && !m.NextWakeAt.After(now) {
+		next, _ := nextCronTime(m.WakeCron, m.Enabled, now)
+		updates["next_wake_at"] = next
+	}
+	if err := dao.DB().Model(&models.Hibernation{}).Where("id = ?", m.ID).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("更新休眠计划失败: %w", err)
+	}
+
+	title := "环境休眠 " + m.Name
+	if action == HibernationWake {
+		title = "环境唤醒 " + m.Name
+	}
+	ctx := context.WithValue(context.Background(), constants.JwtUserName, username)
+	t, err := TaskService().Submit(ctx, TaskTypeHibernation, title, m.Cluster, func(run *task.Run) (string, error) {
+		message, err := s.execute(run, m, action)
+		updates := map[string]any{"last_status": "success", "last_message": message}
+		if err != nil {
+			updates["last_status"], updates["last_message"] = "failed", err.Error()
+		}
+		// 部分工作负载失败时仍按操作更新状态，重新执行会跳过已处理的工作负载
+		if action == HibernationSleep {
+			updates["state"] = HibernationSleeping
+		} else {
+			updates["state"] = HibernationAwake
+		}
+		if uErr := dao.DB().Model(&models.Hibernation{}).Where("id = ?", m.ID).Updates(updates).Error; uErr != nil {
+			klog.V(6).Infof("保存休眠计划[%d]结果失败: %v", m.ID, uErr)
+		}
+		return message, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := dao.DB().Model(&models.Hibernation{}).Where("id = ?", m.ID).Update("last_task_id", t.ID).Error; err != nil {
+		klog.V(6).Infof("保存休眠计划[%d]任务失败: %v", m.ID, err)
+	}
+	return t, nil
+}
+
+// execute 逐个命名空间处理工作负载，单个失败不影响其余工作负载，最后汇总失败数量
+func (s *hibernationService) execute(run *task.Run, m *models.Hibernation, action string) (string, error) {
+	namespaces := splitList(m.Namespaces)
+	var changed, skipped, failed int
+	for i, ns := range namespaces {
+		if err := run.Context().Err(); err != nil {
+			return "", err
+		}
+		run.Progress(i*100/len(namespaces), "处理命名空间 %s", ns)
+		for _, kind := range hibernationKinds {
+			var list []*unstructured.Unstructured
+			err := kom.Cluster(m.Cluster).WithContext(run.Context()).CRD("apps", "v1", kind).Namespace(ns).List(&list).Error
+			if err != nil {
+				run.Logf("获取 %s 中的 %s 失败: %v", ns, kind, err)
+				failed++
+				continue
+			}
+			for _, obj := range list {
+				var done bool
+				var err error
+				if action == HibernationSleep {
+					done, err = s.sleep(run.Context(), m.Cluster, kind, obj)
+				} else {
+					done, err = s.wake(run.Context(), m.Cluster, kind, obj)
+				}
+				switch {
+				case err != nil:
+					run.Logf("%s %s/%s 失败: %v", kind, ns, obj.GetName(), err)
+					failed++
+				case done:
+					changed++
+				default:
+					skipped++
+				}
+			}
+		}
+	}
+
+	verb := "休眠"
+	if action == HibernationWake {
+		verb = "唤醒"
+	}
+	message := fmt.Sprintf("%s %d 个工作负载，跳过 %d 个", verb, changed, skipped)
+	if failed > 0 {
+		return "", fmt.Errorf("%s，失败 %d 个，详见任务日志", message, failed)
+	}
+	return message, nil
+}
+
+// sleep 记录当前副本数并缩容到 0。已为 0 或标记为不参与休眠的工作负载跳过，
+// 重复执行不会覆盖已记录的副本数
+func (s *hibernationService) sleep(ctx context.Context, cluster, kind string, obj *unstructured.Unstructured) (bool, error) {
+	if obj.GetAnnotations()[HibernationSkipAnnotation] == "false" {
+		return false, nil
+	}
+	replicas := workloadReplicas(obj)
+	if replicas == 0 {
+		return false, nil
+	}
+	patch := map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{HibernationReplicasAnnotation: strconv.FormatInt(replicas, 10)}},
+		"spec":     map[string]any{"replicas": 0},
+	}
+	return true, s.patch(ctx, cluster, kind, obj, patch)
+}
+
+// wake 恢复到休眠前的副本数。休眠期间被手动调整过副本数的工作负载保留当前副本数，只移除记录
+func (s *hibernationService) wake(ctx context.Context, cluster, kind string, obj *unstructured.Unstructured) (bool, error) {
+	recorded, ok := obj.GetAnnotations()[HibernationReplicasAnnotation]
+	if !ok {
+		return false, nil
+	}
+	patch := map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{HibernationReplicasAnnotation: nil}},
+	}
+	replicas, err := strconv.ParseInt(recorded, 10, 32)
+	if err == nil && replicas > 0 && workloadReplicas(obj) == 0 {
+		patch["spec"] = map[string]any{"replicas": replicas}
+	}
+	return true, s.patch(ctx, cluster, kind, obj, patch)
+}
+
+func (s *hibernationService) patch(ctx context.Context, cluster, kind string, obj *unstructured.Unstructured, patch map[string]any) error {
+	return kom.Cluster(cluster).WithContext(ctx).CRD("apps", "v1", kind).
+		Namespace(obj.GetNamespace()).Name(obj.GetName()).
+		Patch(obj, types.MergePatchType, utils.ToJSON(patch)).Error
+}
+
+// workloadReplicas 返回 spec.replicas，未设置时为默认值 1
+func workloadReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// Workloads 列出休眠计划涉及的工作负载及其当前副本数、休眠前记录的副本数
+func (s *hibernationService) Workloads(ctx context.Context, m *models.Hibernation) ([]*HibernationWorkload, error) {
+	var items []*HibernationWorkload
+	for _, ns := range splitList(m.Namespaces) {
+		for _, kind := range hibernationKinds {
+			var list []*unstructured.Unstructured
+			err := kom.Cluster(m.Cluster).WithContext(ctx).CRD("apps", "v1", kind).Namespace(ns).List(&list).Error
+			if err != nil {
+				return nil, fmt.Errorf("获取 %s 中的 %s 失败: %w", ns, kind, err)
+			}
+			for _, obj := range list {
+				item := &HibernationWorkload{
+					Kind:      kind,
+					Namespace: ns,
+					Name:      obj.GetName(),
+					Replicas:  workloadReplicas(obj),
+					Skipped:   obj.GetAnnotations()[HibernationSkipAnnotation] == "false",
+				}
+				if recorded, err := strconv.ParseInt(obj.GetAnnotations()[HibernationReplicasAnnotation], 10, 64); err == nil {
+					item.Hibernated = &recorded
+				}
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
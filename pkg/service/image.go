@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/registry"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// imageInspectCacheTTL 镜像元数据缓存时间，标签可能被重新推送，不宜过长
+const imageInspectCacheTTL = 10 * time.Minute
+
+type imageService struct{}
+
+// Inspect 从镜像仓库读取镜像元数据。
+// 凭据按以下顺序查找：平台配置的仓库凭据（集群+命名空间 > 集群 > 全局），Pod 的 imagePullSecrets，匿名访问。
+func (s *imageService) Inspect(ctx context.Context, cluster, namespace, image string, pullSecrets []string, platform string) (*registry.ImageInfo, error) {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("image-inspect/%s/%s/%s/%s", cluster, namespace, ref.String(), platform)
+	cache := CacheService().CacheInstance()
+	if cache != nil {
+		if v, ok := cache.Get(cacheKey); ok {
+			if info, ok := v.(*registry.ImageInfo); ok {
+				return info, nil
+			}
+		}
+	}
+
+	cred, insecure := s.ResolveCredential(ctx, cluster, namespace, ref.Registry, pullSecrets)
+	client := registry.NewClient(cred, insecure)
+	client.Platform = platform
+	info, err := client.Inspect(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.SetWithTTL(cacheKey, info, 1, imageInspectCacheTTL)
+	}
+	return info, nil
+}
+
+// ResolveCredential 查找访问指定仓库的凭据，未找到时返回空凭据（匿名访问）
+func (s *imageService) ResolveCredential(ctx context.Context, cluster, namespace, registryHost string, pullSecrets []string) (registry.Credential, bool) {
+	host := registry.NormalizeRegistry(registryHost)
+	if best := s.MatchCredential(cluster, namespace, host); best != nil {
+		return registry.Credential{Username: best.Username, Password: best.Password}, best.Insecure
+	}
+
+	for _, name := range pullSecrets {
+		cred, ok := s.credentialFromSecret(ctx, cluster, namespace, name, host)
+		if ok {
+			return cred, false
+		}
+	}
+	return registry.Credential{}, false
+}
+
+// MatchCredential 查找平台配置的仓库凭据，集群+命名空间 > 集群 > 全局
+func (s *imageService) MatchCredential(cluster, namespace, host string) *models.RegistryCredential {
+	var list []*models.RegistryCredential
+	err := dao.DB().Where("(cluster = ? or cluster = '' or cluster is null) and (namespace = ? or namespace = '' or namespace is null)", cluster, namespace).
+		Find(&list).Error
+	if err != nil {
+		klog.V(6).Infof("查询镜像仓库凭据失败: %v", err)
+		return nil
+	}
+	var best *models.RegistryCredential
+	bestScore := -1
+	for _, item := range list {
+		if registry.NormalizeRegistry(item.Registry) != host {
+			continue
+		}
+		score := 0
+		if item.Cluster != "" {
+			score += 2
+		}
+		if item.Namespace != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = item, score
+		}
+	}
+	return best
+}
+
+// HasSecretFor 判断 imagePullSecrets 中是否包含指定仓库的凭据
+func (s *imageService) HasSecretFor(ctx context.Context, cluster, namespace, host string, pullSecrets []string) bool {
+	for _, name := range pullSecrets {
+		if _, ok := s.credentialFromSecret(ctx, cluster, namespace, name, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerConfigJSON kubernetes.io/dockerconfigjson 类型 Secret 的内容
+type dockerConfigJSON struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"` // base64(username:password)
+	} `json:"auths"`
+}
+
+// credentialFromSecret 从 imagePullSecret 中读取指定仓库的凭据
+func (s *imageService) credentialFromSecret(ctx context.Context, cluster, namespace, name, host string) (registry.Credential, bool) {
+	var secret v1.Secret
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Secret{}).Namespace(namespace).Name(name).Get(&secret).Error
+	if err != nil {
+		klog.V(6).Infof("读取 imagePullSecret %s/%s 失败: %v", namespace, name, err)
+		return registry.Credential{}, false
+	}
+	data, ok := secret.Data[v1.DockerConfigJsonKey]
+	if !ok {
+		return registry.Credential{}, false
+	}
+	var cfg dockerConfigJSON
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		klog.V(6).Infof("解析 imagePullSecret %s/%s 失败: %v", namespace, name, err)
+		return registry.Credential{}, false
+	}
+	for server, auth := range cfg.Auths {
+		if registry.NormalizeRegistry(server) != host {
+			continue
+		}
+		cred := registry.Credential{Username: auth.Username, Password: auth.Password}
+		if cred.Username == "" && auth.Auth != "" {
+			cred.Username, cred.Password = registry.DecodeAuth(auth.Auth)
+		}
+		return cred, true
+	}
+	return registry.Credential{}, false
+}
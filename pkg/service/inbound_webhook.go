@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/envelope"
+	"github.com/weibaohui/k8m/pkg/inbound"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"k8s.io/klog/v2"
+)
+
+// 入站 webhook 动作
+const (
+	InboundWebhookRestart       = "restart"        // 重启工作负载
+	InboundWebhookSetImage      = "set_image"      // 更新容器镜像，镜像或标签来自请求体
+	InboundWebhookApplyTemplate = "apply_template" // 渲染并应用模板，变量可由请求体覆盖
+)
+
+// 入站 webhook 最近一次执行结果
+const (
+	InboundWebhookSuccess = "success"
+	InboundWebhookFailed  = "failed"
+)
+
+// inboundWebhookTimeout 单次触发的执行时间上限
+const inboundWebhookTimeout = 2 * time.Minute
+
+const (
+	inboundDeliveryKey = "inbound:delivery:" // 共享状态表中保存已处理投递标识的键前缀
+	inboundDeliveryTTL = 24 * time.Hour      // 投递标识保留时长，期间重复投递视为重放
+)
+
+// ErrDuplicateDelivery 投递标识已处理过
+var ErrDuplicateDelivery = errors.New("该投递已处理，拒绝重放")
+
+type inboundWebhookService struct{}
+
+// Validate 校验动作与操作对象，新建或编辑时调用
+func (s *inboundWebhookService) Validate(hook *models.InboundWebhook) error {
+	if hook.Name == "" || hook.Cluster == "" {
+		return fmt.Errorf("名称与集群不能为空")
+	}
+	switch hook.Action {
+	case InboundWebhookRestart, InboundWebhookSetImage:
+		switch strings.ToLower(hook.Kind) {
+		case "deployment", "statefulset", "daemonset":
+		default:
+			return fmt.Errorf("不支持的资源类型: %s，仅支持 Deployment、StatefulSet、DaemonSet", hook.Kind)
+		}
+		if hook.Namespace == "" || hook.Target == "" {
+			return fmt.Errorf("命名空间与工作负载名称不能为空")
+		}
+		if hook.Action == InboundWebhookSetImage && hook.Container == "" {
+			return fmt.Errorf("更新镜像须指定容器")
+		}
+	case InboundWebhookApplyTemplate:
+		if hook.TemplateID == 0 {
+			return fmt.Errorf("应用模板须指定模板")
+		}
+		if err := dao.DB().Select("id").First(&models.CustomTemplate{}, hook.TemplateID).Error; err != nil {
+			return fmt.Errorf("模板 %d 不存在", hook.TemplateID)
+		}
+	default:
+		return fmt.Errorf("不支持的动作: %s", hook.Action)
+	}
+	return nil
+}
+
+// GetByKey 按地址中的标识读取已启用的 webhook
+func (s *inboundWebhookService) GetByKey(key string) (*models.InboundWebhook, error) {
+	var hook models.InboundWebhook
+	if key == "" {
+		return nil, fmt.Errorf("webhook 不存在")
+	}
+	// key 在 MySQL 中为保留字，使用结构体条件由 gorm 按方言转义
+	if err := dao.DB().Where(&models.InboundWebhook{Key: key, Enabled: true}).First(&hook).Error; err != nil {
+		return nil, fmt.Errorf("webhook 不存在或未启用")
+	}
+	return &hook, nil
+}
+
+// Verify 校验请求体签名，timestamp 为可选的签名时间戳
+func (s *inboundWebhookService) Verify(hook *models.InboundWebhook, body []byte, signature, timestamp string) error {
+	secret, err := envelope.Decrypt(hook.Secret)
+	if err != nil {
+		return fmt.Errorf("解密 webhook 签名密钥失败: %w", err)
+	}
+	return inbound.Verify(secret, body, signature, timestamp, time.Now())
+}
+
+// RecordDelivery 记录投递标识，同一 webhook 在 inboundDeliveryTTL 内重复投递相同标识时返回 ErrDuplicateDelivery。
+// 标识记录在多实例共享的状态存储中，负载均衡后的任意实例都能识别重放
+func (s *inboundWebhookService) RecordDelivery(hook *models.InboundWebhook, delivery string) error {
+	if delivery == "" {
+		return nil
+	}
+	ok, err := StateStoreService().PutIfAbsent(fmt.Sprintf("%s%d:%s", inboundDeliveryKey, hook.ID, delivery), time.Now(), inboundDeliveryTTL)
+	if err != nil {
+		return fmt.Errorf("记录投递标识失败: %w", err)
+	}
+	if !ok {
+		return ErrDuplicateDelivery
+	}
+	return nil
+}
+
+// ResetSecret 生成新的签名密钥并加密保存，返回明文，仅在此时可见
+func (s *inboundWebhookService) ResetSecret(hook *models.InboundWebhook) (string, error) {
+	secret, err := inbound.NewSecret()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := envelope.Encrypt(secret)
+	if err != nil {
+		return "", err
+	}
+	if hook.ID != 0 {
+		if err := dao.DB().Model(&models.InboundWebhook{}).Where("id = ?", hook.ID).Update("secret", encrypted).Error; err != nil {
+			return "", err
+		}
+	}
+	hook.Secret = encrypted
+	return secret, nil
+}
+
+// Trigger 以创建人身份执行 webhook 的动作，并记录执行结果与操作日志
+func (s *inboundWebhookService) Trigger(hook *models.InboundWebhook, payload map[string]any) (string, error) {
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), constants.JwtUserName, hook.CreatedBy), inboundWebhookTimeout)
+	defer cancel()
+
+	message, err := s.run(ctx, hook, payload)
+	status, result := InboundWebhookSuccess, message
+	if err != nil {
+		status, result = InboundWebhookFailed, err.Error()
+	}
+	now := time.Now()
+	if e := dao.DB().Model(&models.InboundWebhook{}).Where("id = ?", hook.ID).
+		Updates(map[string]any{"last_triggered_at": now, "last_status": status, "last_message": result}).Error; e != nil {
+		klog.Errorf("保存 webhook[%s]执行结果失败: %v", hook.Name, e)
+	}
+	OperationLogService().Add(&models.OperationLog{
+		Action:       "webhook-" + hook.Action,
+		Cluster:      hook.Cluster,
+		Kind:         hook.Kind,
+		Namespace:    hook.Namespace,
+		Name:         hook.Target,
+		UserName:     hook.CreatedBy,
+		ActionResult: result,
+	}, map[string]string{"webhook": hook.Name})
+	return message, err
+}
+
+func (s *inboundWebhookService) run(ctx context.Context, hook *models.InboundWebhook, payload map[string]any) (string, error) {
+	if kom.Cluster(hook.Cluster) == nil {
+		return "", fmt.Errorf("集群 %s 未连接", hook.Cluster)
+	}
+	switch hook.Action {
+	case InboundWebhookRestart:
+		w, err := ImageService().getWorkload(ctx, hook.Cluster, hook.Kind, hook.Namespace, hook.Target)
+		if err != nil {
+			return "", err
+		}
+		err = kom.Cluster(hook.Cluster).WithContext(ctx).Resource(w.obj).Namespace(hook.Namespace).Name(hook.Target).
+			Ctl().Rollout().Restart()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("已重启 %s %s/%s", hook.Kind, hook.Namespace, hook.Target), nil
+	case InboundWebhookSetImage:
+		return s.setImage(ctx, hook, payload)
+	case InboundWebhookApplyTemplate:
+		return s.applyTemplate(ctx, hook, payload)
+	}
+	return "", fmt.Errorf("不支持的动作: %s", hook.Action)
+}
+
+// setImage 按请求体中的镜像或标签更新容器，镜像须在允许范围内
+func (s *inboundWebhookService) setImage(ctx context.Context, hook *models.InboundWebhook, payload map[string]any) (string, error) {
+	imagePath := hook.ImagePath
+	if imagePath == "" {
+		imagePath = "image"
+	}
+	value, _ := inbound.Lookup(payload, imagePath)
+	w, err := ImageService().getWorkload(ctx, hook.Cluster, hook.Kind, hook.Namespace, hook.Target)
+	if err != nil {
+		return "", err
+	}
+	current, _, err := w.container(hook.Container)
+	if err != nil {
+		return "", err
+	}
+	image, err := inbound.ResolveImage(current, value, hook.ImagePattern)
+	if err != nil {
+		return "", err
+	}
+	if image == current {
+		return fmt.Sprintf("容器 %s 已在使用镜像 %s，无需更新", hook.Container, image), nil
+	}
+	result, err := ImageService().UpdateWorkloadImage(ctx, hook.Cluster, hook.Kind, hook.Namespace, hook.Target,
+		&WorkloadImageUpdate{Container: hook.Container, Image: image})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("容器 %s 镜像已由 %s 更新为 %s", result.Container, result.Previous, result.Image), nil
+}
+
+// applyTemplate 渲染模板并依次应用其中的资源。配置了命名空间时，资源只能位于该命名空间
+func (s *inboundWebhookService) applyTemplate(ctx context.Context, hook *models.InboundWebhook, payload map[string]any) (string, error) {
+	var tpl models.CustomTemplate
+	if err := dao.DB().First(&tpl, hook.TemplateID).Error; err != nil {
+		return "", fmt.Errorf("模板 %d 不存在", hook.TemplateID)
+	}
+	vars := make(map[string]string, len(hook.Variables))
+	for k, v := range hook.Variables {
+		vars[k] = v
+	}
+	if override, ok := payload["variables"].(map[string]any); ok {
+		for k, v := range override {
+			if _, declared := hook.Variables[k]; !declared {
+				return "", fmt.Errorf("变量 %s 未在 webhook 中声明，不允许由请求体设置", k)
+			}
+			switch v := v.(type) {
+			case string:
+				vars[k] = v
+			case float64, bool:
+				vars[k] = fmt.Sprint(v)
+			default:
+				return "", fmt.Errorf("变量 %s 的值须为字符串、数字或布尔值", k)
+			}
+		}
+	}
+	rendered, err := utils.RenderTemplate(tpl.Engine, tpl.Content, vars)
+	if err != nil {
+		return "", err
+	}
+	objs, err := ManifestService().Parse(rendered)
+	if err != nil {
+		return "", err
+	}
+	if len(objs) == 0 {
+		return "", fmt.Errorf("模板渲染结果中没有资源")
+	}
+	ManifestService().SortByDependency(objs)
+	if hook.Namespace != "" {
+		tools := kom.Cluster(hook.Cluster).Tools()
+		for _, obj := range objs {
+			_, namespaced, _ := tools.GetGVRByGVK(obj.GroupVersionKind())
+			switch {
+			case !namespaced:
+				return "", fmt.Errorf("%s %s 为集群级资源，超出 webhook 的命名空间 %s", obj.GetKind(), obj.GetName(), hook.Namespace)
+			case obj.GetNamespace() == "":
+				obj.SetNamespace(hook.Namespace)
+			case obj.GetNamespace() != hook.Namespace:
+				return "", fmt.Errorf("%s %s/%s 超出 webhook 的命名空间 %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), hook.Namespace)
+			}
+		}
+	}
+	var applied []string
+	for _, obj := range objs {
+		action, err := ManifestService().Apply(ctx, hook.Cluster, obj)
+		if err != nil {
+			return "", fmt.Errorf("%s %s/%s 应用失败: %w；此前已应用: %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err, strings.Join(applied, ", "))
+		}
+		applied = append(applied, fmt.Sprintf("%s %s/%s %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), action))
+	}
+	return "模板已应用: " + strings.Join(applied, ", "), nil
+}
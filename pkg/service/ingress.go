@@ -123,6 +123,8 @@ func (p *ingressService) watchSingleCluster(selectedCluster string) watch.Interf
 				klog.V(6).Infof("%s 无法将对象转换为 *v1.Ingress 类型: %v", selectedCluster, err)
 				return
 			}
+			// 资源变更，使列表缓存失效
+			ListCacheService().Invalidate(selectedCluster, "Ingress")
 			switch event.Type {
 			case watch.Added:
 				p.IncreaseIngressCount(selectedCluster, &ingress)
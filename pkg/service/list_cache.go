@@ -0,0 +1,74 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/flag"
+)
+
+// ListCacheEntry 列表接口的缓存结果
+type ListCacheEntry struct {
+	ETag string // 响应内容的摘要，用于 If-None-Match 协商
+	Body []byte // 已序列化的响应内容
+}
+
+// listCacheService 列表接口的短期响应缓存。
+// 缓存键包含 集群/资源类型 的代数，资源发生变更（watch 事件或经由 k8m 的写操作）时代数递增，旧缓存自然失效。
+type listCacheService struct {
+	generations sync.Map // key: cluster/kind, value: *atomic.Int64
+}
+
+// TTL 返回缓存有效期，为 0 表示不启用缓存
+func (l *listCacheService) TTL() time.Duration {
+	return time.Duration(flag.Init().ListCacheTTL) * time.Second
+}
+
+func (l *listCacheService) generation(cluster, kind string) *atomic.Int64 {
+	v, _ := l.generations.LoadOrStore(cluster+"/"+strings.ToLower(kind), &atomic.Int64{})
+	return v.(*atomic.Int64)
+}
+
+// Key 根据集群、资源类型、命名空间、查询条件以及用户生成缓存键。
+// 不同用户的权限可能不同，因此用户名也是缓存键的一部分。
+func (l *listCacheService) Key(cluster, group, version, kind, ns, username string, query []byte) string {
+	gen := l.generation(cluster, kind).Load()
+	sum := sha256.Sum256(query)
+	return fmt.Sprintf("list-cache/%s/%s/%s/%s/%d/%s/%s/%s", cluster, group, version, strings.ToLower(kind), gen, ns, username, hex.EncodeToString(sum[:8]))
+}
+
+// Get 获取缓存
+func (l *listCacheService) Get(key string) (*ListCacheEntry, bool) {
+	c := CacheService().CacheInstance()
+	if c == nil {
+		return nil, false
+	}
+	v, ok := c.Get(key)
+	if !ok {
+		return nil, false
+	}
+	entry, ok := v.(*ListCacheEntry)
+	return entry, ok
+}
+
+// Set 写入缓存并返回生成的 ETag
+func (l *listCacheService) Set(key string, body []byte) string {
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:16]))
+	c := CacheService().CacheInstance()
+	if c == nil || l.TTL() <= 0 {
+		return etag
+	}
+	c.SetWithTTL(key, &ListCacheEntry{ETag: etag, Body: body}, int64(len(body)), l.TTL())
+	return etag
+}
+
+// Invalidate 使指定集群下某类资源的列表缓存失效
+func (l *listCacheService) Invalidate(cluster, kind string) {
+	l.generation(cluster, kind).Add(1)
+}
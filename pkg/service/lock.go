@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm/clause"
+)
+
+// lockService 基于数据库的分布式锁，适用于多个实例共享 MySQL/PostgreSQL 的部署方式。
+// 过期时间以各实例本地时间计算，多实例之间需保持时钟同步。
+type lockService struct {
+}
+
+// TryAcquire 尝试获取或续约名为 name 的锁，持有者为 holder，有效期为 ttl。
+// 锁空闲、已过期或本来就由 holder 持有时返回 true。
+func (l *lockService) TryAcquire(name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	// 续约自己的锁，或抢占已过期的锁
+	result := dao.DB().Model(&models.DistributedLock{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]any{"holder": holder, "expires_at": expiresAt})
+	if result.Error != nil {
+		return false, fmt.Errorf("更新分布式锁[%s]失败: %w", name, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// 锁记录不存在时创建，主键冲突说明已被其他实例持有
+	result = dao.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&models.DistributedLock{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: expiresAt,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("创建分布式锁[%s]失败: %w", name, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Release 释放由 holder 持有的锁，不是自己持有的锁不会被删除
+func (l *lockService) Release(name, holder string) error {
+	return dao.DB().Where("name = ? AND holder = ?", name, holder).Delete(&models.DistributedLock{}).Error
+}
+
+// Holder 查询锁的当前持有者，锁不存在或已过期时返回空字符串
+func (l *lockService) Holder(name string) (string, error) {
+	var lock models.DistributedLock
+	result := dao.DB().Where("name = ? AND expires_at >= ?", name, time.Now()).Limit(1).Find(&lock)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return lock.Holder, nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/kom/kom"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type manifestService struct{}
+
+// 应用顺序，数值越小越先应用；未列出的资源排在最后
+var manifestKindOrder = map[string]int{
+	"Namespace":                      0,
+	"CustomResourceDefinition":       1,
+	"PriorityClass":                  2,
+	"StorageClass":                   3,
+	"PersistentVolume":               3,
+	"ServiceAccount":                 4,
+	"Secret":                         5,
+	"ConfigMap":                      5,
+	"ClusterRole":                    6,
+	"ClusterRoleBinding":             7,
+	"Role":                           6,
+	"RoleBinding":                    7,
+	"ResourceQuota":                  8,
+	"LimitRange":                     8,
+	"PersistentVolumeClaim":          9,
+	"Service":                        10,
+	"DaemonSet":                      11,
+	"Deployment":                     11,
+	"StatefulSet":                    11,
+	"ReplicaSet":                     11,
+	"Job":                            12,
+	"CronJob":                        12,
+	"Ingress":                        13,
+	"HorizontalPodAutoscaler":        14,
+	"PodDisruptionBudget":            14,
+	"MutatingWebhookConfiguration":   15,
+	"ValidatingWebhookConfiguration": 15,
+}
+
+// Parse 将多文档YAML解析为对象列表，kind: List 展开为其中的各项，缺少 apiVersion、kind、metadata.name 时返回错误
+func (m *manifestService) Parse(content string) ([]*unstructured.Unstructured, error) {
+	return utils.ParseManifests(content)
+}
+
+// SortByDependency 按依赖顺序稳定排序：Namespace、CRD 优先，其次是配置、RBAC，最后是工作负载
+func (m *manifestService) SortByDependency(objs []*unstructured.Unstructured) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		return kindOrder(objs[i].GetKind()) < kindOrder(objs[j].GetKind())
+	})
+}
+
+func kindOrder(kind string) int {
+	if o, ok := manifestKindOrder[kind]; ok {
+		return o
+	}
+	return 100
+}
+
+// DryRun 在服务端以 dryRun=All 方式预演创建或更新，不会真正修改集群。
+// 读取与预演均经 kom 以 ctx 中的用户执行，与实际应用一样校验权限与准入策略
+func (m *manifestService) DryRun(ctx context.Context, cluster string, obj *unstructured.Unstructured) (string, error) {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return "", fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	gvk := obj.GroupVersionKind()
+	_, namespaced, ok := k.Tools().GetGVRByGVK(gvk)
+	if !ok {
+		return "", fmt.Errorf("集群中不存在资源类型 %s", gvk.String())
+	}
+	target := obj.DeepCopy()
+	ns := target.GetNamespace()
+	if namespaced && ns == "" {
+		ns = metav1.NamespaceDefault
+		target.SetNamespace(ns)
+	}
+	dryCtx := context.WithValue(ctx, constants.DryRun, true)
+	var existing *unstructured.Unstructured
+	var err error
+	if target.GetName() != "" {
+		err = kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(ns).Name(target.GetName()).Get(&existing).Error
+	}
+	switch {
+	case err == nil && existing != nil && existing.GetName() != "":
+		target.SetResourceVersion(existing.GetResourceVersion())
+		err = kom.Cluster(cluster).WithContext(dryCtx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(ns).Name(target.GetName()).Update(&target).Error
+		return "update", err
+	case err != nil && !apierrors.IsNotFound(err):
+		// 无权读取等错误直接返回，不再尝试创建，避免借预演探测无权访问的命名空间中对象是否存在
+		return "create", err
+	}
+	err = kom.Cluster(cluster).WithContext(dryCtx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(ns).Name(target.GetName()).Create(&target).Error
+	return "create", err
+}
+
+// Apply 创建或更新单个资源，返回执行的动作
+func (m *manifestService) Apply(ctx context.Context, cluster string, obj *unstructured.Unstructured) (string, error) {
+	action, _, err := m.ApplyTracked(ctx, cluster, obj)
+	return action, err
+}
+
+// ApplyTracked 创建或更新单个资源，更新时同时返回更新前的对象，用于失败后回滚
+func (m *manifestService) ApplyTracked(ctx context.Context, cluster string, obj *unstructured.Unstructured) (string, *unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return "", nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	_, namespaced, _ := k.Tools().GetGVRByGVK(gvk)
+	ns := obj.GetNamespace()
+	if ns == "" && namespaced {
+		ns = metav1.NamespaceDefault
+		obj.SetNamespace(ns)
+	}
+	var existing *unstructured.Unstructured
+	err := kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(ns).Name(obj.GetName()).Get(&existing).Error
+	if err == nil && existing != nil && existing.GetName() != "" {
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		err = kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(ns).Name(obj.GetName()).Update(&obj).Error
+		return "updated", existing, err
+	}
+	err = kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(ns).Name(obj.GetName()).Create(&obj).Error
+	return "created", nil, err
+}
+
+// Rollback 撤销一次 ApplyTracked：新创建的资源被删除，更新过的资源恢复为更新前的定义
+func (m *manifestService) Rollback(ctx context.Context, cluster string, obj *unstructured.Unstructured, action string, previous *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	k := kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).Namespace(obj.GetNamespace()).Name(obj.GetName())
+	switch action {
+	case "created":
+		return k.Delete().Error
+	case "updated":
+		if previous == nil {
+			return nil
+		}
+		var live *unstructured.Unstructured
+		if err := k.Get(&live).Error; err != nil {
+			return err
+		}
+		restore := previous.DeepCopy()
+		unstructured.RemoveNestedField(restore.Object, "status")
+		unstructured.RemoveNestedField(restore.Object, "metadata", "managedFields")
+		restore.SetResourceVersion(live.GetResourceVersion())
+		return kom.Cluster(cluster).WithContext(ctx).CRD(gvk.Group, gvk.Version, gvk.Kind).
+			Namespace(obj.GetNamespace()).Name(obj.GetName()).Update(&restore).Error
+	}
+	return nil
+}
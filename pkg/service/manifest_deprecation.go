@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/deprecation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// DeprecatedAPIWarning 清单中使用了目标集群已弃用或已移除的 API 版本
+type DeprecatedAPIWarning struct {
+	Kind         string `json:"kind"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name"`
+	APIVersion   string `json:"apiVersion"`            // 清单中原本的 apiVersion
+	Replacement  string `json:"replacement,omitempty"` // 建议改用的 apiVersion
+	DeprecatedIn string `json:"deprecated_in"`
+	RemovedIn    string `json:"removed_in"`
+	Removed      bool   `json:"removed"`   // 目标集群已不再提供该版本，不转换时应用会失败
+	Converted    bool   `json:"converted"` // 已无损转换为替代版本
+	Message      string `json:"message"`
+}
+
+// CheckDeprecated 按目标集群的版本检查清单中已弃用或已移除的 apiVersion。
+// convert 为 true 时，将只需修改 apiVersion 即可无损转换的对象原地改为替代版本，其余仅给出提示
+func (m *manifestService) CheckDeprecated(cluster string, objs []*unstructured.Unstructured, convert bool) ([]*DeprecatedAPIWarning, error) {
+	version, err := clusterServerVersion(cluster)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []*DeprecatedAPIWarning
+	for _, obj := range objs {
+		a := deprecation.Lookup(obj.GetAPIVersion(), obj.GetKind())
+		if a == nil || !a.DeprecatedBy(version) {
+			continue
+		}
+		w := &DeprecatedAPIWarning{
+			Kind:         obj.GetKind(),
+			Namespace:    obj.GetNamespace(),
+			Name:         obj.GetName(),
+			APIVersion:   obj.GetAPIVersion(),
+			Replacement:  a.Replacement,
+			DeprecatedIn: a.DeprecatedIn,
+			RemovedIn:    a.RemovedIn,
+			Removed:      a.RemovedBy(version),
+		}
+		msg := []string{a.Message()}
+		if w.Removed {
+			msg = append(msg, fmt.Sprintf("当前集群 %s 已不再提供该版本", version))
+		}
+		switch {
+		case convert && a.Lossless():
+			obj.SetAPIVersion(a.Replacement)
+			w.Converted = true
+			msg = append(msg, "已自动转换为 "+a.Replacement)
+		case a.Lossless():
+			msg = append(msg, "可无损转换，开启自动转换即可")
+		case a.Replacement != "":
+			msg = append(msg, "新版本的字段或默认行为有变化，需手动迁移")
+		}
+		w.Message = strings.Join(msg, "，")
+		warnings = append(warnings, w)
+	}
+	return warnings, nil
+}
+
+// CheckDeprecatedYAML 检查多文档YAML中的弃用 API，有对象被转换时返回重新生成的YAML，否则原样返回
+func (m *manifestService) CheckDeprecatedYAML(cluster, content string, convert bool) (string, []*DeprecatedAPIWarning, error) {
+	objs, err := m.Parse(content)
+	if err != nil {
+		return content, nil, err
+	}
+	warnings, err := m.CheckDeprecated(cluster, objs, convert)
+	if err != nil {
+		return content, nil, err
+	}
+	converted := false
+	for _, w := range warnings {
+		converted = converted || w.Converted
+	}
+	if !converted {
+		return content, warnings, nil
+	}
+	docs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return content, nil, err
+		}
+		docs = append(docs, string(b))
+	}
+	return strings.Join(docs, "---\n"), warnings, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/weibaohui/k8m/pkg/disruption"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+)
+
+// DrainPodKey 参与驱逐预览的单个 Pod
+type DrainPodKey struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// DrainPreview 预览驱逐节点上的 Pod 及指定 Pod 时 PDB 的影响，不做任何变更。
+// 节点尚未隔离时在提示中说明驱逐会先隔离节点
+func (n *nodeService) DrainPreview(ctx context.Context, cluster string, nodes []string, podKeys []DrainPodKey) (*disruption.Preview, error) {
+	if len(nodes) == 0 && len(podKeys) == 0 {
+		return nil, fmt.Errorf("请指定节点或 Pod")
+	}
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	seen := map[string]bool{}
+	var pods []*v1.Pod
+	addPod := func(pod *v1.Pod) {
+		key := pod.Namespace + "/" + pod.Name
+		if !seen[key] {
+			seen[key] = true
+			pods = append(pods, pod)
+		}
+	}
+	var unschedulable []string
+	for _, name := range nodes {
+		var node v1.Node
+		if err := k.WithContext(ctx).Resource(&node).Name(name).Get(&node).Error; err != nil {
+			return nil, fmt.Errorf("读取节点 %s 失败: %w", name, err)
+		}
+		if node.Spec.Unschedulable {
+			unschedulable = append(unschedulable, name)
+		}
+		var list []*v1.Pod
+		if err := k.WithContext(ctx).Resource(&v1.Pod{}).AllNamespace().
+			WithFieldSelector("spec.nodeName=" + name).List(&list).Error; err != nil {
+			return nil, fmt.Errorf("列出节点 %s 上的 Pod 失败: %w", name, err)
+		}
+		for _, pod := range list {
+			addPod(pod)
+		}
+	}
+	for _, key := range podKeys {
+		var pod v1.Pod
+		if err := k.WithContext(ctx).Resource(&pod).Namespace(key.Namespace).Name(key.Name).Get(&pod).Error; err != nil {
+			return nil, fmt.Errorf("读取 Pod %s/%s 失败: %w", key.Namespace, key.Name, err)
+		}
+		addPod(&pod)
+	}
+
+	namespaces := map[string]bool{}
+	for _, pod := range pods {
+		namespaces[pod.Namespace] = true
+	}
+	var pdbs []*policyv1.PodDisruptionBudget
+	for ns := range namespaces {
+		var list []*policyv1.PodDisruptionBudget
+		if err := k.WithContext(ctx).Resource(&policyv1.PodDisruptionBudget{}).Namespace(ns).List(&list).Error; err != nil {
+			return nil, fmt.Errorf("列出命名空间 %s 的 PDB 失败: %w", ns, err)
+		}
+		pdbs = append(pdbs, list...)
+	}
+
+	preview := disruption.Evaluate(pods, pdbs)
+	for _, name := range nodes {
+		if !slices.Contains(unschedulable, name) {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("节点 %s 未隔离，驱逐时将先隔离，新 Pod 不再调度到该节点", name))
+		}
+	}
+	return preview, nil
+}
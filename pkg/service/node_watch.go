@@ -142,6 +142,8 @@ func (n *nodeService) watchSingleCluster(selectedCluster string) watch.Interface
 				return
 			}
 			// 处理事件
+			// 资源变更，使列表缓存失效
+			ListCacheService().Invalidate(selectedCluster, "Node")
 			switch event.Type {
 			case watch.Added:
 				// 新增节点时，保存节点标签
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/nsclone"
+	"github.com/weibaohui/kom/kom"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+)
+
+type nsCloneService struct{}
+
+// NamespaceCloneRequest 命名空间克隆请求
+type NamespaceCloneRequest struct {
+	// TargetCluster 目标集群，为空时克隆到当前集群
+	TargetCluster   string         `json:"target_cluster"`
+	TargetNamespace string         `json:"target_namespace"`
+	Kinds           []nsclone.Kind `json:"kinds"`
+	// Names 名称改写规则，引用了被改名资源的字段同步改写
+	Names        nsclone.NameRewrite `json:"names"`
+	SetLabels    map[string]string   `json:"set_labels"`
+	RemoveLabels []string            `json:"remove_labels"`
+	// Secrets Secret 处理方式：copy（默认）、exclude、seal
+	Secrets nsclone.SecretMode `json:"secrets"`
+	// SealCert seal 模式下目标集群 sealed-secrets 控制器的证书，可通过 kubeseal --fetch-cert 获取
+	SealCert string `json:"seal_cert"`
+	// Overwrite 目标命名空间已存在时是否继续，同名资源会被更新
+	Overwrite bool `json:"overwrite"`
+	DryRun    bool `json:"dry_run"`
+}
+
+// 单个资源的克隆状态
+const (
+	nsCloneStatusValid   = "valid"   // 预演通过
+	nsCloneStatusPending = "pending" // 目标命名空间尚未创建，预演时无法校验
+	nsCloneStatusInvalid = "invalid" // 预演失败
+	nsCloneStatusApplied = "applied" // 已应用
+	nsCloneStatusFailed  = "failed"  // 应用失败
+)
+
+// NamespaceCloneItem 单个资源的克隆结果
+type NamespaceCloneItem struct {
+	Kind       string `json:"kind"`
+	SourceName string `json:"source_name,omitempty"`
+	Name       string `json:"name"`
+	Action     string `json:"action,omitempty"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+}
+
+// NamespaceCloneResult 命名空间克隆结果
+type NamespaceCloneResult struct {
+	TargetCluster   string                `json:"target_cluster"`
+	TargetNamespace string                `json:"target_namespace"`
+	DryRun          bool                  `json:"dry_run"`
+	Succeeded       bool                  `json:"succeeded"`
+	Items           []*NamespaceCloneItem `json:"items"`
+	Skipped         []nsclone.Skipped     `json:"skipped"`
+}
+
+// Clone 将 cluster 中 namespace 的资源克隆到目标命名空间。目标命名空间不存在时自动创建；
+// 资源逐个创建或更新，单个失败不影响其余资源，不做回滚。PVC 只克隆声明，不复制卷中的数据
+func (s *nsCloneService) Clone(ctx context.Context, cluster, namespace string, req *NamespaceCloneRequest) (*NamespaceCloneResult, error) {
+	targetCluster := req.TargetCluster
+	if targetCluster == "" {
+		targetCluster = cluster
+	}
+	if errs := validation.IsDNS1123Label(req.TargetNamespace); len(errs) > 0 {
+		return nil, fmt.Errorf("目标命名空间 %q 无效: %s", req.TargetNamespace, strings.Join(errs, "; "))
+	}
+	if targetCluster == cluster && req.TargetNamespace == namespace {
+		return nil, fmt.Errorf("目标命名空间不能与源命名空间相同")
+	}
+	if !ClusterService().IsConnected(targetCluster) {
+		return nil, fmt.Errorf("目标集群未连接，请先连接集群: %s", targetCluster)
+	}
+
+	opt := &nsclone.Options{
+		SourceNamespace: namespace,
+		TargetNamespace: req.TargetNamespace,
+		Names:           req.Names,
+		SetLabels:       req.SetLabels,
+		RemoveLabels:    req.RemoveLabels,
+		Secrets:         req.Secrets,
+	}
+	switch req.Secrets {
+	case "":
+		opt.Secrets = nsclone.SecretCopy
+	case nsclone.SecretCopy, nsclone.SecretExclude:
+	case nsclone.SecretSeal:
+		key, err := nsclone.ParseSealCert([]byte(req.SealCert))
+		if err != nil {
+			return nil, err
+		}
+		opt.SealKey = key
+	default:
+		return nil, fmt.Errorf("不支持的 Secret 处理方式 %q", req.Secrets)
+	}
+
+	var sourceNs *unstructured.Unstructured
+	if err := kom.Cluster(cluster).WithContext(ctx).CRD("", "v1", "Namespace").Name(namespace).Get(&sourceNs).Error; err != nil {
+		return nil, fmt.Errorf("获取源命名空间 %s 失败: %w", namespace, err)
+	}
+	var existing *unstructured.Unstructured
+	err := kom.Cluster(targetCluster).WithContext(ctx).CRD("", "v1", "Namespace").Name(req.TargetNamespace).Get(&existing).Error
+	targetExists := err == nil && existing != nil && existing.GetName() != ""
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("检查目标命名空间失败: %w", err)
+	}
+	if targetExists && !req.Overwrite {
+		return nil, fmt.Errorf("目标命名空间 %s 已存在，如需覆盖其中的同名资源请开启 overwrite", req.TargetNamespace)
+	}
+
+	kinds := req.Kinds
+	if len(kinds) == 0 {
+		kinds = nsclone.DefaultKinds
+	}
+	var objs []*unstructured.Unstructured
+	var skipped []nsclone.Skipped
+	for _, kind := range kinds {
+		var list []*unstructured.Unstructured
+		err := kom.Cluster(cluster).WithContext(ctx).CRD(kind.Group, kind.Version, kind.Kind).Namespace(namespace).List(&list).Error
+		if err != nil {
+			// 集群中不存在的资源类型（如未安装的 CRD）不影响其他资源
+			klog.V(6).Infof("namespace clone list %s in %s/%s error: %v", kind.Kind, cluster, namespace, err)
+			skipped = append(skipped, nsclone.Skipped{Kind: kind.Kind, Reason: fmt.Sprintf("获取资源失败: %v", err)})
+			continue
+		}
+		for _, obj := range list {
+			// 部分资源的列表结果不带 apiVersion、kind，应用前需要补齐
+			obj.SetAPIVersion(strings.TrimPrefix(kind.Group+"/"+kind.Version, "/"))
+			obj.SetKind(kind.Kind)
+			objs = append(objs, obj)
+		}
+	}
+
+	clones, transformSkipped, err := nsclone.Transform(objs, opt)
+	if err != nil {
+		return nil, err
+	}
+	skipped = append(skipped, transformSkipped...)
+	targets := make([]*unstructured.Unstructured, 0, len(clones)+1)
+	sources := make(map[*unstructured.Unstructured]string, len(clones))
+	if !targetExists {
+		targets = append(targets, nsclone.Namespace(sourceNs, opt))
+	}
+	for _, c := range clones {
+		targets = append(targets, c.Object)
+		sources[c.Object] = c.Source.GetName()
+	}
+	ManifestService().SortByDependency(targets)
+
+	result := &NamespaceCloneResult{
+		TargetCluster:   targetCluster,
+		TargetNamespace: req.TargetNamespace,
+		DryRun:          req.DryRun,
+		Succeeded:       true,
+		Skipped:         skipped,
+	}
+	for _, obj := range targets {
+		item := &NamespaceCloneItem{Kind: obj.GetKind(), SourceName: sources[obj], Name: obj.GetName()}
+		result.Items = append(result.Items, item)
+		if req.DryRun {
+			action, err := ManifestService().DryRun(ctx, targetCluster, obj)
+			item.Action = action
+			switch {
+			case err == nil:
+				item.Status = nsCloneStatusValid
+			case !targetExists && apierrors.IsNotFound(err):
+				item.Status = nsCloneStatusPending
+				item.Message = "依赖将要创建的目标命名空间，应用时校验"
+			default:
+				item.Status = nsCloneStatusInvalid
+				item.Message = err.Error()
+				result.Succeeded = false
+			}
+			continue
+		}
+		action, err := ManifestService().Apply(ctx, targetCluster, obj)
+		item.Action = action
+		if err != nil {
+			item.Status = nsCloneStatusFailed
+			item.Message = err.Error()
+			result.Succeeded = false
+			// 目标命名空间创建失败时其余资源无法创建
+			if obj.GetKind() == "Namespace" {
+				return result, nil
+			}
+			continue
+		}
+		item.Status = nsCloneStatusApplied
+	}
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/objectstorage"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+)
+
+// objectStorageService 在容器与 S3 兼容存储之间传输文件，内容不经过用户浏览器
+type objectStorageService struct{}
+
+// ObjectTransferResult 传输结果
+type ObjectTransferResult struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// client 每次按平台参数设置创建客户端，配置修改后立即生效
+func (o *objectStorageService) client() (*objectstorage.S3Client, error) {
+	m, err := ConfigService().GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return objectstorage.NewS3Client(objectstorage.S3Config{
+		Endpoint:  m.S3Endpoint,
+		Region:    m.S3Region,
+		Bucket:    m.S3Bucket,
+		AccessKey: m.S3AccessKey,
+		SecretKey: m.S3SecretKey,
+		PathStyle: m.S3PathStyle,
+	})
+}
+
+// podFileKey 将对象 key 限定在集群、命名空间对应的前缀下，并拒绝落入平台制品前缀的 key，
+// 防止用户借容器文件传输读取或覆盖其他命名空间的对象与巡检包等平台制品
+func (o *objectStorageService) podFileKey(ref *PodFileRef, key string) (string, error) {
+	scoped, err := objectstorage.PodFileKey(ref.Cluster, ref.Namespace, key)
+	if err != nil {
+		return "", err
+	}
+	m, err := ConfigService().GetConfig()
+	if err != nil {
+		return "", err
+	}
+	if m.ArtifactDriver == ArtifactDriverS3 {
+		prefix := strings.Trim(m.ArtifactS3Prefix, "/")
+		if prefix == "" {
+			prefix = defaultArtifactS3Prefix
+		}
+		if strings.HasPrefix(scoped+"/", prefix+"/") {
+			return "", fmt.Errorf("对象 key %s 位于平台制品目录，不允许读写", scoped)
+		}
+	}
+	return scoped, nil
+}
+
+// Push 将容器中的文件上传到存储桶，目录以 tar 打包后上传。
+// key 限定在 k8m-pod-files/集群/命名空间/ 之下，为空时使用 Pod/时间/文件名。S3 上传需要确定长度，内容先暂存到 k8m 的临时文件。
+func (o *objectStorageService) Push(ctx context.Context, src *PodFileRef, isDir bool, key string) (*ObjectTransferResult, error) {
+	client, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	src.Path = path.Clean(src.Path)
+	name := path.Base(src.Path)
+	contentType := "application/octet-stream"
+	command := []string{"cat", src.Path}
+	if isDir {
+		name += ".tar"
+		contentType = "application/x-tar"
+		command = []string{"tar", "cf", "-", "-C", path.Dir(src.Path), path.Base(src.Path)}
+	}
+	if key == "" {
+		key = strings.Join([]string{src.PodName, time.Now().Format("20060102-150405"), name}, "/")
+	}
+	if key, err = o.podFileKey(src, key); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(UploadStagingService().Root(), "k8m-s3-*")
+	if err != nil {
+		return nil, fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	var stderr strings.Builder
+	err = kom.Cluster(src.Cluster).WithContext(WithReadOnlyExec(ctx)).Resource(&v1.Pod{}).Namespace(src.Namespace).Name(src.PodName).
+		Ctl().Pod().ContainerName(src.ContainerName).Command(command[0], command[1:]...).
+		StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdout: tmp, Stderr: &stderr}).Error
+	if err != nil {
+		return nil, fmt.Errorf("读取容器文件失败: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := client.PutObject(ctx, key, tmp, size, contentType); err != nil {
+		return nil, fmt.Errorf("上传到存储桶失败: %w", err)
+	}
+	klog.V(6).Infof("容器文件 %s/%s/%s:%s 已上传到 %s/%s，%d 字节", src.Cluster, src.Namespace, src.PodName, src.Path, client.Bucket(), key, size)
+	return &ObjectTransferResult{Bucket: client.Bucket(), Key: key, Bytes: size}, nil
+}
+
+// Pull 将存储桶中的对象流式写入容器，不在 k8m 落盘。key 与推送相同，限定在目标容器所在集群、命名空间的前缀下。
+// extract 为 true 时按 tar 解包到 dst.Path 目录，否则写入 dst.Path 文件（覆盖已有内容）。
+func (o *objectStorageService) Pull(ctx context.Context, key string, dst *PodFileRef, extract bool) (*ObjectTransferResult, error) {
+	client, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	if key, err = o.podFileKey(dst, key); err != nil {
+		return nil, err
+	}
+	body, size, err := client.GetObject(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("读取存储桶对象失败: %w", err)
+	}
+	defer body.Close()
+
+	command := []string{"sh", "-c", "cat > " + utils.ShellQuote(dst.Path)}
+	if extract {
+		command = []string{"tar", "xmf", "-", "-C", dst.Path}
+	}
+	var stderr strings.Builder
+	err = kom.Cluster(dst.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(dst.Namespace).Name(dst.PodName).
+		Ctl().Pod().ContainerName(dst.ContainerName).Command(command[0], command[1:]...).
+		StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdin: body, Stdout: io.Discard, Stderr: &stderr}).Error
+	if err != nil {
+		return nil, fmt.Errorf("写入容器失败: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return &ObjectTransferResult{Bucket: client.Bucket(), Key: key, Bytes: size}, nil
+}
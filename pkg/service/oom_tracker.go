@@ -0,0 +1,279 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	utils2 "github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"gorm.io/gorm/clause"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+)
+
+// oomEventRetention OOMKilled 与驱逐记录的保留时间
+const oomEventRetention = 90 * 24 * time.Hour
+
+type oomTrackerService struct{}
+
+// Watch 定时检查已连接集群，为未开启采集的集群监听 Pod 变化，记录 OOMKilled 与驱逐，并清理过期记录
+func (o *oomTrackerService) Watch() {
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", func() {
+		clusters := ClusterService().ConnectedClusters()
+		for _, cluster := range clusters {
+			if !cluster.GetClusterWatchStatus("oom") {
+				selectedCluster := ClusterService().ClusterID(cluster)
+				watcher := o.watchSingleCluster(selectedCluster)
+				if watcher != nil {
+					cluster.SetClusterWatchStarted("oom", watcher)
+				}
+			}
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增OOM采集定时任务报错: %v", err)
+	}
+	_, err = inst.AddFunc("@daily", o.cleanup)
+	if err != nil {
+		klog.Errorf("新增OOM记录清理定时任务报错: %v", err)
+	}
+	inst.Start()
+	klog.V(6).Infof("新增OOM采集定时任务【@every 1m】")
+}
+
+func (o *oomTrackerService) watchSingleCluster(selectedCluster string) watch.Interface {
+	ctx := utils2.GetContextWithAdmin()
+
+	var watcher watch.Interface
+	var pod v1.Pod
+	err := kom.Cluster(selectedCluster).WithContext(ctx).Resource(&pod).AllNamespace().Watch(&watcher).Error
+	if err != nil {
+		klog.Errorf("%s 创建OOM采集监听器失败 %v", selectedCluster, err)
+		return nil
+	}
+	go func() {
+		klog.V(6).Infof("%s start watch oom", selectedCluster)
+		defer watcher.Stop()
+		for event := range watcher.ResultChan() {
+			if event.Type != watch.Added && event.Type != watch.Modified {
+				continue
+			}
+			var item v1.Pod
+			err = kom.Cluster(selectedCluster).WithContext(ctx).Tools().ConvertRuntimeObjectToTypedObject(event.Object, &item)
+			if err != nil {
+				klog.V(6).Infof("%s 无法将对象转换为 *v1.Pod 类型: %v", selectedCluster, err)
+				continue
+			}
+			o.record(selectedCluster, &item)
+		}
+	}()
+	return watcher
+}
+
+// record 从 Pod 状态中提取 OOMKilled 与驱逐记录，依靠唯一键去重，Pod 的多次变更只记录一次
+func (o *oomTrackerService) record(selectedCluster string, pod *v1.Pod) {
+	var events []*models.OOMEvent
+	workload := workloadOfPod(pod)
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, st := range statuses {
+		// 重启策略为 Never 时 OOMKilled 保留在当前状态，否则在上次终止状态中
+		for _, t := range []*v1.ContainerStateTerminated{st.State.Terminated, st.LastTerminationState.Terminated} {
+			if t == nil || t.Reason != "OOMKilled" {
+				continue
+			}
+			occurred := t.FinishedAt.Time
+			if occurred.IsZero() {
+				occurred = time.Now()
+			}
+			events = append(events, &models.OOMEvent{
+				EvtKey:      fmt.Sprintf("%s/oom/%s/%s/%d", selectedCluster, pod.UID, st.Name, occurred.Unix()),
+				Cluster:     selectedCluster,
+				Kind:        models.OOMEventKindOOM,
+				Namespace:   pod.Namespace,
+				Workload:    workload,
+				Pod:         pod.Name,
+				Container:   st.Name,
+				Node:        pod.Spec.NodeName,
+				MemoryLimit: memoryLimitOf(pod, st.Name),
+				Message:     t.Message,
+				OccurredAt:  occurred,
+			})
+		}
+	}
+
+	if pod.Status.Phase == v1.PodFailed && pod.Status.Reason == "Evicted" {
+		events = append(events, &models.OOMEvent{
+			EvtKey:     fmt.Sprintf("%s/eviction/%s", selectedCluster, pod.UID),
+			Cluster:    selectedCluster,
+			Kind:       models.OOMEventKindEviction,
+			Namespace:  pod.Namespace,
+			Workload:   workload,
+			Pod:        pod.Name,
+			Node:       pod.Spec.NodeName,
+			Message:    pod.Status.Message,
+			OccurredAt: evictedAt(pod),
+		})
+	}
+
+	for _, e := range events {
+		if err := dao.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(e).Error; err != nil {
+			klog.V(6).Infof("%s 保存OOM记录 %s/%s 失败: %v", selectedCluster, e.Namespace, e.Pod, err)
+		}
+	}
+}
+
+func (o *oomTrackerService) cleanup() {
+	err := dao.DB().Where("occurred_at < ?", time.Now().Add(-oomEventRetention)).Delete(&models.OOMEvent{}).Error
+	if err != nil {
+		klog.V(6).Infof("清理过期OOM记录失败: %v", err)
+	}
+}
+
+// workloadOfPod 返回 Pod 所属的工作负载，ReplicaSet 按 pod-template-hash 还原为 Deployment，使趋势不受滚动更新影响
+func workloadOfPod(pod *v1.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		if ref.Kind == "ReplicaSet" {
+			if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(ref.Name, "-"+hash) {
+				return "Deployment/" + strings.TrimSuffix(ref.Name, "-"+hash)
+			}
+		}
+		return ref.Kind + "/" + ref.Name
+	}
+	return ""
+}
+
+func memoryLimitOf(pod *v1.Pod, container string) string {
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		if c.Name != container {
+			continue
+		}
+		if q, ok := c.Resources.Limits[v1.ResourceMemory]; ok {
+			return q.String()
+		}
+	}
+	return ""
+}
+
+// evictedAt 驱逐时间，优先取 DisruptionTarget 条件的时间，其次取最近一次状态条件变化时间
+func evictedAt(pod *v1.Pod) time.Time {
+	var latest time.Time
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.DisruptionTarget && !c.LastTransitionTime.IsZero() {
+			return c.LastTransitionTime.Time
+		}
+		if c.LastTransitionTime.After(latest) {
+			latest = c.LastTransitionTime.Time
+		}
+	}
+	if latest.IsZero() {
+		return time.Now()
+	}
+	return latest
+}
+
+// OOMTrendPoint 某一天的 OOMKilled 与驱逐次数
+type OOMTrendPoint struct {
+	Date     string `json:"date"` // 形如 2006-01-02
+	OOM      int    `json:"oom"`
+	Eviction int    `json:"eviction"`
+}
+
+// OOMTrendGroup 按命名空间或工作负载汇总的趋势
+type OOMTrendGroup struct {
+	Namespace string          `json:"namespace"`
+	Workload  string          `json:"workload,omitempty"`
+	OOM       int             `json:"oom"`
+	Eviction  int             `json:"eviction"`
+	LastAt    time.Time       `json:"last_at"`
+	Daily     []OOMTrendPoint `json:"daily"`
+}
+
+// OOMReport OOMKilled 与驱逐趋势报表
+type OOMReport struct {
+	Since  time.Time        `json:"since"`
+	Total  []OOMTrendPoint  `json:"total"`  // 整个集群（或命名空间）每天的次数
+	Groups []*OOMTrendGroup `json:"groups"` // 按次数从多到少排序
+}
+
+// Report 统计最近 days 天的 OOMKilled 与驱逐次数，groupBy 为 namespace 时按命名空间汇总，否则按工作负载汇总
+func (o *oomTrackerService) Report(selectedCluster, namespace string, days int, groupBy string) (*OOMReport, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	since := today.AddDate(0, 0, -(days - 1))
+
+	q := dao.DB().Where("cluster = ? and occurred_at >= ?", selectedCluster, since)
+	if namespace != "" {
+		q = q.Where("namespace = ?", namespace)
+	}
+	var list []*models.OOMEvent
+	if err := q.Order("occurred_at").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	// 按日期预先生成序列，没有记录的日期也返回 0，便于前端绘图
+	dates := make([]string, 0, days)
+	index := map[string]int{}
+	for d := since; !d.After(today); d = d.AddDate(0, 0, 1) {
+		index[d.Format("2006-01-02")] = len(dates)
+		dates = append(dates, d.Format("2006-01-02"))
+	}
+	newSeries := func() []OOMTrendPoint {
+		s := make([]OOMTrendPoint, len(dates))
+		for i, d := range dates {
+			s[i].Date = d
+		}
+		return s
+	}
+
+	report := &OOMReport{Since: since, Total: newSeries(), Groups: []*OOMTrendGroup{}}
+	groups := map[string]*OOMTrendGroup{}
+	for _, e := range list {
+		i, ok := index[e.OccurredAt.In(now.Location()).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		workload := e.Workload
+		if workload == "" {
+			workload = "Pod/" + e.Pod
+		}
+		if groupBy == "namespace" {
+			workload = ""
+		}
+		key := e.Namespace + "/" + workload
+		g, ok := groups[key]
+		if !ok {
+			g = &OOMTrendGroup{Namespace: e.Namespace, Workload: workload, Daily: newSeries()}
+			groups[key] = g
+			report.Groups = append(report.Groups, g)
+		}
+		switch e.Kind {
+		case models.OOMEventKindOOM:
+			g.OOM++
+			g.Daily[i].OOM++
+			report.Total[i].OOM++
+		case models.OOMEventKindEviction:
+			g.Eviction++
+			g.Daily[i].Eviction++
+			report.Total[i].Eviction++
+		}
+		if e.OccurredAt.After(g.LastAt) {
+			g.LastAt = e.OccurredAt
+		}
+	}
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		return report.Groups[i].OOM+report.Groups[i].Eviction > report.Groups[j].OOM+report.Groups[j].Eviction
+	})
+	return report, nil
+}
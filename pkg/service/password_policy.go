@@ -0,0 +1,180 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm"
+)
+
+// PasswordPolicy 本地账户密码策略，字段为 0 表示不限制
+type PasswordPolicy struct {
+	MinLength  int `json:"min_length"`
+	MinClasses int `json:"min_classes"`
+	MaxAgeDays int `json:"max_age_days"`
+	History    int `json:"history"`
+}
+
+// PasswordPolicy 返回当前生效的密码策略
+func (u *userService) PasswordPolicy() *PasswordPolicy {
+	cfg := flag.Init()
+	return &PasswordPolicy{
+		MinLength:  cfg.PasswordMinLength,
+		MinClasses: cfg.PasswordMinClasses,
+		MaxAgeDays: cfg.PasswordMaxAgeDays,
+		History:    cfg.PasswordHistory,
+	}
+}
+
+// EncryptPassword 密码加盐后加密，返回数据库中保存的值
+func (u *userService) EncryptPassword(plain, salt string) (string, error) {
+	psw, err := utils.AesEncrypt([]byte(plain + salt))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(psw), nil
+}
+
+// PasswordExpired 判断用户是否需要先修改密码：管理员要求轮换，或超过密码有效期。仅对本地账户生效
+func (u *userService) PasswordExpired(user *models.User) bool {
+	if !user.IsLocal() {
+		return false
+	}
+	if user.MustChangePassword {
+		return true
+	}
+	maxAge := u.PasswordPolicy().MaxAgeDays
+	if maxAge <= 0 {
+		return false
+	}
+	changed := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changed = *user.PasswordChangedAt
+	}
+	return time.Since(changed) > time.Duration(maxAge)*24*time.Hour
+}
+
+// ChangePassword 按密码策略校验后修改本地账户密码。
+// 旧密码记入历史，并使该用户此前签发的登录 Token 全部失效；mustChange 为 true 时要求用户下次登录后再次修改（管理员重置为临时密码时使用）
+func (u *userService) ChangePassword(username, plain string, mustChange bool) error {
+	user := &models.User{}
+	if err := dao.DB().Where("username = ?", username).First(user).Error; err != nil {
+		return fmt.Errorf("用户不存在")
+	}
+	if !user.IsLocal() {
+		return fmt.Errorf("用户 %s 来自 %s，密码不由本平台管理", username, user.Source)
+	}
+	policy := u.PasswordPolicy()
+	if err := utils.CheckPasswordComplexity(plain, username, policy.MinLength, policy.MinClasses); err != nil {
+		return err
+	}
+	if err := u.checkPasswordHistory(user, plain, policy.History); err != nil {
+		return err
+	}
+
+	salt := utils.RandNLengthString(8)
+	psw, err := u.EncryptPassword(plain, salt)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	err = dao.DB().Transaction(func(tx *gorm.DB) error {
+		if user.Password != "" && policy.History > 1 {
+			if err := tx.Create(&models.PasswordHistory{Username: username, Salt: user.Salt, Password: user.Password}).Error; err != nil {
+				return err
+			}
+			// 当前密码单独校验，历史中只保留 N-1 条
+			var ids []uint
+			if err := tx.Model(&models.PasswordHistory{}).Where("username = ?", username).Order("id desc").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if len(ids) > policy.History-1 {
+				if err := tx.Where("id in ?", ids[policy.History-1:]).Delete(&models.PasswordHistory{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]any{
+			"password":             psw,
+			"salt":                 salt,
+			"password_changed_at":  now,
+			"must_change_password": mustChange,
+			"tokens_valid_after":   now.Unix(),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	u.ClearCacheByKey(username)
+	return nil
+}
+
+// checkPasswordHistory 新密码不能与当前密码及最近 history-1 条历史密码相同
+func (u *userService) checkPasswordHistory(user *models.User, plain string, history int) error {
+	if history <= 0 {
+		return nil
+	}
+	used := []*models.PasswordHistory{{Salt: user.Salt, Password: user.Password}}
+	if history > 1 {
+		var items []*models.PasswordHistory
+		dao.DB().Where("username = ?", user.Username).Order("id desc").Limit(history - 1).Find(&items)
+		used = append(used, items...)
+	}
+	for _, h := range used {
+		if h.Password == "" {
+			continue
+		}
+		psw, err := u.EncryptPassword(plain, h.Salt)
+		if err != nil {
+			return err
+		}
+		if psw == h.Password {
+			return fmt.Errorf("新密码不能与最近 %d 次使用过的密码相同", history)
+		}
+	}
+	return nil
+}
+
+// ForcePasswordRotation 要求用户下次登录时修改密码，并使其已签发的登录 Token 立即失效
+func (u *userService) ForcePasswordRotation(userID uint) error {
+	user := &models.User{}
+	if err := dao.DB().Where("id = ?", userID).First(user).Error; err != nil {
+		return fmt.Errorf("用户不存在")
+	}
+	if !user.IsLocal() {
+		return fmt.Errorf("用户 %s 来自 %s，密码不由本平台管理", user.Username, user.Source)
+	}
+	err := dao.DB().Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]any{
+		"must_change_password": true,
+		"tokens_valid_after":   time.Now().Unix(),
+	}).Error
+	if err != nil {
+		return err
+	}
+	u.ClearCacheByKey(user.Username)
+	return nil
+}
+
+// TokenRevoked 判断登录 Token 是否因修改密码或强制轮换而失效。
+// 只校验带签发时间 iat 的登录 Token，API 密钥等长期 Token 不受影响
+func (u *userService) TokenRevoked(username string, issuedAt int64) bool {
+	cacheKey := u.formatCacheKey("user:tokensvalidafter:%s", username)
+	validAfter, err := utils.GetOrSetCache(CacheService().CacheInstance(), cacheKey, 5*time.Minute, func() (int64, error) {
+		user := &models.User{}
+		err := dao.DB().Select("tokens_valid_after").Where("username = ?", username).First(user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return user.TokensValidAfter, err
+	})
+	if err != nil {
+		return false
+	}
+	return issuedAt < validAfter
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// 重启循环的诊断类别
+const (
+	CrashCauseOOM           = "oom"            // 内存超限被杀
+	CrashCauseBadCommand    = "bad_command"    // 启动命令错误，如可执行文件不存在、无执行权限
+	CrashCauseFailedProbe   = "failed_probe"   // 存活/启动探针失败被重启
+	CrashCauseMissingConfig = "missing_config" // 引用的 ConfigMap/Secret 或其中的 key 不存在
+	CrashCauseAppError      = "app_error"      // 应用自身以非 0 退出码退出，需结合日志排查
+	CrashCauseUnknown       = "unknown"
+)
+
+// crashLoopLogTailLines 读取上一次容器日志的行数
+const crashLoopLogTailLines = 50
+
+// CrashLoopDiagnosis 单个容器的重启循环诊断结果
+type CrashLoopDiagnosis struct {
+	Namespace          string     `json:"namespace"`
+	Pod                string     `json:"pod"`
+	Workload           string     `json:"workload,omitempty"` // 控制者，形如 ReplicaSet/nginx-5d8f7c
+	Container          string     `json:"container"`
+	Init               bool       `json:"init,omitempty"`
+	RestartCount       int32      `json:"restart_count"`
+	WaitingReason      string     `json:"waiting_reason,omitempty"` // 当前等待原因，如 CrashLoopBackOff
+	WaitingMessage     string     `json:"waiting_message,omitempty"`
+	ExitCode           *int32     `json:"exit_code,omitempty"` // 上一次终止的退出码
+	Signal             int32      `json:"signal,omitempty"`
+	TerminationReason  string     `json:"termination_reason,omitempty"` // 上一次终止的原因，如 OOMKilled、Error
+	TerminationMessage string     `json:"termination_message,omitempty"`
+	FinishedAt         *time.Time `json:"finished_at,omitempty"`
+	OOMKilled          bool       `json:"oom_killed"`
+	ProbeFailures      []string   `json:"probe_failures,omitempty"` // 探针失败事件
+	ConfigErrors       []string   `json:"config_errors,omitempty"`  // 配置引用错误事件
+	LogTail            string     `json:"log_tail,omitempty"`       // 上一次运行的日志末尾
+	Cause              string     `json:"cause"`
+	Summary            string     `json:"summary"`
+	Suggestions        []string   `json:"suggestions,omitempty"`
+}
+
+// crashLoopWaitingReasons 需要诊断的容器等待原因
+var crashLoopWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"RunContainerError":          true,
+}
+
+// AnalyzeCrashLoop 诊断命名空间内处于重启循环的容器，name 不为空时只诊断该 Pod
+func (p *podService) AnalyzeCrashLoop(ctx context.Context, cluster, ns, name string) ([]*CrashLoopDiagnosis, error) {
+	var pods []v1.Pod
+	q := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns)
+	if name != "" {
+		var pod v1.Pod
+		if err := q.Name(name).Get(&pod).Error; err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	} else if err := q.List(&pods).Error; err != nil {
+		return nil, err
+	}
+
+	// 一次读取命名空间内的 Pod 事件，按 Pod 名称分组
+	var events []v1.Event
+	selector := "involvedObject.kind=Pod"
+	if name != "" {
+		selector += ",involvedObject.name=" + name
+	}
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Event{}).Namespace(ns).WithFieldSelector(selector).List(&events).Error; err != nil {
+		klog.V(6).Infof("读取命名空间[%s]事件失败: %v", ns, err)
+	}
+	eventsByPod := map[string][]v1.Event{}
+	for _, e := range events {
+		eventsByPod[e.InvolvedObject.Name] = append(eventsByPod[e.InvolvedObject.Name], e)
+	}
+
+	result := []*CrashLoopDiagnosis{}
+	for i := range pods {
+		pod := &pods[i]
+		var workload string
+		for _, ref := range pod.OwnerReferences {
+			if ref.Controller != nil && *ref.Controller {
+				workload = ref.Kind + "/" + ref.Name
+			}
+		}
+		check := func(st v1.ContainerStatus, init bool) {
+			if st.State.Waiting == nil || !crashLoopWaitingReasons[st.State.Waiting.Reason] {
+				return
+			}
+			d := &CrashLoopDiagnosis{
+				Namespace:      pod.Namespace,
+				Pod:            pod.Name,
+				Workload:       workload,
+				Container:      st.Name,
+				Init:           init,
+				RestartCount:   st.RestartCount,
+				WaitingReason:  st.State.Waiting.Reason,
+				WaitingMessage: st.State.Waiting.Message,
+			}
+			if t := st.LastTerminationState.Terminated; t != nil {
+				exitCode := t.ExitCode
+				finished := t.FinishedAt.Time
+				d.ExitCode = &exitCode
+				d.Signal = t.Signal
+				d.TerminationReason = t.Reason
+				d.TerminationMessage = t.Message
+				d.FinishedAt = &finished
+				d.OOMKilled = t.Reason == "OOMKilled"
+			}
+			d.ProbeFailures, d.ConfigErrors = crashLoopEventHints(eventsByPod[pod.Name], st.Name)
+			if st.RestartCount > 0 {
+				d.LogTail = p.previousLogTail(ctx, cluster, pod.Namespace, pod.Name, st.Name)
+			}
+			diagnoseCrashLoop(d)
+			result = append(result, d)
+		}
+		for _, st := range pod.Status.InitContainerStatuses {
+			check(st, true)
+		}
+		for _, st := range pod.Status.ContainerStatuses {
+			check(st, false)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RestartCount > result[j].RestartCount
+	})
+	return result, nil
+}
+
+// previousLogTail 读取容器上一次运行的日志末尾
+func (p *podService) previousLogTail(ctx context.Context, cluster, ns, pod, container string) string {
+	tail := int64(crashLoopLogTailLines)
+	limit := int64(32 * 1024)
+	stream, err := p.StreamPodLogs(ctx, cluster, ns, pod, &v1.PodLogOptions{
+		Container:  container,
+		Previous:   true,
+		TailLines:  &tail,
+		LimitBytes: &limit,
+	})
+	if err != nil {
+		klog.V(6).Infof("读取 %s/%s[%s] 上一次日志失败: %v", ns, pod, container, err)
+		return ""
+	}
+	defer stream.Close()
+	b, err := io.ReadAll(stream)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// crashLoopEventHints 从 Pod 事件中提取与该容器相关的探针失败、配置错误信息
+func crashLoopEventHints(events []v1.Event, container string) (probes []string, configs []string) {
+	seen := map[string]bool{}
+	for _, e := range events {
+		// fieldPath 形如 spec.containers{app}，为空的事件属于整个 Pod
+		if e.InvolvedObject.FieldPath != "" && !strings.Contains(e.InvolvedObject.FieldPath, "{"+container+"}") {
+			continue
+		}
+		msg := e.Message
+		lower := strings.ToLower(msg)
+		if seen[msg] {
+			continue
+		}
+		switch {
+		case e.Reason == "Unhealthy" && (strings.Contains(lower, "liveness probe") || strings.Contains(lower, "startup probe")),
+			e.Reason == "Killing" && strings.Contains(lower, "probe"):
+			probes = append(probes, msg)
+			seen[msg] = true
+		case isMissingConfigMessage(lower):
+			configs = append(configs, msg)
+			seen[msg] = true
+		}
+	}
+	return probes, configs
+}
+
+func isMissingConfigMessage(lower string) bool {
+	return (strings.Contains(lower, "configmap") || strings.Contains(lower, "secret")) && strings.Contains(lower, "not found") ||
+		strings.Contains(lower, "couldn't find key")
+}
+
+// diagnoseCrashLoop 按可信度从高到低判断原因，并给出处理建议
+func diagnoseCrashLoop(d *CrashLoopDiagnosis) {
+	waiting := strings.ToLower(d.WaitingMessage)
+	termination := strings.ToLower(d.TerminationMessage)
+	exitCode := int32(-1)
+	if d.ExitCode != nil {
+		exitCode = *d.ExitCode
+	}
+
+	switch {
+	case d.WaitingReason == "CreateContainerConfigError" || len(d.ConfigErrors) > 0 || isMissingConfigMessage(waiting):
+		d.Cause = CrashCauseMissingConfig
+		d.Summary = "容器引用的 ConfigMap、Secret 或其中的 key 不存在，容器无法创建"
+		d.Suggestions = []string{
+			"检查 env、envFrom、volumes 中引用的 ConfigMap/Secret 名称与 key 是否正确",
+			"确认 ConfigMap/Secret 与 Pod 位于同一命名空间",
+		}
+	case d.OOMKilled:
+		d.Cause = CrashCauseOOM
+		d.Summary = "容器内存使用超过 limits.memory，被内核 OOM Killer 终止"
+		d.Suggestions = []string{
+			"适当提高 resources.limits.memory",
+			"检查应用是否存在内存泄漏，JVM 等运行时需让堆大小与容器内存限制匹配",
+		}
+	case len(d.ProbeFailures) > 0:
+		d.Cause = CrashCauseFailedProbe
+		d.Summary = "存活或启动探针连续失败，容器被 kubelet 重启"
+		d.Suggestions = []string{
+			"确认探针的端口、路径与应用实际监听的一致",
+			"应用启动较慢时，增加 startupProbe 或调大 initialDelaySeconds、failureThreshold",
+		}
+	case exitCode == 126 || exitCode == 127 || d.TerminationReason == "ContainerCannotRun" || d.TerminationReason == "StartError" ||
+		d.WaitingReason == "RunContainerError" ||
+		strings.Contains(termination, "executable file not found") || strings.Contains(waiting, "executable file not found") ||
+		strings.Contains(termination, "exec format error") || strings.Contains(termination, "permission denied"):
+		d.Cause = CrashCauseBadCommand
+		d.Summary = "容器启动命令无法执行，可执行文件不存在、无执行权限或架构不匹配"
+		d.Suggestions = []string{
+			"检查 command、args 与镜像中的 ENTRYPOINT/CMD 是否正确",
+			"确认镜像架构与节点架构一致",
+		}
+	case exitCode > 0:
+		d.Cause = CrashCauseAppError
+		d.Summary = fmt.Sprintf("应用以退出码 %d 退出", exitCode)
+		if exitCode == 137 || exitCode == 143 {
+			d.Summary += "（收到 SIGKILL/SIGTERM 信号）"
+		}
+		d.Suggestions = []string{"查看上一次运行的日志，确认应用启动失败的原因"}
+	default:
+		d.Cause = CrashCauseUnknown
+		d.Summary = "未能确定重启原因"
+		if exitCode == 0 {
+			d.Summary = "容器正常退出（退出码 0）后被重启，主进程可能没有常驻运行"
+			d.Suggestions = []string{"确认容器主进程以前台方式持续运行，一次性任务应使用 Job"}
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/bundle"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	podDiagnosticsDefaultTail  = 1000              // 默认读取的日志行数
+	podDiagnosticsMaxTail      = 10000             // 日志行数上限
+	podDiagnosticsLogLimit     = 10 * 1024 * 1024  // 单个日志文件上限
+	podDiagnosticsFileLimit    = 10 * 1024 * 1024  // 单个容器内文件上限
+	podDiagnosticsMaxFiles     = 200               // 每个容器最多收集的文件数
+	podDiagnosticsBundleLimit  = 200 * 1024 * 1024 // 诊断包内容总大小上限
+	podDiagnosticsExecTimeout  = 2 * time.Minute   // 单个容器收集文件的超时时间
+	podDiagnosticsManifestFile = "manifest.json"
+)
+
+// PodDiagnosticsOptions 诊断包收集选项
+type PodDiagnosticsOptions struct {
+	TailLines int64    // 每个容器读取的日志行数，0 为默认值
+	FileGlobs []string // 容器内收集的文件匹配模式，为 nil 时使用平台配置
+}
+
+// PodDiagnosticsManifest 诊断包说明，位于包内 manifest.json
+type PodDiagnosticsManifest struct {
+	Cluster     string    `json:"cluster"`
+	Namespace   string    `json:"namespace"`
+	Pod         string    `json:"pod"`
+	CollectedAt time.Time `json:"collected_at"`
+	CollectedBy string    `json:"collected_by,omitempty"`
+	TailLines   int64     `json:"tail_lines"`
+	FileGlobs   []string  `json:"file_globs,omitempty"`
+	Files       []string  `json:"files"`
+	Errors      []string  `json:"errors,omitempty"`
+}
+
+// DiagnosticsFileGlobs 平台配置的默认收集文件，配置无效时返回空
+func (p *podService) DiagnosticsFileGlobs() []string {
+	globs, _ := bundle.ParseGlobs(flag.Init().DiagnosticsFileGlobs)
+	return globs
+}
+
+// CollectDiagnostics 收集 Pod 的 YAML、describe、事件、重启诊断、日志、环境变量与容器内文件，以 tar.gz 写入 w。
+// 以当前用户身份读取，Pod 不存在时返回错误；其余各项尽力收集，失败原因记录在包内 errors.txt。
+// 环境变量中来自 Secret 的值已掩码，容器内文件通过只读命令读取
+func (p *podService) CollectDiagnostics(ctx context.Context, cluster, ns, name string, opts *PodDiagnosticsOptions, w io.Writer) error {
+	var pod v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name).Get(&pod).Error; err != nil {
+		return fmt.Errorf("读取 Pod %s/%s 失败: %w", ns, name, err)
+	}
+	tail := opts.TailLines
+	if tail <= 0 {
+		tail = podDiagnosticsDefaultTail
+	}
+	tail = min(tail, podDiagnosticsMaxTail)
+	globs := opts.FileGlobs
+	if globs == nil {
+		globs = p.DiagnosticsFileGlobs()
+	}
+
+	b := bundle.NewWriter(w, fmt.Sprintf("%s-%s", ns, name), podDiagnosticsBundleLimit)
+
+	item := pod.DeepCopy()
+	item.ManagedFields = nil
+	if err := b.AddYAML("pod.yaml", item); err != nil {
+		return err
+	}
+
+	var describe []byte
+	if err := kom.Cluster(cluster).WithContext(ctx).Name(name).Namespace(ns).CRD("", "v1", "Pod").Describe(&describe).Error; err != nil {
+		b.Errorf("describe.txt: %v", err)
+	} else if err := b.AddFile("describe.txt", describe); err != nil {
+		return err
+	}
+
+	var events []v1.Event
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Event{}).Namespace(ns).
+		WithFieldSelector("involvedObject.kind=Pod,involvedObject.name=" + name).List(&events).Error; err != nil {
+		b.Errorf("events.txt: %v", err)
+	} else if err := b.AddFile("events.txt", formatDiagnosticsEvents(events)); err != nil {
+		return err
+	}
+
+	if diagnoses, err := p.AnalyzeCrashLoop(ctx, cluster, ns, name); err != nil {
+		b.Errorf("crashloop.json: %v", err)
+	} else if len(diagnoses) > 0 {
+		if err := b.AddJSON("crashloop.json", diagnoses); err != nil {
+			return err
+		}
+	}
+
+	restarts := map[string]int32{}
+	running := map[string]bool{}
+	for _, st := range append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		restarts[st.Name] = st.RestartCount
+		running[st.Name] = st.State.Running != nil
+	}
+	var containers []v1.Container
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, ctn := range containers {
+		if err := p.addDiagnosticsLog(ctx, b, cluster, ns, name, ctn.Name, tail, false); err != nil {
+			return err
+		}
+		if restarts[ctn.Name] > 0 {
+			if err := p.addDiagnosticsLog(ctx, b, cluster, ns, name, ctn.Name, tail, true); err != nil {
+				return err
+			}
+		}
+		if err := p.addDiagnosticsEnv(ctx, b, cluster, ns, name, ctn.Name); err != nil {
+			return err
+		}
+		if len(globs) > 0 && running[ctn.Name] {
+			if err := p.addDiagnosticsFiles(ctx, b, cluster, ns, name, ctn.Name, globs); err != nil {
+				return err
+			}
+		}
+	}
+
+	user, _ := ctx.Value(constants.JwtUserName).(string)
+	manifest := &PodDiagnosticsManifest{
+		Cluster:     cluster,
+		Namespace:   ns,
+		Pod:         name,
+		CollectedAt: time.Now(),
+		CollectedBy: user,
+		TailLines:   tail,
+		FileGlobs:   globs,
+		Files:       append([]string{}, b.Files()...),
+		Errors:      b.Errors(),
+	}
+	if err := b.AddJSON(podDiagnosticsManifestFile, manifest); err != nil {
+		return err
+	}
+	return b.Close()
+}
+
+// addDiagnosticsLog 写入容器日志，previous 为上一次运行的日志
+func (p *podService) addDiagnosticsLog(ctx context.Context, b *bundle.Writer, cluster, ns, name, container string, tail int64, previous bool) error {
+	file := fmt.Sprintf("logs/%s.log", container)
+	if previous {
+		file = fmt.Sprintf("logs/%s.previous.log", container)
+	}
+	limit := int64(podDiagnosticsLogLimit)
+	stream, err := p.StreamPodLogs(ctx, cluster, ns, name, &v1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		TailLines:  &tail,
+		LimitBytes: &limit,
+		Timestamps: true,
+	})
+	if err != nil {
+		b.Errorf("%s: %v", file, err)
+		return nil
+	}
+	defer stream.Close()
+	return b.AddReader(file, stream, limit)
+}
+
+// addDiagnosticsEnv 写入容器环境变量，Secret 中的值已掩码
+func (p *podService) addDiagnosticsEnv(ctx context.Context, b *bundle.Writer, cluster, ns, name, container string) error {
+	file := fmt.Sprintf("env/%s.txt", container)
+	vars, err := ContainerEnvService().Resolve(ctx, cluster, "", "v1", "Pod", ns, name, container)
+	if err != nil {
+		b.Errorf("%s: %v", file, err)
+		return nil
+	}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tVALUE\tSOURCE\tNOTE")
+	for _, v := range vars {
+		source := v.Source
+		if v.SourceName != "" {
+			source += ":" + v.SourceName
+		}
+		if v.Key != "" {
+			source += "/" + v.Key
+		}
+		var notes []string
+		if v.Masked {
+			notes = append(notes, "masked")
+		}
+		if v.Overridden {
+			notes = append(notes, "overridden")
+		}
+		if v.Error != "" {
+			notes = append(notes, v.Error)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", v.Name, oneLine(v.Value), source, strings.Join(notes, "; "))
+	}
+	_ = tw.Flush()
+	return b.AddFile(file, buf.Bytes())
+}
+
+// addDiagnosticsFiles 在容器中展开匹配模式，以 tar 流读取匹配的文件写入 files/<容器名>/ 目录
+func (p *podService) addDiagnosticsFiles(ctx context.Context, b *bundle.Writer, cluster, ns, name, container string, globs []string) error {
+	dir := "files/" + container
+	ctx, cancel := context.WithTimeout(WithReadOnlyExec(ctx), podDiagnosticsExecTimeout)
+	defer cancel()
+	q := func() *kom.Kubectl {
+		return kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name)
+	}
+
+	var out []byte
+	if err := q().Ctl().Pod().ContainerName(container).Command("sh", "-c", bundle.FileListScript(globs)).Execute(&out).Error; err != nil {
+		b.Errorf("%s: 列出文件失败: %v", dir, err)
+		return nil
+	}
+	files, skipped := bundle.ParseFileList(string(out), podDiagnosticsFileLimit, podDiagnosticsMaxFiles)
+	for _, s := range skipped {
+		b.Errorf("%s: %s", dir, s)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	// 文件路径作为参数传给 tar，不经 shell 解析；h 跟随符号链接，读取链接指向的文件
+	pr, pw := io.Pipe()
+	var stderr bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := q().Ctl().Pod().ContainerName(container).Command("tar", append([]string{"chf", "-"}, files...)...).
+			StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdout: pw, Stderr: &stderr}).Error
+		_ = pw.CloseWithError(err)
+	}()
+	_, err := b.AddTar(dir, pr, podDiagnosticsFileLimit)
+	// 提前结束时关闭管道让 tar 进程退出，等待其结束后再读取 stderr
+	_ = pr.Close()
+	cancel()
+	<-done
+	if err != nil {
+		b.Errorf("%s: 读取文件失败: %v %s", dir, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// formatDiagnosticsEvents 按时间顺序输出事件，格式与 kubectl get events 相近
+func formatDiagnosticsEvents(events []v1.Event) []byte {
+	sort.SliceStable(events, func(i, j int) bool {
+		return diagnosticsEventTime(&events[i]).Before(diagnosticsEventTime(&events[j]))
+	})
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "LAST SEEN\tNAMESPACE\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE")
+	for i := range events {
+		e := &events[i]
+		object := e.InvolvedObject.Kind + "/" + e.InvolvedObject.Name
+		if e.InvolvedObject.FieldPath != "" {
+			object += " " + e.InvolvedObject.FieldPath
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", diagnosticsEventTime(e).Format(time.RFC3339), e.Namespace, e.Type, e.Reason, object, max(e.Count, 1), oneLine(e.Message))
+	}
+	_ = tw.Flush()
+	return buf.Bytes()
+}
+
+// diagnosticsEventTime 事件最后发生的时间
+func diagnosticsEventTime(e *v1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// oneLine 将换行与制表符替换为空格，避免破坏表格
+func oneLine(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ").Replace(s)
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+)
+
+// DNS 诊断的默认值与限制
+const (
+	dnsDefaultExternalName = "kubernetes.io"
+	dnsLookupTimeout       = 10 * time.Second
+	dnsSlowLookup          = time.Second // 超过该耗时视为解析缓慢
+	dnsMaxExtraNames       = 10
+	dnsResolvConfPath      = "/etc/resolv.conf"
+	dnsNoToolMarker        = "__k8m_no_dns_tool__"
+	dnsNoToolError         = "容器内没有 getent 或 nslookup，无法解析"
+)
+
+// 诊断结论级别
+const (
+	DNSLevelOK      = "ok"
+	DNSLevelInfo    = "info"
+	DNSLevelWarning = "warning"
+	DNSLevelError   = "error"
+)
+
+// dnsNameRegexp 允许解析的域名，名称会拼入容器内执行的命令
+var dnsNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?\.?$`)
+
+// ResolvConf 解析后的 /etc/resolv.conf
+type ResolvConf struct {
+	Raw         string   `json:"raw"`
+	Nameservers []string `json:"nameservers"`
+	Search      []string `json:"search"`
+	Options     []string `json:"options"`
+	Ndots       int      `json:"ndots"` // 未配置时为 glibc 默认值 1
+}
+
+// DNSLookup 容器内一次域名解析的结果
+type DNSLookup struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"` // cluster、short、external、custom
+	Success    bool     `json:"success"`
+	Addresses  []string `json:"addresses,omitempty"`
+	Expected   string   `json:"expected,omitempty"` // 期望解析到的地址，如 kubernetes Service 的 ClusterIP
+	Tool       string   `json:"tool,omitempty"`     // 使用的解析工具：getent、nslookup
+	Output     string   `json:"output,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	DurationMs int64    `json:"duration_ms"` // 包含一次 exec 的开销
+}
+
+// DNSFinding 一条诊断结论
+type DNSFinding struct {
+	Level   string `json:"level"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// DNSDiagnosis Pod 的 DNS 诊断结果
+type DNSDiagnosis struct {
+	Namespace  string        `json:"namespace"`
+	Pod        string        `json:"pod"`
+	Container  string        `json:"container"`
+	DNSPolicy  string        `json:"dns_policy"`
+	ResolvConf *ResolvConf   `json:"resolv_conf,omitempty"`
+	CoreDNS    *CoreDNSInfo  `json:"coredns"`
+	Lookups    []*DNSLookup  `json:"lookups"`
+	Findings   []*DNSFinding `json:"findings"`
+	Healthy    bool          `json:"healthy"` // 没有 error 级别的结论
+}
+
+func (d *DNSDiagnosis) add(level, code, format string, args ...any) {
+	d.Findings = append(d.Findings, &DNSFinding{Level: level, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// DiagnoseDNS 诊断 Pod 内的 DNS：读取 /etc/resolv.conf，在容器内解析集群内与外部域名，并与集群 DNS Service、CoreDNS 配置对照，
+// 给出结构化的结论。external 为空时使用 kubernetes.io，names 为额外需要解析的域名
+func (p *podService) DiagnoseDNS(ctx context.Context, cluster, ns, name, container, external string, names []string) (*DNSDiagnosis, error) {
+	if external == "" {
+		external = dnsDefaultExternalName
+	}
+	if len(names) > dnsMaxExtraNames {
+		return nil, fmt.Errorf("额外解析的域名最多 %d 个", dnsMaxExtraNames)
+	}
+	for _, n := range append([]string{external}, names...) {
+		if !dnsNameRegexp.MatchString(n) {
+			return nil, fmt.Errorf("域名 %s 格式错误", n)
+		}
+	}
+
+	var pod v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name).Get(&pod).Error; err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, fmt.Errorf("Pod %s/%s 当前状态为 %s，只能诊断运行中的 Pod", ns, name, pod.Status.Phase)
+	}
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+	if _, _, err := findContainer(&pod.Spec, container); err != nil {
+		return nil, err
+	}
+
+	d := &DNSDiagnosis{Namespace: ns, Pod: name, Container: container, DNSPolicy: string(pod.Spec.DNSPolicy)}
+	if d.DNSPolicy == "" {
+		d.DNSPolicy = string(v1.DNSClusterFirst)
+	}
+	d.CoreDNS = ClusterComponentService().CoreDNSInfo(ctx, cluster)
+
+	content, err := kom.Cluster(cluster).WithContext(WithReadOnlyExec(ctx)).Resource(&v1.Pod{}).Namespace(ns).Name(name).
+		Ctl().Pod().ContainerName(container).DownloadFile(dnsResolvConfPath)
+	if err != nil {
+		d.add(DNSLevelError, "resolv_conf_unreadable", "读取 %s 失败: %v", dnsResolvConfPath, err)
+	} else {
+		d.ResolvConf = ParseResolvConf(string(content))
+	}
+
+	domain := d.CoreDNS.ClusterDomain
+	if domain == "" {
+		domain = "cluster.local"
+	}
+	var kubernetesIP string
+	var svc v1.Service
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Service{}).Namespace("default").Name("kubernetes").Get(&svc).Error; err == nil {
+		kubernetesIP = svc.Spec.ClusterIP
+	}
+	d.Lookups = []*DNSLookup{
+		{Name: "kubernetes.default.svc." + domain, Kind: "cluster", Expected: kubernetesIP},
+		{Name: "kubernetes.default", Kind: "short", Expected: kubernetesIP},
+		{Name: external, Kind: "external"},
+	}
+	for _, n := range names {
+		d.Lookups = append(d.Lookups, &DNSLookup{Name: n, Kind: "custom"})
+	}
+	var wg sync.WaitGroup
+	for _, l := range d.Lookups {
+		wg.Add(1)
+		go func(l *DNSLookup) {
+			defer wg.Done()
+			p.dnsLookup(ctx, cluster, ns, name, container, l)
+		}(l)
+	}
+	wg.Wait()
+
+	p.diagnoseDNS(d, &pod, ns, domain)
+	d.Healthy = true
+	for _, f := range d.Findings {
+		if f.Level == DNSLevelError {
+			d.Healthy = false
+		}
+	}
+	return d, nil
+}
+
+// ParseResolvConf 解析 resolv.conf，search 与 domain 以最后出现的为准，与 glibc 一致
+func ParseResolvConf(content string) *ResolvConf {
+	rc := &ResolvConf{Raw: content, Ndots: 1}
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			rc.Nameservers = append(rc.Nameservers, fields[1])
+		case "search", "domain":
+			rc.Search = fields[1:]
+		case "options":
+			rc.Options = append(rc.Options, fields[1:]...)
+			for _, opt := range fields[1:] {
+				if v, ok := strings.CutPrefix(opt, "ndots:"); ok {
+					if n, err := strconv.Atoi(v); err == nil {
+						rc.Ndots = n
+					}
+				}
+			}
+		}
+	}
+	return rc
+}
+
+// dnsLookup 在容器内解析域名，优先使用 getent（走 libc 解析器与 search 路径），其次 nslookup
+func (p *podService) dnsLookup(ctx context.Context, cluster, ns, name, container string, l *DNSLookup) {
+	n := utils.ShellQuote(l.Name)
+	script := fmt.Sprintf("if command -v getent >/dev/null 2>&1; then echo getent; getent hosts %s; "+
+		"elif command -v nslookup >/dev/null 2>&1; then echo nslookup; nslookup %s; "+
+		"else echo %s; fi", n, n, dnsNoToolMarker)
+	execCtx, cancel := context.WithTimeout(WithReadOnlyExec(ctx), dnsLookupTimeout)
+	defer cancel()
+	var out []byte
+	start := time.Now()
+	err := kom.Cluster(cluster).WithContext(execCtx).Resource(&v1.Pod{}).Namespace(ns).Name(name).
+		Ctl().Pod().ContainerName(container).Command("sh", "-c", script).Execute(&out).Error
+	l.DurationMs = time.Since(start).Milliseconds()
+
+	tool, output, _ := strings.Cut(string(out), "\n")
+	l.Tool, l.Output = strings.TrimSpace(tool), strings.TrimSpace(output)
+	if l.Tool == dnsNoToolMarker {
+		l.Tool, l.Output, l.Error = "", "", dnsNoToolError
+		return
+	}
+	l.Addresses = parseLookupAddresses(l.Tool, l.Output)
+	l.Success = err == nil && len(l.Addresses) > 0
+	if !l.Success {
+		l.Error = "解析失败"
+		if err != nil {
+			l.Error = err.Error()
+		}
+		if execCtx.Err() != nil {
+			l.Error = fmt.Sprintf("%s 内未完成解析", dnsLookupTimeout)
+		}
+	}
+}
+
+// parseLookupAddresses 从 getent 或 nslookup 的输出中提取解析结果，nslookup 的服务器地址位于 Name: 之前，不计入结果
+func parseLookupAddresses(tool, output string) []string {
+	var addrs []string
+	answer := tool == "getent"
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Name:") {
+			answer = true
+			continue
+		}
+		if !answer {
+			continue
+		}
+		for _, f := range strings.Fields(line) {
+			if net.ParseIP(f) != nil && !slices.Contains(addrs, f) {
+				addrs = append(addrs, f)
+			}
+		}
+	}
+	return addrs
+}
+
+// diagnoseDNS 根据 resolv.conf、CoreDNS 状态与解析结果给出结论
+func (p *podService) diagnoseDNS(d *DNSDiagnosis, pod *v1.Pod, ns, domain string) {
+	core := d.CoreDNS
+	clusterFirst := d.DNSPolicy == string(v1.DNSClusterFirst) && !pod.Spec.HostNetwork ||
+		d.DNSPolicy == string(v1.DNSClusterFirstWithHostNet)
+
+	if core.Error != "" {
+		d.add(DNSLevelWarning, "cluster_dns_unknown", "%s", core.Error)
+	} else if core.TotalPods > 0 && core.ReadyPods == 0 {
+		d.add(DNSLevelError, "coredns_not_ready", "集群 DNS 的 %d 个 Pod 均未就绪", core.TotalPods)
+	} else if core.ReadyPods < core.TotalPods {
+		d.add(DNSLevelWarning, "coredns_partially_ready", "集群 DNS 只有 %d/%d 个 Pod 就绪，部分查询可能超时", core.ReadyPods, core.TotalPods)
+	}
+	if d.DNSPolicy == string(v1.DNSClusterFirst) && pod.Spec.HostNetwork {
+		d.add(DNSLevelInfo, "host_network_default_dns", "Pod 使用 hostNetwork 且 dnsPolicy 为 ClusterFirst，实际使用节点的 DNS 配置，无法解析集群内域名；如需解析请改为 ClusterFirstWithHostNet")
+	}
+
+	if rc := d.ResolvConf; rc != nil {
+		if len(rc.Nameservers) == 0 {
+			d.add(DNSLevelError, "no_nameserver", "resolv.conf 中没有 nameserver")
+		}
+		if len(rc.Nameservers) > 3 {
+			d.add(DNSLevelWarning, "too_many_nameservers", "resolv.conf 中有 %d 个 nameserver，glibc 只使用前 3 个", len(rc.Nameservers))
+		}
+		if clusterFirst && core.ServiceIP != "" && !slices.Contains(rc.Nameservers, core.ServiceIP) {
+			d.add(DNSLevelError, "nameserver_mismatch", "dnsPolicy 为 %s，但 nameserver %v 中不包含集群 DNS 地址 %s，请检查 kubelet 的 --cluster-dns 配置",
+				d.DNSPolicy, rc.Nameservers, core.ServiceIP)
+		}
+		expected := ns + ".svc." + domain
+		if clusterFirst && !slices.Contains(rc.Search, expected) {
+			d.add(DNSLevelError, "search_domain_mismatch", "search %v 中不包含 %s，CoreDNS 的集群域为 %s，请检查 kubelet 的 --cluster-domain 与 Corefile 是否一致",
+				rc.Search, expected, domain)
+		}
+		if rc.Ndots >= 5 {
+			d.add(DNSLevelInfo, "high_ndots", "ndots 为 %d，少于 %d 个点的外部域名会先依次尝试 %d 个 search 域，解析外部域名时查询次数增加；可通过 dnsConfig 调低 ndots 或在域名末尾加点",
+				rc.Ndots, rc.Ndots, len(rc.Search))
+		}
+	}
+
+	byKind := map[string]*DNSLookup{}
+	for _, l := range d.Lookups {
+		byKind[l.Kind] = l
+		if l.Success && l.DurationMs > dnsSlowLookup.Milliseconds() {
+			d.add(DNSLevelWarning, "slow_lookup", "解析 %s 耗时 %dms，常见原因为 search 域逐一尝试、上游 DNS 慢或 conntrack 竞争导致的 5 秒超时", l.Name, l.DurationMs)
+		}
+		if l.Success && l.Expected != "" && !slices.Contains(l.Addresses, l.Expected) {
+			d.add(DNSLevelWarning, "unexpected_address", "%s 解析为 %v，期望为 %s", l.Name, l.Addresses, l.Expected)
+		}
+		if !l.Success && l.Kind == "custom" {
+			d.add(DNSLevelWarning, "custom_lookup_failed", "解析 %s 失败: %s", l.Name, l.Error)
+		}
+	}
+	cl, short, ext := byKind["cluster"], byKind["short"], byKind["external"]
+	if cl.Error == dnsNoToolError {
+		d.add(DNSLevelWarning, "no_dns_tool", "%s，只能依据 resolv.conf 与 CoreDNS 配置诊断", cl.Error)
+		return
+	}
+	switch {
+	case !cl.Success && !ext.Success:
+		d.add(DNSLevelError, "dns_unreachable", "集群内与外部域名均无法解析，请检查集群 DNS Pod 是否正常、NetworkPolicy 是否放行到 %s 的 UDP/TCP 53 端口", core.ServiceIP)
+	case !cl.Success:
+		d.add(DNSLevelError, "cluster_lookup_failed", "外部域名可以解析，但 %s 解析失败，请检查 Corefile 中 kubernetes 插件与集群域配置", cl.Name)
+	case !ext.Success:
+		d.add(DNSLevelError, "external_lookup_failed", "集群内域名可以解析，但外部域名 %s 解析失败，请检查 CoreDNS 的上游 %v 是否可达", ext.Name, core.Upstreams)
+	}
+	if cl.Success && !short.Success {
+		d.add(DNSLevelWarning, "search_path_broken", "完整域名可以解析，但短名称 %s 解析失败，请检查 resolv.conf 的 search 配置", short.Name)
+	}
+	if len(d.Findings) == 0 {
+		d.add(DNSLevelOK, "healthy", "DNS 配置与解析均正常")
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duke-git/lancet/v2/slice"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// 一次性命令的超时与输出限制
+const (
+	ExecDefaultTimeout = 30 * time.Second
+	ExecMaxTimeout     = 10 * time.Minute
+	execMaxOutputBytes = 1 << 20 // stdout、stderr 各自最多保留 1MiB
+)
+
+var execExitCodeRe = regexp.MustCompile(`command terminated with exit code (\d+)`)
+
+// ExecResult 一次性命令的执行结果
+type ExecResult struct {
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	ExitCode   int    `json:"exit_code"`
+	TimedOut   bool   `json:"timed_out,omitempty"`
+	Truncated  bool   `json:"truncated,omitempty"` // 输出超过上限被截断
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// limitedBuffer 超过上限后丢弃后续输出
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (l *limitedBuffer) Write(p []byte) (int, error) {
+	if remain := execMaxOutputBytes - l.buf.Len(); remain < len(p) {
+		l.truncated = true
+		if remain > 0 {
+			l.buf.Write(p[:remain])
+		}
+		return len(p), nil
+	}
+	return l.buf.Write(p)
+}
+
+// ExecCommand 在容器中以非 TTY 方式执行一次性命令，返回标准输出、标准错误与退出码。
+// 命令需符合用户角色对应的允许列表，执行记录写入 Shell 日志。
+func (p *podService) ExecCommand(ctx context.Context, cluster, ns, name, container string, command []string, timeout time.Duration) (*ExecResult, error) {
+	if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+		return nil, fmt.Errorf("命令不能为空")
+	}
+	if err := authorizeExec(ctx, cluster, ns, name, container, command); err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = ExecDefaultTimeout
+	}
+	if timeout > ExecMaxTimeout {
+		timeout = ExecMaxTimeout
+	}
+
+	execCtx, cancel := context.WithTimeout(context.WithValue(ctx, constants.CommandScope, constants.CommandScopeExec), timeout)
+	defer cancel()
+	stdout, stderr := &limitedBuffer{}, &limitedBuffer{}
+	start := time.Now()
+	err := kom.Cluster(cluster).WithContext(execCtx).Resource(&v1.Pod{}).Namespace(ns).Name(name).
+		Ctl().Pod().ContainerName(container).Command(command[0], command[1:]...).
+		StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr}).Error
+
+	result := &ExecResult{
+		Stdout:     stdout.buf.String(),
+		Stderr:     stderr.buf.String(),
+		Truncated:  stdout.truncated || stderr.truncated,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err == nil {
+		return result, nil
+	}
+	// 非 0 退出码属于正常结果，由调用方根据 exit_code 判断
+	if code, ok := ExecExitCode(err); ok {
+		result.ExitCode = code
+		return result, nil
+	}
+	if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		result.ExitCode = -1
+		return result, nil
+	}
+	return nil, err
+}
+
+// StreamExec 在容器中执行命令，输入输出通过 opt 流式传递，可开启 TTY。
+// 与 ExecCommand 一样校验允许列表并写入 Shell 日志，命令以非 0 退出码结束时返回的错误包含退出码
+func (p *podService) StreamExec(ctx context.Context, cluster, ns, name, container string, command []string, opt *remotecommand.StreamOptions) error {
+	if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+		return fmt.Errorf("命令不能为空")
+	}
+	if err := authorizeExec(ctx, cluster, ns, name, container, command); err != nil {
+		return err
+	}
+	return kom.Cluster(cluster).WithContext(context.WithValue(ctx, constants.CommandScope, constants.CommandScopeExec)).
+		Resource(&v1.Pod{}).Namespace(ns).Name(name).
+		Ctl().Pod().ContainerName(container).Command(command[0], command[1:]...).
+		StreamExecuteWithOptions(opt).Error
+}
+
+// ExecExitCode 从执行错误中解析命令的退出码，不是非 0 退出导致的错误时返回 false
+func ExecExitCode(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	if m := execExitCodeRe.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return code, true
+	}
+	return 0, false
+}
+
+// authorizeExec 校验命令允许列表，通过后写入 Shell 日志
+func authorizeExec(ctx context.Context, cluster, ns, name, container string, command []string) error {
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	cmdline := strings.Join(command, " ")
+	if err := checkExecAllowlist(username, cluster, cmdline); err != nil {
+		return err
+	}
+	roles, _ := UserService().GetRolesByUserName(username)
+	ShellLogService().Add(&models.ShellLog{
+		Cluster:       cluster,
+		Namespace:     ns,
+		PodName:       name,
+		ContainerName: container,
+		UserName:      username,
+		Command:       cmdline,
+		Role:          strings.Join(roles, ","),
+	})
+	return nil
+}
+
+// checkExecAllowlist 按用户在集群上的角色校验命令，平台管理员不受限制
+func checkExecAllowlist(username, cluster, cmdline string) error {
+	if username == "" || UserService().IsUserPlatformAdmin(username) {
+		return nil
+	}
+	cfg := flag.Init()
+	allowlist := cfg.ExecAllowlistPodExec
+	if roles, err := UserService().GetClusters(username); err == nil {
+		if _, ok := slice.FindBy(roles, func(_ int, r *models.ClusterUserRole) bool {
+			return r.Cluster == cluster && r.Role == constants.RoleClusterAdmin
+		}); ok {
+			allowlist = cfg.ExecAllowlistClusterAdmin
+		}
+	}
+	if strings.TrimSpace(allowlist) == "" {
+		return nil
+	}
+	for _, pattern := range strings.Split(allowlist, "\n") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" && MatchCommandPattern(pattern, cmdline) {
+			return nil
+		}
+	}
+	return fmt.Errorf("命令 [%s] 不在允许列表中", cmdline)
+}
+
+// MatchCommandPattern 判断命令行是否匹配模式，* 匹配任意字符（含空格），其余字符按字面匹配
+func MatchCommandPattern(pattern, cmdline string) bool {
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.TrimSpace(cmdline))
+}
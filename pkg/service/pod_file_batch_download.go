@@ -0,0 +1,208 @@
+package service
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// MaxBatchDownloadPaths 一次批量下载最多包含的路径数
+const MaxBatchDownloadPaths = 100
+
+// BatchDownloadReport 有路径下载失败时，压缩包中记录各路径结果的文件
+const BatchDownloadReport = "k8m-download-report.json"
+
+// FileDownloadResult 批量下载中单个路径的结果
+type FileDownloadResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"` // done 或 error
+	Files  int    `json:"files"`  // 打包的文件与目录数
+	Bytes  int64  `json:"bytes"`  // 文件内容字节数
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchDownloadPaths 清理并去重待下载的路径，已被其他所选目录包含的路径不再单独打包，保持原有顺序
+func BatchDownloadPaths(paths []string) ([]string, error) {
+	seen := map[string]bool{}
+	var cleaned []string
+	for _, p := range paths {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		p = path.Clean(p)
+		if !path.IsAbs(p) || p == "/" {
+			return nil, fmt.Errorf("路径 %s 无效", p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			cleaned = append(cleaned, p)
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil, i18n.NewError(i18n.CodeFileBatchPathsRequired)
+	}
+	if len(cleaned) > MaxBatchDownloadPaths {
+		return nil, i18n.NewError(i18n.CodeFileBatchTooMany, MaxBatchDownloadPaths)
+	}
+	var result []string
+	for _, p := range cleaned {
+		nested := false
+		for d := path.Dir(p); d != "/"; d = path.Dir(d) {
+			if seen[d] {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// errWriter 记录首次写入错误与写入的字节数
+type errWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	e.n += int64(n)
+	e.err = err
+	return n, err
+}
+
+// DownloadBatch 将同一容器中的多个文件或目录打包为 tar.gz 写入 w，条目名称为去掉开头 / 的完整路径。
+// 每个路径在容器中单独执行 tar，边读取边写入，不在 k8m 落盘或整体缓存；某个路径失败不影响其余路径，
+// 有失败时在压缩包末尾写入 BatchDownloadReport。全部失败时不向 w 写入任何内容并返回错误，调用方可改为返回错误信息。
+// 返回的错误也可能来自 w，如客户端断开连接。
+func (p *podService) DownloadBatch(ctx context.Context, ref *PodFileRef, paths []string, w io.Writer) ([]*FileDownloadResult, error) {
+	paths, err := BatchDownloadPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	ctx = WithReadOnlyExec(ctx)
+	out := &errWriter{w: w}
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	var results []*FileDownloadResult
+	var failed []string
+	for _, filePath := range paths {
+		result := &FileDownloadResult{Path: filePath, Status: "done"}
+		results = append(results, result)
+		if err := p.appendTar(ctx, ref, filePath, tw, result); err != nil {
+			if out.err != nil {
+				return results, out.err
+			}
+			result.Status, result.Error = "error", err.Error()
+			failed = append(failed, fmt.Sprintf("%s: %s", filePath, result.Error))
+		}
+	}
+	if out.n == 0 && len(failed) > 0 {
+		return results, i18n.NewError(i18n.CodeFileBatchAllFailed, strings.Join(failed, "; "))
+	}
+
+	if len(failed) > 0 {
+		report, _ := json.MarshalIndent(results, "", "  ")
+		hdr := &tar.Header{Name: BatchDownloadReport, Mode: 0644, Size: int64(len(report)), ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err == nil {
+			_, _ = tw.Write(report)
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	return results, out.err
+}
+
+// appendTar 在容器中打包单个路径，将其中的条目加上所在目录前缀后写入 tw
+func (p *podService) appendTar(ctx context.Context, ref *PodFileRef, filePath string, tw *tar.Writer, result *FileDownloadResult) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pr, pw := io.Pipe()
+	var stderr strings.Builder
+	done := make(chan error, 1)
+	go func() {
+		err := kom.Cluster(ref.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ref.Namespace).Name(ref.PodName).
+			Ctl().Pod().ContainerName(ref.ContainerName).
+			Command("tar", "cf", "-", "-C", path.Dir(filePath), path.Base(filePath)).
+			StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdout: pw, Stderr: &stderr}).Error
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	prefix := strings.TrimPrefix(path.Dir(filePath), "/")
+	copyErr := copyTarEntries(tar.NewReader(pr), tw, prefix, result)
+	if copyErr == nil {
+		// tar 结束标记之后可能还有填充数据，读完以便命令正常退出
+		_, copyErr = io.Copy(io.Discard, pr)
+	}
+	if copyErr != nil {
+		cancel()
+		_ = pr.CloseWithError(copyErr)
+	}
+	execErr := <-done
+	switch {
+	case execErr != nil && stderr.Len() > 0:
+		return fmt.Errorf("%s", strings.TrimSpace(stderr.String()))
+	case execErr != nil:
+		return execErr
+	}
+	return copyErr
+}
+
+// copyTarEntries 逐个复制条目，只保留常规字段，名称与硬链接目标加上 prefix
+func copyTarEntries(tr *tar.Reader, tw *tar.Writer, prefix string, result *FileDownloadResult) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		entry := &tar.Header{
+			Typeflag: hdr.Typeflag,
+			Name:     path.Join(prefix, hdr.Name),
+			Linkname: hdr.Linkname,
+			Size:     hdr.Size,
+			Mode:     hdr.Mode,
+			Uid:      hdr.Uid,
+			Gid:      hdr.Gid,
+			Uname:    hdr.Uname,
+			Gname:    hdr.Gname,
+			ModTime:  hdr.ModTime,
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			entry.Name += "/"
+		case tar.TypeLink:
+			entry.Linkname = path.Join(prefix, hdr.Linkname)
+		}
+		if err := tw.WriteHeader(entry); err != nil {
+			return err
+		}
+		n, err := io.Copy(tw, tr)
+		result.Bytes += n
+		if err != nil {
+			return err
+		}
+		result.Files++
+	}
+}
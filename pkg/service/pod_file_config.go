@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"unicode/utf8"
+
+	"github.com/weibaohui/kom/kom"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// configObjectMaxBytes ConfigMap、Secret 的数据上限
+const configObjectMaxBytes = 1 << 20
+
+// ConfigObjectRef ConfigMap 或 Secret 中的一个键
+type ConfigObjectRef struct {
+	Kind      string `json:"kind"` // ConfigMap 或 Secret
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+func (r *ConfigObjectRef) validate() error {
+	if r.Kind != "ConfigMap" && r.Kind != "Secret" {
+		return fmt.Errorf("类型只能是 ConfigMap 或 Secret")
+	}
+	if r.Name == "" || r.Namespace == "" {
+		return fmt.Errorf("%s 名称与命名空间不能为空", r.Kind)
+	}
+	if errs := validation.IsConfigMapKey(r.Key); len(errs) > 0 {
+		return fmt.Errorf("键名 %s 无效: %v", r.Key, errs)
+	}
+	return nil
+}
+
+// FileToConfig 读取容器中的文件，写入 ConfigMap 或 Secret 的指定键，对象不存在时创建，已存在时只更新该键。
+// 键名为空时使用文件名；ConfigMap 中非 UTF-8 内容写入 binaryData。返回是否新建了对象。
+func (p *podService) FileToConfig(ctx context.Context, src *PodFileRef, target *ConfigObjectRef) (bool, error) {
+	if target.Key == "" {
+		target.Key = path.Base(src.Path)
+	}
+	if target.Namespace == "" {
+		target.Namespace = src.Namespace
+	}
+	if err := target.validate(); err != nil {
+		return false, err
+	}
+	content, err := kom.Cluster(src.Cluster).WithContext(ctx).Resource(&corev1.Pod{}).Namespace(src.Namespace).Name(src.PodName).
+		Ctl().Pod().ContainerName(src.ContainerName).DownloadFile(src.Path)
+	if err != nil {
+		return false, fmt.Errorf("读取容器文件失败: %w", err)
+	}
+	if len(content) > configObjectMaxBytes {
+		return false, fmt.Errorf("文件大小 %d 字节超过 %s 1MiB 上限", len(content), target.Kind)
+	}
+	if target.Kind == "Secret" {
+		return p.upsertSecretKey(ctx, src.Cluster, target, content)
+	}
+	return p.upsertConfigMapKey(ctx, src.Cluster, target, content)
+}
+
+func (p *podService) upsertConfigMapKey(ctx context.Context, cluster string, target *ConfigObjectRef, content []byte) (bool, error) {
+	var cm corev1.ConfigMap
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&cm).Namespace(target.Namespace).Name(target.Name).Get(&cm).Error
+	created := apierrors.IsNotFound(err)
+	switch {
+	case created:
+		cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: target.Name, Namespace: target.Namespace}}
+	case err != nil:
+		return false, fmt.Errorf("读取 ConfigMap 失败: %w", err)
+	}
+	// 同一个键只能存在于 data 或 binaryData 之一
+	delete(cm.Data, target.Key)
+	delete(cm.BinaryData, target.Key)
+	if utf8.Valid(content) {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[target.Key] = string(content)
+	} else {
+		if cm.BinaryData == nil {
+			cm.BinaryData = map[string][]byte{}
+		}
+		cm.BinaryData[target.Key] = content
+	}
+	q := kom.Cluster(cluster).WithContext(ctx).Resource(&cm).Namespace(target.Namespace).Name(target.Name)
+	if created {
+		err = q.Create(&cm).Error
+	} else {
+		err = q.Update(&cm).Error
+	}
+	if err != nil {
+		return false, fmt.Errorf("保存 ConfigMap 失败: %w", err)
+	}
+	return created, nil
+}
+
+func (p *podService) upsertSecretKey(ctx context.Context, cluster string, target *ConfigObjectRef, content []byte) (bool, error) {
+	var secret corev1.Secret
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.Name).Get(&secret).Error
+	created := apierrors.IsNotFound(err)
+	switch {
+	case created:
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: target.Name, Namespace: target.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+	case err != nil:
+		return false, fmt.Errorf("读取 Secret 失败: %w", err)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[target.Key] = content
+	q := kom.Cluster(cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.Name)
+	if created {
+		err = q.Create(&secret).Error
+	} else {
+		err = q.Update(&secret).Error
+	}
+	if err != nil {
+		return false, fmt.Errorf("保存 Secret 失败: %w", err)
+	}
+	return created, nil
+}
+
+// ConfigToFile 将 ConfigMap 或 Secret 中指定键的内容写入容器文件，覆盖已有内容
+func (p *podService) ConfigToFile(ctx context.Context, source *ConfigObjectRef, dst *PodFileRef) (int, error) {
+	if source.Namespace == "" {
+		source.Namespace = dst.Namespace
+	}
+	if err := source.validate(); err != nil {
+		return 0, err
+	}
+	var content []byte
+	if source.Kind == "Secret" {
+		var secret corev1.Secret
+		if err := kom.Cluster(dst.Cluster).WithContext(ctx).Resource(&secret).Namespace(source.Namespace).Name(source.Name).Get(&secret).Error; err != nil {
+			return 0, fmt.Errorf("读取 Secret 失败: %w", err)
+		}
+		v, ok := secret.Data[source.Key]
+		if !ok {
+			return 0, fmt.Errorf("Secret %s/%s 中不存在键 %s", source.Namespace, source.Name, source.Key)
+		}
+		content = v
+	} else {
+		var cm corev1.ConfigMap
+		if err := kom.Cluster(dst.Cluster).WithContext(ctx).Resource(&cm).Namespace(source.Namespace).Name(source.Name).Get(&cm).Error; err != nil {
+			return 0, fmt.Errorf("读取 ConfigMap 失败: %w", err)
+		}
+		if v, ok := cm.Data[source.Key]; ok {
+			content = []byte(v)
+		} else if v, ok := cm.BinaryData[source.Key]; ok {
+			content = v
+		} else {
+			return 0, fmt.Errorf("ConfigMap %s/%s 中不存在键 %s", source.Namespace, source.Name, source.Key)
+		}
+	}
+	err := kom.Cluster(dst.Cluster).WithContext(ctx).Resource(&corev1.Pod{}).Namespace(dst.Namespace).Name(dst.PodName).
+		Ctl().Pod().ContainerName(dst.ContainerName).SaveFile(dst.Path, string(content))
+	if err != nil {
+		return 0, fmt.Errorf("写入容器文件失败: %w", err)
+	}
+	return len(content), nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+)
+
+// PodFileRef 容器中的文件位置
+type PodFileRef struct {
+	Cluster       string `json:"cluster"`
+	Namespace     string `json:"namespace"`
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+	Path          string `json:"path"`
+}
+
+// countingWriter 统计经过的字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// CopyFile 将源容器中的文件或目录复制到目标容器的目录中，源与目标可以位于不同集群。
+// 源端 tar 打包的输出经 k8m 通过管道直接写入目标端 tar 解包，不落盘也不整体缓存，
+// 两端均需要 tar 命令，并分别按各自集群校验 Exec 权限。返回传输的 tar 流字节数。
+func (p *podService) CopyFile(ctx context.Context, src, dst *PodFileRef) (int64, error) {
+	src.Path = path.Clean(src.Path)
+	if !path.IsAbs(src.Path) || src.Path == "/" {
+		return 0, fmt.Errorf("源路径 %s 无效", src.Path)
+	}
+	if dst.Path == "" {
+		dst.Path = path.Dir(src.Path)
+	}
+	for _, ref := range []*PodFileRef{src, dst} {
+		if kom.Cluster(ref.Cluster) == nil {
+			return 0, fmt.Errorf("集群 %s 不存在或未连接", ref.Cluster)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pr, pw := io.Pipe()
+	counter := &countingWriter{w: pw}
+	var srcErr error
+	var srcStderr strings.Builder
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		srcErr = kom.Cluster(src.Cluster).WithContext(WithReadOnlyExec(ctx)).Resource(&v1.Pod{}).Namespace(src.Namespace).Name(src.PodName).
+			Ctl().Pod().ContainerName(src.ContainerName).
+			Command("tar", "cf", "-", "-C", path.Dir(src.Path), path.Base(src.Path)).
+			StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdout: counter, Stderr: &srcStderr}).Error
+		if srcErr != nil {
+			_ = pw.CloseWithError(srcErr)
+			return
+		}
+		_ = pw.Close()
+	}()
+
+	var dstStderr strings.Builder
+	dstErr := kom.Cluster(dst.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(dst.Namespace).Name(dst.PodName).
+		Ctl().Pod().ContainerName(dst.ContainerName).
+		Command("tar", "xmf", "-", "-C", dst.Path).
+		StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdin: pr, Stdout: io.Discard, Stderr: &dstStderr}).Error
+	if dstErr != nil {
+		// 目标端失败时中止源端，避免源端阻塞在管道写入上
+		_ = pr.CloseWithError(dstErr)
+		cancel()
+	}
+	wg.Wait()
+
+	// 一端失败通常会导致另一端随之失败，两端的错误都返回便于定位
+	var problems []string
+	if srcErr != nil {
+		problems = append(problems, fmt.Sprintf("读取源文件失败: %v %s", srcErr, strings.TrimSpace(srcStderr.String())))
+	}
+	if dstErr != nil {
+		problems = append(problems, fmt.Sprintf("写入目标容器失败: %v %s", dstErr, strings.TrimSpace(dstStderr.String())))
+	}
+	if len(problems) > 0 {
+		return counter.n, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	klog.V(6).Infof("复制文件 %s/%s/%s:%s 到 %s/%s/%s:%s，传输 %d 字节", src.Cluster, src.Namespace, src.PodName, src.Path,
+		dst.Cluster, dst.Namespace, dst.PodName, dst.Path, counter.n)
+	return counter.n, nil
+}
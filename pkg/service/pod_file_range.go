@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// PodFileReader 按需从容器中读取文件的 io.ReadSeeker，用于 Range 下载。
+// Seek 只记录位置，首次 Read 时在容器中执行 tail -c +N 从该位置开始流式读取，不缓存整个文件。
+type PodFileReader struct {
+	ctx     context.Context
+	ref     *PodFileRef
+	Size    int64
+	ModTime time.Time
+
+	offset int64
+	stream *io.PipeReader
+	cancel context.CancelFunc
+	read   int64 // 实际读取的字节数
+}
+
+// PodFileStat 容器中文件的大小、修改时间与类型，符号链接按其指向的目标返回
+type PodFileStat struct {
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+	Regular bool
+}
+
+// StatFile 读取容器中文件的基本信息，文件不存在时返回的错误满足 errors.Is(err, os.ErrNotExist)
+func (p *podService) StatFile(ctx context.Context, ref *PodFileRef) (*PodFileStat, error) {
+	ctx = WithReadOnlyExec(ctx)
+	var out []byte
+	err := kom.Cluster(ref.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ref.Namespace).Name(ref.PodName).
+		Ctl().Pod().ContainerName(ref.ContainerName).
+		Command("stat", "-L", "-c", "%s %Y %F", ref.Path).Execute(&out).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "No such file") {
+			return nil, fmt.Errorf("%s: %w", ref.Path, os.ErrNotExist)
+		}
+		return nil, fmt.Errorf("读取文件信息失败: %w", err)
+	}
+	fields := strings.SplitN(strings.TrimSpace(string(out)), " ", 3)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("读取文件信息失败: %s", out)
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	mtime, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &PodFileStat{
+		Size:    size,
+		ModTime: time.Unix(mtime, 0),
+		IsDir:   fields[2] == "directory",
+		Regular: strings.HasPrefix(fields[2], "regular"),
+	}, nil
+}
+
+// OpenFile 读取容器中普通文件的大小与修改时间，返回可按位置读取的 reader，调用方需要 Close
+func (p *podService) OpenFile(ctx context.Context, ref *PodFileRef) (*PodFileReader, error) {
+	ctx = WithReadOnlyExec(ctx)
+	st, err := p.StatFile(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !st.Regular {
+		return nil, fmt.Errorf("%s 不是普通文件", ref.Path)
+	}
+	return &PodFileReader{ctx: ctx, ref: ref, Size: st.Size, ModTime: st.ModTime}, nil
+}
+
+// ETag 由大小与修改时间生成，文件被修改后续传请求会收到完整内容
+func (r *PodFileReader) ETag() string {
+	return fmt.Sprintf(`"%x-%x"`, r.Size, r.ModTime.Unix())
+}
+
+// BytesRead 返回实际传输的字节数
+func (r *PodFileReader) BytesRead() int64 {
+	return r.read
+}
+
+func (r *PodFileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.Size
+	default:
+		return 0, errors.New("无效的 whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("无效的偏移量")
+	}
+	if offset != r.offset {
+		r.closeStream()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+func (r *PodFileReader) Read(b []byte) (int, error) {
+	if r.offset >= r.Size {
+		return 0, io.EOF
+	}
+	if r.stream == nil {
+		r.openStream()
+	}
+	n, err := r.stream.Read(b)
+	r.offset += int64(n)
+	r.read += int64(n)
+	return n, err
+}
+
+func (r *PodFileReader) openStream() {
+	ctx, cancel := context.WithCancel(r.ctx)
+	pr, pw := io.Pipe()
+	r.stream, r.cancel = pr, cancel
+	script := fmt.Sprintf("tail -c +%d %s", r.offset+1, utils.ShellQuote(r.ref.Path))
+	go func() {
+		err := kom.Cluster(r.ref.Cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(r.ref.Namespace).Name(r.ref.PodName).
+			Ctl().Pod().ContainerName(r.ref.ContainerName).Command("sh", "-c", script).
+			StreamExecuteWithOptions(&remotecommand.StreamOptions{Stdout: pw}).Error
+		_ = pw.CloseWithError(err)
+	}()
+}
+
+func (r *PodFileReader) closeStream() {
+	if r.stream != nil {
+		r.cancel()
+		_ = r.stream.Close()
+		r.stream, r.cancel = nil, nil
+	}
+}
+
+// Close 结束容器中正在执行的读取
+func (r *PodFileReader) Close() error {
+	r.closeStream()
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/weibaohui/k8m/pkg/podindex"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+)
+
+// podIndexService 各集群的 Pod 反向索引，由 Pod watch 增量维护
+type podIndexService struct {
+	mu      sync.RWMutex
+	indexes map[string]*podindex.Index
+	synced  map[string]bool
+}
+
+// Reset 清空集群索引，Pod watch 重新建立时调用，之后由 watch 的初始事件重新填充
+func (s *podIndexService) Reset(cluster string) *podindex.Index {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexes == nil {
+		s.indexes = map[string]*podindex.Index{}
+		s.synced = map[string]bool{}
+	}
+	x := podindex.New()
+	s.indexes[cluster] = x
+	s.synced[cluster] = false
+	return x
+}
+
+// MarkSynced 标记集群索引已完成首次同步，之后的查询直接使用索引
+func (s *podIndexService) MarkSynced(cluster string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.indexes[cluster]; ok {
+		s.synced[cluster] = true
+	}
+}
+
+// Invalidate 移除 Reset 创建的索引，Pod watch 结束时调用；已被新的 watch 替换时不处理
+func (s *podIndexService) Invalidate(cluster string, x *podindex.Index) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexes[cluster] == x {
+		delete(s.indexes, cluster)
+		delete(s.synced, cluster)
+	}
+}
+
+func (s *podIndexService) get(cluster string) (*podindex.Index, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.indexes[cluster], s.synced[cluster]
+}
+
+// Update 处理 Pod 新增或修改事件
+func (s *podIndexService) Update(cluster string, pod *v1.Pod) {
+	if x, _ := s.get(cluster); x != nil {
+		x.Update(pod)
+	}
+}
+
+// Delete 处理 Pod 删除事件
+func (s *podIndexService) Delete(cluster, ns, name string) {
+	if x, _ := s.get(cluster); x != nil {
+		x.Delete(ns, name)
+	}
+}
+
+// Index 返回集群的 Pod 索引。
+// 未启用资源监听或尚未完成首次同步时，以当前用户身份列出全部 Pod 临时构建索引
+func (s *podIndexService) Index(ctx context.Context, cluster string) (*podindex.Index, error) {
+	if x, synced := s.get(cluster); x != nil && synced {
+		return x, nil
+	}
+	var pods []v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).AllNamespace().List(&pods).Error; err != nil {
+		return nil, err
+	}
+	x := podindex.New()
+	for i := range pods {
+		x.Update(&pods[i])
+	}
+	return x, nil
+}
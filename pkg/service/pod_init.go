@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/weibaohui/k8m/pkg/podinit"
+	"github.com/weibaohui/kom/kom"
+	"github.com/weibaohui/kom/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// SetContainerInsightOnPod 在 Pod 上附加 init 容器进度与 sidecar 注解，便于列表直接展示卡在哪一步初始化
+func (p *podService) SetContainerInsightOnPod(item *unstructured.Unstructured) *unstructured.Unstructured {
+	var pod v1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &pod); err != nil {
+		return item
+	}
+	if ann := podinit.Analyze(&pod).Annotations(); len(ann) > 0 {
+		utils.AddOrUpdateAnnotations(item, ann)
+	}
+	return item
+}
+
+// ContainerInsight 分析 Pod 每个容器的状态：init 容器进度、当前阻塞的步骤与原因、sidecar 就绪情况
+func (p *podService) ContainerInsight(ctx context.Context, cluster, ns, name string) (*podinit.Insight, error) {
+	var pod v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name).Get(&pod).Error; err != nil {
+		return nil, err
+	}
+	return podinit.Analyze(&pod), nil
+}
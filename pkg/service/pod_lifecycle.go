@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// Pod 生命周期操作
+const (
+	PodActionForceDelete       = "force_delete"       // 以指定宽限期强制删除
+	PodActionReschedule        = "reschedule"         // 隔离所在节点后删除，节点保持隔离
+	PodActionRecreateElsewhere = "recreate_elsewhere" // 临时隔离所在节点，替代 Pod 调度到其他节点后解除隔离
+)
+
+// 临时隔离的等待时间
+const (
+	podRecreateDefaultWait = 5 * time.Minute
+	podRecreateMaxWait     = 30 * time.Minute
+	podRecreatePoll        = 3 * time.Second
+)
+
+// nodeCordonReasonAnnotation 记录 k8m 隔离节点的原因，解除隔离时一并清除
+const nodeCordonReasonAnnotation = "k8m.io/cordon-reason"
+
+// PodActionOptions Pod 生命周期操作参数
+type PodActionOptions struct {
+	GracePeriodSeconds *int64 `json:"grace_period_seconds,omitempty"` // force_delete 使用，为空时为 0
+	WaitSeconds        int    `json:"wait_seconds,omitempty"`         // recreate_elsewhere 等待替代 Pod 调度的最长时间，默认 300
+	Confirm            bool   `json:"confirm"`                        // 为 false 时只返回确认信息，不执行
+}
+
+// PodActionPlan 操作前返回给页面的确认信息，执行后附带结果
+type PodActionPlan struct {
+	Action             string   `json:"action"`
+	Namespace          string   `json:"namespace"`
+	Name               string   `json:"name"`
+	Node               string   `json:"node,omitempty"`
+	Owner              string   `json:"owner,omitempty"`   // 控制器，如 Deployment/nginx
+	Managed            bool     `json:"managed"`           // 由控制器管理，删除后会自动重建
+	NodeCordoned       bool     `json:"node_cordoned"`     // 节点当前已处于隔离状态
+	NodePodCount       int      `json:"node_pod_count"`    // 节点上运行的 Pod 数，隔离后不再接收新 Pod
+	SchedulableNodes   int      `json:"schedulable_nodes"` // 除当前节点外可调度的节点数
+	GracePeriodSeconds *int64   `json:"grace_period_seconds,omitempty"`
+	Title              string   `json:"title"`
+	Summary            string   `json:"summary"`
+	Warnings           []string `json:"warnings,omitempty"`
+	Allowed            bool     `json:"allowed"` // 为 false 时不能执行，原因见 warnings
+	Executed           bool     `json:"executed"`
+	Message            string   `json:"message,omitempty"`
+}
+
+// PlanAction 生成操作的确认信息：Pod 归属、所在节点、隔离影响与风险提示
+func (p *podService) PlanAction(ctx context.Context, cluster, ns, name, action string, opts *PodActionOptions) (*PodActionPlan, *v1.Pod, error) {
+	var pod v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&pod).Namespace(ns).Name(name).Get(&pod).Error; err != nil {
+		return nil, nil, err
+	}
+	plan := &PodActionPlan{Action: action, Namespace: ns, Name: name, Node: pod.Spec.NodeName, Allowed: true}
+	ref := metav1.GetControllerOf(&pod)
+	if ref != nil {
+		plan.Managed = true
+		plan.Owner = p.ownerName(ctx, cluster, ns, ref)
+	} else {
+		plan.Warnings = append(plan.Warnings, "Pod 不受控制器管理，删除后不会自动重建")
+	}
+
+	switch action {
+	case PodActionForceDelete:
+		grace := int64(0)
+		if opts.GracePeriodSeconds != nil && *opts.GracePeriodSeconds >= 0 {
+			grace = *opts.GracePeriodSeconds
+		}
+		plan.GracePeriodSeconds = &grace
+		plan.Title = "强制删除 Pod"
+		plan.Summary = fmt.Sprintf("以 %d 秒宽限期删除 Pod %s/%s", grace, ns, name)
+		if grace == 0 {
+			plan.Warnings = append(plan.Warnings, "宽限期为 0 时不等待容器退出，容器进程可能仍在节点上运行")
+			if ref != nil && ref.Kind == "StatefulSet" {
+				plan.Warnings = append(plan.Warnings, "StatefulSet Pod 被强制删除后，同名 Pod 可能在旧容器退出前启动，存在数据损坏风险")
+			}
+		}
+		return plan, &pod, nil
+	case PodActionReschedule:
+		plan.Title = "隔离节点并重新调度"
+		plan.Summary = fmt.Sprintf("隔离节点 %s 后删除 Pod %s/%s，节点保持隔离，需要手动解除", pod.Spec.NodeName, ns, name)
+	case PodActionRecreateElsewhere:
+		wait := podRecreateWait(opts)
+		plan.Title = "在其他节点重建"
+		plan.Summary = fmt.Sprintf("临时隔离节点 %s 并删除 Pod %s/%s，替代 Pod 调度到其他节点或等待 %s 后自动解除隔离",
+			pod.Spec.NodeName, ns, name, wait)
+	default:
+		return nil, nil, fmt.Errorf("不支持的操作: %s", action)
+	}
+
+	if pod.Spec.NodeName == "" {
+		plan.Allowed = false
+		plan.Warnings = append(plan.Warnings, "Pod 尚未调度到节点")
+		return plan, &pod, nil
+	}
+	if !plan.Managed {
+		plan.Allowed = false
+	}
+	if ref != nil && ref.Kind == "DaemonSet" {
+		plan.Allowed = false
+		plan.Warnings = append(plan.Warnings, "DaemonSet Pod 只会在原节点重建")
+	}
+	p.fillNodeImpact(ctx, cluster, plan)
+	if plan.SchedulableNodes == 0 {
+		plan.Warnings = append(plan.Warnings, "集群中没有其他可调度的节点，替代 Pod 将保持 Pending")
+	}
+	if plan.NodeCordoned {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("节点 %s 已处于隔离状态，操作结束后保持隔离", pod.Spec.NodeName))
+	}
+	return plan, &pod, nil
+}
+
+// ownerName 返回控制器名称，ReplicaSet 继续向上查找 Deployment
+func (p *podService) ownerName(ctx context.Context, cluster, ns string, ref *metav1.OwnerReference) string {
+	if ref.Kind == "ReplicaSet" {
+		var rs appsv1.ReplicaSet
+		if err := kom.Cluster(cluster).WithContext(ctx).Resource(&rs).Namespace(ns).Name(ref.Name).Get(&rs).Error; err == nil {
+			if owner := metav1.GetControllerOf(&rs); owner != nil {
+				return owner.Kind + "/" + owner.Name
+			}
+		}
+	}
+	return ref.Kind + "/" + ref.Name
+}
+
+func (p *podService) fillNodeImpact(ctx context.Context, cluster string, plan *PodActionPlan) {
+	var nodes []v1.Node
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Node{}).List(&nodes).Error; err != nil {
+		klog.V(6).Infof("读取节点列表失败: %v", err)
+		return
+	}
+	for _, n := range nodes {
+		if n.Name == plan.Node {
+			plan.NodeCordoned = n.Spec.Unschedulable
+			continue
+		}
+		if !n.Spec.Unschedulable && nodeReady(&n) {
+			plan.SchedulableNodes++
+		}
+	}
+	var pods []v1.Pod
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).AllNamespace().
+		Where(fmt.Sprintf("spec.nodeName='%s'", plan.Node)).List(&pods).Error
+	if err == nil {
+		plan.NodePodCount = len(pods)
+	}
+}
+
+func nodeReady(n *v1.Node) bool {
+	for _, c := range n.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func podRecreateWait(opts *PodActionOptions) time.Duration {
+	wait := time.Duration(opts.WaitSeconds) * time.Second
+	if wait <= 0 {
+		wait = podRecreateDefaultWait
+	}
+	if wait > podRecreateMaxWait {
+		wait = podRecreateMaxWait
+	}
+	return wait
+}
+
+// RunAction 执行 Pod 生命周期操作。未确认或不允许执行时只返回确认信息
+func (p *podService) RunAction(ctx context.Context, cluster, ns, name, action string, opts *PodActionOptions) (*PodActionPlan, error) {
+	plan, pod, err := p.PlanAction(ctx, cluster, ns, name, action, opts)
+	if err != nil || !opts.Confirm || !plan.Allowed {
+		return plan, err
+	}
+
+	switch action {
+	case PodActionForceDelete:
+		delCtx := context.WithValue(ctx, constants.DeleteGracePeriod, *plan.GracePeriodSeconds)
+		err = kom.Cluster(cluster).WithContext(delCtx).Resource(&v1.Pod{}).Namespace(ns).Name(name).Delete().Error
+		plan.Message = "Pod 已删除"
+	case PodActionReschedule:
+		if err = p.cordonAndDelete(ctx, cluster, plan, "重新调度 Pod "+ns+"/"+name); err == nil {
+			plan.Message = fmt.Sprintf("节点 %s 已隔离，Pod 已删除，确认后请手动解除节点隔离", plan.Node)
+		}
+	case PodActionRecreateElsewhere:
+		deletedAt := time.Now().Truncate(time.Second)
+		if err = p.cordonAndDelete(ctx, cluster, plan, "在其他节点重建 Pod "+ns+"/"+name); err == nil {
+			wait := podRecreateWait(opts)
+			if plan.NodeCordoned {
+				plan.Message = "Pod 已删除，节点原本处于隔离状态，不会自动解除"
+			} else {
+				plan.Message = fmt.Sprintf("节点 %s 已临时隔离，Pod 已删除，替代 Pod 调度到其他节点或 %s 后自动解除隔离", plan.Node, wait)
+				go p.uncordonAfterReschedule(context.WithoutCancel(ctx), cluster, pod, deletedAt, wait)
+			}
+		}
+	}
+	if err != nil {
+		return plan, err
+	}
+	plan.Executed = true
+	return plan, nil
+}
+
+// cordonAndDelete 隔离节点并在注解中记录原因，随后删除 Pod
+func (p *podService) cordonAndDelete(ctx context.Context, cluster string, plan *PodActionPlan, reason string) error {
+	if !plan.NodeCordoned {
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}},"spec":{"unschedulable":true}}`, nodeCordonReasonAnnotation,
+			reason+"，操作时间 "+time.Now().Format(time.RFC3339))
+		var node v1.Node
+		err := kom.Cluster(cluster).WithContext(ctx).Resource(&node).Name(plan.Node).
+			Patch(&node, types.StrategicMergePatchType, patch).Error
+		if err != nil {
+			return fmt.Errorf("隔离节点 %s 失败: %w", plan.Node, err)
+		}
+	}
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(plan.Namespace).Name(plan.Name).Delete().Error
+	if err != nil && !plan.NodeCordoned {
+		// 删除失败时撤销本次隔离
+		if uncordonErr := p.uncordon(ctx, cluster, plan.Node); uncordonErr != nil {
+			klog.Errorf("撤销节点 %s 的隔离失败: %v", plan.Node, uncordonErr)
+		}
+	}
+	return err
+}
+
+// uncordon 解除 k8m 设置的节点隔离并清除原因注解
+func (p *podService) uncordon(ctx context.Context, cluster, nodeName string) error {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}},"spec":{"unschedulable":null}}`, nodeCordonReasonAnnotation)
+	var node v1.Node
+	return kom.Cluster(cluster).WithContext(ctx).Resource(&node).Name(nodeName).
+		Patch(&node, types.StrategicMergePatchType, patch).Error
+}
+
+// uncordonAfterReschedule 等待同一控制器的替代 Pod 调度到其他节点后解除隔离，超时后同样解除
+func (p *podService) uncordonAfterReschedule(ctx context.Context, cluster string, pod *v1.Pod, deletedAt time.Time, wait time.Duration) {
+	ref := metav1.GetControllerOf(pod)
+	deadline := time.Now().Add(wait)
+	for time.Now().Before(deadline) && ref != nil {
+		if p.replacementScheduled(ctx, cluster, pod, ref, deletedAt) {
+			break
+		}
+		time.Sleep(podRecreatePoll)
+	}
+	if err := p.uncordon(ctx, cluster, pod.Spec.NodeName); err != nil {
+		klog.Errorf("解除节点 %s 的临时隔离失败: %v", pod.Spec.NodeName, err)
+		return
+	}
+	klog.V(4).Infof("Pod %s/%s 重建后已解除节点 %s 的临时隔离", pod.Namespace, pod.Name, pod.Spec.NodeName)
+}
+
+// replacementScheduled 判断是否已有同一控制器在删除之后创建、且调度到其他节点的 Pod
+func (p *podService) replacementScheduled(ctx context.Context, cluster string, pod *v1.Pod, ref *metav1.OwnerReference, deletedAt time.Time) bool {
+	var pods []v1.Pod
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(pod.Namespace).
+		Where(fmt.Sprintf("'metadata.ownerReferences.name'='%s'", ref.Name)).List(&pods).Error
+	if err != nil {
+		return false
+	}
+	for _, item := range pods {
+		owner := metav1.GetControllerOf(&item)
+		if owner == nil || owner.UID != ref.UID || item.UID == pod.UID {
+			continue
+		}
+		if !item.CreationTimestamp.Time.Before(deletedAt) && item.Spec.NodeName != "" && item.Spec.NodeName != pod.Spec.NodeName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+)
+
+// 探针类型
+const (
+	ProbeLiveness  = "liveness"
+	ProbeReadiness = "readiness"
+	ProbeStartup   = "startup"
+)
+
+// 探针测试的限制
+const (
+	probeMaxBodyBytes  = 1024 // HTTP 探针返回的响应体最多保留 1KiB
+	probeRecentEvents  = 5    // 返回最近的探针失败事件数
+	probeProxyDialFail = "error trying to reach service"
+)
+
+// ProbeEvent kubelet 记录的探针失败事件
+type ProbeEvent struct {
+	Time    time.Time `json:"time"`
+	Count   int32     `json:"count"`
+	Message string    `json:"message"`
+}
+
+// ProbeTestResult 一次探针测试的结果
+type ProbeTestResult struct {
+	Container  string        `json:"container"`
+	Type       string        `json:"type"`       // liveness、readiness、startup
+	Handler    string        `json:"handler"`    // exec、httpGet、tcpSocket、grpc
+	Target     string        `json:"target"`     // 实际探测的命令或地址
+	Overridden bool          `json:"overridden"` // 使用请求中的探针定义，而非容器中已配置的
+	Probe      *v1.Probe     `json:"probe"`      // 本次测试使用的探针定义
+	Success    bool          `json:"success"`    // 按 kubelet 的判定规则是否成功
+	Message    string        `json:"message"`    // 失败原因或成功说明
+	StatusCode int           `json:"status_code,omitempty"`
+	Body       string        `json:"body,omitempty"` // HTTP 响应体，最多 1KiB
+	Exec       *ExecResult   `json:"exec,omitempty"`
+	TimedOut   bool          `json:"timed_out,omitempty"` // 超过探针的 timeoutSeconds
+	DurationMs int64         `json:"duration_ms"`
+	Notes      []string      `json:"notes,omitempty"`           // 本次测试与 kubelet 实际探测方式的差异
+	Recent     []*ProbeEvent `json:"recent_failures,omitempty"` // 最近的探针失败事件
+}
+
+// TestProbe 按需执行容器的存活、就绪或启动探针并返回结果与耗时，override 不为空时使用其定义，便于不重新部署即可调试探针。
+// exec 探针在容器内执行，受命令允许列表约束并记录 Shell 日志；httpGet、tcpSocket 探针经 API Server 的 Pod 代理访问容器端口
+func (p *podService) TestProbe(ctx context.Context, cluster, ns, name, container, probeType string, override *v1.Probe) (*ProbeTestResult, error) {
+	var pod v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(name).Get(&pod).Error; err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, fmt.Errorf("Pod %s/%s 当前状态为 %s，只能测试运行中的 Pod", ns, name, pod.Status.Phase)
+	}
+	ctn, _, err := findContainer(&pod.Spec, container)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe *v1.Probe
+	switch probeType {
+	case ProbeLiveness:
+		probe = ctn.LivenessProbe
+	case ProbeReadiness:
+		probe = ctn.ReadinessProbe
+	case ProbeStartup:
+		probe = ctn.StartupProbe
+	default:
+		return nil, fmt.Errorf("不支持的探针类型: %s", probeType)
+	}
+	if override != nil {
+		probe = override
+	}
+	if probe == nil {
+		return nil, fmt.Errorf("容器 %s 未配置 %s 探针", container, probeType)
+	}
+
+	result := &ProbeTestResult{Container: container, Type: probeType, Overridden: override != nil, Probe: probe}
+	timeout := time.Duration(probe.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+
+	start := time.Now()
+	switch {
+	case probe.Exec != nil:
+		err = p.probeExec(ctx, cluster, &pod, container, probe.Exec, timeout, result)
+	case probe.HTTPGet != nil:
+		err = p.probeHTTP(ctx, cluster, &pod, ctn, probe.HTTPGet, timeout, result)
+	case probe.TCPSocket != nil:
+		err = p.probeTCP(ctx, cluster, &pod, ctn, probe.TCPSocket, timeout, result)
+	case probe.GRPC != nil:
+		result.Handler = "grpc"
+		err = fmt.Errorf("暂不支持测试 gRPC 探针")
+	default:
+		err = fmt.Errorf("探针未定义 exec、httpGet、tcpSocket 或 grpc")
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Recent = p.recentProbeFailures(ctx, cluster, &pod, container, probeType)
+	return result, nil
+}
+
+func (p *podService) probeExec(ctx context.Context, cluster string, pod *v1.Pod, container string, action *v1.ExecAction, timeout time.Duration, result *ProbeTestResult) error {
+	result.Handler, result.Target = "exec", strings.Join(action.Command, " ")
+	exec, err := p.ExecCommand(ctx, cluster, pod.Namespace, pod.Name, container, action.Command, timeout)
+	if err != nil {
+		return err
+	}
+	result.Exec, result.TimedOut = exec, exec.TimedOut
+	result.Success = exec.ExitCode == 0 && !exec.TimedOut
+	switch {
+	case exec.TimedOut:
+		result.Message = fmt.Sprintf("命令在 %s 内未结束", timeout)
+	case exec.ExitCode != 0:
+		result.Message = fmt.Sprintf("命令退出码为 %d", exec.ExitCode)
+	default:
+		result.Message = "命令退出码为 0"
+	}
+	return nil
+}
+
+func (p *podService) probeHTTP(ctx context.Context, cluster string, pod *v1.Pod, ctn *v1.Container, action *v1.HTTPGetAction, timeout time.Duration, result *ProbeTestResult) error {
+	port, err := resolveProbePort(ctn, action.Port)
+	if err != nil {
+		return err
+	}
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	path := action.Path
+	if path == "" {
+		path = "/"
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("探针路径 %s 格式错误: %w", path, err)
+	}
+	result.Handler, result.Target = "httpGet", fmt.Sprintf("GET %s://%s:%d%s", scheme, pod.Status.PodIP, port, path)
+	result.Notes = append(result.Notes, "经 API Server 的 Pod 代理发起请求，kubelet 则从节点直接访问 Pod IP，网络策略等差异可能导致结果不同")
+	if action.Host != "" {
+		result.Notes = append(result.Notes, fmt.Sprintf("探针指定了 host=%s，测试时仍访问 Pod 本身", action.Host))
+	}
+
+	code, body, err := p.proxyGet(ctx, cluster, pod, scheme, port, u, action.HTTPHeaders, timeout)
+	if err != nil {
+		result.Message, result.TimedOut = err.Error(), errors.Is(err, context.DeadlineExceeded)
+		return nil
+	}
+	if strings.Contains(body, probeProxyDialFail) {
+		result.Message = body
+		return nil
+	}
+	result.StatusCode, result.Body = code, body
+	// 与 kubelet 一致，200 <= code < 400 视为成功
+	result.Success = code >= 200 && code < 400
+	result.Message = fmt.Sprintf("HTTP 状态码 %d", code)
+	return nil
+}
+
+func (p *podService) probeTCP(ctx context.Context, cluster string, pod *v1.Pod, ctn *v1.Container, action *v1.TCPSocketAction, timeout time.Duration, result *ProbeTestResult) error {
+	port, err := resolveProbePort(ctn, action.Port)
+	if err != nil {
+		return err
+	}
+	result.Handler, result.Target = "tcpSocket", fmt.Sprintf("%s:%d", pod.Status.PodIP, port)
+	result.Notes = append(result.Notes, "经 API Server 的 Pod 代理建立连接，能建立连接即视为成功，连接后的响应内容不影响结果")
+
+	_, body, err := p.proxyGet(ctx, cluster, pod, "http", port, &url.URL{Path: "/"}, nil, timeout)
+	if errors.Is(err, context.DeadlineExceeded) {
+		result.Message, result.TimedOut = fmt.Sprintf("在 %s 内未能建立连接", timeout), true
+		return nil
+	}
+	if err != nil {
+		result.Message = err.Error()
+		return nil
+	}
+	if strings.Contains(body, probeProxyDialFail) {
+		result.Message = body
+		return nil
+	}
+	result.Success, result.Message = true, fmt.Sprintf("端口 %d 可以建立连接", port)
+	return nil
+}
+
+// proxyGet 通过 API Server 的 Pod 代理发起 GET 请求，返回状态码与截断后的响应体
+func (p *podService) proxyGet(ctx context.Context, cluster string, pod *v1.Pod, scheme string, port int32, u *url.URL, headers []v1.HTTPHeader, timeout time.Duration) (int, string, error) {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return 0, "", fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	target := fmt.Sprintf("%s:%d", pod.Name, port)
+	if scheme == "https" {
+		target = "https:" + target
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req := k.Client().CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/namespaces", pod.Namespace, "pods", target, "proxy", u.Path)
+	for key, values := range u.Query() {
+		for _, v := range values {
+			req.Param(key, v)
+		}
+	}
+	for _, h := range headers {
+		req.SetHeader(h.Name, h.Value)
+	}
+
+	var code int
+	res := req.Do(reqCtx).StatusCode(&code)
+	raw, err := res.Raw()
+	if code == 0 && err != nil {
+		return 0, "", err
+	}
+	if len(raw) > probeMaxBodyBytes {
+		raw = raw[:probeMaxBodyBytes]
+	}
+	return code, string(raw), nil
+}
+
+// resolveProbePort 将探针中的端口名解析为容器端口号
+func resolveProbePort(ctn *v1.Container, port intstr.IntOrString) (int32, error) {
+	if port.Type == intstr.Int {
+		if port.IntVal <= 0 {
+			return 0, fmt.Errorf("探针端口 %d 无效", port.IntVal)
+		}
+		return port.IntVal, nil
+	}
+	for _, p := range ctn.Ports {
+		if p.Name == port.StrVal {
+			return p.ContainerPort, nil
+		}
+	}
+	return 0, fmt.Errorf("容器 %s 中未找到名为 %s 的端口", ctn.Name, port.StrVal)
+}
+
+// recentProbeFailures 读取 kubelet 为该容器记录的同类型探针失败事件，按时间倒序
+func (p *podService) recentProbeFailures(ctx context.Context, cluster string, pod *v1.Pod, container, probeType string) []*ProbeEvent {
+	var events []v1.Event
+	selector := "involvedObject.kind=Pod,reason=Unhealthy,involvedObject.name=" + pod.Name
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Event{}).Namespace(pod.Namespace).WithFieldSelector(selector).List(&events).Error
+	if err != nil {
+		klog.V(6).Infof("读取 Pod[%s/%s]探针事件失败: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	prefix := strings.ToLower(probeType) + " probe"
+	var items []*ProbeEvent
+	for _, e := range events {
+		if e.InvolvedObject.UID != pod.UID || !strings.Contains(e.InvolvedObject.FieldPath, "{"+container+"}") ||
+			!strings.HasPrefix(strings.ToLower(e.Message), prefix) {
+			continue
+		}
+		t := e.LastTimestamp.Time
+		if t.IsZero() {
+			t = e.EventTime.Time
+		}
+		items = append(items, &ProbeEvent{Time: t, Count: e.Count, Message: e.Message})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Time.After(items[j].Time) })
+	if len(items) > probeRecentEvents {
+		items = items[:probeRecentEvents]
+	}
+	return items
+}
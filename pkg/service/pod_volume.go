@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/kom/kom"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// duTopEntries du 统计时返回的最大子目录数
+const duTopEntries = 20
+
+// PodVolumeMount 卷在容器中的挂载
+type PodVolumeMount struct {
+	Container string `json:"container"`
+	Init      bool   `json:"init,omitempty"`
+	MountPath string `json:"mount_path"`
+	SubPath   string `json:"sub_path,omitempty"`
+	ReadOnly  bool   `json:"read_only,omitempty"`
+}
+
+// DiskUsageEntry du 统计的单个目录
+type DiskUsageEntry struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// PodVolumeUsage Pod 单个卷的来源、挂载与用量
+type PodVolumeUsage struct {
+	Name         string            `json:"name"`
+	Type         string            `json:"type"`             // pvc、configMap、secret、hostPath、emptyDir、projected 等
+	Source       string            `json:"source,omitempty"` // 来源对象名称或宿主机路径
+	StorageClass string            `json:"storage_class,omitempty"`
+	PV           string            `json:"pv,omitempty"`
+	PVCPhase     string            `json:"pvc_phase,omitempty"`
+	Capacity     string            `json:"capacity,omitempty"` // PVC 声明的容量
+	Mounts       []*PodVolumeMount `json:"mounts"`
+
+	// kubelet 统计，仅 PVC、emptyDir 等由 kubelet 管理的卷有值
+	UsedBytes      *uint64 `json:"used_bytes,omitempty"`
+	CapacityBytes  *uint64 `json:"capacity_bytes,omitempty"`
+	AvailableBytes *uint64 `json:"available_bytes,omitempty"`
+	InodesUsed     *uint64 `json:"inodes_used,omitempty"`
+
+	// 在容器内执行 du 的结果
+	DuBytes   *int64            `json:"du_bytes,omitempty"`
+	DuTop     []*DiskUsageEntry `json:"du_top,omitempty"` // 占用最大的子目录
+	DuError   string            `json:"du_error,omitempty"`
+	StatError string            `json:"stat_error,omitempty"`
+}
+
+// kubeletStatsSummary kubelet /stats/summary 中用到的字段
+type kubeletStatsSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Volume []struct {
+			Name           string  `json:"name"`
+			UsedBytes      *uint64 `json:"usedBytes"`
+			CapacityBytes  *uint64 `json:"capacityBytes"`
+			AvailableBytes *uint64 `json:"availableBytes"`
+			InodesUsed     *uint64 `json:"inodesUsed"`
+		} `json:"volume"`
+	} `json:"pods"`
+}
+
+// VolumeUsage 列出 Pod 的全部卷，解析来源与挂载位置，并从 kubelet 读取用量。
+// withDu 为 true 时在挂载该卷的容器中执行 du，统计总量及占用最大的子目录，需要 Exec 权限。
+func (p *podService) VolumeUsage(ctx context.Context, cluster, ns, name string, withDu bool) ([]*PodVolumeUsage, error) {
+	var pod v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&pod).Namespace(ns).Name(name).Get(&pod).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*PodVolumeUsage, 0, len(pod.Spec.Volumes))
+	index := map[string]*PodVolumeUsage{}
+	for _, vol := range pod.Spec.Volumes {
+		item := &PodVolumeUsage{Name: vol.Name, Mounts: []*PodVolumeMount{}}
+		describeVolumeSource(item, vol)
+		if vol.PersistentVolumeClaim != nil {
+			p.fillPVC(ctx, cluster, ns, item)
+		}
+		result = append(result, item)
+		index[vol.Name] = item
+	}
+	addMounts := func(containers []v1.Container, init bool) {
+		for _, c := range containers {
+			for _, m := range c.VolumeMounts {
+				if item, ok := index[m.Name]; ok {
+					item.Mounts = append(item.Mounts, &PodVolumeMount{
+						Container: c.Name, Init: init, MountPath: m.MountPath, SubPath: m.SubPath, ReadOnly: m.ReadOnly,
+					})
+				}
+			}
+		}
+	}
+	addMounts(pod.Spec.InitContainers, true)
+	addMounts(pod.Spec.Containers, false)
+
+	if pod.Spec.NodeName != "" {
+		if err := p.fillKubeletStats(ctx, cluster, &pod, index); err != nil {
+			klog.V(4).Infof("读取节点 %s kubelet 卷统计失败: %v", pod.Spec.NodeName, err)
+			for _, item := range result {
+				item.StatError = err.Error()
+			}
+		}
+	}
+
+	if withDu && pod.Status.Phase == v1.PodRunning {
+		running := map[string]bool{}
+		for _, cs := range pod.Status.ContainerStatuses {
+			running[cs.Name] = cs.State.Running != nil
+		}
+		for _, item := range result {
+			p.fillDiskUsage(ctx, cluster, ns, name, item, running)
+		}
+	}
+	return result, nil
+}
+
+func describeVolumeSource(item *PodVolumeUsage, vol v1.Volume) {
+	switch {
+	case vol.PersistentVolumeClaim != nil:
+		item.Type, item.Source = "pvc", vol.PersistentVolumeClaim.ClaimName
+	case vol.ConfigMap != nil:
+		item.Type, item.Source = "configMap", vol.ConfigMap.Name
+	case vol.Secret != nil:
+		item.Type, item.Source = "secret", vol.Secret.SecretName
+	case vol.HostPath != nil:
+		item.Type, item.Source = "hostPath", vol.HostPath.Path
+	case vol.EmptyDir != nil:
+		item.Type = "emptyDir"
+		if vol.EmptyDir.Medium != "" {
+			item.Source = string(vol.EmptyDir.Medium)
+		}
+	case vol.Projected != nil:
+		item.Type = "projected"
+		var sources []string
+		for _, s := range vol.Projected.Sources {
+			switch {
+			case s.ConfigMap != nil:
+				sources = append(sources, "configMap/"+s.ConfigMap.Name)
+			case s.Secret != nil:
+				sources = append(sources, "secret/"+s.Secret.Name)
+			case s.ServiceAccountToken != nil:
+				sources = append(sources, "serviceAccountToken")
+			case s.DownwardAPI != nil:
+				sources = append(sources, "downwardAPI")
+			}
+		}
+		item.Source = strings.Join(sources, ",")
+	case vol.DownwardAPI != nil:
+		item.Type = "downwardAPI"
+	case vol.Ephemeral != nil:
+		item.Type = "ephemeral"
+	case vol.NFS != nil:
+		item.Type, item.Source = "nfs", vol.NFS.Server+":"+vol.NFS.Path
+	case vol.CSI != nil:
+		item.Type, item.Source = "csi", vol.CSI.Driver
+	default:
+		item.Type = "other"
+	}
+}
+
+func (p *podService) fillPVC(ctx context.Context, cluster, ns string, item *PodVolumeUsage) {
+	var pvc v1.PersistentVolumeClaim
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&pvc).Namespace(ns).Name(item.Source).Get(&pvc).Error; err != nil {
+		item.PVCPhase = "NotFound"
+		return
+	}
+	item.PVCPhase = string(pvc.Status.Phase)
+	item.PV = pvc.Spec.VolumeName
+	if pvc.Spec.StorageClassName != nil {
+		item.StorageClass = *pvc.Spec.StorageClassName
+	}
+	if q, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		item.Capacity = q.String()
+	} else if q, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		item.Capacity = q.String()
+	}
+}
+
+// fillKubeletStats 通过节点代理读取 kubelet /stats/summary 中该 Pod 的卷统计
+func (p *podService) fillKubeletStats(ctx context.Context, cluster string, pod *v1.Pod, index map[string]*PodVolumeUsage) error {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	raw, err := k.Client().CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes", pod.Spec.NodeName, "proxy/stats/summary").DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+	var summary kubeletStatsSummary
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return err
+	}
+	for _, ps := range summary.Pods {
+		if ps.PodRef.Namespace != pod.Namespace || ps.PodRef.Name != pod.Name {
+			continue
+		}
+		for _, vs := range ps.Volume {
+			if item, ok := index[vs.Name]; ok {
+				item.UsedBytes = vs.UsedBytes
+				item.CapacityBytes = vs.CapacityBytes
+				item.AvailableBytes = vs.AvailableBytes
+				item.InodesUsed = vs.InodesUsed
+			}
+		}
+	}
+	return nil
+}
+
+// fillDiskUsage 选择挂载该卷且正在运行的容器执行 du，输出单位为 KiB
+func (p *podService) fillDiskUsage(ctx context.Context, cluster, ns, name string, item *PodVolumeUsage, running map[string]bool) {
+	var mount *PodVolumeMount
+	for _, m := range item.Mounts {
+		if !m.Init && running[m.Container] {
+			mount = m
+			break
+		}
+	}
+	if mount == nil {
+		return
+	}
+	path := utils.ShellQuote(mount.MountPath)
+	script := fmt.Sprintf("du -sk %s 2>/dev/null; du -k -d 1 %s 2>/dev/null | sort -rn | head -n %d", path, path, duTopEntries+1)
+	var out []byte
+	err := kom.Cluster(cluster).WithContext(WithReadOnlyExec(ctx)).Resource(&v1.Pod{}).Namespace(ns).Name(name).
+		Ctl().Pod().ContainerName(mount.Container).Command("sh", "-c", script).Execute(&out).Error
+	if err != nil {
+		item.DuError = err.Error()
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	for i, line := range lines {
+		size, dir, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		kb, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+		if err != nil {
+			continue
+		}
+		bytes := kb * 1024
+		if i == 0 {
+			item.DuBytes = &bytes
+			continue
+		}
+		// 跳过挂载点自身
+		if strings.TrimSuffix(dir, "/") == strings.TrimSuffix(mount.MountPath, "/") {
+			continue
+		}
+		item.DuTop = append(item.DuTop, &DiskUsageEntry{Path: dir, Bytes: bytes})
+	}
+	if item.DuBytes == nil {
+		item.DuError = "容器内没有可用的 du 命令或目录不可读"
+	}
+	sort.SliceStable(item.DuTop, func(i, j int) bool { return item.DuTop[i].Bytes > item.DuTop[j].Bytes })
+	if len(item.DuTop) > duTopEntries {
+		item.DuTop = item.DuTop[:duTopEntries]
+	}
+}
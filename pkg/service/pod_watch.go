@@ -302,14 +302,19 @@ func (p *podService) watchSingleCluster(selectedCluster string) watch.Interface
 
 	var watcher watch.Interface
 	var pod v1.Pod
+	// watch 的初始事件包含全部现有 Pod，重新建立时从空索引开始
+	index := PodIndexService().Reset(selectedCluster)
 	err := kom.Cluster(selectedCluster).WithContext(ctx).Resource(&pod).AllNamespace().Watch(&watcher).Error
 	if err != nil {
 		klog.Errorf("%s 创建Pod监听器失败 %v", selectedCluster, err)
+		PodIndexService().Invalidate(selectedCluster, index)
 		return nil
 	}
 	go func() {
 		klog.V(6).Infof("%s start watch pod", selectedCluster)
 		defer watcher.Stop()
+		// watch 结束后索引不再更新，查询改为直接读取
+		defer PodIndexService().Invalidate(selectedCluster, index)
 		for event := range watcher.ResultChan() {
 			err = kom.Cluster(selectedCluster).WithContext(ctx).Tools().ConvertRuntimeObjectToTypedObject(event.Object, &pod)
 			if err != nil {
@@ -317,24 +322,29 @@ func (p *podService) watchSingleCluster(selectedCluster string) watch.Interface
 				return
 			}
 			// 处理事件
+			// 资源变更，使列表缓存失效
+			ListCacheService().Invalidate(selectedCluster, "Pod")
 			switch event.Type {
 			case watch.Added:
 				p.CacheAllocatedStatus(selectedCluster, &pod)
 				p.IncreasePodCount(selectedCluster, &pod)
 				// 新增Pod时，保存Pod标签
 				p.UpdatePodLabels(selectedCluster, pod.Namespace, pod.Name, pod.Labels)
+				PodIndexService().Update(selectedCluster, &pod)
 				klog.V(6).Infof("%s 添加Pod [ %s/%s ] 标签数量: %d\n", selectedCluster, pod.Namespace, pod.Name, len(pod.Labels))
 			case watch.Modified:
 				p.RemoveCacheAllocatedStatus(selectedCluster, &pod)
 				p.CacheAllocatedStatus(selectedCluster, &pod)
 				// 修改Pod时，更新Pod标签
 				p.UpdatePodLabels(selectedCluster, pod.Namespace, pod.Name, pod.Labels)
+				PodIndexService().Update(selectedCluster, &pod)
 				klog.V(6).Infof("%s 修改Pod [ %s/%s ] 标签数量: %d\n", selectedCluster, pod.Namespace, pod.Name, len(pod.Labels))
 			case watch.Deleted:
 				p.RemoveCacheAllocatedStatus(selectedCluster, &pod)
 				p.ReducePodCount(selectedCluster, &pod)
 				// 删除Pod时，删除Pod标签
 				p.DeletePodLabels(selectedCluster, pod.Namespace, pod.Name)
+				PodIndexService().Delete(selectedCluster, pod.Namespace, pod.Name)
 				klog.V(6).Infof("%s 删除Pod [ %s/%s ]\n", selectedCluster, pod.Namespace, pod.Name)
 			}
 		}
@@ -343,6 +353,7 @@ func (p *podService) watchSingleCluster(selectedCluster string) watch.Interface
 	// 延迟设置完成状态，等待Pod ListWatch完成
 	ClusterService().DelayStartFunc(func() {
 		ClusterService().SetPodStatusAggregated(selectedCluster, true)
+		PodIndexService().MarkSynced(selectedCluster)
 	})
 	return watcher
 }
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"gorm.io/gorm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type projectService struct{}
+
+// ProjectQuotaName 按项目配额同步到命名空间的 ResourceQuota 名称
+const ProjectQuotaName = "k8m-project-quota"
+
+// ProjectView 项目及其成员、命名空间
+type ProjectView struct {
+	*models.Project
+	Role       string                     `json:"role,omitempty"` // 当前用户在项目中的角色，平台管理员非成员时为空
+	Members    []*models.ProjectMember    `json:"members"`
+	Namespaces []*models.ProjectNamespace `json:"namespaces"`
+}
+
+// ProjectQuotaResult 项目配额同步到单个命名空间的结果
+type ProjectQuotaResult struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Action    string `json:"action,omitempty"` // created、updated
+	Error     string `json:"error,omitempty"`
+}
+
+// GetProject 按名称获取项目
+func (p *projectService) GetProject(name string) (*models.Project, error) {
+	m := &models.Project{}
+	item, err := m.GetOne(&dao.Params{}, func(db *gorm.DB) *gorm.DB {
+		return db.Where("name = ?", name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("项目 %s 不存在", name)
+	}
+	return item, nil
+}
+
+// View 返回项目及其成员、命名空间
+func (p *projectService) View(project *models.Project, username string) (*ProjectView, error) {
+	view := &ProjectView{Project: project}
+	if err := dao.DB().Where("project_id = ?", project.ID).Order("username").Find(&view.Members).Error; err != nil {
+		return nil, err
+	}
+	if err := dao.DB().Where("project_id = ?", project.ID).Order("cluster, namespace").Find(&view.Namespaces).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range view.Members {
+		if m.Username == username {
+			view.Role = m.Role
+		}
+	}
+	return view, nil
+}
+
+// UserProjects 返回用户所在的项目，平台管理员返回全部项目
+func (p *projectService) UserProjects(username string) ([]*ProjectView, error) {
+	var projects []*models.Project
+	db := dao.DB().Model(&models.Project{}).Order("name")
+	if !UserService().IsUserPlatformAdmin(username) {
+		db = db.Where("id in (?)", dao.DB().Model(&models.ProjectMember{}).Select("project_id").Where("username = ?", username))
+	}
+	if err := db.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	views := make([]*ProjectView, 0, len(projects))
+	for _, project := range projects {
+		view, err := p.View(project, username)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// UserProjectNames 返回用户作为成员所在的项目名称，用于按项目共享的模板等资源的可见性判断
+func (p *projectService) UserProjectNames(username string) []string {
+	var names []string
+	dao.DB().Model(&models.Project{}).
+		Where("id in (?)", dao.DB().Model(&models.ProjectMember{}).Select("project_id").Where("username = ?", username)).
+		Pluck("name", &names)
+	return names
+}
+
+// memberRole 返回用户在项目中的角色，非成员返回空
+func (p *projectService) memberRole(username string, projectID uint) string {
+	var member models.ProjectMember
+	if err := dao.DB().Where("project_id = ? and username = ?", projectID, username).First(&member).Error; err != nil {
+		return ""
+	}
+	return member.Role
+}
+
+// CanAccess 判断用户能否访问项目：平台管理员或项目成员
+func (p *projectService) CanAccess(username string, projectID uint) bool {
+	return UserService().IsUserPlatformAdmin(username) || p.memberRole(username, projectID) != ""
+}
+
+// CanManage 判断用户能否管理项目成员与命名空间：平台管理员或项目负责人
+func (p *projectService) CanManage(username string, projectID uint) bool {
+	return UserService().IsUserPlatformAdmin(username) || p.memberRole(username, projectID) == models.ProjectRoleOwner
+}
+
+// ScopeNamespaces 将列表查询的命名空间范围限定在项目内。
+// nsList 为空或只含空字符串表示全部命名空间，此时返回项目在该集群中的全部命名空间；
+// 项目包含整个集群时原样返回；返回空切片表示查询范围与项目没有交集
+func (p *projectService) ScopeNamespaces(username, project, cluster string, nsList []string) ([]string, error) {
+	item, err := p.GetProject(project)
+	if err != nil {
+		return nil, err
+	}
+	if !p.CanAccess(username, item.ID) {
+		return nil, fmt.Errorf("不是项目 %s 的成员", project)
+	}
+	var entries []*models.ProjectNamespace
+	if err := dao.DB().Where("project_id = ? and cluster = ?", item.ID, cluster).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("项目 %s 不包含集群 %s", project, cluster)
+	}
+	allowed := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Namespace == "" {
+			return nsList, nil
+		}
+		allowed = append(allowed, e.Namespace)
+	}
+	sort.Strings(allowed)
+
+	all := len(nsList) == 0 || (len(nsList) == 1 && nsList[0] == "")
+	if all {
+		return allowed, nil
+	}
+	scoped := []string{}
+	for _, ns := range nsList {
+		if slices.Contains(allowed, ns) {
+			scoped = append(scoped, ns)
+		}
+	}
+	return scoped, nil
+}
+
+// SaveMembers 整体替换项目成员
+func (p *projectService) SaveMembers(projectID uint, members []*models.ProjectMember) error {
+	seen := map[string]bool{}
+	for _, m := range members {
+		m.Username = strings.TrimSpace(m.Username)
+		if m.Username == "" {
+			return fmt.Errorf("成员用户名不能为空")
+		}
+		if seen[m.Username] {
+			return fmt.Errorf("成员 %s 重复", m.Username)
+		}
+		seen[m.Username] = true
+		if m.Role == "" {
+			m.Role = models.ProjectRoleMember
+		}
+		if m.Role != models.ProjectRoleOwner && m.Role != models.ProjectRoleMember {
+			return fmt.Errorf("不支持的成员角色: %s", m.Role)
+		}
+		m.ID, m.ProjectID = 0, projectID
+	}
+	return dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&models.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		return tx.Create(&members).Error
+	})
+}
+
+// SaveNamespaces 整体替换项目包含的集群与命名空间
+func (p *projectService) SaveNamespaces(projectID uint, items []*models.ProjectNamespace) error {
+	seen := map[string]bool{}
+	for _, item := range items {
+		item.Cluster, item.Namespace = strings.TrimSpace(item.Cluster), strings.TrimSpace(item.Namespace)
+		if item.Cluster == "" {
+			return fmt.Errorf("集群不能为空")
+		}
+		key := item.Cluster + "/" + item.Namespace
+		if seen[key] {
+			return fmt.Errorf("命名空间 %s 重复", key)
+		}
+		seen[key] = true
+		item.ID, item.ProjectID = 0, projectID
+	}
+	return dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&models.ProjectNamespace{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+// DeleteProjects 删除项目及其成员、命名空间
+func (p *projectService) DeleteProjects(ids []int64) error {
+	return dao.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id in ?", ids).Delete(&models.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id in ?", ids).Delete(&models.ProjectNamespace{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id in ?", ids).Delete(&models.Project{}).Error
+	})
+}
+
+// ParseQuota 解析项目配额，返回 ResourceQuota 的 hard
+func (p *projectService) ParseQuota(quota string) (corev1.ResourceList, error) {
+	if strings.TrimSpace(quota) == "" {
+		return nil, nil
+	}
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(quota), &raw); err != nil {
+		return nil, fmt.Errorf("配额格式错误，应为 JSON 对象: %w", err)
+	}
+	hard := corev1.ResourceList{}
+	for k, v := range raw {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("配额 %s 的值 %s 格式错误: %w", k, v, err)
+		}
+		hard[corev1.ResourceName(k)] = q
+	}
+	return hard, nil
+}
+
+// SyncQuota 将项目配额以 ResourceQuota 的形式同步到项目内的每个命名空间，包含整个集群的条目不处理
+func (p *projectService) SyncQuota(ctx context.Context, project *models.Project) ([]*ProjectQuotaResult, error) {
+	hard, err := p.ParseQuota(project.Quota)
+	if err != nil {
+		return nil, err
+	}
+	if len(hard) == 0 {
+		return nil, fmt.Errorf("项目 %s 未设置配额", project.Name)
+	}
+	var entries []*models.ProjectNamespace
+	if err := dao.DB().Where("project_id = ? and namespace <> ''", project.ID).Order("cluster, namespace").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	results := make([]*ProjectQuotaResult, 0, len(entries))
+	for _, e := range entries {
+		r := &ProjectQuotaResult{Cluster: e.Cluster, Namespace: e.Namespace}
+		r.Action, err = p.applyQuota(ctx, e.Cluster, e.Namespace, project.Name, hard)
+		if err != nil {
+			r.Error = err.Error()
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (p *projectService) applyQuota(ctx context.Context, cluster, ns, project string, hard corev1.ResourceList) (string, error) {
+	if kom.Cluster(cluster) == nil {
+		return "", fmt.Errorf("集群 %s 未连接", cluster)
+	}
+	var quota corev1.ResourceQuota
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&quota).Namespace(ns).Name(ProjectQuotaName).Get(&quota).Error
+	switch {
+	case apierrors.IsNotFound(err):
+		quota = corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ProjectQuotaName,
+				Namespace:   ns,
+				Labels:      map[string]string{managedByLabel: "k8m"},
+				Annotations: map[string]string{"k8m.io/project": project},
+			},
+			Spec: corev1.ResourceQuotaSpec{Hard: hard},
+		}
+		err = kom.Cluster(cluster).WithContext(ctx).Resource(&quota).Namespace(ns).Name(ProjectQuotaName).Create(&quota).Error
+		return "created", err
+	case err != nil:
+		return "", err
+	}
+	quota.Spec.Hard = hard
+	err = kom.Cluster(cluster).WithContext(ctx).Resource(&quota).Namespace(ns).Name(ProjectQuotaName).Update(&quota).Error
+	return "updated", err
+}
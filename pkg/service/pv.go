@@ -125,6 +125,8 @@ func (p *pvService) watchSingleCluster(selectedCluster string) watch.Interface {
 				return
 			}
 			// 处理事件
+			// 资源变更，使列表缓存失效
+			ListCacheService().Invalidate(selectedCluster, "PersistentVolume")
 			switch event.Type {
 			case watch.Added:
 				p.IncreasePVCount(selectedCluster, &pv)
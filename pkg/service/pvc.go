@@ -134,6 +134,8 @@ func (p *pvcService) watchSingleCluster(selectedCluster string) watch.Interface
 				return
 			}
 			// 处理事件
+			// 资源变更，使列表缓存失效
+			ListCacheService().Invalidate(selectedCluster, "PersistentVolumeClaim")
 			switch event.Type {
 			case watch.Added:
 				p.IncreasePVCCount(selectedCluster, &pvc)
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/ratelimit"
+	"golang.org/x/time/rate"
+	"k8s.io/klog/v2"
+)
+
+// rateLimitMaxWait 令牌不足时最多排队等待的时长，超过则直接拒绝
+const rateLimitMaxWait = 2 * time.Second
+
+// rateLimitService 在 kom 客户端层按用户、按集群限制访问 apiserver 的频率。
+// 页面、gRPC、入站 webhook、定时任务等凡是以用户身份发起的 kom 调用都计入该用户的额度；
+// 不带用户的内部调用（心跳、能力探测等）只受 --kube-client-qps 约束
+type rateLimitService struct {
+	once     sync.Once
+	users    *ratelimit.Set
+	clusters *ratelimit.Set
+}
+
+// Wait 为本次调用预留令牌，短暂超额时排队等待，等待超过 rateLimitMaxWait 时返回 *ratelimit.Error
+func (s *rateLimitService) Wait(ctx context.Context, cluster string) error {
+	if ctx == nil {
+		return nil
+	}
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	if username == "" {
+		return nil
+	}
+	s.once.Do(func() {
+		cfg := flag.Init()
+		s.users = ratelimit.New(cfg.RateLimitUserQPS, cfg.RateLimitUserBurst)
+		s.clusters = ratelimit.New(cfg.RateLimitClusterQPS, cfg.RateLimitClusterBurst)
+	})
+
+	var reserved []*rate.Reservation
+	cancel := func() {
+		for _, r := range reserved {
+			r.Cancel()
+		}
+	}
+	var delay time.Duration
+	for _, l := range []struct {
+		set *ratelimit.Set
+		key string
+		err *i18n.Error
+	}{
+		{s.users, username, i18n.NewError(i18n.CodeTooManyRequests)},
+		{s.clusters, cluster, i18n.NewError(i18n.CodeClusterTooManyRequests, cluster)},
+	} {
+		r := l.set.Reserve(l.key)
+		if r == nil {
+			continue
+		}
+		reserved = append(reserved, r)
+		d := r.Delay()
+		if !r.OK() || d > rateLimitMaxWait {
+			if !r.OK() {
+				d = time.Second
+			}
+			cancel()
+			klog.V(6).Infof("用户[%s]访问集群[%s]过于频繁，已限流", username, cluster)
+			return &ratelimit.Error{Err: l.err, RetryAfter: d}
+		}
+		delay = max(delay, d)
+	}
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
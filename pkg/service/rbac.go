@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/duke-git/lancet/v2/slice"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type rbacService struct{}
+
+// RBACGrant 一条绑定授予的权限
+type RBACGrant struct {
+	BindingKind      string              `json:"binding_kind"` // RoleBinding 或 ClusterRoleBinding
+	BindingNamespace string              `json:"binding_namespace,omitempty"`
+	BindingName      string              `json:"binding_name"`
+	RoleKind         string              `json:"role_kind"` // Role 或 ClusterRole
+	RoleName         string              `json:"role_name"`
+	Scope            string              `json:"scope"`             // 生效范围：命名空间名称，集群级为 *
+	Subject          rbacv1.Subject      `json:"subject"`           // 命中的主体，可能是 SA 本身或其所属的组
+	Rules            []rbacv1.PolicyRule `json:"rules,omitempty"`   // 角色规则
+	Missing          bool                `json:"missing,omitempty"` // 引用的角色不存在
+}
+
+// EffectiveRule 按生效范围聚合后的规则
+type EffectiveRule struct {
+	Scope           string   `json:"scope"`
+	APIGroups       []string `json:"api_groups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resource_names,omitempty"`
+	NonResourceURLs []string `json:"non_resource_urls,omitempty"`
+	Verbs           []string `json:"verbs"`
+	Sources         []string `json:"sources"` // 来源绑定，如 ClusterRoleBinding/admin
+}
+
+// ServiceAccountPermissions ServiceAccount 的有效权限
+type ServiceAccountPermissions struct {
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Groups    []string         `json:"groups"`
+	Grants    []*RBACGrant     `json:"grants"`
+	Rules     []*EffectiveRule `json:"rules"`
+}
+
+// rbacSnapshot 集群中的全部角色与绑定
+type rbacSnapshot struct {
+	roles               map[string]*rbacv1.Role // namespace/name
+	clusterRoles        map[string]*rbacv1.ClusterRole
+	roleBindings        []rbacv1.RoleBinding
+	clusterRoleBindings []rbacv1.ClusterRoleBinding
+}
+
+func (r *rbacService) snapshot(ctx context.Context, cluster string) (*rbacSnapshot, error) {
+	var roles []*rbacv1.Role
+	var clusterRoles []*rbacv1.ClusterRole
+	s := &rbacSnapshot{roles: map[string]*rbacv1.Role{}, clusterRoles: map[string]*rbacv1.ClusterRole{}}
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&rbacv1.Role{}).AllNamespace().List(&roles).Error; err != nil {
+		return nil, fmt.Errorf("读取 Role 失败: %w", err)
+	}
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&rbacv1.ClusterRole{}).List(&clusterRoles).Error; err != nil {
+		return nil, fmt.Errorf("读取 ClusterRole 失败: %w", err)
+	}
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&rbacv1.RoleBinding{}).AllNamespace().List(&s.roleBindings).Error; err != nil {
+		return nil, fmt.Errorf("读取 RoleBinding 失败: %w", err)
+	}
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&rbacv1.ClusterRoleBinding{}).List(&s.clusterRoleBindings).Error; err != nil {
+		return nil, fmt.Errorf("读取 ClusterRoleBinding 失败: %w", err)
+	}
+	for _, role := range roles {
+		s.roles[role.Namespace+"/"+role.Name] = role
+	}
+	for _, role := range clusterRoles {
+		s.clusterRoles[role.Name] = role
+	}
+	return s, nil
+}
+
+// rules 解析绑定引用的角色规则，角色不存在时返回 false。
+// 聚合 ClusterRole 的规则由控制器写回 rules 字段，直接读取即可。
+func (s *rbacSnapshot) rules(ns string, ref rbacv1.RoleRef) ([]rbacv1.PolicyRule, bool) {
+	switch ref.Kind {
+	case "Role":
+		if role, ok := s.roles[ns+"/"+ref.Name]; ok {
+			return role.Rules, true
+		}
+	case "ClusterRole":
+		if role, ok := s.clusterRoles[ref.Name]; ok {
+			return role.Rules, true
+		}
+	}
+	return nil, false
+}
+
+// grants 遍历全部绑定，对命中 match 的主体生成授权记录
+func (s *rbacSnapshot) grants(match func(subject rbacv1.Subject, bindingNs string) bool) []*RBACGrant {
+	var result []*RBACGrant
+	add := func(kind, ns, name, scope string, ref rbacv1.RoleRef, subjects []rbacv1.Subject) {
+		for _, subject := range subjects {
+			if !match(subject, ns) {
+				continue
+			}
+			rules, ok := s.rules(ns, ref)
+			result = append(result, &RBACGrant{
+				BindingKind:      kind,
+				BindingNamespace: ns,
+				BindingName:      name,
+				RoleKind:         ref.Kind,
+				RoleName:         ref.Name,
+				Scope:            scope,
+				Subject:          subject,
+				Rules:            rules,
+				Missing:          !ok,
+			})
+		}
+	}
+	for _, b := range s.clusterRoleBindings {
+		add("ClusterRoleBinding", "", b.Name, "*", b.RoleRef, b.Subjects)
+	}
+	for _, b := range s.roleBindings {
+		add("RoleBinding", b.Namespace, b.Name, b.Namespace, b.RoleRef, b.Subjects)
+	}
+	return result
+}
+
+// serviceAccountGroups ServiceAccount 认证后自动所属的组
+func serviceAccountGroups(ns string) []string {
+	return []string{"system:serviceaccounts", "system:serviceaccounts:" + ns, "system:authenticated"}
+}
+
+// ServiceAccountPermissions 汇总 ServiceAccount 直接或经由所属组获得的全部权限，按生效范围合并相同的规则
+func (r *rbacService) ServiceAccountPermissions(ctx context.Context, cluster, ns, name string) (*ServiceAccountPermissions, error) {
+	s, err := r.snapshot(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	groups := serviceAccountGroups(ns)
+	result := &ServiceAccountPermissions{Namespace: ns, Name: name, Groups: groups}
+	result.Grants = s.grants(func(subject rbacv1.Subject, bindingNs string) bool {
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			subjectNs := subject.Namespace
+			if subjectNs == "" {
+				subjectNs = bindingNs
+			}
+			return subject.Name == name && subjectNs == ns
+		case rbacv1.GroupKind:
+			return slice.Contain(groups, subject.Name)
+		case rbacv1.UserKind:
+			return subject.Name == fmt.Sprintf("system:serviceaccount:%s:%s", ns, name)
+		}
+		return false
+	})
+	result.Rules = mergeRules(result.Grants)
+	return result, nil
+}
+
+// mergeRules 按生效范围与资源合并规则，动词取并集
+func mergeRules(grants []*RBACGrant) []*EffectiveRule {
+	index := map[string]*EffectiveRule{}
+	var keys []string
+	for _, g := range grants {
+		source := g.BindingKind + "/" + g.BindingName
+		if g.BindingNamespace != "" {
+			source = g.BindingKind + "/" + g.BindingNamespace + "/" + g.BindingName
+		}
+		for _, rule := range g.Rules {
+			key := strings.Join([]string{g.Scope, strings.Join(rule.APIGroups, ","), strings.Join(rule.Resources, ","),
+				strings.Join(rule.ResourceNames, ","), strings.Join(rule.NonResourceURLs, ",")}, "|")
+			er, ok := index[key]
+			if !ok {
+				er = &EffectiveRule{
+					Scope:           g.Scope,
+					APIGroups:       rule.APIGroups,
+					Resources:       rule.Resources,
+					ResourceNames:   rule.ResourceNames,
+					NonResourceURLs: rule.NonResourceURLs,
+				}
+				index[key] = er
+				keys = append(keys, key)
+			}
+			for _, verb := range rule.Verbs {
+				if !slice.Contain(er.Verbs, verb) {
+					er.Verbs = append(er.Verbs, verb)
+				}
+			}
+			if !slice.Contain(er.Sources, source) {
+				er.Sources = append(er.Sources, source)
+			}
+		}
+	}
+	sort.Strings(keys)
+	result := make([]*EffectiveRule, 0, len(keys))
+	for _, key := range keys {
+		sort.Strings(index[key].Verbs)
+		result = append(result, index[key])
+	}
+	return result
+}
+
+// WhoCanQuery 反查条件
+type WhoCanQuery struct {
+	Verb        string // 如 get、delete
+	Group       string // 核心组为空
+	Resource    string // 如 pods、pods/log
+	Namespace   string // 为空表示集群级资源，仅由 ClusterRoleBinding 授予
+	Name        string // 资源名称，可为空
+	NonResource string // 非资源地址，如 /healthz，设置后忽略资源相关条件
+}
+
+// WhoCan 反查可以对指定资源执行指定动作的全部主体及授予该权限的绑定
+func (r *rbacService) WhoCan(ctx context.Context, cluster string, q WhoCanQuery) ([]*RBACGrant, error) {
+	if q.Verb == "" || (q.Resource == "" && q.NonResource == "") {
+		return nil, fmt.Errorf("动作与资源不能为空")
+	}
+	s, err := r.snapshot(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	var result []*RBACGrant
+	for _, g := range s.grants(func(rbacv1.Subject, string) bool { return true }) {
+		// RoleBinding 只在自身命名空间生效，集群级资源与非资源地址只能由 ClusterRoleBinding 授予
+		if g.Scope != "*" && (q.NonResource != "" || q.Namespace != g.Scope) {
+			continue
+		}
+		var matched []rbacv1.PolicyRule
+		for _, rule := range g.Rules {
+			if ruleAllows(rule, q) {
+				matched = append(matched, rule)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		g.Rules = matched
+		result = append(result, g)
+	}
+	return result, nil
+}
+
+// ruleAllows 判断单条规则是否允许请求
+func ruleAllows(rule rbacv1.PolicyRule, q WhoCanQuery) bool {
+	if !matchRBAC(rule.Verbs, q.Verb) {
+		return false
+	}
+	if q.NonResource != "" {
+		for _, url := range rule.NonResourceURLs {
+			if url == rbacv1.NonResourceAll || url == q.NonResource ||
+				(strings.HasSuffix(url, "*") && strings.HasPrefix(q.NonResource, strings.TrimSuffix(url, "*"))) {
+				return true
+			}
+		}
+		return false
+	}
+	if !matchRBAC(rule.APIGroups, q.Group) {
+		return false
+	}
+	resourceOK := false
+	for _, res := range rule.Resources {
+		// 支持 pods/* 与 */scale 形式的子资源通配
+		if res == rbacv1.ResourceAll || res == q.Resource {
+			resourceOK = true
+			break
+		}
+		if parent, sub, ok := strings.Cut(q.Resource, "/"); ok &&
+			(res == parent+"/*" || res == "*/"+sub) {
+			resourceOK = true
+			break
+		}
+	}
+	if !resourceOK {
+		return false
+	}
+	if len(rule.ResourceNames) == 0 {
+		return true
+	}
+	return q.Name != "" && slice.Contain(rule.ResourceNames, q.Name)
+}
+
+func matchRBAC(list []string, value string) bool {
+	for _, item := range list {
+		if item == "*" || item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateToken 通过 TokenRequest 为 ServiceAccount 签发短期令牌，便于调试其权限
+func (r *rbacService) CreateToken(ctx context.Context, cluster, ns, name string, ttl time.Duration, audiences []string) (*authenticationv1.TokenRequestStatus, error) {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	// 先以用户身份读取，确认 ServiceAccount 存在且用户可见
+	var sa corev1.ServiceAccount
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&sa).Namespace(ns).Name(name).Get(&sa).Error; err != nil {
+		return nil, err
+	}
+	seconds := int64(ttl.Seconds())
+	req := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &seconds,
+		},
+	}
+	resp, err := k.Client().CoreV1().ServiceAccounts(ns).CreateToken(ctx, name, req, metav1.CreateOptions{})
+	// 签发令牌等同于获得该 ServiceAccount 的身份，记录操作日志
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	log := &models.OperationLog{
+		Action:       "create-token",
+		Cluster:      cluster,
+		Kind:         "ServiceAccount",
+		Namespace:    ns,
+		Name:         name,
+		UserName:     username,
+		ActionResult: "success",
+	}
+	if err != nil {
+		log.ActionResult = err.Error()
+	}
+	OperationLogService().Add(log, map[string]any{"expiration_seconds": seconds, "audiences": audiences})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Status, nil
+}
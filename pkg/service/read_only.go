@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm/clause"
+)
+
+// 只读模式状态在共享状态表中的键
+const (
+	readOnlyGlobalKey    = "readonly:global"
+	readOnlyClusterKey   = "readonly:cluster:"
+	readOnlyCacheTTL     = 10 * time.Second // 多实例部署时其他实例最多延迟该时间生效
+	readOnlyTimeLayout   = "2006-01-02 15:04"
+	readOnlyReasonMaxLen = 500
+)
+
+// ReadOnlyState 只读模式设置，全局或单个集群
+type ReadOnlyState struct {
+	Cluster   string     `json:"cluster,omitempty"` // 为空表示全局
+	Enabled   bool       `json:"enabled"`
+	Reason    string     `json:"reason,omitempty"` // 展示给用户的原因，如故障冻结、维护窗口
+	Until     *time.Time `json:"until,omitempty"`  // 到期后自动解除，为空时需手动关闭
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// readOnlyService 只读模式开关，开启后阻止保存、上传、删除、应用、Exec 等全部变更操作，
+// 由 kom 回调统一拦截，中间件对已知的变更接口提前拒绝
+type readOnlyService struct{}
+
+// Set 开启或关闭只读模式，cluster 为空时设置全局开关
+func (r *readOnlyService) Set(state *ReadOnlyState) error {
+	key := readOnlyKey(state.Cluster)
+	defer utils.ClearCacheByKey(CacheService().CacheInstance(), key)
+	if !state.Enabled {
+		return StateStoreService().Delete(key)
+	}
+	if len(state.Reason) > readOnlyReasonMaxLen {
+		state.Reason = state.Reason[:readOnlyReasonMaxLen]
+	}
+	var ttl time.Duration
+	if state.Until != nil {
+		ttl = time.Until(*state.Until)
+		if ttl <= 0 {
+			return StateStoreService().Delete(key)
+		}
+	}
+	state.UpdatedAt = time.Now()
+	return StateStoreService().Put(key, state, ttl)
+}
+
+// List 列出当前生效的全局与集群只读设置
+func (r *readOnlyService) List() ([]*ReadOnlyState, error) {
+	var rows []*models.SharedState
+	key := clause.Column{Name: "key"}
+	err := dao.DB().Where(clause.Or(
+		clause.Eq{Column: key, Value: readOnlyGlobalKey},
+		clause.Like{Column: key, Value: readOnlyClusterKey + "%"},
+	)).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ReadOnlyState, 0, len(rows))
+	for _, row := range rows {
+		if state, _ := r.get(row.Key); state != nil {
+			result = append(result, state)
+		}
+	}
+	return result, nil
+}
+
+// Check 检查集群是否处于只读模式，cluster 为空时只检查全局开关
+func (r *readOnlyService) Check(cluster string) error {
+	if state := r.Status(cluster); state != nil {
+		return readOnlyError(state)
+	}
+	return nil
+}
+
+// Status 返回集群当前生效的只读设置，全局开关优先，未开启时返回 nil
+func (r *readOnlyService) Status(cluster string) *ReadOnlyState {
+	if state := r.cached(readOnlyGlobalKey); state != nil {
+		return state
+	}
+	if cluster == "" {
+		return nil
+	}
+	return r.cached(readOnlyKey(cluster))
+}
+
+// CheckExec 只读模式下仅允许标记为只读的容器命令
+func (r *readOnlyService) CheckExec(ctx context.Context, cluster string) error {
+	if readOnly, _ := ctx.Value(constants.ReadOnlyExec).(bool); readOnly {
+		return nil
+	}
+	return r.Check(cluster)
+}
+
+// WithReadOnlyExec 标记容器命令只读取数据，只读模式下仍可执行
+func WithReadOnlyExec(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constants.ReadOnlyExec, true)
+}
+
+func (r *readOnlyService) cached(key string) *ReadOnlyState {
+	state, _ := utils.GetOrSetCache(CacheService().CacheInstance(), key, readOnlyCacheTTL, func() (*ReadOnlyState, error) {
+		return r.get(key)
+	})
+	// 缓存期间到期的设置立即失效
+	if state == nil || !state.Enabled || (state.Until != nil && state.Until.Before(time.Now())) {
+		return nil
+	}
+	return state
+}
+
+func (r *readOnlyService) get(key string) (*ReadOnlyState, error) {
+	var state ReadOnlyState
+	ok, err := StateStoreService().Get(key, &state)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func readOnlyKey(cluster string) string {
+	if cluster == "" {
+		return readOnlyGlobalKey
+	}
+	return readOnlyClusterKey + cluster
+}
+
+func readOnlyError(state *ReadOnlyState) error {
+	var reason, until any = strings.TrimSpace(state.Reason), i18n.CodeReadOnlyManual
+	if reason == "" {
+		reason = i18n.CodeReadOnlyNoReason
+	}
+	if state.Until != nil {
+		until = state.Until.Local().Format(readOnlyTimeLayout)
+	}
+	if state.Cluster != "" {
+		return i18n.NewError(i18n.CodeClusterReadOnly, state.Cluster, reason, until)
+	}
+	return i18n.NewError(i18n.CodeReadOnly, reason, until)
+}
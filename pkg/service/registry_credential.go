@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils/amis"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/registry"
+	"github.com/weibaohui/kom/kom"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// 同步状态
+const (
+	RegistrySyncStatusSynced = "synced"
+	RegistrySyncStatusFailed = "failed"
+)
+
+// managedByLabel 标记由 k8m 同步的 Secret，便于识别与清理
+const managedByLabel = "k8m.io/managed-by"
+
+type registryCredentialService struct{}
+
+// DockerConfigJSON 生成 kubernetes.io/dockerconfigjson 类型 Secret 的内容
+func (r *registryCredentialService) DockerConfigJSON(cred *models.RegistryCredential) ([]byte, error) {
+	server := registry.NormalizeRegistry(cred.Registry)
+	if server == registry.DockerHub {
+		// kubelet 对 Docker Hub 使用该地址匹配凭据
+		server = "https://index.docker.io/v1/"
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(cred.Username + ":" + cred.Password))
+	return json.Marshal(map[string]any{
+		"auths": map[string]any{
+			server: map[string]string{
+				"username": cred.Username,
+				"password": cred.Password,
+				"auth":     auth,
+			},
+		},
+	})
+}
+
+// Sync 将凭据同步为目标命名空间中的 imagePullSecret，并按需绑定到 ServiceAccount，同步结果写回记录
+func (r *registryCredentialService) Sync(cred *models.RegistryCredential, target *models.RegistryCredentialSync) error {
+	err := r.apply(amis.GetContextForAdmin(), cred, target)
+	now := time.Now()
+	target.SyncedAt = &now
+	target.Status = RegistrySyncStatusSynced
+	target.Message = ""
+	if err != nil {
+		target.Status = RegistrySyncStatusFailed
+		target.Message = err.Error()
+	}
+	if saveErr := dao.DB().Save(target).Error; saveErr != nil {
+		klog.Errorf("保存仓库凭据同步记录失败: %v", saveErr)
+	}
+	return err
+}
+
+func (r *registryCredentialService) apply(ctx context.Context, cred *models.RegistryCredential, target *models.RegistryCredentialSync) error {
+	if kom.Cluster(target.Cluster) == nil {
+		return fmt.Errorf("集群 %s 未连接", target.Cluster)
+	}
+	data, err := r.DockerConfigJSON(cred)
+	if err != nil {
+		return err
+	}
+
+	var secret corev1.Secret
+	err = kom.Cluster(target.Cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.SecretName).Get(&secret).Error
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.SecretName,
+				Namespace: target.Namespace,
+				Labels:    map[string]string{managedByLabel: "k8m"},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: data},
+		}
+		err = kom.Cluster(target.Cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.SecretName).Create(&secret).Error
+		if err != nil {
+			return fmt.Errorf("创建 Secret 失败: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("读取 Secret 失败: %w", err)
+	default:
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			return fmt.Errorf("Secret %s/%s 已存在且类型为 %s，不能覆盖", target.Namespace, target.SecretName, secret.Type)
+		}
+		secret.Data = map[string][]byte{corev1.DockerConfigJsonKey: data}
+		err = kom.Cluster(target.Cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.SecretName).Update(&secret).Error
+		if err != nil {
+			return fmt.Errorf("更新 Secret 失败: %w", err)
+		}
+	}
+
+	if target.ServiceAccount == "" {
+		return nil
+	}
+	var sa corev1.ServiceAccount
+	err = kom.Cluster(target.Cluster).WithContext(ctx).Resource(&sa).Namespace(target.Namespace).Name(target.ServiceAccount).Get(&sa).Error
+	if err != nil {
+		return fmt.Errorf("读取 ServiceAccount 失败: %w", err)
+	}
+	for _, s := range sa.ImagePullSecrets {
+		if s.Name == target.SecretName {
+			return nil
+		}
+	}
+	sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: target.SecretName})
+	err = kom.Cluster(target.Cluster).WithContext(ctx).Resource(&sa).Namespace(target.Namespace).Name(target.ServiceAccount).Update(&sa).Error
+	if err != nil {
+		return fmt.Errorf("绑定 ServiceAccount 失败: %w", err)
+	}
+	return nil
+}
+
+// ResyncAll 凭据修改后，按已有同步记录重新同步
+func (r *registryCredentialService) ResyncAll(credentialID uint) {
+	var cred models.RegistryCredential
+	if err := dao.DB().First(&cred, credentialID).Error; err != nil {
+		klog.V(6).Infof("读取仓库凭据[%d]失败: %v", credentialID, err)
+		return
+	}
+	var targets []*models.RegistryCredentialSync
+	if err := dao.DB().Where("credential_id = ?", credentialID).Find(&targets).Error; err != nil {
+		klog.V(6).Infof("读取仓库凭据[%d]同步记录失败: %v", credentialID, err)
+		return
+	}
+	for _, t := range targets {
+		if err := r.Sync(&cred, t); err != nil {
+			klog.V(4).Infof("仓库凭据[%d]同步到 %s/%s 失败: %v", credentialID, t.Cluster, t.Namespace, err)
+		}
+	}
+}
+
+// DeleteSecret 删除同步到集群中的 Secret，仅删除带有 k8m 标记的 Secret
+func (r *registryCredentialService) DeleteSecret(target *models.RegistryCredentialSync) error {
+	ctx := amis.GetContextForAdmin()
+	if kom.Cluster(target.Cluster) == nil {
+		return fmt.Errorf("集群 %s 未连接", target.Cluster)
+	}
+	var secret corev1.Secret
+	err := kom.Cluster(target.Cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.SecretName).Get(&secret).Error
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if secret.Labels[managedByLabel] != "k8m" {
+		return fmt.Errorf("Secret %s/%s 不是由 k8m 创建的，未删除", target.Namespace, target.SecretName)
+	}
+	return kom.Cluster(target.Cluster).WithContext(ctx).Resource(&secret).Namespace(target.Namespace).Name(target.SecretName).Delete().Error
+}
+
+// 镜像拉取失败的原因分类
+const (
+	PullFailureMissingCredentials = "missing_credentials" // 私有仓库认证失败，且 Pod 未配置该仓库的 imagePullSecret
+	PullFailureInvalidCredentials = "invalid_credentials" // 已配置 imagePullSecret，但认证仍失败
+	PullFailureImageNotFound      = "image_not_found"     // 镜像或标签不存在
+	PullFailureOther              = "other"               // 网络等其他原因
+)
+
+// ImagePullFailure 镜像拉取失败的容器
+type ImagePullFailure struct {
+	Namespace           string `json:"namespace"`
+	Pod                 string `json:"pod"`
+	Workload            string `json:"workload,omitempty"` // 控制者，形如 ReplicaSet/nginx-5d8f7c
+	Container           string `json:"container"`
+	Image               string `json:"image"`
+	Registry            string `json:"registry"`
+	Reason              string `json:"reason"` // ImagePullBackOff 或 ErrImagePull
+	Message             string `json:"message,omitempty"`
+	Cause               string `json:"cause"`
+	HasPullSecret       bool   `json:"has_pull_secret"`                 // Pod 的 imagePullSecrets 中是否有该仓库的凭据
+	SuggestCredentialID uint   `json:"suggest_credential_id,omitempty"` // 平台中可同步到该命名空间的仓库凭据
+}
+
+// PullFailures 查找处于 ImagePullBackOff/ErrImagePull 状态的容器，并分析是否由缺少仓库凭据导致
+func (r *registryCredentialService) PullFailures(ctx context.Context, cluster, namespace string) ([]*ImagePullFailure, error) {
+	var pods []corev1.Pod
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&corev1.Pod{}).Namespace(namespace).List(&pods).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*ImagePullFailure{}
+	for _, pod := range pods {
+		var pullSecrets []string
+		for _, s := range pod.Spec.ImagePullSecrets {
+			pullSecrets = append(pullSecrets, s.Name)
+		}
+		var workload string
+		for _, ref := range pod.OwnerReferences {
+			if ref.Controller != nil && *ref.Controller {
+				workload = ref.Kind + "/" + ref.Name
+			}
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, st := range statuses {
+			if st.State.Waiting == nil {
+				continue
+			}
+			reason := st.State.Waiting.Reason
+			if reason != "ImagePullBackOff" && reason != "ErrImagePull" {
+				continue
+			}
+			item := &ImagePullFailure{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				Workload:  workload,
+				Container: st.Name,
+				Image:     st.Image,
+				Reason:    reason,
+				Message:   st.State.Waiting.Message,
+			}
+			if ref, err := registry.ParseReference(st.Image); err == nil {
+				item.Registry = ref.Registry
+			}
+			item.HasPullSecret = item.Registry != "" && ImageService().HasSecretFor(ctx, cluster, pod.Namespace, item.Registry, pullSecrets)
+			item.Cause = classifyPullFailure(item.Message, item.HasPullSecret)
+			if item.Cause == PullFailureMissingCredentials || item.Cause == PullFailureInvalidCredentials {
+				if cred := ImageService().MatchCredential(cluster, pod.Namespace, item.Registry); cred != nil {
+					item.SuggestCredentialID = cred.ID
+				}
+			}
+			result = append(result, item)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Pod < result[j].Pod
+	})
+	return result, nil
+}
+
+// classifyPullFailure 根据 kubelet 给出的错误信息判断拉取失败原因
+func classifyPullFailure(message string, hasPullSecret bool) string {
+	msg := strings.ToLower(message)
+	authHints := []string{"unauthorized", "authentication required", "no basic auth credentials", "denied", "403 forbidden", "401"}
+	for _, hint := range authHints {
+		if strings.Contains(msg, hint) {
+			if hasPullSecret {
+				return PullFailureInvalidCredentials
+			}
+			return PullFailureMissingCredentials
+		}
+	}
+	if strings.Contains(msg, "not found") || strings.Contains(msg, "manifest unknown") {
+		return PullFailureImageNotFound
+	}
+	return PullFailureOther
+}
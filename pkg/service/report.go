@@ -0,0 +1,476 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/registry"
+	"github.com/weibaohui/k8m/pkg/report"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// 报表类型
+const (
+	ReportInventory = "inventory" // 集群资源清单
+	ReportRBAC      = "rbac"      // RBAC 绑定
+	ReportImages    = "images"    // 镜像及漏洞
+	ReportQuota     = "quota"     // 配额使用
+)
+
+// ReportType 报表类型说明
+type ReportType struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+var reportTypes = []ReportType{
+	{ReportInventory, "资源清单", "节点、命名空间、工作负载、Service、Ingress、PVC"},
+	{ReportRBAC, "RBAC绑定", "RoleBinding 与 ClusterRoleBinding，每个主体一行"},
+	{ReportImages, "镜像清单", "工作负载使用的镜像；集群安装 Trivy Operator 时附带漏洞统计"},
+	{ReportQuota, "配额使用", "ResourceQuota 各资源的已用量、上限与使用率"},
+}
+
+// trivy-operator 生成的镜像漏洞报告
+const (
+	vulnerabilityReportGroup   = "aquasecurity.github.io"
+	vulnerabilityReportVersion = "v1alpha1"
+	vulnerabilityReportKind    = "VulnerabilityReport"
+)
+
+type reportService struct{}
+
+// Types 支持的报表类型
+func (s *reportService) Types() []ReportType {
+	return reportTypes
+}
+
+// ParseTypes 解析逗号分隔的报表类型，为空时返回全部类型
+func (s *reportService) ParseTypes(value string) ([]string, error) {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t == "" {
+			continue
+		}
+		if s.title(t) == "" {
+			return nil, fmt.Errorf("不支持的报表类型: %s", t)
+		}
+		types = append(types, t)
+	}
+	if len(types) == 0 {
+		for _, t := range reportTypes {
+			types = append(types, t.Name)
+		}
+	}
+	return types, nil
+}
+
+func (s *reportService) title(name string) string {
+	for _, t := range reportTypes {
+		if t.Name == name {
+			return t.Title
+		}
+	}
+	return ""
+}
+
+// Generate 生成单个集群的报表，以 ctx 中的用户身份读取资源
+func (s *reportService) Generate(ctx context.Context, cluster, reportType string) (*report.Table, error) {
+	if kom.Cluster(cluster) == nil {
+		return nil, fmt.Errorf("集群 %s 未连接", cluster)
+	}
+	switch reportType {
+	case ReportInventory:
+		return s.inventory(ctx, cluster)
+	case ReportRBAC:
+		return s.rbac(ctx, cluster)
+	case ReportImages:
+		return s.images(ctx, cluster)
+	case ReportQuota:
+		return s.quota(ctx, cluster)
+	}
+	return nil, fmt.Errorf("不支持的报表类型: %s", reportType)
+}
+
+// ReportFile 导出的报表文件
+type ReportFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Export 导出单个集群的报表。xlsx 每种报表一个工作表，合并为一个文件；csv 每种报表一个文件
+func (s *reportService) Export(ctx context.Context, cluster string, types []string, format string) ([]*ReportFile, error) {
+	if format != report.FormatCSV && format != report.FormatXLSX {
+		return nil, fmt.Errorf("不支持的导出格式: %s，仅支持 csv、xlsx", format)
+	}
+	date := time.Now().Format("20060102")
+	var tables []*report.Table
+	for _, t := range types {
+		table, err := s.Generate(ctx, cluster, t)
+		if err != nil {
+			return nil, fmt.Errorf("生成%s报表失败: %w", s.title(t), err)
+		}
+		tables = append(tables, table)
+	}
+
+	var files []*ReportFile
+	if format == report.FormatXLSX {
+		var b bytes.Buffer
+		if err := report.WriteXLSX(&b, tables...); err != nil {
+			return nil, err
+		}
+		name := "report"
+		if len(tables) == 1 {
+			name = tables[0].Name
+		}
+		return append(files, &ReportFile{report.Filename(name, cluster, date, format), report.ContentType(format), b.Bytes()}), nil
+	}
+	for _, table := range tables {
+		var b bytes.Buffer
+		if err := report.WriteCSV(&b, table); err != nil {
+			return nil, err
+		}
+		files = append(files, &ReportFile{report.Filename(table.Name, cluster, date, format), report.ContentType(format), b.Bytes()})
+	}
+	return files, nil
+}
+
+func reportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
+
+func (s *reportService) inventory(ctx context.Context, cluster string) (*report.Table, error) {
+	t := report.NewTable(ReportInventory, s.title(ReportInventory), "类型", "命名空间", "名称", "状态", "详情", "创建时间")
+	k := kom.Cluster(cluster).WithContext(ctx)
+
+	var nodes []*corev1.Node
+	if err := k.Resource(&corev1.Node{}).List(&nodes).Error; err != nil {
+		return nil, fmt.Errorf("读取 Node 失败: %w", err)
+	}
+	for _, n := range nodes {
+		status := "NotReady"
+		for _, c := range n.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+				status = "Ready"
+			}
+		}
+		if n.Spec.Unschedulable {
+			status += ",SchedulingDisabled"
+		}
+		var roles []string
+		for label := range n.Labels {
+			if role, ok := strings.CutPrefix(label, "node-role.kubernetes.io/"); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+		sort.Strings(roles)
+		detail := fmt.Sprintf("%s CPU:%s 内存:%s", n.Status.NodeInfo.KubeletVersion,
+			n.Status.Capacity.Cpu().String(), n.Status.Capacity.Memory().String())
+		if len(roles) > 0 {
+			detail += " 角色:" + strings.Join(roles, ",")
+		}
+		t.Add("Node", "", n.Name, status, detail, reportTime(n.CreationTimestamp.Time))
+	}
+
+	var namespaces []*corev1.Namespace
+	if err := k.Resource(&corev1.Namespace{}).List(&namespaces).Error; err != nil {
+		return nil, fmt.Errorf("读取 Namespace 失败: %w", err)
+	}
+	for _, ns := range namespaces {
+		t.Add("Namespace", "", ns.Name, string(ns.Status.Phase), "", reportTime(ns.CreationTimestamp.Time))
+	}
+
+	var deployments []*appsv1.Deployment
+	if err := k.Resource(&appsv1.Deployment{}).AllNamespace().List(&deployments).Error; err != nil {
+		return nil, fmt.Errorf("读取 Deployment 失败: %w", err)
+	}
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		t.Add("Deployment", d.Namespace, d.Name, fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, desired),
+			"副本 就绪/期望", reportTime(d.CreationTimestamp.Time))
+	}
+	var statefulSets []*appsv1.StatefulSet
+	if err := k.Resource(&appsv1.StatefulSet{}).AllNamespace().List(&statefulSets).Error; err != nil {
+		return nil, fmt.Errorf("读取 StatefulSet 失败: %w", err)
+	}
+	for _, sts := range statefulSets {
+		desired := int32(1)
+		if sts.Spec.Replicas != nil {
+			desired = *sts.Spec.Replicas
+		}
+		t.Add("StatefulSet", sts.Namespace, sts.Name, fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, desired),
+			"副本 就绪/期望", reportTime(sts.CreationTimestamp.Time))
+	}
+	var daemonSets []*appsv1.DaemonSet
+	if err := k.Resource(&appsv1.DaemonSet{}).AllNamespace().List(&daemonSets).Error; err != nil {
+		return nil, fmt.Errorf("读取 DaemonSet 失败: %w", err)
+	}
+	for _, ds := range daemonSets {
+		t.Add("DaemonSet", ds.Namespace, ds.Name, fmt.Sprintf("%d/%d", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled),
+			"Pod 就绪/期望", reportTime(ds.CreationTimestamp.Time))
+	}
+	var cronJobs []*batchv1.CronJob
+	if err := k.Resource(&batchv1.CronJob{}).AllNamespace().List(&cronJobs).Error; err != nil {
+		return nil, fmt.Errorf("读取 CronJob 失败: %w", err)
+	}
+	for _, cj := range cronJobs {
+		status := "Active"
+		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+			status = "Suspended"
+		}
+		t.Add("CronJob", cj.Namespace, cj.Name, status, "调度 "+cj.Spec.Schedule, reportTime(cj.CreationTimestamp.Time))
+	}
+
+	var services []*corev1.Service
+	if err := k.Resource(&corev1.Service{}).AllNamespace().List(&services).Error; err != nil {
+		return nil, fmt.Errorf("读取 Service 失败: %w", err)
+	}
+	for _, svc := range services {
+		var ports []string
+		for _, p := range svc.Spec.Ports {
+			port := fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+			if p.NodePort > 0 {
+				port = fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol)
+			}
+			ports = append(ports, port)
+		}
+		t.Add("Service", svc.Namespace, svc.Name, string(svc.Spec.Type),
+			strings.TrimSpace(svc.Spec.ClusterIP+" "+strings.Join(ports, ",")), reportTime(svc.CreationTimestamp.Time))
+	}
+
+	var ingresses []*networkingv1.Ingress
+	if err := k.Resource(&networkingv1.Ingress{}).AllNamespace().List(&ingresses).Error; err != nil {
+		return nil, fmt.Errorf("读取 Ingress 失败: %w", err)
+	}
+	for _, ing := range ingresses {
+		var hosts []string
+		for _, r := range ing.Spec.Rules {
+			if r.Host != "" {
+				hosts = append(hosts, r.Host)
+			}
+		}
+		class := ""
+		if ing.Spec.IngressClassName != nil {
+			class = *ing.Spec.IngressClassName
+		}
+		t.Add("Ingress", ing.Namespace, ing.Name, class, strings.Join(hosts, ","), reportTime(ing.CreationTimestamp.Time))
+	}
+
+	var pvcs []*corev1.PersistentVolumeClaim
+	if err := k.Resource(&corev1.PersistentVolumeClaim{}).AllNamespace().List(&pvcs).Error; err != nil {
+		return nil, fmt.Errorf("读取 PVC 失败: %w", err)
+	}
+	for _, pvc := range pvcs {
+		class := ""
+		if pvc.Spec.StorageClassName != nil {
+			class = *pvc.Spec.StorageClassName
+		}
+		capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+		t.Add("PersistentVolumeClaim", pvc.Namespace, pvc.Name, string(pvc.Status.Phase),
+			strings.TrimSpace(capacity.String()+" "+class), reportTime(pvc.CreationTimestamp.Time))
+	}
+	return t, nil
+}
+
+func (s *reportService) rbac(ctx context.Context, cluster string) (*report.Table, error) {
+	t := report.NewTable(ReportRBAC, s.title(ReportRBAC), "绑定类型", "命名空间", "绑定名称", "角色类型", "角色名称", "主体类型", "主体命名空间", "主体名称")
+	k := kom.Cluster(cluster).WithContext(ctx)
+
+	var clusterBindings []*rbacv1.ClusterRoleBinding
+	if err := k.Resource(&rbacv1.ClusterRoleBinding{}).List(&clusterBindings).Error; err != nil {
+		return nil, fmt.Errorf("读取 ClusterRoleBinding 失败: %w", err)
+	}
+	var bindings []*rbacv1.RoleBinding
+	if err := k.Resource(&rbacv1.RoleBinding{}).AllNamespace().List(&bindings).Error; err != nil {
+		return nil, fmt.Errorf("读取 RoleBinding 失败: %w", err)
+	}
+
+	add := func(kind, ns, name string, ref rbacv1.RoleRef, subjects []rbacv1.Subject) {
+		if len(subjects) == 0 {
+			t.Add(kind, ns, name, ref.Kind, ref.Name)
+			return
+		}
+		for _, sub := range subjects {
+			t.Add(kind, ns, name, ref.Kind, ref.Name, sub.Kind, sub.Namespace, sub.Name)
+		}
+	}
+	for _, b := range clusterBindings {
+		add("ClusterRoleBinding", "", b.Name, b.RoleRef, b.Subjects)
+	}
+	for _, b := range bindings {
+		add("RoleBinding", b.Namespace, b.Name, b.RoleRef, b.Subjects)
+	}
+	return t, nil
+}
+
+// vulnerabilitySummary 单个镜像的漏洞统计
+type vulnerabilitySummary struct {
+	critical, high, medium, low int64
+	scannedAt                   string
+}
+
+// imageKey 统一镜像地址写法，便于与漏洞报告中的镜像匹配
+func imageKey(server, repository, tag, digest string) string {
+	switch server {
+	case "", "index.docker.io", "registry-1.docker.io":
+		server = registry.DockerHub
+	}
+	if server == registry.DockerHub && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	if digest != "" {
+		return server + "/" + repository + "@" + digest
+	}
+	return server + "/" + repository + ":" + tag
+}
+
+// vulnerabilities 读取 trivy-operator 的漏洞报告，未安装时返回 nil
+func (s *reportService) vulnerabilities(ctx context.Context, cluster string) map[string]*vulnerabilitySummary {
+	var list []*unstructured.Unstructured
+	err := kom.Cluster(cluster).WithContext(ctx).CRD(vulnerabilityReportGroup, vulnerabilityReportVersion, vulnerabilityReportKind).
+		AllNamespace().List(&list).Error
+	if err != nil {
+		klog.V(6).Infof("集群 %s 读取漏洞报告失败，镜像报表不包含漏洞统计: %v", cluster, err)
+		return nil
+	}
+	result := map[string]*vulnerabilitySummary{}
+	for _, item := range list {
+		server, _, _ := unstructured.NestedString(item.Object, "report", "registry", "server")
+		repository, _, _ := unstructured.NestedString(item.Object, "report", "artifact", "repository")
+		tag, _, _ := unstructured.NestedString(item.Object, "report", "artifact", "tag")
+		digest, _, _ := unstructured.NestedString(item.Object, "report", "artifact", "digest")
+		if repository == "" {
+			continue
+		}
+		v := &vulnerabilitySummary{}
+		v.critical, _, _ = unstructured.NestedInt64(item.Object, "report", "summary", "criticalCount")
+		v.high, _, _ = unstructured.NestedInt64(item.Object, "report", "summary", "highCount")
+		v.medium, _, _ = unstructured.NestedInt64(item.Object, "report", "summary", "mediumCount")
+		v.low, _, _ = unstructured.NestedInt64(item.Object, "report", "summary", "lowCount")
+		v.scannedAt, _, _ = unstructured.NestedString(item.Object, "report", "updateTimestamp")
+
+		keys := []string{imageKey(server, repository, tag, "")}
+		if digest != "" {
+			keys = append(keys, imageKey(server, repository, "", digest))
+		}
+		for _, key := range keys {
+			// 同一镜像有多份报告时取最近扫描的结果
+			if old, ok := result[key]; !ok || old.scannedAt < v.scannedAt {
+				result[key] = v
+			}
+		}
+	}
+	return result
+}
+
+func (s *reportService) images(ctx context.Context, cluster string) (*report.Table, error) {
+	t := report.NewTable(ReportImages, s.title(ReportImages), "命名空间", "工作负载类型", "工作负载", "容器", "初始化容器", "镜像",
+		"仓库", "标签", "摘要", "严重", "高危", "中危", "低危", "扫描时间")
+	k := kom.Cluster(cluster).WithContext(ctx)
+	vulns := s.vulnerabilities(ctx, cluster)
+
+	add := func(kind, ns, name string, spec *corev1.PodSpec) {
+		containers := func(list []corev1.Container, init string) {
+			for _, c := range list {
+				row := []string{ns, kind, name, c.Name, init, c.Image}
+				ref, err := registry.ParseReference(c.Image)
+				if err != nil {
+					t.Add(row...)
+					continue
+				}
+				row = append(row, ref.Registry+"/"+ref.Repository, ref.Tag, ref.Digest)
+				if vulns == nil {
+					row = append(row, "", "", "", "", "无漏洞数据")
+				} else {
+					v := vulns[imageKey(ref.Registry, ref.Repository, "", ref.Digest)]
+					if v == nil || ref.Digest == "" {
+						v = vulns[imageKey(ref.Registry, ref.Repository, ref.Tag, "")]
+					}
+					if v != nil {
+						row = append(row, strconv.FormatInt(v.critical, 10), strconv.FormatInt(v.high, 10),
+							strconv.FormatInt(v.medium, 10), strconv.FormatInt(v.low, 10), v.scannedAt)
+					} else {
+						row = append(row, "", "", "", "", "未扫描")
+					}
+				}
+				t.Add(row...)
+			}
+		}
+		containers(spec.InitContainers, "是")
+		containers(spec.Containers, "否")
+	}
+
+	var deployments []*appsv1.Deployment
+	if err := k.Resource(&appsv1.Deployment{}).AllNamespace().List(&deployments).Error; err != nil {
+		return nil, fmt.Errorf("读取 Deployment 失败: %w", err)
+	}
+	for _, d := range deployments {
+		add("Deployment", d.Namespace, d.Name, &d.Spec.Template.Spec)
+	}
+	var statefulSets []*appsv1.StatefulSet
+	if err := k.Resource(&appsv1.StatefulSet{}).AllNamespace().List(&statefulSets).Error; err != nil {
+		return nil, fmt.Errorf("读取 StatefulSet 失败: %w", err)
+	}
+	for _, sts := range statefulSets {
+		add("StatefulSet", sts.Namespace, sts.Name, &sts.Spec.Template.Spec)
+	}
+	var daemonSets []*appsv1.DaemonSet
+	if err := k.Resource(&appsv1.DaemonSet{}).AllNamespace().List(&daemonSets).Error; err != nil {
+		return nil, fmt.Errorf("读取 DaemonSet 失败: %w", err)
+	}
+	for _, ds := range daemonSets {
+		add("DaemonSet", ds.Namespace, ds.Name, &ds.Spec.Template.Spec)
+	}
+	var cronJobs []*batchv1.CronJob
+	if err := k.Resource(&batchv1.CronJob{}).AllNamespace().List(&cronJobs).Error; err != nil {
+		return nil, fmt.Errorf("读取 CronJob 失败: %w", err)
+	}
+	for _, cj := range cronJobs {
+		add("CronJob", cj.Namespace, cj.Name, &cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+	return t, nil
+}
+
+func (s *reportService) quota(ctx context.Context, cluster string) (*report.Table, error) {
+	t := report.NewTable(ReportQuota, s.title(ReportQuota), "命名空间", "ResourceQuota", "资源", "已用", "上限", "使用率(%)")
+	var quotas []*corev1.ResourceQuota
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&corev1.ResourceQuota{}).AllNamespace().List(&quotas).Error; err != nil {
+		return nil, fmt.Errorf("读取 ResourceQuota 失败: %w", err)
+	}
+	for _, q := range quotas {
+		names := make([]string, 0, len(q.Status.Hard))
+		for name := range q.Status.Hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hard := q.Status.Hard[corev1.ResourceName(name)]
+			used := q.Status.Used[corev1.ResourceName(name)]
+			percent := ""
+			if h := hard.AsApproximateFloat64(); h > 0 {
+				percent = strconv.FormatFloat(used.AsApproximateFloat64()*100/h, 'f', 1, 64)
+			}
+			t.Add(q.Namespace, q.Name, name, used.String(), hard.String(), percent)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/plugins/api"
+	"github.com/weibaohui/k8m/pkg/report"
+	"github.com/weibaohui/k8m/pkg/task"
+	"k8s.io/klog/v2"
+)
+
+// reportMaxAttachmentSize 单次发送的附件总大小上限，超出时只发送汇总消息，避免被邮件服务器拒收
+const reportMaxAttachmentSize = 20 << 20
+
+// TaskTypeReport 报表计划对应的异步任务类型
+const TaskTypeReport = "report"
+
+type reportScheduleService struct{}
+
+// splitList 拆分逗号分隔的列表，忽略空项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate 校验并规范化报表计划，启用时按 cron 计算下次执行时间
+func (s *reportScheduleService) Validate(m *models.ReportSchedule) error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return fmt.Errorf("名称不能为空")
+	}
+	clusters := splitList(m.Clusters)
+	if len(clusters) == 0 {
+		return fmt.Errorf("请选择集群")
+	}
+	m.Clusters = strings.Join(clusters, ",")
+	types, err := ReportService().ParseTypes(m.Types)
+	if err != nil {
+		return err
+	}
+	m.Types = strings.Join(types, ",")
+	if m.Format == "" {
+		m.Format = report.FormatXLSX
+	}
+	if m.Format != report.FormatCSV && m.Format != report.FormatXLSX {
+		return fmt.Errorf("不支持的导出格式: %s，仅支持 csv、xlsx", m.Format)
+	}
+	webhooks := splitList(m.Webhooks)
+	if len(webhooks) == 0 {
+		return fmt.Errorf("请选择接收报表的 webhook")
+	}
+	m.Webhooks = strings.Join(webhooks, ",")
+
+	m.Cron = strings.TrimSpace(m.Cron)
+	sched, err := cron.ParseStandard(m.Cron)
+	if err != nil {
+		return fmt.Errorf("cron 表达式格式错误: %w", err)
+	}
+	m.NextRunAt = nil
+	if m.Enabled {
+		next := sched.Next(time.Now())
+		m.NextRunAt = &next
+	}
+	return nil
+}
+
+// Start 每分钟检查到期的报表计划，多实例部署时通过分布式锁保证同一计划只由一个实例执行
+func (s *reportScheduleService) Start() {
+	holder, _ := os.Hostname()
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", func() {
+		var list []*models.ReportSchedule
+		if err := dao.DB().Where("enabled = ? and next_run_at <= ?", true, time.Now()).Find(&list).Error; err != nil {
+			klog.V(6).Infof("读取报表计划失败: %v", err)
+			return
+		}
+		for _, m := range list {
+			ok, err := LockService().TryAcquire(fmt.Sprintf("report-schedule-%d", m.ID), holder, 10*time.Minute)
+			if err != nil || !ok {
+				continue
+			}
+			if _, err := s.Run(m); err != nil {
+				klog.V(6).Infof("报表计划[%d]执行失败: %v", m.ID, err)
+			}
+		}
+	})
+	if err != nil {
+		klog.Errorf("新增报表计划定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+	klog.V(6).Infof("新增报表计划定时任务【@every 1m】")
+}
+
+// Run 以创建人身份提交异步任务生成并发送报表，同时推进下次执行时间
+func (s *reportScheduleService) Run(m *models.ReportSchedule) (*models.Task, error) {
+	now := time.Now()
+	updates := map[string]any{"last_run_at": now}
+	if sched, err := cron.ParseStandard(m.Cron); err == nil && m.Enabled {
+		updates["next_run_at"] = sched.Next(now)
+	}
+	if err := dao.DB().Model(&models.ReportSchedule{}).Where("id = ?", m.ID).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("更新报表计划失败: %w", err)
+	}
+
+	ctx := context.WithValue(context.Background(), constants.JwtUserName, m.CreatedBy)
+	cluster := ""
+	if clusters := splitList(m.Clusters); len(clusters) == 1 {
+		cluster = clusters[0]
+	}
+	t, err := TaskService().Submit(ctx, TaskTypeReport, "报表计划 "+m.Name, cluster, func(run *task.Run) (string, error) {
+		message, err := s.execute(run, m)
+		status := "success"
+		if err != nil {
+			status, message = "failed", err.Error()
+		}
+		if uErr := dao.DB().Model(&models.ReportSchedule{}).Where("id = ?", m.ID).
+			Updates(map[string]any{"last_status": status, "last_message": message}).Error; uErr != nil {
+			klog.V(6).Infof("保存报表计划[%d]结果失败: %v", m.ID, uErr)
+		}
+		return message, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := dao.DB().Model(&models.ReportSchedule{}).Where("id = ?", m.ID).Update("last_task_id", t.ID).Error; err != nil {
+		klog.V(6).Infof("保存报表计划[%d]任务失败: %v", m.ID, err)
+	}
+	return t, nil
+}
+
+// reportDelivery 发送给 webhook 的原始数据
+type reportDelivery struct {
+	Schedule string   `json:"schedule"`
+	Types    []string `json:"types"`
+	Files    []string `json:"files"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// execute 逐个集群生成报表后一次性发送，部分集群失败时仍发送其余集群的报表并在消息中说明
+func (s *reportScheduleService) execute(run *task.Run, m *models.ReportSchedule) (string, error) {
+	clusters := splitList(m.Clusters)
+	types, err := ReportService().ParseTypes(m.Types)
+	if err != nil {
+		return "", err
+	}
+	delivery := &reportDelivery{Schedule: m.Name, Types: types}
+	var attachments []api.Attachment
+	size := 0
+	for i, cluster := range clusters {
+		if err := run.Context().Err(); err != nil {
+			return "", err
+		}
+		run.Progress(i*90/len(clusters), "生成集群 %s 的报表", cluster)
+		files, err := ReportService().Export(run.Context(), cluster, types, m.Format)
+		if err != nil {
+			run.Logf("集群 %s 报表生成失败: %v", cluster, err)
+			delivery.Errors = append(delivery.Errors, fmt.Sprintf("%s: %v", cluster, err))
+			continue
+		}
+		for _, f := range files {
+			run.Logf("生成 %s（%d 字节）", f.Filename, len(f.Data))
+			attachments = append(attachments, api.Attachment{Filename: f.Filename, ContentType: f.ContentType, Data: f.Data})
+			delivery.Files = append(delivery.Files, f.Filename)
+			size += len(f.Data)
+		}
+	}
+
+	titles := make([]string, 0, len(types))
+	for _, t := range types {
+		titles = append(titles, ReportService().title(t))
+	}
+	lines := []string{
+		fmt.Sprintf("[k8m 报表] %s", m.Name),
+		fmt.Sprintf("报表：%s，集群：%s", strings.Join(titles, "、"), strings.Join(clusters, "、")),
+	}
+	if len(delivery.Files) > 0 {
+		lines = append(lines, "文件："+strings.Join(delivery.Files, "、"))
+	}
+	if size > reportMaxAttachmentSize {
+		run.Logf("附件共 %d 字节，超过 %d 字节上限，只发送汇总消息", size, reportMaxAttachmentSize)
+		lines = append(lines, fmt.Sprintf("附件超过 %d MB，未随消息发送，请在 k8m 中导出", reportMaxAttachmentSize>>20))
+		attachments = nil
+	}
+	for _, e := range delivery.Errors {
+		lines = append(lines, "生成失败 "+e)
+	}
+
+	run.Progress(95, "发送报表")
+	raw, _ := json.Marshal(delivery)
+	results := api.WebhookService().PushMsgWithAttachmentsByIDs(strings.Join(lines, "\n"), string(raw), attachments, splitList(m.Webhooks))
+	sent, failed := 0, 0
+	for _, r := range results {
+		if r != nil && r.Status == "success" {
+			sent++
+			continue
+		}
+		failed++
+		if r != nil {
+			run.Logf("发送失败: %s", r.RespBody)
+		}
+	}
+	message := fmt.Sprintf("生成 %d 个文件，%d 个集群失败；发送成功 %d 个，失败 %d 个", len(delivery.Files), len(delivery.Errors), sent, failed)
+	switch {
+	case len(delivery.Files) == 0:
+		return "", fmt.Errorf("所有集群的报表生成失败: %s", strings.Join(delivery.Errors, "；"))
+	case sent == 0:
+		return "", fmt.Errorf("报表发送失败，请检查 webhook 插件是否启用及接收者配置: %s", message)
+	}
+	return message, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// 资源健康状态
+const (
+	HealthHealthy     = "Healthy"
+	HealthProgressing = "Progressing"
+	HealthDegraded    = "Degraded"
+	HealthMissing     = "Missing"
+	HealthUnknown     = "Unknown"
+)
+
+// ResourceHealth 根据资源状态判断健康情况：工作负载比较就绪副本数，Pod 检查阶段与容器状态，
+// 其他资源依据 Ready、Available 等 condition，没有状态信息的资源视为健康
+func ResourceHealth(obj *unstructured.Unstructured) (string, string) {
+	if obj.GetDeletionTimestamp() != nil {
+		return HealthProgressing, "正在删除"
+	}
+	switch obj.GetKind() {
+	case "Pod":
+		return podHealth(obj)
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		return replicaHealth(ready, desired)
+	case "DaemonSet":
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		return replicaHealth(ready, desired)
+	case "Job":
+		if status, msg, ok := findCondition(obj, "Failed"); ok && status == "True" {
+			return HealthDegraded, msg
+		}
+		if status, _, ok := findCondition(obj, "Complete"); ok && status == "True" {
+			return HealthHealthy, "已完成"
+		}
+		return HealthProgressing, "运行中"
+	case "PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Bound":
+			return HealthHealthy, phase
+		case "Lost":
+			return HealthDegraded, phase
+		}
+		return HealthProgressing, phase
+	}
+
+	for _, condType := range []string{"Ready", "Available"} {
+		if status, msg, ok := findCondition(obj, condType); ok {
+			switch status {
+			case "True":
+				return HealthHealthy, ""
+			case "False":
+				return HealthDegraded, msg
+			}
+			return HealthUnknown, msg
+		}
+	}
+	return HealthHealthy, ""
+}
+
+func replicaHealth(ready, desired int64) (string, string) {
+	msg := fmt.Sprintf("就绪 %d/%d", ready, desired)
+	switch {
+	case ready >= desired:
+		return HealthHealthy, msg
+	case ready == 0:
+		return HealthDegraded, msg
+	}
+	return HealthProgressing, msg
+}
+
+func podHealth(obj *unstructured.Unstructured) (string, string) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+	for _, s := range statuses {
+		cs, _ := s.(map[string]any)
+		reason, _, _ := unstructured.NestedString(cs, "state", "waiting", "reason")
+		if reason == "CrashLoopBackOff" || strings.HasSuffix(reason, "Error") || reason == "ImagePullBackOff" {
+			name, _, _ := unstructured.NestedString(cs, "name")
+			return HealthDegraded, fmt.Sprintf("容器 %s: %s", name, reason)
+		}
+	}
+	switch phase {
+	case "Running":
+		if status, msg, ok := findCondition(obj, "Ready"); ok && status != "True" {
+			return HealthProgressing, msg
+		}
+		return HealthHealthy, phase
+	case "Succeeded":
+		return HealthHealthy, phase
+	case "Failed":
+		return HealthDegraded, phase
+	}
+	return HealthProgressing, phase
+}
+
+// findCondition 查找 status.conditions 中指定类型的状态与说明
+func findCondition(obj *unstructured.Unstructured, condType string) (status, message string, found bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, _ := c.(map[string]any)
+		if t, _ := cond["type"].(string); t == condType {
+			status, _ = cond["status"].(string)
+			message, _ = cond["message"].(string)
+			if message == "" {
+				message, _ = cond["reason"].(string)
+			}
+			return status, message, true
+		}
+	}
+	return "", "", false
+}
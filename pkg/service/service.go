@@ -33,6 +33,50 @@ var localUserService = &userService{
 var localOperationLogService = NewOperationLogService()
 var localShellLogService = &shellLogService{}
 var localLeaderService = &leaderService{}
+var localManifestService = &manifestService{}
+var localLockService = &lockService{}
+var localStateStoreService = &stateStoreService{}
+var localListCacheService = &listCacheService{}
+var localImageService = &imageService{}
+var localRegistryCredentialService = &registryCredentialService{}
+var localOOMTrackerService = &oomTrackerService{}
+var localTimelineService = &timelineService{}
+var localDriftService = &driftService{}
+var localAdmissionPolicyService = &admissionPolicyService{}
+var localRBACService = &rbacService{}
+var localCommandPolicyService = &commandPolicyService{}
+var localFileChunkService = &fileChunkService{}
+var localFileTrashService = &fileTrashService{}
+var localObjectStorageService = &objectStorageService{}
+var localFileWatchService = &fileWatchService{}
+var localUploadStagingService = &uploadStagingService{}
+var localUserPreferenceService = &userPreferenceService{}
+var localUserResourceService = &userResourceService{}
+var localReadOnlyService = &readOnlyService{}
+var localCapabilityService = &capabilityService{}
+var localClusterAgentService = &clusterAgentService{}
+var localApprovalService = &approvalService{}
+var localChangeSetService = &changeSetService{}
+var localContainerEnvService = &containerEnvService{}
+var localClusterComponentService = &clusterComponentService{}
+var localUpgradeService = &upgradeService{}
+var localProjectService = &projectService{}
+var localEncryptionService = &encryptionService{}
+var localAccessRuleService = &accessRuleService{}
+var localInboundWebhookService = &inboundWebhookService{}
+var localTaskService = newTaskService()
+var localReportService = &reportService{}
+var localReportScheduleService = &reportScheduleService{}
+var localSupportBundleService = &supportBundleService{}
+var localPodIndexService = &podIndexService{}
+var localStatefulSetService = &statefulSetService{}
+var localCRConditionService = &crConditionService{}
+var localClockService = &clockService{}
+var localArtifactService = &artifactService{}
+var localGraphQLService = &graphQLService{}
+var localNsCloneService = &nsCloneService{}
+var localHibernationService = &hibernationService{}
+var localRateLimitService = &rateLimitService{}
 
 // init 中文函数注释：在 service 初始化时向 lease 包注入 ClusterID → RestConfig 的解析器，避免循环引入。
 func init() {
@@ -50,6 +94,221 @@ func LeaderService() *leaderService {
 	return localLeaderService
 }
 
+// LockService 获取基于数据库的分布式锁服务
+func LockService() *lockService {
+	return localLockService
+}
+
+// StateStoreService 获取多实例共享状态存储服务
+func StateStoreService() *stateStoreService {
+	return localStateStoreService
+}
+
+// ListCacheService 获取列表接口响应缓存服务
+func ListCacheService() *listCacheService {
+	return localListCacheService
+}
+
+// ImageService 获取镜像元数据查询服务
+func ImageService() *imageService {
+	return localImageService
+}
+
+// RegistryCredentialService 获取镜像仓库凭据同步服务
+func RegistryCredentialService() *registryCredentialService {
+	return localRegistryCredentialService
+}
+
+// OOMTrackerService 获取 OOMKilled 与驱逐记录采集服务
+func OOMTrackerService() *oomTrackerService {
+	return localOOMTrackerService
+}
+
+// TimelineService 获取命名空间变更时间线服务
+func TimelineService() *timelineService {
+	return localTimelineService
+}
+
+// DriftService 获取基线漂移检测服务
+func DriftService() *driftService {
+	return localDriftService
+}
+
+// AdmissionPolicyService 获取平台准入策略服务
+func AdmissionPolicyService() *admissionPolicyService {
+	return localAdmissionPolicyService
+}
+
+// RBACService 获取 RBAC 权限分析服务
+func RBACService() *rbacService {
+	return localRBACService
+}
+
+// CommandPolicyService 获取容器命令策略服务
+func CommandPolicyService() *commandPolicyService {
+	return localCommandPolicyService
+}
+
+// FileChunkService 获取文件分片保存服务
+func FileChunkService() *fileChunkService {
+	return localFileChunkService
+}
+
+// FileTrashService 获取文件回收站服务
+func FileTrashService() *fileTrashService {
+	return localFileTrashService
+}
+
+// ObjectStorageService 获取对象存储传输服务
+func ObjectStorageService() *objectStorageService {
+	return localObjectStorageService
+}
+
+// FileWatchService 获取容器文件监视服务
+func FileWatchService() *fileWatchService {
+	return localFileWatchService
+}
+
+// UploadStagingService 获取上传暂存服务
+func UploadStagingService() *uploadStagingService {
+	return localUploadStagingService
+}
+
+// UserPreferenceService 获取用户偏好服务
+func UserPreferenceService() *userPreferenceService {
+	return localUserPreferenceService
+}
+
+// UserResourceService 获取收藏与最近访问资源服务
+func UserResourceService() *userResourceService {
+	return localUserResourceService
+}
+
+// ReadOnlyService 获取只读模式服务
+func ReadOnlyService() *readOnlyService {
+	return localReadOnlyService
+}
+
+// RateLimitService 获取按用户、按集群限制 apiserver 访问频率的服务
+func RateLimitService() *rateLimitService {
+	return localRateLimitService
+}
+
+// CapabilityService 获取集群能力探测与功能开关服务
+func CapabilityService() *capabilityService {
+	return localCapabilityService
+}
+
+// ClusterAgentService 获取 agent 接入令牌服务
+func ClusterAgentService() *clusterAgentService {
+	return localClusterAgentService
+}
+
+// ApprovalService 获取变更审批服务
+func ApprovalService() *approvalService {
+	return localApprovalService
+}
+
+// ChangeSetService 获取变更集计划应用服务
+func ChangeSetService() *changeSetService {
+	return localChangeSetService
+}
+
+// ContainerEnvService 获取容器环境变量解析与修改服务
+func ContainerEnvService() *containerEnvService {
+	return localContainerEnvService
+}
+
+// ClusterComponentService 获取集群核心组件配置服务
+func ClusterComponentService() *clusterComponentService {
+	return localClusterComponentService
+}
+
+// UpgradeService 获取集群升级检查服务
+func UpgradeService() *upgradeService {
+	return localUpgradeService
+}
+
+// ProjectService 获取项目（团队）服务
+func ProjectService() *projectService {
+	return localProjectService
+}
+
+// EncryptionService 获取敏感字段加密状态与主密钥轮换服务
+func EncryptionService() *encryptionService {
+	return localEncryptionService
+}
+
+// AccessRuleService 获取访问来源与时间段限制服务
+func AccessRuleService() *accessRuleService {
+	return localAccessRuleService
+}
+
+// InboundWebhookService 获取入站 webhook 服务
+func InboundWebhookService() *inboundWebhookService {
+	return localInboundWebhookService
+}
+
+// TaskService 获取异步任务服务
+func TaskService() *taskService {
+	return localTaskService
+}
+
+// ReportService 获取报表服务
+func ReportService() *reportService {
+	return localReportService
+}
+
+// ReportScheduleService 获取报表计划服务
+func ReportScheduleService() *reportScheduleService {
+	return localReportScheduleService
+}
+
+// SupportBundleService 获取集群支持包服务
+func SupportBundleService() *supportBundleService {
+	return localSupportBundleService
+}
+
+// PodIndexService 获取 Pod 反向索引服务
+func PodIndexService() *podIndexService {
+	return localPodIndexService
+}
+
+// StatefulSetService 获取 StatefulSet 按序运维服务
+func StatefulSetService() *statefulSetService {
+	return localStatefulSetService
+}
+
+// CRConditionService 获取自定义资源状态看板服务
+func CRConditionService() *crConditionService {
+	return localCRConditionService
+}
+
+// ClockService 获取时钟偏差检查服务
+func ClockService() *clockService {
+	return localClockService
+}
+
+// ArtifactService 获取制品存储服务
+func ArtifactService() *artifactService {
+	return localArtifactService
+}
+
+// GraphQLService 获取 GraphQL 查询服务
+func GraphQLService() *graphQLService {
+	return localGraphQLService
+}
+
+// NsCloneService 获取命名空间克隆服务
+func NsCloneService() *nsCloneService {
+	return localNsCloneService
+}
+
+// HibernationService 获取环境休眠服务
+func HibernationService() *hibernationService {
+	return localHibernationService
+}
+
 func DeploymentService() *deployService {
 	return localDeploymentService
 }
@@ -89,6 +348,11 @@ func ShellLogService() *shellLogService {
 	return localShellLogService
 }
 
+// ManifestService 获取YAML清单解析、预演与应用服务实例。
+func ManifestService() *manifestService {
+	return localManifestService
+}
+
 func ConfigService() *configService {
 	return NewConfigService()
 }
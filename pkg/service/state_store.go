@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm/clause"
+	"k8s.io/klog/v2"
+)
+
+// stateStoreService 多实例共享的键值状态存储，存放在数据库中。
+// 用于 OIDC 登录 state、集群只读与功能开关等需要在负载均衡后的任意实例上都能读取的状态。
+// 上传暂存与分片上传的文件保存在处理请求的实例本地，不经过该存储，多实例部署时需开启会话保持。
+type stateStoreService struct {
+}
+
+// Put 写入状态，ttl 为 0 表示不过期
+func (s *stateStoreService) Put(key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	state := &models.SharedState{
+		Key:   key,
+		Value: string(data),
+	}
+	if ttl > 0 {
+		state.ExpiresAt = time.Now().Add(ttl)
+	}
+	return dao.DB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at", "updated_at"}),
+	}).Create(state).Error
+}
+
+// PutIfAbsent 仅在 key 不存在或已过期时写入，返回是否写入成功，多个实例同时写入时只有一个成功
+func (s *stateStoreService) PutIfAbsent(key string, value any, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if err := dao.DB().Where(&models.SharedState{Key: key}).Where("expires_at > ? AND expires_at < ?", time.Time{}, now).Delete(&models.SharedState{}).Error; err != nil {
+		return false, err
+	}
+	state := &models.SharedState{
+		Key:   key,
+		Value: string(data),
+	}
+	if ttl > 0 {
+		state.ExpiresAt = now.Add(ttl)
+	}
+	result := dao.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(state)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Get 读取状态并反序列化到 out，不存在或已过期时返回 false
+func (s *stateStoreService) Get(key string, out any) (bool, error) {
+	var state models.SharedState
+	result := dao.DB().Where(&models.SharedState{Key: key}).Limit(1).Find(&state)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	if !state.ExpiresAt.IsZero() && state.ExpiresAt.Before(time.Now()) {
+		_ = s.Delete(key)
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(state.Value), out); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete 删除状态
+func (s *stateStoreService) Delete(key string) error {
+	return dao.DB().Delete(&models.SharedState{Key: key}).Error
+}
+
+// CleanExpired 清理已过期的状态，由 Leader 实例定期执行
+func (s *stateStoreService) CleanExpired() {
+	result := dao.DB().Where("expires_at > ? AND expires_at < ?", time.Time{}, time.Now()).Delete(&models.SharedState{})
+	if result.Error != nil {
+		klog.Errorf("清理过期共享状态失败: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		klog.V(6).Infof("已清理过期共享状态 %d 条", result.RowsAffected)
+	}
+}
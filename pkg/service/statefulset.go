@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/task"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// 逐个重启时每个 Pod 等待就绪的时间
+const (
+	stsRestartDefaultWait = 10 * time.Minute
+	stsRestartMaxWait     = time.Hour
+	stsRestartPoll        = 3 * time.Second
+)
+
+type statefulSetService struct{}
+
+// StatefulSetPod StatefulSet 管理的单个 Pod
+type StatefulSetPod struct {
+	Name     string `json:"name"`
+	Ordinal  int    `json:"ordinal"`
+	Revision string `json:"revision"`
+	Updated  bool   `json:"updated"` // 已是目标版本
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Node     string `json:"node,omitempty"`
+}
+
+// StatefulSetRevisionCount 某个版本上的 Pod
+type StatefulSetRevisionCount struct {
+	Revision string `json:"revision"`
+	Current  bool   `json:"current"` // status.currentRevision
+	Update   bool   `json:"update"`  // status.updateRevision，即目标版本
+	Pods     int    `json:"pods"`
+	Ordinals []int  `json:"ordinals"`
+}
+
+// StatefulSetRevisionStatus StatefulSet 的更新策略、分区与各版本 Pod 分布
+type StatefulSetRevisionStatus struct {
+	Namespace           string                      `json:"namespace"`
+	Name                string                      `json:"name"`
+	Strategy            string                      `json:"strategy"`
+	PodManagementPolicy string                      `json:"pod_management_policy"`
+	Partition           int32                       `json:"partition"` // 序号不小于分区的 Pod 才会更新到目标版本
+	Replicas            int32                       `json:"replicas"`
+	ReadyReplicas       int32                       `json:"ready_replicas"`
+	UpdatedReplicas     int32                       `json:"updated_replicas"`
+	CurrentRevision     string                      `json:"current_revision"`
+	UpdateRevision      string                      `json:"update_revision"`
+	Revisions           []*StatefulSetRevisionCount `json:"revisions"`
+	Pods                []*StatefulSetPod           `json:"pods"`
+}
+
+// StatefulSetRestartOptions 逐个重启参数
+type StatefulSetRestartOptions struct {
+	Reverse     bool `json:"reverse"`      // 为 true 时从最大序号开始
+	WaitSeconds int  `json:"wait_seconds"` // 每个 Pod 重建后等待就绪的最长时间，默认 600，最大 3600
+}
+
+func (s *statefulSetService) get(ctx context.Context, cluster, ns, name string) (*appsv1.StatefulSet, error) {
+	var sts appsv1.StatefulSet
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&sts).Namespace(ns).Name(name).Get(&sts).Error; err != nil {
+		return nil, err
+	}
+	return &sts, nil
+}
+
+// pods 返回 StatefulSet 控制的 Pod，按序号升序
+func (s *statefulSetService) pods(ctx context.Context, cluster string, sts *appsv1.StatefulSet) ([]*StatefulSetPod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("解析 StatefulSet 选择器失败: %w", err)
+	}
+	var list []v1.Pod
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(sts.Namespace).
+		WithLabelSelector(selector.String()).List(&list).Error; err != nil {
+		return nil, err
+	}
+	var result []*StatefulSetPod
+	for i := range list {
+		pod := &list[i]
+		ref := metav1.GetControllerOf(pod)
+		if ref == nil || ref.UID != sts.UID {
+			continue
+		}
+		ordinal, err := strconv.Atoi(strings.TrimPrefix(pod.Name, sts.Name+"-"))
+		if err != nil {
+			continue
+		}
+		revision := pod.Labels[appsv1.StatefulSetRevisionLabel]
+		result = append(result, &StatefulSetPod{
+			Name:     pod.Name,
+			Ordinal:  ordinal,
+			Revision: revision,
+			Updated:  revision != "" && revision == sts.Status.UpdateRevision,
+			Phase:    string(pod.Status.Phase),
+			Ready:    podReady(pod),
+			Node:     pod.Spec.NodeName,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Ordinal < result[j].Ordinal })
+	return result, nil
+}
+
+// Revisions 返回更新策略、分区以及各版本上的 Pod 分布
+func (s *statefulSetService) Revisions(ctx context.Context, cluster, ns, name string) (*StatefulSetRevisionStatus, error) {
+	sts, err := s.get(ctx, cluster, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.pods(ctx, cluster, sts)
+	if err != nil {
+		return nil, err
+	}
+	status := &StatefulSetRevisionStatus{
+		Namespace:           ns,
+		Name:                name,
+		Strategy:            string(sts.Spec.UpdateStrategy.Type),
+		PodManagementPolicy: string(sts.Spec.PodManagementPolicy),
+		ReadyReplicas:       sts.Status.ReadyReplicas,
+		UpdatedReplicas:     sts.Status.UpdatedReplicas,
+		CurrentRevision:     sts.Status.CurrentRevision,
+		UpdateRevision:      sts.Status.UpdateRevision,
+		Pods:                pods,
+	}
+	if sts.Spec.Replicas != nil {
+		status.Replicas = *sts.Spec.Replicas
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		status.Partition = *ru.Partition
+	}
+	byRevision := map[string]*StatefulSetRevisionCount{}
+	for _, rev := range []string{sts.Status.CurrentRevision, sts.Status.UpdateRevision} {
+		if rev != "" && byRevision[rev] == nil {
+			byRevision[rev] = &StatefulSetRevisionCount{Revision: rev, Ordinals: []int{}}
+			status.Revisions = append(status.Revisions, byRevision[rev])
+		}
+	}
+	for _, pod := range pods {
+		rc := byRevision[pod.Revision]
+		if rc == nil {
+			rc = &StatefulSetRevisionCount{Revision: pod.Revision}
+			byRevision[pod.Revision] = rc
+			status.Revisions = append(status.Revisions, rc)
+		}
+		rc.Pods++
+		rc.Ordinals = append(rc.Ordinals, pod.Ordinal)
+	}
+	for _, rc := range status.Revisions {
+		rc.Current = rc.Revision == sts.Status.CurrentRevision
+		rc.Update = rc.Revision == sts.Status.UpdateRevision
+	}
+	return status, nil
+}
+
+// SetPartition 设置滚动更新分区，序号不小于 partition 的 Pod 更新到目标版本，其余保持当前版本。
+// 逐步调小分区即可按序号从大到小分批发布，OnDelete 策略不支持分区
+func (s *statefulSetService) SetPartition(ctx context.Context, cluster, ns, name string, partition int32) (*StatefulSetRevisionStatus, error) {
+	sts, err := s.get(ctx, cluster, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return nil, fmt.Errorf("StatefulSet %s/%s 使用 OnDelete 更新策略，不支持分区", ns, name)
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if partition < 0 || partition > replicas {
+		return nil, fmt.Errorf("分区须在 0 到副本数 %d 之间", replicas)
+	}
+	patch := fmt.Sprintf(`{"spec":{"updateStrategy":{"type":"RollingUpdate","rollingUpdate":{"partition":%d}}}}`, partition)
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(sts).Namespace(ns).Name(name).
+		Patch(sts, types.MergePatchType, patch).Error; err != nil {
+		return nil, err
+	}
+	return s.Revisions(ctx, cluster, ns, name)
+}
+
+// OrphanDelete 以 Orphan 级联策略删除 StatefulSet，Pod 与 PVC 保留继续运行。
+// 返回删除前的定义（已去除状态与服务端字段），修改后重新创建即可接管原有 Pod，用于变更不可修改的字段或手动分批发布
+func (s *statefulSetService) OrphanDelete(ctx context.Context, cluster, ns, name string) (string, error) {
+	sts, err := s.get(ctx, cluster, ns, name)
+	if err != nil {
+		return "", err
+	}
+	manifest := sts.DeepCopy()
+	manifest.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"}
+	manifest.ObjectMeta = metav1.ObjectMeta{
+		Name:        sts.Name,
+		Namespace:   sts.Namespace,
+		Labels:      sts.Labels,
+		Annotations: sts.Annotations,
+	}
+	delete(manifest.Annotations, v1.LastAppliedConfigAnnotation)
+	manifest.Status = appsv1.StatefulSetStatus{}
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	delCtx := context.WithValue(ctx, constants.DeletePropagation, metav1.DeletePropagationOrphan)
+	if err := kom.Cluster(cluster).WithContext(delCtx).Resource(&appsv1.StatefulSet{}).Namespace(ns).Name(name).
+		Delete().Error; err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Restart 在异步任务中按序号逐个删除 Pod，等待 StatefulSet 重建的 Pod 就绪后再处理下一个；
+// 某个 Pod 超时未就绪时停止，避免同时有多个副本不可用
+func (s *statefulSetService) Restart(run *task.Run, cluster, ns, name string, opts *StatefulSetRestartOptions) (string, error) {
+	ctx := run.Context()
+	sts, err := s.get(ctx, cluster, ns, name)
+	if err != nil {
+		return "", err
+	}
+	pods, err := s.pods(ctx, cluster, sts)
+	if err != nil {
+		return "", err
+	}
+	if len(pods) == 0 {
+		return "没有需要重启的 Pod", nil
+	}
+	if opts.Reverse {
+		sort.Slice(pods, func(i, j int) bool { return pods[i].Ordinal > pods[j].Ordinal })
+	}
+	wait := stsRestartDefaultWait
+	if opts.WaitSeconds > 0 {
+		wait = min(time.Duration(opts.WaitSeconds)*time.Second, stsRestartMaxWait)
+	}
+	if sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		run.Logf("注意：StatefulSet 正在更新（当前版本 %s，目标版本 %s），重建的 Pod 将按分区设置使用对应版本",
+			sts.Status.CurrentRevision, sts.Status.UpdateRevision)
+	}
+
+	for i, p := range pods {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		run.Progress(i*100/len(pods), "正在重启 %s（%d/%d）", p.Name, i+1, len(pods))
+		if !p.Ready {
+			run.Logf("Pod %s 重启前未就绪", p.Name)
+		}
+		var pod v1.Pod
+		if err := kom.Cluster(cluster).WithContext(ctx).Resource(&pod).Namespace(ns).Name(p.Name).Get(&pod).Error; err != nil {
+			return "", fmt.Errorf("读取 Pod %s 失败: %w", p.Name, err)
+		}
+		if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(ns).Name(p.Name).Delete().Error; err != nil {
+			return "", fmt.Errorf("删除 Pod %s 失败: %w", p.Name, err)
+		}
+		run.Logf("已删除 Pod %s，等待重建并就绪", p.Name)
+		start := time.Now()
+		if err := s.waitRecreated(ctx, cluster, ns, p.Name, pod.UID, wait); err != nil {
+			return "", fmt.Errorf("%w，已停止，剩余 %d 个 Pod 未重启", err, len(pods)-i-1)
+		}
+		run.Logf("Pod %s 已就绪，耗时 %s", p.Name, time.Since(start).Round(time.Second))
+	}
+	return fmt.Sprintf("已按序重启 %d 个 Pod", len(pods)), nil
+}
+
+// waitRecreated 等待同名的新 Pod（UID 不同）就绪
+func (s *statefulSetService) waitRecreated(ctx context.Context, cluster, ns, name string, oldUID types.UID, wait time.Duration) error {
+	deadline := time.Now().Add(wait)
+	for {
+		var pod v1.Pod
+		err := kom.Cluster(cluster).WithContext(ctx).Resource(&pod).Namespace(ns).Name(name).Get(&pod).Error
+		switch {
+		case err == nil && pod.UID != oldUID && podReady(&pod):
+			return nil
+		case err != nil && !apierrors.IsNotFound(err) && ctx.Err() == nil:
+			return fmt.Errorf("读取 Pod %s 失败: %w", name, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Pod %s 在 %s 内未就绪", name, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(stsRestartPoll):
+		}
+	}
+}
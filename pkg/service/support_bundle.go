@@ -0,0 +1,424 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/bundle"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	supportBundleDefaultSince = 24 * time.Hour
+	supportBundleMaxSince     = 7 * 24 * time.Hour
+	supportBundleDefaultTail  = 500
+	supportBundleMaxTail      = 5000
+	supportBundleLogLimit     = 5 * 1024 * 1024   // 单个控制面日志上限
+	supportBundleLimit        = 200 * 1024 * 1024 // 支持包内容总大小上限
+	supportBundleMaxEvents    = 2000
+	supportBundleMaxAudit     = 2000 // 每类审计记录的条数上限
+	supportBundleMaxFailing   = 1000
+)
+
+// SupportBundleOptions 集群支持包收集选项
+type SupportBundleOptions struct {
+	Since     time.Duration // 事件与审计记录的时间范围，0 为默认 24 小时
+	TailLines int64         // 每个控制面容器读取的日志行数，0 为默认值
+}
+
+// NodeConditionSummary 节点状态与异常条件
+type NodeConditionSummary struct {
+	Name           string             `json:"name"`
+	Ready          string             `json:"ready"`
+	Unschedulable  bool               `json:"unschedulable,omitempty"`
+	KubeletVersion string             `json:"kubelet_version"`
+	Taints         []string           `json:"taints,omitempty"`
+	Problems       []string           `json:"problems,omitempty"` // 未就绪或压力类条件为 True 的原因
+	Conditions     []v1.NodeCondition `json:"conditions"`
+}
+
+// FailingWorkload 未达到期望状态的工作负载或 Pod
+type FailingWorkload struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// SupportBundleManifest 支持包说明，位于包内 manifest.json
+type SupportBundleManifest struct {
+	Cluster       string    `json:"cluster"`
+	ServerVersion string    `json:"server_version,omitempty"`
+	CollectedAt   time.Time `json:"collected_at"`
+	CollectedBy   string    `json:"collected_by,omitempty"`
+	Since         time.Time `json:"since"`
+	TailLines     int64     `json:"tail_lines"`
+	Files         []string  `json:"files"`
+	Errors        []string  `json:"errors,omitempty"`
+}
+
+type supportBundleService struct{}
+
+// Collect 生成集群支持包，以 tar.gz 写入 w：节点条件、控制面 Pod 状态与日志、异常工作负载、
+// 时间范围内的 Warning 事件，以及 k8m 自身记录的该集群操作审计与终端命令。
+// 各项尽力收集，失败原因记录在包内 errors.txt；文本内容中的口令、令牌等敏感值以 MaskString 掩码
+func (s *supportBundleService) Collect(ctx context.Context, cluster string, opts *SupportBundleOptions, w io.Writer) error {
+	if kom.Cluster(cluster) == nil {
+		return fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	since := opts.Since
+	if since <= 0 {
+		since = supportBundleDefaultSince
+	}
+	since = min(since, supportBundleMaxSince)
+	tail := opts.TailLines
+	if tail <= 0 {
+		tail = supportBundleDefaultTail
+	}
+	tail = min(tail, supportBundleMaxTail)
+	now := time.Now()
+	start := now.Add(-since)
+
+	b := bundle.NewWriter(w, "support-bundle-"+utils.SanitizeFileName(cluster), supportBundleLimit)
+
+	if err := s.addNodes(ctx, b, cluster); err != nil {
+		return err
+	}
+	if err := s.addControlPlane(ctx, b, cluster, tail); err != nil {
+		return err
+	}
+	if err := s.addFailingWorkloads(ctx, b, cluster); err != nil {
+		return err
+	}
+	if err := s.addWarningEvents(ctx, b, cluster, start); err != nil {
+		return err
+	}
+	if err := s.addAudit(b, cluster, start); err != nil {
+		return err
+	}
+
+	user, _ := ctx.Value(constants.JwtUserName).(string)
+	manifest := &SupportBundleManifest{
+		Cluster:     cluster,
+		CollectedAt: now,
+		CollectedBy: user,
+		Since:       start,
+		TailLines:   tail,
+	}
+	if version, err := clusterServerVersion(cluster); err != nil {
+		b.Errorf("server_version: %v", err)
+	} else {
+		manifest.ServerVersion = version
+	}
+	manifest.Files = append([]string{}, b.Files()...)
+	manifest.Errors = b.Errors()
+	if err := b.AddJSON("manifest.json", manifest); err != nil {
+		return err
+	}
+	return b.Close()
+}
+
+// addRedacted 掩码敏感值后写入文本
+func addRedacted(b *bundle.Writer, name string, data []byte) error {
+	return b.AddFile(name, []byte(bundle.RedactSecrets(string(data))))
+}
+
+// addNodes 写入节点就绪状态、污点与全部条件，异常条件汇总在 problems 中
+func (s *supportBundleService) addNodes(ctx context.Context, b *bundle.Writer, cluster string) error {
+	var nodes []v1.Node
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Node{}).List(&nodes).Error; err != nil {
+		b.Errorf("nodes: %v", err)
+		return nil
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	items := make([]*NodeConditionSummary, 0, len(nodes))
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tREADY\tSCHEDULABLE\tVERSION\tPROBLEMS")
+	for i := range nodes {
+		n := &nodes[i]
+		item := &NodeConditionSummary{
+			Name:           n.Name,
+			Ready:          string(v1.ConditionUnknown),
+			Unschedulable:  n.Spec.Unschedulable,
+			KubeletVersion: n.Status.NodeInfo.KubeletVersion,
+			Conditions:     n.Status.Conditions,
+		}
+		for _, t := range n.Spec.Taints {
+			item.Taints = append(item.Taints, fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect))
+		}
+		for _, c := range n.Status.Conditions {
+			if c.Type == v1.NodeReady {
+				item.Ready = string(c.Status)
+				if c.Status != v1.ConditionTrue {
+					item.Problems = append(item.Problems, fmt.Sprintf("NotReady: %s %s", c.Reason, c.Message))
+				}
+			} else if c.Status == v1.ConditionTrue {
+				// MemoryPressure、DiskPressure、PIDPressure、NetworkUnavailable 等为 True 时表示异常
+				item.Problems = append(item.Problems, fmt.Sprintf("%s: %s %s", c.Type, c.Reason, c.Message))
+			}
+		}
+		items = append(items, item)
+		schedulable := "true"
+		if item.Unschedulable {
+			schedulable = "false"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.Name, item.Ready, schedulable, item.KubeletVersion, oneLine(strings.Join(item.Problems, "; ")))
+	}
+	_ = tw.Flush()
+	if err := addRedacted(b, "nodes.txt", buf.Bytes()); err != nil {
+		return err
+	}
+	return b.AddJSON("nodes.json", items)
+}
+
+// addControlPlane 写入控制面 Pod 状态、组件启动参数与各容器最近日志，托管集群通常没有控制面 Pod
+func (s *supportBundleService) addControlPlane(ctx context.Context, b *bundle.Writer, cluster string, tail int64) error {
+	var pods []v1.Pod
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Pod{}).Namespace(componentNamespace).
+		WithLabelSelector(controlPlaneLabel).List(&pods).Error
+	if err != nil {
+		b.Errorf("control-plane: %v", err)
+		return nil
+	}
+	if len(pods) == 0 {
+		b.Errorf("control-plane: %s 中没有以静态 Pod 运行的控制面组件，托管集群通常不暴露控制面", componentNamespace)
+		return nil
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tNODE\tPHASE\tREADY\tRESTARTS\tSTATUS")
+	for i := range pods {
+		pod := &pods[i]
+		var restarts int32
+		for _, st := range pod.Status.ContainerStatuses {
+			restarts += st.RestartCount
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%d\t%s\n", pod.Name, pod.Spec.NodeName, pod.Status.Phase, podReady(pod), restarts, oneLine(podProblem(pod)))
+	}
+	_ = tw.Flush()
+	if err := addRedacted(b, "control-plane/pods.txt", buf.Bytes()); err != nil {
+		return err
+	}
+	if err := b.AddJSON("control-plane/components.json", ClusterComponentService().ControlPlane(ctx, cluster)); err != nil {
+		return err
+	}
+
+	limit := int64(supportBundleLogLimit)
+	for i := range pods {
+		pod := &pods[i]
+		for _, ctn := range pod.Spec.Containers {
+			file := fmt.Sprintf("control-plane/logs/%s/%s.log", pod.Name, ctn.Name)
+			stream, err := PodService().StreamPodLogs(ctx, cluster, pod.Namespace, pod.Name, &v1.PodLogOptions{
+				Container:  ctn.Name,
+				TailLines:  &tail,
+				LimitBytes: &limit,
+				Timestamps: true,
+			})
+			if err != nil {
+				b.Errorf("%s: %v", file, err)
+				continue
+			}
+			data, err := io.ReadAll(io.LimitReader(stream, limit))
+			_ = stream.Close()
+			if err != nil {
+				b.Errorf("%s: 读取中断，内容不完整: %v", file, err)
+			}
+			if err := addRedacted(b, file, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addFailingWorkloads 写入未达到期望副本数的工作负载、失败的 Job 以及异常的 Pod
+func (s *supportBundleService) addFailingWorkloads(ctx context.Context, b *bundle.Writer, cluster string) error {
+	k := kom.Cluster(cluster).WithContext(ctx)
+	var items []*FailingWorkload
+	add := func(kind, ns, name, status, reason string) {
+		items = append(items, &FailingWorkload{Kind: kind, Namespace: ns, Name: name, Status: status, Reason: oneLine(reason)})
+	}
+
+	var deployments []appsv1.Deployment
+	if err := k.Resource(&appsv1.Deployment{}).AllNamespace().List(&deployments).Error; err != nil {
+		b.Errorf("failing-workloads: 读取 Deployment 失败: %v", err)
+	}
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		var reason string
+		for _, c := range d.Status.Conditions {
+			if c.Status != v1.ConditionTrue && (c.Type == appsv1.DeploymentAvailable || c.Type == appsv1.DeploymentProgressing) {
+				reason = c.Reason + " " + c.Message
+			}
+		}
+		if d.Status.AvailableReplicas < desired || reason != "" {
+			add("Deployment", d.Namespace, d.Name, fmt.Sprintf("%d/%d 可用", d.Status.AvailableReplicas, desired), reason)
+		}
+	}
+	var statefulSets []appsv1.StatefulSet
+	if err := k.Resource(&appsv1.StatefulSet{}).AllNamespace().List(&statefulSets).Error; err != nil {
+		b.Errorf("failing-workloads: 读取 StatefulSet 失败: %v", err)
+	}
+	for _, sts := range statefulSets {
+		desired := int32(1)
+		if sts.Spec.Replicas != nil {
+			desired = *sts.Spec.Replicas
+		}
+		if sts.Status.ReadyReplicas < desired {
+			add("StatefulSet", sts.Namespace, sts.Name, fmt.Sprintf("%d/%d 就绪", sts.Status.ReadyReplicas, desired), "")
+		}
+	}
+	var daemonSets []appsv1.DaemonSet
+	if err := k.Resource(&appsv1.DaemonSet{}).AllNamespace().List(&daemonSets).Error; err != nil {
+		b.Errorf("failing-workloads: 读取 DaemonSet 失败: %v", err)
+	}
+	for _, ds := range daemonSets {
+		if ds.Status.NumberUnavailable > 0 || ds.Status.NumberReady < ds.Status.DesiredNumberScheduled {
+			add("DaemonSet", ds.Namespace, ds.Name, fmt.Sprintf("%d/%d 就绪", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled), "")
+		}
+	}
+	var jobs []batchv1.Job
+	if err := k.Resource(&batchv1.Job{}).AllNamespace().List(&jobs).Error; err != nil {
+		b.Errorf("failing-workloads: 读取 Job 失败: %v", err)
+	}
+	for _, job := range jobs {
+		for _, c := range job.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Status == v1.ConditionTrue {
+				add("Job", job.Namespace, job.Name, "Failed", c.Reason+" "+c.Message)
+			}
+		}
+	}
+	var pods []v1.Pod
+	if err := k.Resource(&v1.Pod{}).AllNamespace().List(&pods).Error; err != nil {
+		b.Errorf("failing-workloads: 读取 Pod 失败: %v", err)
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if reason := podProblem(pod); reason != "" {
+			add("Pod", pod.Namespace, pod.Name, string(pod.Status.Phase), reason)
+		}
+	}
+
+	if len(items) > supportBundleMaxFailing {
+		b.Errorf("failing-workloads: 异常对象共 %d 个，只保留前 %d 个", len(items), supportBundleMaxFailing)
+		items = items[:supportBundleMaxFailing]
+	}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KIND\tNAMESPACE\tNAME\tSTATUS\tREASON")
+	for _, item := range items {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.Kind, item.Namespace, item.Name, item.Status, item.Reason)
+	}
+	_ = tw.Flush()
+	if err := addRedacted(b, "failing-workloads.txt", buf.Bytes()); err != nil {
+		return err
+	}
+	for _, item := range items {
+		item.Reason = bundle.RedactSecrets(item.Reason)
+	}
+	return b.AddJSON("failing-workloads.json", items)
+}
+
+// podProblem 返回 Pod 的异常原因，正常运行或已成功结束时返回空
+func podProblem(pod *v1.Pod) string {
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		return ""
+	case v1.PodFailed, v1.PodUnknown:
+		return strings.TrimSpace(pod.Status.Reason + " " + pod.Status.Message)
+	}
+	var reasons []string
+	for _, st := range append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		switch {
+		case st.State.Waiting != nil && st.State.Waiting.Reason != "PodInitializing" && st.State.Waiting.Reason != "ContainerCreating":
+			reasons = append(reasons, fmt.Sprintf("%s: %s %s", st.Name, st.State.Waiting.Reason, st.State.Waiting.Message))
+		case st.State.Terminated != nil && st.State.Terminated.ExitCode != 0:
+			reasons = append(reasons, fmt.Sprintf("%s: %s exit %d", st.Name, st.State.Terminated.Reason, st.State.Terminated.ExitCode))
+		}
+	}
+	if len(reasons) == 0 && pod.Status.Phase == v1.PodPending {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodScheduled && c.Status != v1.ConditionTrue {
+				reasons = append(reasons, fmt.Sprintf("%s %s", c.Reason, c.Message))
+			}
+		}
+	}
+	return strings.TrimSpace(strings.Join(reasons, "; "))
+}
+
+// addWarningEvents 写入时间范围内全部命名空间的 Warning 事件，超出上限时保留最新的
+func (s *supportBundleService) addWarningEvents(ctx context.Context, b *bundle.Writer, cluster string, start time.Time) error {
+	var events []v1.Event
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Event{}).AllNamespace().
+		WithFieldSelector("type=" + v1.EventTypeWarning).List(&events).Error
+	if err != nil {
+		b.Errorf("events.txt: %v", err)
+		return nil
+	}
+	recent := events[:0]
+	for i := range events {
+		if !diagnosticsEventTime(&events[i]).Before(start) {
+			recent = append(recent, events[i])
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool {
+		return diagnosticsEventTime(&recent[i]).After(diagnosticsEventTime(&recent[j]))
+	})
+	if len(recent) > supportBundleMaxEvents {
+		b.Errorf("events.txt: Warning 事件共 %d 条，只保留最新的 %d 条", len(recent), supportBundleMaxEvents)
+		recent = recent[:supportBundleMaxEvents]
+	}
+	return addRedacted(b, "events.txt", formatDiagnosticsEvents(recent))
+}
+
+// addAudit 写入 k8m 记录的该集群操作审计与终端命令，参数与命令中的敏感值已掩码，Secret 的操作参数整体掩码
+func (s *supportBundleService) addAudit(b *bundle.Writer, cluster string, start time.Time) error {
+	var operations []*models.OperationLog
+	err := dao.DB().Where("cluster = ? AND created_at >= ?", cluster, start).
+		Order("id desc").Limit(supportBundleMaxAudit).Find(&operations).Error
+	if err != nil {
+		b.Errorf("audit/operations.json: %v", err)
+	} else {
+		for _, op := range operations {
+			if op.Kind == "Secret" {
+				op.Params = utils.MaskString(op.Params, 0)
+			}
+			op.Params = bundle.RedactSecrets(op.Params)
+			op.ActionResult = bundle.RedactSecrets(op.ActionResult)
+		}
+		if err := b.AddJSON("audit/operations.json", operations); err != nil {
+			return err
+		}
+	}
+
+	var shells []*models.ShellLog
+	err = dao.DB().Where("cluster = ? AND created_at >= ?", cluster, start).
+		Order("id desc").Limit(supportBundleMaxAudit).Find(&shells).Error
+	if err != nil {
+		b.Errorf("audit/shell.json: %v", err)
+		return nil
+	}
+	for _, sh := range shells {
+		sh.Command = bundle.RedactSecrets(sh.Command)
+	}
+	return b.AddJSON("audit/shell.json", shells)
+}
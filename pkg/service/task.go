@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/k8m/pkg/task"
+	"k8s.io/klog/v2"
+)
+
+const (
+	taskConcurrency   = 8                  // 单个实例同时执行的任务数，超出的任务排队等待
+	taskFlushInterval = 2 * time.Second    // 进度、日志写回数据库及检查取消请求的间隔
+	taskStaleAfter    = 2 * time.Minute    // 超过该时间未更新的未完成任务视为执行实例已退出
+	taskRetention     = 7 * 24 * time.Hour // 已结束任务的保留时间
+)
+
+// TaskFunc 任务函数，通过 run 汇报进度与日志，run.Context() 取消后应尽快返回；返回值为任务结束时的说明
+type TaskFunc func(run *task.Run) (string, error)
+
+type taskService struct {
+	lock    sync.RWMutex
+	running map[uint]*task.Run // 本实例中未结束的任务
+	slots   chan struct{}
+}
+
+func newTaskService() *taskService {
+	return &taskService{running: map[uint]*task.Run{}, slots: make(chan struct{}, taskConcurrency)}
+}
+
+// Start 每分钟将执行实例已退出的任务标记为失败，并清理过期任务
+func (s *taskService) Start() {
+	inst := cron.New()
+	_, err := inst.AddFunc("@every 1m", s.cleanup)
+	if err != nil {
+		klog.Errorf("新增异步任务清理定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+	klog.V(6).Infof("新增异步任务清理定时任务【@every 1m】")
+}
+
+func (s *taskService) cleanup() {
+	s.lock.RLock()
+	local := make([]uint, 0, len(s.running))
+	for id := range s.running {
+		local = append(local, id)
+	}
+	s.lock.RUnlock()
+
+	query := dao.DB().Model(&models.Task{}).
+		Where("status in ? and updated_at < ?", []string{task.StatusPending, task.StatusRunning}, time.Now().Add(-taskStaleAfter))
+	if len(local) > 0 {
+		query = query.Where("id not in ?", local)
+	}
+	if err := query.Updates(map[string]any{"status": task.StatusFailed, "message": "执行实例已退出，任务中断", "finished_at": time.Now()}).Error; err != nil {
+		klog.V(6).Infof("标记中断的异步任务失败: %v", err)
+	}
+	err := dao.DB().Where("status in ? and finished_at < ?", []string{task.StatusSucceeded, task.StatusFailed, task.StatusCancelled},
+		time.Now().Add(-taskRetention)).Delete(&models.Task{}).Error
+	if err != nil {
+		klog.V(6).Infof("清理过期异步任务失败: %v", err)
+	}
+}
+
+// Submit 创建任务并在后台执行，立即返回任务记录。任务以 ctx 中的用户身份执行，不随请求结束而取消
+func (s *taskService) Submit(ctx context.Context, taskType, title, cluster string, fn TaskFunc) (*models.Task, error) {
+	username, _ := ctx.Value(constants.JwtUserName).(string)
+	t := &models.Task{
+		Type:      taskType,
+		Title:     title,
+		Cluster:   cluster,
+		Status:    task.StatusPending,
+		CreatedBy: username,
+	}
+	if err := dao.DB().Create(t).Error; err != nil {
+		return nil, fmt.Errorf("创建任务失败: %w", err)
+	}
+	run := task.NewRun(context.WithValue(context.Background(), constants.JwtUserName, username))
+	s.lock.Lock()
+	s.running[t.ID] = run
+	s.lock.Unlock()
+	go s.execute(t.ID, run, fn)
+	return t, nil
+}
+
+func (s *taskService) execute(id uint, run *task.Run, fn TaskFunc) {
+	stop := make(chan struct{})
+	defer func() {
+		close(stop)
+		s.lock.Lock()
+		delete(s.running, id)
+		s.lock.Unlock()
+		run.Cancel()
+	}()
+	go s.heartbeat(id, run, stop)
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-run.Context().Done():
+		s.finish(id, run, task.StatusCancelled, "任务在开始前已取消")
+		return
+	}
+	if err := dao.DB().Model(&models.Task{}).Where("id = ?", id).
+		Updates(map[string]any{"status": task.StatusRunning, "started_at": time.Now()}).Error; err != nil {
+		klog.V(6).Infof("更新任务[%d]状态失败: %v", id, err)
+	}
+
+	message, err := s.call(run, fn)
+	switch {
+	case err != nil && run.Context().Err() != nil:
+		s.finish(id, run, task.StatusCancelled, "任务已取消")
+	case err != nil:
+		s.finish(id, run, task.StatusFailed, err.Error())
+	default:
+		run.Progress(100, "%s", message)
+		s.finish(id, run, task.StatusSucceeded, message)
+	}
+}
+
+// call 执行任务函数，任务函数 panic 时视为失败
+func (s *taskService) call(run *task.Run, fn TaskFunc) (message string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			klog.Errorf("异步任务 panic: %v", r)
+			err = fmt.Errorf("任务异常退出: %v", r)
+		}
+	}()
+	return fn(run)
+}
+
+// heartbeat 定期写回进度与日志，并检查其他实例写入的取消请求
+func (s *taskService) heartbeat(id uint, run *task.Run, stop <-chan struct{}) {
+	ticker := time.NewTicker(taskFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		updates := map[string]any{"updated_at": time.Now()}
+		if state, dirty := run.Flush(); dirty {
+			updates["progress"], updates["message"], updates["log"], updates["log_size"] = state.Progress, state.Message, state.Log, state.LogSize
+		}
+		if err := dao.DB().Model(&models.Task{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			klog.V(6).Infof("写回任务[%d]进度失败: %v", id, err)
+		}
+		var cancelRequested []bool
+		if err := dao.DB().Model(&models.Task{}).Where("id = ?", id).Pluck("cancel_requested", &cancelRequested).Error; err == nil &&
+			len(cancelRequested) > 0 && cancelRequested[0] {
+			run.Cancel()
+		}
+	}
+}
+
+func (s *taskService) finish(id uint, run *task.Run, status, message string) {
+	state := run.State()
+	err := dao.DB().Model(&models.Task{}).Where("id = ?", id).Updates(map[string]any{
+		"status":      status,
+		"progress":    state.Progress,
+		"message":     message,
+		"log":         state.Log,
+		"log_size":    state.LogSize,
+		"finished_at": time.Now(),
+	}).Error
+	if err != nil {
+		klog.Errorf("保存任务[%d]结果失败: %v", id, err)
+	}
+}
+
+func (s *taskService) local(id uint) *task.Run {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.running[id]
+}
+
+// Get 读取任务，在本实例执行的任务使用内存中的最新进度
+func (s *taskService) Get(id uint) (*models.Task, error) {
+	var t models.Task
+	if err := dao.DB().First(&t, id).Error; err != nil {
+		return nil, fmt.Errorf("任务 %d 不存在", id)
+	}
+	if run := s.local(id); run != nil && !task.Finished(t.Status) {
+		state := run.State()
+		t.Progress, t.Message, t.Log, t.LogSize = state.Progress, state.Message, state.Log, state.LogSize
+	}
+	return &t, nil
+}
+
+// Cancel 请求取消未结束的任务，在其他实例执行的任务于下次写回进度时取消
+func (s *taskService) Cancel(id uint) error {
+	result := dao.DB().Model(&models.Task{}).
+		Where("id = ? and status in ?", id, []string{task.StatusPending, task.StatusRunning}).
+		Update("cancel_requested", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("任务 %d 不存在或已结束", id)
+	}
+	if run := s.local(id); run != nil {
+		run.Cancel()
+	}
+	return nil
+}
+
+// Watch 在任务进度变化时推送最新状态，任务结束或 ctx 取消后关闭通道
+func (s *taskService) Watch(ctx context.Context, id uint) <-chan *models.Task {
+	ch := make(chan *models.Task)
+	go func() {
+		defer close(ch)
+		for {
+			t, err := s.Get(id)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- t:
+			case <-ctx.Done():
+				return
+			}
+			if task.Finished(t.Status) {
+				return
+			}
+			// 本实例执行的任务在变化时推送，其他实例执行的任务轮询数据库
+			var changed <-chan struct{}
+			if run := s.local(id); run != nil {
+				changed = run.Changed()
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				// 合并短时间内的连续变化
+				time.Sleep(200 * time.Millisecond)
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+	return ch
+}
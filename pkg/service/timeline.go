@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/changeid"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+// 时间线条目来源
+const (
+	TimelineSourceAudit   = "audit"   // 平台操作日志
+	TimelineSourceEvent   = "event"   // Kubernetes 事件
+	TimelineSourceRollout = "rollout" // 工作负载发布，新的 ReplicaSet 或 ControllerRevision
+)
+
+// TimelineEntry 命名空间时间线中的一条变更
+type TimelineEntry struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"`
+	Kind    string    `json:"kind"`
+	Name    string    `json:"name"`
+	Action  string    `json:"action"`         // 操作日志为 create/update/delete 等，事件为 Reason，发布为 rollout
+	Type    string    `json:"type,omitempty"` // 事件类型 Normal/Warning，操作失败时为 Warning
+	Message string    `json:"message,omitempty"`
+	User    string    `json:"user,omitempty"`
+	Count   int32     `json:"count,omitempty"` // 事件重复次数
+	// Change 引起该条目的经由 k8m 的变更：操作日志为其自身的变更，Warning 事件与发布沿属主链找到资源上的变更ID
+	Change *TimelineChange `json:"change,omitempty"`
+
+	changeID string // 条目对应资源上的变更ID，事件需要解析属主后才能确定
+	ref      timelineRef
+}
+
+// TimelineChange 经由 k8m 的一次变更
+type TimelineChange struct {
+	ID     string    `json:"id"`
+	LogID  uint      `json:"log_id"` // 操作日志ID
+	User   string    `json:"user"`
+	Action string    `json:"action"`
+	Kind   string    `json:"kind"`
+	Name   string    `json:"name"`
+	Time   time.Time `json:"time"`
+}
+
+func newTimelineChange(l *models.OperationLog) *TimelineChange {
+	return &TimelineChange{ID: l.ChangeID, LogID: l.ID, User: l.UserName, Action: l.Action, Kind: l.Kind, Name: l.Name, Time: l.CreatedAt}
+}
+
+// timelineRef 条目涉及的资源，用于解析变更ID
+type timelineRef struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+type timelineService struct{}
+
+// Namespace 合并 [since, until) 内的操作日志、事件与发布记录，按时间倒序返回。
+// sources 为空时返回全部来源；change 不为空时只返回与该变更关联的条目。
+func (t *timelineService) Namespace(ctx context.Context, cluster, ns string, since, until time.Time, sources []string, change string) ([]*TimelineEntry, error) {
+	want := func(source string) bool {
+		if len(sources) == 0 {
+			return true
+		}
+		for _, s := range sources {
+			if s == source {
+				return true
+			}
+		}
+		return false
+	}
+	inRange := func(tm time.Time) bool {
+		return !tm.Before(since) && tm.Before(until)
+	}
+
+	result := []*TimelineEntry{}
+
+	// 先读取集群资源，借助用户上下文完成命名空间权限校验，再读取平台操作日志
+	if want(TimelineSourceEvent) {
+		entries, err := t.events(ctx, cluster, ns, inRange)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entries...)
+	}
+	if want(TimelineSourceRollout) {
+		entries, err := t.rollouts(ctx, cluster, ns, inRange)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entries...)
+	}
+	if want(TimelineSourceAudit) {
+		entries, err := t.audits(cluster, ns, since, until)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entries...)
+	}
+
+	t.correlate(ctx, cluster, ns, result)
+	if change != "" {
+		filtered := []*TimelineEntry{}
+		for _, e := range result {
+			if e.Change != nil && e.Change.ID == change {
+				filtered = append(filtered, e)
+			}
+		}
+		result = filtered
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.After(result[j].Time)
+	})
+	return result, nil
+}
+
+func (t *timelineService) events(ctx context.Context, cluster, ns string, inRange func(time.Time) bool) ([]*TimelineEntry, error) {
+	var events []v1.Event
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Event{}).Namespace(ns).List(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("读取事件失败: %w", err)
+	}
+	var result []*TimelineEntry
+	for _, e := range events {
+		tm := e.LastTimestamp.Time
+		if tm.IsZero() {
+			tm = e.EventTime.Time
+		}
+		if tm.IsZero() {
+			tm = e.CreationTimestamp.Time
+		}
+		if !inRange(tm) {
+			continue
+		}
+		result = append(result, &TimelineEntry{
+			Time:    tm,
+			Source:  TimelineSourceEvent,
+			Kind:    e.InvolvedObject.Kind,
+			Name:    e.InvolvedObject.Name,
+			Action:  e.Reason,
+			Type:    e.Type,
+			Message: e.Message,
+			Count:   e.Count,
+			ref:     timelineRef{APIVersion: e.InvolvedObject.APIVersion, Kind: e.InvolvedObject.Kind, Name: e.InvolvedObject.Name},
+		})
+	}
+	return result, nil
+}
+
+// rollouts 将新建的 ReplicaSet、ControllerRevision 视为一次发布，记录修订版本与镜像
+func (t *timelineService) rollouts(ctx context.Context, cluster, ns string, inRange func(time.Time) bool) ([]*TimelineEntry, error) {
+	var result []*TimelineEntry
+
+	var rsList []appsv1.ReplicaSet
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&appsv1.ReplicaSet{}).Namespace(ns).List(&rsList).Error
+	if err != nil {
+		return nil, fmt.Errorf("读取 ReplicaSet 失败: %w", err)
+	}
+	for _, rs := range rsList {
+		if !inRange(rs.CreationTimestamp.Time) {
+			continue
+		}
+		kind, name := "ReplicaSet", rs.Name
+		for _, ref := range rs.OwnerReferences {
+			if ref.Controller != nil && *ref.Controller {
+				kind, name = ref.Kind, ref.Name
+			}
+		}
+		var images []string
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		result = append(result, &TimelineEntry{
+			Time:    rs.CreationTimestamp.Time,
+			Source:  TimelineSourceRollout,
+			Kind:    kind,
+			Name:    name,
+			Action:  "rollout",
+			Message: fmt.Sprintf("修订版本 %s，ReplicaSet %s，镜像 %s", rs.Annotations["deployment.kubernetes.io/revision"], rs.Name, strings.Join(images, ", ")),
+			// Deployment 的注解在创建 ReplicaSet 时被复制，记录的是引起本次发布的变更
+			changeID: changeid.Get(&rs),
+		})
+	}
+
+	// StatefulSet、DaemonSet 的每次发布生成一个 ControllerRevision，读取失败不影响其他来源
+	var revisions []appsv1.ControllerRevision
+	err = kom.Cluster(cluster).WithContext(ctx).Resource(&appsv1.ControllerRevision{}).Namespace(ns).List(&revisions).Error
+	if err != nil {
+		klog.V(6).Infof("读取命名空间[%s] ControllerRevision 失败: %v", ns, err)
+		return result, nil
+	}
+	for _, rev := range revisions {
+		if !inRange(rev.CreationTimestamp.Time) {
+			continue
+		}
+		kind, name := "ControllerRevision", rev.Name
+		for _, ref := range rev.OwnerReferences {
+			if ref.Controller != nil && *ref.Controller {
+				kind, name = ref.Kind, ref.Name
+			}
+		}
+		result = append(result, &TimelineEntry{
+			Time:    rev.CreationTimestamp.Time,
+			Source:  TimelineSourceRollout,
+			Kind:    kind,
+			Name:    name,
+			Action:  "rollout",
+			Message: fmt.Sprintf("修订版本 %d，ControllerRevision %s", rev.Revision, rev.Name),
+			ref:     timelineRef{APIVersion: "apps/v1", Kind: kind, Name: name},
+		})
+	}
+
+	// 超过 progressDeadlineSeconds 仍未完成的发布没有对应事件，按 Deployment 的 Progressing 条件补充
+	var deployments []appsv1.Deployment
+	err = kom.Cluster(cluster).WithContext(ctx).Resource(&appsv1.Deployment{}).Namespace(ns).List(&deployments).Error
+	if err != nil {
+		klog.V(6).Infof("读取命名空间[%s] Deployment 失败: %v", ns, err)
+		return result, nil
+	}
+	for _, d := range deployments {
+		for _, cond := range d.Status.Conditions {
+			if cond.Type != appsv1.DeploymentProgressing || cond.Status != v1.ConditionFalse || !inRange(cond.LastUpdateTime.Time) {
+				continue
+			}
+			result = append(result, &TimelineEntry{
+				Time:     cond.LastUpdateTime.Time,
+				Source:   TimelineSourceRollout,
+				Kind:     "Deployment",
+				Name:     d.Name,
+				Action:   cond.Reason,
+				Type:     v1.EventTypeWarning,
+				Message:  cond.Message,
+				changeID: changeid.Get(&d),
+			})
+		}
+	}
+	return result, nil
+}
+
+func (t *timelineService) audits(cluster, ns string, since, until time.Time) ([]*TimelineEntry, error) {
+	var logs []*models.OperationLog
+	err := dao.DB().Where("cluster = ? and namespace = ? and created_at >= ? and created_at < ?", cluster, ns, since, until).
+		Order("created_at desc").Limit(1000).Find(&logs).Error
+	if err != nil {
+		return nil, fmt.Errorf("读取操作日志失败: %w", err)
+	}
+	var result []*TimelineEntry
+	for _, l := range logs {
+		entry := &TimelineEntry{
+			Time:   l.CreatedAt,
+			Source: TimelineSourceAudit,
+			Kind:   l.Kind,
+			Name:   l.Name,
+			Action: l.Action,
+			Type:   "Normal",
+			User:   l.UserName,
+		}
+		if l.ChangeID != "" {
+			entry.Change = newTimelineChange(l)
+		}
+		if l.ActionResult != "success" {
+			entry.Type = "Warning"
+			entry.Message = l.ActionResult
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// maxTimelineLookups 关联变更时额外读取的资源数上限
+const maxTimelineLookups = 200
+
+// correlate 为 Warning 事件与发布记录找到引起它们的变更。资源上的变更ID只记录最近一次变更，
+// 因此只关联发生在该变更之后的条目
+func (t *timelineService) correlate(ctx context.Context, cluster, ns string, entries []*TimelineEntry) {
+	r := &changeResolver{ctx: ctx, cluster: cluster, ns: ns, ids: map[string]string{}}
+	var ids []string
+	for _, e := range entries {
+		if e.Change != nil {
+			continue
+		}
+		switch {
+		case e.Source == TimelineSourceEvent && e.Type == v1.EventTypeWarning,
+			e.Source == TimelineSourceRollout && e.changeID == "" && e.ref.Kind != "":
+			e.changeID = r.resolve(e.ref.APIVersion, e.ref.Kind, e.ref.Name)
+		}
+		if e.changeID != "" {
+			ids = append(ids, e.changeID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	var logs []*models.OperationLog
+	if err := dao.DB().Where("cluster = ? and change_id in ?", cluster, ids).Find(&logs).Error; err != nil {
+		klog.V(6).Infof("读取变更[%s/%s]操作日志失败: %v", cluster, ns, err)
+		return
+	}
+	changes := make(map[string]*TimelineChange, len(logs))
+	for _, l := range logs {
+		changes[l.ChangeID] = newTimelineChange(l)
+	}
+	for _, e := range entries {
+		// 事件时间只精确到秒
+		if c := changes[e.changeID]; e.Change == nil && c != nil && !e.Time.Before(c.Time.Truncate(time.Second)) {
+			e.Change = c
+		}
+	}
+}
+
+// changeResolver 沿属主链查找资源上的变更ID，如 Pod 的事件经 ReplicaSet 找到 Deployment 的变更
+type changeResolver struct {
+	ctx     context.Context
+	cluster string
+	ns      string
+	ids     map[string]string // kind/name -> 变更ID，查找过的资源都会记录，未找到时为空
+	lookups int
+}
+
+func (r *changeResolver) resolve(apiVersion, kind, name string) string {
+	var visited []string
+	id := ""
+	for depth := 0; depth < 4 && name != ""; depth++ {
+		key := kind + "/" + name
+		if cached, ok := r.ids[key]; ok {
+			id = cached
+			break
+		}
+		visited = append(visited, key)
+		if r.lookups >= maxTimelineLookups {
+			break
+		}
+		r.lookups++
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			break
+		}
+		var obj *unstructured.Unstructured
+		if err := kom.Cluster(r.cluster).WithContext(r.ctx).CRD(gv.Group, gv.Version, kind).Namespace(r.ns).Name(name).Get(&obj).Error; err != nil || obj == nil {
+			break
+		}
+		if id = changeid.Get(obj); id != "" {
+			break
+		}
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil {
+			break
+		}
+		apiVersion, kind, name = owner.APIVersion, owner.Kind, owner.Name
+	}
+	for _, key := range visited {
+		r.ids[key] = id
+	}
+	return id
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weibaohui/k8m/pkg/deprecation"
+	"github.com/weibaohui/kom/kom"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+type upgradeService struct{}
+
+// 升级检查项的严重程度
+const (
+	UpgradeSeverityBlocker = "blocker" // 不处理会导致升级失败或升级后业务中断
+	UpgradeSeverityWarning = "warning" // 建议在升级前处理
+	UpgradeSeverityInfo    = "info"
+)
+
+// 升级检查项的类别
+const (
+	UpgradeCategoryVersion = "version"
+	UpgradeCategoryAPI     = "api"
+	UpgradeCategoryWebhook = "webhook"
+	UpgradeCategoryNode    = "node"
+)
+
+// lastAppliedAnnotation kubectl apply 记录的上次应用内容
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// upgradeSkipScanKinds 不由用户清单管理的资源，扫描 last-applied 注解时跳过，避免列出大量对象
+var upgradeSkipScanKinds = map[string]bool{
+	"Event": true, "Lease": true, "EndpointSlice": true, "CSINode": true, "VolumeAttachment": true,
+	"CertificateSigningRequest": true, "TokenReview": true, "SubjectAccessReview": true,
+	"LocalSubjectAccessReview": true, "SelfSubjectAccessReview": true, "CSIStorageCapacity": true,
+}
+
+// deprecatedMetricRe 匹配 apiserver_requested_deprecated_apis 指标行
+var deprecatedMetricRe = regexp.MustCompile(`^apiserver_requested_deprecated_apis\{([^}]*)\}\s+(\S+)`)
+var metricLabelRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// UpgradeReadinessItem 升级检查发现的问题
+type UpgradeReadinessItem struct {
+	Category   string `json:"category"` // version、api、webhook、node
+	Severity   string `json:"severity"` // blocker、warning、info
+	Object     string `json:"object,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// UpgradeReadinessReport 集群升级到目标版本前的检查报告
+type UpgradeReadinessReport struct {
+	Cluster        string                  `json:"cluster"`
+	CurrentVersion string                  `json:"current_version"`
+	TargetVersion  string                  `json:"target_version"`
+	Ready          bool                    `json:"ready"` // 没有阻塞项
+	Blockers       int                     `json:"blockers"`
+	Warnings       int                     `json:"warnings"`
+	Items          []*UpgradeReadinessItem `json:"items"`
+	Errors         []string                `json:"errors,omitempty"` // 未能完成的检查，报告可能不完整
+	CheckedAt      time.Time               `json:"checked_at"`
+}
+
+func (r *UpgradeReadinessReport) add(category, severity, object, suggestion, format string, args ...any) {
+	r.Items = append(r.Items, &UpgradeReadinessItem{
+		Category: category, Severity: severity, Object: object, Suggestion: suggestion,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Readiness 检查集群升级到目标版本前需要处理的问题：仍在使用的将被移除或已弃用的 API、
+// 与目标版本不兼容的准入 Webhook、超出允许范围的 kubelet 版本偏差，并给出阻塞项
+func (u *upgradeService) Readiness(ctx context.Context, cluster, target string) (*UpgradeReadinessReport, error) {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return nil, fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	version, err := clusterServerVersion(cluster)
+	if err != nil {
+		return nil, err
+	}
+	current, target := deprecation.Minor(version), deprecation.Minor(target)
+	if len(strings.Split(target, ".")) != 2 {
+		return nil, fmt.Errorf("目标版本 %s 格式错误，应为 1.30 或 v1.30.2 形式", target)
+	}
+	if !deprecation.MinorLess(current, target) {
+		return nil, fmt.Errorf("目标版本 %s 须高于当前版本 %s", target, current)
+	}
+
+	report := &UpgradeReadinessReport{Cluster: cluster, CurrentVersion: version, TargetVersion: target, CheckedAt: time.Now()}
+	if diff := deprecation.MinorDiff(current, target); diff > 1 {
+		report.add(UpgradeCategoryVersion, UpgradeSeverityBlocker, "", fmt.Sprintf("依次升级到 %s 后再继续", upgradePath(current, target)),
+			"控制面只能逐个次版本升级，当前 %s 与目标 %s 相差 %d 个次版本", current, target, diff)
+	}
+
+	// 需要检查的 API：当前仍可用，但在目标版本中被移除或已弃用
+	var apis []*deprecation.API
+	for _, a := range deprecation.APIs {
+		if !a.RemovedBy(current) && a.DeprecatedBy(target) {
+			apis = append(apis, a)
+		}
+	}
+	u.checkRequestedAPIs(ctx, k, target, apis, report)
+	u.checkAppliedManifests(ctx, cluster, target, apis, report)
+	u.checkWebhooks(ctx, cluster, target, report)
+	u.checkNodes(ctx, cluster, target, report)
+
+	order := map[string]int{UpgradeSeverityBlocker: 0, UpgradeSeverityWarning: 1, UpgradeSeverityInfo: 2}
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return order[report.Items[i].Severity] < order[report.Items[j].Severity]
+	})
+	for _, item := range report.Items {
+		switch item.Severity {
+		case UpgradeSeverityBlocker:
+			report.Blockers++
+		case UpgradeSeverityWarning:
+			report.Warnings++
+		}
+	}
+	report.Ready = report.Blockers == 0
+	return report, nil
+}
+
+// checkRequestedAPIs 读取 apiserver 的 apiserver_requested_deprecated_apis 指标，找出自 apiserver 启动以来仍被请求的弃用 API
+func (u *upgradeService) checkRequestedAPIs(ctx context.Context, k *kom.Kubectl, target string, apis []*deprecation.API, report *UpgradeReadinessReport) {
+	raw, err := k.Client().CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("读取 apiserver 指标失败，无法统计弃用 API 的请求情况: %v", err))
+		return
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	seen := map[string]bool{}
+	for scanner.Scan() {
+		m := deprecatedMetricRe.FindStringSubmatch(scanner.Text())
+		if m == nil || m[2] == "0" {
+			continue
+		}
+		labels := map[string]string{}
+		for _, l := range metricLabelRe.FindAllStringSubmatch(m[1], -1) {
+			labels[l[1]] = l[2]
+		}
+		a := deprecation.LookupResource(labels["group"], labels["version"], labels["resource"])
+		if a == nil || !slices.Contains(apis, a) || seen[a.APIVersion()+"/"+a.Resource] {
+			continue
+		}
+		seen[a.APIVersion()+"/"+a.Resource] = true
+		severity := UpgradeSeverityWarning
+		if a.RemovedBy(target) {
+			severity = UpgradeSeverityBlocker
+		}
+		report.add(UpgradeCategoryAPI, severity, a.APIVersion()+"/"+a.Resource, "通过 apiserver 审计日志定位请求方，将客户端、控制器与 Helm Chart 改为使用新版本",
+			"apiserver 启动以来仍有客户端请求 %s", a.Message())
+	}
+}
+
+// checkAppliedManifests 检查对象 last-applied-configuration 注解中记录的 apiVersion，找出仍以旧版本维护的清单
+func (u *upgradeService) checkAppliedManifests(ctx context.Context, cluster, target string, apis []*deprecation.API, report *UpgradeReadinessReport) {
+	scanned := map[string]bool{}
+	for _, a := range apis {
+		if a.Replacement == "" || upgradeSkipScanKinds[a.Kind] {
+			continue
+		}
+		group, version := "", a.Replacement
+		if i := strings.LastIndex(a.Replacement, "/"); i >= 0 {
+			group, version = a.Replacement[:i], a.Replacement[i+1:]
+		}
+		key := a.Replacement + "/" + a.Kind
+		if scanned[key] {
+			continue
+		}
+		scanned[key] = true
+
+		var list []*unstructured.Unstructured
+		err := kom.Cluster(cluster).WithContext(ctx).CRD(group, version, a.Kind).AllNamespace().List(&list).Error
+		if err != nil {
+			// 新版本尚未提供时无法列出，由指标检查覆盖
+			klog.V(6).Infof("升级检查列出 %s %s 失败: %v", a.Replacement, a.Kind, err)
+			continue
+		}
+		for _, item := range list {
+			applied := item.GetAnnotations()[lastAppliedAnnotation]
+			if applied == "" {
+				continue
+			}
+			var obj unstructured.Unstructured
+			if err := obj.UnmarshalJSON([]byte(applied)); err != nil {
+				continue
+			}
+			old := deprecation.Lookup(obj.GetAPIVersion(), a.Kind)
+			if old == nil || !slices.Contains(apis, old) {
+				continue
+			}
+			name := item.GetName()
+			if ns := item.GetNamespace(); ns != "" {
+				name = ns + "/" + name
+			}
+			severity := UpgradeSeverityWarning
+			if old.RemovedBy(target) {
+				severity = UpgradeSeverityBlocker
+			}
+			report.add(UpgradeCategoryAPI, severity, a.Kind+" "+name, "将清单中的 apiVersion 改为 "+old.Replacement+" 并重新应用，否则升级后再次 apply 会失败",
+				"对象上次以 %s 应用，%s", old.APIVersion(), old.Message())
+		}
+	}
+}
+
+// checkWebhooks 检查准入 Webhook 的 AdmissionReview 版本、拦截规则中将被移除的 API 以及后端服务可用性
+func (u *upgradeService) checkWebhooks(ctx context.Context, cluster, target string, report *UpgradeReadinessReport) {
+	type hook struct {
+		config, name  string
+		reviews       []string
+		failurePolicy *admissionv1.FailurePolicyType
+		service       *admissionv1.ServiceReference
+		rules         []admissionv1.RuleWithOperations
+	}
+	var hooks []hook
+	var mutating []admissionv1.MutatingWebhookConfiguration
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&admissionv1.MutatingWebhookConfiguration{}).List(&mutating).Error; err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("读取 MutatingWebhookConfiguration 失败: %v", err))
+	}
+	for _, c := range mutating {
+		for _, w := range c.Webhooks {
+			hooks = append(hooks, hook{"MutatingWebhookConfiguration/" + c.Name, w.Name, w.AdmissionReviewVersions, w.FailurePolicy, w.ClientConfig.Service, w.Rules})
+		}
+	}
+	var validating []admissionv1.ValidatingWebhookConfiguration
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&admissionv1.ValidatingWebhookConfiguration{}).List(&validating).Error; err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("读取 ValidatingWebhookConfiguration 失败: %v", err))
+	}
+	for _, c := range validating {
+		for _, w := range c.Webhooks {
+			hooks = append(hooks, hook{"ValidatingWebhookConfiguration/" + c.Name, w.Name, w.AdmissionReviewVersions, w.FailurePolicy, w.ClientConfig.Service, w.Rules})
+		}
+	}
+
+	for _, h := range hooks {
+		object := h.config + " " + h.name
+		if !slices.Contains(h.reviews, "v1") {
+			report.add(UpgradeCategoryWebhook, UpgradeSeverityWarning, object, "升级 Webhook 服务并在 admissionReviewVersions 中加入 v1",
+				"admissionReviewVersions 为 %v，未包含 v1，AdmissionReview v1beta1 已弃用", h.reviews)
+		}
+		for _, rule := range h.rules {
+			for _, g := range rule.APIGroups {
+				for _, v := range rule.APIVersions {
+					for _, r := range rule.Resources {
+						a := deprecation.LookupResource(g, v, strings.Split(r, "/")[0])
+						if a == nil || !a.RemovedBy(target) {
+							continue
+						}
+						report.add(UpgradeCategoryWebhook, UpgradeSeverityWarning, object, "在规则中加入 "+a.Replacement+" 或改为 *",
+							"拦截规则只匹配 %s/%s，升级后该版本被移除，Webhook 将不再拦截对应资源", a.APIVersion(), a.Resource)
+					}
+				}
+			}
+		}
+		// failurePolicy 默认为 Fail，后端不可用时匹配的请求都会被拒绝，升级过程中重建的系统组件可能因此无法创建
+		failClosed := h.failurePolicy == nil || *h.failurePolicy == admissionv1.Fail
+		if !failClosed || h.service == nil {
+			continue
+		}
+		ready, err := u.serviceHasReadyEndpoints(ctx, cluster, h.service.Namespace, h.service.Name)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("读取 Webhook %s 的服务 %s/%s 失败: %v", object, h.service.Namespace, h.service.Name, err))
+			continue
+		}
+		if !ready {
+			report.add(UpgradeCategoryWebhook, UpgradeSeverityBlocker, object, "恢复 Webhook 服务，或在升级期间将 failurePolicy 临时改为 Ignore",
+				"failurePolicy 为 Fail，但服务 %s/%s 没有就绪的后端，匹配的请求都会被拒绝", h.service.Namespace, h.service.Name)
+		}
+	}
+}
+
+func (u *upgradeService) serviceHasReadyEndpoints(ctx context.Context, cluster, ns, name string) (bool, error) {
+	var ep v1.Endpoints
+	err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Endpoints{}).Namespace(ns).Name(name).Get(&ep).Error
+	if err != nil {
+		return false, err
+	}
+	for _, s := range ep.Subsets {
+		if len(s.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkNodes 检查节点 kubelet 与目标版本的偏差。kubelet 最多可比 apiserver 低 3 个次版本（1.28 之前为 2 个），且不能高于 apiserver
+func (u *upgradeService) checkNodes(ctx context.Context, cluster, target string, report *UpgradeReadinessReport) {
+	var nodes []v1.Node
+	if err := kom.Cluster(cluster).WithContext(ctx).Resource(&v1.Node{}).List(&nodes).Error; err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("读取节点失败: %v", err))
+		return
+	}
+	maxSkew := 3
+	if deprecation.MinorLess(target, "1.28") {
+		maxSkew = 2
+	}
+	for _, n := range nodes {
+		kubelet := n.Status.NodeInfo.KubeletVersion
+		if kubelet == "" {
+			continue
+		}
+		skew := deprecation.MinorDiff(kubelet, target)
+		switch {
+		case skew > maxSkew:
+			report.add(UpgradeCategoryNode, UpgradeSeverityBlocker, "Node "+n.Name, fmt.Sprintf("先将节点升级到 %s 或更高版本", upgradeMinus(target, maxSkew)),
+				"kubelet 版本为 %s，比目标版本 %s 低 %d 个次版本，超出允许的 %d 个", kubelet, target, skew, maxSkew)
+		case skew == maxSkew:
+			report.add(UpgradeCategoryNode, UpgradeSeverityWarning, "Node "+n.Name, "控制面升级后尽快升级该节点",
+				"kubelet 版本为 %s，升级后与控制面的偏差达到上限 %d 个次版本，下次升级前必须先升级该节点", kubelet, maxSkew)
+		case skew < 0:
+			report.add(UpgradeCategoryNode, UpgradeSeverityBlocker, "Node "+n.Name, "检查该节点的 kubelet 版本",
+				"kubelet 版本为 %s，高于目标版本 %s，kubelet 不能高于 apiserver", kubelet, target)
+		}
+	}
+}
+
+// clusterServerVersion 返回集群 apiserver 的版本号，如 v1.30.2
+func clusterServerVersion(cluster string) (string, error) {
+	k := kom.Cluster(cluster)
+	if k == nil {
+		return "", fmt.Errorf("集群 %s 不存在", cluster)
+	}
+	info, err := k.Client().Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("获取集群版本失败: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
+// upgradePath 返回从 current 到 target 需要依次经过的中间版本
+func upgradePath(current, target string) string {
+	var steps []string
+	for i := 1; i < deprecation.MinorDiff(current, target); i++ {
+		steps = append(steps, upgradeMinus(target, deprecation.MinorDiff(current, target)-i))
+	}
+	return strings.Join(steps, "、")
+}
+
+// upgradeMinus 返回比 version 低 n 个次版本的版本号
+func upgradeMinus(version string, n int) string {
+	var major, minor int
+	if _, err := fmt.Sscanf(deprecation.Minor(version), "%d.%d", &major, &minor); err != nil {
+		return version
+	}
+	return fmt.Sprintf("%d.%d", major, minor-n)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/weibaohui/k8m/pkg/flag"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/telemetry"
+	"k8s.io/klog/v2"
+)
+
+// stagingStaleAge 暂存目录超过该时间且不在使用中时由定时任务清理
+const stagingStaleAge = 6 * time.Hour
+
+// uploadStagingService 管理上传文件在 k8m 本地的暂存目录。
+// 所有暂存文件位于同一个根目录下，启动时清理上次异常退出遗留的文件，并按用户限制同时占用的磁盘空间。
+// 暂存文件与用量均为实例本地状态，多实例部署时配额按实例分别计算。
+type uploadStagingService struct {
+	lock   sync.Mutex
+	usage  map[string]int64    // 用户 -> 正在使用的字节数
+	active map[string]struct{} // 正在使用的暂存目录
+}
+
+// Root 暂存根目录
+func (u *uploadStagingService) Root() string {
+	return filepath.Join(os.TempDir(), "k8m-staging")
+}
+
+// Start 清理遗留的暂存文件，并每小时清理长时间未释放的暂存目录
+func (u *uploadStagingService) Start() {
+	u.lock.Lock()
+	u.usage = map[string]int64{}
+	u.active = map[string]struct{}{}
+	u.lock.Unlock()
+
+	// 进程刚启动，根目录下的内容都是上次运行遗留的
+	if err := os.RemoveAll(u.Root()); err != nil {
+		klog.Errorf("清理上传暂存目录 %s 失败: %v", u.Root(), err)
+	}
+	if err := os.MkdirAll(u.Root(), 0700); err != nil {
+		klog.Errorf("创建上传暂存目录 %s 失败: %v", u.Root(), err)
+	}
+	telemetry.SetStagingBytes(0)
+
+	inst := cron.New()
+	_, err := inst.AddFunc("@hourly", u.cleanup)
+	if err != nil {
+		klog.Errorf("新增上传暂存清理定时任务报错: %v", err)
+		return
+	}
+	inst.Start()
+}
+
+func (u *uploadStagingService) cleanup() {
+	entries, err := os.ReadDir(u.Root())
+	if err != nil {
+		return
+	}
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	for _, e := range entries {
+		p := filepath.Join(u.Root(), e.Name())
+		if _, ok := u.active[p]; ok {
+			continue
+		}
+		if info, err := e.Info(); err == nil && time.Since(info.ModTime()) > stagingStaleAge {
+			klog.V(6).Infof("清理过期的上传暂存 %s", p)
+			_ = os.RemoveAll(p)
+		}
+	}
+}
+
+// reserve 按用户配额预占空间，配额为 0 表示不限制
+func (u *uploadStagingService) reserve(username string, size int64) error {
+	quota := int64(flag.Init().UploadStagingQuotaMB) << 20
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if u.usage == nil {
+		u.usage = map[string]int64{}
+		u.active = map[string]struct{}{}
+	}
+	if quota > 0 && u.usage[username]+size > quota {
+		return i18n.NewError(i18n.CodeFileStagingQuota,
+			humanBytes(u.usage[username]), humanBytes(size), humanBytes(quota))
+	}
+	u.usage[username] += size
+	telemetry.AddStagingBytes(size)
+	return nil
+}
+
+func (u *uploadStagingService) release(username string, size int64, dir string) {
+	_ = os.RemoveAll(dir)
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	delete(u.active, dir)
+	if u.usage[username] -= size; u.usage[username] <= 0 {
+		delete(u.usage, username)
+	}
+	telemetry.AddStagingBytes(-size)
+}
+
+// Stage 将上传的文件保存到暂存目录，返回文件路径与释放函数，调用方使用完毕后必须调用释放函数。
+// 暂存文件名与上传文件名相同，以便打包到容器时保留文件名。
+func (u *uploadStagingService) Stage(username string, file *multipart.FileHeader) (string, func(), error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", nil, fmt.Errorf("打开上传文件错误: %v", err)
+	}
+	defer src.Close()
+	return u.StageReader(username, file.Filename, file.Size, src)
+}
+
+// StageReader 与 Stage 相同，内容从 r 读取，读取的字节数必须等于 size
+func (u *uploadStagingService) StageReader(username, name string, size int64, r io.Reader) (string, func(), error) {
+	if name = filepath.Base(name); name == "." || name == ".." || name == string(filepath.Separator) {
+		return "", nil, fmt.Errorf("文件名无效")
+	}
+	if err := u.reserve(username, size); err != nil {
+		return "", nil, err
+	}
+	if err := os.MkdirAll(u.Root(), 0700); err != nil {
+		u.release(username, size, "")
+		return "", nil, fmt.Errorf("创建上传暂存目录错误: %v", err)
+	}
+	dir, err := os.MkdirTemp(u.Root(), "upload-*")
+	if err != nil {
+		u.release(username, size, "")
+		return "", nil, fmt.Errorf("创建临时目录错误: %v", err)
+	}
+	u.lock.Lock()
+	u.active[dir] = struct{}{}
+	u.lock.Unlock()
+	release := func() { u.release(username, size, dir) }
+
+	if err := copyToFile(filepath.Join(dir, name), size, r); err != nil {
+		release()
+		return "", nil, err
+	}
+	return filepath.Join(dir, name), release, nil
+}
+
+func copyToFile(dest string, size int64, r io.Reader) error {
+	tempFile, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("创建临时文件错误: %v", err)
+	}
+	defer tempFile.Close()
+
+	// 多读一个字节，用于发现实际内容超过声明的大小
+	n, err := io.Copy(tempFile, io.LimitReader(r, size+1))
+	if err != nil {
+		return fmt.Errorf("无法写入临时文件: %v", err)
+	}
+	if n != size {
+		return fmt.Errorf("上传内容 %d 字节，与声明的 %d 字节不一致", n, size)
+	}
+	return nil
+}
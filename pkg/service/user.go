@@ -16,6 +16,7 @@ import (
 	"github.com/weibaohui/k8m/internal/dao"
 	"github.com/weibaohui/k8m/pkg/comm/utils"
 	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/envelope"
 	"github.com/weibaohui/k8m/pkg/flag"
 	"github.com/weibaohui/k8m/pkg/models"
 	"gorm.io/gorm"
@@ -234,13 +235,35 @@ func (u *userService) GenerateJWTTokenOnlyUserName(username string, duration tim
 	if username == "" {
 		return "", errors.New("username cannot be empty")
 	}
-	name := constants.JwtUserName
-
-	var token = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		name:              username,
-		"isPlatformAdmin": u.IsUserPlatformAdmin(username), //前端展示平台管理员使用，没有其他用处
-		"exp":             time.Now().Add(duration).Unix(),
+	return u.signJWT(jwt.MapClaims{
+		constants.JwtUserName: username,
+		"isPlatformAdmin":     u.IsUserPlatformAdmin(username), //前端展示平台管理员使用，没有其他用处
+		"exp":                 time.Now().Add(duration).Unix(),
 	})
+}
+
+// GenerateLoginToken 生成登录会话 Token。
+// 与 GenerateJWTTokenOnlyUserName 相比多了签发时间 iat，修改密码或管理员强制轮换后，早于该时间签发的会话 Token 失效；
+// mustChangePassword 为 true 时 Token 只能用于修改密码
+func (u *userService) GenerateLoginToken(username string, duration time.Duration, mustChangePassword bool) (string, error) {
+	if username == "" {
+		return "", errors.New("username cannot be empty")
+	}
+	now := time.Now()
+	claims := jwt.MapClaims{
+		constants.JwtUserName: username,
+		"isPlatformAdmin":     u.IsUserPlatformAdmin(username), //前端展示平台管理员使用，没有其他用处
+		"iat":                 now.Unix(),
+		"exp":                 now.Add(duration).Unix(),
+	}
+	if mustChangePassword {
+		claims[constants.JwtMustChangePassword] = true
+	}
+	return u.signJWT(claims)
+}
+
+func (u *userService) signJWT(claims jwt.MapClaims) (string, error) {
+	var token = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	cfg := flag.Init()
 	var jwtSecret = []byte(cfg.JwtTokenSecret)
 	return token.SignedString(jwtSecret)
@@ -346,17 +369,18 @@ func (u *userService) searchRequest(conn *ldap.Conn, username string, config *mo
 		cur              *ldap.SearchResult
 		ldapFieldsFilter = []string{
 			"dn",
+			"memberOf",
 		}
 	)
 
 	// 解密管理员密码
-	bindPassword, err := utils.AesDecrypt(config.BindPassword)
+	bindPassword, err := envelope.Decrypt(config.BindPassword)
 	if err != nil {
 		klog.Errorf("LDAP密码解密失败: %v", err)
 		return nil, errors.New("LDAP配置错误")
 	}
 
-	err = conn.Bind(config.BindDN, string(bindPassword))
+	err = conn.Bind(config.BindDN, bindPassword)
 	if err != nil {
 		klog.Errorf("LDAP绑定失败: %v", err)
 		return nil, errors.New("LDAP认证失败")
@@ -437,3 +461,38 @@ func (u *userService) IsUserPlatformAdmin(user string) bool {
 	}
 	return slices.Contains(roles, constants.RolePlatformAdmin)
 }
+
+// IsUserClusterAdmin 判断用户是否为平台管理员或指定集群的集群管理员
+func (u *userService) IsUserClusterAdmin(user string, cluster string) bool {
+	if u.IsUserPlatformAdmin(user) {
+		return true
+	}
+	roles, err := u.GetClusters(user)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(roles, func(r *models.ClusterUserRole) bool {
+		return r.Cluster == cluster && r.Role == constants.RoleClusterAdmin
+	})
+}
+
+// CheckClusterAccess 校验用户是否有权限访问指定集群，且集群已连接。
+// 用于请求体中携带多个集群、无法经过集群中间件校验的场景。
+func (u *userService) CheckClusterAccess(username string, clusterID string) error {
+	if clusterID == "" {
+		return errors.New("未指定集群")
+	}
+	if !u.IsUserPlatformAdmin(username) {
+		clusters, err := u.GetClusterNames(username)
+		if err != nil {
+			return fmt.Errorf("获取集群授权失败: %w", err)
+		}
+		if !slices.Contains(clusters, clusterID) {
+			return fmt.Errorf("无权限访问集群: %s", clusterID)
+		}
+	}
+	if !ClusterService().IsConnected(clusterID) {
+		return fmt.Errorf("集群未连接，请先连接集群: %s", clusterID)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm"
+)
+
+// EnsureExternalUser 校验由客户端证书或可信代理认证的外部用户：不存在时按来源自动创建，已禁用时返回错误。
+// 结果缓存 5 分钟，禁用、删除用户时会清除缓存
+func (u *userService) EnsureExternalUser(username, source string) error {
+	cacheKey := u.formatCacheKey("user:external:%s", username)
+	disabled, err := utils.GetOrSetCache(CacheService().CacheInstance(), cacheKey, 5*time.Minute, func() (bool, error) {
+		user := &models.User{}
+		err := dao.DB().Select("disabled").Where("username = ?", username).First(user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, u.CheckAndCreateUser(username, source, "")
+		}
+		return user.Disabled, err
+	})
+	if err != nil {
+		return err
+	}
+	if disabled {
+		return fmt.Errorf("用户 %s 已被禁用", username)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/models"
+	"k8s.io/klog/v2"
+)
+
+// MapExternalGroups 按映射将外部身份源的用户组转换为 k8m 用户组，结果去重。
+// keepUnmapped 为 true 时未配置映射的组名原样保留，兼容 OIDC 直接以 groups 声明作为用户组的方式；
+// LDAP 的组以 DN 传入，映射中既可以写完整 DN，也可以只写 CN
+func (u *userService) MapExternalGroups(source string, external []string, keepUnmapped bool) []string {
+	var mappings []*models.ExternalGroupMapping
+	if err := dao.DB().Where("source = ?", source).Find(&mappings).Error; err != nil {
+		klog.V(6).Infof("读取外部用户组映射失败: %v", err)
+	}
+	var groups []string
+	add := func(g string) {
+		if g != "" && !slices.Contains(groups, g) {
+			groups = append(groups, g)
+		}
+	}
+	for _, ext := range external {
+		ext = strings.TrimSpace(ext)
+		if ext == "" {
+			continue
+		}
+		cn := ext
+		if source == models.ExternalGroupSourceLDAP {
+			cn = ldapGroupCN(ext)
+		}
+		mapped := false
+		for _, m := range mappings {
+			if strings.EqualFold(m.ExternalGroup, ext) || strings.EqualFold(m.ExternalGroup, cn) {
+				add(m.GroupName)
+				mapped = true
+			}
+		}
+		if !mapped && keepUnmapped {
+			add(ext)
+		}
+	}
+	return groups
+}
+
+// ldapGroupCN 返回 LDAP 组 DN 中第一个 RDN 的值，如 cn=dev,ou=groups,dc=example,dc=com 返回 dev
+func ldapGroupCN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return dn
+	}
+	return parsed.RDNs[0].Attributes[0].Value
+}
+
+// AssignGroups 设置用户所在的用户组，用户组必须已存在
+func (u *userService) AssignGroups(userID uint, groupNames []string) error {
+	var user models.User
+	if err := dao.DB().Select("id", "username").Where("id = ?", userID).First(&user).Error; err != nil {
+		return fmt.Errorf("用户不存在: %w", err)
+	}
+	var names []string
+	for _, g := range groupNames {
+		if g = strings.TrimSpace(g); g != "" && !slices.Contains(names, g) {
+			names = append(names, g)
+		}
+	}
+	if len(names) > 0 {
+		var existing []string
+		if err := dao.DB().Model(&models.UserGroup{}).Where("group_name in ?", names).Pluck("group_name", &existing).Error; err != nil {
+			return err
+		}
+		for _, g := range names {
+			if !slices.Contains(existing, g) {
+				return fmt.Errorf("用户组 %s 不存在", g)
+			}
+		}
+	}
+	if err := user.UpdateColumn("group_names", strings.Join(names, ",")); err != nil {
+		return err
+	}
+	u.ClearCacheByKey(user.Username)
+	return nil
+}
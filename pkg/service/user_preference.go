@@ -0,0 +1,158 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/constants"
+	"github.com/weibaohui/k8m/pkg/i18n"
+	"github.com/weibaohui/k8m/pkg/models"
+	"gorm.io/gorm"
+)
+
+// 用户偏好的限制
+const (
+	preferenceMaxPageSize  = 500
+	preferenceMaxItems     = 200      // 收藏、固定资源、分页设置的最大条数
+	preferenceMaxUIState   = 64 << 10 // 界面状态序列化后的最大字节数
+	preferenceLangCacheTTL = time.Minute
+)
+
+var preferenceThemes = []string{"", "light", "dark", "auto"}
+
+type userPreferenceService struct{}
+
+// Get 读取用户偏好，未保存过时返回空偏好
+func (u *userPreferenceService) Get(username string) (*models.UserPreference, error) {
+	var pref models.UserPreference
+	err := dao.DB().Where("username = ?", username).First(&pref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.UserPreference{Username: username}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// Save 校验并整体保存用户偏好
+func (u *userPreferenceService) Save(username string, pref *models.UserPreference) error {
+	if err := validatePreference(pref); err != nil {
+		return err
+	}
+	existing, err := u.Get(username)
+	if err != nil {
+		return err
+	}
+	pref.ID = existing.ID
+	pref.Username = username
+	pref.CreatedAt = existing.CreatedAt
+	if err := pref.Save(nil); err != nil {
+		return err
+	}
+	utils.ClearCacheByKey(CacheService().CacheInstance(), preferenceLangCacheKey(username))
+	return nil
+}
+
+// SaveUIState 更新单项界面状态，value 为 nil 时删除该项
+func (u *userPreferenceService) SaveUIState(username, key string, value any) error {
+	if key == "" {
+		return fmt.Errorf("界面状态标识不能为空")
+	}
+	pref, err := u.Get(username)
+	if err != nil {
+		return err
+	}
+	if pref.UIState == nil {
+		pref.UIState = map[string]any{}
+	}
+	if value == nil {
+		delete(pref.UIState, key)
+	} else {
+		pref.UIState[key] = value
+	}
+	return u.Save(username, pref)
+}
+
+// Reset 删除用户偏好，恢复默认设置
+func (u *userPreferenceService) Reset(username string) error {
+	if err := dao.DB().Where("username = ?", username).Delete(&models.UserPreference{}).Error; err != nil {
+		return err
+	}
+	utils.ClearCacheByKey(CacheService().CacheInstance(), preferenceLangCacheKey(username))
+	return nil
+}
+
+// Language 读取用户设置的界面语言，未设置时返回空
+func (u *userPreferenceService) Language(username string) string {
+	if username == "" {
+		return ""
+	}
+	lang, err := utils.GetOrSetCache(CacheService().CacheInstance(), preferenceLangCacheKey(username), preferenceLangCacheTTL, func() (string, error) {
+		pref, err := u.Get(username)
+		if err != nil {
+			return "", err
+		}
+		return pref.Language, nil
+	})
+	if err != nil {
+		return ""
+	}
+	return lang
+}
+
+// LangFromRequest 读取当前登录用户的语言偏好，注册给 i18n 作为错误信息的翻译语言
+func (u *userPreferenceService) LangFromRequest(r *http.Request) string {
+	username, _ := r.Context().Value(constants.JwtUserName).(string)
+	return u.Language(username)
+}
+
+func preferenceLangCacheKey(username string) string {
+	return "user:preference:lang:" + username
+}
+
+func validatePreference(pref *models.UserPreference) error {
+	if pref.Language != "" && pref.Language != i18n.LangZh && pref.Language != i18n.LangEn {
+		return fmt.Errorf("不支持的语言: %s", pref.Language)
+	}
+	if !slices.Contains(preferenceThemes, pref.Theme) {
+		return fmt.Errorf("不支持的主题: %s", pref.Theme)
+	}
+	if pref.DefaultPageSize < 0 || pref.DefaultPageSize > preferenceMaxPageSize {
+		return fmt.Errorf("分页大小需在 1 到 %d 之间", preferenceMaxPageSize)
+	}
+	for page, size := range pref.PageSizes {
+		if size <= 0 || size > preferenceMaxPageSize {
+			return fmt.Errorf("页面 %s 的分页大小需在 1 到 %d 之间", page, preferenceMaxPageSize)
+		}
+	}
+	favoriteNamespaces := 0
+	for _, list := range pref.FavoriteNamespaces {
+		favoriteNamespaces += len(list)
+	}
+	if len(pref.PageSizes) > preferenceMaxItems || len(pref.FavoriteClusters) > preferenceMaxItems ||
+		favoriteNamespaces > preferenceMaxItems || len(pref.PinnedResources) > preferenceMaxItems {
+		return fmt.Errorf("收藏、固定资源与分页设置各自最多 %d 条", preferenceMaxItems)
+	}
+	for _, r := range pref.PinnedResources {
+		if r == nil || r.Cluster == "" || r.Kind == "" || r.Version == "" || r.Name == "" {
+			return fmt.Errorf("固定资源需包含集群、版本、类型与名称")
+		}
+	}
+	if pref.UIState != nil {
+		raw, err := json.Marshal(pref.UIState)
+		if err != nil {
+			return fmt.Errorf("界面状态不是有效的 JSON: %w", err)
+		}
+		if len(raw) > preferenceMaxUIState {
+			return fmt.Errorf("界面状态超过 %d KiB 上限", preferenceMaxUIState>>10)
+		}
+	}
+	return nil
+}
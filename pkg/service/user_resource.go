@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/weibaohui/k8m/internal/dao"
+	"github.com/weibaohui/k8m/pkg/models"
+	"github.com/weibaohui/kom/kom"
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// 收藏与最近访问的限制
+const (
+	FavoriteMaxPerUser     = 200
+	RecentMaxPerUser       = 50
+	dashboardHealthTimeout = 5 * time.Second
+	dashboardConcurrency   = 10
+)
+
+type userResourceService struct{}
+
+// ResourceStatus 收藏或最近访问的资源及其实时健康状态
+type ResourceStatus struct {
+	ID         uint                `json:"id"`
+	Ref        *models.ResourceRef `json:"ref"`
+	AccessedAt *time.Time          `json:"accessed_at,omitempty"`
+	Health     string              `json:"health"` // 取值见 ResourceHealth，资源不存在为 Missing，无法读取为 Unknown
+	Message    string              `json:"message,omitempty"`
+}
+
+// AddFavorite 收藏资源，重复收藏时返回已有记录
+func (u *userResourceService) AddFavorite(username string, ref *models.ResourceRef) (*models.ResourceFavorite, error) {
+	if err := validateResourceRef(ref); err != nil {
+		return nil, err
+	}
+	var existing models.ResourceFavorite
+	err := u.whereRef(username, ref).First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	var count int64
+	if err := dao.DB().Model(&models.ResourceFavorite{}).Where("created_by = ?", username).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count >= FavoriteMaxPerUser {
+		return nil, fmt.Errorf("每个用户最多收藏 %d 个资源", FavoriteMaxPerUser)
+	}
+	item := &models.ResourceFavorite{ResourceRef: *ref, CreatedBy: username}
+	if err := dao.DB().Create(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Touch 记录一次资源访问，只保留最近的 RecentMaxPerUser 条
+func (u *userResourceService) Touch(username string, ref *models.ResourceRef) {
+	if username == "" || validateResourceRef(ref) != nil {
+		return
+	}
+	now := time.Now()
+	err := dao.DB().Transaction(func(tx *gorm.DB) error {
+		var existing models.RecentResource
+		err := u.whereRefTx(tx, username, ref).First(&existing).Error
+		switch {
+		case err == nil:
+			return tx.Model(&existing).Updates(map[string]any{
+				"access_count": gorm.Expr("access_count + 1"),
+				"accessed_at":  now,
+			}).Error
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(&models.RecentResource{ResourceRef: *ref, AccessCount: 1, AccessedAt: now, CreatedBy: username}).Error; err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+		// 删除超出保留条数的旧记录
+		var stale []uint
+		if err := tx.Model(&models.RecentResource{}).Where("created_by = ?", username).
+			Order("accessed_at desc").Offset(RecentMaxPerUser).Pluck("id", &stale).Error; err != nil {
+			return err
+		}
+		if len(stale) > 0 {
+			return tx.Delete(&models.RecentResource{}, stale).Error
+		}
+		return nil
+	})
+	if err != nil {
+		klog.V(6).Infof("记录用户[%s]最近访问的资源失败: %v", username, err)
+	}
+}
+
+// ClearRecent 清空用户的最近访问记录
+func (u *userResourceService) ClearRecent(username string) error {
+	return dao.DB().Where("created_by = ?", username).Delete(&models.RecentResource{}).Error
+}
+
+// Dashboard 返回用户收藏与最近访问的资源，并以用户身份读取各资源的实时健康状态
+func (u *userResourceService) Dashboard(ctx context.Context, username string) (favorites, recents []*ResourceStatus, err error) {
+	var favs []*models.ResourceFavorite
+	if err := dao.DB().Where("created_by = ?", username).Order("id desc").Find(&favs).Error; err != nil {
+		return nil, nil, err
+	}
+	var recs []*models.RecentResource
+	if err := dao.DB().Where("created_by = ?", username).Order("accessed_at desc").Limit(RecentMaxPerUser).Find(&recs).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, f := range favs {
+		favorites = append(favorites, &ResourceStatus{ID: f.ID, Ref: &f.ResourceRef})
+	}
+	for _, r := range recs {
+		recents = append(recents, &ResourceStatus{ID: r.ID, Ref: &r.ResourceRef, AccessedAt: &r.AccessedAt})
+	}
+
+	// 同一资源同时出现在收藏与最近访问中时只读取一次
+	type health struct{ status, message string }
+	results := map[models.ResourceRef]*health{}
+	all := append(append([]*ResourceStatus{}, favorites...), recents...)
+	for _, item := range all {
+		results[*item.Ref] = &health{}
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, dashboardConcurrency)
+	for ref, h := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ref models.ResourceRef, h *health) {
+			defer func() { <-sem; wg.Done() }()
+			h.status, h.message = u.health(ctx, &ref)
+		}(ref, h)
+	}
+	wg.Wait()
+	for _, item := range all {
+		h := results[*item.Ref]
+		item.Health, item.Message = h.status, h.message
+	}
+	return favorites, recents, nil
+}
+
+func (u *userResourceService) health(ctx context.Context, ref *models.ResourceRef) (string, string) {
+	k := kom.Cluster(ref.Cluster)
+	if k == nil {
+		return HealthUnknown, fmt.Sprintf("集群 %s 未连接", ref.Cluster)
+	}
+	ctx, cancel := context.WithTimeout(ctx, dashboardHealthTimeout)
+	defer cancel()
+	var obj *unstructured.Unstructured
+	err := k.WithContext(ctx).CRD(ref.Group, ref.Version, ref.Kind).Namespace(ref.Namespace).Name(ref.Name).Get(&obj).Error
+	if apierrors.IsNotFound(err) {
+		return HealthMissing, "资源不存在"
+	}
+	if err != nil {
+		return HealthUnknown, err.Error()
+	}
+	return ResourceHealth(obj)
+}
+
+func (u *userResourceService) whereRef(username string, ref *models.ResourceRef) *gorm.DB {
+	return u.whereRefTx(dao.DB(), username, ref)
+}
+
+func (u *userResourceService) whereRefTx(tx *gorm.DB, username string, ref *models.ResourceRef) *gorm.DB {
+	return tx.Where(map[string]any{
+		"created_by": username,
+		"cluster":    ref.Cluster,
+		"group":      ref.Group,
+		"kind":       ref.Kind,
+		"namespace":  ref.Namespace,
+		"name":       ref.Name,
+	})
+}
+
+func validateResourceRef(ref *models.ResourceRef) error {
+	if ref == nil || ref.Cluster == "" || ref.Version == "" || ref.Kind == "" || ref.Name == "" {
+		return fmt.Errorf("资源需包含集群、版本、类型与名称")
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/weibaohui/k8m/pkg/comm/utils"
+	"github.com/weibaohui/k8m/pkg/registry"
+	"github.com/weibaohui/kom/kom"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PreviousImageAnnotationPrefix 记录容器上一次镜像的注解前缀，完整 key 为 previous-image.k8m.io/<容器名>。
+// 注解写在工作负载自身而非 Pod 模板上，修改注解不会触发滚动更新
+const PreviousImageAnnotationPrefix = "previous-image.k8m.io/"
+
+// WorkloadImageUpdate 更新工作负载中单个容器的镜像
+type WorkloadImageUpdate struct {
+	Container string `json:"container"`            // 容器名，可以是 initContainer
+	Image     string `json:"image"`                // 完整镜像名，可带标签或摘要，如 nginx:1.25、nginx@sha256:xxx
+	PinDigest bool   `json:"pin_digest,omitempty"` // 更新时将标签解析为摘要，写入 image:tag@sha256:xxx，避免标签被重新推送后各副本镜像不一致
+	Platform  string `json:"platform,omitempty"`   // 解析摘要时多架构镜像选择的平台，默认 linux/amd64；写入的是多架构索引的摘要
+}
+
+// WorkloadImageResult 镜像更新或回滚结果
+type WorkloadImageResult struct {
+	Container string `json:"container"`
+	Init      bool   `json:"init,omitempty"`
+	Previous  string `json:"previous"` // 更新前的镜像，已记录在注解中，可一键回滚
+	Image     string `json:"image"`    // 更新后的镜像
+	Digest    string `json:"digest,omitempty"`
+}
+
+// workload 支持更新镜像的工作负载
+type workload struct {
+	obj         runtime.Object
+	annotations map[string]string
+	spec        *v1.PodSpec
+}
+
+// getWorkload 读取 Deployment、StatefulSet、DaemonSet
+func (s *imageService) getWorkload(ctx context.Context, cluster, kind, ns, name string) (*workload, error) {
+	k := kom.Cluster(cluster).WithContext(ctx)
+	switch strings.ToLower(kind) {
+	case "deployment":
+		var obj appsv1.Deployment
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &workload{obj: &obj, annotations: obj.Annotations, spec: &obj.Spec.Template.Spec}, err
+	case "statefulset":
+		var obj appsv1.StatefulSet
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &workload{obj: &obj, annotations: obj.Annotations, spec: &obj.Spec.Template.Spec}, err
+	case "daemonset":
+		var obj appsv1.DaemonSet
+		err := k.Resource(&obj).Namespace(ns).Name(name).Get(&obj).Error
+		return &workload{obj: &obj, annotations: obj.Annotations, spec: &obj.Spec.Template.Spec}, err
+	}
+	return nil, fmt.Errorf("不支持的资源类型: %s，仅支持 Deployment、StatefulSet、DaemonSet", kind)
+}
+
+// container 查找容器当前镜像，init 表示是否为 initContainer
+func (w *workload) container(name string) (image string, init bool, err error) {
+	for _, c := range w.spec.Containers {
+		if c.Name == name {
+			return c.Image, false, nil
+		}
+	}
+	for _, c := range w.spec.InitContainers {
+		if c.Name == name {
+			return c.Image, true, nil
+		}
+	}
+	return "", false, fmt.Errorf("容器 %s 不存在", name)
+}
+
+// pullSecrets Pod 模板中的 imagePullSecrets 名称
+func (w *workload) pullSecrets() []string {
+	var names []string
+	for _, s := range w.spec.ImagePullSecrets {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// UpdateWorkloadImage 更新工作负载中单个容器的镜像，并在注解中记录更新前的镜像用于回滚
+func (s *imageService) UpdateWorkloadImage(ctx context.Context, cluster, kind, ns, name string, req *WorkloadImageUpdate) (*WorkloadImageResult, error) {
+	req.Image = strings.TrimSpace(req.Image)
+	if req.Container == "" {
+		return nil, fmt.Errorf("容器名称不能为空")
+	}
+	ref, err := registry.ParseReference(req.Image)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.getWorkload(ctx, cluster, kind, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	current, init, err := w.container(req.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkloadImageResult{Container: req.Container, Init: init, Previous: current, Image: req.Image, Digest: ref.Digest}
+	if req.PinDigest && ref.Digest == "" {
+		info, err := s.Inspect(ctx, cluster, ns, req.Image, w.pullSecrets(), req.Platform)
+		if err != nil {
+			return nil, fmt.Errorf("解析镜像 %s 的摘要失败: %w", req.Image, err)
+		}
+		if info.Digest == "" {
+			return nil, fmt.Errorf("镜像仓库未返回 %s 的摘要", req.Image)
+		}
+		result.Image, result.Digest = req.Image+"@"+info.Digest, info.Digest
+	}
+	if result.Image == current {
+		return nil, fmt.Errorf("容器 %s 已在使用镜像 %s", req.Container, current)
+	}
+
+	if err := s.patchContainerImage(ctx, cluster, w, ns, name, req.Container, init, result.Image, current); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RollbackWorkloadImage 将容器镜像恢复为注解中记录的上一次镜像，回滚前的镜像同样被记录，再次回滚即可撤销
+func (s *imageService) RollbackWorkloadImage(ctx context.Context, cluster, kind, ns, name, container string) (*WorkloadImageResult, error) {
+	w, err := s.getWorkload(ctx, cluster, kind, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	current, init, err := w.container(container)
+	if err != nil {
+		return nil, err
+	}
+	previous := w.annotations[PreviousImageAnnotationPrefix+container]
+	if previous == "" {
+		return nil, fmt.Errorf("容器 %s 没有可回滚的镜像记录", container)
+	}
+	if previous == current {
+		return nil, fmt.Errorf("容器 %s 已在使用镜像 %s", container, current)
+	}
+
+	if err := s.patchContainerImage(ctx, cluster, w, ns, name, container, init, previous, current); err != nil {
+		return nil, err
+	}
+	result := &WorkloadImageResult{Container: container, Init: init, Previous: current, Image: previous}
+	if ref, err := registry.ParseReference(previous); err == nil {
+		result.Digest = ref.Digest
+	}
+	return result, nil
+}
+
+// patchContainerImage 通过 kom 以策略合并方式更新镜像与注解，权限、审计、准入策略等回调均生效
+func (s *imageService) patchContainerImage(ctx context.Context, cluster string, w *workload, ns, name, container string, init bool, image, previous string) error {
+	field := "containers"
+	if init {
+		field = "initContainers"
+	}
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{PreviousImageAnnotationPrefix + container: previous},
+		},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					field: []map[string]string{{"name": container, "image": image}},
+				},
+			},
+		},
+	}
+	return kom.Cluster(cluster).WithContext(ctx).Resource(w.obj).Namespace(ns).Name(name).
+		Patch(w.obj, types.StrategicMergePatchType, utils.ToJSON(patch)).Error
+}
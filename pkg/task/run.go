@@ -0,0 +1,130 @@
+// Package task 长耗时任务的执行状态：进度、日志、取消与变更通知。
+// 持久化与调度由 service 层负责，这里只维护单个任务在内存中的状态
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 任务状态
+const (
+	StatusPending   = "pending"   // 排队等待执行
+	StatusRunning   = "running"   // 执行中
+	StatusSucceeded = "succeeded" // 执行成功
+	StatusFailed    = "failed"    // 执行失败
+	StatusCancelled = "cancelled" // 已取消
+)
+
+// Finished 状态是否为终态
+func Finished(status string) bool {
+	return status == StatusSucceeded || status == StatusFailed || status == StatusCancelled
+}
+
+// MaxLogSize 保留的日志大小上限，超出后丢弃最早的内容
+const MaxLogSize = 64 << 10
+
+// State 任务某一时刻的进度
+type State struct {
+	Progress int    `json:"progress"`
+	Message  string `json:"message,omitempty"`
+	Log      string `json:"log,omitempty"`
+	LogSize  int    `json:"log_size"` // 累计写入的日志字节数，包含已丢弃的部分，用于计算增量
+}
+
+// Run 正在执行的任务，供任务函数汇报进度、写日志并响应取消
+type Run struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	state   State
+	log     []byte
+	dirty   bool
+	changed chan struct{}
+}
+
+// NewRun 创建任务执行状态，parent 取消或调用 Cancel 时任务上下文随之取消
+func NewRun(parent context.Context) *Run {
+	ctx, cancel := context.WithCancel(parent)
+	return &Run{ctx: ctx, cancel: cancel, changed: make(chan struct{})}
+}
+
+// Context 任务上下文，任务函数应在其取消后尽快返回
+func (r *Run) Context() context.Context {
+	return r.ctx
+}
+
+// Cancel 请求取消任务
+func (r *Run) Cancel() {
+	r.cancel()
+}
+
+// Progress 更新进度百分比与当前说明，进度限定在 0-100
+func (r *Run) Progress(percent int, format string, args ...any) {
+	percent = max(0, min(percent, 100))
+	r.update(func() {
+		r.state.Progress = percent
+		r.state.Message = fmt.Sprintf(format, args...)
+	})
+}
+
+// Logf 追加一行带时间的日志
+func (r *Run) Logf(format string, args ...any) {
+	line := time.Now().Format(time.TimeOnly) + " " + strings.TrimRight(fmt.Sprintf(format, args...), "\n") + "\n"
+	r.update(func() {
+		r.log = append(r.log, line...)
+		r.state.LogSize += len(line)
+		if over := len(r.log) - MaxLogSize; over > 0 {
+			r.log = r.log[over:]
+		}
+	})
+}
+
+func (r *Run) update(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn()
+	r.dirty = true
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// State 返回当前进度
+func (r *Run) State() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.state
+	s.Log = string(r.log)
+	return s
+}
+
+// Flush 返回自上次 Flush 以来是否有变化及当前进度，用于节流写入数据库
+func (r *Run) Flush() (State, bool) {
+	r.mu.Lock()
+	dirty := r.dirty
+	r.dirty = false
+	r.mu.Unlock()
+	return r.State(), dirty
+}
+
+// Changed 返回在下一次进度或日志变化时关闭的通道
+func (r *Run) Changed() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changed
+}
+
+// LogDelta 返回客户端已收到 sent 字节后新增的日志。新增部分已被丢弃时返回全部保留的日志
+func LogDelta(log string, logSize, sent int) string {
+	if sent >= logSize {
+		return ""
+	}
+	if n := logSize - sent; n < len(log) {
+		return log[len(log)-n:]
+	}
+	return log
+}
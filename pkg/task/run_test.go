@@ -0,0 +1,80 @@
+package task
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunProgressAndLog(t *testing.T) {
+	r := NewRun(context.Background())
+	changed := r.Changed()
+	r.Progress(150, "驱逐节点 %s", "n1")
+	select {
+	case <-changed:
+	default:
+		t.Fatal("更新进度后应通知变化")
+	}
+	r.Logf("开始驱逐\n")
+	r.Logf("完成")
+
+	s, dirty := r.Flush()
+	if !dirty || s.Progress != 100 || s.Message != "驱逐节点 n1" {
+		t.Fatalf("unexpected state: %+v dirty=%v", s, dirty)
+	}
+	if lines := strings.Split(strings.TrimSpace(s.Log), "\n"); len(lines) != 2 || !strings.HasSuffix(lines[0], " 开始驱逐") {
+		t.Fatalf("unexpected log: %q", s.Log)
+	}
+	if _, dirty := r.Flush(); dirty {
+		t.Fatal("未变化时 Flush 不应返回 dirty")
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	r := NewRun(parent)
+	cancel()
+	<-r.Context().Done()
+
+	r = NewRun(context.Background())
+	r.Cancel()
+	if r.Context().Err() == nil {
+		t.Fatal("Cancel 后上下文应取消")
+	}
+}
+
+func TestLogTruncateAndDelta(t *testing.T) {
+	r := NewRun(context.Background())
+	line := strings.Repeat("x", 1023)
+	for i := 0; i < 80; i++ {
+		r.Logf("%s", line)
+	}
+	s := r.State()
+	if len(s.Log) > MaxLogSize {
+		t.Fatalf("日志应限制在 %d 字节内，实际 %d", MaxLogSize, len(s.Log))
+	}
+	if s.LogSize <= MaxLogSize {
+		t.Fatalf("LogSize 应累计全部写入: %d", s.LogSize)
+	}
+
+	if got := LogDelta("abcdef", 10, 10); got != "" {
+		t.Errorf("无新增: %q", got)
+	}
+	if got := LogDelta("abcdef", 10, 8); got != "ef" {
+		t.Errorf("增量: %q", got)
+	}
+	if got := LogDelta("abcdef", 10, 0); got != "abcdef" {
+		t.Errorf("新增部分已丢弃时应返回全部: %q", got)
+	}
+}
+
+func TestFinished(t *testing.T) {
+	for status, want := range map[string]bool{
+		StatusPending: false, StatusRunning: false,
+		StatusSucceeded: true, StatusFailed: true, StatusCancelled: true,
+	} {
+		if Finished(status) != want {
+			t.Errorf("Finished(%s) != %v", status, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8m_http_requests_total",
+		Help: "k8m API 请求总数",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k8m_http_request_duration_seconds",
+		Help:    "k8m API 请求耗时",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	apiErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8m_api_errors_total",
+		Help: "k8m API 返回业务错误的次数（含HTTP状态码为200但status非0的amis错误响应）",
+	}, []string{"route"})
+
+	fileTransferBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8m_file_transfer_bytes_total",
+		Help: "容器文件上传、下载的字节数",
+	}, []string{"direction"})
+
+	uploadStagingBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "k8m_upload_staging_bytes",
+		Help: "上传暂存目录中正在使用的字节数",
+	})
+
+	websocketSessions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k8m_websocket_sessions",
+		Help: "当前活跃的 WebSocket 会话数",
+	}, []string{"type"})
+
+	kubeRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8m_kube_requests_total",
+		Help: "k8m 向集群 apiserver 发起的请求总数",
+	}, []string{"cluster", "method", "code"})
+
+	kubeRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k8m_kube_request_duration_seconds",
+		Help:    "k8m 向集群 apiserver 发起请求的耗时",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"cluster", "method"})
+)
+
+// RoutePattern 获取 chi 匹配到的路由模板，避免将路径参数作为指标标签导致基数爆炸
+func RoutePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if p := rctx.RoutePattern(); p != "" {
+			return p
+		}
+	}
+	return "unmatched"
+}
+
+// ObserveHTTPRequest 记录一次 API 请求
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// RecordAPIError 记录一次业务错误响应
+func RecordAPIError(r *http.Request) {
+	if r == nil {
+		return
+	}
+	apiErrorsTotal.WithLabelValues(RoutePattern(r)).Inc()
+}
+
+// AddUploadBytes 累计上传到容器的字节数
+func AddUploadBytes(n int64) {
+	fileTransferBytes.WithLabelValues("upload").Add(float64(n))
+}
+
+// AddDownloadBytes 累计从容器下载的字节数
+func AddDownloadBytes(n int64) {
+	fileTransferBytes.WithLabelValues("download").Add(float64(n))
+}
+
+// AddStagingBytes 调整上传暂存占用的字节数，释放时传入负数
+func AddStagingBytes(n int64) {
+	uploadStagingBytes.Add(float64(n))
+}
+
+// SetStagingBytes 设置上传暂存占用的字节数
+func SetStagingBytes(n int64) {
+	uploadStagingBytes.Set(float64(n))
+}
+
+// TrackWebSocketSession 活跃会话数加一，返回的函数在会话结束时调用
+func TrackWebSocketSession(sessionType string) func() {
+	g := websocketSessions.WithLabelValues(sessionType)
+	g.Inc()
+	return g.Dec
+}
@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+const tracerName = "github.com/weibaohui/k8m"
+
+// InitTracer 初始化 OpenTelemetry 链路追踪，endpoint 为空时不导出链路数据。
+// 返回的函数用于在退出时刷新并关闭导出器。
+func InitTracer(endpoint string, serviceName string, version string) func(context.Context) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if endpoint == "" {
+		return func(context.Context) error { return nil }
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		klog.Errorf("初始化 OpenTelemetry 导出器失败: %v", err)
+		return func(context.Context) error { return nil }
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(5*time.Second)),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	klog.V(2).Infof("已启用 OpenTelemetry 链路追踪，导出地址: %s", endpoint)
+	return tp.Shutdown
+}
+
+// Tracer 获取 k8m 的 Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
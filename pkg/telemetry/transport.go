@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WrapKubeTransport 返回 rest.Config.WrapTransport 使用的包装函数，
+// 为访问集群 apiserver 的每个请求记录指标并创建子 Span
+func WrapKubeTransport(cluster string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &kubeTransport{cluster: cluster, next: rt}
+	}
+}
+
+type kubeTransport struct {
+	cluster string
+	next    http.RoundTripper
+}
+
+func (t *kubeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Tracer().Start(req.Context(), "kube "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("k8m.cluster", t.cluster),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+		))
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	kubeRequestDuration.WithLabelValues(t.cluster, req.Method).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		code = strconv.Itoa(resp.StatusCode)
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 500 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	kubeRequestsTotal.WithLabelValues(t.cluster, req.Method, code).Inc()
+	return resp, err
+}
@@ -0,0 +1,269 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// AgentOptions agent 运行参数
+type AgentOptions struct {
+	Server     string       // k8m 访问地址，如 https://k8m.example.com
+	Token      string       // k8m 管理员为该 agent 签发的令牌，令牌与集群名称绑定
+	Name       string       // 集群名称，可为空；非空时须与令牌绑定的名称一致
+	RestConfig *rest.Config // 访问本集群 apiserver 的配置，通常为 InCluster 配置
+}
+
+// ConnectPath k8m 端接收 agent 连接的路径
+const ConnectPath = "/agent/connect"
+
+// pingInterval agent 定期发送 ping，避免中间代理因空闲断开长连接
+const pingInterval = 20 * time.Second
+
+// RunAgent 持续与 k8m 保持隧道连接，断开后按退避间隔重连，直到 ctx 结束
+func RunAgent(ctx context.Context, opts AgentOptions) error {
+	if opts.Server == "" || opts.Token == "" {
+		return fmt.Errorf("server、token 均不能为空")
+	}
+	httpClient, err := rest.HTTPClientFor(opts.RestConfig)
+	if err != nil {
+		return fmt.Errorf("创建 apiserver 客户端失败: %w", err)
+	}
+	apiServer, err := url.Parse(opts.RestConfig.Host)
+	if err != nil {
+		return fmt.Errorf("解析 apiserver 地址失败: %w", err)
+	}
+	wsURL, err := connectURL(opts.Server)
+	if err != nil {
+		return err
+	}
+
+	backoff := time.Second
+	for {
+		start := time.Now()
+		err := runOnce(ctx, wsURL, opts, httpClient, apiServer)
+		if ctx.Err() != nil {
+			return nil
+		}
+		// 连接保持过一段时间说明服务端正常，重置退避
+		if time.Since(start) > time.Minute {
+			backoff = time.Second
+		}
+		klog.Warningf("与 k8m 的隧道断开: %v，%s 后重连", err, backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+	}
+}
+
+// connectURL 将 k8m 访问地址转换为 WebSocket 连接地址
+func connectURL(server string) (string, error) {
+	u, err := url.Parse(strings.TrimRight(server, "/"))
+	if err != nil {
+		return "", fmt.Errorf("解析 k8m 地址失败: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http", "":
+		u.Scheme = "ws"
+	}
+	u.Path += ConnectPath
+	return u.String(), nil
+}
+
+// runOnce 建立一次隧道连接并处理请求，连接断开后返回
+func runOnce(ctx context.Context, wsURL string, opts AgentOptions, httpClient *http.Client, apiServer *url.URL) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+opts.Token)
+	if opts.Name != "" {
+		header.Set(AgentNameHeader, opts.Name)
+	}
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("连接 %s 失败(%s): %w", wsURL, resp.Status, err)
+		}
+		return fmt.Errorf("连接 %s 失败: %w", wsURL, err)
+	}
+	defer conn.Close()
+	klog.Infof("已与 k8m 建立隧道 %s", wsURL)
+
+	a := &agentConn{conn: conn, client: httpClient, apiServer: apiServer, cancels: map[uint64]context.CancelFunc{}, windows: map[uint64]*sendWindow{}}
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer a.cancelAll()
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-connCtx.Done():
+				_ = conn.Close()
+				return
+			case <-ticker.C:
+				a.writeMu.Lock()
+				err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+				a.writeMu.Unlock()
+				if err != nil {
+					_ = conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		var f Frame
+		if err := conn.ReadJSON(&f); err != nil {
+			return err
+		}
+		switch f.Type {
+		case FrameRequest:
+			reqCtx, reqCancel := context.WithCancel(connCtx)
+			window := newSendWindow()
+			a.mu.Lock()
+			a.cancels[f.ID] = reqCancel
+			a.windows[f.ID] = window
+			a.mu.Unlock()
+			go a.handle(reqCtx, f, window)
+		case FrameWindow:
+			a.mu.Lock()
+			window := a.windows[f.ID]
+			a.mu.Unlock()
+			if window != nil {
+				window.add(f.Window)
+			}
+		case FrameCancel:
+			a.mu.Lock()
+			if c, ok := a.cancels[f.ID]; ok {
+				c()
+				delete(a.cancels, f.ID)
+			}
+			a.mu.Unlock()
+		}
+	}
+}
+
+// agentConn agent 端的一条隧道
+type agentConn struct {
+	conn      *websocket.Conn
+	writeMu   sync.Mutex
+	client    *http.Client
+	apiServer *url.URL
+
+	mu      sync.Mutex
+	cancels map[uint64]context.CancelFunc
+	windows map[uint64]*sendWindow
+}
+
+func (a *agentConn) write(f *Frame) error {
+	a.writeMu.Lock()
+	defer a.writeMu.Unlock()
+	return a.conn.WriteJSON(f)
+}
+
+func (a *agentConn) cancelAll() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id, c := range a.cancels {
+		c()
+		delete(a.cancels, id)
+	}
+}
+
+// handle 使用本地凭据调用 apiserver，并在窗口内将响应流式回传
+func (a *agentConn) handle(ctx context.Context, f Frame, window *sendWindow) {
+	defer func() {
+		a.mu.Lock()
+		if c, ok := a.cancels[f.ID]; ok {
+			c()
+			delete(a.cancels, f.ID)
+		}
+		delete(a.windows, f.ID)
+		a.mu.Unlock()
+	}()
+
+	end := func(err error) {
+		frame := &Frame{Type: FrameEnd, ID: f.ID}
+		if err != nil {
+			frame.Error = err.Error()
+		}
+		_ = a.write(frame)
+	}
+
+	target, err := a.apiServer.Parse(f.URL)
+	if err != nil {
+		end(fmt.Errorf("解析请求地址失败: %w", err))
+		return
+	}
+	// 只允许访问本集群 apiserver，防止隧道被用于访问其他地址
+	target.Scheme, target.Host = a.apiServer.Scheme, a.apiServer.Host
+	var body io.Reader
+	if len(f.Body) > 0 {
+		body = strings.NewReader(string(f.Body))
+	}
+	req, err := http.NewRequestWithContext(ctx, f.Method, target.String(), body)
+	if err != nil {
+		end(err)
+		return
+	}
+	for k, v := range f.Header {
+		// 认证信息由 agent 自身的凭据提供，忽略 server 端传入的值
+		if strings.EqualFold(k, "Authorization") {
+			continue
+		}
+		req.Header[k] = v
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		end(err)
+		return
+	}
+	defer resp.Body.Close()
+	if err := a.write(&Frame{Type: FrameResponse, ID: f.ID, Status: resp.StatusCode, Header: resp.Header}); err != nil {
+		return
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		for sent := 0; sent < n; {
+			size, werr := window.acquire(ctx, n-sent)
+			if werr != nil {
+				return
+			}
+			if werr := a.write(&Frame{Type: FrameData, ID: f.ID, Body: buf[sent : sent+size]}); werr != nil {
+				return
+			}
+			sent += size
+		}
+		if err == io.EOF {
+			end(nil)
+			return
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				end(err)
+			}
+			return
+		}
+	}
+}
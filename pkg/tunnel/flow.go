@@ -0,0 +1,142 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// InitialWindow 每个流的初始发送窗口。agent 发送的响应体数据不超过 server 授予的窗口，
+// server 的读循环将数据放入流各自的缓冲区后立即返回，某个流的读取方处理慢不会阻塞同一隧道的其他流
+const InitialWindow = 256 * 1024
+
+// errWindowExceeded agent 发送的数据超出授予的窗口
+var errWindowExceeded = errors.New("agent 发送的数据超出流控窗口")
+
+// streamBuffer server 端单个流的响应体缓冲区，读取方读取后通过 onConsume 归还窗口
+type streamBuffer struct {
+	mu           sync.Mutex
+	cond         *sync.Cond
+	buf          bytes.Buffer
+	err          error // 写入方结束的原因，正常结束为 io.EOF
+	readerClosed bool
+	consumed     int // 已读取但尚未归还的字节数
+	onConsume    func(n int)
+}
+
+func newStreamBuffer(onConsume func(n int)) *streamBuffer {
+	b := &streamBuffer{onConsume: onConsume}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// Write 追加数据，不等待读取方
+func (b *streamBuffer) Write(p []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case b.readerClosed:
+		return io.ErrClosedPipe
+	case b.err != nil:
+		return b.err
+	case b.buf.Len()+len(p) > InitialWindow:
+		return errWindowExceeded
+	}
+	b.buf.Write(p)
+	b.cond.Broadcast()
+	return nil
+}
+
+// CloseWithError 结束写入，err 为空时读取方读完缓冲区后收到 EOF
+func (b *streamBuffer) CloseWithError(err error) {
+	if err == nil {
+		err = io.EOF
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err == nil {
+		b.err = err
+	}
+	b.cond.Broadcast()
+}
+
+// Read 读取缓冲区，每读取半个窗口归还一次
+func (b *streamBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	for b.buf.Len() == 0 && b.err == nil && !b.readerClosed {
+		b.cond.Wait()
+	}
+	if b.readerClosed {
+		b.mu.Unlock()
+		return 0, io.ErrClosedPipe
+	}
+	if b.buf.Len() == 0 {
+		err := b.err
+		b.mu.Unlock()
+		return 0, err
+	}
+	n, _ := b.buf.Read(p)
+	b.consumed += n
+	var credit int
+	if b.consumed >= InitialWindow/2 && b.err == nil {
+		credit, b.consumed = b.consumed, 0
+	}
+	b.mu.Unlock()
+	if credit > 0 && b.onConsume != nil {
+		b.onConsume(credit)
+	}
+	return n, nil
+}
+
+// Close 读取方关闭，之后的写入返回错误
+func (b *streamBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.readerClosed = true
+	b.buf.Reset()
+	b.cond.Broadcast()
+	return nil
+}
+
+// sendWindow agent 端单个流的发送窗口
+type sendWindow struct {
+	mu     sync.Mutex
+	avail  int
+	signal chan struct{}
+}
+
+func newSendWindow() *sendWindow {
+	return &sendWindow{avail: InitialWindow, signal: make(chan struct{}, 1)}
+}
+
+// add 归还窗口
+func (w *sendWindow) add(n int) {
+	w.mu.Lock()
+	w.avail += n
+	w.mu.Unlock()
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// acquire 申请最多 n 字节的窗口，至少得到 1 字节，窗口耗尽时等待归还或 ctx 结束
+func (w *sendWindow) acquire(ctx context.Context, n int) (int, error) {
+	for {
+		w.mu.Lock()
+		if w.avail > 0 {
+			got := min(n, w.avail)
+			w.avail -= got
+			w.mu.Unlock()
+			return got, nil
+		}
+		w.mu.Unlock()
+		select {
+		case <-w.signal:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestStreamBuffer(t *testing.T) {
+	var credits []int
+	b := newStreamBuffer(func(n int) { credits = append(credits, n) })
+	chunk := bytes.Repeat([]byte("x"), InitialWindow/2)
+	if err := b.Write(chunk); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Write(chunk); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Write([]byte("y")); !errors.Is(err, errWindowExceeded) {
+		t.Fatalf("write beyond window err = %v", err)
+	}
+	if _, err := io.ReadFull(b, make([]byte, InitialWindow/2)); err != nil {
+		t.Fatal(err)
+	}
+	if len(credits) != 1 || credits[0] != InitialWindow/2 {
+		t.Errorf("credits = %v", credits)
+	}
+	b.CloseWithError(nil)
+	data, err := io.ReadAll(b)
+	if err != nil || len(data) != InitialWindow/2 {
+		t.Fatalf("read %d bytes, err %v", len(data), err)
+	}
+
+	closed := newStreamBuffer(nil)
+	_ = closed.Close()
+	if err := closed.Write([]byte("x")); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("write after reader close err = %v", err)
+	}
+}
+
+func TestSendWindow(t *testing.T) {
+	w := newSendWindow()
+	if n, err := w.acquire(context.Background(), InitialWindow*2); err != nil || n != InitialWindow {
+		t.Fatalf("acquire = %d, %v", n, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := w.acquire(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("exhausted window should block until ctx done, err = %v", err)
+	}
+	go w.add(10)
+	if n, err := w.acquire(context.Background(), 100); err != nil || n != 10 {
+		t.Fatalf("acquire after add = %d, %v", n, err)
+	}
+}
+
+// 一个流的读取方不读取时，同一隧道上的其他流仍可完成
+func TestSlowStreamDoesNotBlockOthers(t *testing.T) {
+	big := strings.Repeat("x", 4*InitialWindow)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			_, _ = io.WriteString(w, big)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer api.Close()
+
+	sessionCh := make(chan *Session, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s, err := Register("flow-test", conn)
+		if err != nil {
+			_ = conn.Close()
+			return
+		}
+		sessionCh <- s
+		_ = s.Serve()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	apiURL, _ := url.Parse(api.URL)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	go func() {
+		_ = runOnce(ctx, wsURL, AgentOptions{Token: "t"}, api.Client(), apiURL)
+	}()
+	var s *Session
+	select {
+	case s = <-sessionCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("agent did not connect")
+	}
+	defer s.close()
+
+	get := func(path string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, AgentHost+path, nil)
+		resp, err := s.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		return resp
+	}
+	slow := get("/slow")
+	defer slow.Body.Close()
+	// 等待 agent 填满慢速流的窗口
+	time.Sleep(100 * time.Millisecond)
+
+	done := make(chan string, 1)
+	go func() {
+		resp := get("/fast")
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		done <- string(b)
+	}()
+	select {
+	case got := <-done:
+		if got != "ok" {
+			t.Errorf("fast body = %q", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fast stream blocked by slow stream")
+	}
+
+	b, err := io.ReadAll(slow.Body)
+	if err != nil || len(b) != len(big) {
+		t.Fatalf("slow body %d bytes, err %v", len(b), err)
+	}
+}
@@ -0,0 +1,44 @@
+// Package tunnel 实现 k8m 与集群内 agent 之间的反向隧道。
+//
+// 位于防火墙或 NAT 之后的集群无法被 k8m 直接访问，agent 在集群内运行并主动向 k8m 建立
+// WebSocket 长连接。k8m 将发往该集群 apiserver 的 HTTP 请求封装为帧经隧道下发，
+// agent 使用自身的 ServiceAccount 调用本地 apiserver，再将响应以流的方式回传。
+// 每个流按窗口做流控，agent 只在 server 授予的窗口内发送数据，多个流共用一条连接时互不阻塞。
+//
+// 当前隧道只转发普通 HTTP 请求（含 watch 等长连接流），不支持 exec、port-forward 等需要协议升级的请求，
+// 因此经 agent 接入的集群无法使用终端、执行命令、文件管理与节点 Shell。集群能力探测将这类集群的 exec 能力标记为不可用，
+// 前端据此隐藏相关功能，相关接口直接返回说明。
+package tunnel
+
+import "net/http"
+
+// FrameType 隧道帧类型
+type FrameType string
+
+const (
+	FrameRequest  FrameType = "request"  // server -> agent，发起一次 HTTP 请求
+	FrameResponse FrameType = "response" // agent -> server，响应状态码与响应头
+	FrameData     FrameType = "data"     // agent -> server，响应体数据块
+	FrameEnd      FrameType = "end"      // agent -> server，响应结束，Error 非空表示异常结束
+	FrameCancel   FrameType = "cancel"   // server -> agent，调用方已放弃该请求
+	FrameWindow   FrameType = "window"   // server -> agent，调用方已读取 Window 字节，agent 可继续发送同样多的数据
+)
+
+// Frame 隧道中传输的一帧，同一请求的所有帧使用相同的 ID
+type Frame struct {
+	Type   FrameType   `json:"type"`
+	ID     uint64      `json:"id"`
+	Method string      `json:"method,omitempty"`
+	URL    string      `json:"url,omitempty"` // 请求路径及查询参数，如 /api/v1/pods?watch=true
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	Window int         `json:"window,omitempty"`
+}
+
+// AgentHost 经隧道访问集群时 rest.Config 使用的占位地址，实际请求不会按该地址建立连接
+const AgentHost = "http://k8m-agent"
+
+// AgentNameHeader agent 建立连接时通过该请求头上报集群名称
+const AgentNameHeader = "X-K8m-Agent-Name"
@@ -0,0 +1,298 @@
+package tunnel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// ErrSessionClosed 隧道已断开
+var ErrSessionClosed = errors.New("agent 隧道已断开")
+
+// ErrSessionExists 同名 agent 已有在线隧道
+var ErrSessionExists = errors.New("同名 agent 已在线")
+
+// readTimeout 超过该时间未收到 agent 的任何帧或 ping 即认为连接已失效，
+// 避免半开连接一直占用名称，导致 agent 重连时被当作重复连接拒绝
+const readTimeout = 3 * pingInterval
+
+// stream 一次经隧道转发的请求
+type stream struct {
+	header chan *Frame
+	body   *streamBuffer
+}
+
+// Session server 端的一条 agent 隧道，实现 http.RoundTripper
+type Session struct {
+	name    string
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	nextID  atomic.Uint64
+
+	mu      sync.Mutex
+	streams map[uint64]*stream
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newSession(name string, conn *websocket.Conn) *Session {
+	return &Session{
+		name:    name,
+		conn:    conn,
+		streams: map[uint64]*stream{},
+		closed:  make(chan struct{}),
+	}
+}
+
+// Name agent 上报的集群名称
+func (s *Session) Name() string {
+	return s.name
+}
+
+// Done 隧道断开后关闭
+func (s *Session) Done() <-chan struct{} {
+	return s.closed
+}
+
+func (s *Session) write(f *Frame) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteJSON(f)
+}
+
+// RoundTrip 将请求封装为帧发送给 agent，并等待响应头返回，响应体以流的方式读取
+func (s *Session) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Upgrade") != "" {
+		return nil, fmt.Errorf("agent 隧道不支持协议升级请求，终端、执行命令、文件管理等功能不可用: %s %s", req.Method, req.URL.Path)
+	}
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	id := s.nextID.Add(1)
+	st := &stream{header: make(chan *Frame, 1), body: newStreamBuffer(func(n int) {
+		_ = s.write(&Frame{Type: FrameWindow, ID: id, Window: n})
+	})}
+	s.mu.Lock()
+	select {
+	case <-s.closed:
+		s.mu.Unlock()
+		return nil, ErrSessionClosed
+	default:
+	}
+	s.streams[id] = st
+	s.mu.Unlock()
+
+	err := s.write(&Frame{
+		Type:   FrameRequest,
+		ID:     id,
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Header: req.Header,
+		Body:   body,
+	})
+	if err != nil {
+		s.finish(id, err)
+		return nil, err
+	}
+
+	select {
+	case f := <-st.header:
+		if f.Error != "" {
+			s.finish(id, nil)
+			return nil, errors.New(f.Error)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+			StatusCode:    f.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        f.Header,
+			Body:          &streamBody{streamBuffer: st.body, session: s, id: id},
+			ContentLength: -1,
+			Request:       req,
+		}, nil
+	case <-req.Context().Done():
+		s.cancel(id)
+		return nil, req.Context().Err()
+	case <-s.closed:
+		return nil, ErrSessionClosed
+	}
+}
+
+// cancel 通知 agent 停止处理该请求，并释放本地资源
+func (s *Session) cancel(id uint64) {
+	_ = s.write(&Frame{Type: FrameCancel, ID: id})
+	s.finish(id, io.ErrClosedPipe)
+}
+
+// finish 结束一个流，err 为空时读取方收到 EOF
+func (s *Session) finish(id uint64, err error) {
+	s.mu.Lock()
+	st, ok := s.streams[id]
+	delete(s.streams, id)
+	s.mu.Unlock()
+	if ok {
+		st.body.CloseWithError(err)
+	}
+}
+
+// serve 读取 agent 回传的帧并分发给对应的流，直到连接断开
+func (s *Session) serve() error {
+	defer s.close()
+	_ = s.conn.SetReadDeadline(time.Now().Add(readTimeout))
+	s.conn.SetPingHandler(func(data string) error {
+		_ = s.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		return s.conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(10*time.Second))
+	})
+	for {
+		var f Frame
+		if err := s.conn.ReadJSON(&f); err != nil {
+			return err
+		}
+		_ = s.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		s.mu.Lock()
+		st, ok := s.streams[f.ID]
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+		switch f.Type {
+		case FrameResponse:
+			st.header <- &f
+		case FrameData:
+			// 写入流各自的缓冲区，不等待读取方消费；读取方已关闭响应体或 agent 超出窗口时通知 agent 停止发送
+			if err := st.body.Write(f.Body); err != nil {
+				s.cancel(f.ID)
+			}
+		case FrameEnd:
+			if f.Error != "" {
+				// 尚未返回响应头时，通过 header 通道将错误交给 RoundTrip
+				select {
+				case st.header <- &f:
+				default:
+				}
+				s.finish(f.ID, errors.New(f.Error))
+			} else {
+				s.finish(f.ID, nil)
+			}
+		}
+	}
+}
+
+func (s *Session) close() {
+	s.once.Do(func() {
+		s.mu.Lock()
+		close(s.closed)
+		streams := s.streams
+		s.streams = map[uint64]*stream{}
+		s.mu.Unlock()
+		for _, st := range streams {
+			st.body.CloseWithError(ErrSessionClosed)
+		}
+		_ = s.conn.Close()
+		klog.V(4).Infof("agent[%s] 隧道已关闭", s.name)
+	})
+}
+
+// streamBody 响应体，关闭时通知 agent 取消仍在传输的请求
+type streamBody struct {
+	*streamBuffer
+	session *Session
+	id      uint64
+	once    sync.Once
+}
+
+func (b *streamBody) Close() error {
+	b.once.Do(func() {
+		b.session.mu.Lock()
+		_, running := b.session.streams[b.id]
+		b.session.mu.Unlock()
+		if running {
+			b.session.cancel(b.id)
+		}
+	})
+	return b.streamBuffer.Close()
+}
+
+var sessions sync.Map // agent 名称 -> *Session
+
+// Register 登记 agent 隧道。同名 agent 已有在线隧道时拒绝，不替换已有隧道，
+// 防止新连接接管该集群的流量；原隧道失效后会在 readTimeout 内注销，agent 可随后重连。
+// 返回的 Session 需调用 Serve 开始处理帧。
+func Register(name string, conn *websocket.Conn) (*Session, error) {
+	s := newSession(name, conn)
+	if _, loaded := sessions.LoadOrStore(name, s); loaded {
+		return nil, ErrSessionExists
+	}
+	klog.V(2).Infof("agent[%s] 隧道已建立，来源 %s", name, conn.RemoteAddr())
+	return s, nil
+}
+
+// Close 断开 agent 的在线隧道，用于令牌重置或停用后立即生效
+func Close(name string) {
+	if s, ok := Get(name); ok {
+		s.close()
+	}
+}
+
+// Serve 阻塞处理 agent 回传的帧，连接断开后注销隧道并返回
+func (s *Session) Serve() error {
+	defer sessions.CompareAndDelete(s.name, s)
+	return s.serve()
+}
+
+// Current 判断该隧道是否仍是同名 agent 当前在用的隧道
+func (s *Session) Current() bool {
+	cur, ok := Get(s.name)
+	return ok && cur == s
+}
+
+// Get 获取在线的 agent 隧道
+func Get(name string) (*Session, bool) {
+	if v, ok := sessions.Load(name); ok {
+		return v.(*Session), true
+	}
+	return nil, false
+}
+
+// Transport 返回按名称查找隧道的 RoundTripper。
+// 每次请求时查找当前在线的隧道，agent 断线重连后无需重新构建客户端。
+func Transport(name string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		s, ok := Get(name)
+		if !ok {
+			return nil, fmt.Errorf("agent[%s] 未连接", name)
+		}
+		return s.RoundTrip(req)
+	})
+}
+
+// RestConfig 构建经隧道访问集群的 rest.Config，认证由 agent 端完成
+func RestConfig(name string) *rest.Config {
+	return &rest.Config{
+		Host:      AgentHost,
+		Transport: Transport(name),
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,26 @@
+package tunnel
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
+
+// NamePattern agent 名称作为集群ID的一部分，限制为 DNS 标签风格，避免出现 / 等特殊字符
+var NamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,61}[a-z0-9])?$`)
+
+// NewToken 生成 agent 接入令牌
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "k8ma_" + hex.EncodeToString(b), nil
+}
+
+// HashToken 令牌摘要，服务端只保存摘要。令牌为随机生成的高熵值，无需加盐
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
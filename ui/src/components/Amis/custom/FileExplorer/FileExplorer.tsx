@@ -19,7 +19,7 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
         const namespace = data?.metadata?.namespace;
 
         const [treeData, setTreeData] = useState<FileNode[]>([]);
-        const [selected, setSelected] = useState<FileNode>();
+        const [selectedNodes, setSelectedNodes] = useState<FileNode[]>([]);
         const [selectedContainer, setSelectedContainer] = useState('');
 
         const [contextMenu, setContextMenu] = useState<{
@@ -48,7 +48,10 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
                 y: event.clientY,
                 node: node,
             });
-            setSelected(node);
+            // 在多选范围内右键时保留多选，否则只选中当前节点
+            if (!selectedNodes.some(n => n.key === node.key)) {
+                setSelectedNodes([node]);
+            }
         };
 
         const updateTreeData = (list: FileNode[], key: string, children: FileNode[]): FileNode[] => {
@@ -137,6 +140,9 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
                 case 'downloadZip':
                     await fileOperations.downloadFile(contextMenu.node, 'tar');
                     break;
+                case 'downloadBatch':
+                    await fileOperations.downloadBatch(selectedNodes.length > 0 ? selectedNodes : [contextMenu.node]);
+                    break;
                 case 'upload':
                     await fileOperations.handleUpload(contextMenu.node, async () => {
                         await fileOperations.handleRefresh(contextMenu.node!, (children) => {
@@ -175,6 +181,7 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
             const initializeTree = async () => {
                 const rootData = await fileOperations.fetchData("/", true);
                 setTreeData(rootData);
+                setSelectedNodes([]);
             };
             if (selectedContainer) {
                 initializeTree();
@@ -199,7 +206,7 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
             selectedNodes: FileNode[];
             nativeEvent: MouseEvent;
         }) => {
-            setSelected(info.node);
+            setSelectedNodes(info.selectedNodes);
         };
 
         return (
@@ -213,12 +220,12 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
                                 onContainerChange={setSelectedContainer}
                             />
                             <span style={{ marginLeft: '8px', fontSize: '12px', color: '#888' }}>
-                                鼠标右键管理文件
+                                鼠标右键管理文件，Ctrl/Shift 多选后可批量下载
                             </span>
                             <div style={{ height: 'calc(100vh - 150px)', overflowY: 'auto' }}>
                                 <FileTree
                                     treeData={treeData}
-                                    selected={selected}
+                                    selectedKeys={selectedNodes.map(n => n.key)}
                                     onSelect={onSelect}
                                     onExpand={onExpand}
                                     onRightClick={handleRightClick}
@@ -228,6 +235,7 @@ const FileExplorerComponent = React.forwardRef<HTMLDivElement, FileExplorerProps
                                     x={contextMenu.x}
                                     y={contextMenu.y}
                                     node={contextMenu.node}
+                                    selectedCount={selectedNodes.length}
                                     onMenuClick={handleMenuClick}
                                 />
                             </div>
@@ -18,6 +18,7 @@ interface ContextMenuProps {
     x: number;
     y: number;
     node: FileNode | null;
+    selectedCount: number;
     onMenuClick: MenuProps['onClick'];
 }
 
@@ -26,6 +27,7 @@ const ContextMenu: React.FC<ContextMenuProps> = ({
     x,
     y,
     node,
+    selectedCount,
     onMenuClick
 }) => {
     if (!visible || !node) return null;
@@ -64,6 +66,11 @@ const ContextMenu: React.FC<ContextMenuProps> = ({
             disabled: !(node?.type === 'file'),
             icon: <FileZipOutlined style={{ color: '#faad14' }} />
         },
+        {
+            label: selectedCount > 1 ? `批量下载（${selectedCount}）` : '打包下载',
+            key: 'downloadBatch',
+            icon: <FileZipOutlined style={{ color: '#eb2f96' }} />
+        },
         {
             label: '上传',
             key: 'upload',
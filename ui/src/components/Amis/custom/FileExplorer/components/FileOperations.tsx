@@ -130,6 +130,49 @@ export class FileOperations {
         }
     }
 
+    // 将多个文件与目录在服务端打包为一个 tar.gz 下载，部分路径失败时详情见压缩包中的 k8m-download-report.json
+    async downloadBatch(nodes: FileNode[]) {
+        if (nodes.length === 0 || !this.props.selectedContainer || !this.props.podName || !this.props.namespace) {
+            message.error('缺少必要的参数，请检查输入');
+            return;
+        }
+
+        const hide = message.loading('正在打包下载...', 0);
+        try {
+            const url = ProcessK8sUrlWithCluster('/k8s/file/batch-download');
+            const response = await fetch(url, {
+                method: 'POST',
+                headers: {
+                    'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                    'Content-Type': 'application/json'
+                },
+                body: JSON.stringify({
+                    containerName: this.props.selectedContainer,
+                    podName: this.props.podName,
+                    namespace: this.props.namespace,
+                    paths: nodes.map(node => node.path)
+                })
+            });
+            if (response.headers.get('Content-Type')?.includes('application/json')) {
+                const result = await response.json();
+                message.error(result.msg || '下载失败');
+                return;
+            }
+            const blob = await response.blob();
+            const disposition = response.headers.get('Content-Disposition') || '';
+            const a = document.createElement('a');
+            a.href = URL.createObjectURL(blob);
+            a.download = disposition.split('filename=')[1] || `${this.props.podName}.tar.gz`;
+            a.click();
+            URL.revokeObjectURL(a.href);
+            message.success('下载完成，如有文件失败，详情见压缩包中的 k8m-download-report.json');
+        } catch (e) {
+            message.error('下载失败，请重试');
+        } finally {
+            hide();
+        }
+    }
+
     async handleUpload(node: FileNode, onUploadSuccess: () => void) {
         if (!node.isDir) {
             message.error('只能在目录下上传文件');
@@ -25,7 +25,7 @@ interface FileNode {
 
 interface FileTreeProps {
     treeData: FileNode[];
-    selected: FileNode | undefined;
+    selectedKeys: React.Key[];
     onSelect: (selectedKeys: React.Key[], info: {
         event: "select";
         selected: boolean;
@@ -43,7 +43,7 @@ interface FileTreeProps {
 
 const FileTree: React.FC<FileTreeProps> = ({
     treeData,
-    selected,
+    selectedKeys,
     onSelect,
     onExpand,
     onRightClick
@@ -67,12 +67,13 @@ const FileTree: React.FC<FileTreeProps> = ({
             className='mt-4'
             treeData={treeData}
             showLine={true}
+            multiple={true}
             checkStrictly={true}
             onSelect={onSelect}
             onExpand={onExpand}
             switcherIcon={<DownOutlined />}
             onRightClick={onRightClick}
-            selectedKeys={selected ? [selected.key] : []}
+            selectedKeys={selectedKeys}
         />
     );
 };
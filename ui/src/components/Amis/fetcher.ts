@@ -7,12 +7,16 @@ import {ProcessK8sUrlWithCluster} from "@/utils/utils.ts";
 
 export const fetcher = ({url, method = 'get', data, config}: FetcherConfig): Promise<fetcherResult> => {
     const token = localStorage.getItem('token') || '';
+    // 当前界面语言，后端据此翻译错误信息
+    //@ts-ignore
+    const currentLang: string = window.translate?.language?.getCurrent?.() || '';
 
     const ajax = axios.create({
         baseURL: '/',
         headers: {
             ...config?.headers,
-            Authorization: token ? `Bearer ${token}` : ''
+            Authorization: token ? `Bearer ${token}` : '',
+            'X-K8M-Lang': currentLang.startsWith('chinese') ? 'zh' : currentLang === 'english' ? 'en' : ''
         }
     });
 
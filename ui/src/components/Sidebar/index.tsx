@@ -19,7 +19,7 @@ const Sidebar = () => {
 
     // 使用自定义hooks
     const { userRole, menuData, groups } = useUserRole();
-    const { isGatewayAPISupported, isOpenKruiseSupported, isIstioSupported } = useCRDStatus();
+    const { enabledCapabilities, isGatewayAPISupported, isOpenKruiseSupported, isIstioSupported } = useCRDStatus();
     const [pluginMenus, setPluginMenus] = useState<MenuItem[]>([]);
 
     // 创建菜单可见性上下文
@@ -29,7 +29,8 @@ const Sidebar = () => {
         menuData,
         isGatewayAPISupported,
         isOpenKruiseSupported,
-        isIstioSupported
+        isIstioSupported,
+        enabledCapabilities
     };
 
     // 拉取插件菜单并缓存
@@ -187,7 +188,8 @@ const Sidebar = () => {
         menuData,
         isGatewayAPISupported,
         isOpenKruiseSupported,
-        isIstioSupported
+        isIstioSupported,
+        enabledCapabilities
     ]);
 
     return (
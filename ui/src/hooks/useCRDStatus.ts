@@ -1,42 +1,39 @@
 import { useState, useEffect } from 'react';
 import { fetcher } from '@/components/Amis/fetcher';
 
-interface CRDSupportedStatus {
-    IsGatewayAPISupported: boolean;
-    IsOpenKruiseSupported: boolean;
-    IsIstioSupported: boolean;
+interface Capability {
+    name: string;
+    enabled: boolean;
 }
 
+// 读取当前集群的能力，已合并管理员设置的功能开关
 export const useCRDStatus = () => {
-    const [isGatewayAPISupported, setIsGatewayAPISupported] = useState<boolean>(false);
-    const [isOpenKruiseSupported, setIsOpenKruiseSupported] = useState<boolean>(false);
-    const [isIstioSupported, setIsIstioSupported] = useState<boolean>(false);
+    const [enabledCapabilities, setEnabledCapabilities] = useState<string[]>([]);
 
     useEffect(() => {
-        const fetchCRDSupportedStatus = async () => {
+        const fetchCapabilities = async () => {
             try {
                 const response = await fetcher({
-                    url: '/k8s/crd/status',
+                    url: '/k8s/capabilities',
                     method: 'get'
                 });
-                
+
                 if (response.data && typeof response.data === 'object') {
-                    const status = response.data.data as CRDSupportedStatus;
-                    setIsGatewayAPISupported(status.IsGatewayAPISupported);
-                    setIsOpenKruiseSupported(status.IsOpenKruiseSupported);
-                    setIsIstioSupported(status.IsIstioSupported);
+                    const capabilities = (response.data.data?.capabilities || []) as Capability[];
+                    setEnabledCapabilities(capabilities.filter(c => c.enabled).map(c => c.name));
                 }
             } catch (error) {
-                console.error('Failed to fetch CRD status:', error);
+                console.error('Failed to fetch cluster capabilities:', error);
             }
         };
 
-        fetchCRDSupportedStatus();
+        fetchCapabilities();
     }, []);
 
     return {
-        isGatewayAPISupported,
-        isOpenKruiseSupported,
-        isIstioSupported
+        enabledCapabilities,
+        isGatewayAPISupported: enabledCapabilities.includes('gateway_api'),
+        isOpenKruiseSupported: enabledCapabilities.includes('openkruise'),
+        isIstioSupported: enabledCapabilities.includes('istio')
     };
-};
\ No newline at end of file
+};
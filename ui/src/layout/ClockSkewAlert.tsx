@@ -0,0 +1,37 @@
+import { useEffect, useState } from "react";
+import { Alert } from "antd";
+import { fetcher } from "@/components/Amis/fetcher";
+
+// 服务器时钟检查每 10 分钟执行一次，页面按相同频率刷新
+const refreshInterval = 10 * 60 * 1000;
+
+const ClockSkewAlert = () => {
+    const [message, setMessage] = useState("");
+
+    useEffect(() => {
+        const load = () => {
+            fetcher({
+                url: '/params/clock/status',
+                method: 'get'
+            })
+                .then(response => {
+                    //@ts-ignore
+                    const status = response.data?.data;
+                    setMessage(status?.skewed ? status.message : "");
+                })
+                .catch(error => {
+                    console.error('Error fetching clock status:', error);
+                });
+        };
+        load();
+        const timer = setInterval(load, refreshInterval);
+        return () => clearInterval(timer);
+    }, []);
+
+    if (!message) {
+        return null;
+    }
+    return <Alert type="warning" showIcon banner closable message={message} />;
+};
+
+export default ClockSkewAlert;
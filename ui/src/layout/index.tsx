@@ -7,6 +7,7 @@ import useStore from '@/store/layout'
 import { useCallback, useEffect, useState } from 'react'
 import styles from './index.module.scss'
 import FloatingChatGPTButton from './FloatingChatGPTButton'
+import ClockSkewAlert from './ClockSkewAlert'
 import { fetcher } from '@/components/Amis/fetcher'
 import I18nTranslateProvider from '@/components/I18n/I18nTranslateProvider';
 
@@ -79,6 +80,7 @@ const App = () => {
                 <Sidebar />
             </Layout.Sider>
             <Layout.Content className={styles.content}>
+                <ClockSkewAlert />
                 <FloatingChatGPTButton></FloatingChatGPTButton>
                 <Outlet />
 
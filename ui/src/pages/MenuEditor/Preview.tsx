@@ -28,7 +28,7 @@ const Preview: React.FC<PreviewProps> = ({ menuData, navigate: propNavigate }) =
 
     // 使用自定义hooks
     const { userRole, menuData: _menuData } = useUserRole();
-    const { isGatewayAPISupported, isOpenKruiseSupported, isIstioSupported } = useCRDStatus();
+    const { enabledCapabilities, isGatewayAPISupported, isOpenKruiseSupported, isIstioSupported } = useCRDStatus();
 
     // 创建菜单可见性上下文
     const visibilityContext = {
@@ -36,7 +36,8 @@ const Preview: React.FC<PreviewProps> = ({ menuData, navigate: propNavigate }) =
         _menuData,
         isGatewayAPISupported,
         isOpenKruiseSupported,
-        isIstioSupported
+        isIstioSupported,
+        enabledCapabilities
     };
 
 
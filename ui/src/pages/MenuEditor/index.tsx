@@ -625,6 +625,7 @@ const MenuEditor: React.FC = () => {
                                     <li><code>isGatewayAPISupported()==true</code>：检查集群是否支持Gateway API</li>
                                     <li><code>isIstioSupported()==true</code>：检查集群是否支持Istio</li>
                                     <li><code>isOpenKruiseSupported()==true</code>：检查集群是否支持OpenKruise</li>
+                                    <li><code>isCapabilityEnabled('metrics')==true</code>：检查集群是否可用某项能力，可选 metrics、gateway_api、volume_snapshot、openkruise、istio、exec</li>
                                     <li><code>isPlatformAdmin()==true</code>：检查用户是否为平台管理员</li>
                                 </ul>
                             </li>
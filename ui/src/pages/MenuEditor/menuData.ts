@@ -477,6 +477,14 @@ export const initialMenu: MenuItem[] = [
                 customEvent: '() => loadJsonPage("/admin/config/ldap_config")',
                 order: 11,
             },
+            {
+                key: 'artifact_storage',
+                title: '制品存储',
+                icon: 'fa-solid fa-box-archive',
+                eventType: 'custom',
+                customEvent: '() => loadJsonPage("/admin/config/artifact")',
+                order: 11.5,
+            },
             {
                 key: 'operation_audit',
                 title: '操作审计',
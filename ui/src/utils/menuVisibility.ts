@@ -7,6 +7,7 @@ interface MenuVisibilityContext {
     isGatewayAPISupported: boolean;
     isOpenKruiseSupported: boolean;
     isIstioSupported: boolean;
+    enabledCapabilities?: string[];
 }
 
 export const shouldShowMenuItem = (item: MenuItem, context: MenuVisibilityContext): boolean => {
@@ -46,6 +47,10 @@ export const shouldShowMenuItem = (item: MenuItem, context: MenuVisibilityContex
                 return context.isOpenKruiseSupported;
             };
 
+            parser.functions.isCapabilityEnabled = function (name: string) {
+                return (context.enabledCapabilities || []).includes(name);
+            };
+
             parser.functions.isPlatformAdmin = function () {
                 return context.userRole.includes('platform_admin');
             };